@@ -0,0 +1,89 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccountConfigSpec defines the binding of namespaces and teams to an AWS
+// account, supplementing the ack-role-account-map and ack-role-team-map
+// ConfigMaps of cross account resource management (CARM).
+type AccountConfigSpec struct {
+	// AccountID is the AWS account the resources of the bound namespaces and
+	// teams are managed in
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9]{12}$`
+	AccountID AWSAccountID `json:"accountID"`
+	// RoleARN is the ARN of the IAM role assumed to manage the resources in
+	// the AWS account
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	RoleARN AWSResourceName `json:"roleARN"`
+	// Namespaces are the namespaces whose resources are managed in the AWS
+	// account, as if they were annotated with the
+	// `services.k8s.aws/owner-account-id` annotation. The annotations of the
+	// namespaces take precedence.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// TeamIDs are the team IDs of the `services.k8s.aws/team-id` annotation
+	// of the namespaces whose resources are managed with the IAM role
+	// +optional
+	TeamIDs []string `json:"teamIDs,omitempty"`
+	// Region is the default AWS region of the resources of the bound
+	// namespaces, as if they were annotated with the
+	// `services.k8s.aws/default-region` annotation
+	// +optional
+	Region *AWSRegion `json:"region,omitempty"`
+	// EndpointURL overrides the endpoint of the AWS service APIs for the
+	// resources of the bound namespaces, as if they were annotated with the
+	// `services.k8s.aws/endpoint-url` annotation
+	// +optional
+	EndpointURL *string `json:"endpointURL,omitempty"`
+}
+
+// AccountConfigStatus defines the observed status of the AccountConfig.
+type AccountConfigStatus struct {
+	// ObservedGeneration is the generation of the spec last validated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// A collection of `ackv1alpha1.Condition` objects. The ACK.ResourceSynced
+	// condition is False when the IAM role cannot be assumed.
+	Conditions []*Condition `json:"conditions"`
+}
+
+// AccountConfig is the schema for the AccountConfig API. It binds namespaces
+// and teams to an AWS account and the IAM role managing their resources in
+// it, and is reloaded by the ACK service controllers as soon as it changes.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type AccountConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              AccountConfigSpec   `json:"spec,omitempty"`
+	Status            AccountConfigStatus `json:"status,omitempty"`
+}
+
+// AccountConfigList defines a list of AccountConfigs.
+// +kubebuilder:object:root=true
+type AccountConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccountConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AccountConfig{}, &AccountConfigList{})
+}
@@ -44,6 +44,13 @@ const (
 	// to have the ability to call the AWS STS::AssumeRole API call and assume an
 	// IAM Role in the target AWS Account.
 	AnnotationTeamID = AnnotationPrefix + "team-id"
+	// AnnotationOwnerRoleARN is an annotation whose value is the ARN of the
+	// AWS IAM Role the ACK service controller assumes to manage the resource,
+	// in the account of the role. If this annotation is set on a CR, it takes
+	// precedence over the owner account ID and team ID annotations of its
+	// namespace. The role must be allowed to the namespace of the CR by the
+	// --allowed-owner-role-arns flag of the controller.
+	AnnotationOwnerRoleARN = AnnotationPrefix + "owner-role-arn"
 	// AnnotationRegion is an annotation whose value is the identifier for the
 	// the AWS region in which the resources should be created. If this annotation
 	// is set on a CR metadata, that means the user is indicating to the ACK service
@@ -75,6 +82,45 @@ const (
 	// resource manager will leave the AWS resource intact when the K8s resource
 	// is deleted.
 	AnnotationDeletionPolicy = AnnotationPrefix + "deletion-policy"
+	// AnnotationFinalizerPolicy is an annotation whose value is the
+	// finalizer policy of the current resource. If this annotation is set to
+	// "before-create" the ACK service controller adds its finalizer to the
+	// resource before creating the AWS resource. If this annotation is set to
+	// "after-create" the finalizer is only added once the AWS resource is
+	// created. If this annotation is not set, the finalizer policy of the
+	// resource kind, or of the controller configuration, applies.
+	AnnotationFinalizerPolicy = AnnotationPrefix + "finalizer-policy"
+	// AnnotationReconcileInterval is an annotation whose value is the
+	// interval, as a Go duration (e.g. "1m" or "1h"), at which the ACK
+	// service controller reconciles the current resource once it is synced,
+	// checking it for drift. If this annotation is set on a namespace, it is
+	// the default interval of the resources of the namespace. If it is not
+	// set, the resync period of the controller configuration applies.
+	AnnotationReconcileInterval = AnnotationPrefix + "reconcile-interval"
+	// AnnotationAWSRetryMode is an annotation whose value is the retry mode
+	// ("standard" or "adaptive") of the AWS SDK clients of the resources of
+	// the current namespace. If it is not set, the retry mode of the
+	// controller configuration applies.
+	//
+	// NOTE: This annotation is only applicable to namespaces.
+	AnnotationAWSRetryMode = AnnotationPrefix + "aws-retry-mode"
+	// AnnotationAWSRetryMaxAttempts is an annotation whose value is the
+	// maximum number of attempts of the calls of the AWS SDK clients of the
+	// resources of the current namespace. If it is not set, the maximum
+	// number of attempts of the controller configuration applies.
+	//
+	// NOTE: This annotation is only applicable to namespaces.
+	AnnotationAWSRetryMaxAttempts = AnnotationPrefix + "aws-retry-max-attempts"
+	// AnnotationMutators is an annotation whose value is the JSON list of the
+	// mutators applied, in order, to the desired state of the resources of
+	// the current namespace before it is compared with, or created as, the
+	// AWS resource. Each mutator is an object with the name of a registered
+	// mutator, e.g. "enforce-tags", its parameters, and optionally the kinds
+	// it applies to, e.g.
+	// `[{"name":"name-prefix","params":{"prefix":"payments-"},"kinds":["Bucket"]}]`
+	//
+	// NOTE: This annotation is only applicable to namespaces.
+	AnnotationMutators = AnnotationPrefix + "mutators"
 	// AnnotationReadOnly is an annotation whose value is a boolean indicating
 	// whether the resource is read-only. If this annotation is set to true on a
 	// CR, that means the user is indicating to the ACK service controller that
@@ -91,4 +137,120 @@ const (
 	// format of the requied fields to do a ReadOne when attempting to force-adopt
 	// a Resource
 	AnnotationAdoptionFields = AnnotationPrefix + "adoption-fields"
+	// AnnotationUnknowableFields is an annotation whose value is a
+	// comma-separated list of the paths (e.g. "spec.masterUserPassword") of
+	// the create-only fields of an adopted resource whose value could neither
+	// be read back from the AWS service API nor found in the spec or the
+	// adoption fields of the resource. These fields are excluded from drift
+	// detection.
+	AnnotationUnknowableFields = AnnotationPrefix + "unknowable-fields"
+	// AnnotationUpdateGroups is an annotation whose value is a json-like
+	// format of the result of the last update of each update group of a
+	// resource whose resource manager updates groups of fields separately.
+	// e.g. {"tags":{"succeeded":true,"time":"2026-01-01T10:00:00Z"}}
+	AnnotationUpdateGroups = AnnotationPrefix + "update-groups"
+	// AnnotationTakeOver is an annotation whose value is a boolean indicating
+	// whether the ACK service controller is allowed to take over the
+	// ownership of an AWS resource currently managed by the controller of
+	// another cluster. If this annotation is set to true on a CR, the
+	// controller replaces the cluster identity tag of the AWS resource with
+	// its own, and the controller of the previous cluster stops managing it.
+	AnnotationTakeOver = AnnotationPrefix + "take-over"
+	// AnnotationDeferredOperations is an annotation, managed by the ACK
+	// service controller, whose value is a JSON object mapping the names of
+	// the operations deferred for the resource (e.g. the end of a maintenance
+	// window) to the RFC3339 time at which they are due. Persisting deferred
+	// operations on the resource lets a newly elected leader schedule them
+	// again.
+	AnnotationDeferredOperations = AnnotationPrefix + "deferred-operations"
+	// AnnotationErrorFingerprint is an annotation, managed by the ACK service
+	// controller, whose value is a JSON object describing the error the
+	// reconciliation of the resource currently fails with: its fingerprint,
+	// and when it was first and last seen. It is removed once the error is
+	// resolved.
+	AnnotationErrorFingerprint = AnnotationPrefix + "error-fingerprint"
+	// AnnotationSpecHash is an annotation, managed by the ACK service
+	// controller, whose value is the hash of the spec of the resource last
+	// synced, once defaulted and with its references resolved, e.g.
+	// "sha256:9f86d0...". Tools can compare the hashes of a resource to tell
+	// whether anything effective changed, without comparing whole specs.
+	AnnotationSpecHash = AnnotationPrefix + "spec-hash"
+	// AnnotationMigrateTo is an annotation whose value, of the form
+	// "<account ID>/<region>" (e.g. "123456789012/eu-west-1"), authorizes the
+	// ACK service controller to reconcile a created resource in a region or
+	// owner account other than the one it was created in. The AWS resource
+	// is then created again in the new region or account, and the one it
+	// was created in is left untouched. Naming the destination keeps the
+	// authorization from applying to later moves.
+	AnnotationMigrateTo = AnnotationPrefix + "migrate-to"
+	// AnnotationCreateIntent is an annotation, managed by the ACK service
+	// controller, whose value is the RFC3339 time at which the controller
+	// first attempted to create the AWS resource of the resource. It is set
+	// before the AWS resource is created and removed once the creation is
+	// recorded on the resource. A resource found with this annotation had its
+	// creation interrupted, e.g. by a restart of the controller, and the
+	// controller completes it: it adds its finalizer if it is missing and
+	// removes the annotation once the AWS resource is found.
+	AnnotationCreateIntent = AnnotationPrefix + "create-intent"
+	// AnnotationTraceParent is an annotation whose value is the W3C trace
+	// context of the request that created or last changed the resource, in
+	// the format of the traceparent HTTP header, e.g.
+	// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01". When tracing
+	// is enabled, the spans of the reconciliations of the resource link to
+	// the span of the request.
+	AnnotationTraceParent = AnnotationPrefix + "traceparent"
+	// AnnotationPlan is an annotation whose value is a boolean indicating
+	// whether the ACK service controller only plans the reconciliations of
+	// the resource. If this annotation is set to true on a resource, the
+	// controller resolves its references and compares its desired state
+	// with the latest observed state of its AWS resource, but does not
+	// create, update or delete the AWS resource. The plan, i.e. what the
+	// controller would do, is written to the "ack-plan-<kind>-<name>"
+	// ConfigMap of the namespace of the resource, and summarized in its
+	// status.ackResourceMetadata.lastPlan field. Removing the annotation
+	// lets the controller act, unless it was started with --dry-run, which
+	// plans the reconciliations of all the resources.
+	AnnotationPlan = AnnotationPrefix + "plan"
+	// AnnotationProgressDeadline is an annotation whose value is the
+	// duration, e.g. "3h", within which the resource is expected to sync
+	// after being created or changed, overriding the progress deadline of
+	// its kind for resources whose provisioning legitimately takes longer.
+	// A duration of "0s" disables the progress deadline of the resource.
+	AnnotationProgressDeadline = AnnotationPrefix + "progress-deadline"
+	// AnnotationCreateProgressDeadline is an annotation whose value is the
+	// duration, e.g. "6h", within which the resource is expected to sync
+	// after being created, overriding the progress-deadline annotation and
+	// the progress deadline of its kind while the resource is created. A
+	// duration of "0s" disables the progress deadline of the creation.
+	AnnotationCreateProgressDeadline = AnnotationPrefix + "create-progress-deadline"
+	// AnnotationUpdateProgressDeadline is an annotation whose value is the
+	// duration, e.g. "1h", within which the resource is expected to sync
+	// after being changed, overriding the progress-deadline annotation and
+	// the progress deadline of its kind while the resource is updated. A
+	// duration of "0s" disables the progress deadline of the updates.
+	AnnotationUpdateProgressDeadline = AnnotationPrefix + "update-progress-deadline"
+	// AnnotationDeleteProgressDeadline is an annotation whose value is the
+	// duration, e.g. "2h", within which the AWS resource of the resource is
+	// expected to be deleted after the resource is deleted, overriding the
+	// progress-deadline annotation and the progress deadline of its kind
+	// while the resource is deleted. A duration of "0s" disables the
+	// progress deadline of the deletion.
+	AnnotationDeleteProgressDeadline = AnnotationPrefix + "delete-progress-deadline"
+	// AnnotationPaused is an annotation whose value is a boolean indicating
+	// whether the reconciliations of the resource are paused. If this
+	// annotation is set to true on a resource, the ACK service controller
+	// neither reads nor changes its AWS resource, and sets its ACK.Paused
+	// condition. Set on a namespace, it pauses the resources of the
+	// namespace whose own annotation is not set. Removing the annotation, or
+	// setting it to false, resumes the reconciliations immediately.
+	AnnotationPaused = AnnotationPrefix + "paused"
+	// AnnotationMaintenanceWindow is an annotation of a namespace whose value
+	// is the maintenance window of the resources of the namespace, e.g.
+	// "0 2 * * SAT 4h" for four hours every Saturday from 2am, overriding the
+	// --maintenance-window flag of the controller. Outside of the window,
+	// the ACK service controller reads the AWS resources but defers their
+	// creation, update and deletion until the window opens, and sets the
+	// ACK.Deferred condition of the resources. See the
+	// --maintenance-window flag for the format of the window.
+	AnnotationMaintenanceWindow = AnnotationPrefix + "maintenance-window"
 )
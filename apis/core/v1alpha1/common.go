@@ -13,9 +13,44 @@
 
 package v1alpha1
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 // AWSRegion represents an AWS regional identifier
 type AWSRegion string
 
+// AWSRegionSource describes where the AWS region of a resource was resolved
+// from
+type AWSRegionSource string
+
+const (
+	// AWSRegionSourceResourceAnnotation is the source of regions set with
+	// the `services.k8s.aws/region` annotation of the resource
+	AWSRegionSourceResourceAnnotation AWSRegionSource = "ResourceAnnotation"
+	// AWSRegionSourceNamespaceAnnotation is the source of regions set with
+	// the `services.k8s.aws/default-region` annotation of the namespace of
+	// the resource
+	AWSRegionSourceNamespaceAnnotation AWSRegionSource = "NamespaceAnnotation"
+	// AWSRegionSourceAccountConfig is the source of regions set with the
+	// default region of the AccountConfig binding the namespace of the
+	// resource
+	AWSRegionSourceAccountConfig AWSRegionSource = "AccountConfig"
+	// AWSRegionSourceFlag is the source of regions set with the
+	// `--aws-region` flag of the controller
+	AWSRegionSourceFlag AWSRegionSource = "ControllerFlag"
+	// AWSRegionSourceEnvironment is the source of regions set with the
+	// `AWS_REGION` or `AWS_DEFAULT_REGION` environment variables of the
+	// controller
+	AWSRegionSourceEnvironment AWSRegionSource = "Environment"
+	// AWSRegionSourceInstanceMetadata is the source of regions discovered
+	// from the instance metadata service (IMDS) of the node the controller
+	// runs on
+	AWSRegionSourceInstanceMetadata AWSRegionSource = "InstanceMetadata"
+)
+
 // AWSAccountID represents an AWS account identifier
 type AWSAccountID string
 
@@ -25,6 +60,47 @@ type TeamID string
 // AWSResourceName represents an AWS Resource Name (ARN)
 type AWSResourceName string
 
+// ParsedAWSResourceName holds the components of an AWS Resource Name
+type ParsedAWSResourceName struct {
+	Partition string
+	Service   string
+	Region    string
+	AccountID string
+	Resource  string
+}
+
+// Parse returns the components of the AWS Resource Name, or an error if it
+// does not have the format arn:partition:service:region:account-id:resource
+func (n AWSResourceName) Parse() (ParsedAWSResourceName, error) {
+	sections := strings.SplitN(string(n), ":", 6)
+	if len(sections) != 6 || sections[0] != "arn" {
+		return ParsedAWSResourceName{}, fmt.Errorf(
+			"invalid ARN %q: expected arn:partition:service:region:account-id:resource", string(n),
+		)
+	}
+	parsed := ParsedAWSResourceName{
+		Partition: sections[1],
+		Service:   sections[2],
+		Region:    sections[3],
+		AccountID: sections[4],
+		Resource:  sections[5],
+	}
+	if parsed.Partition == "" || parsed.Service == "" || parsed.Resource == "" {
+		return ParsedAWSResourceName{}, fmt.Errorf(
+			"invalid ARN %q: partition, service and resource must not be empty", string(n),
+		)
+	}
+	if parsed.AccountID != "" && !accountIDRegex.MatchString(parsed.AccountID) {
+		return ParsedAWSResourceName{}, fmt.Errorf(
+			"invalid ARN %q: account ID must be 12 digits", string(n),
+		)
+	}
+	return parsed, nil
+}
+
+// accountIDRegex matches AWS account identifiers
+var accountIDRegex = regexp.MustCompile(`^[0-9]{12}$`)
+
 // FieldExportOutputType represents all types that can be produced by a field
 // export operation
 // +kubebuilder:validation:Enum=configmap;secret
@@ -62,6 +62,99 @@ const (
 	// "False" status indicates that the resource references failed to resolve.
 	// For Ex: When referenced resource is in terminal condition
 	ConditionTypeReferencesResolved ConditionType = "ACK.ReferencesResolved"
+	// ConditionTypeUpdateRolledBack indicates that an update of the resource,
+	// spanning multiple AWS API calls, failed and that the runtime executed
+	// the compensating actions of the successful calls.
+	//
+	// Absence of this condition means that the last update did not fail after
+	// partially updating the AWS resource.
+	// "True" status indicates that all the successful calls were undone.
+	// "False" status indicates that some successful calls could not be
+	// undone: the AWS resource is partially updated, as described in the
+	// message of the condition.
+	ConditionTypeUpdateRolledBack ConditionType = "ACK.UpdateRolledBack"
+	// ConditionTypeRegionAccountChanged indicates that the region or the
+	// owner account resolved for the resource, e.g. after an edit of its
+	// region annotation or of the account binding of its namespace, differs
+	// from the one the AWS resource was created in. The resource is not
+	// reconciled, so that it is not silently created again elsewhere, until
+	// the change is reverted or the move is authorized with the
+	// `services.k8s.aws/migrate-to` annotation.
+	//
+	// Absence of this condition means that the resource is reconciled in the
+	// region and owner account it was created in.
+	ConditionTypeRegionAccountChanged ConditionType = "ACK.RegionAccountChanged"
+	// ConditionTypeDegraded indicates whether the resource failed to sync
+	// within the progress deadline of its kind, while the controller keeps
+	// retrying.
+	//
+	// Absence of this condition means that the resource is synced, or that
+	// its kind has no progress deadline.
+	// "False" status indicates that the resource is progressing towards its
+	// desired state, since the last transition time of the condition.
+	// "True" status indicates that the resource did not sync within the
+	// progress deadline.
+	ConditionTypeDegraded ConditionType = "ACK.Degraded"
+	// ConditionTypePaused indicates that the reconciliations of the resource
+	// are paused by the services.k8s.aws/paused annotation of the resource or
+	// of its namespace, the controller neither reading nor changing the AWS
+	// resource.
+	//
+	// Absence of this condition means that the resource is not paused.
+	// "True" status indicates that the reconciliations are paused.
+	ConditionTypePaused ConditionType = "ACK.Paused"
+	// ConditionTypeDeferred indicates that the creation, update or deletion
+	// of the AWS resource is deferred until the next maintenance window of
+	// the resource, whose opening time is given in the message of the
+	// condition.
+	//
+	// Absence of this condition means that no change is deferred.
+	// "True" status indicates that a change of the AWS resource is deferred.
+	ConditionTypeDeferred ConditionType = "ACK.Deferred"
+	// ConditionTypePolicyViolation indicates that the creation or update of
+	// the AWS resource would violate an invariant spanning several resources,
+	// defined by the operators of the controller, and is not made.
+	//
+	// Absence of this condition means that the resource violates no
+	// invariant.
+	// "True" status indicates that the resource violates the invariant given
+	// in the message of the condition.
+	ConditionTypePolicyViolation ConditionType = "ACK.PolicyViolation"
+	// ConditionTypeBlocked indicates that the creation, update or deletion
+	// of the AWS resource is denied by a policy defined by the operators of
+	// the controller, and is not made until the policy allows it.
+	//
+	// Absence of this condition means that no policy blocks the resource.
+	// "True" status indicates that the change is blocked by the policy, and
+	// its failing rule, given in the message of the condition.
+	ConditionTypeBlocked ConditionType = "ACK.Blocked"
+	// ConditionTypeSyncedObjectiveMet is set on the ControllerConfig and
+	// indicates whether the percentage of synced resources of each kind meets
+	// the objective of the controller.
+	//
+	// "True" status indicates that the error budget of every kind is burning
+	// at most as fast as allowed over the SLO window.
+	// "False" status indicates that the error budget of some kinds, listed in
+	// the message of the condition with their burn rate, burns too fast.
+	ConditionTypeSyncedObjectiveMet ConditionType = "ACK.SyncedObjectiveMet"
+	// ConditionTypeTimeToSyncObjectiveMet is set on the ControllerConfig and
+	// indicates whether the 99th percentile of the time it takes the
+	// resources of each kind to sync meets the objective of the controller.
+	//
+	// "True" status indicates that at most 1% of the resources of every kind
+	// took longer than the objective to sync over the SLO window.
+	// "False" status indicates that the resources of some kinds, listed in
+	// the message of the condition with their burn rate, sync too slowly.
+	ConditionTypeTimeToSyncObjectiveMet ConditionType = "ACK.TimeToSyncObjectiveMet"
+	// ConditionTypeServiceQuotasAvailable is set on the ControllerConfig and
+	// indicates whether the AWS service quotas consumed by the kinds of the
+	// controller have capacity left.
+	//
+	// "True" status indicates that the usage of every quota is below the
+	// warning threshold of the controller.
+	// "False" status indicates that the usage of some quotas, listed in the
+	// message of the condition with their usage, reached the threshold.
+	ConditionTypeServiceQuotasAvailable ConditionType = "ACK.ServiceQuotasAvailable"
 )
 
 // Condition is the common struct used by all CRDs managed by ACK service
@@ -0,0 +1,124 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControllerConfigSpec defines the settings of an ACK service controller that
+// can be tuned while it runs.
+type ControllerConfigSpec struct {
+	// Paused pauses the reconciliation of all the resources of the controller
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// Kinds contains the settings of the reconcilers of the resource kinds.
+	// The kinds without settings keep the settings of the controller flags.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	Kinds []ControllerKindConfig `json:"kinds,omitempty"`
+}
+
+// ControllerKindConfig defines the settings of the reconciler of a resource
+// kind.
+type ControllerKindConfig struct {
+	// Kind is the resource kind, e.g. "Bucket"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+	// MaxConcurrentReconciles is the maximum number of concurrent
+	// reconciliations of the resources of the kind. Values greater than the
+	// number of workers the controller was started with are staged, and only
+	// applied when the controller restarts.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentReconciles *int `json:"maxConcurrentReconciles,omitempty"`
+	// ResyncPeriodSeconds is the interval, in seconds, at which the resources
+	// of the kind are checked for drift
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ResyncPeriodSeconds *int64 `json:"resyncPeriodSeconds,omitempty"`
+	// Paused pauses the reconciliation of the resources of the kind
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+	// CanaryPercent is the percentage of the resources of the kind getting
+	// the new behavior rolled out by an upgrade of the controller, ramping up
+	// the one of the --reconcile-resource-canary-percent flag
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CanaryPercent *int `json:"canaryPercent,omitempty"`
+}
+
+// ControllerKindStatus describes the settings applied to the reconciler of a
+// resource kind.
+type ControllerKindStatus struct {
+	// Kind is the resource kind
+	Kind string `json:"kind"`
+	// MaxConcurrentReconciles is the applied maximum number of concurrent
+	// reconciliations
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles"`
+	// ResyncPeriodSeconds is the applied drift detection interval
+	ResyncPeriodSeconds int64 `json:"resyncPeriodSeconds"`
+	// Paused is true if the reconciliation of the kind is paused
+	Paused bool `json:"paused"`
+	// CanaryPercent is the applied canary percentage, if a rollout of the
+	// kind is in progress
+	// +optional
+	CanaryPercent *int `json:"canaryPercent,omitempty"`
+	// Staged contains the settings that are only applied when the
+	// controller restarts
+	// +optional
+	Staged []string `json:"staged,omitempty"`
+}
+
+// ControllerConfigStatus defines the observed status of the ControllerConfig.
+type ControllerConfigStatus struct {
+	// ObservedGeneration is the generation of the spec last applied
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// A collection of `ackv1alpha1.Condition` objects. The ACK.ResourceSynced
+	// condition is False when the spec is invalid, in which case none of it
+	// is applied.
+	Conditions []*Condition `json:"conditions"`
+	// Kinds contains the settings applied to the reconcilers of the resource
+	// kinds
+	// +optional
+	Kinds []ControllerKindStatus `json:"kinds,omitempty"`
+}
+
+// ControllerConfig is the schema for the ControllerConfig API. It holds the
+// settings of an ACK service controller that operators can tune, with
+// kubectl, without redeploying the controller.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type ControllerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ControllerConfigSpec   `json:"spec,omitempty"`
+	Status            ControllerConfigStatus `json:"status,omitempty"`
+}
+
+// ControllerConfigList defines a list of ControllerConfigs.
+// +kubebuilder:object:root=true
+type ControllerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ControllerConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ControllerConfig{}, &ControllerConfigList{})
+}
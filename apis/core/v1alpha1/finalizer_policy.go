@@ -0,0 +1,53 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// FinalizerPolicy represents when the ACK reconciler adds its finalizer to a
+// resource being created. A FinalizerPolicy of "before-create" adds the
+// finalizer before the AWS resource is created, so that the AWS resource is
+// never orphaned, at the risk of blocking the deletion of the K8s object
+// until the controller can tell the AWS resource does not exist. A
+// FinalizerPolicy of "after-create" only adds the finalizer once the AWS
+// resource is created, so that a K8s object whose AWS resource could not be
+// created is deleted right away, at the risk of orphaning the AWS resource if
+// the K8s object is deleted while the controller is interrupted between the
+// creation and the addition of the finalizer.
+type FinalizerPolicy string
+
+const (
+	FinalizerPolicyBeforeCreate FinalizerPolicy = "before-create"
+	FinalizerPolicyAfterCreate  FinalizerPolicy = "after-create"
+)
+
+func (e *FinalizerPolicy) String() string {
+	return string(*e)
+}
+
+func (e *FinalizerPolicy) Set(v string) error {
+	switch v {
+	case string(FinalizerPolicyBeforeCreate), string(FinalizerPolicyAfterCreate):
+		*e = FinalizerPolicy(v)
+		return nil
+	default:
+		return fmt.Errorf("invalid FinalizerPolicy value: %s", v)
+	}
+}
+
+func (e *FinalizerPolicy) Type() string {
+	return "FinalizerPolicy"
+}
@@ -14,20 +14,62 @@
 package v1alpha1
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // AWSIdentifiers provide all unique ways to reference an AWS resource.
+// +kubebuilder:validation:XValidation:rule="has(self.arn) || has(self.nameOrID) || has(self.additionalKeys)",message="at least one of arn, nameOrID or additionalKeys must be set"
 type AWSIdentifiers struct {
 	// ARN is the AWS Resource Name for the resource. It is a globally
 	// unique identifier.
+	// +kubebuilder:validation:XValidation:rule="self.matches('^arn:[^:]+:[^:]+:[^:]*:[^:]*:.+$')",message="arn must have the format arn:partition:service:region:account-id:resource"
 	ARN *AWSResourceName `json:"arn,omitempty"`
 	// NameOrId is a user-supplied string identifier for the resource. It may
 	// or may not be globally unique, depending on the type of resource.
+	// +kubebuilder:validation:MinLength=1
 	NameOrID string `json:"nameOrID,omitempty"`
 	// AdditionalKeys represents any additional arbitrary identifiers used when
 	// describing the target resource.
-	AdditionalKeys map[string]string `json:"additionalKeys,omitempty"`
+	AdditionalKeys AWSAdditionalKeys `json:"additionalKeys,omitempty"`
+}
+
+// Validate returns an error if the identifiers are empty or malformed
+func (ids *AWSIdentifiers) Validate() error {
+	if ids == nil || (ids.ARN == nil && ids.NameOrID == "" && len(ids.AdditionalKeys) == 0) {
+		return errors.New("at least one of arn, nameOrID or additionalKeys must be set")
+	}
+	if ids.ARN != nil {
+		if _, err := ids.ARN.Parse(); err != nil {
+			return err
+		}
+	}
+	return ids.AdditionalKeys.Validate()
+}
+
+// additionalKeyRegex matches the valid additional identifier keys, the JSON
+// names of the spec fields they identify
+var additionalKeyRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.]*$`)
+
+// AWSAdditionalKeys are additional identifiers of an AWS resource, keyed by
+// the JSON name of the spec field they identify (e.g. "clusterName").
+// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[a-zA-Z][a-zA-Z0-9_.]*$') && self[k] != '')",message="additionalKeys must be field names with non-empty values"
+type AWSAdditionalKeys map[string]string
+
+// Validate returns an error if a key is not a field name or a value is empty
+func (keys AWSAdditionalKeys) Validate() error {
+	for key, value := range keys {
+		if !additionalKeyRegex.MatchString(key) {
+			return fmt.Errorf("invalid additional key %q: expected a field name", key)
+		}
+		if value == "" {
+			return fmt.Errorf("invalid additional key %q: value must not be empty", key)
+		}
+	}
+	return nil
 }
 
 // NamespacedResource provides all the values necessary to identify an ACK
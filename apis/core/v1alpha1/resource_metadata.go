@@ -13,6 +13,10 @@
 
 package v1alpha1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // ResourceMetadata is common to all custom resources (CRs) managed by an ACK
 // service controller. It is contained in the CR's `Status` member field and
 // comprises various status and identifier fields useful to ACK for tracking
@@ -32,4 +36,45 @@ type ResourceMetadata struct {
 	OwnerAccountID *AWSAccountID `json:"ownerAccountID"`
 	// Region is the AWS region in which the resource exists or will exist.
 	Region *AWSRegion `json:"region"`
+	// RegionSource describes where the region of the resource was resolved
+	// from when the resource was created.
+	RegionSource *AWSRegionSource `json:"regionSource,omitempty"`
+	// LastDrift describes the last differences detected between the desired
+	// state of the resource and the latest observed state of the AWS
+	// resource, which the controller then updated. It is only recorded by
+	// the controllers started with --record-last-drift.
+	LastDrift *DriftSummary `json:"lastDrift,omitempty"`
+	// LastPlan describes what the last reconciliation of the resource would
+	// have done to its AWS resource, when its reconciliations are only
+	// planned, either because of its services.k8s.aws/plan annotation or
+	// because the controller was started with --dry-run.
+	LastPlan *PlanSummary `json:"lastPlan,omitempty"`
+}
+
+// DriftSummary describes differences between the desired state of a resource
+// and the latest observed state of its AWS resource
+type DriftSummary struct {
+	// DetectedAt is the time the differences were detected
+	DetectedAt *metav1.Time `json:"detectedAt"`
+	// Changes are the human-readable differences, one per field, e.g.
+	// `Spec.Versioning: "Suspended" => "Enabled"`. The values of the
+	// sensitive fields are elided.
+	Changes []string `json:"changes"`
+}
+
+// PlanSummary describes what reconciling a resource would do to its AWS
+// resource
+type PlanSummary struct {
+	// PlannedAt is the time the reconciliation was planned
+	PlannedAt *metav1.Time `json:"plannedAt"`
+	// Action is what the reconciliation would do to the AWS resource, one of
+	// "Create", "Update", "Delete" or "None". It is empty when the
+	// reconciliation could not be planned.
+	Action string `json:"action,omitempty"`
+	// Changes are the paths of the fields the reconciliation would set or
+	// change, e.g. "Spec.Versioning"
+	Changes []string `json:"changes,omitempty"`
+	// Error is the error that prevented the reconciliation from being
+	// planned
+	Error string `json:"error,omitempty"`
 }
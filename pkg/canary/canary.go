@@ -0,0 +1,97 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package canary lets service controllers roll out a change of the computed
+// defaults, or of the construction of the desired state, of a kind to a
+// percentage of its resources first, instead of updating all of them at
+// once after an upgrade of the controller.
+//
+// The resources of a kind are spread across 100 buckets by their UID. The
+// resources whose bucket is below the canary percentage of their kind, set
+// by the --reconcile-resource-canary-percent flag and ramped up through the
+// ControllerConfig of the controller, form the canary cohort. Ramping up the
+// percentage only adds resources to the cohort.
+//
+// A resource manager checks the cohort of the resource in the context of its
+// calls:
+//
+//	if canary.Enabled(ctx) {
+//	    input.SetStorageType("gp3")
+//	} else {
+//	    input.SetStorageType("gp2")
+//	}
+package canary
+
+import (
+	"context"
+	"hash/fnv"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Buckets is the number of buckets the resources are spread across, so that
+// a bucket is a percent of the resources
+const Buckets = 100
+
+// Cohort is the cohort of a resource during the rollout of a change of its
+// kind
+type Cohort string
+
+const (
+	// CohortCanary is the cohort of the resources getting the new behavior
+	CohortCanary Cohort = "canary"
+	// CohortBaseline is the cohort of the resources keeping the previous
+	// behavior
+	CohortBaseline Cohort = "baseline"
+)
+
+// contextKey is the context key of the Cohort of a resource
+type contextKey struct{}
+
+// Bucket returns the bucket, between 0 and Buckets-1, of the resource with
+// the supplied UID
+func Bucket(uid k8stypes.UID) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(uid))
+	return int(h.Sum32() % Buckets)
+}
+
+// CohortOf returns the cohort of the resource with the supplied UID when the
+// canary percentage of its kind is the supplied one
+func CohortOf(uid k8stypes.UID, percent int) Cohort {
+	if Bucket(uid) < percent {
+		return CohortCanary
+	}
+	return CohortBaseline
+}
+
+// WithCohort returns a copy of the supplied context holding the supplied
+// cohort of the resource being reconciled
+func WithCohort(ctx context.Context, cohort Cohort) context.Context {
+	return context.WithValue(ctx, contextKey{}, cohort)
+}
+
+// FromContext returns the cohort of the resource being reconciled, and false
+// if its kind has no rollout in progress
+func FromContext(ctx context.Context) (Cohort, bool) {
+	cohort, ok := ctx.Value(contextKey{}).(Cohort)
+	return cohort, ok
+}
+
+// Enabled returns true if the resource being reconciled gets the new
+// behavior: it is in the canary cohort, or its kind has no rollout in
+// progress
+func Enabled(ctx context.Context) bool {
+	cohort, ok := FromContext(ctx)
+	return !ok || cohort == CohortCanary
+}
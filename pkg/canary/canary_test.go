@@ -0,0 +1,61 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package canary_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/canary"
+)
+
+func TestCohortOf(t *testing.T) {
+	require := require.New(t)
+
+	canaries := map[int]int{}
+	for _, percent := range []int{0, 10, 50, 100} {
+		for i := 0; i < 1000; i++ {
+			uid := k8stypes.UID(fmt.Sprintf("uid-%d", i))
+			if canary.CohortOf(uid, percent) == canary.CohortCanary {
+				canaries[percent]++
+				// Ramping up the percentage keeps the canaries
+				require.Equal(canary.CohortCanary, canary.CohortOf(uid, percent+10))
+			}
+		}
+	}
+	require.Equal(0, canaries[0])
+	require.InDelta(100, canaries[10], 50)
+	require.InDelta(500, canaries[50], 75)
+	require.Equal(1000, canaries[100])
+}
+
+func TestEnabled(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	// Without a rollout in progress, every resource gets the new behavior
+	require.True(canary.Enabled(ctx))
+	_, ok := canary.FromContext(ctx)
+	require.False(ok)
+
+	require.True(canary.Enabled(canary.WithCohort(ctx, canary.CohortCanary)))
+	require.False(canary.Enabled(canary.WithCohort(ctx, canary.CohortBaseline)))
+	cohort, ok := canary.FromContext(canary.WithCohort(ctx, canary.CohortBaseline))
+	require.True(ok)
+	require.Equal(canary.CohortBaseline, cohort)
+}
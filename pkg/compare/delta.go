@@ -78,6 +78,30 @@ func (d *Delta) DifferentExcept(
 	return foundExcepts != numDiffs
 }
 
+// Select returns a new Delta containing the differences of the Delta at any
+// of the supplied paths, e.g. to update only a group of fields of a resource.
+func (d *Delta) Select(paths ...string) *Delta {
+	selected := NewDelta()
+	for _, diff := range d.Differences {
+		if diff.containedIn(paths) {
+			selected.Differences = append(selected.Differences, diff)
+		}
+	}
+	return selected
+}
+
+// Except returns a new Delta containing the differences of the Delta at none
+// of the supplied paths.
+func (d *Delta) Except(paths ...string) *Delta {
+	remaining := NewDelta()
+	for _, diff := range d.Differences {
+		if !diff.containedIn(paths) {
+			remaining.Differences = append(remaining.Differences, diff)
+		}
+	}
+	return remaining
+}
+
 // Add adds a new Difference to the Delta
 func (d *Delta) Add(
 	path string,
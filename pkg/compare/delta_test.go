@@ -90,3 +90,27 @@ func TestDifferentExcept(t *testing.T) {
 	require.True(d.DifferentExcept("Bar"))    // there is a difference that is *not* Bar
 	require.False(d.DifferentExcept("Baz.Y")) // there is *not* a different that is *not* Bar
 }
+
+func TestSelectExcept(t *testing.T) {
+	require := require.New(t)
+
+	d := compare.NewDelta()
+	d.Add("Spec.Tags", nil, nil)
+	d.Add("Spec.Logging.Enabled", nil, nil)
+	d.Add("Spec.Name", nil, nil)
+
+	selected := d.Select("Spec.Tags", "Spec.Logging")
+	require.Len(selected.Differences, 2)
+	require.True(selected.DifferentAt("Spec.Tags"))
+	require.True(selected.DifferentAt("Spec.Logging.Enabled"))
+	require.False(selected.DifferentAt("Spec.Name"))
+
+	remaining := d.Except("Spec.Tags", "Spec.Logging")
+	require.Len(remaining.Differences, 1)
+	require.True(remaining.DifferentAt("Spec.Name"))
+
+	require.Empty(d.Select("Status").Differences)
+	require.Len(d.Except().Differences, 3)
+	// The Delta itself is not modified
+	require.Len(d.Differences, 3)
+}
@@ -24,3 +24,14 @@ type Difference struct {
 	// B is the value of the first resource under comparison at the Path
 	B interface{}
 }
+
+// containedIn returns whether the Path of the Difference is at any of the
+// supplied paths
+func (d *Difference) containedIn(paths []string) bool {
+	for _, path := range paths {
+		if d.Path.Contains(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Normalizer returns the normalized form of a field value. Values are the
+// ones found in the unstructured representation of a resource (string,
+// bool, int64, float64, []interface{} or map[string]interface{}).
+// Normalizers must return the supplied value unchanged if they do not know
+// how to normalize it.
+type Normalizer func(value interface{}) interface{}
+
+// FieldNormalizers maps field paths to the normalizers applied, in order, to
+// the values found at those paths before comparing a desired and a latest
+// resource.
+//
+// Field paths are dot-separated lists of JSON field names, starting at the
+// root of the object, e.g. "spec.policyDocument". When a path traverses a
+// list, the remainder of the path is applied to every element of that list,
+// e.g. "spec.rules.description" normalizes the description of every rule.
+type FieldNormalizers map[string][]Normalizer
+
+// Normalize applies the normalizers in place to the supplied unstructured
+// object.
+func (n FieldNormalizers) Normalize(obj map[string]interface{}) {
+	for path, normalizers := range n {
+		if path == "" || len(normalizers) == 0 {
+			continue
+		}
+		normalizeAtPath(obj, strings.Split(path, "."), normalizers)
+	}
+}
+
+// normalizeAtPath applies the supplied normalizers to the value found at
+// path within the supplied map.
+func normalizeAtPath(
+	obj map[string]interface{},
+	path []string,
+	normalizers []Normalizer,
+) {
+	value, ok := obj[path[0]]
+	if !ok || value == nil {
+		return
+	}
+	if len(path) == 1 {
+		for _, normalizer := range normalizers {
+			value = normalizer(value)
+		}
+		obj[path[0]] = value
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalizeAtPath(v, path[1:], normalizers)
+	case []interface{}:
+		for _, elem := range v {
+			if m, ok := elem.(map[string]interface{}); ok {
+				normalizeAtPath(m, path[1:], normalizers)
+			}
+		}
+	}
+}
+
+// NormalizeTrimSpace removes leading and trailing white space from string
+// values, or from every string of a list of strings.
+func NormalizeTrimSpace(value interface{}) interface{} {
+	return mapStrings(value, strings.TrimSpace)
+}
+
+// NormalizeLowercase lowercases string values, or every string of a list of
+// strings.
+func NormalizeLowercase(value interface{}) interface{} {
+	return mapStrings(value, strings.ToLower)
+}
+
+// NormalizeJSONDocument rewrites string values holding a JSON document into
+// their canonical form: without insignificant white space, and with object
+// keys sorted. Strings that are not valid JSON documents are returned
+// unchanged.
+func NormalizeJSONDocument(value interface{}) interface{} {
+	return mapStrings(value, func(s string) string {
+		var doc interface{}
+		if err := json.Unmarshal([]byte(s), &doc); err != nil {
+			return s
+		}
+		// encoding/json sorts map keys when marshalling
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return s
+		}
+		return string(b)
+	})
+}
+
+// NormalizeSortStrings sorts lists of strings. Lists containing any value
+// that isn't a string are returned unchanged.
+func NormalizeSortStrings(value interface{}) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	strs := make([]string, 0, len(list))
+	for _, elem := range list {
+		s, ok := elem.(string)
+		if !ok {
+			return value
+		}
+		strs = append(strs, s)
+	}
+	sort.Strings(strs)
+	sorted := make([]interface{}, 0, len(strs))
+	for _, s := range strs {
+		sorted = append(sorted, s)
+	}
+	return sorted
+}
+
+// mapStrings applies fn to a string value, or to every string of a list.
+func mapStrings(value interface{}, fn func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case []interface{}:
+		mapped := make([]interface{}, 0, len(v))
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				mapped = append(mapped, fn(s))
+			} else {
+				mapped = append(mapped, elem)
+			}
+		}
+		return mapped
+	}
+	return value
+}
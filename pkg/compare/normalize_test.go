@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+func TestFieldNormalizers(t *testing.T) {
+	require := require.New(t)
+
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"name":           "  my-name ",
+			"engine":         "MySQL",
+			"policyDocument": "{ \"b\": 1,\n  \"a\": [\"x\"] }",
+			"zones":          []interface{}{"us-west-2b", "us-west-2a"},
+			"rules": []interface{}{
+				map[string]interface{}{"description": " first "},
+				map[string]interface{}{"description": "second "},
+				map[string]interface{}{},
+			},
+			"notADocument": "{oops",
+		},
+	}
+	normalizers := compare.FieldNormalizers{
+		"spec.name":              {compare.NormalizeTrimSpace},
+		"spec.engine":            {compare.NormalizeTrimSpace, compare.NormalizeLowercase},
+		"spec.policyDocument":    {compare.NormalizeJSONDocument},
+		"spec.zones":             {compare.NormalizeSortStrings},
+		"spec.rules.description": {compare.NormalizeTrimSpace},
+		"spec.notADocument":      {compare.NormalizeJSONDocument},
+		"spec.missing.field":     {compare.NormalizeLowercase},
+	}
+	normalizers.Normalize(obj)
+
+	spec := obj["spec"].(map[string]interface{})
+	require.Equal("my-name", spec["name"])
+	require.Equal("mysql", spec["engine"])
+	require.Equal(`{"a":["x"],"b":1}`, spec["policyDocument"])
+	require.Equal([]interface{}{"us-west-2a", "us-west-2b"}, spec["zones"])
+	rules := spec["rules"].([]interface{})
+	require.Equal("first", rules[0].(map[string]interface{})["description"])
+	require.Equal("second", rules[1].(map[string]interface{})["description"])
+	require.Empty(rules[2])
+	require.Equal("{oops", spec["notADocument"])
+	require.NotContains(spec, "missing")
+}
+
+func TestNormalizeSortStrings_Mixed(t *testing.T) {
+	require := require.New(t)
+
+	mixed := []interface{}{"b", int64(1), "a"}
+	require.Equal(mixed, compare.NormalizeSortStrings(mixed))
+	require.Equal("b", compare.NormalizeSortStrings("b"))
+	require.Equal(
+		[]interface{}{"a", int64(1)},
+		compare.NormalizeLowercase([]interface{}{"A", int64(1)}),
+	)
+}
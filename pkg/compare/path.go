@@ -76,6 +76,12 @@ func (p Path) Contains(subject string) bool {
 	return true
 }
 
+// String returns the dotted-notation representation of the Path, e.g.
+// "Author.Name"
+func (p Path) String() string {
+	return strings.Join(p.parts, ".")
+}
+
 // NewPath returns a new Path struct pointer from a dotted-notation string,
 // e.g. "Author.Name"
 func NewPath(dotted string) Path {
@@ -0,0 +1,231 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// defaultPolicyVersion is the version of the IAM policy language used
+	// by AWS when a policy document does not specify one.
+	defaultPolicyVersion = "2008-10-17"
+)
+
+var (
+	// policyListKeys are the statement keys whose values may either be a
+	// single string or a list of strings, and whose order is not
+	// significant.
+	policyListKeys = []string{"Action", "NotAction", "Resource", "NotResource"}
+	// policyPrincipalKeys are the statement keys holding principals
+	policyPrincipalKeys = []string{"Principal", "NotPrincipal"}
+)
+
+// PolicyDocumentEqual returns true if the supplied IAM-style JSON policy
+// documents are semantically equal. Documents are compared in their
+// canonical form, as returned by CanonicalPolicyDocument. An error is
+// returned if either document cannot be parsed.
+func PolicyDocumentEqual(a, b string) (bool, error) {
+	ca, err := canonicalPolicy(a)
+	if err != nil {
+		return false, err
+	}
+	cb, err := canonicalPolicy(b)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(ca, cb), nil
+}
+
+// PolicyDocumentPEqual returns true if the supplied pointers to IAM-style
+// JSON policy documents are either both nil or semantically equal. If either
+// document cannot be parsed, the documents are compared as strings.
+func PolicyDocumentPEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	equal, err := PolicyDocumentEqual(*a, *b)
+	if err != nil {
+		return *a == *b
+	}
+	return equal
+}
+
+// CanonicalPolicyDocument returns the canonical form of the supplied IAM-style
+// JSON policy document:
+//
+//   - URL-encoded documents (as returned by some IAM APIs) are decoded
+//   - the default "Version" is set if the document does not specify one
+//   - a single "Statement" object is turned into a list of one statement,
+//     and statements are sorted
+//   - "Action", "NotAction", "Resource" and "NotResource" values, principal
+//     values and condition values are turned into sorted lists without
+//     duplicates
+//   - the document is serialized without insignificant white space and
+//     with object keys sorted
+func CanonicalPolicyDocument(doc string) (string, error) {
+	canonical, err := canonicalPolicy(doc)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// NormalizePolicyDocument is a Normalizer rewriting string values holding
+// IAM-style JSON policy documents into their canonical form. Strings that
+// cannot be parsed as policy documents are returned unchanged.
+func NormalizePolicyDocument(value interface{}) interface{} {
+	return mapStrings(value, func(s string) string {
+		canonical, err := CanonicalPolicyDocument(s)
+		if err != nil {
+			return s
+		}
+		return canonical
+	})
+}
+
+// canonicalPolicy parses the supplied policy document and returns its
+// canonical representation.
+func canonicalPolicy(doc string) (map[string]interface{}, error) {
+	policy := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		decoded, uerr := url.QueryUnescape(doc)
+		if uerr != nil || decoded == doc {
+			return nil, fmt.Errorf("parsing policy document: %v", err)
+		}
+		if err := json.Unmarshal([]byte(decoded), &policy); err != nil {
+			return nil, fmt.Errorf("parsing policy document: %v", err)
+		}
+	}
+
+	if _, ok := policy["Version"]; !ok {
+		policy["Version"] = defaultPolicyVersion
+	}
+
+	if statement, ok := policy["Statement"]; ok {
+		statements := []interface{}{}
+		switch s := statement.(type) {
+		case map[string]interface{}:
+			statements = append(statements, s)
+		case []interface{}:
+			statements = s
+		default:
+			return nil, fmt.Errorf("parsing policy document: unexpected Statement type %T", statement)
+		}
+		for _, s := range statements {
+			if m, ok := s.(map[string]interface{}); ok {
+				canonicalStatement(m)
+			}
+		}
+		policy["Statement"] = sortByJSON(statements)
+	}
+	return policy, nil
+}
+
+// canonicalStatement canonicalizes a single policy statement in place.
+func canonicalStatement(statement map[string]interface{}) {
+	for _, key := range policyListKeys {
+		if v, ok := statement[key]; ok {
+			statement[key] = stringSet(v)
+		}
+	}
+	for _, key := range policyPrincipalKeys {
+		principal, ok := statement[key].(map[string]interface{})
+		if !ok {
+			// "*" principals are kept as is
+			continue
+		}
+		for principalType, v := range principal {
+			principal[principalType] = stringSet(v)
+		}
+	}
+	if condition, ok := statement["Condition"].(map[string]interface{}); ok {
+		for _, c := range condition {
+			values, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for key, v := range values {
+				values[key] = stringSet(v)
+			}
+		}
+	}
+}
+
+// stringSet turns a single value, or a list of values, into a sorted list of
+// unique strings. Non-string scalar values (e.g. booleans and numbers in
+// conditions) are turned into their string representation, as IAM does.
+func stringSet(value interface{}) interface{} {
+	var values []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		values = v
+	default:
+		values = []interface{}{v}
+	}
+	seen := map[string]bool{}
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		var s string
+		switch sv := v.(type) {
+		case string:
+			s = sv
+		case bool, float64:
+			s = fmt.Sprintf("%v", sv)
+		default:
+			// Leave values we don't know how to canonicalize untouched
+			return value
+		}
+		if !seen[s] {
+			seen[s] = true
+			strs = append(strs, s)
+		}
+	}
+	sort.Strings(strs)
+	set := make([]interface{}, 0, len(strs))
+	for _, s := range strs {
+		set = append(set, s)
+	}
+	return set
+}
+
+// sortByJSON sorts the supplied values by their JSON representation.
+func sortByJSON(values []interface{}) []interface{} {
+	type keyed struct {
+		key   string
+		value interface{}
+	}
+	keyedValues := make([]keyed, 0, len(values))
+	for _, v := range values {
+		b, _ := json.Marshal(v)
+		keyedValues = append(keyedValues, keyed{key: string(b), value: v})
+	}
+	sort.SliceStable(keyedValues, func(i, j int) bool {
+		return strings.Compare(keyedValues[i].key, keyedValues[j].key) < 0
+	})
+	sorted := make([]interface{}, 0, len(values))
+	for _, kv := range keyedValues {
+		sorted = append(sorted, kv.value)
+	}
+	return sorted
+}
@@ -0,0 +1,128 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+func TestPolicyDocumentEqual(t *testing.T) {
+	base := `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": {"AWS": ["arn:aws:iam::123456789012:root"]},
+			"Action": ["s3:GetObject", "s3:PutObject"],
+			"Resource": "arn:aws:s3:::my-bucket/*",
+			"Condition": {"Bool": {"aws:SecureTransport": "true"}}
+		}]
+	}`
+	tests := []struct {
+		name  string
+		other string
+		equal bool
+	}{
+		{
+			name:  "identical",
+			other: base,
+			equal: true,
+		},
+		{
+			name: "formatting, key order, single values and action order",
+			other: `{"Statement":{"Resource":["arn:aws:s3:::my-bucket/*"],` +
+				`"Condition":{"Bool":{"aws:SecureTransport":true}},` +
+				`"Action":["s3:PutObject","s3:GetObject","s3:GetObject"],` +
+				`"Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Effect":"Allow"},` +
+				`"Version":"2012-10-17"}`,
+			equal: true,
+		},
+		{
+			name:  "url encoded",
+			other: url.QueryEscape(base),
+			equal: true,
+		},
+		{
+			name: "different action",
+			other: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow",` +
+				`"Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"s3:GetObject",` +
+				`"Resource":"arn:aws:s3:::my-bucket/*",` +
+				`"Condition":{"Bool":{"aws:SecureTransport":"true"}}}]}`,
+			equal: false,
+		},
+		{
+			name: "default version",
+			other: `{"Statement":[{"Effect":"Allow",` +
+				`"Principal":{"AWS":"arn:aws:iam::123456789012:root"},` +
+				`"Action":["s3:GetObject","s3:PutObject"],"Resource":"arn:aws:s3:::my-bucket/*",` +
+				`"Condition":{"Bool":{"aws:SecureTransport":"true"}}}]}`,
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, err := compare.PolicyDocumentEqual(base, tt.other)
+			require.Nil(t, err)
+			assert.Equal(t, tt.equal, equal)
+		})
+	}
+}
+
+func TestPolicyDocumentEqual_DefaultVersionAndStatementOrder(t *testing.T) {
+	require := require.New(t)
+
+	a := `{"Statement":[{"Effect":"Allow","Action":"a:B","Resource":"*"},{"Effect":"Deny","Action":"c:D","Resource":"*"}]}`
+	b := `{"Version":"2008-10-17","Statement":[{"Effect":"Deny","Action":"c:D","Resource":"*"},{"Effect":"Allow","Action":["a:B"],"Resource":"*"}]}`
+	equal, err := compare.PolicyDocumentEqual(a, b)
+	require.Nil(err)
+	require.True(equal)
+
+	_, err = compare.PolicyDocumentEqual(a, "not a policy")
+	require.NotNil(err)
+}
+
+func TestPolicyDocumentPEqual(t *testing.T) {
+	require := require.New(t)
+
+	a := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"a:B","Resource":"*"}}`
+	b := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["a:B"],"Resource":["*"]}]}`
+	invalid := "{"
+
+	require.True(compare.PolicyDocumentPEqual(nil, nil))
+	require.False(compare.PolicyDocumentPEqual(&a, nil))
+	require.False(compare.PolicyDocumentPEqual(nil, &a))
+	require.True(compare.PolicyDocumentPEqual(&a, &b))
+	require.True(compare.PolicyDocumentPEqual(&invalid, &invalid))
+	require.False(compare.PolicyDocumentPEqual(&a, &invalid))
+}
+
+func TestCanonicalPolicyDocument(t *testing.T) {
+	require := require.New(t)
+
+	canonical, err := compare.CanonicalPolicyDocument(
+		`{"Statement":{"Principal":"*","Effect":"Allow","Action":["b:C","a:B"],"Resource":"*"}}`,
+	)
+	require.Nil(err)
+	require.Equal(
+		`{"Statement":[{"Action":["a:B","b:C"],"Effect":"Allow","Principal":"*","Resource":["*"]}],"Version":"2008-10-17"}`,
+		canonical,
+	)
+	require.Equal(canonical, compare.NormalizePolicyDocument(canonical))
+	require.Equal("oops", compare.NormalizePolicyDocument("oops"))
+}
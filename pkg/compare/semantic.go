@@ -0,0 +1,320 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ComparatorCIDR is the name of the CIDR block comparator
+	ComparatorCIDR = "cidr"
+	// ComparatorARN is the name of the ARN comparator
+	ComparatorARN = "arn"
+	// ComparatorDuration is the name of the duration comparator
+	ComparatorDuration = "duration"
+	// ComparatorTime is the name of the timestamp comparator
+	ComparatorTime = "time"
+	// ComparatorPolicyDocument is the name of the IAM policy document
+	// comparator
+	ComparatorPolicyDocument = "policyDocument"
+)
+
+// Comparator returns true if the two supplied values are semantically equal.
+// Values are the ones found in a Difference, and are usually pointers to
+// scalar types (e.g. *string or *int64).
+type Comparator func(a, b interface{}) bool
+
+// FieldComparators maps field paths, in the dotted notation used by Delta
+// (e.g. "Spec.CIDRBlock"), to the semantic comparator used for the values at
+// that path.
+type FieldComparators map[string]Comparator
+
+// Filter returns a copy of the supplied Delta without the differences whose
+// values are semantically equal according to the comparator registered for
+// their path.
+func (c FieldComparators) Filter(delta *Delta) *Delta {
+	if delta == nil || len(c) == 0 {
+		return delta
+	}
+	filtered := NewDelta()
+	for _, diff := range delta.Differences {
+		if comparator, ok := c[diff.Path.String()]; ok && comparator(diff.A, diff.B) {
+			continue
+		}
+		filtered.Differences = append(filtered.Differences, diff)
+	}
+	return filtered
+}
+
+var (
+	comparatorsLock sync.RWMutex
+	// comparators holds the named comparators
+	comparators = map[string]Comparator{
+		ComparatorCIDR:           CIDREqual,
+		ComparatorARN:            ARNEqual,
+		ComparatorDuration:       DurationEqual,
+		ComparatorTime:           TimeEqual,
+		ComparatorPolicyDocument: policyDocumentComparator,
+	}
+)
+
+// RegisterComparator registers a named comparator, so that it can be
+// selected by name with GetComparator. Registering a comparator with the
+// name of an existing one replaces it.
+func RegisterComparator(name string, comparator Comparator) {
+	comparatorsLock.Lock()
+	defer comparatorsLock.Unlock()
+	comparators[name] = comparator
+}
+
+// GetComparator returns the comparator registered with the supplied name.
+func GetComparator(name string) (Comparator, bool) {
+	comparatorsLock.RLock()
+	defer comparatorsLock.RUnlock()
+	comparator, ok := comparators[name]
+	return comparator, ok
+}
+
+// CIDREqual returns true if the supplied values are CIDR blocks representing
+// the same network, e.g. "10.0.0.1/16" and "10.0.0.0/16", or
+// "2001:DB8::/32" and "2001:db8::/32".
+func CIDREqual(a, b interface{}) bool {
+	return compareStrings(a, b, func(sa, sb string) (bool, error) {
+		_, na, err := net.ParseCIDR(sa)
+		if err != nil {
+			return false, err
+		}
+		_, nb, err := net.ParseCIDR(sb)
+		if err != nil {
+			return false, err
+		}
+		return na.String() == nb.String(), nil
+	})
+}
+
+// ARNEqual returns true if the supplied values are equal ARNs. A partition
+// of "*" in either ARN matches any partition.
+func ARNEqual(a, b interface{}) bool {
+	return compareStrings(a, b, func(sa, sb string) (bool, error) {
+		aa, err := arn.Parse(sa)
+		if err != nil {
+			return false, err
+		}
+		ab, err := arn.Parse(sb)
+		if err != nil {
+			return false, err
+		}
+		if aa.Partition == "*" || ab.Partition == "*" {
+			aa.Partition = ab.Partition
+		}
+		return aa == ab, nil
+	})
+}
+
+// DurationEqual returns true if the supplied values represent the same
+// duration. Durations can be expressed as ISO 8601 durations (e.g. "PT1H"),
+// Go durations (e.g. "1h0m0s") or as a number of seconds: "PT60M", "1h" and
+// 3600 are all equal.
+func DurationEqual(a, b interface{}) bool {
+	da, err := toDuration(a)
+	if err != nil {
+		return defaultEqual(a, b)
+	}
+	db, err := toDuration(b)
+	if err != nil {
+		return defaultEqual(a, b)
+	}
+	return da == db
+}
+
+// TimeEqual returns true if the supplied values represent the same instant,
+// with a precision of one second. Times can be expressed as RFC 3339 strings,
+// Unix epoch seconds (numbers or numeric strings), time.Time or metav1.Time.
+func TimeEqual(a, b interface{}) bool {
+	ta, err := toTime(a)
+	if err != nil {
+		return defaultEqual(a, b)
+	}
+	tb, err := toTime(b)
+	if err != nil {
+		return defaultEqual(a, b)
+	}
+	return ta.Unix() == tb.Unix()
+}
+
+// policyDocumentComparator is the Comparator flavour of PolicyDocumentEqual.
+func policyDocumentComparator(a, b interface{}) bool {
+	return compareStrings(a, b, func(sa, sb string) (bool, error) {
+		return PolicyDocumentEqual(sa, sb)
+	})
+}
+
+// compareStrings compares string (or *string) values using eq. Values that
+// are not strings, or that eq fails to parse, are compared for equality.
+func compareStrings(a, b interface{}, eq func(a, b string) (bool, error)) bool {
+	sa, oka := toString(a)
+	sb, okb := toString(b)
+	if !oka || !okb {
+		return defaultEqual(a, b)
+	}
+	if sa == nil || sb == nil {
+		return sa == nil && sb == nil
+	}
+	equal, err := eq(*sa, *sb)
+	if err != nil {
+		return *sa == *sb
+	}
+	return equal
+}
+
+// defaultEqual is used when values cannot be compared semantically.
+func defaultEqual(a, b interface{}) bool {
+	if IsNil(a) || IsNil(b) {
+		return IsNil(a) && IsNil(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// toString returns the string held by the supplied value, if it is a string
+// or a *string.
+func toString(v interface{}) (*string, bool) {
+	switch s := v.(type) {
+	case string:
+		return &s, true
+	case *string:
+		return s, true
+	case nil:
+		return nil, true
+	}
+	return nil, false
+}
+
+// toFloat returns the number held by the supplied value, if it is a number
+// or a pointer to a number.
+func toFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return 0, false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// isoDurationRegexp matches ISO 8601 durations
+var isoDurationRegexp = regexp.MustCompile(
+	`^P(?:(\d+(?:\.\d+)?)Y)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?` +
+		`(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`,
+)
+
+// isoDurationUnits are the lengths of the units of an ISO 8601 duration, in
+// the order of the isoDurationRegexp groups. Years and months are assumed to
+// be 365 and 30 days long.
+var isoDurationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// toDuration returns the duration represented by the supplied value.
+func toDuration(v interface{}) (time.Duration, error) {
+	if f, ok := toFloat(v); ok {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	s, ok := toString(v)
+	if !ok || s == nil {
+		return 0, fmt.Errorf("unsupported duration value %v", v)
+	}
+	if seconds, err := strconv.ParseFloat(*s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	if d, err := time.ParseDuration(*s); err == nil {
+		return d, nil
+	}
+	matches := isoDurationRegexp.FindStringSubmatch(*s)
+	if matches == nil || *s == "P" || *s == "PT" {
+		return 0, fmt.Errorf("invalid duration %q", *s)
+	}
+	var d time.Duration
+	for i, m := range matches[1:] {
+		if m == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(math.Round(f * float64(isoDurationUnits[i])))
+	}
+	return d, nil
+}
+
+// toTime returns the time represented by the supplied value.
+func toTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t != nil {
+			return *t, nil
+		}
+	case k8smetav1.Time:
+		return t.Time, nil
+	case *k8smetav1.Time:
+		if t != nil {
+			return t.Time, nil
+		}
+	}
+	if f, ok := toFloat(v); ok {
+		return epochToTime(f), nil
+	}
+	s, ok := toString(v)
+	if !ok || s == nil {
+		return time.Time{}, fmt.Errorf("unsupported time value %v", v)
+	}
+	if f, err := strconv.ParseFloat(*s, 64); err == nil {
+		return epochToTime(f), nil
+	}
+	return time.Parse(time.RFC3339Nano, *s)
+}
+
+// epochToTime converts Unix epoch seconds into a time.Time
+func epochToTime(seconds float64) time.Time {
+	sec, frac := math.Modf(seconds)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second)))
+}
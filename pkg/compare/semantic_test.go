@@ -0,0 +1,98 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestSemanticComparators(t *testing.T) {
+	epoch := int64(1700000000)
+	rfc3339 := time.Unix(epoch, 0).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name       string
+		comparator compare.Comparator
+		a          interface{}
+		b          interface{}
+		equal      bool
+	}{
+		{"cidr same network", compare.CIDREqual, strPtr("10.0.0.1/16"), strPtr("10.0.0.0/16"), true},
+		{"cidr ipv6 case", compare.CIDREqual, "2001:DB8::/32", "2001:db8::/32", true},
+		{"cidr different prefix", compare.CIDREqual, strPtr("10.0.0.0/16"), strPtr("10.0.0.0/24"), false},
+		{"cidr invalid", compare.CIDREqual, strPtr("oops"), strPtr("oops"), true},
+		{"cidr nil", compare.CIDREqual, (*string)(nil), strPtr("10.0.0.0/16"), false},
+		{"cidr both nil", compare.CIDREqual, (*string)(nil), (*string)(nil), true},
+		{"arn equal", compare.ARNEqual, "arn:aws:s3:::bucket", "arn:aws:s3:::bucket", true},
+		{"arn wildcard partition", compare.ARNEqual, "arn:*:iam::123456789012:role/r", "arn:aws-cn:iam::123456789012:role/r", true},
+		{"arn different account", compare.ARNEqual, "arn:aws:iam::123456789012:role/r", "arn:aws:iam::210987654321:role/r", false},
+		{"duration iso vs go", compare.DurationEqual, strPtr("PT1H"), strPtr("60m"), true},
+		{"duration iso vs seconds", compare.DurationEqual, strPtr("P1DT30M"), int64(88200), true},
+		{"duration numeric string", compare.DurationEqual, "3600", "PT60M", true},
+		{"duration different", compare.DurationEqual, "PT1H", "PT2H", false},
+		{"duration invalid", compare.DurationEqual, "soon", "soon", true},
+		{"time epoch vs rfc3339", compare.TimeEqual, epoch, strPtr(rfc3339), true},
+		{"time metav1 vs epoch string", compare.TimeEqual, &metav1.Time{Time: time.Unix(epoch, 0)}, "1700000000", true},
+		{"time different", compare.TimeEqual, epoch, epoch + 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, tt.comparator(tt.a, tt.b))
+		})
+	}
+}
+
+func TestFieldComparators_Filter(t *testing.T) {
+	require := require.New(t)
+
+	delta := compare.NewDelta()
+	delta.Add("Spec.CIDRBlock", strPtr("10.0.0.1/16"), strPtr("10.0.0.0/16"))
+	delta.Add("Spec.RoleARN", strPtr("arn:aws:iam::123456789012:role/a"), strPtr("arn:aws:iam::123456789012:role/b"))
+	delta.Add("Spec.Name", strPtr("a"), strPtr("b"))
+
+	arnComparator, ok := compare.GetComparator(compare.ComparatorARN)
+	require.True(ok)
+	comparators := compare.FieldComparators{
+		"Spec.CIDRBlock": compare.CIDREqual,
+		"Spec.RoleARN":   arnComparator,
+	}
+	filtered := comparators.Filter(delta)
+	require.Len(filtered.Differences, 2)
+	require.False(filtered.DifferentAt("Spec.CIDRBlock"))
+	require.True(filtered.DifferentAt("Spec.RoleARN"))
+	require.True(filtered.DifferentAt("Spec.Name"))
+	// The original delta is left untouched
+	require.Len(delta.Differences, 3)
+}
+
+func TestRegisterComparator(t *testing.T) {
+	require := require.New(t)
+
+	_, ok := compare.GetComparator("always")
+	require.False(ok)
+	compare.RegisterComparator("always", func(a, b interface{}) bool { return true })
+	comparator, ok := compare.GetComparator("always")
+	require.True(ok)
+	require.True(comparator("a", "b"))
+}
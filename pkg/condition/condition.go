@@ -29,13 +29,22 @@ var (
 	NotManagedReason  = "This resource already exists but is not managed by ACK. " +
 		"To bring the resource under ACK management, you should explicitly adopt " +
 		"the resource by enabling the ResourceAdoption feature gate and populating " +
-		"the `services.k8s.aws/adoption-policy` and `services.k8s.aws/adoption-fields` " + 
+		"the `services.k8s.aws/adoption-policy` and `services.k8s.aws/adoption-fields` " +
 		"annotations."
-	UnknownSyncedMessage             = "Unable to determine if desired resource state matches latest observed state"
-	NotSyncedMessage                 = "Resource not synced"
-	SyncedMessage                    = "Resource synced successfully"
-	FailedReferenceResolutionMessage = "Reference resolution failed"
-	UnavailableIAMRoleMessage        = "IAM Role is not available"
+	UnknownSyncedMessage              = "Unable to determine if desired resource state matches latest observed state"
+	NotSyncedMessage                  = "Resource not synced"
+	SyncedMessage                     = "Resource synced successfully"
+	FailedReferenceResolutionMessage  = "Reference resolution failed"
+	UnavailableIAMRoleMessage         = "IAM Role is not available"
+	AssumeRoleFailedMessage           = "Unable to assume the IAM Role of the namespace account binding"
+	UnavailableRegionMessage          = "AWS Region is not available"
+	RegionNotEnabledMessage           = "AWS Region is not enabled for the AWS account"
+	RegionAccountChangedMessage       = "AWS Region or owner account changed after creation"
+	OwnerRoleNotAllowedMessage        = "IAM Role of the owner role ARN annotation is invalid or not allowed"
+	ResourceManagerUnavailableMessage = "Unable to construct the resource manager for the AWS account and region"
+	ProgressingMessage                = "Resource progressing towards its desired state"
+	ProgressDeadlineExceededMessage   = "Resource did not sync within its progress deadline"
+	PausedMessage                     = "Reconciliation paused, the AWS resource is neither read nor changed"
 )
 
 // Synced returns the Condition in the resource's Conditions collection that is
@@ -73,6 +82,53 @@ func ReferencesResolved(subject acktypes.ConditionManager) *ackv1alpha1.Conditio
 	return FirstOfType(subject, ackv1alpha1.ConditionTypeReferencesResolved)
 }
 
+// UpdateRolledBack returns the Condition in the resource's Conditions
+// collection that is of type ConditionTypeUpdateRolledBack. If no such
+// condition is found, returns nil.
+func UpdateRolledBack(subject acktypes.ConditionManager) *ackv1alpha1.Condition {
+	return FirstOfType(subject, ackv1alpha1.ConditionTypeUpdateRolledBack)
+}
+
+// RegionAccountChanged returns the Condition in the resource's Conditions
+// collection that is of type ConditionTypeRegionAccountChanged. If no such
+// condition is found, returns nil.
+func RegionAccountChanged(subject acktypes.ConditionManager) *ackv1alpha1.Condition {
+	return FirstOfType(subject, ackv1alpha1.ConditionTypeRegionAccountChanged)
+}
+
+// Degraded returns the Condition in the resource's Conditions collection that
+// is of type ConditionTypeDegraded. If no such condition is found, returns
+// nil.
+func Degraded(subject acktypes.ConditionManager) *ackv1alpha1.Condition {
+	return FirstOfType(subject, ackv1alpha1.ConditionTypeDegraded)
+}
+
+// Paused returns the Condition in the resource's Conditions collection that
+// is of type ConditionTypePaused. If no such condition is found, returns nil.
+func Paused(subject acktypes.ConditionManager) *ackv1alpha1.Condition {
+	return FirstOfType(subject, ackv1alpha1.ConditionTypePaused)
+}
+
+// Deferred returns the Condition in the resource's Conditions collection
+// that is of type ConditionTypeDeferred. If no such condition is found,
+// returns nil.
+func Deferred(subject acktypes.ConditionManager) *ackv1alpha1.Condition {
+	return FirstOfType(subject, ackv1alpha1.ConditionTypeDeferred)
+}
+
+// PolicyViolation returns the Condition in the resource's Conditions
+// collection that is of type ConditionTypePolicyViolation. If no such
+// condition is found, returns nil.
+func PolicyViolation(subject acktypes.ConditionManager) *ackv1alpha1.Condition {
+	return FirstOfType(subject, ackv1alpha1.ConditionTypePolicyViolation)
+}
+
+// Blocked returns the Condition in the resource's Conditions collection that
+// is of type ConditionTypeBlocked. If no such condition is found, returns nil.
+func Blocked(subject acktypes.ConditionManager) *ackv1alpha1.Condition {
+	return FirstOfType(subject, ackv1alpha1.ConditionTypeBlocked)
+}
+
 // FirstOfType returns the first Condition in the resource's Conditions
 // collection of the supplied type. If no such condition is found, returns nil.
 func FirstOfType(
@@ -239,6 +295,294 @@ func RemoveReferencesResolved(
 	}
 }
 
+// SetUpdateRolledBack sets the resource's Condition of type
+// ConditionTypeUpdateRolledBack to the supplied status, optional message and
+// reason.
+func SetUpdateRolledBack(
+	subject acktypes.ConditionManager,
+	status corev1.ConditionStatus,
+	message *string,
+	reason *string,
+) {
+	allConds := subject.Conditions()
+	var c *ackv1alpha1.Condition
+	if c = UpdateRolledBack(subject); c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: ackv1alpha1.ConditionTypeUpdateRolledBack,
+		}
+		allConds = append(allConds, c)
+	}
+	now := metav1.Now()
+	c.LastTransitionTime = &now
+	c.Status = status
+	c.Message = message
+	c.Reason = reason
+	subject.ReplaceConditions(allConds)
+}
+
+// SetRegionAccountChanged sets the resource's Condition of type
+// ConditionTypeRegionAccountChanged to the supplied status, optional message
+// and reason.
+func SetRegionAccountChanged(
+	subject acktypes.ConditionManager,
+	status corev1.ConditionStatus,
+	message *string,
+	reason *string,
+) {
+	allConds := subject.Conditions()
+	var c *ackv1alpha1.Condition
+	if c = RegionAccountChanged(subject); c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: ackv1alpha1.ConditionTypeRegionAccountChanged,
+		}
+		allConds = append(allConds, c)
+	}
+	now := metav1.Now()
+	c.LastTransitionTime = &now
+	c.Status = status
+	c.Message = message
+	c.Reason = reason
+	subject.ReplaceConditions(allConds)
+}
+
+// SetDegraded sets the resource's Condition of type ConditionTypeDegraded to
+// the supplied status, optional message and reason.
+func SetDegraded(
+	subject acktypes.ConditionManager,
+	status corev1.ConditionStatus,
+	message *string,
+	reason *string,
+) {
+	allConds := subject.Conditions()
+	var c *ackv1alpha1.Condition
+	if c = Degraded(subject); c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: ackv1alpha1.ConditionTypeDegraded,
+		}
+		allConds = append(allConds, c)
+	}
+	now := metav1.Now()
+	c.LastTransitionTime = &now
+	c.Status = status
+	c.Message = message
+	c.Reason = reason
+	subject.ReplaceConditions(allConds)
+}
+
+// SetDeferred sets the resource's Condition of type ConditionTypeDeferred to
+// True, with the supplied message and optional reason
+func SetDeferred(
+	subject acktypes.ConditionManager,
+	message *string,
+	reason *string,
+) {
+	allConds := subject.Conditions()
+	var c *ackv1alpha1.Condition
+	if c = Deferred(subject); c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: ackv1alpha1.ConditionTypeDeferred,
+		}
+		allConds = append(allConds, c)
+	}
+	now := metav1.Now()
+	c.LastTransitionTime = &now
+	c.Status = corev1.ConditionTrue
+	c.Message = message
+	c.Reason = reason
+	subject.ReplaceConditions(allConds)
+}
+
+// SetPolicyViolation sets the resource's Condition of type
+// ConditionTypePolicyViolation to True, with the supplied message and
+// optional reason
+func SetPolicyViolation(
+	subject acktypes.ConditionManager,
+	message *string,
+	reason *string,
+) {
+	allConds := subject.Conditions()
+	var c *ackv1alpha1.Condition
+	if c = PolicyViolation(subject); c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: ackv1alpha1.ConditionTypePolicyViolation,
+		}
+		allConds = append(allConds, c)
+	}
+	now := metav1.Now()
+	c.LastTransitionTime = &now
+	c.Status = corev1.ConditionTrue
+	c.Message = message
+	c.Reason = reason
+	subject.ReplaceConditions(allConds)
+}
+
+// SetBlocked sets the resource's Condition of type ConditionTypeBlocked to
+// True, with the supplied message and optional reason
+func SetBlocked(
+	subject acktypes.ConditionManager,
+	message *string,
+	reason *string,
+) {
+	allConds := subject.Conditions()
+	var c *ackv1alpha1.Condition
+	if c = Blocked(subject); c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: ackv1alpha1.ConditionTypeBlocked,
+		}
+		allConds = append(allConds, c)
+	}
+	now := metav1.Now()
+	c.LastTransitionTime = &now
+	c.Status = corev1.ConditionTrue
+	c.Message = message
+	c.Reason = reason
+	subject.ReplaceConditions(allConds)
+}
+
+// SetPaused sets the resource's Condition of type ConditionTypePaused to
+// True, with the supplied optional reason. The last transition time of an
+// existing True condition is kept.
+func SetPaused(
+	subject acktypes.ConditionManager,
+	reason *string,
+) {
+	allConds := subject.Conditions()
+	var c *ackv1alpha1.Condition
+	if c = Paused(subject); c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: ackv1alpha1.ConditionTypePaused,
+		}
+		allConds = append(allConds, c)
+	}
+	if c.Status != corev1.ConditionTrue || c.LastTransitionTime == nil {
+		now := metav1.Now()
+		c.LastTransitionTime = &now
+	}
+	c.Status = corev1.ConditionTrue
+	c.Message = &PausedMessage
+	c.Reason = reason
+	subject.ReplaceConditions(allConds)
+}
+
+// RemovePaused removes the condition of type ConditionTypePaused from the
+// resource's conditions
+func RemovePaused(
+	subject acktypes.ConditionManager,
+) {
+	allConds := subject.Conditions()
+	var newConds []*ackv1alpha1.Condition
+	if c := Paused(subject); c != nil {
+		for _, cond := range allConds {
+			if cond.Type != ackv1alpha1.ConditionTypePaused {
+				newConds = append(newConds, cond)
+			}
+		}
+		subject.ReplaceConditions(newConds)
+	}
+}
+
+// RemoveDeferred removes the condition of type ConditionTypeDeferred from the
+// resource's conditions
+func RemoveDeferred(
+	subject acktypes.ConditionManager,
+) {
+	allConds := subject.Conditions()
+	var newConds []*ackv1alpha1.Condition
+	if c := Deferred(subject); c != nil {
+		for _, cond := range allConds {
+			if cond.Type != ackv1alpha1.ConditionTypeDeferred {
+				newConds = append(newConds, cond)
+			}
+		}
+		subject.ReplaceConditions(newConds)
+	}
+}
+
+// RemovePolicyViolation removes the condition of type
+// ConditionTypePolicyViolation from the resource's conditions
+func RemovePolicyViolation(
+	subject acktypes.ConditionManager,
+) {
+	allConds := subject.Conditions()
+	var newConds []*ackv1alpha1.Condition
+	if c := PolicyViolation(subject); c != nil {
+		for _, cond := range allConds {
+			if cond.Type != ackv1alpha1.ConditionTypePolicyViolation {
+				newConds = append(newConds, cond)
+			}
+		}
+		subject.ReplaceConditions(newConds)
+	}
+}
+
+// RemoveBlocked removes the condition of type ConditionTypeBlocked from the
+// resource's conditions
+func RemoveBlocked(
+	subject acktypes.ConditionManager,
+) {
+	allConds := subject.Conditions()
+	var newConds []*ackv1alpha1.Condition
+	if c := Blocked(subject); c != nil {
+		for _, cond := range allConds {
+			if cond.Type != ackv1alpha1.ConditionTypeBlocked {
+				newConds = append(newConds, cond)
+			}
+		}
+		subject.ReplaceConditions(newConds)
+	}
+}
+
+// RemoveUpdateRolledBack removes the condition of type
+// ConditionTypeUpdateRolledBack from the resource's conditions
+func RemoveUpdateRolledBack(
+	subject acktypes.ConditionManager,
+) {
+	allConds := subject.Conditions()
+	var newConds []*ackv1alpha1.Condition
+	if c := UpdateRolledBack(subject); c != nil {
+		for _, cond := range allConds {
+			if cond.Type != ackv1alpha1.ConditionTypeUpdateRolledBack {
+				newConds = append(newConds, cond)
+			}
+		}
+		subject.ReplaceConditions(newConds)
+	}
+}
+
+// RemoveRegionAccountChanged removes the condition of type
+// ConditionTypeRegionAccountChanged from the resource's conditions
+func RemoveRegionAccountChanged(
+	subject acktypes.ConditionManager,
+) {
+	allConds := subject.Conditions()
+	var newConds []*ackv1alpha1.Condition
+	if c := RegionAccountChanged(subject); c != nil {
+		for _, cond := range allConds {
+			if cond.Type != ackv1alpha1.ConditionTypeRegionAccountChanged {
+				newConds = append(newConds, cond)
+			}
+		}
+		subject.ReplaceConditions(newConds)
+	}
+}
+
+// RemoveDegraded removes the condition of type ConditionTypeDegraded from the
+// resource's conditions
+func RemoveDegraded(
+	subject acktypes.ConditionManager,
+) {
+	allConds := subject.Conditions()
+	var newConds []*ackv1alpha1.Condition
+	if c := Degraded(subject); c != nil {
+		for _, cond := range allConds {
+			if cond.Type != ackv1alpha1.ConditionTypeDegraded {
+				newConds = append(newConds, cond)
+			}
+		}
+		subject.ReplaceConditions(newConds)
+	}
+}
+
 // WithReferencesResolvedCondition returns a new AWSResource with the
 // ConditionTypeReferencesResolved set based on the err parameter
 func WithReferencesResolvedCondition(
@@ -16,6 +16,7 @@ package condition_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +25,7 @@ import (
 	ackcond "github.com/aws-controllers-k8s/runtime/pkg/condition"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
 )
@@ -243,6 +245,164 @@ func TestConditionSetters(t *testing.T) {
 	)
 	ackcond.RemoveReferencesResolved(r)
 
+	// UpdateRolledBack condition
+	// SetUpdateRolledBack
+	r = &ackmocks.AWSResource{}
+	r.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			if len(subject) != 1 {
+				return false
+			}
+			return (subject[0].Type == ackv1alpha1.ConditionTypeUpdateRolledBack &&
+				subject[0].Status == corev1.ConditionFalse &&
+				subject[0].Message == &msg1)
+		}),
+	)
+	ackcond.SetUpdateRolledBack(r, corev1.ConditionFalse, &msg1, nil)
+
+	// SetRegionAccountChanged
+	r = &ackmocks.AWSResource{}
+	r.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			if len(subject) != 1 {
+				return false
+			}
+			return (subject[0].Type == ackv1alpha1.ConditionTypeRegionAccountChanged &&
+				subject[0].Status == corev1.ConditionTrue &&
+				subject[0].Message == &msg1)
+		}),
+	)
+	ackcond.SetRegionAccountChanged(r, corev1.ConditionTrue, &msg1, nil)
+
+	// RemoveUpdateRolledBack
+	r = &ackmocks.AWSResource{}
+	r.On("Conditions").Return(
+		[]*ackv1alpha1.Condition{
+			{
+				Type:   ackv1alpha1.ConditionTypeUpdateRolledBack,
+				Status: corev1.ConditionTrue,
+			},
+			{
+				Type:   ackv1alpha1.ConditionTypeResourceSynced,
+				Status: corev1.ConditionTrue,
+			},
+		},
+	)
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			return len(subject) == 1 && subject[0].Type == ackv1alpha1.ConditionTypeResourceSynced
+		}),
+	)
+	ackcond.RemoveUpdateRolledBack(r)
+
+	// RemoveRegionAccountChanged
+	r = &ackmocks.AWSResource{}
+	r.On("Conditions").Return(
+		[]*ackv1alpha1.Condition{
+			{
+				Type:   ackv1alpha1.ConditionTypeRegionAccountChanged,
+				Status: corev1.ConditionTrue,
+			},
+			{
+				Type:   ackv1alpha1.ConditionTypeResourceSynced,
+				Status: corev1.ConditionFalse,
+			},
+		},
+	)
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			return len(subject) == 1 && subject[0].Type == ackv1alpha1.ConditionTypeResourceSynced
+		}),
+	)
+	ackcond.RemoveRegionAccountChanged(r)
+
+	// SetDegraded
+	r = &ackmocks.AWSResource{}
+	r.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			if len(subject) != 1 {
+				return false
+			}
+			return (subject[0].Type == ackv1alpha1.ConditionTypeDegraded &&
+				subject[0].Status == corev1.ConditionTrue &&
+				subject[0].Message == &msg1 &&
+				subject[0].LastTransitionTime != nil)
+		}),
+	)
+	ackcond.SetDegraded(r, corev1.ConditionTrue, &msg1, nil)
+
+	// RemoveDegraded
+	r = &ackmocks.AWSResource{}
+	r.On("Conditions").Return(
+		[]*ackv1alpha1.Condition{
+			{
+				Type:   ackv1alpha1.ConditionTypeDegraded,
+				Status: corev1.ConditionFalse,
+			},
+			{
+				Type:   ackv1alpha1.ConditionTypeResourceSynced,
+				Status: corev1.ConditionTrue,
+			},
+		},
+	)
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			return len(subject) == 1 && subject[0].Type == ackv1alpha1.ConditionTypeResourceSynced
+		}),
+	)
+	ackcond.RemoveDegraded(r)
+
+	// SetPaused keeps the last transition time of the paused resources
+	r = &ackmocks.AWSResource{}
+	pausedSince := metav1.NewTime(time.Now().Add(-time.Hour))
+	r.On("Conditions").Return([]*ackv1alpha1.Condition{{
+		Type:               ackv1alpha1.ConditionTypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: &pausedSince,
+	}})
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			return len(subject) == 1 &&
+				subject[0].Type == ackv1alpha1.ConditionTypePaused &&
+				subject[0].Status == corev1.ConditionTrue &&
+				subject[0].Reason == &msg1 &&
+				subject[0].LastTransitionTime.Equal(&pausedSince)
+		}),
+	)
+	ackcond.SetPaused(r, &msg1)
+
+	// RemovePaused
+	r = &ackmocks.AWSResource{}
+	r.On("Conditions").Return(
+		[]*ackv1alpha1.Condition{
+			{
+				Type:   ackv1alpha1.ConditionTypePaused,
+				Status: corev1.ConditionTrue,
+			},
+			{
+				Type:   ackv1alpha1.ConditionTypeResourceSynced,
+				Status: corev1.ConditionTrue,
+			},
+		},
+	)
+	r.On(
+		"ReplaceConditions",
+		mock.MatchedBy(func(subject []*ackv1alpha1.Condition) bool {
+			return len(subject) == 1 && subject[0].Type == ackv1alpha1.ConditionTypeResourceSynced
+		}),
+	)
+	ackcond.RemovePaused(r)
+
 	//WithReferencesResolvedCondition
 	// Without Error
 	r = &ackmocks.AWSResource{}
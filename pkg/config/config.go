@@ -17,13 +17,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/jaypipes/envutil"
 	flag "github.com/spf13/pflag"
@@ -31,12 +37,20 @@ import (
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 	ctrlrt "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
+	"github.com/aws-controllers-k8s/runtime/pkg/logschema"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/audit"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/inventory"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/maintenance"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/namespacestatus"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/webhookcert"
 	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
 	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
 )
@@ -46,6 +60,7 @@ const (
 	flagEnableFieldExportReconciler     = "enable-field-export-reconciler"
 	flagEnableLeaderElection            = "enable-leader-election"
 	flagLeaderElectionNamespace         = "leader-election-namespace"
+	flagWarmStandby                     = "warm-standby"
 	flagMetricAddr                      = "metrics-addr"
 	flagHealthzAddr                     = "healthz-addr"
 	flagEnableDevLogging                = "enable-development-logging"
@@ -54,19 +69,102 @@ const (
 	flagAWSIdentityEndpointURL          = "aws-identity-endpoint-url"
 	flagUnsafeAWSEndpointURLs           = "allow-unsafe-aws-endpoint-urls"
 	flagLogLevel                        = "log-level"
+	flagLogFormat                       = "log-format"
 	flagResourceTags                    = "resource-tags"
 	flagWatchNamespace                  = "watch-namespace"
 	flagWatchSelectors                  = "watch-selectors"
 	flagEnableWebhookServer             = "enable-webhook-server"
 	flagWebhookServerAddr               = "webhook-server-addr"
+	flagWebhookCertMode                 = "webhook-cert-mode"
+	flagWebhookCertSecret               = "webhook-cert-secret"
+	flagWebhookService                  = "webhook-service"
+	flagWebhookConfigurations           = "webhook-configurations"
 	flagDeletionPolicy                  = "deletion-policy"
 	flagReconcileDefaultResyncSeconds   = "reconcile-default-resync-seconds"
 	flagReconcileResourceResyncSeconds  = "reconcile-resource-resync-seconds"
 	flagReconcileDefaultMaxConcurrency  = "reconcile-default-max-concurrent-syncs"
 	flagReconcileResourceMaxConcurrency = "reconcile-resource-max-concurrent-syncs"
+	flagDefaultProgressDeadline         = "reconcile-default-progress-deadline-seconds"
+	flagResourceProgressDeadline        = "reconcile-resource-progress-deadline-seconds"
+	flagResourceUpdateDebounce          = "reconcile-resource-update-debounce-seconds"
+	flagResourceCanaryPercent           = "reconcile-resource-canary-percent"
 	flagFeatureGates                    = "feature-gates"
 	flagReconcileResources              = "reconcile-resources"
+	flagClusterID                       = "cluster-id"
+	flagFencingAllowedClusterIDs        = "fencing-allowed-cluster-ids"
+	flagMetricsDropLabels               = "metrics-drop-labels"
+	flagMetricsLabelMaxCardinality      = "metrics-label-max-cardinality"
+	flagMetricsRelabelRules             = "metrics-relabel-rules"
+	flagNamespaceStatus                 = "namespace-status"
+	flagNamespaceStatusIntervalSeconds  = "namespace-status-interval-seconds"
+	flagSmokeTest                       = "smoke-test"
+	flagSmokeTestTemplate               = "smoke-test-template"
+	flagSmokeTestIntervalSeconds        = "smoke-test-interval-seconds"
+	flagSmokeTestTimeoutSeconds         = "smoke-test-timeout-seconds"
+	flagSmokeTestRetention              = "smoke-test-retention-seconds"
+	flagDesiredStateOverlayFile         = "desired-state-overlay-file"
+	flagErrorClassificationFile         = "error-classification-file"
+	flagSelfCheck                       = "self-check"
+	flagAdminBindAddress                = "admin-bind-address"
+	flagAdminTLSCertFile                = "admin-tls-cert-file"
+	flagAdminTLSKeyFile                 = "admin-tls-key-file"
+	flagAdminTokenAudiences             = "admin-token-audiences"
+	flagFinalizerName                   = "finalizer-name"
+	flagMigrateDefaultFinalizer         = "migrate-default-finalizer"
+	flagFinalizerPolicy                 = "finalizer-policy"
+	flagExplainAccessDenied             = "explain-access-denied"
+	flagFingerprintErrors               = "fingerprint-errors"
+	flagGrantedIAMActions               = "granted-iam-actions"
+	flagReconcileResultWebhook          = "reconcile-result-webhook"
+	flagInventoryDestination            = "inventory-destination"
+	flagInventoryFormat                 = "inventory-format"
+	flagInventoryIntervalSeconds        = "inventory-interval-seconds"
+	flagKubeStateMetricsConfigFile      = "kube-state-metrics-config-file"
+	flagStateDumpSignal                 = "state-dump-signal"
+	flagStateDumpDirectory              = "state-dump-directory"
+	flagStateDumpRetention              = "state-dump-retention"
+	flagReconcileExcludeSelector        = "reconcile-exclude-selector"
+	flagResourceExcludeSelector         = "reconcile-resource-exclude-selector"
+	flagResourceSensitiveFields         = "resource-sensitive-fields"
+	flagResyncBudgetPerMinute           = "resync-budget-per-minute"
+	flagResyncMinAgeSeconds             = "resync-min-age-seconds"
+	flagRequeueStormThreshold           = "requeue-storm-threshold"
+	flagRequeueStormCoolDownSeconds     = "requeue-storm-cooldown-seconds"
+	flagCircuitBreakerThreshold         = "circuit-breaker-threshold"
+	flagCircuitBreakerCoolDown          = "circuit-breaker-cooldown-seconds"
+	flagMaxConcurrentDeletions          = "max-concurrent-deletions"
+	flagTracingEndpoint                 = "tracing-endpoint"
+	flagTracingSamplingRatio            = "tracing-sampling-ratio"
+	flagControllerConfig                = "controller-config"
+	flagControllerConfigInterval        = "controller-config-interval-seconds"
+	flagSLOSyncedObjective              = "slo-synced-objective"
+	flagSLOTimeToSyncSeconds            = "slo-time-to-sync-seconds"
+	flagSLOWindowSeconds                = "slo-window-seconds"
+	flagServiceQuotaIntervalSeconds     = "service-quota-interval-seconds"
+	flagServiceQuotaWarningThreshold    = "service-quota-warning-threshold"
+	flagSpecHashAnnotation              = "spec-hash-annotation"
+	flagRecordLastDrift                 = "record-last-drift"
+	flagDryRun                          = "dry-run"
+	flagMaintenanceWindow               = "maintenance-window"
+	flagInvariantsFile                  = "invariants-file"
+	flagPoliciesFile                    = "policies-file"
+	flagAuditLogSink                    = "audit-log-sink"
+	flagFieldEncryptionKMSKeyID         = "field-encryption-kms-key-id"
+	flagAWSRetryMode                    = "aws-retry-mode"
+	flagAWSRetryMaxAttempts             = "aws-retry-max-attempts"
+	flagAWSOperationRetryMaxAttempts    = "aws-operation-retry-max-attempts"
+	flagAWSAPIRateLimit                 = "aws-api-rate-limit"
+	flagAWSAPIRateLimitBurst            = "aws-api-rate-limit-burst"
+	flagHTTPSProxy                      = "https-proxy"
+	flagNoProxy                         = "no-proxy"
+	flagCABundle                        = "ca-bundle"
+	flagAllowedOwnerRoleARNs            = "allowed-owner-role-arns"
 	envVarAWSRegion                     = "AWS_REGION"
+	envVarAWSDefaultRegion              = "AWS_DEFAULT_REGION"
+
+	// instanceMetadataRegionTimeout is the maximum duration of the lookup of
+	// the region in the instance metadata service
+	instanceMetadataRegionTimeout = 5 * time.Second
 )
 
 var (
@@ -82,6 +180,10 @@ var (
 	defaultLogLevel = zapcore.InfoLevel
 )
 
+// LogFormatJSON is the --log-format writing the logs, and the emitted
+// events, as JSON lines of the versioned log schema
+const LogFormatJSON = "json"
+
 // Config contains configuration options for ACK service controllers
 type Config struct {
 	MetricsAddr                     string
@@ -90,27 +192,117 @@ type Config struct {
 	EnableAdoptedResourceReconciler bool
 	EnableFieldExportReconciler     bool
 	LeaderElectionNamespace         string
+	WarmStandby                     bool
 	EnableDevelopmentLogging        bool
 	AccountID                       string
+	// CallerARN is the ARN of the IAM identity of the controller, set with
+	// AccountID
+	CallerARN                       string
 	Region                          string
+	RegionSource                    ackv1alpha1.AWSRegionSource
 	IdentityEndpointURL             string
 	EndpointURL                     string
 	AllowUnsafeEndpointURL          bool
 	LogLevel                        string
+	LogFormat                       string
 	ResourceTags                    []string
 	WatchNamespace                  string
 	WatchSelectors                  string
 	EnableWebhookServer             bool
 	WebhookServerAddr               string
+	WebhookCertMode                 string
+	WebhookCertSecret               string
+	WebhookService                  string
+	WebhookConfigurations           []string
 	DeletionPolicy                  ackv1alpha1.DeletionPolicy
 	ReconcileDefaultResyncSeconds   int
 	ReconcileResourceResyncSeconds  []string
 	ReconcileDefaultMaxConcurrency  int
 	ReconcileResourceMaxConcurrency []string
+	DefaultProgressDeadlineSeconds  int
+	ResourceProgressDeadlineSeconds []string
+	ResourceUpdateDebounceSeconds   []string
+	ResourceCanaryPercent           []string
 	ReconcileResources              string
+	ClusterID                       string
+	FencingAllowedClusterIDs        []string
+	MetricsDropLabels               []string
+	MetricsLabelMaxCardinality      []string
+	MetricsRelabelRules             []string
+	NamespaceStatus                 string
+	NamespaceStatusIntervalSeconds  int
+	SmokeTest                       bool
+	SmokeTestTemplate               string
+	SmokeTestIntervalSeconds        int
+	SmokeTestTimeoutSeconds         int
+	SmokeTestRetentionSeconds       int
+	DesiredStateOverlayFile         string
+	ErrorClassificationFile         string
+	SelfCheck                       bool
+	AdminBindAddress                string
+	AdminTLSCertFile                string
+	AdminTLSKeyFile                 string
+	AdminTokenAudiences             []string
+	FinalizerName                   string
+	MigrateDefaultFinalizer         bool
+	FinalizerPolicy                 ackv1alpha1.FinalizerPolicy
+	ExplainAccessDenied             bool
+	FingerprintErrors               bool
+	GrantedIAMActions               []string
+	ReconcileResultWebhooks         []string
+	InventoryDestination            string
+	InventoryFormat                 string
+	InventoryIntervalSeconds        int
+	KubeStateMetricsConfigFile      string
+	StateDumpSignal                 bool
+	StateDumpDirectory              string
+	StateDumpRetention              int
+	ReconcileExcludeSelector        string
+	ResourceExcludeSelectors        []string
+	ResourceSensitiveFields         []string
+	ResyncBudgetPerMinute           int
+	ResyncMinAgeSeconds             int
+	RequeueStormThreshold           int
+	RequeueStormCoolDownSeconds     int
+	CircuitBreakerThreshold         int
+	CircuitBreakerCoolDownSeconds   int
+	MaxConcurrentDeletions          int
+	TracingEndpoint                 string
+	TracingSamplingRatio            float64
+	ControllerConfig                string
+	ControllerConfigIntervalSeconds int
+	SLOSyncedObjective              float64
+	SLOTimeToSyncSeconds            int
+	SLOWindowSeconds                int
+	ServiceQuotaIntervalSeconds     int
+	ServiceQuotaWarningThreshold    float64
+	SpecHashAnnotation              bool
+	RecordLastDrift                 bool
+	DryRun                          bool
+	MaintenanceWindow               string
+	InvariantsFile                  string
+	PoliciesFile                    string
+	AuditLogSink                    string
+	FieldEncryptionKMSKeyID         string
+	AWSRetryMode                    string
+	AWSRetryMaxAttempts             int
+	AWSOperationRetryMaxAttempts    []string
+	AWSAPIRateLimit                 float64
+	AWSAPIRateLimitBurst            int
+	HTTPSProxy                      string
+	NoProxy                         string
+	CABundle                        string
+	AllowedOwnerRoleARNs            []string
 	// TODO(a-hilaly): migrate to k8s.io/component-base and implement a proper parser for feature gates.
 	FeatureGates    featuregate.FeatureGates
 	featureGatesRaw string
+	// serviceFeatureGates holds the feature gate overrides of each service
+	// controller, keyed by service alias, when several service controllers
+	// are compiled into the same binary
+	serviceFeatureGates map[string]map[string]bool
+	// allowedOwnerRoleARNs holds the patterns of the --allowed-owner-role-arns
+	// flag, keyed by namespace, compiled once by Validate
+	allowedOwnerRoleARNs map[string][]*regexp.Regexp
 }
 
 // BindFlags defines CLI/runtime configuration options
@@ -135,6 +327,34 @@ func (cfg *Config) BindFlags() {
 		"0.0.0.0:9433",
 		"The address the webhook endpoint binds to.",
 	)
+	flag.StringVar(
+		&cfg.WebhookCertMode, flagWebhookCertMode,
+		"",
+		"If set, the controller manages the serving certificate of the webhook server. Valid values are "+
+			"'cert-manager', to annotate the webhook configurations of --"+flagWebhookConfigurations+" for the "+
+			"cert-manager CA injector to inject the CA of the --"+flagWebhookCertSecret+" Secret, mounted in the "+
+			"certificate directory of the webhook server, and 'self-signed', to issue a self-signed CA and serving "+
+			"certificate in the --"+flagWebhookCertSecret+" Secret, rotate them before they expire and set the CA "+
+			"bundle of the webhook configurations. Certificate changes are reloaded without restarting the controller.",
+	)
+	flag.StringVar(
+		&cfg.WebhookCertSecret, flagWebhookCertSecret,
+		"",
+		"The 'namespace/name' of the Secret holding the serving certificate of the webhook server. Required "+
+			"when --"+flagWebhookCertMode+" is set.",
+	)
+	flag.StringVar(
+		&cfg.WebhookService, flagWebhookService,
+		"",
+		"The 'namespace/name' of the Service in front of the webhook server, whose DNS names the self-signed "+
+			"serving certificate is issued for. Required when --"+flagWebhookCertMode+" is 'self-signed'.",
+	)
+	flag.StringSliceVar(
+		&cfg.WebhookConfigurations, flagWebhookConfigurations,
+		[]string{},
+		"A comma-separated list of the names of the mutating and validating webhook configurations whose CA "+
+			"bundle is managed when --"+flagWebhookCertMode+" is set.",
+	)
 	flag.BoolVar(
 		&cfg.EnableLeaderElection, flagEnableLeaderElection,
 		false,
@@ -168,6 +388,14 @@ func (cfg *Config) BindFlags() {
 		"Specific namespace that the controller will utilize to manage the coordination.k8s.io/lease object for leader election."+
 			" By default it will try to use the namespace of the service account mounted to the controller pod.",
 	)
+	flag.BoolVar(
+		&cfg.WarmStandby, flagWarmStandby,
+		false,
+		"Keep the replicas that are not the leader warm: they sync the caches of the reconciled resources "+
+			"and keep the credentials of the IAM roles of the cross account resource management bindings "+
+			"fresh, without reconciling, so that a new leader resumes the reconciliations in seconds. Only "+
+			"useful with --"+flagEnableLeaderElection+".",
+	)
 	flag.BoolVar(
 		&cfg.EnableDevelopmentLogging, flagEnableDevLogging,
 		false,
@@ -203,6 +431,14 @@ func (cfg *Config) BindFlags() {
 		"info",
 		"The log level. The default is info. The options are: debug, info, warn, error, dpanic, panic, fatal",
 	)
+	flag.StringVar(
+		&cfg.LogFormat, flagLogFormat,
+		"",
+		"The format of the logs. If 'json', the logs are written as JSON lines of the versioned log schema, "+
+			"served by the admin service, whose fields are stable across controller versions, and the "+
+			"emitted events are also written as log lines. If empty, the format depends on --"+flagEnableDevLogging+
+			" and may change between releases.",
+	)
 	flag.StringSliceVar(
 		&cfg.ResourceTags, flagResourceTags,
 		defaultResourceTags,
@@ -236,9 +472,39 @@ func (cfg *Config) BindFlags() {
 		&cfg.ReconcileResourceResyncSeconds, flagReconcileResourceResyncSeconds,
 		[]string{},
 		"A Key/Value list of strings representing the reconcile resync configuration for each resource. This"+
-			" configuration maps resource kinds to drift remediation periods in seconds. If provided, "+
+			" configuration maps resource kinds to drift remediation periods in seconds, e.g. 'Bucket=600,Table=3600'. If provided, "+
 			" resource-specific resync periods take precedence over the default period.",
 	)
+	flag.IntVar(
+		&cfg.DefaultProgressDeadlineSeconds, flagDefaultProgressDeadline,
+		0,
+		"The default duration, in seconds, within which custom resources are expected to sync before their "+
+			"ACK.Degraded condition is set, while the controller keeps retrying. This value is used if no "+
+			"resource-specific override has been specified. Default is 0, for no progress deadline.",
+	)
+	flag.StringArrayVar(
+		&cfg.ResourceProgressDeadlineSeconds, flagResourceProgressDeadline,
+		[]string{},
+		"A Key/Value list of strings representing the progress deadline configuration for each resource. This"+
+			" configuration maps resource kinds to progress deadlines in seconds, e.g. 'DBInstance=3600'. If provided, "+
+			" resource-specific progress deadlines take precedence over the default progress deadline.",
+	)
+	flag.StringArrayVar(
+		&cfg.ResourceUpdateDebounceSeconds, flagResourceUpdateDebounce,
+		[]string{},
+		"A Key/Value list of strings representing the update debounce window configuration for each resource."+
+			" This configuration maps resource kinds to windows in seconds, e.g. 'DBInstance=60'. The updates of"+
+			" the AWS resources of these kinds are held for the window once a change is detected, the changes"+
+			" made meanwhile being applied by a single update at the end of the window.",
+	)
+	flag.StringArrayVar(
+		&cfg.ResourceCanaryPercent, flagResourceCanaryPercent,
+		[]string{},
+		"A Key/Value list of strings representing the canary percentage of the resources of each kind."+
+			" This configuration maps resource kinds to percentages, e.g. 'DBInstance=10'. Only this percentage"+
+			" of the resources of these kinds get the new behavior rolled out by an upgrade of the controller,"+
+			" until the percentage is ramped up through the ControllerConfig or this flag.",
+	)
 	flag.IntVar(
 		&cfg.ReconcileDefaultMaxConcurrency, flagReconcileDefaultMaxConcurrency,
 		1,
@@ -249,14 +515,16 @@ func (cfg *Config) BindFlags() {
 		&cfg.ReconcileResourceMaxConcurrency, flagReconcileResourceMaxConcurrency,
 		[]string{},
 		"A Key/Value list of strings representing the reconcile max concurrency configuration for each resource. This"+
-			" configuration maps resource kinds to maximum number of concurrent reconciles. If provided, "+
+			" configuration maps resource kinds to maximum number of concurrent reconciles, e.g. 'Bucket=10,Table=2'. If provided, "+
 			" resource-specific max concurrency takes precedence over the default max concurrency.",
 	)
 	flag.StringVar(
 		&cfg.featureGatesRaw, flagFeatureGates,
 		"",
 		"Feature gates to enable. The format is a comma-separated list of key=value pairs. "+
-			"Valid keys are feature names and valid values are 'true' or 'false'."+
+			"Valid keys are feature names and valid values are 'true' or 'false'. "+
+			"Feature names prefixed with a service alias, e.g. 's3/ReferenceCaching', only apply to "+
+			"the controller of the service when several controllers run in the same binary. "+
 			"Available features: "+strings.Join(featuregate.GetDefaultFeatureGates().GetFeatureNames(), ", "),
 	)
 	flag.StringVar(
@@ -264,6 +532,489 @@ func (cfg *Config) BindFlags() {
 		"",
 		"A comma-separated list of resource kinds to reconcile. If unspecified, all resources will be reconciled.",
 	)
+	flag.StringVar(
+		&cfg.ClusterID, flagClusterID,
+		"",
+		"A unique identifier of the cluster the controller runs in. If set, the controller tags the AWS resources "+
+			"it manages with this identifier and refuses to manage AWS resources tagged with the identifier of "+
+			"another cluster, unless they are annotated with '"+ackv1alpha1.AnnotationTakeOver+"=true'.",
+	)
+	flag.StringSliceVar(
+		&cfg.FencingAllowedClusterIDs, flagFencingAllowedClusterIDs,
+		[]string{},
+		"A comma-separated list of cluster identifiers whose AWS resources the controller is allowed to manage "+
+			"without taking over their ownership. Only used when --"+flagClusterID+" is set.",
+	)
+	flag.StringSliceVar(
+		&cfg.MetricsDropLabels, flagMetricsDropLabels,
+		[]string{},
+		"A comma-separated list of metric label names (e.g. 'namespace,name') whose values are dropped "+
+			"before metrics are recorded. Dropped labels are exported with an empty value.",
+	)
+	flag.StringArrayVar(
+		&cfg.MetricsLabelMaxCardinality, flagMetricsLabelMaxCardinality,
+		[]string{},
+		"A Key/Value list of strings mapping metric label names to the maximum number of distinct values "+
+			"recorded, per metric, for that label. Values seen once the limit is reached are recorded as '"+
+			ackmetrics.OverflowLabelValue+"'.",
+	)
+	flag.StringArrayVar(
+		&cfg.MetricsRelabelRules, flagMetricsRelabelRules,
+		[]string{},
+		"A list of relabel rules, in the form 'label:regex:replacement', applied in order to metric labels "+
+			"before they are recorded. Label values fully matching the regex are replaced with the replacement, "+
+			"which can reference capture groups (e.g. '$1').",
+	)
+	flag.StringVar(
+		&cfg.NamespaceStatus, flagNamespaceStatus,
+		"",
+		"If set, the controller periodically writes, in each namespace, a summary of the numbers of synced and "+
+			"terminal resources per kind. Valid values are 'configmap', to write it in the ack-<service>-status "+
+			"ConfigMap, and 'annotation', to write it in the "+ackv1alpha1.AnnotationPrefix+"<service>-status "+
+			"annotation of the Namespace.",
+	)
+	flag.IntVar(
+		&cfg.NamespaceStatusIntervalSeconds, flagNamespaceStatusIntervalSeconds,
+		60,
+		"The interval, in seconds, at which the namespace status summaries are written. Only used when --"+
+			flagNamespaceStatus+" is set.",
+	)
+	flag.BoolVar(
+		&cfg.SmokeTest, flagSmokeTest,
+		false,
+		"Enable smoke tests. The controller creates a canary resource from the --"+flagSmokeTestTemplate+
+			" manifest, waits for it to be synced and deletes it, at startup and then every --"+
+			flagSmokeTestIntervalSeconds+". Results are recorded in metrics and in the "+
+			"ack-<service>-controller-status ConfigMap.",
+	)
+	flag.StringVar(
+		&cfg.SmokeTestTemplate, flagSmokeTestTemplate,
+		"",
+		"The path to the YAML manifest of the canary resource of the smoke tests.",
+	)
+	flag.IntVar(
+		&cfg.SmokeTestIntervalSeconds, flagSmokeTestIntervalSeconds,
+		0,
+		"The interval, in seconds, between smoke tests. If 0, the smoke test only runs at startup.",
+	)
+	flag.IntVar(
+		&cfg.SmokeTestTimeoutSeconds, flagSmokeTestTimeoutSeconds,
+		300,
+		"The maximum duration, in seconds, the smoke tests wait for the canary resource to be synced and "+
+			"then deleted.",
+	)
+	flag.IntVar(
+		&cfg.SmokeTestRetentionSeconds, flagSmokeTestRetention,
+		3600,
+		"The age, in seconds, after which the canary resources left over by interrupted smoke tests, "+
+			"e.g. when the controller restarted during a smoke test, are deleted before the next smoke "+
+			"test. If 0, leftover canary resources are never deleted.",
+	)
+	flag.StringVar(
+		&cfg.DesiredStateOverlayFile, flagDesiredStateOverlayFile,
+		"",
+		"The path to a break-glass desired state overlay file, mapping '<kind>/<namespace>/<name>' resource "+
+			"keys to JSON merge patches applied on top of the spec of the resources before they are "+
+			"reconciled. The file is read again whenever it changes.",
+	)
+	flag.StringVar(
+		&cfg.ErrorClassificationFile, flagErrorClassificationFile,
+		"",
+		"The path to a YAML file, usually mounted from a ConfigMap, mapping resource kinds, or '*' for all "+
+			"kinds, to the classification (Terminal, Retryable, Requeue or Unknown) of AWS error codes, or to "+
+			"the delay after which the resources failing with them are requeued, e.g. "+
+			"'{Bucket: {DependencyViolation: 2m, InvalidParameterValue: Terminal}}'. The classifications of the file take precedence over "+
+			"the ones of the controller. The file is read again whenever it changes.",
+	)
+	flag.BoolVar(
+		&cfg.SelfCheck, flagSelfCheck,
+		false,
+		"Enable the startup self-check. For each reconciled kind, a defaulted empty resource is copied, "+
+			"round-tripped through its unstructured representation and compared with the original, and "+
+			"any difference, a sign of a mismatch between the generated code and the runtime, is logged.",
+	)
+	flag.StringVar(
+		&cfg.AdminBindAddress, flagAdminBindAddress,
+		"",
+		"The address the admin REST service binds to, e.g. ':8082'. The admin service lists the tracked "+
+			"resources, forces their reconciliation, dumps the caches and the runtime state of the "+
+			"resources and collects support bundles, over TLS, for requests authenticated with Kubernetes bearer "+
+			"tokens and authorized with the RBAC permissions of their users. Disabled if empty.",
+	)
+	flag.StringVar(
+		&cfg.AdminTLSCertFile, flagAdminTLSCertFile,
+		"",
+		"The path of the PEM encoded serving certificate of the admin service. Required when --"+
+			flagAdminBindAddress+" is set.",
+	)
+	flag.StringVar(
+		&cfg.AdminTLSKeyFile, flagAdminTLSKeyFile,
+		"",
+		"The path of the PEM encoded private key of the serving certificate of the admin service. "+
+			"Required when --"+flagAdminBindAddress+" is set.",
+	)
+	flag.StringSliceVar(
+		&cfg.AdminTokenAudiences, flagAdminTokenAudiences,
+		[]string{"ack-admin"},
+		"A comma-separated list of the audiences of the bearer tokens accepted by the admin service, e.g. "+
+			"the ones of 'kubectl create token --audience ack-admin'. The tokens of the API server audience, "+
+			"like the ones mounted in pods, are rejected unless it is listed.",
+	)
+	flag.StringVar(
+		&cfg.FinalizerName, flagFinalizerName,
+		"",
+		"The finalizer, e.g. 'team-a.services.k8s.aws/finalizer', marking the resources managed by "+
+			"this installation of the controller. Lets several installations reconciling the same kinds "+
+			"coexist. Defaults to the finalizer of each kind.",
+	)
+	flag.BoolVar(
+		&cfg.MigrateDefaultFinalizer, flagMigrateDefaultFinalizer,
+		false,
+		"Manage the resources holding the default finalizer of their kind, replacing it with the --"+
+			flagFinalizerName+" finalizer. Used to migrate the resources of an installation to a custom "+
+			"finalizer.",
+	)
+	flag.Var(
+		&cfg.FinalizerPolicy, flagFinalizerPolicy,
+		"The default finalizer policy for all resources managed by the controller: 'before-create' "+
+			"adds the finalizer before creating the AWS resource, 'after-create' once the AWS resource "+
+			"is created. Defaults to 'before-create'.",
+	)
+	flag.BoolVar(
+		&cfg.ExplainAccessDenied, flagExplainAccessDenied,
+		false,
+		"Emit an event with the denied action, the resource ARN, with account IDs redacted, and the "+
+			"authorization message decoded with STS DecodeAuthorizationMessage when an AWS service API "+
+			"denies a call. Decoding requires the sts:DecodeAuthorizationMessage permission.",
+	)
+	flag.BoolVar(
+		&cfg.FingerprintErrors, flagFingerprintErrors,
+		false,
+		"Fingerprint the errors of the resources, recording them in the "+
+			"services.k8s.aws/error-fingerprint annotation, and only emit the Warning events and "+
+			"update the conditions of a resource when its error changes.",
+	)
+	flag.StringSliceVar(
+		&cfg.GrantedIAMActions, flagGrantedIAMActions,
+		[]string{},
+		"A comma-separated list of the IAM actions (e.g. 's3:CreateBucket,s3:Get*') granted to the controller. "+
+			"If set, the operations requiring actions that are not granted, as declared by the resource "+
+			"managers, are skipped and reported instead of failing with an access denied error.",
+	)
+	flag.StringArrayVar(
+		&cfg.ReconcileResultWebhooks, flagReconcileResultWebhook,
+		[]string{},
+		"A Key/Value list of strings mapping resource kinds to the URL of a webhook receiving, with a POST "+
+			"request, a JSON document describing the result of each reconciliation of the resources of "+
+			"that kind (e.g. 'Bucket=https://cmdb.example.com/ack').",
+	)
+	flag.StringVar(
+		&cfg.InventoryDestination, flagInventoryDestination,
+		"",
+		"If set, the controller periodically exports an inventory of the resources it manages (kind, "+
+			"identifiers, account, region, tags and sync state) to this destination: an absolute file path, "+
+			"an http(s) URL receiving the inventory with a PUT request, or an s3://bucket/key URL.",
+	)
+	flag.StringVar(
+		&cfg.InventoryFormat, flagInventoryFormat,
+		"json",
+		"The format of the exported inventory, either 'json' or 'jsonl'. Only used when --"+
+			flagInventoryDestination+" is set.",
+	)
+	flag.IntVar(
+		&cfg.InventoryIntervalSeconds, flagInventoryIntervalSeconds,
+		3600,
+		"The interval, in seconds, at which the inventory is exported. Only used when --"+
+			flagInventoryDestination+" is set.",
+	)
+	flag.StringVar(
+		&cfg.KubeStateMetricsConfigFile, flagKubeStateMetricsConfigFile,
+		"",
+		"If set, the controller writes to this file, when it starts, the kube-state-metrics "+
+			"CustomResourceState configuration exporting the conditions and the AWS identifiers of the "+
+			"resources it manages.",
+	)
+	flag.BoolVar(
+		&cfg.StateDumpSignal, flagStateDumpSignal,
+		false,
+		"If true, the controller dumps its internal state, e.g. the contents of its work queues, "+
+			"its in-flight reconciliations and its backoffs, when it receives the SIGUSR1 signal.",
+	)
+	flag.StringVar(
+		&cfg.StateDumpDirectory, flagStateDumpDirectory,
+		"",
+		"The directory the controller writes its state dumps to. If empty, the state is dumped "+
+			"to the logs.",
+	)
+	flag.IntVar(
+		&cfg.StateDumpRetention, flagStateDumpRetention,
+		10,
+		"The number of the most recent state dumps kept in the --"+flagStateDumpDirectory+
+			" directory. Older dumps are pruned at startup and after each dump. If 0, all the dumps are kept.",
+	)
+	flag.StringVar(
+		&cfg.ReconcileExcludeSelector, flagReconcileExcludeSelector,
+		"",
+		"A label selector (e.g. 'ack.example.com/owner=legacy') matching the resources the controller "+
+			"entirely ignores: it neither adds finalizers nor sets conditions on them. Useful when another "+
+			"instance of the controller owns these resources, e.g. during staged migrations.",
+	)
+	flag.StringArrayVar(
+		&cfg.ResourceExcludeSelectors, flagResourceExcludeSelector,
+		[]string{},
+		"A Key/Value list of strings mapping resource kinds to a label selector matching the resources "+
+			"of that kind the controller entirely ignores, in addition to the ones matching the "+
+			"--reconcile-exclude-selector flag (e.g. 'Bucket=ack.example.com/owner in (legacy,canary)').",
+	)
+	flag.StringArrayVar(
+		&cfg.ResourceSensitiveFields, flagResourceSensitiveFields,
+		[]string{},
+		"A Key/Value list of strings mapping resource kinds to the path of a field, in the JSON "+
+			"representation of the resources, whose values are redacted from the logs, events, plans and "+
+			"support bundles of the controller, in addition to the sensitive fields declared by the "+
+			"controller (e.g. 'Function=spec.environment.variables').",
+	)
+	flag.IntVar(
+		&cfg.ResyncBudgetPerMinute, flagResyncBudgetPerMinute,
+		0,
+		"The maximum number of periodic drift checks, per kind and per minute. Resources exceeding the "+
+			"budget are checked in later minutes, the ones checked the longest time ago first. Drift checks "+
+			"of resources whose spec changed are not limited. Disabled if 0.",
+	)
+	flag.IntVar(
+		&cfg.ResyncMinAgeSeconds, flagResyncMinAgeSeconds,
+		0,
+		"The minimum age, in seconds, of the last drift check of a resource for it to be checked again "+
+			"when its spec did not change. Only used when --"+flagResyncBudgetPerMinute+" is set. Defaults "+
+			"to the resync period of the kind.",
+	)
+	flag.IntVar(
+		&cfg.RequeueStormThreshold, flagRequeueStormThreshold,
+		30,
+		"The number of times a resource can be reconciled in a minute without its state changing before "+
+			"its reconciliations are suspended for the cool-down period of --"+flagRequeueStormCoolDownSeconds+
+			". Disabled if 0.",
+	)
+	flag.IntVar(
+		&cfg.RequeueStormCoolDownSeconds, flagRequeueStormCoolDownSeconds,
+		300,
+		"The duration, in seconds, for which the reconciliations of a resource in a requeue storm are "+
+			"suspended, unless its state changes.",
+	)
+	flag.IntVar(
+		&cfg.CircuitBreakerThreshold, flagCircuitBreakerThreshold,
+		20,
+		"The number of consecutive calls to the API of the AWS service in an AWS account and region "+
+			"throttled or failing with a 5XX response, retries included, after which the calls are "+
+			"stopped and the reconciliations of the resources of the account and region are paused for "+
+			"the cool-down period of --"+flagCircuitBreakerCoolDown+". Disabled if 0.",
+	)
+	flag.IntVar(
+		&cfg.CircuitBreakerCoolDownSeconds, flagCircuitBreakerCoolDown,
+		60,
+		"The duration, in seconds, for which the calls to an AWS service API persistently failing are "+
+			"stopped.",
+	)
+	flag.IntVar(
+		&cfg.MaxConcurrentDeletions, flagMaxConcurrentDeletions,
+		0,
+		"The maximum number of AWS resources deleted at once by the service controllers, e.g. while the "+
+			"ACK resources of a namespace are torn down. Deletions beyond it wait for a deletion slot. "+
+			"Unbounded if 0.",
+	)
+	flag.StringVar(
+		&cfg.TracingEndpoint, flagTracingEndpoint,
+		"",
+		"The URL of the OTLP/HTTP endpoint receiving the OpenTelemetry traces of the reconciliations and "+
+			"of the calls to the AWS service APIs, e.g. 'http://otel-collector:4318'. Tracing is disabled "+
+			"if empty.",
+	)
+	flag.Float64Var(
+		&cfg.TracingSamplingRatio, flagTracingSamplingRatio,
+		1,
+		"The ratio, between 0 and 1, of the reconciliations traced when --"+flagTracingEndpoint+" is set.",
+	)
+	flag.StringVar(
+		&cfg.ControllerConfig, flagControllerConfig,
+		"",
+		"The name of the ControllerConfig, in the ACK system namespace, whose spec tunes the "+
+			"concurrency, drift detection interval and pause of the reconcilers while the controller "+
+			"runs. If empty, only the flags of the controller apply.",
+	)
+	flag.IntVar(
+		&cfg.ControllerConfigIntervalSeconds, flagControllerConfigInterval,
+		30,
+		"The interval, in seconds, at which the ControllerConfig is read. Only used when --"+
+			flagControllerConfig+" is set.",
+	)
+	flag.Float64Var(
+		&cfg.SLOSyncedObjective, flagSLOSyncedObjective,
+		0,
+		"The service level objective, in percent (e.g. 99.5), of the resources of each kind that are "+
+			"synced. The controller exposes the burn rate of its error budget as metrics and, when --"+
+			flagControllerConfig+" is set, as the ACK.SyncedObjectiveMet condition of the ControllerConfig. "+
+			"If 0, the objective is disabled.",
+	)
+	flag.IntVar(
+		&cfg.SLOTimeToSyncSeconds, flagSLOTimeToSyncSeconds,
+		0,
+		"The service level objective, in seconds, of the 99th percentile of the time it takes the "+
+			"resources of each kind to sync after their spec changed. The controller exposes the burn rate "+
+			"of its error budget as metrics and, when --"+flagControllerConfig+" is set, as the "+
+			"ACK.TimeToSyncObjectiveMet condition of the ControllerConfig. If 0, the objective is disabled.",
+	)
+	flag.IntVar(
+		&cfg.SLOWindowSeconds, flagSLOWindowSeconds,
+		3600,
+		"The window, in seconds, over which the time it takes the resources to sync is measured.",
+	)
+	flag.IntVar(
+		&cfg.ServiceQuotaIntervalSeconds, flagServiceQuotaIntervalSeconds,
+		0,
+		"The interval, in seconds, at which the usage of the AWS service quotas declared by the kinds of "+
+			"the controller is read from Service Quotas and exported as metrics. If 0, the usage of the "+
+			"quotas is not read.",
+	)
+	flag.Float64Var(
+		&cfg.ServiceQuotaWarningThreshold, flagServiceQuotaWarningThreshold,
+		80,
+		"The usage, in percent of a service quota, from which the quota is reported as nearly exhausted "+
+			"by the ACK.ServiceQuotasAvailable condition of the ControllerConfig, when --"+flagControllerConfig+
+			" is set.",
+	)
+	flag.BoolVar(
+		&cfg.SpecHashAnnotation, flagSpecHashAnnotation,
+		false,
+		"Record the hash of the spec of the resources last synced, once defaulted and with their "+
+			"references resolved, in the services.k8s.aws/spec-hash annotation.",
+	)
+	flag.BoolVar(
+		&cfg.RecordLastDrift, flagRecordLastDrift,
+		false,
+		"Record the last differences detected between the desired and the latest observed state of the "+
+			"resources in their status.ackResourceMetadata.lastDrift field, the values of their sensitive "+
+			"fields elided.",
+	)
+	flag.BoolVar(
+		&cfg.DryRun, flagDryRun,
+		false,
+		"Only plan the reconciliations of all the resources, as if they were annotated with "+
+			"services.k8s.aws/plan set to true: the controller reads the AWS resources and records what it "+
+			"would create, update or delete in events, plan ConfigMaps and the "+
+			"status.ackResourceMetadata.lastPlan field of the resources, but never changes the AWS "+
+			"resources. The finalizers of the deleted resources are not removed: they stay until the controller "+
+			"runs without --dry-run, which deletes their AWS resources.",
+	)
+	flag.StringVar(
+		&cfg.MaintenanceWindow, flagMaintenanceWindow,
+		"",
+		"The maintenance window outside of which the controller only reads the AWS resources, deferring "+
+			"their creation, update and deletion until the window opens, as \"<schedule> <duration>\": a "+
+			"standard cron expression of the times the window opens, optionally prefixed with "+
+			"CRON_TZ=<time zone>, and the Go duration for which it stays open, e.g. \"0 2 * * SAT 4h\". The "+
+			"services.k8s.aws/maintenance-window annotation of a namespace overrides it for the resources "+
+			"of the namespace. If empty, the resources are changed at any time.",
+	)
+	flag.StringVar(
+		&cfg.InvariantsFile, flagInvariantsFile,
+		"",
+		"The path to a YAML file, usually mounted from a ConfigMap, defining invariants spanning several "+
+			"resources as CEL rules, e.g. the total provisioned IOPS of the DB instances of a namespace. The "+
+			"invariants of the kind of a resource are checked against the cache before its AWS resource is "+
+			"created or updated, and the resources violating them get the ACK.PolicyViolation condition. The "+
+			"file is read again whenever it changes.",
+	)
+	flag.StringVar(
+		&cfg.PoliciesFile, flagPoliciesFile,
+		"",
+		"The path to a YAML file, usually mounted from a ConfigMap, defining policies as CEL rules "+
+			"evaluated against the desired state of a resource and its differences with the latest observed "+
+			"state, e.g. no public S3 buckets. The policies of the kind of a resource are evaluated before its "+
+			"AWS resource is created, updated or deleted, and the resources they deny get the ACK.Blocked or "+
+			"ACK.Terminal condition, with the failing rule. The file is read again whenever it changes.",
+	)
+	flag.StringVar(
+		&cfg.AuditLogSink, flagAuditLogSink,
+		"",
+		"Where the audit records of the creations, updates and deletions of the AWS resources are written, "+
+			"as JSON documents identifying the resource, its account and region, the calling IAM identity, "+
+			"the IDs of the AWS requests and the changes made: 'stdout', the absolute path of a file, only "+
+			"appended to, or the HTTP(S) URL of a webhook. If empty, the changes are not audited.",
+	)
+	flag.StringVar(
+		&cfg.FieldEncryptionKMSKeyID, flagFieldEncryptionKMSKeyID,
+		"",
+		"The ID, ARN or alias of the KMS key encrypting the sensitive fields of the resources at rest. "+
+			"Requires the webhook server, whose mutating webhook encrypts the fields declared by the "+
+			"resource managers on every write. The fields are decrypted before they are sent to AWS. "+
+			"Disabled if empty.",
+	)
+	flag.StringVar(
+		&cfg.AWSRetryMode, flagAWSRetryMode,
+		"",
+		"The retry mode of the AWS SDK clients, 'standard' or 'adaptive'. The adaptive mode also "+
+			"rate limits the requests sent to AWS once they are throttled. Defaults to the retry mode "+
+			"of the shared AWS configuration, or 'standard'. The services.k8s.aws/aws-retry-mode annotation "+
+			"of a namespace overrides it for the resources of the namespace.",
+	)
+	flag.IntVar(
+		&cfg.AWSRetryMaxAttempts, flagAWSRetryMaxAttempts,
+		0,
+		"The maximum number of attempts of each call of the AWS SDK clients, the first one included. "+
+			"Defaults to the maximum number of attempts of the shared AWS configuration, or 3. The "+
+			"services.k8s.aws/aws-retry-max-attempts annotation of a namespace overrides it for the "+
+			"resources of the namespace.",
+	)
+	flag.StringArrayVar(
+		&cfg.AWSOperationRetryMaxAttempts, flagAWSOperationRetryMaxAttempts,
+		[]string{},
+		"A Key/Value list of strings mapping AWS API operation names, e.g. 'CreateDBInstance', to the "+
+			"maximum number of attempts of their calls. If provided, operation-specific maximum numbers "+
+			"of attempts take precedence over the --"+flagAWSRetryMaxAttempts+" flag.",
+	)
+	flag.Float64Var(
+		&cfg.AWSAPIRateLimit, flagAWSAPIRateLimit,
+		0,
+		"The maximum number of calls per second of the controller to the API of each AWS service, "+
+			"per AWS account and region, retries included. Calls beyond it wait, protecting the API "+
+			"quotas of the accounts shared with other workloads. Disabled if 0.",
+	)
+	flag.IntVar(
+		&cfg.AWSAPIRateLimitBurst, flagAWSAPIRateLimitBurst,
+		0,
+		"The maximum number of calls of the controller to the API of each AWS service, per AWS "+
+			"account and region, sent at once before --"+flagAWSAPIRateLimit+" applies. Defaults to "+
+			"the rate limit, rounded up.",
+	)
+	flag.StringVar(
+		&cfg.HTTPSProxy, flagHTTPSProxy,
+		"",
+		"The URL of the proxy the requests sent to AWS, including the STS requests assuming the IAM "+
+			"roles of cross account resource management, go through, e.g. 'http://proxy.corp:3128'. "+
+			"Defaults to the HTTPS_PROXY environment variable.",
+	)
+	flag.StringVar(
+		&cfg.NoProxy, flagNoProxy,
+		"",
+		"A comma-separated list of the hosts, domains, IP addresses and CIDR blocks the requests sent "+
+			"to AWS reach without going through the proxy, e.g. '.internal,10.0.0.0/8'. Defaults to the "+
+			"NO_PROXY environment variable.",
+	)
+	flag.StringVar(
+		&cfg.CABundle, flagCABundle,
+		"",
+		"The path of a file of PEM encoded CA certificates trusted, in addition to the system ones, "+
+			"by the HTTP clients sending requests to AWS, e.g. the certificate of a TLS-intercepting proxy.",
+	)
+	flag.StringSliceVar(
+		&cfg.AllowedOwnerRoleARNs, flagAllowedOwnerRoleARNs,
+		[]string{},
+		"A comma-separated list of the IAM roles the resources of each namespace can be reconciled with, "+
+			"using their '"+ackv1alpha1.AnnotationOwnerRoleARN+"' annotation, instead of the role of their "+
+			"namespace, as 'namespace=role' entries. Roles can contain the '*' wildcard, e.g. "+
+			"'tenant-a=arn:aws:iam::111122223333:role/tenant-a-*', and are only allowed to the resources of "+
+			"their namespace. The annotation is ignored if empty.",
+	)
 }
 
 // SetupLogger initializes the logger used in the service controller
@@ -276,7 +1027,13 @@ func (cfg *Config) SetupLogger() {
 		Level:       lvl,
 		TimeEncoder: zapcore.ISO8601TimeEncoder,
 	}
+	if cfg.LogFormat == LogFormatJSON {
+		zapOptions.Encoder = zapcore.NewJSONEncoder(logschema.EncoderConfig())
+	}
 	logger := zap.New(zap.UseFlagOptions(&zapOptions))
+	if cfg.LogFormat == LogFormatJSON {
+		logger = logger.WithValues(logschema.KeySchema, logschema.Version)
+	}
 	ctrlrt.SetLogger(logger)
 	klog.SetLogger(logger)
 }
@@ -284,10 +1041,15 @@ func (cfg *Config) SetupLogger() {
 // SetAWSAccountID uses sts GetCallerIdentity API to find AWS AccountId and set
 // in Config
 func (cfg *Config) SetAWSAccountID(ctx context.Context) error {
-	awsCfg, err := config.LoadDefaultConfig(
-		ctx,
-		config.WithRegion(cfg.Region),
-	)
+	options := []func(*config.LoadOptions) error{config.WithRegion(cfg.Region)}
+	transport, err := cfg.HTTPTransport()
+	if err != nil {
+		return err
+	}
+	if transport != nil {
+		options = append(options, config.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	awsCfg, err := config.LoadDefaultConfig(ctx, options...)
 	if err != nil {
 		return fmt.Errorf("unable to create awsCfg for SetAccountID: %v", err)
 	}
@@ -302,6 +1064,7 @@ func (cfg *Config) SetAWSAccountID(ctx context.Context) error {
 		return fmt.Errorf("unable to get caller identity: %v", err)
 	}
 	cfg.AccountID = *res.Account
+	cfg.CallerARN = aws.ToString(res.Arn)
 	return nil
 }
 
@@ -315,8 +1078,28 @@ func (cfg *Config) Validate(ctx context.Context, options ...Option) error {
 		}
 	}
 
-	if cfg.Region == "" {
-		return errors.New("unable to start service controller as AWS region is missing. Please pass --aws-region flag or set AWS_REGION environment variable")
+	if err := cfg.resolveRegion(ctx); err != nil {
+		return err
+	}
+
+	if cfg.AuditLogSink != "" {
+		if err := audit.ValidateSink(cfg.AuditLogSink); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagAuditLogSink, err)
+		}
+	}
+
+	if err := cfg.validateAdmin(); err != nil {
+		return err
+	}
+
+	allowedOwnerRoleARNs, err := cfg.getAllowedOwnerRoleARNs()
+	if err != nil {
+		return fmt.Errorf("invalid value for flag '%s': %v", flagAllowedOwnerRoleARNs, err)
+	}
+	cfg.allowedOwnerRoleARNs = allowedOwnerRoleARNs
+
+	if cfg.LogFormat != "" && cfg.LogFormat != LogFormatJSON {
+		return fmt.Errorf("invalid value for flag '%s': expected '%s' or empty, got %q", flagLogFormat, LogFormatJSON, cfg.LogFormat)
 	}
 
 	if cfg.EndpointURL != "" {
@@ -345,6 +1128,10 @@ func (cfg *Config) Validate(ctx context.Context, options ...Option) error {
 		}
 	}
 
+	if _, err := cfg.HTTPTransport(); err != nil {
+		return err
+	}
+
 	if err := cfg.SetAWSAccountID(ctx); err != nil {
 		return fmt.Errorf("unable to determine account ID: %v", err)
 	}
@@ -353,9 +1140,24 @@ func (cfg *Config) Validate(ctx context.Context, options ...Option) error {
 		return errors.New("empty webhook server address")
 	}
 
+	if err := cfg.validateWebhookCert(); err != nil {
+		return err
+	}
+
+	if err := cfg.validateAWSRetry(); err != nil {
+		return err
+	}
+
+	if cfg.FieldEncryptionKMSKeyID != "" && !cfg.EnableWebhookServer {
+		return fmt.Errorf("invalid value for flag '%s': field encryption requires --%s", flagFieldEncryptionKMSKeyID, flagEnableWebhookServer)
+	}
+
 	if cfg.DeletionPolicy == "" {
 		cfg.DeletionPolicy = ackv1alpha1.DeletionPolicyDelete
 	}
+	if cfg.FinalizerPolicy == "" {
+		cfg.FinalizerPolicy = ackv1alpha1.FinalizerPolicyBeforeCreate
+	}
 
 	if cfg.ReconcileDefaultResyncSeconds < 0 {
 		return fmt.Errorf("invalid value for flag '%s': resync seconds default must be greater than 0", flagReconcileDefaultResyncSeconds)
@@ -363,19 +1165,227 @@ func (cfg *Config) Validate(ctx context.Context, options ...Option) error {
 	if cfg.ReconcileDefaultMaxConcurrency < 1 {
 		return fmt.Errorf("invalid value for flag '%s': max concurrency default must be greater than 0", flagReconcileDefaultMaxConcurrency)
 	}
+	if cfg.DefaultProgressDeadlineSeconds < 0 {
+		return fmt.Errorf("invalid value for flag '%s': progress deadline default must not be negative", flagDefaultProgressDeadline)
+	}
 
 	featureGatesMap, err := parseFeatureGates(cfg.featureGatesRaw)
 	if err != nil {
 		return fmt.Errorf("invalid value for flag '%s': %v", flagFeatureGates, err)
 	}
+	featureGatesMap, cfg.serviceFeatureGates = splitServiceFeatureGates(featureGatesMap)
 	cfg.FeatureGates, err = featuregate.GetFeatureGatesWithOverrides(featureGatesMap)
 	if err != nil {
 		return fmt.Errorf("error overriding feature gates: %v", err)
 	}
+	for alias, overrides := range cfg.serviceFeatureGates {
+		if _, err := featuregate.GetFeatureGatesWithOverrides(overrides); err != nil {
+			return fmt.Errorf("error overriding feature gates of service %s: %v", alias, err)
+		}
+	}
+
+	if _, err := cfg.MetricsRelabelConfig(); err != nil {
+		return err
+	}
+
+	if _, err := namespacestatus.ParseMode(cfg.NamespaceStatus); err != nil {
+		return fmt.Errorf("invalid value for flag '%s': %v", flagNamespaceStatus, err)
+	}
+	if cfg.SmokeTest {
+		if cfg.SmokeTestTemplate == "" {
+			return fmt.Errorf("invalid value for flag '%s': a template is required to run smoke tests", flagSmokeTestTemplate)
+		}
+		if cfg.SmokeTestIntervalSeconds < 0 {
+			return fmt.Errorf("invalid value for flag '%s': interval must not be negative", flagSmokeTestIntervalSeconds)
+		}
+		if cfg.SmokeTestTimeoutSeconds < 1 {
+			return fmt.Errorf("invalid value for flag '%s': timeout must be greater than 0", flagSmokeTestTimeoutSeconds)
+		}
+		if cfg.SmokeTestRetentionSeconds < 0 {
+			return fmt.Errorf("invalid value for flag '%s': retention must not be negative", flagSmokeTestRetention)
+		}
+	}
+
+	if cfg.FinalizerName != "" {
+		if !strings.Contains(cfg.FinalizerName, "/") {
+			return fmt.Errorf("invalid value for flag '%s': finalizer must be domain-qualified, e.g. 'example.com/finalizer'", flagFinalizerName)
+		}
+		if errs := k8svalidation.IsQualifiedName(cfg.FinalizerName); len(errs) > 0 {
+			return fmt.Errorf("invalid value for flag '%s': %s", flagFinalizerName, strings.Join(errs, ", "))
+		}
+	} else if cfg.MigrateDefaultFinalizer {
+		return fmt.Errorf("invalid value for flag '%s': migrating from the default finalizer requires --%s", flagMigrateDefaultFinalizer, flagFinalizerName)
+	}
+
+	if cfg.ResyncBudgetPerMinute < 0 {
+		return fmt.Errorf("invalid value for flag '%s': budget must not be negative", flagResyncBudgetPerMinute)
+	}
+	if cfg.ResyncMinAgeSeconds < 0 {
+		return fmt.Errorf("invalid value for flag '%s': minimum age must not be negative", flagResyncMinAgeSeconds)
+	}
+	if cfg.RequeueStormThreshold < 0 {
+		return fmt.Errorf("invalid value for flag '%s': threshold must not be negative", flagRequeueStormThreshold)
+	}
+	if cfg.RequeueStormThreshold > 0 && cfg.RequeueStormCoolDownSeconds <= 0 {
+		return fmt.Errorf("invalid value for flag '%s': cool-down must be greater than 0", flagRequeueStormCoolDownSeconds)
+	}
+	if cfg.CircuitBreakerThreshold < 0 {
+		return fmt.Errorf("invalid value for flag '%s': threshold must not be negative", flagCircuitBreakerThreshold)
+	}
+	if cfg.CircuitBreakerThreshold > 0 && cfg.CircuitBreakerCoolDownSeconds <= 0 {
+		return fmt.Errorf("invalid value for flag '%s': cool-down must be greater than 0", flagCircuitBreakerCoolDown)
+	}
+	if cfg.MaxConcurrentDeletions < 0 {
+		return fmt.Errorf("invalid value for flag '%s': value must not be negative", flagMaxConcurrentDeletions)
+	}
+	if err := cfg.validateTracing(); err != nil {
+		return err
+	}
+
+	for _, action := range cfg.GrantedIAMActions {
+		if action == "*" {
+			continue
+		}
+		if service, name, ok := strings.Cut(action, ":"); !ok || service == "" || name == "" {
+			return fmt.Errorf("invalid value for flag '%s': expected 'service:action', got %q", flagGrantedIAMActions, action)
+		}
+	}
+
+	if cfg.InventoryDestination != "" {
+		if _, err := inventory.ParseFormat(cfg.InventoryFormat); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagInventoryFormat, err)
+		}
+		if cfg.InventoryIntervalSeconds < 1 {
+			return fmt.Errorf("invalid value for flag '%s': interval must be greater than 0", flagInventoryIntervalSeconds)
+		}
+	}
+
+	if _, err := labels.Parse(cfg.ReconcileExcludeSelector); err != nil {
+		return fmt.Errorf("invalid value for flag '%s': %v", flagReconcileExcludeSelector, err)
+	}
+
+	if cfg.StateDumpDirectory != "" && !filepath.IsAbs(cfg.StateDumpDirectory) {
+		return fmt.Errorf("invalid value for flag '%s': the directory must be an absolute path", flagStateDumpDirectory)
+	}
+	if cfg.StateDumpRetention < 0 {
+		return fmt.Errorf("invalid value for flag '%s': retention must not be negative", flagStateDumpRetention)
+	}
+
+	if cfg.ControllerConfig != "" && cfg.ControllerConfigIntervalSeconds < 1 {
+		return fmt.Errorf("invalid value for flag '%s': interval must be greater than 0", flagControllerConfigInterval)
+	}
+
+	if cfg.SLOSyncedObjective < 0 || cfg.SLOSyncedObjective >= 100 {
+		return fmt.Errorf("invalid value for flag '%s': objective must be between 0 and 100", flagSLOSyncedObjective)
+	}
+	if cfg.SLOTimeToSyncSeconds < 0 {
+		return fmt.Errorf("invalid value for flag '%s': objective must not be negative", flagSLOTimeToSyncSeconds)
+	}
+	if (cfg.SLOSyncedObjective > 0 || cfg.SLOTimeToSyncSeconds > 0) && cfg.SLOWindowSeconds < 1 {
+		return fmt.Errorf("invalid value for flag '%s': window must be greater than 0", flagSLOWindowSeconds)
+	}
+
+	if cfg.ServiceQuotaIntervalSeconds < 0 {
+		return fmt.Errorf("invalid value for flag '%s': interval must not be negative", flagServiceQuotaIntervalSeconds)
+	}
+	if cfg.ServiceQuotaWarningThreshold <= 0 || cfg.ServiceQuotaWarningThreshold > 100 {
+		return fmt.Errorf("invalid value for flag '%s': threshold must be greater than 0 and at most 100", flagServiceQuotaWarningThreshold)
+	}
+
+	if cfg.NamespaceStatus != "" && cfg.NamespaceStatusIntervalSeconds < 1 {
+		return fmt.Errorf("invalid value for flag '%s': interval must be greater than 0", flagNamespaceStatusIntervalSeconds)
+	}
+
+	if cfg.MaintenanceWindow != "" {
+		if _, err := maintenance.Parse(cfg.MaintenanceWindow); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagMaintenanceWindow, err)
+		}
+	}
 
 	return nil
 }
 
+// MetricsRelabelConfig parses the --metrics-drop-labels,
+// --metrics-label-max-cardinality and --metrics-relabel-rules flags and
+// returns the configuration of the label rewriting applied to metrics.
+func (cfg *Config) MetricsRelabelConfig() (ackmetrics.RelabelConfig, error) {
+	relabelCfg := ackmetrics.RelabelConfig{
+		DropLabels:     cfg.MetricsDropLabels,
+		MaxCardinality: make(map[string]int, len(cfg.MetricsLabelMaxCardinality)),
+	}
+	for _, flagArgument := range cfg.MetricsLabelMaxCardinality {
+		label, max, err := parseReconcileFlagArgument(flagArgument)
+		if err != nil {
+			return relabelCfg, fmt.Errorf(
+				"invalid value for flag '%s': error parsing flag argument '%v': %v. Expected format: string=number",
+				flagMetricsLabelMaxCardinality, flagArgument, err,
+			)
+		}
+		relabelCfg.MaxCardinality[label] = max
+	}
+	for _, flagArgument := range cfg.MetricsRelabelRules {
+		rule, err := ackmetrics.ParseRelabelRule(flagArgument)
+		if err != nil {
+			return relabelCfg, fmt.Errorf("invalid value for flag '%s': %v", flagMetricsRelabelRules, err)
+		}
+		relabelCfg.Rules = append(relabelCfg.Rules, rule)
+	}
+	return relabelCfg, nil
+}
+
+// getInstanceMetadataRegion returns the region of the EC2 instance (e.g. the
+// EKS node) the controller runs on, from the instance metadata service.
+var getInstanceMetadataRegion = func(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, instanceMetadataRegionTimeout)
+	defer cancel()
+	out, err := imds.New(imds.Options{}).GetRegion(ctx, &imds.GetRegionInput{})
+	if err != nil {
+		return "", err
+	}
+	return out.Region, nil
+}
+
+// resolveRegion sets the default region of the controller, and where it was
+// resolved from. The region is resolved from, in order of precedence:
+//   - The --aws-region flag
+//   - The AWS_REGION environment variable
+//   - The AWS_DEFAULT_REGION environment variable
+//   - The instance metadata service (IMDS) of the node the controller runs on
+//
+// There is no STS step: STS GetCallerIdentity returns the account and the
+// ARN of the caller, but not a region, and calling it requires a region to
+// pick its endpoint in the first place. Controllers running outside of EC2,
+// e.g. on Fargate, must set the region with the flag or the environment.
+func (cfg *Config) resolveRegion(ctx context.Context) error {
+	if cfg.Region != "" {
+		cfg.RegionSource = ackv1alpha1.AWSRegionSourceFlag
+		if !flag.CommandLine.Changed(flagAWSRegion) && cfg.Region == os.Getenv(envVarAWSRegion) {
+			cfg.RegionSource = ackv1alpha1.AWSRegionSourceEnvironment
+		}
+		return nil
+	}
+	if region := os.Getenv(envVarAWSDefaultRegion); region != "" {
+		cfg.Region = region
+		cfg.RegionSource = ackv1alpha1.AWSRegionSourceEnvironment
+		return nil
+	}
+	region, err := getInstanceMetadataRegion(ctx)
+	if err == nil && region != "" {
+		cfg.Region = region
+		cfg.RegionSource = ackv1alpha1.AWSRegionSourceInstanceMetadata
+		return nil
+	}
+	if err == nil {
+		err = errors.New("empty region")
+	}
+	return fmt.Errorf(
+		"unable to start service controller as AWS region is missing. The region is resolved from, "+
+			"in order: the --%s flag, the %s and %s environment variables and the instance metadata "+
+			"service, which failed with: %v. Please pass --%s flag or set %s environment variable",
+		flagAWSRegion, envVarAWSRegion, envVarAWSDefaultRegion, err, flagAWSRegion, envVarAWSRegion,
+	)
+}
+
 func (cfg *Config) checkUnsafeEndpoint(endpoint *url.URL) error {
 	if !cfg.AllowUnsafeEndpointURL {
 		if endpoint.Scheme != "https" && endpoint.Host != "" {
@@ -393,16 +1403,74 @@ func (cfg *Config) validateReconcileConfigResources(supportedGVKs []schema.Group
 	for _, gvk := range supportedGVKs {
 		validResourceNames = append(validResourceNames, gvk.Kind)
 	}
-	for _, resourceFlagArgument := range cfg.ReconcileResourceResyncSeconds {
+	for _, resourceFlagArgument := range splitReconcileFlagArguments(cfg.ReconcileResourceResyncSeconds) {
 		if err := validateReconcileConfigResource(validResourceNames, resourceFlagArgument); err != nil {
 			return fmt.Errorf("invalid value for flag '%s': %v", flagReconcileResourceResyncSeconds, err)
 		}
 	}
-	for _, resourceFlagArgument := range cfg.ReconcileResourceMaxConcurrency {
+	for _, resourceFlagArgument := range splitReconcileFlagArguments(cfg.ReconcileResourceMaxConcurrency) {
 		if err := validateReconcileConfigResource(validResourceNames, resourceFlagArgument); err != nil {
 			return fmt.Errorf("invalid value for flag '%s': %v", flagReconcileResourceMaxConcurrency, err)
 		}
 	}
+	for _, resourceFlagArgument := range splitReconcileFlagArguments(cfg.ResourceProgressDeadlineSeconds) {
+		if err := validateReconcileConfigResource(validResourceNames, resourceFlagArgument); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagResourceProgressDeadline, err)
+		}
+	}
+	for _, resourceFlagArgument := range splitReconcileFlagArguments(cfg.ResourceUpdateDebounceSeconds) {
+		if err := validateReconcileConfigResource(validResourceNames, resourceFlagArgument); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagResourceUpdateDebounce, err)
+		}
+	}
+	for _, resourceFlagArgument := range splitReconcileFlagArguments(cfg.ResourceCanaryPercent) {
+		if err := validateReconcileConfigResource(validResourceNames, resourceFlagArgument); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagResourceCanaryPercent, err)
+		}
+		if _, percent, _ := parseReconcileFlagArgument(resourceFlagArgument); percent > 100 {
+			return fmt.Errorf(
+				"invalid value for flag '%s': percentage of '%v' must not be greater than 100",
+				flagResourceCanaryPercent, resourceFlagArgument,
+			)
+		}
+	}
+	for _, resourceFlagArgument := range cfg.ReconcileResultWebhooks {
+		resourceName, _, err := parseResultWebhookFlagArgument(resourceFlagArgument)
+		if err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagReconcileResultWebhook, err)
+		}
+		if !ackutil.InStrings(resourceName, validResourceNames) {
+			return fmt.Errorf(
+				"invalid value for flag '%s': resource '%v' is not managed by this controller. Expected one of %v",
+				flagReconcileResultWebhook, resourceName, strings.Join(validResourceNames, ", "),
+			)
+		}
+	}
+	for _, resourceFlagArgument := range cfg.ResourceExcludeSelectors {
+		resourceName, _, err := parseExcludeSelectorFlagArgument(resourceFlagArgument)
+		if err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagResourceExcludeSelector, err)
+		}
+		if !ackutil.InStrings(resourceName, validResourceNames) {
+			return fmt.Errorf(
+				"invalid value for flag '%s': resource '%v' is not managed by this controller. Expected one of %v",
+				flagResourceExcludeSelector, resourceName, strings.Join(validResourceNames, ", "),
+			)
+		}
+	}
+
+	for _, resourceFlagArgument := range cfg.ResourceSensitiveFields {
+		resourceName, _, err := parseSensitiveFieldFlagArgument(resourceFlagArgument)
+		if err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagResourceSensitiveFields, err)
+		}
+		if !ackutil.InStrings(resourceName, validResourceNames) {
+			return fmt.Errorf(
+				"invalid value for flag '%s': resource '%v' is not managed by this controller. Expected one of %v",
+				flagResourceSensitiveFields, resourceName, strings.Join(validResourceNames, ", "),
+			)
+		}
+	}
 
 	// Also validate the resource filter settings
 	if err := cfg.validateReconcileResources(validResourceNames); err != nil {
@@ -431,16 +1499,16 @@ func validateReconcileConfigResource(validResourceNames []string, resourceFlagAr
 }
 
 // ParseReconcileResourceResyncSeconds parses the values of the --reconcile-resource-resync-seconds
-// flag and returns a map that maps resource names to resync periods.
+// flag and returns a map that maps lowercased resource names to resync periods.
 // The flag arguments are expected to have the format "resource=seconds", where "resource" is the
 // name of the resource and "seconds" is the number of seconds that the reconciler should wait before
 // reconciling the resource again.
 func (cfg *Config) ParseReconcileResourceResyncSeconds() (map[string]time.Duration, error) {
 	resourceResyncPeriods := make(map[string]time.Duration, len(cfg.ReconcileResourceResyncSeconds))
-	for _, resourceResyncSecondsFlag := range cfg.ReconcileResourceResyncSeconds {
+	for _, resourceResyncSecondsFlag := range splitReconcileFlagArguments(cfg.ReconcileResourceResyncSeconds) {
 		// Parse the resource name and resync period from the flag argument
 		resourceName, resyncSeconds, _ := parseReconcileFlagArgument(resourceResyncSecondsFlag)
-		resourceResyncPeriods[strings.ToLower(resourceName)] = time.Duration(resyncSeconds)
+		resourceResyncPeriods[strings.ToLower(resourceName)] = time.Duration(resyncSeconds) * time.Second
 	}
 	return resourceResyncPeriods, nil
 }
@@ -449,7 +1517,7 @@ func (cfg *Config) ParseReconcileResourceResyncSeconds() (map[string]time.Durati
 // given resource name. If the resource name is not found in the --reconcile-resource-max-concurrent-syncs
 // flag, the function returns the default maximum concurrency value.
 func (cfg *Config) GetReconcileResourceMaxConcurrency(resourceName string) int {
-	for _, resourceMaxConcurrencyFlag := range cfg.ReconcileResourceMaxConcurrency {
+	for _, resourceMaxConcurrencyFlag := range splitReconcileFlagArguments(cfg.ReconcileResourceMaxConcurrency) {
 		// Parse the resource name and max concurrency from the flag argument
 		name, maxConcurrency, _ := parseReconcileFlagArgument(resourceMaxConcurrencyFlag)
 		if strings.EqualFold(name, resourceName) {
@@ -459,6 +1527,79 @@ func (cfg *Config) GetReconcileResourceMaxConcurrency(resourceName string) int {
 	return cfg.ReconcileDefaultMaxConcurrency
 }
 
+// GetReconcileResourceProgressDeadline returns the duration within which the
+// resources of the given kind are expected to sync, from the
+// --reconcile-resource-progress-deadline-seconds flag, or the
+// --reconcile-default-progress-deadline-seconds flag if the kind is not found.
+// It returns zero if the kind has no progress deadline.
+func (cfg *Config) GetReconcileResourceProgressDeadline(resourceName string) time.Duration {
+	for _, progressDeadlineFlag := range splitReconcileFlagArguments(cfg.ResourceProgressDeadlineSeconds) {
+		name, seconds, _ := parseReconcileFlagArgument(progressDeadlineFlag)
+		if strings.EqualFold(name, resourceName) {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return time.Duration(cfg.DefaultProgressDeadlineSeconds) * time.Second
+}
+
+// GetReconcileResourceUpdateDebounce returns the window for which the updates
+// of the resources of the given kind are held, from the
+// --reconcile-resource-update-debounce-seconds flag. It returns zero if the
+// kind has no debounce window.
+func (cfg *Config) GetReconcileResourceUpdateDebounce(resourceName string) time.Duration {
+	for _, debounceFlag := range splitReconcileFlagArguments(cfg.ResourceUpdateDebounceSeconds) {
+		name, seconds, _ := parseReconcileFlagArgument(debounceFlag)
+		if strings.EqualFold(name, resourceName) {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
+
+// GetReconcileResourceCanaryPercent returns the canary percentage of the
+// resources of the given kind, from the --reconcile-resource-canary-percent
+// flag, and false if the kind has no rollout in progress
+func (cfg *Config) GetReconcileResourceCanaryPercent(resourceName string) (int, bool) {
+	for _, canaryFlag := range splitReconcileFlagArguments(cfg.ResourceCanaryPercent) {
+		name, percent, _ := parseReconcileFlagArgument(canaryFlag)
+		if strings.EqualFold(name, resourceName) {
+			return percent, true
+		}
+	}
+	return 0, false
+}
+
+// GetReconcileResultWebhook returns the URL of the webhook receiving the
+// reconciliation results of the supplied resource name, or an empty string if
+// the --reconcile-result-webhook flag has no entry for the resource.
+func (cfg *Config) GetReconcileResultWebhook(resourceName string) string {
+	for _, resultWebhookFlag := range cfg.ReconcileResultWebhooks {
+		name, webhookURL, err := parseResultWebhookFlagArgument(resultWebhookFlag)
+		if err == nil && strings.EqualFold(name, resourceName) {
+			return webhookURL
+		}
+	}
+	return ""
+}
+
+// parseResultWebhookFlagArgument parses a --reconcile-result-webhook flag
+// argument of the form "resource=url". The URL must be an absolute HTTP or
+// HTTPS URL.
+func parseResultWebhookFlagArgument(flagArgument string) (string, string, error) {
+	resourceName, webhookURL, ok := strings.Cut(flagArgument, "=")
+	if !ok || resourceName == "" || webhookURL == "" {
+		return "", "", fmt.Errorf("invalid flag argument '%v': expected resource=url", flagArgument)
+	}
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid flag argument '%v': %v", flagArgument, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", "", fmt.Errorf("invalid flag argument '%v': expected an http or https URL", flagArgument)
+	}
+	return resourceName, webhookURL, nil
+}
+
 // parseReconcileFlagArgument parses a flag argument of the form "key=value" into
 // its individual elements. The key must be a non-empty string and the value must be
 // a non-empty positive integer. If the flag argument is not in the expected format
@@ -466,6 +1607,7 @@ func (cfg *Config) GetReconcileResourceMaxConcurrency(resourceName string) int {
 //
 // The function returns the parsed key and value as separate elements.
 func parseReconcileFlagArgument(flagArgument string) (string, int, error) {
+	flagArgument = strings.TrimSpace(flagArgument)
 	delimiter := "="
 	elements := strings.Split(flagArgument, delimiter)
 	if len(elements) != 2 {
@@ -488,6 +1630,127 @@ func parseReconcileFlagArgument(flagArgument string) (string, int, error) {
 	return elements[0], value, nil
 }
 
+// splitReconcileFlagArguments returns the "key=value" arguments of the supplied
+// values of a resource-specific reconcile flag, each value being either a
+// single argument or a comma-separated list of arguments, e.g. "Bucket=10,Table=2"
+func splitReconcileFlagArguments(values []string) []string {
+	flagArguments := make([]string, 0, len(values))
+	for _, value := range values {
+		flagArguments = append(flagArguments, strings.Split(value, ",")...)
+	}
+	return flagArguments
+}
+
+// validateWebhookCert validates the --webhook-cert-mode flag and the flags
+// it requires
+func (cfg *Config) validateWebhookCert() error {
+	mode, err := webhookcert.ParseMode(cfg.WebhookCertMode)
+	if err != nil {
+		return fmt.Errorf("invalid value for flag '%s': %v", flagWebhookCertMode, err)
+	}
+	if mode == webhookcert.ModeExternal {
+		return nil
+	}
+	if !cfg.EnableWebhookServer {
+		return fmt.Errorf("invalid value for flag '%s': managing the webhook certificate requires --%s", flagWebhookCertMode, flagEnableWebhookServer)
+	}
+	if _, err := webhookcert.ParseObjectKey(cfg.WebhookCertSecret); err != nil {
+		return fmt.Errorf("invalid value for flag '%s': %v", flagWebhookCertSecret, err)
+	}
+	if mode == webhookcert.ModeSelfSigned {
+		if _, err := webhookcert.ParseObjectKey(cfg.WebhookService); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': %v", flagWebhookService, err)
+		}
+	}
+	if len(cfg.WebhookConfigurations) == 0 {
+		return fmt.Errorf("invalid value for flag '%s': at least one webhook configuration is required", flagWebhookConfigurations)
+	}
+	return nil
+}
+
+// validateAWSRetry validates the --aws-retry-mode, --aws-retry-max-attempts,
+// --aws-operation-retry-max-attempts, --aws-api-rate-limit and
+// --aws-api-rate-limit-burst flags
+func (cfg *Config) validateAWSRetry() error {
+	if cfg.AWSRetryMode != "" {
+		if _, err := aws.ParseRetryMode(cfg.AWSRetryMode); err != nil {
+			return fmt.Errorf("invalid value for flag '%s': expected 'standard' or 'adaptive', got %q", flagAWSRetryMode, cfg.AWSRetryMode)
+		}
+	}
+	if cfg.AWSRetryMaxAttempts < 0 {
+		return fmt.Errorf("invalid value for flag '%s': value must be greater than or equal to 0", flagAWSRetryMaxAttempts)
+	}
+	for _, operationFlagArgument := range cfg.AWSOperationRetryMaxAttempts {
+		if _, _, err := parseReconcileFlagArgument(operationFlagArgument); err != nil {
+			return fmt.Errorf(
+				"invalid value for flag '%s': error parsing flag argument '%v': %v. Expected format: operation=number",
+				flagAWSOperationRetryMaxAttempts, operationFlagArgument, err,
+			)
+		}
+	}
+	if cfg.AWSAPIRateLimit < 0 {
+		return fmt.Errorf("invalid value for flag '%s': value must be greater than or equal to 0", flagAWSAPIRateLimit)
+	}
+	if cfg.AWSAPIRateLimitBurst < 0 {
+		return fmt.Errorf("invalid value for flag '%s': value must be greater than or equal to 0", flagAWSAPIRateLimitBurst)
+	}
+	return nil
+}
+
+// validateTracing validates the --tracing-endpoint and
+// --tracing-sampling-ratio flags
+func (cfg *Config) validateTracing() error {
+	if cfg.TracingEndpoint != "" {
+		u, err := url.Parse(cfg.TracingEndpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid value for flag '%s': expected an http or https URL, got %q", flagTracingEndpoint, cfg.TracingEndpoint)
+		}
+	}
+	if cfg.TracingSamplingRatio < 0 || cfg.TracingSamplingRatio > 1 {
+		return fmt.Errorf("invalid value for flag '%s': ratio must be between 0 and 1", flagTracingSamplingRatio)
+	}
+	return nil
+}
+
+// validateAdmin validates the flags of the admin service, which is only
+// served over TLS, for tokens of restricted audiences
+func (cfg *Config) validateAdmin() error {
+	if cfg.AdminBindAddress == "" {
+		return nil
+	}
+	if cfg.AdminTLSCertFile == "" || cfg.AdminTLSKeyFile == "" {
+		return fmt.Errorf("invalid value for flag '%s': the admin service requires --%s and --%s", flagAdminBindAddress, flagAdminTLSCertFile, flagAdminTLSKeyFile)
+	}
+	if len(cfg.AdminTokenAudiences) == 0 {
+		return fmt.Errorf("invalid value for flag '%s': the admin service requires at least one token audience", flagAdminTokenAudiences)
+	}
+	return nil
+}
+
+// GetAWSAPIRateLimitBurst returns the burst of the calls to the AWS service
+// APIs of the --aws-api-rate-limit-burst flag, defaulting to the rate limit
+// of the --aws-api-rate-limit flag rounded up
+func (cfg *Config) GetAWSAPIRateLimitBurst() int {
+	if cfg.AWSAPIRateLimitBurst > 0 {
+		return cfg.AWSAPIRateLimitBurst
+	}
+	return int(math.Ceil(cfg.AWSAPIRateLimit))
+}
+
+// GetAWSOperationRetryMaxAttempts returns the maximum numbers of attempts of
+// the calls of the AWS API operations of the
+// --aws-operation-retry-max-attempts flag, keyed by operation name
+func (cfg *Config) GetAWSOperationRetryMaxAttempts() map[string]int {
+	maxAttempts := make(map[string]int, len(cfg.AWSOperationRetryMaxAttempts))
+	for _, operationFlagArgument := range cfg.AWSOperationRetryMaxAttempts {
+		operation, attempts, err := parseReconcileFlagArgument(operationFlagArgument)
+		if err == nil {
+			maxAttempts[operation] = attempts
+		}
+	}
+	return maxAttempts
+}
+
 // ParseWatchSelectors parses the --watch-selectors flag and returns a label selector
 // that can be used to filter the objects that the controller watches. If the flag is
 // not set, the function returns nil, which means that the controller will watch all
@@ -507,6 +1770,108 @@ func (cfg *Config) ParseWatchSelectors() (labels.Selector, error) {
 	return labelSelector, nil
 }
 
+// GetReconcileExcludeSelectors returns the label selectors matching the
+// resources, of the supplied resource name, that the controller ignores: the
+// one of the --reconcile-exclude-selector flag and the ones of the
+// --reconcile-resource-exclude-selector flag for the resource. It returns nil
+// if no resources are excluded.
+func (cfg *Config) GetReconcileExcludeSelectors(resourceName string) []labels.Selector {
+	selectors := []labels.Selector{}
+	if cfg.ReconcileExcludeSelector != "" {
+		if selector, err := labels.Parse(cfg.ReconcileExcludeSelector); err == nil {
+			selectors = append(selectors, selector)
+		}
+	}
+	for _, excludeSelectorFlag := range cfg.ResourceExcludeSelectors {
+		name, selector, err := parseExcludeSelectorFlagArgument(excludeSelectorFlag)
+		if err == nil && strings.EqualFold(name, resourceName) {
+			selectors = append(selectors, selector)
+		}
+	}
+	if len(selectors) == 0 {
+		return nil
+	}
+	return selectors
+}
+
+// parseExcludeSelectorFlagArgument parses a --reconcile-resource-exclude-selector
+// flag argument of the form "resource=selector". The selector, which can
+// itself contain '=' characters, must be a non-empty label selector.
+func parseExcludeSelectorFlagArgument(flagArgument string) (string, labels.Selector, error) {
+	resourceName, rawSelector, ok := strings.Cut(flagArgument, "=")
+	if !ok || resourceName == "" || strings.TrimSpace(rawSelector) == "" {
+		return "", nil, fmt.Errorf("invalid flag argument '%v': expected resource=selector", flagArgument)
+	}
+	selector, err := labels.Parse(rawSelector)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid flag argument '%v': %v", flagArgument, err)
+	}
+	return resourceName, selector, nil
+}
+
+// getAllowedOwnerRoleARNs parses the 'namespace=role' entries of the
+// --allowed-owner-role-arns flag into the patterns of the allowed roles of
+// each namespace. The '*' wildcards of the roles match any characters but
+// ':', so that they never span several fields of the ARNs.
+func (cfg *Config) getAllowedOwnerRoleARNs() (map[string][]*regexp.Regexp, error) {
+	allowed := map[string][]*regexp.Regexp{}
+	for _, entry := range cfg.AllowedOwnerRoleARNs {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("expected a 'namespace=role' entry, got %q", entry)
+		}
+		namespace, roleARN := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if strings.Contains(namespace, "*") {
+			return nil, fmt.Errorf("the namespace of entry %q cannot contain wildcards", entry)
+		}
+		if !strings.HasPrefix(roleARN, "arn:") {
+			return nil, fmt.Errorf("%q is not an IAM role ARN", roleARN)
+		}
+		pattern := "^" + strings.ReplaceAll(regexp.QuoteMeta(roleARN), `\*`, "[^:]*") + "$"
+		allowed[namespace] = append(allowed[namespace], regexp.MustCompile(pattern))
+	}
+	return allowed, nil
+}
+
+// IsOwnerRoleARNAllowed returns true if the resources of the supplied
+// namespace can be reconciled with the supplied IAM role, set with their
+// owner role ARN annotation, as specified by the --allowed-owner-role-arns
+// flag. No role is allowed until the flag is validated.
+func (cfg *Config) IsOwnerRoleARNAllowed(namespace, roleARN string) bool {
+	for _, pattern := range cfg.allowedOwnerRoleARNs[namespace] {
+		if pattern.MatchString(roleARN) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetResourceSensitiveFields returns the paths of the fields of the resources
+// of the supplied resource name whose values are redacted, as specified by
+// the --resource-sensitive-fields flag
+func (cfg *Config) GetResourceSensitiveFields(resourceName string) []string {
+	fields := []string{}
+	for _, sensitiveFieldFlag := range cfg.ResourceSensitiveFields {
+		name, field, err := parseSensitiveFieldFlagArgument(sensitiveFieldFlag)
+		if err == nil && strings.EqualFold(name, resourceName) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// parseSensitiveFieldFlagArgument parses a flag argument of the form
+// "resource=spec.field" into its resource name and field path
+func parseSensitiveFieldFlagArgument(flagArgument string) (string, string, error) {
+	resourceName, field, ok := strings.Cut(flagArgument, "=")
+	field = strings.TrimSpace(field)
+	if !ok || resourceName == "" || field == "" || strings.Contains(field, "..") ||
+		strings.HasPrefix(field, ".") || strings.HasSuffix(field, ".") {
+		return "", "", fmt.Errorf("invalid flag argument '%v': expected resource=field.path", flagArgument)
+	}
+	return resourceName, field, nil
+}
+
 // GetWatchNamespaces returns a slice of namespaces to watch for custom resource events.
 // If the watchNamespace flag is empty, the function returns nil, which means that the
 // controller will watch for events in all namespaces.
@@ -585,6 +1950,54 @@ func parseFeatureGates(featureGatesRaw string) (map[string]bool, error) {
 	return featureGatesMap, nil
 }
 
+// splitServiceFeatureGates splits the supplied feature gate settings into the
+// settings applying to all the service controllers and the settings applying
+// to a single one, keyed by service alias, whose names are prefixed with the
+// alias, e.g. "s3/ReferenceCaching"
+func splitServiceFeatureGates(
+	featureGatesMap map[string]bool,
+) (map[string]bool, map[string]map[string]bool) {
+	var global map[string]bool
+	var perService map[string]map[string]bool
+	for name, enabled := range featureGatesMap {
+		alias, feature, ok := strings.Cut(name, "/")
+		if !ok {
+			if global == nil {
+				global = map[string]bool{}
+			}
+			global[name] = enabled
+			continue
+		}
+		if perService == nil {
+			perService = map[string]map[string]bool{}
+		}
+		if perService[alias] == nil {
+			perService[alias] = map[string]bool{}
+		}
+		perService[alias][feature] = enabled
+	}
+	return global, perService
+}
+
+// ForService returns the configuration of the controller of the supplied
+// service, whose feature gates are overridden by the feature gates prefixed
+// with the service alias
+func (cfg Config) ForService(alias string) Config {
+	overrides, ok := cfg.serviceFeatureGates[alias]
+	if !ok {
+		return cfg
+	}
+	gates := make(featuregate.FeatureGates, len(cfg.FeatureGates))
+	for name, feature := range cfg.FeatureGates {
+		if enabled, ok := overrides[name]; ok {
+			feature.Enabled = enabled
+		}
+		gates[name] = feature
+	}
+	cfg.FeatureGates = gates
+	return cfg
+}
+
 // GetReconcileResources returns a slice of resource kinds that should be reconciled.
 func (cfg *Config) GetReconcileResources() ([]string, error) {
 	return parseReconcileResourcesString(cfg.ReconcileResources)
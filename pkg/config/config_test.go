@@ -14,9 +14,24 @@
 package config
 
 import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
 )
 
 func TestParseReconcileFlagArgument(t *testing.T) {
@@ -64,6 +79,107 @@ func TestParseReconcileFlagArgument(t *testing.T) {
 	}
 }
 
+func TestParseReconcileResourceResyncSeconds(t *testing.T) {
+	cfg := Config{ReconcileResourceResyncSeconds: []string{"Bucket=60", "Queue=3600"}}
+	got, err := cfg.ParseReconcileResourceResyncSeconds()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]time.Duration{"bucket": time.Minute, "queue": time.Hour}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestGetReconcileResourceMaxConcurrency(t *testing.T) {
+	cfg := Config{
+		ReconcileDefaultMaxConcurrency:  1,
+		ReconcileResourceMaxConcurrency: []string{"Bucket=10, Table=2", "Queue=5"},
+	}
+	if err := cfg.validateReconcileConfigResources([]schema.GroupVersionKind{
+		{Kind: "Bucket"}, {Kind: "Table"}, {Kind: "Queue"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for kind, expected := range map[string]int{"Bucket": 10, "table": 2, "Queue": 5, "Topic": 1} {
+		if got := cfg.GetReconcileResourceMaxConcurrency(kind); got != expected {
+			t.Errorf("unexpected max concurrency for %s: expected %d, got %d", kind, expected, got)
+		}
+	}
+	cfg.ReconcileResourceMaxConcurrency = []string{"Bucket=10,Table"}
+	if err := cfg.validateReconcileConfigResources([]schema.GroupVersionKind{{Kind: "Bucket"}, {Kind: "Table"}}); err == nil {
+		t.Errorf("expected error for flag argument without value")
+	}
+}
+
+func TestGetReconcileResourceProgressDeadline(t *testing.T) {
+	cfg := Config{ResourceProgressDeadlineSeconds: []string{"DBInstance=3600"}}
+	if got := cfg.GetReconcileResourceProgressDeadline("DBInstance"); got != time.Hour {
+		t.Errorf("unexpected progress deadline for DBInstance: %v", got)
+	}
+	if got := cfg.GetReconcileResourceProgressDeadline("Bucket"); got != 0 {
+		t.Errorf("unexpected progress deadline for Bucket: %v", got)
+	}
+	cfg.DefaultProgressDeadlineSeconds = 600
+	if got := cfg.GetReconcileResourceProgressDeadline("Bucket"); got != 10*time.Minute {
+		t.Errorf("unexpected default progress deadline: %v", got)
+	}
+}
+
+func TestGetReconcileResourceUpdateDebounce(t *testing.T) {
+	cfg := Config{ResourceUpdateDebounceSeconds: []string{"DBInstance=60"}}
+	if got := cfg.GetReconcileResourceUpdateDebounce("dbinstance"); got != time.Minute {
+		t.Errorf("unexpected update debounce window for DBInstance: %v", got)
+	}
+	if got := cfg.GetReconcileResourceUpdateDebounce("Bucket"); got != 0 {
+		t.Errorf("unexpected update debounce window for Bucket: %v", got)
+	}
+}
+
+func TestGetReconcileResourceCanaryPercent(t *testing.T) {
+	cfg := Config{ResourceCanaryPercent: []string{"DBInstance=10"}}
+	if got, ok := cfg.GetReconcileResourceCanaryPercent("dbinstance"); !ok || got != 10 {
+		t.Errorf("unexpected canary percentage for DBInstance: %v", got)
+	}
+	if _, ok := cfg.GetReconcileResourceCanaryPercent("Bucket"); ok {
+		t.Errorf("unexpected canary percentage for Bucket")
+	}
+}
+
+func TestParseResultWebhookFlagArgument(t *testing.T) {
+	tests := []struct {
+		flagArgument string
+		expectedKey  string
+		expectedURL  string
+		expectedErr  bool
+	}{
+		{"Bucket=https://cmdb.example.com/ack", "Bucket", "https://cmdb.example.com/ack", false},
+		{"Bucket=http://cmdb:8080/ack?kind=bucket", "Bucket", "http://cmdb:8080/ack?kind=bucket", false},
+		{"Bucket", "", "", true},
+		{"=https://cmdb.example.com", "", "", true},
+		{"Bucket=", "", "", true},
+		{"Bucket=cmdb.example.com", "", "", true},
+		{"Bucket=ftp://cmdb.example.com", "", "", true},
+	}
+	for _, test := range tests {
+		key, webhookURL, err := parseResultWebhookFlagArgument(test.flagArgument)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error for flag argument '%s': %v", test.flagArgument, err)
+		}
+		if key != test.expectedKey || webhookURL != test.expectedURL {
+			t.Errorf("unexpected result for flag argument '%s': got '%s' and '%s'", test.flagArgument, key, webhookURL)
+		}
+	}
+
+	cfg := Config{ReconcileResultWebhooks: []string{"Bucket=https://cmdb.example.com/ack"}}
+	if got := cfg.GetReconcileResultWebhook("bucket"); got != "https://cmdb.example.com/ack" {
+		t.Errorf("unexpected webhook for bucket: %s", got)
+	}
+	if got := cfg.GetReconcileResultWebhook("Queue"); got != "" {
+		t.Errorf("unexpected webhook for Queue: %s", got)
+	}
+}
+
 const (
 	dns1123SubdomainErrorMsg string = "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character"
 )
@@ -183,3 +299,376 @@ func TestParseFeatureGates(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceFeatureGates(t *testing.T) {
+	gates, err := parseFeatureGates("ReferenceCaching=true,s3/ReferenceCaching=false,rds/TeamLevelCARM=true")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	global, perService := splitServiceFeatureGates(gates)
+	if expected := map[string]bool{"ReferenceCaching": true}; !reflect.DeepEqual(expected, global) {
+		t.Errorf("expected %v, got %v", expected, global)
+	}
+	expected := map[string]map[string]bool{
+		"s3":  {"ReferenceCaching": false},
+		"rds": {"TeamLevelCARM": true},
+	}
+	if !reflect.DeepEqual(expected, perService) {
+		t.Errorf("expected %v, got %v", expected, perService)
+	}
+
+	cfg := Config{serviceFeatureGates: perService}
+	cfg.FeatureGates, err = featuregate.GetFeatureGatesWithOverrides(global)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ForService("s3").FeatureGates.IsEnabled(featuregate.ReferenceCaching) {
+		t.Errorf("expected ReferenceCaching to be disabled for s3")
+	}
+	if !cfg.ForService("sns").FeatureGates.IsEnabled(featuregate.ReferenceCaching) {
+		t.Errorf("expected ReferenceCaching to be enabled for sns")
+	}
+	if !cfg.ForService("rds").FeatureGates.IsEnabled(featuregate.TeamLevelCARM) {
+		t.Errorf("expected TeamLevelCARM to be enabled for rds")
+	}
+	// The configuration of the other services is left untouched
+	if !cfg.FeatureGates.IsEnabled(featuregate.ReferenceCaching) || cfg.FeatureGates.IsEnabled(featuregate.TeamLevelCARM) {
+		t.Errorf("unexpected feature gates: %v", cfg.FeatureGates)
+	}
+}
+
+func TestMetricsRelabelConfig(t *testing.T) {
+	cfg := Config{
+		MetricsDropLabels:          []string{"namespace"},
+		MetricsLabelMaxCardinality: []string{"name=100"},
+		MetricsRelabelRules:        []string{"name:(.*)-[0-9]+:$1"},
+	}
+	relabelCfg, err := cfg.MetricsRelabelConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(relabelCfg.DropLabels, []string{"namespace"}) {
+		t.Errorf("unexpected drop labels: %v", relabelCfg.DropLabels)
+	}
+	if !reflect.DeepEqual(relabelCfg.MaxCardinality, map[string]int{"name": 100}) {
+		t.Errorf("unexpected max cardinality: %v", relabelCfg.MaxCardinality)
+	}
+	if len(relabelCfg.Rules) != 1 || relabelCfg.Rules[0].Apply("bucket-1") != "bucket" {
+		t.Errorf("unexpected relabel rules: %v", relabelCfg.Rules)
+	}
+
+	for _, invalid := range []Config{
+		{MetricsLabelMaxCardinality: []string{"name=0"}},
+		{MetricsLabelMaxCardinality: []string{"name"}},
+		{MetricsRelabelRules: []string{"name:("}},
+	} {
+		if _, err := invalid.MetricsRelabelConfig(); err == nil {
+			t.Errorf("expected error for config %+v, got nil", invalid)
+		}
+	}
+}
+
+func TestResolveRegion(t *testing.T) {
+	defer func(f func(context.Context) (string, error)) {
+		getInstanceMetadataRegion = f
+	}(getInstanceMetadataRegion)
+
+	tests := []struct {
+		name           string
+		region         string
+		awsRegion      string
+		defaultRegion  string
+		imdsRegion     string
+		imdsErr        error
+		expectedRegion string
+		expectedSource ackv1alpha1.AWSRegionSource
+		expectedErr    bool
+	}{
+		{"flag", "us-west-2", "", "", "", nil, "us-west-2", ackv1alpha1.AWSRegionSourceFlag, false},
+		{"AWS_REGION", "eu-west-1", "eu-west-1", "", "", nil, "eu-west-1", ackv1alpha1.AWSRegionSourceEnvironment, false},
+		{"flag overrides AWS_REGION", "us-west-2", "eu-west-1", "", "", nil, "us-west-2", ackv1alpha1.AWSRegionSourceFlag, false},
+		{"AWS_DEFAULT_REGION", "", "", "eu-central-1", "", nil, "eu-central-1", ackv1alpha1.AWSRegionSourceEnvironment, false},
+		{"instance metadata", "", "", "", "ap-south-1", nil, "ap-south-1", ackv1alpha1.AWSRegionSourceInstanceMetadata, false},
+		{"instance metadata failure", "", "", "", "", errors.New("no IMDS"), "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(envVarAWSRegion, test.awsRegion)
+			t.Setenv(envVarAWSDefaultRegion, test.defaultRegion)
+			getInstanceMetadataRegion = func(context.Context) (string, error) {
+				return test.imdsRegion, test.imdsErr
+			}
+			cfg := Config{Region: test.region}
+			err := cfg.resolveRegion(context.TODO())
+			if test.expectedErr {
+				if err == nil || !strings.Contains(err.Error(), "no IMDS") {
+					t.Errorf("expected error explaining the region sources, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Region != test.expectedRegion || cfg.RegionSource != test.expectedSource {
+				t.Errorf(
+					"expected region %q from %q, got %q from %q",
+					test.expectedRegion, test.expectedSource, cfg.Region, cfg.RegionSource,
+				)
+			}
+		})
+	}
+}
+
+func TestGetReconcileExcludeSelectors(t *testing.T) {
+	for _, flagArgument := range []string{"Bucket", "=owner=legacy", "Bucket=", "Bucket=owner in (legacy"} {
+		if _, _, err := parseExcludeSelectorFlagArgument(flagArgument); err == nil {
+			t.Errorf("expected an error for flag argument '%s'", flagArgument)
+		}
+	}
+
+	cfg := Config{}
+	if got := cfg.GetReconcileExcludeSelectors("Bucket"); got != nil {
+		t.Errorf("unexpected selectors: %v", got)
+	}
+
+	cfg = Config{
+		ReconcileExcludeSelector: "owner=legacy",
+		ResourceExcludeSelectors: []string{"Bucket=owner in (canary,staging),!migrated"},
+	}
+	bucket := cfg.GetReconcileExcludeSelectors("bucket")
+	if len(bucket) != 2 {
+		t.Fatalf("unexpected selectors for bucket: %v", bucket)
+	}
+	if !bucket[0].Matches(labels.Set{"owner": "legacy"}) {
+		t.Errorf("expected the global selector to match")
+	}
+	if !bucket[1].Matches(labels.Set{"owner": "canary"}) || bucket[1].Matches(labels.Set{"owner": "canary", "migrated": "true"}) {
+		t.Errorf("unexpected matches of the bucket selector %v", bucket[1])
+	}
+	if got := cfg.GetReconcileExcludeSelectors("Queue"); len(got) != 1 {
+		t.Errorf("unexpected selectors for Queue: %v", got)
+	}
+}
+
+func TestGetResourceSensitiveFields(t *testing.T) {
+	for _, flagArgument := range []string{"Function", "Function=", "=spec.a", "Function=spec..a", "Function=.spec"} {
+		if _, _, err := parseSensitiveFieldFlagArgument(flagArgument); err == nil {
+			t.Errorf("expected an error for flag argument '%s'", flagArgument)
+		}
+	}
+
+	cfg := Config{ResourceSensitiveFields: []string{"Function=spec.environment.variables", "Queue=spec.policy"}}
+	if got := cfg.GetResourceSensitiveFields("function"); len(got) != 1 || got[0] != "spec.environment.variables" {
+		t.Errorf("unexpected sensitive fields for Function: %v", got)
+	}
+	if got := cfg.GetResourceSensitiveFields("Bucket"); len(got) != 0 {
+		t.Errorf("unexpected sensitive fields for Bucket: %v", got)
+	}
+}
+
+func TestIsOwnerRoleARNAllowed(t *testing.T) {
+	cfg := Config{}
+	if cfg.IsOwnerRoleARNAllowed("tenant-a", "arn:aws:iam::111122223333:role/tenant-a") {
+		t.Errorf("expected no role to be allowed without --%s", flagAllowedOwnerRoleARNs)
+	}
+
+	cfg.AllowedOwnerRoleARNs = []string{
+		"tenant-a=arn:aws:iam::111122223333:role/tenant-a-*",
+		"tenant-b=arn:aws:iam::111122223333:role/tenant-b-*",
+		"platform=arn:aws:iam::444455556666:role/platform",
+		"shared=arn:aws:iam::*:role/shared",
+	}
+	if cfg.IsOwnerRoleARNAllowed("tenant-a", "arn:aws:iam::111122223333:role/tenant-a-app") {
+		t.Errorf("expected no role to be allowed before --%s is validated", flagAllowedOwnerRoleARNs)
+	}
+	allowed, err := cfg.getAllowedOwnerRoleARNs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg.allowedOwnerRoleARNs = allowed
+	for _, test := range []struct {
+		namespace string
+		roleARN   string
+		expected  bool
+	}{
+		{"tenant-a", "arn:aws:iam::111122223333:role/tenant-a-app", true},
+		{"tenant-a", "arn:aws:iam::111122223333:role/tenant-a-app/nested", true},
+		{"tenant-a", "arn:aws:iam::111122223333:role/tenant-b-app", false},
+		{"tenant-b", "arn:aws:iam::111122223333:role/tenant-b-app", true},
+		{"tenant-b", "arn:aws:iam::111122223333:role/tenant-a-app", false},
+		{"platform", "arn:aws:iam::444455556666:role/platform", true},
+		{"platform", "arn:aws:iam::444455556666:role/platform-admin", false},
+		{"tenant-a", "arn:aws:iam::444455556666:role/platform", false},
+		{"other", "arn:aws:iam::111122223333:role/tenant-a-app", false},
+		{"tenant-a", "arn:aws:iam::999999999999:role/tenant-a-app", false},
+		{"tenant-a", "xarn:aws:iam::111122223333:role/tenant-a-app", false},
+		{"tenant-a", "arn:aws:iam::111122223333:role/tenant-a-app:extra", false},
+		{"shared", "arn:aws:iam::111122223333:role/shared", true},
+		{"shared", "arn:aws:iam::444455556666:role/shared", true},
+		{"shared", "arn:aws:iam::111122223333:x:role/shared", false},
+		{"shared", "arn:aws:iam::111122223333:role/shared-admin", false},
+	} {
+		if got := cfg.IsOwnerRoleARNAllowed(test.namespace, test.roleARN); got != test.expected {
+			t.Errorf("expected %s to be allowed in %s: %v, got %v", test.roleARN, test.namespace, test.expected, got)
+		}
+	}
+
+	for _, entries := range [][]string{
+		{"arn:aws:iam::111122223333:role/tenant-a"},
+		{"=arn:aws:iam::111122223333:role/tenant-a"},
+		{"*=arn:aws:iam::111122223333:role/tenant-a"},
+		{"tenant-*=arn:aws:iam::111122223333:role/tenant-a"},
+		{"tenant-a=tenant-a"},
+	} {
+		cfg := Config{AllowedOwnerRoleARNs: entries}
+		if _, err := cfg.getAllowedOwnerRoleARNs(); err == nil {
+			t.Errorf("expected %v to be invalid", entries)
+		}
+	}
+}
+
+func TestValidateAWSRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr bool
+	}{
+		{"defaults", Config{}, false},
+		{"adaptive", Config{AWSRetryMode: "adaptive", AWSRetryMaxAttempts: 5}, false},
+		{"unknown mode", Config{AWSRetryMode: "aggressive"}, true},
+		{"negative max attempts", Config{AWSRetryMaxAttempts: -1}, true},
+		{"operation overrides", Config{AWSOperationRetryMaxAttempts: []string{"CreateDBInstance=10"}}, false},
+		{"invalid operation override", Config{AWSOperationRetryMaxAttempts: []string{"CreateDBInstance"}}, true},
+		{"rate limit", Config{AWSAPIRateLimit: 2.5, AWSAPIRateLimitBurst: 10}, false},
+		{"negative rate limit", Config{AWSAPIRateLimit: -1}, true},
+		{"negative rate limit burst", Config{AWSAPIRateLimitBurst: -1}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cfg.validateAWSRetry()
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	cfg := Config{AWSOperationRetryMaxAttempts: []string{"CreateDBInstance=10", "DeleteDBInstance=1"}}
+	expected := map[string]int{"CreateDBInstance": 10, "DeleteDBInstance": 1}
+	if got := cfg.GetAWSOperationRetryMaxAttempts(); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	cfg = Config{AWSAPIRateLimit: 2.5}
+	if got := cfg.GetAWSAPIRateLimitBurst(); got != 3 {
+		t.Errorf("expected the burst to default to 3, got %d", got)
+	}
+	cfg.AWSAPIRateLimitBurst = 10
+	if got := cfg.GetAWSAPIRateLimitBurst(); got != 10 {
+		t.Errorf("expected a burst of 10, got %d", got)
+	}
+}
+
+func TestValidateTracing(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr bool
+	}{
+		{"disabled", Config{}, false},
+		{"endpoint", Config{TracingEndpoint: "http://otel-collector:4318", TracingSamplingRatio: 0.1}, false},
+		{"endpoint without scheme", Config{TracingEndpoint: "otel-collector:4318"}, true},
+		{"grpc endpoint", Config{TracingEndpoint: "grpc://otel-collector:4317"}, true},
+		{"negative ratio", Config{TracingSamplingRatio: -0.5}, true},
+		{"ratio above 1", Config{TracingSamplingRatio: 2}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cfg.validateTracing()
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateAdmin(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr bool
+	}{
+		{"disabled", Config{}, false},
+		{"tls", Config{AdminBindAddress: ":8082", AdminTLSCertFile: "tls.crt", AdminTLSKeyFile: "tls.key", AdminTokenAudiences: []string{"ack-admin"}}, false},
+		{"no certificate", Config{AdminBindAddress: ":8082", AdminTLSKeyFile: "tls.key", AdminTokenAudiences: []string{"ack-admin"}}, true},
+		{"no key", Config{AdminBindAddress: ":8082", AdminTLSCertFile: "tls.crt", AdminTokenAudiences: []string{"ack-admin"}}, true},
+		{"no audience", Config{AdminBindAddress: ":8082", AdminTLSCertFile: "tls.crt", AdminTLSKeyFile: "tls.key"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cfg.validateAdmin()
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPTransport(t *testing.T) {
+	cfg := Config{}
+	if transport, err := cfg.HTTPTransport(); transport != nil || err != nil {
+		t.Fatalf("expected no transport, got %v, %v", transport, err)
+	}
+
+	cfg = Config{HTTPSProxy: "http://proxy.corp:3128", NoProxy: ".internal,10.0.0.0/8"}
+	transport, err := cfg.HTTPTransport()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for target, expected := range map[string]string{
+		"https://sts.us-west-2.amazonaws.com/": "http://proxy.corp:3128",
+		"https://vpce.internal/":               "",
+		"https://10.1.2.3/":                    "",
+	} {
+		targetURL, _ := url.Parse(target)
+		proxyURL, err := transport.Proxy(&http.Request{URL: targetURL})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := ""
+		if proxyURL != nil {
+			got = proxyURL.String()
+		}
+		if got != expected {
+			t.Errorf("expected proxy %q for %s, got %q", expected, target, got)
+		}
+	}
+
+	if _, err := (&Config{HTTPSProxy: "proxy"}).HTTPTransport(); err == nil {
+		t.Errorf("expected an error for an invalid proxy URL")
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{
+		Type: "CERTIFICATE", Bytes: server.Certificate().Raw,
+	}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	transport, err = (&Config{CABundle: bundle}).HTTPTransport()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the CA bundle to be trusted: %v", err)
+	}
+	resp.Body.Close()
+
+	if err := os.WriteFile(bundle, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&Config{CABundle: bundle}).HTTPTransport(); err == nil {
+		t.Errorf("expected an error for a bundle without certificates")
+	}
+}
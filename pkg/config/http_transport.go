@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// HTTPTransport returns the transport of the HTTP clients of the AWS SDK,
+// sending the requests through the proxy of the --https-proxy flag, except
+// for the hosts of the --no-proxy flag, and trusting the CA certificates of
+// the --ca-bundle flag in addition to the system ones. The HTTPS_PROXY and
+// NO_PROXY environment variables apply when the flags are not set.
+//
+// Returns nil if none of the flags is set, in which case the default
+// transport of the AWS SDK is used.
+func (cfg *Config) HTTPTransport() (*http.Transport, error) {
+	if cfg.HTTPSProxy == "" && cfg.NoProxy == "" && cfg.CABundle == "" {
+		return nil, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	proxyCfg := httpproxy.FromEnvironment()
+	if cfg.HTTPSProxy != "" {
+		proxyURL, err := url.Parse(cfg.HTTPSProxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid value for flag '%s': expected a proxy URL, got %q", flagHTTPSProxy, cfg.HTTPSProxy)
+		}
+		proxyCfg.HTTPSProxy = cfg.HTTPSProxy
+	}
+	if cfg.NoProxy != "" {
+		proxyCfg.NoProxy = cfg.NoProxy
+	}
+	proxyFunc := proxyCfg.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	if cfg.CABundle != "" {
+		pem, err := os.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for flag '%s': %v", flagCABundle, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("invalid value for flag '%s': no PEM encoded certificate found in %s", flagCABundle, cfg.CABundle)
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return transport, nil
+}
@@ -0,0 +1,392 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package conformance contains a suite of checks that service controllers run
+// against their generated resource managers and descriptors to verify they
+// adhere to the contract the ACK runtime relies on: creates are idempotent,
+// missing resources are signaled with NotFound, deltas are symmetric and
+// invalid resources are rejected with terminal errors.
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// defaultTimeout is the default maximum duration of the wait for a
+	// deleted resource to be reported as not found
+	defaultTimeout = 5 * time.Minute
+	// defaultPollInterval is the default interval at which a deleted
+	// resource is read while waiting for it to be reported as not found
+	defaultPollInterval = 5 * time.Second
+)
+
+// Check is a conformance check
+type Check string
+
+const (
+	// CheckDeltaSymmetry verifies that a resource has no difference with
+	// itself, and that the differences between two resources are the same
+	// whichever way they are compared
+	CheckDeltaSymmetry Check = "DeltaSymmetry"
+	// CheckTerminalMapping verifies that creating an invalid resource fails
+	// with an error classified as terminal
+	CheckTerminalMapping Check = "TerminalMapping"
+	// CheckNotFoundBeforeCreate verifies that reading a resource not created
+	// yet returns no resource and a NotFound error
+	CheckNotFoundBeforeCreate Check = "NotFoundBeforeCreate"
+	// CheckCreate verifies that a created resource can be read back
+	CheckCreate Check = "Create"
+	// CheckIdempotentCreate verifies that creating a resource again does not
+	// create another AWS resource
+	CheckIdempotentCreate Check = "IdempotentCreate"
+	// CheckNotFoundAfterDelete verifies that reading a deleted resource
+	// eventually returns no resource and a NotFound error
+	CheckNotFoundAfterDelete Check = "NotFoundAfterDelete"
+)
+
+// Status is the status of the result of a conformance check
+type Status string
+
+const (
+	// StatusPass is the status of a check the resource manager passed
+	StatusPass Status = "Pass"
+	// StatusFail is the status of a check the resource manager failed
+	StatusFail Status = "Fail"
+	// StatusSkip is the status of a check that was not run, because the
+	// target does not provide what it needs or a check it depends on failed
+	StatusSkip Status = "Skip"
+)
+
+// Target is the resource manager and descriptor of a resource kind, along
+// with the sample resources the conformance checks are run with
+type Target struct {
+	// Manager is the resource manager under test. The checks create and
+	// delete real AWS resources with it.
+	Manager acktypes.AWSResourceManager
+	// Descriptor is the resource descriptor of the kind under test
+	Descriptor acktypes.AWSResourceDescriptor
+	// Desired is the valid resource the checks create, read and delete
+	Desired acktypes.AWSResource
+	// Modified is Desired with at least one field changed. The delta
+	// symmetry is only checked between Desired and itself if it is nil.
+	Modified acktypes.AWSResource
+	// Invalid is a resource the AWS service API rejects. The terminal
+	// mapping is not checked if it is nil.
+	Invalid acktypes.AWSResource
+	// Classifications are the registries the errors of the resource manager
+	// are classified with, as with ackerr.ClassifyFor
+	Classifications []*ackerr.ClassificationRegistry
+}
+
+// Result is the result of a conformance check
+type Result struct {
+	// Check is the conformance check
+	Check Check `json:"check"`
+	// Status is the status of the check
+	Status Status `json:"status"`
+	// Message explains the failure, or the skipping, of the check
+	Message string `json:"message,omitempty"`
+	// Duration is the duration of the check
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the report of a run of the conformance suite
+type Report struct {
+	// Kind is the kind of the resources under test
+	Kind string `json:"kind"`
+	// Passed is true if no check failed
+	Passed bool `json:"passed"`
+	// Results are the results of the checks, in the order they were run
+	Results []Result `json:"results"`
+}
+
+// Result returns the result of the supplied check, or false if it is not in
+// the report
+func (r *Report) Result(check Check) (Result, bool) {
+	for _, result := range r.Results {
+		if result.Check == check {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
+
+// WriteJSON writes the report to the supplied writer, as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Suite runs the conformance checks
+type Suite struct {
+	// timeout is the maximum duration of the wait for a deleted resource to
+	// be reported as not found
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// NewSuite returns a Suite of conformance checks
+func NewSuite() *Suite {
+	return &Suite{
+		timeout:      defaultTimeout,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// WithTimeout sets the maximum duration of the wait for a deleted resource
+// to be reported as not found
+func (s *Suite) WithTimeout(timeout time.Duration) *Suite {
+	s.timeout = timeout
+	return s
+}
+
+// WithPollInterval sets the interval at which a deleted resource is read
+// while waiting for it to be reported as not found
+func (s *Suite) WithPollInterval(interval time.Duration) *Suite {
+	s.pollInterval = interval
+	return s
+}
+
+// Run runs the conformance checks against the supplied target. The checks
+// needing a created resource are skipped if it could not be created. The
+// created resource is deleted before returning.
+func (s *Suite) Run(ctx context.Context, target Target) *Report {
+	report := &Report{
+		Kind:   target.Descriptor.GroupVersionKind().Kind,
+		Passed: true,
+	}
+	run := func(check Check, fn func() (Status, string)) Status {
+		start := time.Now()
+		status, msg := fn()
+		report.Results = append(report.Results, Result{
+			Check:    check,
+			Status:   status,
+			Message:  msg,
+			Duration: time.Since(start),
+		})
+		if status == StatusFail {
+			report.Passed = false
+		}
+		return status
+	}
+
+	run(CheckDeltaSymmetry, func() (Status, string) {
+		return s.checkDeltaSymmetry(target)
+	})
+	run(CheckTerminalMapping, func() (Status, string) {
+		return s.checkTerminalMapping(ctx, target)
+	})
+	run(CheckNotFoundBeforeCreate, func() (Status, string) {
+		return expectNotFound(ctx, target, target.Desired)
+	})
+
+	var created acktypes.AWSResource
+	createStatus := run(CheckCreate, func() (Status, string) {
+		var status Status
+		var msg string
+		created, status, msg = s.checkCreate(ctx, target)
+		return status, msg
+	})
+	if createStatus != StatusPass {
+		msg := fmt.Sprintf("%s check did not pass", CheckCreate)
+		run(CheckIdempotentCreate, func() (Status, string) { return StatusSkip, msg })
+		if created == nil {
+			run(CheckNotFoundAfterDelete, func() (Status, string) { return StatusSkip, msg })
+			return report
+		}
+	} else {
+		run(CheckIdempotentCreate, func() (Status, string) {
+			return s.checkIdempotentCreate(ctx, target, created)
+		})
+	}
+	run(CheckNotFoundAfterDelete, func() (Status, string) {
+		return s.checkNotFoundAfterDelete(ctx, target, created)
+	})
+	return report
+}
+
+// checkDeltaSymmetry compares the desired and modified resources both ways
+func (s *Suite) checkDeltaSymmetry(target Target) (Status, string) {
+	rd := target.Descriptor
+	if delta := rd.Delta(target.Desired, target.Desired.DeepCopy()); len(delta.Differences) != 0 {
+		return StatusFail, fmt.Sprintf(
+			"resource differs from a copy of itself at %v", diffPaths(delta),
+		)
+	}
+	if target.Modified == nil {
+		return StatusPass, ""
+	}
+	forward := diffPaths(rd.Delta(target.Desired, target.Modified))
+	backward := diffPaths(rd.Delta(target.Modified, target.Desired))
+	if len(forward) == 0 {
+		return StatusFail, "modified resource does not differ from the desired resource"
+	}
+	if fmt.Sprint(forward) != fmt.Sprint(backward) {
+		return StatusFail, fmt.Sprintf(
+			"asymmetric delta: desired to modified differs at %v, modified to desired at %v",
+			forward, backward,
+		)
+	}
+	return StatusPass, ""
+}
+
+// checkTerminalMapping creates the invalid resource, expecting a terminal
+// error. An invalid resource created anyway is deleted.
+func (s *Suite) checkTerminalMapping(ctx context.Context, target Target) (Status, string) {
+	if target.Invalid == nil {
+		return StatusSkip, "target has no invalid resource"
+	}
+	created, err := target.Manager.Create(ctx, target.Invalid)
+	if err == nil {
+		if created != nil {
+			_, _ = target.Manager.Delete(ctx, created)
+		}
+		return StatusFail, "invalid resource was created"
+	}
+	kind := target.Descriptor.GroupVersionKind().Kind
+	if c := ackerr.ClassifyFor(kind, err, target.Classifications...); c != ackerr.ClassificationTerminal {
+		return StatusFail, fmt.Sprintf(
+			"creating the invalid resource failed with a %s error instead of a Terminal one: %v",
+			c, err,
+		)
+	}
+	return StatusPass, ""
+}
+
+// checkCreate creates the desired resource and reads it back. It returns the
+// created resource, if any, even if the check fails.
+func (s *Suite) checkCreate(
+	ctx context.Context,
+	target Target,
+) (acktypes.AWSResource, Status, string) {
+	created, err := target.Manager.Create(ctx, target.Desired)
+	if err != nil {
+		return nil, StatusFail, fmt.Sprintf("creating the desired resource: %v", err)
+	}
+	if created == nil {
+		return nil, StatusFail, "Create returned no resource and no error"
+	}
+	latest, err := target.Manager.ReadOne(ctx, created)
+	if err != nil {
+		return created, StatusFail, fmt.Sprintf("reading the created resource: %v", err)
+	}
+	if latest == nil {
+		return created, StatusFail, "ReadOne returned no resource and no error for the created resource"
+	}
+	return latest, StatusPass, ""
+}
+
+// checkIdempotentCreate creates the desired resource again. Failing is fine,
+// but succeeding must return the resource already created.
+func (s *Suite) checkIdempotentCreate(
+	ctx context.Context,
+	target Target,
+	created acktypes.AWSResource,
+) (Status, string) {
+	again, err := target.Manager.Create(ctx, target.Desired)
+	if err != nil || again == nil {
+		return StatusPass, ""
+	}
+	first := arn(created)
+	second := arn(again)
+	if first != second {
+		// Do not leak the duplicate resource
+		_, _ = target.Manager.Delete(ctx, again)
+		return StatusFail, fmt.Sprintf(
+			"creating the desired resource again created %q besides %q", second, first,
+		)
+	}
+	return StatusPass, ""
+}
+
+// checkNotFoundAfterDelete deletes the created resource and waits for it to
+// be reported as not found
+func (s *Suite) checkNotFoundAfterDelete(
+	ctx context.Context,
+	target Target,
+	created acktypes.AWSResource,
+) (Status, string) {
+	if _, err := target.Manager.Delete(ctx, created); err != nil {
+		return StatusFail, fmt.Sprintf("deleting the created resource: %v", err)
+	}
+	deadline := time.Now().Add(s.timeout)
+	for {
+		latest, err := target.Manager.ReadOne(ctx, created)
+		if err != nil || latest == nil || time.Now().After(deadline) {
+			return expectNotFoundResult(latest, err)
+		}
+		select {
+		case <-ctx.Done():
+			return StatusFail, fmt.Sprintf("waiting for the deleted resource: %v", ctx.Err())
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// expectNotFound reads the supplied resource, expecting it not to be found
+func expectNotFound(
+	ctx context.Context,
+	target Target,
+	res acktypes.AWSResource,
+) (Status, string) {
+	return expectNotFoundResult(target.Manager.ReadOne(ctx, res))
+}
+
+// expectNotFoundResult checks the result of ReadOne for a missing resource
+func expectNotFoundResult(latest acktypes.AWSResource, err error) (Status, string) {
+	switch {
+	case err == nil:
+		return StatusFail, "ReadOne returned no error for a missing resource"
+	case !ackerr.IsNotFound(err):
+		return StatusFail, fmt.Sprintf(
+			"ReadOne returned an error not wrapping NotFound for a missing resource: %v", err,
+		)
+	case latest != nil:
+		return StatusFail, "ReadOne returned a resource along with NotFound"
+	}
+	return StatusPass, ""
+}
+
+// diffPaths returns the sorted paths of the differences of the supplied delta
+func diffPaths(delta *ackcompare.Delta) []string {
+	if delta == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(delta.Differences))
+	for _, diff := range delta.Differences {
+		paths = append(paths, diff.Path.String())
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// arn returns the ARN of the supplied resource, or an empty string if it has
+// none
+func arn(res acktypes.AWSResource) string {
+	ids := res.Identifiers()
+	if ids == nil || ids.ARN() == nil {
+		return ""
+	}
+	return string(*ids.ARN())
+}
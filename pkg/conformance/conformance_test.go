@@ -0,0 +1,245 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package conformance_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/conformance"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// fakeResource is a resource with a name, a size and, once created, an ARN
+type fakeResource struct {
+	*ackmocks.AWSResource
+	name string
+	size int
+	arn  string
+}
+
+func (r *fakeResource) Identifiers() acktypes.AWSResourceIdentifiers {
+	return fakeIdentifiers{arn: r.arn}
+}
+
+func (r *fakeResource) DeepCopy() acktypes.AWSResource {
+	c := *r
+	return &c
+}
+
+type fakeIdentifiers struct {
+	*ackmocks.AWSResourceIdentifiers
+	arn string
+}
+
+func (ids fakeIdentifiers) ARN() *ackv1alpha1.AWSResourceName {
+	if ids.arn == "" {
+		return nil
+	}
+	arn := ackv1alpha1.AWSResourceName(ids.arn)
+	return &arn
+}
+
+// fakeDescriptor compares the sizes of the resources, and their names unless
+// asymmetric
+type fakeDescriptor struct {
+	*ackmocks.AWSResourceDescriptor
+	asymmetric bool
+}
+
+func (d *fakeDescriptor) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "s3.services.k8s.aws", Version: "v1alpha1", Kind: "Bucket"}
+}
+
+func (d *fakeDescriptor) Delta(a, b acktypes.AWSResource) *ackcompare.Delta {
+	delta := ackcompare.NewDelta()
+	ra, rb := a.(*fakeResource), b.(*fakeResource)
+	if ra.size != rb.size {
+		delta.Add("Spec.Size", ra.size, rb.size)
+	}
+	if ra.name != rb.name && !(d.asymmetric && ra.name < rb.name) {
+		delta.Add("Spec.Name", ra.name, rb.name)
+	}
+	return delta
+}
+
+// fakeManager creates resources in memory. Unless idempotent, creating a
+// resource again creates another one.
+type fakeManager struct {
+	*ackmocks.AWSResourceManager
+	created    map[string]bool
+	count      int
+	idempotent bool
+	// notFound is the error returned for missing resources
+	notFound error
+	// deleteDelay is the number of reads a deleted resource is still found
+	deleteDelay int
+	deleting    map[string]int
+}
+
+func newFakeManager() *fakeManager {
+	return &fakeManager{
+		created:    map[string]bool{},
+		deleting:   map[string]int{},
+		idempotent: true,
+		notFound:   ackerr.NotFound,
+	}
+}
+
+func (m *fakeManager) Create(_ context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	r := res.(*fakeResource)
+	if r.size < 0 {
+		return nil, &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "negative size"}
+	}
+	arn := "arn:aws:s3:::" + r.name
+	if !m.idempotent {
+		m.count++
+		arn = arn + "-" + string(rune('0'+m.count))
+	}
+	m.created[arn] = true
+	c := *r
+	c.arn = arn
+	return &c, nil
+}
+
+func (m *fakeManager) ReadOne(_ context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	r := res.(*fakeResource)
+	if !m.created[r.arn] {
+		return nil, m.notFound
+	}
+	if m.deleting[r.arn] > 0 {
+		if m.deleting[r.arn]--; m.deleting[r.arn] == 0 {
+			delete(m.created, r.arn)
+		}
+	}
+	return r.DeepCopy(), nil
+}
+
+func (m *fakeManager) Delete(_ context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	r := res.(*fakeResource)
+	if m.deleteDelay > 0 {
+		m.deleting[r.arn] = m.deleteDelay
+		return r, nil
+	}
+	delete(m.created, r.arn)
+	return nil, nil
+}
+
+func newTarget(rm *fakeManager, rd *fakeDescriptor) conformance.Target {
+	return conformance.Target{
+		Manager:    rm,
+		Descriptor: rd,
+		Desired:    &fakeResource{name: "my-bucket", size: 1},
+		Modified:   &fakeResource{name: "my-other-bucket", size: 2},
+		Invalid:    &fakeResource{name: "my-bucket", size: -1},
+	}
+}
+
+func statuses(report *conformance.Report) map[conformance.Check]conformance.Status {
+	got := map[conformance.Check]conformance.Status{}
+	for _, result := range report.Results {
+		got[result.Check] = result.Status
+	}
+	return got
+}
+
+func TestSuite_Run(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	suite := conformance.NewSuite().WithPollInterval(time.Millisecond).WithTimeout(time.Second)
+
+	rm := newFakeManager()
+	report := suite.Run(ctx, newTarget(rm, &fakeDescriptor{}))
+	require.True(report.Passed, report.Results)
+	require.Equal("Bucket", report.Kind)
+	require.Len(report.Results, 6)
+	for _, result := range report.Results {
+		require.Equal(conformance.StatusPass, result.Status, result.Check)
+	}
+	require.Empty(rm.created)
+
+	// Results are machine-readable
+	var buf bytes.Buffer
+	require.Nil(report.WriteJSON(&buf))
+	decoded := conformance.Report{}
+	require.Nil(json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(*report, decoded)
+
+	// The checks needing what the target does not provide are skipped
+	target := newTarget(newFakeManager(), &fakeDescriptor{})
+	target.Invalid = nil
+	report = suite.Run(ctx, target)
+	require.True(report.Passed)
+	result, ok := report.Result(conformance.CheckTerminalMapping)
+	require.True(ok)
+	require.Equal(conformance.StatusSkip, result.Status)
+
+	// Deletes may be asynchronous
+	rm = newFakeManager()
+	rm.deleteDelay = 1
+	require.True(suite.Run(ctx, newTarget(rm, &fakeDescriptor{})).Passed)
+}
+
+func TestSuite_Run_Failures(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	suite := conformance.NewSuite().WithPollInterval(time.Millisecond).WithTimeout(time.Second)
+
+	// Creating the resource again created another one
+	rm := newFakeManager()
+	rm.idempotent = false
+	report := suite.Run(ctx, newTarget(rm, &fakeDescriptor{}))
+	require.False(report.Passed)
+	require.Equal(conformance.StatusFail, statuses(report)[conformance.CheckIdempotentCreate])
+	require.Empty(rm.created)
+
+	// Missing resources are not signaled with NotFound
+	rm = newFakeManager()
+	rm.notFound = errors.New("no such bucket")
+	report = suite.Run(ctx, newTarget(rm, &fakeDescriptor{}))
+	require.Equal(conformance.StatusFail, statuses(report)[conformance.CheckNotFoundBeforeCreate])
+	require.Equal(conformance.StatusFail, statuses(report)[conformance.CheckNotFoundAfterDelete])
+
+	// A ResourceNotFoundError wraps NotFound
+	rm = newFakeManager()
+	rm.notFound = ackerr.NewResourceNotFound(
+		schema.GroupKind{Kind: "Bucket"}, types.NamespacedName{Namespace: "default", Name: "my-bucket"}, "",
+	)
+	require.True(suite.Run(ctx, newTarget(rm, &fakeDescriptor{})).Passed)
+
+	// The delta is asymmetric
+	report = suite.Run(ctx, newTarget(newFakeManager(), &fakeDescriptor{asymmetric: true}))
+	require.Equal(conformance.StatusFail, statuses(report)[conformance.CheckDeltaSymmetry])
+
+	// The error of the invalid resource is not classified as terminal
+	registry := ackerr.NewClassificationRegistry()
+	registry.Register("Bucket", "InvalidParameterValue", ackerr.ClassificationRetryable)
+	target := newTarget(newFakeManager(), &fakeDescriptor{})
+	target.Classifications = []*ackerr.ClassificationRegistry{registry}
+	report = suite.Run(ctx, target)
+	require.Equal(conformance.StatusFail, statuses(report)[conformance.CheckTerminalMapping])
+}
@@ -0,0 +1,231 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// AllKinds is the kind of the classifications that apply to the errors of
+// all the resource kinds
+const AllKinds = "*"
+
+// ParseClassification parses the supplied classification name, as returned
+// by Classification.String, ignoring case
+func ParseClassification(name string) (Classification, error) {
+	for _, c := range []Classification{
+		ClassificationUnknown,
+		ClassificationRetryable,
+		ClassificationTerminal,
+		ClassificationRequeue,
+	} {
+		if strings.EqualFold(c.String(), name) {
+			return c, nil
+		}
+	}
+	return ClassificationUnknown, fmt.Errorf(
+		"invalid error classification %q: expected Retryable, Terminal, Requeue or Unknown", name,
+	)
+}
+
+// registeredClassification is the classification registered for an error
+// code
+type registeredClassification struct {
+	class Classification
+	// requeueAfter is the delay before the resources failing with the error
+	// are reconciled again, zero for the default delay
+	requeueAfter time.Duration
+}
+
+// ClassificationRegistry maps the error codes returned by AWS service APIs to
+// the classifications of the errors, per resource kind. A nil
+// ClassificationRegistry classifies nothing.
+type ClassificationRegistry struct {
+	sync.RWMutex
+	// classifications holds the classification of each error code, keyed
+	// by resource kind, AllKinds for the ones applying to all kinds
+	classifications map[string]map[string]registeredClassification
+}
+
+// NewClassificationRegistry returns an empty ClassificationRegistry
+func NewClassificationRegistry() *ClassificationRegistry {
+	return &ClassificationRegistry{
+		classifications: map[string]map[string]registeredClassification{},
+	}
+}
+
+// ParseClassificationRegistry returns the ClassificationRegistry of the
+// supplied YAML document, mapping resource kinds, or AllKinds, to the
+// classifications of error codes, or to the delays after which the resources
+// failing with them are reconciled again:
+//
+//	Bucket:
+//	  InvalidParameterValue: Terminal
+//	"*":
+//	  DependencyViolation: 2m
+//	  ResourceInUse: Retryable
+func ParseClassificationRegistry(data []byte) (*ClassificationRegistry, error) {
+	raw := map[string]map[string]string{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing error classifications: %v", err)
+	}
+	registry := NewClassificationRegistry()
+	for kind, codes := range raw {
+		for code, name := range codes {
+			if after, err := time.ParseDuration(name); err == nil {
+				if after <= 0 {
+					return nil, fmt.Errorf(
+						"invalid requeue delay of %s errors of %s: %s is not positive", code, kind, name,
+					)
+				}
+				registry.RegisterRequeueAfter(kind, code, after)
+				continue
+			}
+			c, err := ParseClassification(name)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"invalid classification of %s errors of %s: %v, or a requeue delay", code, kind, err,
+				)
+			}
+			registry.Register(kind, code, c)
+		}
+	}
+	return registry, nil
+}
+
+// Register sets the classification of the errors with the supplied code
+// returned for the resources of the supplied kind, or of all kinds if it is
+// AllKinds. Registering ClassificationUnknown opts the errors out of the
+// built-in classification.
+func (r *ClassificationRegistry) Register(kind, code string, c Classification) {
+	r.register(kind, code, registeredClassification{class: c})
+}
+
+// RegisterRequeueAfter classifies the errors with the supplied code returned
+// for the resources of the supplied kind, or of all kinds if it is AllKinds,
+// as ClassificationRequeue, the resources failing with them being reconciled
+// again after the supplied delay
+func (r *ClassificationRegistry) RegisterRequeueAfter(kind, code string, after time.Duration) {
+	r.register(kind, code, registeredClassification{class: ClassificationRequeue, requeueAfter: after})
+}
+
+// register sets the classification of the errors with the supplied code
+// returned for the resources of the supplied kind
+func (r *ClassificationRegistry) register(kind, code string, c registeredClassification) {
+	r.Lock()
+	defer r.Unlock()
+	if r.classifications[kind] == nil {
+		r.classifications[kind] = map[string]registeredClassification{}
+	}
+	r.classifications[kind][code] = c
+}
+
+// Lookup returns the classification of the errors with the supplied code
+// returned for the resources of the supplied kind, the classifications
+// registered for the kind coming before the ones registered for all kinds.
+// It returns false if none is registered.
+func (r *ClassificationRegistry) Lookup(kind, code string) (Classification, bool) {
+	c, ok := r.lookup(kind, code)
+	return c.class, ok
+}
+
+// LookupRequeueAfter returns the delay after which the resources of the
+// supplied kind failing with the errors with the supplied code are reconciled
+// again, as registered with RegisterRequeueAfter. It returns false if the
+// classification of the errors is not registered, or has no delay.
+func (r *ClassificationRegistry) LookupRequeueAfter(kind, code string) (time.Duration, bool) {
+	c, ok := r.lookup(kind, code)
+	return c.requeueAfter, ok && c.requeueAfter > 0
+}
+
+// lookup returns the classification registered for the errors with the
+// supplied code returned for the resources of the supplied kind
+func (r *ClassificationRegistry) lookup(kind, code string) (registeredClassification, bool) {
+	if r == nil || code == "" {
+		return registeredClassification{}, false
+	}
+	r.RLock()
+	defer r.RUnlock()
+	if c, ok := r.classifications[kind][code]; ok {
+		return c, true
+	}
+	c, ok := r.classifications[AllKinds][code]
+	return c, ok
+}
+
+// defaultClassificationRegistry holds the classifications registered by the
+// service controllers
+var defaultClassificationRegistry = NewClassificationRegistry()
+
+// RegisterClassification sets, in the registry of the service controller,
+// the classification of the errors with the supplied code returned for the
+// resources of the supplied kind, or of all kinds if it is AllKinds. It is
+// meant to be called by service controllers at initialization, e.g. to treat
+// the DependencyViolation errors of a kind as retryable.
+func RegisterClassification(kind, code string, c Classification) {
+	defaultClassificationRegistry.Register(kind, code, c)
+}
+
+// RegisterRequeueAfter sets, in the registry of the service controller, the
+// delay after which the resources of the supplied kind, or of all kinds if it
+// is AllKinds, failing with the errors with the supplied code are reconciled
+// again, e.g. to wait two minutes for the dependents of a resource to be
+// deleted after a DependencyViolation error
+func RegisterRequeueAfter(kind, code string, after time.Duration) {
+	defaultClassificationRegistry.RegisterRequeueAfter(kind, code, after)
+}
+
+// ClassifyFor returns the class of the supplied reconciliation error of a
+// resource of the supplied kind. Errors explicitly marked as terminal are
+// terminal. The class of the other errors returned by AWS service APIs is
+// looked up, by error code, in the supplied registries in order, then in
+// the registry of the service controller, before falling back to Classify.
+func ClassifyFor(kind string, err error, registries ...*ClassificationRegistry) Classification {
+	if err == nil {
+		return ClassificationUnknown
+	}
+	var terminalErr *TerminalError
+	var terminalErrValue TerminalError
+	if errors.Is(err, Terminal) || errors.As(err, &terminalErr) || errors.As(err, &terminalErrValue) {
+		return ClassificationTerminal
+	}
+	code := ErrorCode(err)
+	for _, registry := range append(registries, defaultClassificationRegistry) {
+		if c, ok := registry.Lookup(kind, code); ok {
+			return c
+		}
+	}
+	return Classify(err)
+}
+
+// RequeueAfterFor returns the delay after which the resource of the supplied
+// kind that failed with the supplied error is reconciled again, as registered
+// for the code of the error in the supplied registries, then in the registry
+// of the service controller. The first registry classifying the error
+// decides. It returns false if none has a delay for the error.
+func RequeueAfterFor(kind string, err error, registries ...*ClassificationRegistry) (time.Duration, bool) {
+	code := ErrorCode(err)
+	for _, registry := range append(registries, defaultClassificationRegistry) {
+		if _, ok := registry.Lookup(kind, code); ok {
+			return registry.LookupRequeueAfter(kind, code)
+		}
+	}
+	return 0, false
+}
@@ -16,8 +16,17 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
 var (
@@ -53,6 +62,10 @@ var (
 	// after some wait time
 	TemporaryOutOfSync = fmt.Errorf(
 		"temporary out of sync, reconcile after some time")
+	// ManagedByAnotherCluster is returned when an AWS resource is tagged as
+	// managed by the controller of another cluster
+	ManagedByAnotherCluster = fmt.Errorf(
+		"resource is managed by another cluster")
 	// Terminal is returned with resource is in Terminal Condition
 	Terminal = fmt.Errorf(
 		"resource is in terminal condition")
@@ -65,6 +78,9 @@ var (
 	// ReadOneFailedAfterCreate is returned if a ReadOne call fails right after
 	// a create operation.
 	ReadOneFailedAfterCreate = fmt.Errorf("ReadOne call failed after a Create operation")
+	// OperationNotPermitted is returned when an operation is skipped because
+	// the controller is not granted the IAM actions it requires
+	OperationNotPermitted = fmt.Errorf("operation not permitted")
 )
 
 // AWSError returns the type conversion for the supplied error to an aws-sdk-go
@@ -84,22 +100,230 @@ func AWSRequestFailure(err error) (smithy.APIError, bool) {
 	return awsRF, ok
 }
 
+// ErrorCode returns the error code of the AWS service API error wrapped by the
+// supplied error, e.g. "ThrottlingException", or an empty string if the error
+// was not returned by an AWS service API
+func ErrorCode(err error) string {
+	apiErr, ok := AWSError(err)
+	if !ok {
+		return ""
+	}
+	return apiErr.ErrorCode()
+}
+
+// clockSkewErrorCodes are the error codes returned by AWS service APIs when
+// the clock of the caller is too far from the clock of the service for the
+// signature of the request to be accepted
+var clockSkewErrorCodes = map[string]bool{
+	"RequestExpired":       true,
+	"RequestInTheFuture":   true,
+	"RequestTimeTooSkewed": true,
+}
+
+// IsClockSkew returns true if the supplied error was returned by an AWS
+// service API because the clock of the controller is skewed. Such errors go
+// away once the clock of the node is synchronized, not by changing the
+// resource.
+func IsClockSkew(err error) bool {
+	return clockSkewErrorCodes[ErrorCode(err)]
+}
+
+// IsEndpointConnection returns true if the supplied error was caused by a
+// failure to reach the endpoint of an AWS service API, e.g. a DNS lookup
+// failure, a refused or reset connection or a dial timeout. The request did
+// not reach the service, so such errors say nothing about the resource.
+func IsEndpointConnection(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	return retry.RetryableConnectionError{}.IsErrorRetryable(err) == aws.TrueTernary
+}
+
+// IsRetryable returns true if the supplied error is one the AWS SDK considers
+// transient, e.g. a throttling error, a 5XX response of the service, or a
+// connection or clock skew error, and the same request may succeed later
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsClockSkew(err) {
+		return true
+	}
+	return retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+}
+
+// Classification is the class of an error returned while reconciling a
+// resource, which decides how the reconciliation is retried
+type Classification int
+
+const (
+	// ClassificationUnknown is the class of the errors that are not known
+	// to be transient nor terminal
+	ClassificationUnknown Classification = iota
+	// ClassificationRetryable is the class of the transient faults of AWS or
+	// of the network, e.g. throttling or 5XX errors, that go away by
+	// retrying the same request with backoff
+	ClassificationRetryable
+	// ClassificationTerminal is the class of the errors that do not go away
+	// without a change of the desired state of the resource, e.g.
+	// validation errors
+	ClassificationTerminal
+	// ClassificationRequeue is the class of the errors caused by a
+	// transient state of the AWS resource or of its dependencies, e.g. a
+	// resource still in use or not yet visible, that go away once AWS
+	// catches up
+	ClassificationRequeue
+)
+
+// String returns the name of the classification, e.g. "Retryable"
+func (c Classification) String() string {
+	switch c {
+	case ClassificationRetryable:
+		return "Retryable"
+	case ClassificationTerminal:
+		return "Terminal"
+	case ClassificationRequeue:
+		return "Requeue"
+	}
+	return "Unknown"
+}
+
+// terminalErrorCodes are the error codes returned by AWS service APIs for
+// requests that are invalid whatever the state of the AWS resources
+var terminalErrorCodes = map[string]bool{
+	"InvalidInput":                     true,
+	"InvalidInputException":            true,
+	"InvalidParameter":                 true,
+	"InvalidParameterCombination":      true,
+	"InvalidParameterException":        true,
+	"InvalidParameterValue":            true,
+	"InvalidParameterValueException":   true,
+	"MalformedPolicyDocument":          true,
+	"MalformedPolicyDocumentException": true,
+	"MissingParameter":                 true,
+	"ValidationError":                  true,
+	"ValidationException":              true,
+}
+
+// eventualConsistencyErrorCodes are the error codes returned by AWS service
+// APIs for requests conflicting with a transient state of the AWS resources,
+// e.g. a dependency still being deleted or a concurrent modification
+var eventualConsistencyErrorCodes = map[string]bool{
+	"ConcurrentModification":          true,
+	"ConcurrentModificationException": true,
+	"ConflictException":               true,
+	"DependencyViolation":             true,
+	"IncorrectState":                  true,
+	"IncorrectStateException":         true,
+	"OperationAborted":                true,
+	"OperationAbortedException":       true,
+	"ResourceInUse":                   true,
+	"ResourceInUseException":          true,
+	"ResourceNotReady":                true,
+	"ResourceNotReadyException":       true,
+}
+
+// Classify returns the class of the supplied reconciliation error. Terminal
+// errors, including the ones wrapped in a TerminalError, come first, then
+// the errors caused by the eventual consistency of AWS, and then the
+// transient faults the AWS SDK retries.
+func Classify(err error) Classification {
+	if err == nil {
+		return ClassificationUnknown
+	}
+	var terminalErr *TerminalError
+	var terminalErrValue TerminalError
+	if errors.Is(err, Terminal) || errors.As(err, &terminalErr) || errors.As(err, &terminalErrValue) {
+		return ClassificationTerminal
+	}
+	code := ErrorCode(err)
+	switch {
+	case eventualConsistencyErrorCodes[code]:
+		return ClassificationRequeue
+	case IsRetryable(err) || IsEndpointConnection(err):
+		return ClassificationRetryable
+	case terminalErrorCodes[code]:
+		return ClassificationTerminal
+	}
+	return ClassificationUnknown
+}
+
+// ThrottleDelay returns the delay after which the supplied error, returned by
+// an AWS service API, suggests to retry the request, as given by the
+// Retry-After header of the response, in seconds or as an HTTP date. It
+// returns false if the response carries no such hint.
+func ThrottleDelay(err error) (time.Duration, bool) {
+	resp := httpResponse(err)
+	if resp == nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
 // NewReadOneFailAfterCreate takes a number of attempts and returns a
 // ReadOneFailedAfterCreate error if multiple ReadOne calls fails.
 func NewReadOneFailAfterCreate(numAttempts int) error {
 	return fmt.Errorf("%w: number of attempts: %d", ReadOneFailedAfterCreate, numAttempts)
 }
 
-// HTTPStatusCode returns the HTTP status code from the supplied error by
-// introspecting the error to see if it's an awserr.RequestFailure interface
-// and if so, calling StatusCode() on that type-converted RequestFailure. If
-// the type conversion fails, returns -1
+// httpResponse returns the HTTP response of the AWS service API call that
+// failed with the supplied error, nil if the call got no response, e.g.
+// because the endpoint could not be reached
+func httpResponse(err error) *http.Response {
+	var respErr interface{ HTTPResponse() *smithyhttp.Response }
+	if !errors.As(err, &respErr) {
+		return nil
+	}
+	resp := respErr.HTTPResponse()
+	if resp == nil {
+		return nil
+	}
+	return resp.Response
+}
+
+// HTTPStatusCode returns the HTTP status code of the response of the AWS
+// service API call that failed with the supplied error, e.g. 400 or 503. It
+// returns -1 if the call got no response, e.g. because the endpoint could not
+// be reached, or if the error was not returned by an AWS service API.
 func HTTPStatusCode(err error) int {
-	awsRF, ok := AWSRequestFailure(err)
-	if !ok {
+	resp := httpResponse(err)
+	if resp == nil {
 		return -1
 	}
-	return int(awsRF.ErrorFault())
+	return resp.StatusCode
+}
+
+// RequestID returns the ID AWS assigned to the request of the AWS service API
+// call that failed with the supplied error, which AWS support asks for when
+// investigating a failure. It returns an empty string if the error carries no
+// request ID.
+func RequestID(err error) string {
+	var respErr interface{ ServiceRequestID() string }
+	if !errors.As(err, &respErr) {
+		return ""
+	}
+	return respErr.ServiceRequestID()
 }
 
 // TerminalError defines an error that should be considered terminal, and placed
@@ -124,3 +348,95 @@ func (e TerminalError) Unwrap() error {
 }
 
 var _ error = &TerminalError{}
+
+// assumeRoleOperations are the names of the STS operations used to assume the
+// IAM roles of cross account resource management
+var assumeRoleOperations = map[string]bool{
+	"AssumeRole":                true,
+	"AssumeRoleWithWebIdentity": true,
+}
+
+// AssumeRoleFailure returns the reason of the failure if the supplied error
+// was caused by a failure to assume an IAM role, e.g. while retrieving the
+// credentials of a cross account resource management role. The reason is the
+// error code returned by STS (e.g. "AccessDenied" for a role whose trust
+// policy or external ID does not allow the controller to assume it), or
+// "Unknown" if STS could not be reached.
+func AssumeRoleFailure(err error) (string, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		var opErr *smithy.OperationError
+		if !errors.As(err, &opErr) {
+			return "", false
+		}
+		if opErr.ServiceID == "STS" && assumeRoleOperations[opErr.OperationName] {
+			if apiErr, ok := AWSError(opErr.Err); ok {
+				return apiErr.ErrorCode(), true
+			}
+			return "Unknown", true
+		}
+		err = opErr
+	}
+	return "", false
+}
+
+// regionNotEnabledErrorCodes are the error codes returned by AWS service APIs
+// called in an opt-in region that is not enabled for the AWS account
+var regionNotEnabledErrorCodes = map[string]bool{
+	"OptInRequired":           true,
+	"RegionDisabledException": true,
+}
+
+// RegionNotEnabled returns true if the supplied error was returned by an AWS
+// service API called in an opt-in region that is not enabled for the AWS
+// account. Such errors do not go away on retry, only once the region is
+// enabled.
+func RegionNotEnabled(err error) bool {
+	apiErr, ok := AWSError(err)
+	return ok && regionNotEnabledErrorCodes[apiErr.ErrorCode()]
+}
+
+// accessDeniedErrorCodes are the error codes returned by AWS service APIs
+// when the IAM policies of the caller do not allow a call
+var accessDeniedErrorCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"UnauthorizedOperation": true,
+}
+
+// encodedAuthorizationMessageRegex matches the encoded authorization message
+// some AWS service APIs (e.g. EC2) append to their access denied errors
+var encodedAuthorizationMessageRegex = regexp.MustCompile(
+	`Encoded authorization failure message: (\S+)`,
+)
+
+// AccessDeniedDetails describes a call denied by IAM
+type AccessDeniedDetails struct {
+	// Action is the denied action, e.g. "ec2:RunInstances", as derived from
+	// the SDK service ID and operation name. Empty if unknown.
+	Action string
+	// EncodedAuthorizationMessage is the encoded authorization message of
+	// the error, which STS DecodeAuthorizationMessage decodes into the
+	// details of the authorization decision. Empty if the AWS service API
+	// did not return one.
+	EncodedAuthorizationMessage string
+}
+
+// AccessDenied returns the details of the denied call if the supplied error
+// was returned by an AWS service API because the IAM policies of the caller
+// do not allow the call.
+func AccessDenied(err error) (AccessDeniedDetails, bool) {
+	apiErr, ok := AWSError(err)
+	if !ok || !accessDeniedErrorCodes[apiErr.ErrorCode()] {
+		return AccessDeniedDetails{}, false
+	}
+	details := AccessDeniedDetails{}
+	var opErr *smithy.OperationError
+	if errors.As(err, &opErr) && opErr.ServiceID != "" && opErr.OperationName != "" {
+		details.Action = strings.ToLower(strings.ReplaceAll(opErr.ServiceID, " ", "")) +
+			":" + opErr.OperationName
+	}
+	if m := encodedAuthorizationMessageRegex.FindStringSubmatch(apiErr.ErrorMessage()); m != nil {
+		details.EncodedAuthorizationMessage = m[1]
+	}
+	return details, true
+}
@@ -0,0 +1,418 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+)
+
+func TestAssumeRoleFailure(t *testing.T) {
+	accessDenied := &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"}
+	// assumeRoleError mimics the error returned by an AWS API call whose
+	// credentials could not be retrieved
+	assumeRoleError := func(operation string, err error) error {
+		return &smithy.OperationError{
+			ServiceID:     "S3",
+			OperationName: "GetBucket",
+			Err: fmt.Errorf("failed to refresh cached credentials, %w", &smithy.OperationError{
+				ServiceID:     "STS",
+				OperationName: operation,
+				Err:           err,
+			}),
+		}
+	}
+	tests := []struct {
+		name           string
+		err            error
+		expectedReason string
+		expectedOK     bool
+	}{
+		{"nil error", nil, "", false},
+		{"non AWS error", errors.New("oops"), "", false},
+		{"AWS API error", &smithy.OperationError{ServiceID: "S3", OperationName: "GetBucket", Err: accessDenied}, "", false},
+		{"access denied", assumeRoleError("AssumeRole", accessDenied), "AccessDenied", true},
+		{"web identity", assumeRoleError("AssumeRoleWithWebIdentity", accessDenied), "AccessDenied", true},
+		{"unreachable", assumeRoleError("AssumeRole", errors.New("dial tcp: timeout")), "Unknown", true},
+		{"other STS operation", assumeRoleError("GetCallerIdentity", accessDenied), "", false},
+		{"wrapped", fmt.Errorf("reading: %w", assumeRoleError("AssumeRole", accessDenied)), "AccessDenied", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := ackerr.AssumeRoleFailure(tt.err)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedReason, reason)
+		})
+	}
+}
+
+func TestRegionNotEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil error", nil, false},
+		{"non AWS error", errors.New("oops"), false},
+		{"other AWS error", &smithy.GenericAPIError{Code: "AccessDenied"}, false},
+		{"opt-in required", &smithy.GenericAPIError{Code: "OptInRequired"}, true},
+		{"STS region disabled", &smithy.OperationError{
+			ServiceID:     "STS",
+			OperationName: "AssumeRole",
+			Err:           &smithy.GenericAPIError{Code: "RegionDisabledException"},
+		}, true},
+		{"wrapped", fmt.Errorf("creating: %w", &smithy.GenericAPIError{Code: "OptInRequired"}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ackerr.RegionNotEnabled(tt.err))
+		})
+	}
+}
+
+func TestAWSFaults(t *testing.T) {
+	operationError := func(err error) error {
+		return &smithy.OperationError{ServiceID: "S3", OperationName: "GetBucket", Err: err}
+	}
+	dialError := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	tests := []struct {
+		name               string
+		err                error
+		code               string
+		retryable          bool
+		clockSkew          bool
+		endpointConnection bool
+	}{
+		{"nil error", nil, "", false, false, false},
+		{"non AWS error", errors.New("oops"), "", false, false, false},
+		{"validation", operationError(&smithy.GenericAPIError{Code: "ValidationException"}), "ValidationException", false, false, false},
+		{"throttling", operationError(&smithy.GenericAPIError{Code: "ThrottlingException"}), "ThrottlingException", true, false, false},
+		{"server error", operationError(&smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 503}},
+			Err:      errors.New("service unavailable"),
+		}), "", true, false, false},
+		{"clock skew", operationError(&smithy.GenericAPIError{Code: "RequestTimeTooSkewed"}), "RequestTimeTooSkewed", true, true, false},
+		{"dial", operationError(dialError), "", true, false, true},
+		{"dns", fmt.Errorf("resolving: %w", &net.DNSError{Name: "s3.example", IsNotFound: true}), "", false, false, true},
+		{"canceled", operationError(context.Canceled), "", false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, ackerr.ErrorCode(tt.err))
+			assert.Equal(t, tt.retryable, ackerr.IsRetryable(tt.err))
+			assert.Equal(t, tt.clockSkew, ackerr.IsClockSkew(tt.err))
+			assert.Equal(t, tt.endpointConnection, ackerr.IsEndpointConnection(tt.err))
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	operationError := func(err error) error {
+		return &smithy.OperationError{ServiceID: "EC2", OperationName: "DeleteSecurityGroup", Err: err}
+	}
+	tests := []struct {
+		name     string
+		err      error
+		expected ackerr.Classification
+	}{
+		{"nil error", nil, ackerr.ClassificationUnknown},
+		{"non AWS error", errors.New("oops"), ackerr.ClassificationUnknown},
+		{"terminal", ackerr.Terminal, ackerr.ClassificationTerminal},
+		{"terminal error", fmt.Errorf("adopting: %w", ackerr.NewTerminalError(errors.New("oops"))), ackerr.ClassificationTerminal},
+		{"validation", operationError(&smithy.GenericAPIError{Code: "InvalidParameterValue"}), ackerr.ClassificationTerminal},
+		{"dependency violation", operationError(&smithy.GenericAPIError{Code: "DependencyViolation"}), ackerr.ClassificationRequeue},
+		{"throttling", operationError(&smithy.GenericAPIError{Code: "Throttling"}), ackerr.ClassificationRetryable},
+		{"server error", operationError(&smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 500}},
+			Err:      errors.New("internal error"),
+		}), ackerr.ClassificationRetryable},
+		{"dns", &net.DNSError{Name: "ec2.example", IsNotFound: true}, ackerr.ClassificationRetryable},
+		{"access denied", operationError(&smithy.GenericAPIError{Code: "UnauthorizedOperation"}), ackerr.ClassificationUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ackerr.Classify(tt.err))
+		})
+	}
+}
+
+func TestClassifyFor(t *testing.T) {
+	apiError := func(code string) error {
+		return &smithy.OperationError{ServiceID: "EC2", OperationName: "DeleteSecurityGroup", Err: &smithy.GenericAPIError{Code: code}}
+	}
+	ackerr.RegisterClassification("Widget", "DependencyViolation", ackerr.ClassificationRetryable)
+	ackerr.RegisterClassification("Widget", "InvalidParameterValue", ackerr.ClassificationUnknown)
+
+	overrides, err := ackerr.ParseClassificationRegistry([]byte(`
+Widget:
+  DependencyViolation: Terminal
+"*":
+  ResourceInUse: Retryable
+`))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name      string
+		kind      string
+		err       error
+		overrides *ackerr.ClassificationRegistry
+		expected  ackerr.Classification
+	}{
+		{"built-in", "Gadget", apiError("DependencyViolation"), nil, ackerr.ClassificationRequeue},
+		{"registered", "Widget", apiError("DependencyViolation"), nil, ackerr.ClassificationRetryable},
+		{"opted out", "Widget", apiError("InvalidParameterValue"), nil, ackerr.ClassificationUnknown},
+		{"override", "Widget", apiError("DependencyViolation"), overrides, ackerr.ClassificationTerminal},
+		{"override of all kinds", "Gadget", apiError("ResourceInUse"), overrides, ackerr.ClassificationRetryable},
+		{"not overridden", "Gadget", apiError("DependencyViolation"), overrides, ackerr.ClassificationRequeue},
+		{"explicitly terminal", "Widget", ackerr.NewTerminalError(apiError("ResourceInUse")), overrides, ackerr.ClassificationTerminal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ackerr.ClassifyFor(tt.kind, tt.err, tt.overrides))
+		})
+	}
+
+	_, err = ackerr.ParseClassificationRegistry([]byte(`{Widget: {DependencyViolation: Fatal}}`))
+	assert.NotNil(t, err)
+	_, err = ackerr.ParseClassificationRegistry([]byte(`{Widget: {DependencyViolation: -1m}}`))
+	assert.NotNil(t, err)
+}
+
+func TestRequeueAfterFor(t *testing.T) {
+	apiError := func(code string) error {
+		return &smithy.GenericAPIError{Code: code}
+	}
+	ackerr.RegisterRequeueAfter("Doohickey", "DependencyViolation", 5*time.Minute)
+
+	overrides, err := ackerr.ParseClassificationRegistry([]byte(`
+Gizmo:
+  DependencyViolation: 2m
+  InvalidParameterValue: terminal
+"*":
+  ResourceInUse: 30s
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, ackerr.ClassificationRequeue, ackerr.ClassifyFor("Gizmo", apiError("DependencyViolation"), overrides))
+	assert.Equal(t, ackerr.ClassificationTerminal, ackerr.ClassifyFor("Gizmo", apiError("InvalidParameterValue"), overrides))
+
+	tests := []struct {
+		name          string
+		kind          string
+		err           error
+		expectedAfter time.Duration
+		expectedOK    bool
+	}{
+		{"override", "Gizmo", apiError("DependencyViolation"), 2 * time.Minute, true},
+		{"override of all kinds", "Doohickey", apiError("ResourceInUse"), 30 * time.Second, true},
+		{"registered", "Doohickey", apiError("DependencyViolation"), 5 * time.Minute, true},
+		{"no delay", "Gizmo", apiError("InvalidParameterValue"), 0, false},
+		{"not registered", "Gadget", apiError("DependencyViolation"), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after, ok := ackerr.RequeueAfterFor(tt.kind, tt.err, overrides)
+			assert.Equal(t, tt.expectedAfter, after)
+			assert.Equal(t, tt.expectedOK, ok)
+		})
+	}
+}
+
+func TestThrottleDelay(t *testing.T) {
+	responseError := func(retryAfter string) error {
+		header := http.Header{}
+		if retryAfter != "" {
+			header.Set("Retry-After", retryAfter)
+		}
+		return &smithy.OperationError{
+			ServiceID:     "Route 53",
+			OperationName: "ChangeResourceRecordSets",
+			Err: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 429, Header: header}},
+				Err:      &smithy.GenericAPIError{Code: "Throttling"},
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		err      error
+		expected time.Duration
+		ok       bool
+	}{
+		{"nil error", nil, 0, false},
+		{"non AWS error", errors.New("oops"), 0, false},
+		{"no hint", responseError(""), 0, false},
+		{"seconds", responseError("7"), 7 * time.Second, true},
+		{"negative seconds", responseError("-1"), 0, false},
+		{"past date", responseError("Wed, 21 Oct 2015 07:28:00 GMT"), 0, true},
+		{"invalid", responseError("soon"), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := ackerr.ThrottleDelay(tt.err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, delay)
+		})
+	}
+}
+
+func TestHTTPResponseDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		requestID  string
+	}{
+		{"nil error", nil, -1, ""},
+		{"non AWS error", errors.New("oops"), -1, ""},
+		{"API error without response", &smithy.GenericAPIError{Code: "ValidationException"}, -1, ""},
+		{"response error", &smithy.OperationError{
+			ServiceID:     "SNS",
+			OperationName: "CreateTopic",
+			Err: &awshttp.ResponseError{
+				ResponseError: &smithyhttp.ResponseError{
+					Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 400}},
+					Err:      &smithy.GenericAPIError{Code: "InvalidParameter", Fault: smithy.FaultClient},
+				},
+				RequestID: "b2c5a8e4-1f0e-4a7b-9d3c-6e2f1a0b9c8d",
+			},
+		}, 400, "b2c5a8e4-1f0e-4a7b-9d3c-6e2f1a0b9c8d"},
+		{"response error without request ID", &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 503}},
+			Err:      errors.New("service unavailable"),
+		}, 503, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.statusCode, ackerr.HTTPStatusCode(tt.err))
+			assert.Equal(t, tt.requestID, ackerr.RequestID(tt.err))
+		})
+	}
+}
+
+func TestAccessDenied(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected ackerr.AccessDeniedDetails
+		denied   bool
+	}{
+		{"nil error", nil, ackerr.AccessDeniedDetails{}, false},
+		{"other AWS error", &smithy.GenericAPIError{Code: "OptInRequired"}, ackerr.AccessDeniedDetails{}, false},
+		{"access denied", &smithy.GenericAPIError{Code: "AccessDeniedException"}, ackerr.AccessDeniedDetails{}, true},
+		{"operation error", fmt.Errorf("creating: %w", &smithy.OperationError{
+			ServiceID:     "EC2",
+			OperationName: "RunInstances",
+			Err: &smithy.GenericAPIError{
+				Code:    "UnauthorizedOperation",
+				Message: "You are not authorized to perform this operation. Encoded authorization failure message: abc-123_XYZ",
+			},
+		}), ackerr.AccessDeniedDetails{
+			Action:                      "ec2:RunInstances",
+			EncodedAuthorizationMessage: "abc-123_XYZ",
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details, denied := ackerr.AccessDenied(tt.err)
+			assert.Equal(t, tt.denied, denied)
+			assert.Equal(t, tt.expected, details)
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	first := fmt.Errorf(
+		"operation error S3: CreateBucket, https response error StatusCode: 409, RequestID: 5GZAXTJ1, " +
+			"HostID: abc, api error BucketAlreadyExists: bucket exists since 2026-01-01T10:00:00Z",
+	)
+	second := fmt.Errorf(
+		"operation error S3: CreateBucket, https response error StatusCode: 409, RequestID: 9QW2E8, " +
+			"HostID: abc, api error BucketAlreadyExists: bucket exists since 2026-01-02T11:30:00.123Z",
+	)
+	other := &smithy.GenericAPIError{Code: "AccessDenied", Message: "request 6f1c2d3e-1234-5678-9abc-def012345678 denied"}
+
+	assert.Equal(t, "", ackerr.Fingerprint(nil))
+	assert.Len(t, ackerr.Fingerprint(first), 16)
+	assert.Equal(t, ackerr.Fingerprint(first), ackerr.Fingerprint(second))
+	assert.NotEqual(t, ackerr.Fingerprint(first), ackerr.Fingerprint(other))
+	assert.Equal(t, "api error AccessDenied: request * denied", ackerr.NormalizeMessage(other.Error()))
+}
+
+func TestResourceNotFoundError(t *testing.T) {
+	nfErr := ackerr.NewResourceNotFound(
+		schema.GroupKind{Group: "s3.services.k8s.aws", Kind: "Bucket"},
+		types.NamespacedName{Namespace: "default", Name: "my-bucket"},
+		"arn:aws:s3:::my-bucket",
+	)
+	err := fmt.Errorf("reading resource: %w", nfErr)
+	assert.True(t, errors.Is(err, ackerr.NotFound))
+	assert.True(t, ackerr.IsNotFound(err))
+	assert.Equal(t, "Bucket.s3.services.k8s.aws default/my-bucket (arn:aws:s3:::my-bucket): resource not found", nfErr.Error())
+
+	var found *ackerr.ResourceNotFoundError
+	assert.True(t, errors.As(err, &found))
+	assert.Equal(t, "arn:aws:s3:::my-bucket", found.Identifier)
+
+	nfErr.Err = ackerr.AdoptedResourceNotFound
+	nfErr.Identifier = ""
+	assert.True(t, errors.Is(nfErr, ackerr.AdoptedResourceNotFound))
+	assert.False(t, ackerr.IsNotFound(nfErr))
+	assert.Equal(t, "Bucket.s3.services.k8s.aws default/my-bucket: adopted resource not found", nfErr.Error())
+	assert.False(t, ackerr.IsNotFound(errors.New("resource not found")))
+}
+
+func TestResourceReferencesNotSynced(t *testing.T) {
+	role := ackerr.ResourceReferenceNotSyncedFor("Role", "ns", "my-role")
+	assert.True(t, errors.Is(role, ackerr.ResourceReferenceNotSynced))
+	assert.Equal(t,
+		"the referenced resource is not synced yet. resource:Role, namespace:ns, name:my-role",
+		role.Error(),
+	)
+
+	subnet := ackerr.ResourceReferenceNotSyncedFor("Subnet", "ns", "my-subnet")
+	tests := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{"nil error", nil, nil},
+		{"other error", ackerr.ResourceReferenceTerminalFor("Role", "ns", "my-role"), nil},
+		{"not synced", role, []string{"Role/my-role"}},
+		{"wrapped", fmt.Errorf("resolving: %w", role), []string{"Role/my-role"}},
+		{"joined", errors.Join(role, errors.New("oops"), fmt.Errorf("resolving: %w", subnet)), []string{"Role/my-role", "Subnet/my-subnet"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ref := range ackerr.ResourceReferencesNotSynced(tt.err) {
+				got = append(got, ref.Resource+"/"+ref.Name)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
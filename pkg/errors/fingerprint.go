@@ -0,0 +1,52 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+)
+
+// volatileMessageParts match the parts of error messages that change from one
+// occurrence of an error to the next, e.g. request IDs and timestamps
+var volatileMessageParts = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)(request ?id:?\s*)[^\s,]+`), "${1}*"},
+	{regexp.MustCompile(`(Encoded authorization failure message:\s*)\S+`), "${1}*"},
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "*"},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "*"},
+}
+
+// NormalizeMessage returns the supplied error message with its volatile
+// parts, e.g. request IDs, UUIDs and timestamps, replaced with '*', so that
+// the messages of two occurrences of the same error are equal.
+func NormalizeMessage(message string) string {
+	for _, part := range volatileMessageParts {
+		message = part.re.ReplaceAllString(message, part.replacement)
+	}
+	return message
+}
+
+// Fingerprint returns a short hash identifying the supplied error, equal for
+// all the occurrences of the same error, or an empty string for a nil error
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(NormalizeMessage(err.Error())))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,85 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ResourceNotFoundError is returned when an expected AWS resource was not
+// found. It identifies the missing resource and matches the sentinel error
+// it wraps, NotFound unless stated otherwise, with errors.Is.
+type ResourceNotFoundError struct {
+	// GroupKind is the group and kind of the custom resource
+	GroupKind schema.GroupKind
+	// NamespacedName is the namespace and name of the custom resource
+	NamespacedName types.NamespacedName
+	// Identifier identifies the AWS resource, e.g. its ARN, empty if it is
+	// not known
+	Identifier string
+	// Err is the wrapped sentinel error, e.g. NotFound or
+	// AdoptedResourceNotFound
+	Err error
+}
+
+// NewResourceNotFound returns a NotFound error identifying the missing AWS
+// resource
+func NewResourceNotFound(
+	groupKind schema.GroupKind,
+	namespacedName types.NamespacedName,
+	identifier string,
+) *ResourceNotFoundError {
+	return &ResourceNotFoundError{
+		GroupKind:      groupKind,
+		NamespacedName: namespacedName,
+		Identifier:     identifier,
+		Err:            NotFound,
+	}
+}
+
+// Error returns the message of the wrapped error prefixed with the identity
+// of the resource, e.g. "Bucket.s3.services.k8s.aws default/my-bucket
+// (arn:aws:s3:::my-bucket): resource not found"
+func (e *ResourceNotFoundError) Error() string {
+	var b strings.Builder
+	if e.GroupKind.Kind != "" {
+		b.WriteString(e.GroupKind.String())
+		b.WriteString(" ")
+	}
+	b.WriteString(e.NamespacedName.String())
+	if e.Identifier != "" {
+		fmt.Fprintf(&b, " (%s)", e.Identifier)
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Unwrap().Error())
+	return b.String()
+}
+
+// Unwrap returns the wrapped sentinel error
+func (e *ResourceNotFoundError) Unwrap() error {
+	if e.Err == nil {
+		return NotFound
+	}
+	return e.Err
+}
+
+// IsNotFound returns true if the supplied error is, or wraps, NotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, NotFound)
+}
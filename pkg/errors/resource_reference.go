@@ -72,13 +72,57 @@ func ResourceReferenceTerminalFor(resource string, namespace string,
 		ResourceReferenceTerminal, resource, namespace, name)
 }
 
+// ResourceReferenceNotSyncedError is the ResourceReferenceNotSynced error of
+// a referenced resource, identifying the resource so that its reconciliation
+// can be hastened
+type ResourceReferenceNotSyncedError struct {
+	// Resource is the kind of the referenced resource
+	Resource  string
+	Namespace string
+	Name      string
+}
+
+// Error implements error
+func (e *ResourceReferenceNotSyncedError) Error() string {
+	return fmt.Sprintf("%s. resource:%s, namespace:%s, name:%s",
+		ResourceReferenceNotSynced, e.Resource, e.Namespace, e.Name)
+}
+
+// Unwrap returns ResourceReferenceNotSynced
+func (e *ResourceReferenceNotSyncedError) Unwrap() error {
+	return ResourceReferenceNotSynced
+}
+
 // ResourceReferenceNotSyncedFor returns a ResourceReferenceNotSynced for
 // supplied resource
 func ResourceReferenceNotSyncedFor(resource string, namespace string,
 	name string,
 ) error {
-	return fmt.Errorf("%w. resource:%s, namespace:%s, name:%s",
-		ResourceReferenceNotSynced, resource, namespace, name)
+	return &ResourceReferenceNotSyncedError{
+		Resource:  resource,
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// ResourceReferencesNotSynced returns the referenced resources not synced yet
+// reported by the supplied error, which may wrap or join several errors
+func ResourceReferencesNotSynced(err error) []*ResourceReferenceNotSyncedError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *ResourceReferenceNotSyncedError:
+		return []*ResourceReferenceNotSyncedError{e}
+	case interface{ Unwrap() []error }:
+		var refs []*ResourceReferenceNotSyncedError
+		for _, wrapped := range e.Unwrap() {
+			refs = append(refs, ResourceReferencesNotSynced(wrapped)...)
+		}
+		return refs
+	case interface{ Unwrap() error }:
+		return ResourceReferencesNotSynced(e.Unwrap())
+	}
+	return nil
 }
 
 // ResourceReferenceMissingTargetFieldFor returns a ResourceReferenceMissingTargetField
@@ -0,0 +1,319 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package events contains the registry of the reasons of the Kubernetes
+// events emitted by ACK service controllers.
+//
+// Event reasons are part of the interface of the controllers: downstream
+// alerting and tooling select events by reason, so reasons must be stable
+// and documented. The runtime standard reasons are registered by this
+// package, and service controllers register their own reasons, usually in an
+// init function, with MustRegister.
+package events
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Reason is the reason of a Kubernetes event. By convention, reasons are
+// UpperCamelCase, e.g. "CreateSucceeded".
+type Reason string
+
+const (
+	// ReasonCreateStarted is emitted when the creation of an AWS resource
+	// starts
+	ReasonCreateStarted Reason = "CreateStarted"
+	// ReasonCreateSucceeded is emitted when an AWS resource is created
+	ReasonCreateSucceeded Reason = "CreateSucceeded"
+	// ReasonCreateFailed is emitted when the AWS service API rejects the
+	// creation of an AWS resource
+	ReasonCreateFailed Reason = "CreateFailed"
+	// ReasonCreateRecovered is emitted when the interrupted creation of an
+	// AWS resource is completed, e.g. after a restart of the controller
+	ReasonCreateRecovered Reason = "CreateRecovered"
+	// ReasonDriftDetected is emitted when the latest observed state of an
+	// AWS resource differs from its desired state
+	ReasonDriftDetected Reason = "DriftDetected"
+	// ReasonUpdateSucceeded is emitted when an AWS resource is updated
+	ReasonUpdateSucceeded Reason = "UpdateSucceeded"
+	// ReasonUpdateFailed is emitted when the AWS service API rejects the
+	// update of an AWS resource
+	ReasonUpdateFailed Reason = "UpdateFailed"
+	// ReasonUpdateSkippedPaused is emitted when the update of an AWS
+	// resource is skipped because the reconciliation of the resource is
+	// paused
+	ReasonUpdateSkippedPaused Reason = "UpdateSkippedPaused"
+	// ReasonUpdateRolledBack is emitted when the successful steps of a
+	// failed update of an AWS resource are undone
+	ReasonUpdateRolledBack Reason = "UpdateRolledBack"
+	// ReasonUpdateRollbackFailed is emitted when some successful steps of a
+	// failed update of an AWS resource could not be undone, leaving the AWS
+	// resource partially updated
+	ReasonUpdateRollbackFailed Reason = "UpdateRollbackFailed"
+	// ReasonDeleteStarted is emitted when the deletion of an AWS resource
+	// starts
+	ReasonDeleteStarted Reason = "DeleteStarted"
+	// ReasonDeleteSucceeded is emitted when an AWS resource is deleted
+	ReasonDeleteSucceeded Reason = "DeleteSucceeded"
+	// ReasonDeleteFailed is emitted when the AWS service API rejects the
+	// deletion of an AWS resource
+	ReasonDeleteFailed Reason = "DeleteFailed"
+	// ReasonDeleteBlocked is emitted when the deletion of an AWS resource is
+	// blocked, e.g. by resources depending on it
+	ReasonDeleteBlocked Reason = "DeleteBlocked"
+	// ReasonDesiredStateOverlaid is emitted when a break-glass desired
+	// state overlay is applied on top of the desired state of a resource
+	ReasonDesiredStateOverlaid Reason = "DesiredStateOverlaid"
+	// ReasonAdopted is emitted when an existing AWS resource is adopted
+	ReasonAdopted Reason = "Adopted"
+	// ReasonRegionNotEnabled is emitted when the AWS service API rejects a
+	// call because the region of the resource is not enabled for its AWS
+	// account
+	ReasonRegionNotEnabled Reason = "RegionNotEnabled"
+	// ReasonRegionAccountChanged is emitted when the region or the owner
+	// account resolved for a created resource differs from the one it was
+	// created in, and the resource is not reconciled until the move is
+	// authorized
+	ReasonRegionAccountChanged Reason = "RegionAccountChanged"
+	// ReasonRegionAccountMigrated is emitted when a resource is reconciled in
+	// the region and owner account its migration annotation authorizes
+	ReasonRegionAccountMigrated Reason = "RegionAccountMigrated"
+	// ReasonRegionFailover is emitted when a resource of a global AWS
+	// service is reconciled through the endpoints of another region, because
+	// the endpoints of its region fail
+	ReasonRegionFailover Reason = "RegionFailover"
+	// ReasonAccessDenied is emitted when the IAM policies of the controller
+	// do not allow a call to the AWS service API, with the details of the
+	// denied call
+	ReasonAccessDenied Reason = "AccessDenied"
+	// ReasonRequeueStorm is emitted when a resource is reconciled again and
+	// again without its state changing, and its reconciliations are
+	// suspended for a cool-down period
+	ReasonRequeueStorm Reason = "RequeueStorm"
+	// ReasonProgressDeadlineExceeded is emitted when a resource did not sync
+	// within the progress deadline of its kind, and is marked as degraded
+	ReasonProgressDeadlineExceeded Reason = "ProgressDeadlineExceeded"
+	// ReasonOperationNotPermitted is emitted when an operation on a resource
+	// is skipped because the controller is not granted the IAM actions it
+	// requires
+	ReasonOperationNotPermitted Reason = "OperationNotPermitted"
+	// ReasonPlanned is emitted when the reconciliation of a resource
+	// annotated with services.k8s.aws/plan is planned rather than acted on
+	ReasonPlanned Reason = "Planned"
+	// ReasonAssumeRoleFailed is emitted on a resource when the IAM role of
+	// its namespace account binding could not be assumed
+	ReasonAssumeRoleFailed Reason = "AssumeRoleFailed"
+	// ReasonAccountBindingBroken is emitted on a namespace when the IAM role
+	// of its account binding repeatedly could not be assumed
+	ReasonAccountBindingBroken Reason = "AccountBindingBroken"
+	// ReasonAccountBindingRecovered is emitted on a namespace when the IAM
+	// role of its broken account binding could be assumed again
+	ReasonAccountBindingRecovered Reason = "AccountBindingRecovered"
+	// ReasonResourceManagerUnavailable is emitted on a namespace when the
+	// resource manager of the account and region of its resources of a kind
+	// could not be constructed, and their reconciliations are backed off
+	ReasonResourceManagerUnavailable Reason = "ResourceManagerUnavailable"
+	// ReasonResourceManagerRecovered is emitted on a namespace when the
+	// resource manager of the account and region of its resources of a kind
+	// could be constructed again
+	ReasonResourceManagerRecovered Reason = "ResourceManagerRecovered"
+	// ReasonCircuitBreakerOpen is emitted on a namespace when the calls to
+	// the AWS service API of the account and region of its resources
+	// persistently failed, and their reconciliations are paused for a
+	// cool-down period
+	ReasonCircuitBreakerOpen Reason = "CircuitBreakerOpen"
+	// ReasonResourceNotFound is emitted when the AWS resource of an adopted
+	// or read-only resource is not found
+	ReasonResourceNotFound Reason = "ResourceNotFound"
+	// ReasonTerminal is emitted when the reconciliation of a resource fails
+	// with an error that retrying cannot resolve
+	ReasonTerminal Reason = "Terminal"
+	// ReasonRequeuedWithBackoff is emitted when the reconciliation of a
+	// resource failed and is retried with an increasing delay
+	ReasonRequeuedWithBackoff Reason = "RequeuedWithBackoff"
+	// ReasonPaused is emitted when the reconciliations of a resource are
+	// paused by the services.k8s.aws/paused annotation of the resource or of
+	// its namespace
+	ReasonPaused Reason = "Paused"
+	// ReasonResumed is emitted when the reconciliations of a paused resource
+	// resume
+	ReasonResumed Reason = "Resumed"
+	// ReasonUpdatePending is emitted when the update of an AWS resource is
+	// held by the debounce window of its kind
+	ReasonUpdatePending Reason = "UpdatePending"
+	// ReasonChangeDeferred is emitted when the creation, update or deletion
+	// of an AWS resource is deferred until its maintenance window opens
+	ReasonChangeDeferred Reason = "ChangeDeferred"
+	// ReasonPolicyViolation is emitted when the creation or update of an AWS
+	// resource would violate an invariant spanning several resources
+	ReasonPolicyViolation Reason = "PolicyViolation"
+	// ReasonPolicyDenied is emitted when the creation, update or deletion of
+	// an AWS resource is denied by a policy
+	ReasonPolicyDenied Reason = "PolicyDenied"
+)
+
+// ReasonInfo documents an event reason.
+type ReasonInfo struct {
+	// Reason is the event reason
+	Reason Reason
+	// Type is the type of the events emitted with the reason, either
+	// corev1.EventTypeNormal or corev1.EventTypeWarning
+	Type string
+	// Description documents when events are emitted with the reason
+	Description string
+}
+
+// reasonRegexp matches valid event reasons
+var reasonRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+var (
+	registryLock sync.RWMutex
+	// registry holds the registered event reasons
+	registry = map[Reason]ReasonInfo{}
+)
+
+func init() {
+	for _, info := range []ReasonInfo{
+		{ReasonCreateStarted, corev1.EventTypeNormal, "The creation of the AWS resource started"},
+		{ReasonCreateSucceeded, corev1.EventTypeNormal, "The AWS resource was created"},
+		{ReasonCreateFailed, corev1.EventTypeWarning, "The AWS service API rejected the creation of the AWS resource"},
+		{ReasonCreateRecovered, corev1.EventTypeNormal, "The interrupted creation of the AWS resource was completed"},
+		{ReasonDriftDetected, corev1.EventTypeNormal, "The latest observed state of the AWS resource differs from its desired state"},
+		{ReasonUpdateSucceeded, corev1.EventTypeNormal, "The AWS resource was updated"},
+		{ReasonUpdateFailed, corev1.EventTypeWarning, "The AWS service API rejected the update of the AWS resource"},
+		{ReasonUpdateSkippedPaused, corev1.EventTypeNormal, "The update of the AWS resource was skipped because its reconciliation is paused"},
+		{ReasonUpdateRolledBack, corev1.EventTypeWarning, "The successful steps of a failed update of the AWS resource were undone"},
+		{ReasonUpdateRollbackFailed, corev1.EventTypeWarning, "The successful steps of a failed update of the AWS resource could not all be undone"},
+		{ReasonDeleteStarted, corev1.EventTypeNormal, "The deletion of the AWS resource started"},
+		{ReasonDeleteSucceeded, corev1.EventTypeNormal, "The AWS resource was deleted"},
+		{ReasonDeleteFailed, corev1.EventTypeWarning, "The AWS service API rejected the deletion of the AWS resource"},
+		{ReasonDeleteBlocked, corev1.EventTypeWarning, "The deletion of the AWS resource is blocked"},
+		{ReasonDesiredStateOverlaid, corev1.EventTypeWarning, "A break-glass overlay was applied on top of the desired state of the resource"},
+		{ReasonAdopted, corev1.EventTypeNormal, "An existing AWS resource was adopted"},
+		{ReasonRegionNotEnabled, corev1.EventTypeWarning, "The region of the AWS resource is not enabled for its AWS account"},
+		{ReasonRegionAccountChanged, corev1.EventTypeWarning, "The region or owner account resolved for the AWS resource differs from the one it was created in"},
+		{ReasonRegionAccountMigrated, corev1.EventTypeNormal, "The resource is reconciled in the region and owner account its migration annotation authorizes"},
+		{ReasonRegionFailover, corev1.EventTypeWarning, "The resource of a global AWS service is reconciled through the endpoints of another region"},
+		{ReasonAccessDenied, corev1.EventTypeWarning, "The IAM policies of the controller do not allow a call to the AWS service API"},
+		{ReasonRequeueStorm, corev1.EventTypeWarning, "The resource is reconciled again and again without its state changing, its reconciliations are suspended for a while"},
+		{ReasonProgressDeadlineExceeded, corev1.EventTypeWarning, "The resource did not sync within the progress deadline of its kind and is marked as degraded"},
+		{ReasonOperationNotPermitted, corev1.EventTypeWarning, "An operation was skipped because the controller is not granted the IAM actions it requires"},
+		{ReasonPlanned, corev1.EventTypeNormal, "The reconciliation of the resource was planned, without acting on the AWS resource"},
+		{ReasonAssumeRoleFailed, corev1.EventTypeWarning, "The IAM role of the namespace account binding could not be assumed"},
+		{ReasonAccountBindingBroken, corev1.EventTypeWarning, "The IAM role of the namespace account binding repeatedly could not be assumed"},
+		{ReasonAccountBindingRecovered, corev1.EventTypeNormal, "The IAM role of the namespace account binding can be assumed again"},
+		{ReasonResourceManagerUnavailable, corev1.EventTypeWarning, "The resource manager of an AWS account and region could not be constructed, the reconciliations of its resources are backed off"},
+		{ReasonResourceManagerRecovered, corev1.EventTypeNormal, "The resource manager of an AWS account and region could be constructed again"},
+		{ReasonCircuitBreakerOpen, corev1.EventTypeWarning, "The calls to the AWS service API of an AWS account and region persistently failed, the reconciliations of its resources are paused"},
+		{ReasonResourceNotFound, corev1.EventTypeWarning, "The AWS resource of an adopted or read-only resource was not found"},
+		{ReasonTerminal, corev1.EventTypeWarning, "The reconciliation of the resource failed with an error that retrying cannot resolve, the resource must be changed"},
+		{ReasonRequeuedWithBackoff, corev1.EventTypeWarning, "The reconciliation of the resource failed and is retried with an increasing delay"},
+		{ReasonPaused, corev1.EventTypeNormal, "The reconciliations of the resource are paused by the services.k8s.aws/paused annotation, the AWS resource is neither read nor changed"},
+		{ReasonResumed, corev1.EventTypeNormal, "The reconciliations of the paused resource resumed"},
+		{ReasonUpdatePending, corev1.EventTypeNormal, "The update of the AWS resource is held by the debounce window of its kind, to apply the changes made meanwhile at once"},
+		{ReasonChangeDeferred, corev1.EventTypeNormal, "The creation, update or deletion of the AWS resource is deferred until its maintenance window opens"},
+		{ReasonPolicyViolation, corev1.EventTypeWarning, "The creation or update of the AWS resource would violate an invariant spanning several resources"},
+		{ReasonPolicyDenied, corev1.EventTypeWarning, "The creation, update or deletion of the AWS resource is denied by a policy"},
+	} {
+		MustRegister(info)
+	}
+}
+
+// Register adds an event reason to the registry. Registering a reason twice
+// with the same type is a no-op, registering it with a different type is an
+// error.
+func Register(info ReasonInfo) error {
+	if !reasonRegexp.MatchString(string(info.Reason)) {
+		return fmt.Errorf("invalid event reason %q: expected an UpperCamelCase string", info.Reason)
+	}
+	if info.Type != corev1.EventTypeNormal && info.Type != corev1.EventTypeWarning {
+		return fmt.Errorf(
+			"invalid type %q for event reason %q: expected %s or %s",
+			info.Type, info.Reason, corev1.EventTypeNormal, corev1.EventTypeWarning,
+		)
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if existing, ok := registry[info.Reason]; ok && existing.Type != info.Type {
+		return fmt.Errorf(
+			"event reason %q is already registered with type %s", info.Reason, existing.Type,
+		)
+	}
+	registry[info.Reason] = info
+	return nil
+}
+
+// MustRegister is like Register but panics if the reason cannot be
+// registered.
+func MustRegister(info ReasonInfo) {
+	if err := Register(info); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the registered information of an event reason.
+func Lookup(reason Reason) (ReasonInfo, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	info, ok := registry[reason]
+	return info, ok
+}
+
+// Registered returns the registered event reasons, sorted by reason.
+func Registered() []ReasonInfo {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	infos := make([]ReasonInfo, 0, len(registry))
+	for _, info := range registry {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Reason < infos[j].Reason
+	})
+	return infos
+}
+
+// Lint checks that the supplied recorded events, formatted as
+// record.FakeRecorder formats them ("<type> <reason> <message>"), all have a
+// registered reason and the registered type. It is meant to be used by the
+// tests of service controllers, to catch unregistered event reasons.
+func Lint(recorded []string) error {
+	problems := []string{}
+	for _, event := range recorded {
+		fields := strings.SplitN(event, " ", 3)
+		if len(fields) < 2 {
+			problems = append(problems, fmt.Sprintf("malformed event %q", event))
+			continue
+		}
+		info, ok := Lookup(Reason(fields[1]))
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unregistered event reason %q", fields[1]))
+			continue
+		}
+		if info.Type != fields[0] {
+			problems = append(problems, fmt.Sprintf(
+				"event reason %q recorded with type %s instead of %s", fields[1], fields[0], info.Type,
+			))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid events: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
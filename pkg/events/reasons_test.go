@@ -0,0 +1,154 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/logschema"
+)
+
+func TestRegister(t *testing.T) {
+	require := require.New(t)
+
+	info, ok := events.Lookup(events.ReasonCreateFailed)
+	require.True(ok)
+	require.Equal(corev1.EventTypeWarning, info.Type)
+	require.NotEmpty(info.Description)
+
+	custom := events.ReasonInfo{
+		Reason:      "BucketPolicyApplied",
+		Type:        corev1.EventTypeNormal,
+		Description: "The bucket policy was applied",
+	}
+	require.Nil(events.Register(custom))
+	// Registering a reason again with the same type is a no-op
+	require.Nil(events.Register(custom))
+	custom.Type = corev1.EventTypeWarning
+	require.NotNil(events.Register(custom))
+
+	require.NotNil(events.Register(events.ReasonInfo{Reason: "notCamelCase", Type: corev1.EventTypeNormal}))
+	require.NotNil(events.Register(events.ReasonInfo{Reason: "Valid", Type: "Error"}))
+
+	registered := events.Registered()
+	for i := 1; i < len(registered); i++ {
+		require.Less(string(registered[i-1].Reason), string(registered[i].Reason))
+	}
+}
+
+func TestRecorderAndLint(t *testing.T) {
+	require := require.New(t)
+
+	fake := record.NewFakeRecorder(10)
+	recorder := events.NewRecorder(fake)
+	obj := &ackv1alpha1.AdoptedResource{}
+	recorder.Event(obj, events.ReasonCreateSucceeded, "Created AWS resource")
+	recorder.Eventf(obj, events.ReasonDeleteFailed, "Unable to delete AWS resource: %s", "oops")
+	recorder.Event(obj, events.Reason("SomethingHappened"), "unregistered")
+	close(fake.Events)
+
+	recorded := []string{}
+	for event := range fake.Events {
+		recorded = append(recorded, event)
+	}
+	require.Equal([]string{
+		"Normal CreateSucceeded Created AWS resource",
+		"Warning DeleteFailed Unable to delete AWS resource: oops",
+		"Warning SomethingHappened unregistered",
+	}, recorded)
+
+	require.Nil(events.Lint(recorded[:2]))
+	err := events.Lint(recorded)
+	require.NotNil(err)
+	require.Contains(err.Error(), `unregistered event reason "SomethingHappened"`)
+	err = events.Lint([]string{"Warning CreateSucceeded Created AWS resource", "oops"})
+	require.NotNil(err)
+	require.Contains(err.Error(), "recorded with type Warning instead of Normal")
+	require.Contains(err.Error(), "malformed event")
+
+	// A nil recorder drops events
+	var nilRecorder *events.Recorder
+	nilRecorder.Event(obj, events.ReasonCreateStarted, "dropped")
+}
+
+func TestRecorder_WithDeduplication(t *testing.T) {
+	require := require.New(t)
+
+	fake := record.NewFakeRecorder(10)
+	recorder := events.NewRecorder(fake).WithDeduplication(time.Hour)
+	first := &ackv1alpha1.AdoptedResource{}
+	first.SetNamespace("default")
+	first.SetName("first")
+	second := first.DeepCopy()
+	second.SetName("second")
+
+	recorder.Event(first, events.ReasonTerminal, "Reconciliation failed with a terminal error: oops")
+	recorder.Event(first, events.ReasonTerminal, "Reconciliation failed with a terminal error: oops")
+	recorder.Event(second, events.ReasonTerminal, "Reconciliation failed with a terminal error: oops")
+	recorder.Event(first, events.ReasonTerminal, "Reconciliation failed with a terminal error: other")
+	close(fake.Events)
+
+	recorded := []string{}
+	for event := range fake.Events {
+		recorded = append(recorded, event)
+	}
+	require.Equal([]string{
+		"Warning Terminal Reconciliation failed with a terminal error: oops",
+		"Warning Terminal Reconciliation failed with a terminal error: oops",
+		"Warning Terminal Reconciliation failed with a terminal error: other",
+	}, recorded)
+}
+
+func TestRecorder_WithLogger(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	log := ctrlrtzap.New(
+		ctrlrtzap.WriteTo(&buf),
+		ctrlrtzap.Encoder(zapcore.NewJSONEncoder(logschema.EncoderConfig())),
+	)
+	fake := record.NewFakeRecorder(10)
+	recorder := events.NewRecorder(fake).WithLogger(log)
+	obj := &ackv1alpha1.AdoptedResource{}
+	obj.SetGroupVersionKind(ackv1alpha1.GroupVersion.WithKind("AdoptedResource"))
+	obj.SetNamespace("default")
+	obj.SetName("my-book")
+	obj.SetUID("1234")
+	recorder.Event(obj, events.ReasonDeleteFailed, "Unable to delete AWS resource: oops")
+	close(fake.Events)
+
+	// The event is both emitted and written as a log line
+	require.Equal("Warning DeleteFailed Unable to delete AWS resource: oops", <-fake.Events)
+	line := map[string]interface{}{}
+	require.Nil(json.Unmarshal(buf.Bytes(), &line))
+	require.Equal(logschema.MessageEvent, line[logschema.KeyMessage])
+	require.Equal("Warning", line[logschema.KeyEventType])
+	require.Equal("DeleteFailed", line[logschema.KeyEventReason])
+	require.Equal("Unable to delete AWS resource: oops", line[logschema.KeyEventMessage])
+	require.Equal("AdoptedResource", line[logschema.KeyObjectKind])
+	require.Equal("default", line[logschema.KeyObjectNamespace])
+	require.Equal("my-book", line[logschema.KeyObjectName])
+	require.Equal("1234", line[logschema.KeyObjectUID])
+}
@@ -0,0 +1,153 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/logschema"
+)
+
+// emitted identifies an event emitted on an object
+type emitted struct {
+	object  string
+	reason  Reason
+	message string
+}
+
+// Recorder emits Kubernetes events with registered reasons. The type of the
+// events is the registered type of their reason. A nil Recorder drops all
+// events.
+type Recorder struct {
+	recorder record.EventRecorder
+	// dedupeWindow is the duration during which the events identical to an
+	// emitted event are dropped, 0 if no event is dropped
+	dedupeWindow time.Duration
+	dedupeLock   sync.Mutex
+	// emitted holds the time each event was last emitted at
+	emitted map[emitted]time.Time
+	// sweptAt is the time the events emitted before the deduplication
+	// window were last removed from emitted
+	sweptAt time.Time
+	// now returns the current time
+	now func() time.Time
+	// log writes the emitted events as log lines, if logEvents is true
+	log       logr.Logger
+	logEvents bool
+}
+
+// NewRecorder returns a Recorder emitting events with the supplied
+// record.EventRecorder
+func NewRecorder(recorder record.EventRecorder) *Recorder {
+	return &Recorder{recorder: recorder, now: time.Now}
+}
+
+// WithDeduplication makes the Recorder drop the events with the same reason
+// and message as an event emitted on the same object during the supplied
+// window, so that the reconciliations of a resource failing again and again
+// do not flood it with events
+func (r *Recorder) WithDeduplication(window time.Duration) *Recorder {
+	r.dedupeWindow = window
+	r.emitted = map[emitted]time.Time{}
+	return r
+}
+
+// WithLogger makes the Recorder also write the events it emits as log lines
+// of the log schema with the supplied logger, so that the log pipelines
+// ingest them along with the logs of the controller
+func (r *Recorder) WithLogger(log logr.Logger) *Recorder {
+	r.log = log
+	r.logEvents = true
+	return r
+}
+
+// logEvent writes the supplied emitted event as a log line
+func (r *Recorder) logEvent(obj runtime.Object, eventType string, reason Reason, message string) {
+	values := []interface{}{
+		logschema.KeyEventType, eventType,
+		logschema.KeyEventReason, string(reason),
+		logschema.KeyEventMessage, message,
+		logschema.KeyObjectKind, obj.GetObjectKind().GroupVersionKind().Kind,
+	}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		values = append(values,
+			logschema.KeyObjectNamespace, accessor.GetNamespace(),
+			logschema.KeyObjectName, accessor.GetName(),
+			logschema.KeyObjectUID, string(accessor.GetUID()),
+		)
+	}
+	r.log.Info(logschema.MessageEvent, values...)
+}
+
+// duplicate returns true if the supplied event was emitted on the supplied
+// object during the deduplication window, and records it otherwise
+func (r *Recorder) duplicate(obj runtime.Object, reason Reason, message string) bool {
+	if r.dedupeWindow <= 0 {
+		return false
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	key := emitted{
+		object:  accessor.GetNamespace() + "/" + accessor.GetName() + "/" + string(accessor.GetUID()),
+		reason:  reason,
+		message: message,
+	}
+	now := r.now()
+	r.dedupeLock.Lock()
+	defer r.dedupeLock.Unlock()
+	if at, ok := r.emitted[key]; ok && now.Sub(at) < r.dedupeWindow {
+		return true
+	}
+	if now.Sub(r.sweptAt) >= r.dedupeWindow {
+		for k, at := range r.emitted {
+			if now.Sub(at) >= r.dedupeWindow {
+				delete(r.emitted, k)
+			}
+		}
+		r.sweptAt = now
+	}
+	r.emitted[key] = now
+	return false
+}
+
+// Event emits an event with the supplied reason on the supplied object.
+// Events with unregistered reasons are emitted as Warning events.
+func (r *Recorder) Event(obj runtime.Object, reason Reason, message string) {
+	if r == nil || r.recorder == nil || obj == nil || r.duplicate(obj, reason, message) {
+		return
+	}
+	eventType := corev1.EventTypeWarning
+	if info, ok := Lookup(reason); ok {
+		eventType = info.Type
+	}
+	r.recorder.Event(obj, eventType, string(reason), message)
+	if r.logEvents {
+		r.logEvent(obj, eventType, reason, message)
+	}
+}
+
+// Eventf is like Event but formats its message with fmt.Sprintf.
+func (r *Recorder) Eventf(obj runtime.Object, reason Reason, format string, args ...interface{}) {
+	r.Event(obj, reason, fmt.Sprintf(format, args...))
+}
@@ -31,15 +31,32 @@ const (
 
 	// ServiceLevelCARM is a feature gate for enabling CARM for service-level resources.
 	ServiceLevelCARM = "ServiceLevelCARM"
+
+	// ReferenceCaching is a feature gate for enabling caching of the objects
+	// read while resolving resource references.
+	ReferenceCaching = "ReferenceCaching"
+
+	// ReferenceOwnerReferences is a feature gate for enabling owner references
+	// from ACK resources to the ACK resources they reference.
+	ReferenceOwnerReferences = "ReferenceOwnerReferences"
+
+	// ReferenceIndex is a feature gate for enabling the index of the ACK
+	// resources referencing each resource, which protects referenced
+	// resources from deletion and reconciles their referrers when they
+	// change.
+	ReferenceIndex = "ReferenceIndex"
 )
 
 // defaultACKFeatureGates is a map of feature names to Feature structs
 // representing the default feature gates for ACK controllers.
 var defaultACKFeatureGates = FeatureGates{
-	ResourceAdoption:  {Stage: Beta, Enabled: true},
-	ReadOnlyResources: {Stage: Beta, Enabled: true},
-	TeamLevelCARM:     {Stage: Alpha, Enabled: false},
-	ServiceLevelCARM:  {Stage: Alpha, Enabled: false},
+	ResourceAdoption:         {Stage: Beta, Enabled: true},
+	ReadOnlyResources:        {Stage: Beta, Enabled: true},
+	TeamLevelCARM:            {Stage: Alpha, Enabled: false},
+	ServiceLevelCARM:         {Stage: Alpha, Enabled: false},
+	ReferenceCaching:         {Stage: Alpha, Enabled: false},
+	ReferenceOwnerReferences: {Stage: Alpha, Enabled: false},
+	ReferenceIndex:           {Stage: Alpha, Enabled: false},
 }
 
 // FeatureStage represents the development stage of a feature.
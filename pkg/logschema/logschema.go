@@ -0,0 +1,98 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package logschema contains the versioned schema of the JSON log lines
+// written by the controllers with --log-format=json, the events they emit
+// being also written as log lines, so that the log pipelines parse them
+// reliably across controller versions.
+//
+// The schema is versioned, every line carrying the version of its schema in
+// the "schema" field. Within a version, the fields are never renamed,
+// removed, or change type; new optional fields may be added, so the parsers
+// must ignore the fields they do not know. Any other change is made in a new
+// version of the schema.
+package logschema
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Version is the version of the schema of the log lines
+const Version = "ack.log/v1"
+
+// The fields of the log lines
+const (
+	// KeySchema is the version of the schema of the line
+	KeySchema = "schema"
+	// KeyTimestamp is the time of the line, in RFC 3339 format, in UTC
+	KeyTimestamp = "ts"
+	// KeyLevel is the level of the line, e.g. "info"
+	KeyLevel = "level"
+	// KeyLogger is the name of the logger writing the line
+	KeyLogger = "logger"
+	// KeyMessage is the message of the line
+	KeyMessage = "msg"
+	// KeyCaller is the source file and line writing the line
+	KeyCaller = "caller"
+	// KeyStacktrace is the stack trace of the error lines
+	KeyStacktrace = "stacktrace"
+	// KeyError is the error of the line
+	KeyError = "error"
+)
+
+// The fields of the log lines of the emitted events
+const (
+	// MessageEvent is the message of the log lines of the emitted events
+	MessageEvent = "event emitted"
+	// KeyEventType is the type of the event, Normal or Warning
+	KeyEventType = "event_type"
+	// KeyEventReason is the reason of the event, e.g. "CreateFailed"
+	KeyEventReason = "event_reason"
+	// KeyEventMessage is the message of the event
+	KeyEventMessage = "event_message"
+	// KeyObjectKind is the kind of the object the event is emitted on
+	KeyObjectKind = "object_kind"
+	// KeyObjectNamespace is the namespace of the object the event is
+	// emitted on
+	KeyObjectNamespace = "object_namespace"
+	// KeyObjectName is the name of the object the event is emitted on
+	KeyObjectName = "object_name"
+	// KeyObjectUID is the UID of the object the event is emitted on
+	KeyObjectUID = "object_uid"
+)
+
+// EncoderConfig returns the configuration of the zap JSON encoder writing
+// the log lines of the schema
+func EncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        KeyTimestamp,
+		LevelKey:       KeyLevel,
+		NameKey:        KeyLogger,
+		CallerKey:      KeyCaller,
+		MessageKey:     KeyMessage,
+		StacktraceKey:  KeyStacktrace,
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     encodeTime,
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+		EncodeName:     zapcore.FullNameEncoder,
+	}
+}
+
+// encodeTime encodes the time of the log lines in RFC 3339 format, in UTC
+func encodeTime(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString(t.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package logschema_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/logschema"
+)
+
+func TestSchema(t *testing.T) {
+	require := require.New(t)
+
+	schema := map[string]interface{}{}
+	require.Nil(json.Unmarshal([]byte(logschema.Schema), &schema))
+	require.Equal(logschema.Version, schema["$id"])
+	properties := schema["properties"].(map[string]interface{})
+	for _, key := range []string{
+		logschema.KeySchema, logschema.KeyTimestamp, logschema.KeyLevel, logschema.KeyLogger,
+		logschema.KeyMessage, logschema.KeyCaller, logschema.KeyStacktrace, logschema.KeyError,
+		logschema.KeyEventType, logschema.KeyEventReason, logschema.KeyEventMessage,
+		logschema.KeyObjectKind, logschema.KeyObjectNamespace, logschema.KeyObjectName, logschema.KeyObjectUID,
+	} {
+		require.Contains(properties, key)
+	}
+	require.Equal(logschema.Version, properties[logschema.KeySchema].(map[string]interface{})["const"])
+}
+
+func TestEncoderConfig(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	log := ctrlrtzap.New(
+		ctrlrtzap.WriteTo(&buf),
+		ctrlrtzap.Encoder(zapcore.NewJSONEncoder(logschema.EncoderConfig())),
+	).WithName("reconciler").WithValues(logschema.KeySchema, logschema.Version)
+	log.Error(errors.New("oops"), "reconciliation failed", "name", "my-book")
+
+	line := map[string]interface{}{}
+	require.Nil(json.Unmarshal(buf.Bytes(), &line))
+	require.Equal(logschema.Version, line[logschema.KeySchema])
+	require.Equal("error", line[logschema.KeyLevel])
+	require.Equal("reconciler", line[logschema.KeyLogger])
+	require.Equal("reconciliation failed", line[logschema.KeyMessage])
+	require.Equal("oops", line[logschema.KeyError])
+	require.Equal("my-book", line["name"])
+	ts, err := time.Parse(time.RFC3339Nano, line[logschema.KeyTimestamp].(string))
+	require.Nil(err)
+	require.Equal(time.UTC, ts.Location())
+}
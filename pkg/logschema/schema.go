@@ -0,0 +1,51 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package logschema
+
+// Schema is the JSON Schema of the log lines of Version, served by the admin
+// service of the controllers
+const Schema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "ack.log/v1",
+  "title": "ACK controller log line",
+  "description": "A log line written by an ACK controller with --log-format=json. Fields are never renamed, removed or retyped within a version; new optional fields may be added.",
+  "type": "object",
+  "required": ["schema", "ts", "level", "msg"],
+  "properties": {
+    "schema": {"const": "ack.log/v1", "description": "The version of the schema of the line"},
+    "ts": {"type": "string", "format": "date-time", "description": "The time of the line, in UTC"},
+    "level": {"type": "string", "description": "The level of the line: debug, info, warn, error, dpanic, panic or fatal, or Level(-N) for the debug verbosities above 1"},
+    "logger": {"type": "string", "description": "The name of the logger writing the line"},
+    "msg": {"type": "string", "description": "The message of the line"},
+    "caller": {"type": "string", "description": "The source file and line writing the line"},
+    "stacktrace": {"type": "string", "description": "The stack trace of the error lines"},
+    "error": {"type": "string", "description": "The error of the line"},
+    "controller": {"type": "string", "description": "The controller of the reconciliation"},
+    "controllerGroup": {"type": "string", "description": "The API group of the reconciled kind"},
+    "controllerKind": {"type": "string", "description": "The reconciled kind"},
+    "namespace": {"type": "string", "description": "The namespace of the reconciled resource"},
+    "name": {"type": "string", "description": "The name of the reconciled resource"},
+    "reconcileID": {"type": "string", "description": "The identifier of the reconciliation"},
+    "generation": {"type": "integer", "description": "The generation of the reconciled resource"},
+    "event_type": {"enum": ["Normal", "Warning"], "description": "The type of the emitted event"},
+    "event_reason": {"type": "string", "description": "The reason of the emitted event"},
+    "event_message": {"type": "string", "description": "The message of the emitted event"},
+    "object_kind": {"type": "string", "description": "The kind of the object the event is emitted on"},
+    "object_namespace": {"type": "string", "description": "The namespace of the object the event is emitted on"},
+    "object_name": {"type": "string", "description": "The name of the object the event is emitted on"},
+    "object_uid": {"type": "string", "description": "The UID of the object the event is emitted on"}
+  },
+  "additionalProperties": true
+}
+`
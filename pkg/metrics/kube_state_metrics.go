@@ -0,0 +1,170 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// KubeStateMetricsPrefix is the prefix of the names of the metrics exported
+// by kube-state-metrics for ACK resources with the configuration returned by
+// KubeStateMetricsConfig. The same metric names are used for every kind, so
+// that fleet dashboards and alerts do not depend on the kinds; kube-state-
+// metrics adds the group, version and kind of the resources as labels.
+const KubeStateMetricsPrefix = "ack_resource"
+
+// CustomResourceStateMetrics is the kube-state-metrics CustomResourceState
+// configuration. Only the fields used for ACK resources are modeled.
+type CustomResourceStateMetrics struct {
+	Kind string                         `json:"kind"`
+	Spec CustomResourceStateMetricsSpec `json:"spec"`
+}
+
+// CustomResourceStateMetricsSpec is the specification of a
+// CustomResourceStateMetrics configuration
+type CustomResourceStateMetricsSpec struct {
+	Resources []CustomResourceStateResource `json:"resources"`
+}
+
+// CustomResourceStateResource configures the metrics of a kind
+type CustomResourceStateResource struct {
+	GroupVersionKind GroupVersionKind            `json:"groupVersionKind"`
+	MetricNamePrefix string                      `json:"metricNamePrefix"`
+	LabelsFromPath   map[string][]string         `json:"labelsFromPath,omitempty"`
+	Metrics          []CustomResourceStateMetric `json:"metrics"`
+}
+
+// GroupVersionKind is the group, version and kind of a resource, as
+// configured in kube-state-metrics
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// CustomResourceStateMetric configures a metric of a kind
+type CustomResourceStateMetric struct {
+	Name string                        `json:"name"`
+	Help string                        `json:"help"`
+	Each CustomResourceStateMetricType `json:"each"`
+}
+
+// CustomResourceStateMetricType configures the type and source of the
+// values of a metric
+type CustomResourceStateMetricType struct {
+	Type  string                    `json:"type"`
+	Gauge *CustomResourceStateGauge `json:"gauge,omitempty"`
+	Info  *CustomResourceStateInfo  `json:"info,omitempty"`
+}
+
+// CustomResourceStateGauge configures a gauge metric
+type CustomResourceStateGauge struct {
+	Path           []string            `json:"path,omitempty"`
+	LabelsFromPath map[string][]string `json:"labelsFromPath,omitempty"`
+	ValueFrom      []string            `json:"valueFrom"`
+	NilIsZero      bool                `json:"nilIsZero,omitempty"`
+}
+
+// CustomResourceStateInfo configures an info metric
+type CustomResourceStateInfo struct {
+	LabelsFromPath map[string][]string `json:"labelsFromPath"`
+}
+
+// KubeStateMetricsConfig returns the kube-state-metrics CustomResourceState
+// configuration of the supplied kinds, covering the conventions shared by
+// all ACK resources:
+//
+//   - ack_resource_info, with the ARN, owner account ID and region of the
+//     status.ackResourceMetadata of the resource
+//   - ack_resource_condition, with one series per condition type whose value
+//     is 1 when the condition status is True and 0 when it is False
+//   - ack_resource_created, the creation timestamp of the resource
+//   - ack_resource_generation, the generation of the spec of the resource
+func KubeStateMetricsConfig(gvks []schema.GroupVersionKind) CustomResourceStateMetrics {
+	resources := make([]CustomResourceStateResource, 0, len(gvks))
+	for _, gvk := range gvks {
+		resources = append(resources, CustomResourceStateResource{
+			GroupVersionKind: GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+			MetricNamePrefix: KubeStateMetricsPrefix,
+			LabelsFromPath: map[string][]string{
+				"name":      {"metadata", "name"},
+				"namespace": {"metadata", "namespace"},
+			},
+			Metrics: kubeStateMetrics,
+		})
+	}
+	return CustomResourceStateMetrics{
+		Kind: "CustomResourceStateMetrics",
+		Spec: CustomResourceStateMetricsSpec{Resources: resources},
+	}
+}
+
+// kubeStateMetrics are the metrics configured for every ACK kind
+var kubeStateMetrics = []CustomResourceStateMetric{
+	{
+		Name: "info",
+		Help: "Information about the AWS resource of an ACK resource",
+		Each: CustomResourceStateMetricType{
+			Type: "Info",
+			Info: &CustomResourceStateInfo{
+				LabelsFromPath: map[string][]string{
+					"arn":              {"status", "ackResourceMetadata", "arn"},
+					"owner_account_id": {"status", "ackResourceMetadata", "ownerAccountID"},
+					"region":           {"status", "ackResourceMetadata", "region"},
+				},
+			},
+		},
+	},
+	{
+		Name: "condition",
+		Help: "The conditions of an ACK resource, 1 when True and 0 when False",
+		Each: CustomResourceStateMetricType{
+			Type: "Gauge",
+			Gauge: &CustomResourceStateGauge{
+				Path: []string{"status", "conditions"},
+				LabelsFromPath: map[string][]string{
+					"type":   {"type"},
+					"reason": {"reason"},
+				},
+				ValueFrom: []string{"status"},
+			},
+		},
+	},
+	{
+		Name: "created",
+		Help: "The creation timestamp of an ACK resource",
+		Each: CustomResourceStateMetricType{
+			Type: "Gauge",
+			Gauge: &CustomResourceStateGauge{
+				ValueFrom: []string{"metadata", "creationTimestamp"},
+			},
+		},
+	},
+	{
+		Name: "generation",
+		Help: "The generation of the spec of an ACK resource",
+		Each: CustomResourceStateMetricType{
+			Type: "Gauge",
+			Gauge: &CustomResourceStateGauge{
+				ValueFrom: []string{"metadata", "generation"},
+			},
+		},
+	},
+}
+
+// Marshal returns the YAML document of the configuration
+func (c CustomResourceStateMetrics) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/metrics"
+)
+
+func TestKubeStateMetricsConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := metrics.KubeStateMetricsConfig([]schema.GroupVersionKind{
+		{Group: "s3.services.k8s.aws", Version: "v1alpha1", Kind: "Bucket"},
+		{Group: "sqs.services.k8s.aws", Version: "v1alpha1", Kind: "Queue"},
+	})
+	require.Equal("CustomResourceStateMetrics", config.Kind)
+	require.Len(config.Spec.Resources, 2)
+	require.Equal("Bucket", config.Spec.Resources[0].GroupVersionKind.Kind)
+	require.Equal(metrics.KubeStateMetricsPrefix, config.Spec.Resources[1].MetricNamePrefix)
+
+	body, err := config.Marshal()
+	require.Nil(err)
+	parsed := map[string]interface{}{}
+	require.Nil(yaml.Unmarshal(body, &parsed))
+	resources := parsed["spec"].(map[string]interface{})["resources"].([]interface{})
+	resource := resources[0].(map[string]interface{})
+	require.Equal(map[string]interface{}{
+		"group": "s3.services.k8s.aws", "version": "v1alpha1", "kind": "Bucket",
+	}, resource["groupVersionKind"])
+	condition := resource["metrics"].([]interface{})[1].(map[string]interface{})
+	require.Equal("condition", condition["name"])
+	require.Equal(
+		[]interface{}{"status", "conditions"},
+		condition["each"].(map[string]interface{})["gauge"].(map[string]interface{})["path"],
+	)
+}
@@ -14,13 +14,29 @@
 package metrics
 
 import (
+	"context"
 	"strconv"
+	"time"
 
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
 	"github.com/prometheus/client_golang/prometheus"
 
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 )
 
+const (
+	// awsAPICallMiddlewareID is the ID of the middleware recording the calls
+	// to the AWS service APIs
+	awsAPICallMiddlewareID = "ACKMetrics"
+	// CompanionObjectStateDump is the companion object label of the state
+	// dump files
+	CompanionObjectStateDump = "state_dump"
+	// CompanionObjectSmokeTestCanary is the companion object label of the
+	// canary resources left over by interrupted smoke tests
+	CompanionObjectSmokeTestCanary = "smoke_test_canary"
+)
+
 var (
 	outboundAPIRequestsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -44,6 +60,269 @@ var (
 			"status_code",
 		},
 	)
+	awsAPICallDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ack_aws_api_call_duration_seconds",
+			Help:    "Duration of the calls to the AWS service APIs made by the controller, retries included.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{
+			"service",
+			"aws_service",
+			"operation",
+		},
+	)
+	awsAPIErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_aws_api_errors_total",
+			Help: "Total number of calls to the AWS service APIs made by the controller that failed, retries included.",
+		},
+		[]string{
+			"service",
+			"aws_service",
+			"operation",
+			"error_code",
+			"status_code",
+		},
+	)
+	assumeRoleFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_carm_assume_role_failures_total",
+			Help: "Total number of failures to assume the IAM role of a cross account resource management binding.",
+		},
+		[]string{
+			"service",
+			"role_arn",
+			"namespace",
+			"reason",
+		},
+	)
+	assumeRoleFailing = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_carm_assume_role_failing",
+			Help: "Whether the last attempt to assume the IAM role of a cross account resource management binding failed.",
+		},
+		[]string{
+			"service",
+			"role_arn",
+			"namespace",
+		},
+	)
+	regionNotEnabledErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_region_not_enabled_errors_total",
+			Help: "Total number of reconciliations that failed because the region of the resource is not enabled for its AWS account.",
+		},
+		[]string{
+			"service",
+			"region",
+			"account_id",
+		},
+	)
+	infrastructureFaultsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_infrastructure_faults_total",
+			Help: "Total number of reconciliations that failed because AWS could not be reached or rejected the clock of the controller, by fault.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"fault",
+		},
+	)
+	tagConsistencyLagSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ack_tag_consistency_lag_seconds",
+			Help:    "Time between a mutation of the tags of an AWS resource and the first read observing it.",
+			Buckets: []float64{0.5, 1, 2, 5, 10, 30, 60, 120},
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	tagConsistencyTimeoutsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_tag_consistency_timeouts_total",
+			Help: "Total number of mutations of the tags of AWS resources that were still not observed after re-reading the resources.",
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	requeueStormsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_requeue_storms_total",
+			Help: "Total number of requeue storms, in which a resource was reconciled again and again without its state changing.",
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	progressDeadlineExceededTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_progress_deadline_exceeded_total",
+			Help: "Total number of resources that did not sync within the progress deadline of their kind.",
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	circuitBreakerTripsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_circuit_breaker_trips_total",
+			Help: "Total number of times the calls to the AWS service API of an AWS account and region were stopped after persistently failing.",
+		},
+		[]string{
+			"service",
+			"account",
+			"region",
+		},
+	)
+	companionObjectsPrunedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_companion_objects_pruned_total",
+			Help: "Total number of companion objects created by the controller, e.g. state dumps, pruned past their retention.",
+		},
+		[]string{
+			"service",
+			"object",
+		},
+	)
+	smokeTestRunsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_smoke_test_runs_total",
+			Help: "Total number of smoke tests run by the controller, by result.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"result",
+		},
+	)
+	smokeTestSucceeded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_smoke_test_succeeded",
+			Help: "Whether the last smoke test run by the controller succeeded.",
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	smokeTestDurationSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_smoke_test_duration_seconds",
+			Help: "Duration of the last smoke test run by the controller.",
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	sloSyncedRatio = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_slo_synced_ratio",
+			Help: "Fraction of the resources whose last reconciliation left them synced.",
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	sloTimeToSyncP99Seconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_slo_time_to_sync_p99_seconds",
+			Help: "99th percentile of the time it took the resources to sync over the SLO window.",
+		},
+		[]string{
+			"service",
+			"kind",
+		},
+	)
+	sloBurnRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_slo_burn_rate",
+			Help: "Rate at which the error budget of a service level objective is burnt. Values greater than 1 mean that the objective is not met.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"objective",
+		},
+	)
+	serviceQuotaLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_service_quota_limit",
+			Help: "Value of the AWS service quotas consumed by the resources of a kind.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"aws_service",
+			"quota_code",
+		},
+	)
+	serviceQuotaUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_service_quota_usage",
+			Help: "Usage of the AWS service quotas consumed by the resources of a kind.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"aws_service",
+			"quota_code",
+		},
+	)
+	serviceQuotaRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_service_quota_remaining",
+			Help: "Remaining capacity of the AWS service quotas consumed by the resources of a kind.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"aws_service",
+			"quota_code",
+		},
+	)
+	canaryReconcilesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_canary_reconciles_total",
+			Help: "Total number of reconciliations of the resources of the kinds with a rollout in progress, by cohort.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"cohort",
+		},
+	)
+	canaryUpdatesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_canary_updates_total",
+			Help: "Total number of updates of the AWS resources of the kinds with a rollout in progress, by cohort.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"cohort",
+		},
+	)
+	resources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_resources",
+			Help: "Number of the resources managed by the controller, by kind and state.",
+		},
+		[]string{
+			"service",
+			"kind",
+			"state",
+		},
+	)
 )
 
 // Metrics contains the set of Prometheus metric objects used to store counter
@@ -58,6 +337,86 @@ type Metrics struct {
 	// requests made by the service controller that resulted in an HTTP 4XX or
 	// 5XX status code
 	obAPIRequestErrorTotal *prometheus.CounterVec
+	// awsAPICallDurationSeconds contains the duration of the calls to the
+	// AWS service APIs made by the clients of the service controller
+	awsAPICallDurationSeconds *prometheus.HistogramVec
+	// awsAPIErrorsTotal contains the total number of calls to the AWS
+	// service APIs made by the clients of the service controller that failed
+	awsAPIErrorsTotal *prometheus.CounterVec
+	// assumeRoleFailuresTotal contains the total number of failures to
+	// assume the IAM roles of cross account resource management bindings
+	assumeRoleFailuresTotal *prometheus.CounterVec
+	// assumeRoleFailing is set to 1 for the cross account resource
+	// management bindings whose IAM role could not be assumed
+	assumeRoleFailing *prometheus.GaugeVec
+	// regionNotEnabledErrorsTotal contains the total number of
+	// reconciliations that failed because of a region that is not enabled
+	// for the AWS account
+	regionNotEnabledErrorsTotal *prometheus.CounterVec
+	// infrastructureFaultsTotal contains the total number of
+	// reconciliations that failed because of a fault of the infrastructure
+	// rather than of the resource
+	infrastructureFaultsTotal *prometheus.CounterVec
+	// tagConsistencyLagSeconds contains the time it took the mutations of
+	// the tags of the AWS resources to be observed
+	tagConsistencyLagSeconds *prometheus.HistogramVec
+	// tagConsistencyTimeoutsTotal contains the total number of mutations of
+	// the tags of the AWS resources that were not observed in time
+	tagConsistencyTimeoutsTotal *prometheus.CounterVec
+	// requeueStormsTotal contains the total number of requeue storms
+	// detected
+	requeueStormsTotal *prometheus.CounterVec
+	// progressDeadlineExceededTotal contains the total number of resources
+	// marked as degraded for not syncing within their progress deadline
+	progressDeadlineExceededTotal *prometheus.CounterVec
+	// circuitBreakerTripsTotal contains the total number of trips of the
+	// circuit breakers of the AWS service APIs
+	circuitBreakerTripsTotal *prometheus.CounterVec
+	// companionObjectsPrunedTotal contains the total number of companion
+	// objects pruned past their retention, by object
+	companionObjectsPrunedTotal *prometheus.CounterVec
+	// smokeTestRunsTotal contains the total number of smoke tests run, by
+	// result
+	smokeTestRunsTotal *prometheus.CounterVec
+	// smokeTestSucceeded is set to 1 if the last smoke test succeeded
+	smokeTestSucceeded *prometheus.GaugeVec
+	// smokeTestDurationSeconds contains the duration of the last smoke test
+	smokeTestDurationSeconds *prometheus.GaugeVec
+	// sloSyncedRatio contains the fraction of the resources that are synced
+	sloSyncedRatio *prometheus.GaugeVec
+	// sloTimeToSyncP99Seconds contains the 99th percentile of the time it
+	// took the resources to sync
+	sloTimeToSyncP99Seconds *prometheus.GaugeVec
+	// sloBurnRate contains the burn rates of the error budgets of the
+	// service level objectives
+	sloBurnRate *prometheus.GaugeVec
+	// serviceQuotaLimit contains the values of the AWS service quotas
+	// consumed by the resources
+	serviceQuotaLimit *prometheus.GaugeVec
+	// serviceQuotaUsage contains the usage of the AWS service quotas
+	// consumed by the resources
+	serviceQuotaUsage *prometheus.GaugeVec
+	// serviceQuotaRemaining contains the remaining capacity of the AWS
+	// service quotas consumed by the resources
+	serviceQuotaRemaining *prometheus.GaugeVec
+	// canaryReconcilesTotal contains the total number of reconciliations of
+	// the resources of the kinds with a rollout in progress
+	canaryReconcilesTotal *prometheus.CounterVec
+	// canaryUpdatesTotal contains the total number of updates of the AWS
+	// resources of the kinds with a rollout in progress
+	canaryUpdatesTotal *prometheus.CounterVec
+	// resources contains the number of the resources managed by the
+	// service controller, by kind and state
+	resources *prometheus.GaugeVec
+	// relabeler rewrites metric labels before they are recorded
+	relabeler *Relabeler
+}
+
+// WithRelabeler sets the Relabeler used to rewrite metric labels before they
+// are recorded
+func (m *Metrics) WithRelabeler(relabeler *Relabeler) *Metrics {
+	m.relabeler = relabeler
+	return m
 }
 
 // RecordAPICall increments appropriate metrics tracking the count and duration
@@ -71,22 +430,374 @@ func (m *Metrics) RecordAPICall(
 	err error,
 ) {
 	m.obAPIRequestTotal.With(
-		prometheus.Labels{
+		m.relabeler.Relabel("ack_outbound_api_requests_total", prometheus.Labels{
 			"service": m.serviceID,
 			"op_type": opType,
 			"op_id":   opID,
-		},
+		}),
 	).Inc()
 	if err != nil {
 		statusCode := ackerr.HTTPStatusCode(err)
 		m.obAPIRequestErrorTotal.With(
-			prometheus.Labels{
+			m.relabeler.Relabel("ack_outbound_api_requests_error_total", prometheus.Labels{
 				"service":     m.serviceID,
 				"op_id":       opID,
 				"status_code": strconv.Itoa(statusCode),
+			}),
+		).Inc()
+	}
+}
+
+// RecordAWSAPICall updates the metrics tracking the duration and the errors
+// of the calls to the AWS service APIs
+func (m *Metrics) RecordAWSAPICall(
+	// The ID of the called AWS service, e.g. "S3"
+	awsService string,
+	// The name of the called operation, e.g. "CreateBucket"
+	operation string,
+	// The duration of the call, retries included
+	duration time.Duration,
+	// The error the call failed with, nil if it succeeded
+	err error,
+) {
+	m.awsAPICallDurationSeconds.With(
+		m.relabeler.Relabel("ack_aws_api_call_duration_seconds", prometheus.Labels{
+			"service":     m.serviceID,
+			"aws_service": awsService,
+			"operation":   operation,
+		}),
+	).Observe(duration.Seconds())
+	if err != nil {
+		m.awsAPIErrorsTotal.With(
+			m.relabeler.Relabel("ack_aws_api_errors_total", prometheus.Labels{
+				"service":     m.serviceID,
+				"aws_service": awsService,
+				"operation":   operation,
+				"error_code":  ackerr.ErrorCode(err),
+				"status_code": strconv.Itoa(ackerr.HTTPStatusCode(err)),
+			}),
+		).Inc()
+	}
+}
+
+// AWSAPICallMiddleware returns the API option recording, with
+// RecordAWSAPICall, the duration and the errors of the calls of the AWS
+// service clients. The calls canceled by their caller are not recorded.
+func (m *Metrics) AWSAPICallMiddleware() func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(
+			awsAPICallMiddlewareID,
+			func(
+				ctx context.Context,
+				in middleware.InitializeInput,
+				next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				start := time.Now()
+				out, md, err := next.HandleInitialize(ctx, in)
+				if ctx.Err() == nil {
+					m.RecordAWSAPICall(
+						awsmiddleware.GetServiceID(ctx),
+						awsmiddleware.GetOperationName(ctx),
+						time.Since(start),
+						err,
+					)
+				}
+				return out, md, err
 			},
+		), middleware.After)
+	}
+}
+
+// RecordAssumeRoleResult updates the metrics tracking the failures to assume
+// the IAM role of the cross account resource management binding of a
+// namespace. A nil error records a successful attempt.
+func (m *Metrics) RecordAssumeRoleResult(
+	// The ARN of the IAM role
+	roleARN string,
+	// The namespace of the resource the role was assumed for
+	namespace string,
+	// The reason of the failure, e.g. "AccessDenied", or an empty string if
+	// the role was assumed successfully
+	reason string,
+) {
+	failing := 0.0
+	if reason != "" {
+		failing = 1
+		m.assumeRoleFailuresTotal.With(
+			m.relabeler.Relabel("ack_carm_assume_role_failures_total", prometheus.Labels{
+				"service":   m.serviceID,
+				"role_arn":  roleARN,
+				"namespace": namespace,
+				"reason":    reason,
+			}),
 		).Inc()
 	}
+	m.assumeRoleFailing.With(
+		m.relabeler.Relabel("ack_carm_assume_role_failing", prometheus.Labels{
+			"service":   m.serviceID,
+			"role_arn":  roleARN,
+			"namespace": namespace,
+		}),
+	).Set(failing)
+}
+
+// RecordRegionNotEnabled increments the metric tracking the reconciliations
+// that failed because the region of the resource is not enabled for its AWS
+// account
+func (m *Metrics) RecordRegionNotEnabled(
+	// The region of the resource
+	region string,
+	// The AWS account of the resource
+	accountID string,
+) {
+	m.regionNotEnabledErrorsTotal.With(
+		m.relabeler.Relabel("ack_region_not_enabled_errors_total", prometheus.Labels{
+			"service":    m.serviceID,
+			"region":     region,
+			"account_id": accountID,
+		}),
+	).Inc()
+}
+
+// RecordInfrastructureFault increments the metric tracking the
+// reconciliations that failed because of a fault of the infrastructure, e.g.
+// "endpoint_connection" or "clock_skew"
+func (m *Metrics) RecordInfrastructureFault(
+	// The kind of the reconciled resource
+	kind string,
+	// The fault
+	fault string,
+) {
+	m.infrastructureFaultsTotal.With(
+		m.relabeler.Relabel("ack_infrastructure_faults_total", prometheus.Labels{
+			"service": m.serviceID,
+			"kind":    kind,
+			"fault":   fault,
+		}),
+	).Inc()
+}
+
+// RecordTagConsistency updates the metrics tracking the time it took a
+// mutation of the tags of an AWS resource to be observed
+func (m *Metrics) RecordTagConsistency(
+	// The kind of the resource
+	kind string,
+	// The time between the mutation and the first read observing it
+	lag time.Duration,
+	// Whether the mutation was observed
+	observed bool,
+) {
+	labels := prometheus.Labels{
+		"service": m.serviceID,
+		"kind":    kind,
+	}
+	if !observed {
+		m.tagConsistencyTimeoutsTotal.With(
+			m.relabeler.Relabel("ack_tag_consistency_timeouts_total", labels),
+		).Inc()
+		return
+	}
+	m.tagConsistencyLagSeconds.With(
+		m.relabeler.Relabel("ack_tag_consistency_lag_seconds", labels),
+	).Observe(lag.Seconds())
+}
+
+// RecordRequeueStorm increments the metric tracking the requeue storms
+func (m *Metrics) RecordRequeueStorm(
+	// The kind of the resource in a requeue storm
+	kind string,
+) {
+	m.requeueStormsTotal.With(
+		m.relabeler.Relabel("ack_requeue_storms_total", prometheus.Labels{
+			"service": m.serviceID,
+			"kind":    kind,
+		}),
+	).Inc()
+}
+
+// RecordProgressDeadlineExceeded increments the metric tracking the resources
+// that did not sync within their progress deadline
+func (m *Metrics) RecordProgressDeadlineExceeded(
+	// The kind of the degraded resource
+	kind string,
+) {
+	m.progressDeadlineExceededTotal.With(
+		m.relabeler.Relabel("ack_progress_deadline_exceeded_total", prometheus.Labels{
+			"service": m.serviceID,
+			"kind":    kind,
+		}),
+	).Inc()
+}
+
+// RecordCircuitBreakerTrip increments the metric tracking the trips of the
+// circuit breakers of the AWS service APIs
+func (m *Metrics) RecordCircuitBreakerTrip(
+	// The AWS account ID of the stopped calls
+	account string,
+	// The region of the stopped calls
+	region string,
+) {
+	m.circuitBreakerTripsTotal.With(
+		m.relabeler.Relabel("ack_circuit_breaker_trips_total", prometheus.Labels{
+			"service": m.serviceID,
+			"account": account,
+			"region":  region,
+		}),
+	).Inc()
+}
+
+// RecordCompanionObjectsPruned increments the metric tracking the companion
+// objects created by the controller and pruned past their retention
+func (m *Metrics) RecordCompanionObjectsPruned(
+	// The companion object, e.g. CompanionObjectStateDump
+	object string,
+	// The number of pruned objects
+	count int,
+) {
+	if count <= 0 {
+		return
+	}
+	m.companionObjectsPrunedTotal.With(
+		m.relabeler.Relabel("ack_companion_objects_pruned_total", prometheus.Labels{
+			"service": m.serviceID,
+			"object":  object,
+		}),
+	).Add(float64(count))
+}
+
+// RecordSmokeTestResult updates the metrics tracking the smoke tests run by
+// the controller
+func (m *Metrics) RecordSmokeTestResult(
+	// The kind of the canary resource of the smoke test
+	kind string,
+	// Whether the smoke test succeeded
+	succeeded bool,
+	// The duration of the smoke test
+	duration time.Duration,
+) {
+	result := "failure"
+	value := 0.0
+	if succeeded {
+		result = "success"
+		value = 1
+	}
+	m.smokeTestRunsTotal.With(
+		m.relabeler.Relabel("ack_smoke_test_runs_total", prometheus.Labels{
+			"service": m.serviceID,
+			"kind":    kind,
+			"result":  result,
+		}),
+	).Inc()
+	labels := prometheus.Labels{
+		"service": m.serviceID,
+		"kind":    kind,
+	}
+	m.smokeTestSucceeded.With(
+		m.relabeler.Relabel("ack_smoke_test_succeeded", labels),
+	).Set(value)
+	m.smokeTestDurationSeconds.With(
+		m.relabeler.Relabel("ack_smoke_test_duration_seconds", labels),
+	).Set(duration.Seconds())
+}
+
+// RecordSLOIndicators updates the metrics tracking the service level
+// indicators of the resources of a kind
+func (m *Metrics) RecordSLOIndicators(
+	// The kind of the resources
+	kind string,
+	// The fraction of the resources that are synced
+	syncedRatio float64,
+	// The 99th percentile of the time it took the resources to sync
+	timeToSyncP99 time.Duration,
+	// The burn rates of the enabled objectives, keyed by objective name
+	burnRates map[string]float64,
+) {
+	labels := prometheus.Labels{
+		"service": m.serviceID,
+		"kind":    kind,
+	}
+	m.sloSyncedRatio.With(
+		m.relabeler.Relabel("ack_slo_synced_ratio", labels),
+	).Set(syncedRatio)
+	m.sloTimeToSyncP99Seconds.With(
+		m.relabeler.Relabel("ack_slo_time_to_sync_p99_seconds", labels),
+	).Set(timeToSyncP99.Seconds())
+	for objective, rate := range burnRates {
+		m.sloBurnRate.With(
+			m.relabeler.Relabel("ack_slo_burn_rate", prometheus.Labels{
+				"service":   m.serviceID,
+				"kind":      kind,
+				"objective": objective,
+			}),
+		).Set(rate)
+	}
+}
+
+// RecordServiceQuota updates the metrics tracking the usage of an AWS service
+// quota consumed by the resources of a kind
+func (m *Metrics) RecordServiceQuota(
+	// The kind of the resources
+	kind string,
+	// The code of the AWS service of the quota, e.g. "ec2"
+	awsService string,
+	// The code of the quota, e.g. "L-0263D0A3"
+	quotaCode string,
+	// The value of the quota
+	limit float64,
+	// The usage of the quota
+	usage float64,
+) {
+	labels := prometheus.Labels{
+		"service":     m.serviceID,
+		"kind":        kind,
+		"aws_service": awsService,
+		"quota_code":  quotaCode,
+	}
+	m.serviceQuotaLimit.With(
+		m.relabeler.Relabel("ack_service_quota_limit", labels),
+	).Set(limit)
+	m.serviceQuotaUsage.With(
+		m.relabeler.Relabel("ack_service_quota_usage", labels),
+	).Set(usage)
+	m.serviceQuotaRemaining.With(
+		m.relabeler.Relabel("ack_service_quota_remaining", labels),
+	).Set(limit - usage)
+}
+
+// RecordCanaryReconcile increments the metric tracking the reconciliations of
+// the resources of a kind with a rollout in progress
+func (m *Metrics) RecordCanaryReconcile(
+	// The kind of the reconciled resource
+	kind string,
+	// The cohort of the resource, "canary" or "baseline"
+	cohort string,
+) {
+	m.canaryReconcilesTotal.With(
+		m.relabeler.Relabel("ack_canary_reconciles_total", prometheus.Labels{
+			"service": m.serviceID,
+			"kind":    kind,
+			"cohort":  cohort,
+		}),
+	).Inc()
+}
+
+// RecordCanaryUpdate increments the metric tracking the updates of the AWS
+// resources of a kind with a rollout in progress. The update rates of the
+// canary and baseline cohorts diverge when the new behavior changes the AWS
+// resources.
+func (m *Metrics) RecordCanaryUpdate(
+	// The kind of the updated resource
+	kind string,
+	// The cohort of the resource, "canary" or "baseline"
+	cohort string,
+) {
+	m.canaryUpdatesTotal.With(
+		m.relabeler.Relabel("ack_canary_updates_total", prometheus.Labels{
+			"service": m.serviceID,
+			"kind":    kind,
+			"cohort":  cohort,
+		}),
+	).Inc()
 }
 
 // Collectors simply provides an iterator over the `prometheus.Collector`
@@ -97,6 +808,30 @@ func (m *Metrics) Collectors() []prometheus.Collector {
 	return []prometheus.Collector{
 		m.obAPIRequestTotal,
 		m.obAPIRequestErrorTotal,
+		m.awsAPICallDurationSeconds,
+		m.awsAPIErrorsTotal,
+		m.assumeRoleFailuresTotal,
+		m.assumeRoleFailing,
+		m.regionNotEnabledErrorsTotal,
+		m.infrastructureFaultsTotal,
+		m.tagConsistencyLagSeconds,
+		m.tagConsistencyTimeoutsTotal,
+		m.requeueStormsTotal,
+		m.progressDeadlineExceededTotal,
+		m.circuitBreakerTripsTotal,
+		m.companionObjectsPrunedTotal,
+		m.smokeTestRunsTotal,
+		m.smokeTestSucceeded,
+		m.smokeTestDurationSeconds,
+		m.sloSyncedRatio,
+		m.sloTimeToSyncP99Seconds,
+		m.sloBurnRate,
+		m.serviceQuotaLimit,
+		m.serviceQuotaUsage,
+		m.serviceQuotaRemaining,
+		m.canaryReconcilesTotal,
+		m.canaryUpdatesTotal,
+		m.resources,
 	}
 }
 
@@ -104,8 +839,32 @@ func (m *Metrics) Collectors() []prometheus.Collector {
 // and expose various Prometheus metrics
 func NewMetrics(serviceID string) *Metrics {
 	return &Metrics{
-		serviceID:              serviceID,
-		obAPIRequestTotal:      outboundAPIRequestsTotal,
-		obAPIRequestErrorTotal: outboundAPIRequestsErrorTotal,
+		serviceID:                     serviceID,
+		obAPIRequestTotal:             outboundAPIRequestsTotal,
+		obAPIRequestErrorTotal:        outboundAPIRequestsErrorTotal,
+		awsAPICallDurationSeconds:     awsAPICallDurationSeconds,
+		awsAPIErrorsTotal:             awsAPIErrorsTotal,
+		assumeRoleFailuresTotal:       assumeRoleFailuresTotal,
+		assumeRoleFailing:             assumeRoleFailing,
+		regionNotEnabledErrorsTotal:   regionNotEnabledErrorsTotal,
+		infrastructureFaultsTotal:     infrastructureFaultsTotal,
+		tagConsistencyLagSeconds:      tagConsistencyLagSeconds,
+		tagConsistencyTimeoutsTotal:   tagConsistencyTimeoutsTotal,
+		requeueStormsTotal:            requeueStormsTotal,
+		progressDeadlineExceededTotal: progressDeadlineExceededTotal,
+		circuitBreakerTripsTotal:      circuitBreakerTripsTotal,
+		companionObjectsPrunedTotal:   companionObjectsPrunedTotal,
+		smokeTestRunsTotal:            smokeTestRunsTotal,
+		smokeTestSucceeded:            smokeTestSucceeded,
+		smokeTestDurationSeconds:      smokeTestDurationSeconds,
+		sloSyncedRatio:                sloSyncedRatio,
+		sloTimeToSyncP99Seconds:       sloTimeToSyncP99Seconds,
+		sloBurnRate:                   sloBurnRate,
+		serviceQuotaLimit:             serviceQuotaLimit,
+		serviceQuotaUsage:             serviceQuotaUsage,
+		serviceQuotaRemaining:         serviceQuotaRemaining,
+		canaryReconcilesTotal:         canaryReconcilesTotal,
+		canaryUpdatesTotal:            canaryUpdatesTotal,
+		resources:                     resources,
 	}
 }
@@ -0,0 +1,140 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/metrics"
+)
+
+// gatherService returns the metrics of the supplied family recorded for the
+// supplied service
+func gatherService(t *testing.T, m *metrics.Metrics, family, service string) []*dto.Metric {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m.Collectors()...)
+	families, err := registry.Gather()
+	require.Nil(t, err)
+	found := []*dto.Metric{}
+	for _, f := range families {
+		if f.GetName() != family {
+			continue
+		}
+		for _, metric := range f.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "service" && label.GetValue() == service {
+					found = append(found, metric)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// labelsOf returns the labels of the supplied metric
+func labelsOf(metric *dto.Metric) map[string]string {
+	labels := map[string]string{}
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	return labels
+}
+
+func TestMetrics_AWSAPICallMiddleware(t *testing.T) {
+	require := require.New(t)
+	m := metrics.NewMetrics("awsapicalltest")
+
+	call := func(err error) {
+		stack := middleware.NewStack("CreateBucket", func() interface{} { return nil })
+		require.Nil(stack.Initialize.Add(&awsmiddleware.RegisterServiceMetadata{
+			ServiceID:     "S3",
+			OperationName: "CreateBucket",
+		}, middleware.Before))
+		require.Nil(m.AWSAPICallMiddleware()(stack))
+		handler := middleware.DecorateHandler(
+			middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+				return nil, middleware.Metadata{}, err
+			}),
+			stack,
+		)
+		_, _, _ = handler.Handle(context.Background(), nil)
+	}
+	call(nil)
+	call(&smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusConflict}},
+		Err:      &smithy.GenericAPIError{Code: "BucketAlreadyExists"},
+	})
+	call(errors.New("connection reset"))
+
+	durations := gatherService(t, m, "ack_aws_api_call_duration_seconds", "awsapicalltest")
+	require.Len(durations, 1)
+	require.Equal(uint64(3), durations[0].GetHistogram().GetSampleCount())
+	require.Equal("S3", labelsOf(durations[0])["aws_service"])
+	require.Equal("CreateBucket", labelsOf(durations[0])["operation"])
+
+	errs := gatherService(t, m, "ack_aws_api_errors_total", "awsapicalltest")
+	require.Len(errs, 2)
+	codes := map[string]string{}
+	for _, metric := range errs {
+		labels := labelsOf(metric)
+		codes[labels["error_code"]] = labels["status_code"]
+		require.Equal(1.0, metric.GetCounter().GetValue())
+	}
+	require.Equal(map[string]string{"BucketAlreadyExists": "409", "": "-1"}, codes)
+}
+
+func TestResourceStateTracker(t *testing.T) {
+	require := require.New(t)
+	m := metrics.NewMetrics("resourcestatetest")
+
+	counts := func() map[string]float64 {
+		found := map[string]float64{}
+		for _, metric := range gatherService(t, m, "ack_resources", "resourcestatetest") {
+			found[labelsOf(metric)["state"]] = metric.GetGauge().GetValue()
+		}
+		return found
+	}
+
+	tracker := metrics.NewResourceStateTracker(m, "Bucket")
+	require.Equal(map[string]float64{
+		"synced": 0, "not_synced": 0, "terminal": 0, "recoverable": 0, "deleting": 0,
+	}, counts())
+
+	tracker.Set("default/a", metrics.ResourceStateNotSynced)
+	tracker.Set("default/b", metrics.ResourceStateTerminal)
+	tracker.Set("default/a", metrics.ResourceStateSynced)
+	tracker.Set("default/a", metrics.ResourceStateSynced)
+	require.Equal(1.0, counts()["synced"])
+	require.Equal(0.0, counts()["not_synced"])
+	require.Equal(1.0, counts()["terminal"])
+
+	tracker.Set("default/b", metrics.ResourceStateDeleting)
+	tracker.Forget("default/b")
+	tracker.Forget("default/unknown")
+	require.Equal(0.0, counts()["terminal"])
+	require.Equal(0.0, counts()["deleting"])
+
+	require.Nil(metrics.NewResourceStateTracker(nil, "Bucket"))
+}
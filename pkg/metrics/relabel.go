@@ -0,0 +1,179 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// OverflowLabelValue is the value given to a label once the number of
+	// distinct values seen for that label exceeds its configured maximum
+	// cardinality.
+	OverflowLabelValue = "__overflow__"
+	// DroppedLabelValue is the value given to a dropped label. Prometheus
+	// vectors have a fixed set of label names, so dropping a label means
+	// collapsing all of its values into a single, empty, one.
+	DroppedLabelValue = ""
+)
+
+// RelabelRule rewrites the value of a metric label. Values matching Regex are
+// replaced with Replacement, which can reference capture groups of Regex
+// (e.g. "$1"). Values not matching Regex are left untouched.
+type RelabelRule struct {
+	// Label is the name of the label the rule applies to
+	Label string
+	// Regex is matched against the whole label value
+	Regex *regexp.Regexp
+	// Replacement is the new value of the label
+	Replacement string
+}
+
+// Apply returns the value of the label after applying the rule
+func (r RelabelRule) Apply(value string) string {
+	if r.Regex == nil || !r.Regex.MatchString(value) {
+		return value
+	}
+	return r.Regex.ReplaceAllString(value, r.Replacement)
+}
+
+// ParseRelabelRule parses a relabel rule of the form
+// "label:regex:replacement". The regex is anchored so that it matches the
+// whole label value, and can contain ':' characters, the replacement cannot.
+func ParseRelabelRule(rule string) (RelabelRule, error) {
+	first := strings.Index(rule, ":")
+	last := strings.LastIndex(rule, ":")
+	if first <= 0 || first == last {
+		return RelabelRule{}, fmt.Errorf(
+			"invalid relabel rule %q: expected label:regex:replacement", rule,
+		)
+	}
+	re, err := regexp.Compile("^(?:" + rule[first+1:last] + ")$")
+	if err != nil {
+		return RelabelRule{}, fmt.Errorf("invalid relabel rule %q: %v", rule, err)
+	}
+	return RelabelRule{
+		Label:       rule[:first],
+		Regex:       re,
+		Replacement: rule[last+1:],
+	}, nil
+}
+
+// RelabelConfig contains the label rewriting options applied to metrics
+// before they are recorded.
+type RelabelConfig struct {
+	// DropLabels contains the names of the labels whose values are always
+	// replaced with DroppedLabelValue
+	DropLabels []string
+	// MaxCardinality maps label names to the maximum number of distinct
+	// values recorded, per metric, for that label. Values seen after the
+	// limit is reached are replaced with OverflowLabelValue.
+	MaxCardinality map[string]int
+	// Rules contains the relabel rules, applied in order, before the
+	// cardinality limits are enforced
+	Rules []RelabelRule
+}
+
+// Relabeler rewrites metric labels according to a RelabelConfig, preventing
+// high cardinality labels (e.g. resource namespaces and names) from
+// overwhelming the metrics backend in large clusters. A nil Relabeler leaves
+// labels untouched.
+type Relabeler struct {
+	sync.Mutex
+	cfg  RelabelConfig
+	drop map[string]struct{}
+	// seen maps metric names to the set of values recorded for each label
+	// that has a maximum cardinality
+	seen map[string]map[string]map[string]struct{}
+}
+
+// Relabel returns the labels to record for the supplied metric name
+func (r *Relabeler) Relabel(metric string, labels prometheus.Labels) prometheus.Labels {
+	if r == nil {
+		return labels
+	}
+	relabeled := make(prometheus.Labels, len(labels))
+	for name, value := range labels {
+		relabeled[name] = value
+	}
+	for _, rule := range r.cfg.Rules {
+		if value, ok := relabeled[rule.Label]; ok {
+			relabeled[rule.Label] = rule.Apply(value)
+		}
+	}
+	for name := range r.drop {
+		if _, ok := relabeled[name]; ok {
+			relabeled[name] = DroppedLabelValue
+		}
+	}
+	if len(r.cfg.MaxCardinality) == 0 {
+		return relabeled
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	for name, max := range r.cfg.MaxCardinality {
+		value, ok := relabeled[name]
+		if !ok {
+			continue
+		}
+		if _, dropped := r.drop[name]; dropped {
+			continue
+		}
+		values := r.seenValues(metric, name)
+		if _, ok := values[value]; ok {
+			continue
+		}
+		if len(values) >= max {
+			relabeled[name] = OverflowLabelValue
+			continue
+		}
+		values[value] = struct{}{}
+	}
+	return relabeled
+}
+
+// seenValues returns the set of values recorded for a label of a metric.
+// The caller must hold the lock.
+func (r *Relabeler) seenValues(metric, label string) map[string]struct{} {
+	labels, ok := r.seen[metric]
+	if !ok {
+		labels = map[string]map[string]struct{}{}
+		r.seen[metric] = labels
+	}
+	values, ok := labels[label]
+	if !ok {
+		values = map[string]struct{}{}
+		labels[label] = values
+	}
+	return values
+}
+
+// NewRelabeler returns a Relabeler applying the supplied configuration
+func NewRelabeler(cfg RelabelConfig) *Relabeler {
+	drop := make(map[string]struct{}, len(cfg.DropLabels))
+	for _, name := range cfg.DropLabels {
+		drop[name] = struct{}{}
+	}
+	return &Relabeler{
+		cfg:  cfg,
+		drop: drop,
+		seen: map[string]map[string]map[string]struct{}{},
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/metrics"
+)
+
+func TestParseRelabelRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		label    string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"name:(.*)-[0-9]+:$1", "name", "bucket-1234", "bucket", false},
+		{"name:(.*)-[0-9]+:$1", "name", "bucket", "bucket", false},
+		{"op_id:arn:aws:.*:arn", "op_id", "arn:aws:s3:::bucket", "arn", false},
+		{"namespace:team-.*:teams", "namespace", "team-a", "teams", false},
+		{"namespace:team:teams", "namespace", "team-a", "team-a", false},
+		{"name", "", "", "", true},
+		{"name:oops", "", "", "", true},
+		{":.*:x", "", "", "", true},
+		{"name:(:x", "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			require := require.New(t)
+			rule, err := metrics.ParseRelabelRule(tt.rule)
+			if tt.wantErr {
+				require.NotNil(err)
+				return
+			}
+			require.Nil(err)
+			require.Equal(tt.label, rule.Label)
+			require.Equal(tt.expected, rule.Apply(tt.value))
+		})
+	}
+}
+
+func TestRelabeler(t *testing.T) {
+	require := require.New(t)
+
+	rule, err := metrics.ParseRelabelRule("kind:(.*)s:$1")
+	require.Nil(err)
+	relabeler := metrics.NewRelabeler(metrics.RelabelConfig{
+		DropLabels:     []string{"namespace"},
+		MaxCardinality: map[string]int{"name": 2, "namespace": 1},
+		Rules:          []metrics.RelabelRule{rule},
+	})
+
+	labels := prometheus.Labels{"namespace": "ns", "name": "a", "kind": "buckets"}
+	require.Equal(
+		prometheus.Labels{"namespace": "", "name": "a", "kind": "bucket"},
+		relabeler.Relabel("m1", labels),
+	)
+	// The supplied labels are not modified
+	require.Equal("ns", labels["namespace"])
+
+	require.Equal("b", relabeler.Relabel("m1", prometheus.Labels{"name": "b"})["name"])
+	require.Equal(metrics.OverflowLabelValue, relabeler.Relabel("m1", prometheus.Labels{"name": "c"})["name"])
+	// Already seen values are still recorded
+	require.Equal("a", relabeler.Relabel("m1", prometheus.Labels{"name": "a"})["name"])
+	// Cardinality is tracked per metric
+	require.Equal("c", relabeler.Relabel("m2", prometheus.Labels{"name": "c"})["name"])
+
+	var nilRelabeler *metrics.Relabeler
+	require.Equal(labels, nilRelabeler.Relabel("m1", labels))
+}
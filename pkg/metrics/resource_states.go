@@ -0,0 +1,133 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ResourceState is the state of a resource managed by the controller, as
+// counted by the ack_resources metric
+type ResourceState string
+
+const (
+	// ResourceStateSynced is the state of the resources whose
+	// ACK.ResourceSynced condition is True
+	ResourceStateSynced ResourceState = "synced"
+	// ResourceStateNotSynced is the state of the resources not synced yet,
+	// without a Terminal or Recoverable condition
+	ResourceStateNotSynced ResourceState = "not_synced"
+	// ResourceStateTerminal is the state of the resources whose ACK.Terminal
+	// condition is True
+	ResourceStateTerminal ResourceState = "terminal"
+	// ResourceStateRecoverable is the state of the resources whose
+	// ACK.Recoverable condition is True
+	ResourceStateRecoverable ResourceState = "recoverable"
+	// ResourceStateDeleting is the state of the resources being deleted
+	ResourceStateDeleting ResourceState = "deleting"
+)
+
+// resourceStates are the states of the resources, in the order they are
+// initialized
+var resourceStates = []ResourceState{
+	ResourceStateSynced,
+	ResourceStateNotSynced,
+	ResourceStateTerminal,
+	ResourceStateRecoverable,
+	ResourceStateDeleting,
+}
+
+// ResourceStateTracker tracks the state of the resources of a kind and keeps
+// the ack_resources metric of the kind up to date. A nil
+// ResourceStateTracker tracks nothing.
+type ResourceStateTracker struct {
+	sync.Mutex
+	metrics *Metrics
+	kind    string
+	// states holds the state of each resource, keyed by namespace and name
+	states map[string]ResourceState
+	// counts holds the number of resources in each state
+	counts map[ResourceState]int
+}
+
+// NewResourceStateTracker returns a ResourceStateTracker of the resources of
+// the supplied kind recording their number in the supplied metrics, every
+// state starting at 0 so that alerts on a state do not depend on it having
+// been observed. It returns nil if the supplied metrics are nil.
+func NewResourceStateTracker(m *Metrics, kind string) *ResourceStateTracker {
+	if m == nil {
+		return nil
+	}
+	t := &ResourceStateTracker{
+		metrics: m,
+		kind:    kind,
+		states:  map[string]ResourceState{},
+		counts:  map[ResourceState]int{},
+	}
+	for _, state := range resourceStates {
+		t.record(state)
+	}
+	return t
+}
+
+// Set sets the state of the resource with the supplied key
+func (t *ResourceStateTracker) Set(key string, state ResourceState) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	previous, ok := t.states[key]
+	if ok && previous == state {
+		return
+	}
+	t.states[key] = state
+	t.counts[state]++
+	t.record(state)
+	if ok {
+		t.counts[previous]--
+		t.record(previous)
+	}
+}
+
+// Forget stops counting the resource with the supplied key, e.g. once it is
+// deleted
+func (t *ResourceStateTracker) Forget(key string) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	previous, ok := t.states[key]
+	if !ok {
+		return
+	}
+	delete(t.states, key)
+	t.counts[previous]--
+	t.record(previous)
+}
+
+// record sets the ack_resources metric of the supplied state to the number
+// of resources in it
+func (t *ResourceStateTracker) record(state ResourceState) {
+	t.metrics.resources.With(
+		t.metrics.relabeler.Relabel("ack_resources", prometheus.Labels{
+			"service": t.metrics.serviceID,
+			"kind":    t.kind,
+			"state":   string(state),
+		}),
+	).Set(float64(t.counts[state]))
+}
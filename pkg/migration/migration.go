@@ -0,0 +1,355 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package migration exports the ACK resources of a cluster as manifests that
+// can be imported into another cluster, where the ACK service controllers
+// adopt the existing AWS resources instead of creating new ones.
+//
+// A typical cluster migration:
+//
+//  1. Export the resources from the old cluster, with Export and
+//     WriteManifests.
+//  2. Remove the ACK service controllers from the old cluster, or configure
+//     the controllers of both clusters with different --cluster-id values.
+//  3. Import the manifests into the new cluster, with ReadManifests and
+//     Import (or kubectl apply).
+//  4. Verify that the controllers of the new cluster took over the AWS
+//     resources, with Verify.
+package migration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackunstructured "github.com/aws-controllers-k8s/runtime/pkg/unstructured"
+)
+
+const (
+	// adoptionPolicyAdopt is the adoption policy of resources whose AWS
+	// resource must exist.
+	adoptionPolicyAdopt = "adopt"
+	// adoptionPolicyAdoptOrCreate is the adoption policy of resources whose
+	// AWS resource is identified by their spec.
+	adoptionPolicyAdoptOrCreate = "adopt-or-create"
+	// adoptionFieldARN is the adoption field holding the ARN of the AWS
+	// resource.
+	adoptionFieldARN = "arn"
+	// lastAppliedConfigAnnotation is the annotation set by kubectl apply
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// AdoptionFieldsFunc returns the adoption fields (see
+// AnnotationAdoptionFields) used to adopt the AWS resource of the supplied
+// exported resource. Returning nil fields lets the controller use the
+// identifiers found in the resource spec.
+type AdoptionFieldsFunc func(*k8sunstructured.Unstructured) (map[string]string, error)
+
+// ExportOptions configures the export of ACK resources.
+type ExportOptions struct {
+	// Namespaces restricts the export to the supplied namespaces. All
+	// namespaces are exported when empty.
+	Namespaces []string
+	// AdoptionFields returns the adoption fields of a resource, overriding
+	// the default, which uses the ARN of the resource when it is known.
+	AdoptionFields AdoptionFieldsFunc
+	// NoTakeOver disables the take-over annotation on the exported
+	// resources. The annotation is required for the controllers of the new
+	// cluster to manage AWS resources tagged with the identifier of the old
+	// cluster.
+	NoTakeOver bool
+}
+
+// Export lists the ACK resources of the supplied kinds and returns them as
+// manifests ready to be imported into another cluster.
+func Export(
+	ctx context.Context,
+	reader client.Reader,
+	gvks []schema.GroupVersionKind,
+	opts ExportOptions,
+) ([]*k8sunstructured.Unstructured, error) {
+	namespaces := opts.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	manifests := []*k8sunstructured.Unstructured{}
+	for _, gvk := range gvks {
+		for _, namespace := range namespaces {
+			list := &k8sunstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err := reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+				return nil, fmt.Errorf("listing %s resources: %v", gvk.Kind, err)
+			}
+			for i := range list.Items {
+				u := &list.Items[i]
+				if u.GetDeletionTimestamp() != nil {
+					continue
+				}
+				u.SetGroupVersionKind(gvk)
+				manifest, err := ToImportManifest(u, opts)
+				if err != nil {
+					return nil, fmt.Errorf(
+						"exporting %s %s/%s: %v", gvk.Kind, u.GetNamespace(), u.GetName(), err,
+					)
+				}
+				manifests = append(manifests, manifest)
+			}
+		}
+	}
+	return manifests, nil
+}
+
+// ToImportManifest returns a copy of the supplied ACK resource, stripped from
+// its status and cluster specific metadata, and annotated so that the
+// controller of the cluster it is imported into adopts its AWS resource
+// instead of creating a new one.
+//
+// The owner account ID and region of the resource are recorded as
+// annotations, so that the resource is adopted from the same account and
+// region regardless of the configuration of the new cluster.
+func ToImportManifest(
+	u *k8sunstructured.Unstructured,
+	opts ExportOptions,
+) (*k8sunstructured.Unstructured, error) {
+	metadata, err := ackunstructured.GetResourceMetadata(u)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &k8sunstructured.Unstructured{Object: map[string]interface{}{}}
+	manifest.SetGroupVersionKind(u.GroupVersionKind())
+	manifest.SetName(u.GetName())
+	manifest.SetNamespace(u.GetNamespace())
+	manifest.SetLabels(u.GetLabels())
+	if spec, ok := u.Object["spec"]; ok {
+		manifest.Object["spec"] = k8sruntime.DeepCopyJSONValue(spec)
+	}
+
+	annotations := map[string]string{}
+	for k, v := range u.GetAnnotations() {
+		annotations[k] = v
+	}
+	delete(annotations, ackv1alpha1.AnnotationAdopted)
+	delete(annotations, lastAppliedConfigAnnotation)
+
+	var fields map[string]string
+	if opts.AdoptionFields != nil {
+		if fields, err = opts.AdoptionFields(u); err != nil {
+			return nil, err
+		}
+	} else if metadata != nil && metadata.ARN != nil && *metadata.ARN != "" {
+		fields = map[string]string{adoptionFieldARN: string(*metadata.ARN)}
+	}
+	if fields != nil {
+		raw, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		annotations[ackv1alpha1.AnnotationAdoptionPolicy] = adoptionPolicyAdopt
+		annotations[ackv1alpha1.AnnotationAdoptionFields] = string(raw)
+	} else {
+		annotations[ackv1alpha1.AnnotationAdoptionPolicy] = adoptionPolicyAdoptOrCreate
+		delete(annotations, ackv1alpha1.AnnotationAdoptionFields)
+	}
+	if metadata != nil {
+		if _, ok := annotations[ackv1alpha1.AnnotationOwnerAccountID]; !ok && metadata.OwnerAccountID != nil {
+			annotations[ackv1alpha1.AnnotationOwnerAccountID] = string(*metadata.OwnerAccountID)
+		}
+		if _, ok := annotations[ackv1alpha1.AnnotationRegion]; !ok && metadata.Region != nil {
+			annotations[ackv1alpha1.AnnotationRegion] = string(*metadata.Region)
+		}
+	}
+	if !opts.NoTakeOver {
+		annotations[ackv1alpha1.AnnotationTakeOver] = "true"
+	}
+	manifest.SetAnnotations(annotations)
+	return manifest, nil
+}
+
+// WriteManifests writes the supplied manifests as a multi-document YAML
+// stream.
+func WriteManifests(w io.Writer, manifests []*k8sunstructured.Unstructured) error {
+	for _, manifest := range manifests {
+		raw, err := yaml.Marshal(manifest.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadManifests reads manifests from a multi-document YAML stream, as
+// written by WriteManifests.
+func ReadManifests(r io.Reader) ([]*k8sunstructured.Unstructured, error) {
+	manifests := []*k8sunstructured.Unstructured{}
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		manifest := &k8sunstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &manifest.Object); err != nil {
+			return nil, err
+		}
+		if len(manifest.Object) == 0 {
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+}
+
+// Import creates the supplied manifests. Resources that already exist are
+// left untouched.
+func Import(
+	ctx context.Context,
+	writer client.Writer,
+	manifests []*k8sunstructured.Unstructured,
+) error {
+	for _, manifest := range manifests {
+		if err := writer.Create(ctx, manifest.DeepCopy()); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf(
+				"importing %s %s/%s: %v",
+				manifest.GetKind(), manifest.GetNamespace(), manifest.GetName(), err,
+			)
+		}
+	}
+	return nil
+}
+
+// VerificationResult is the outcome of the verification of an imported
+// resource.
+type VerificationResult struct {
+	// GroupVersionKind is the kind of the imported resource
+	GroupVersionKind schema.GroupVersionKind
+	// Namespace is the namespace of the imported resource
+	Namespace string
+	// Name is the name of the imported resource
+	Name string
+	// Verified is true if the AWS resource has been adopted and is managed
+	// by the controller of the new cluster
+	Verified bool
+	// Reason explains why the resource is not verified
+	Reason string
+}
+
+// Verify checks that the AWS resources of the supplied imported manifests
+// have been taken over by the controllers of the cluster they were imported
+// into: the resources must be adopted, synced, not terminal and, when the
+// manifest records the ARN of the AWS resource, backed by that same AWS
+// resource.
+func Verify(
+	ctx context.Context,
+	reader client.Reader,
+	manifests []*k8sunstructured.Unstructured,
+) ([]VerificationResult, error) {
+	results := make([]VerificationResult, 0, len(manifests))
+	for _, manifest := range manifests {
+		result := VerificationResult{
+			GroupVersionKind: manifest.GroupVersionKind(),
+			Namespace:        manifest.GetNamespace(),
+			Name:             manifest.GetName(),
+		}
+		imported := &k8sunstructured.Unstructured{}
+		imported.SetGroupVersionKind(manifest.GroupVersionKind())
+		err := reader.Get(ctx, client.ObjectKeyFromObject(manifest), imported)
+		if apierrors.IsNotFound(err) {
+			result.Reason = "resource not found"
+		} else if err != nil {
+			return nil, err
+		} else {
+			result.Reason, err = verifyImported(manifest, imported)
+			if err != nil {
+				return nil, err
+			}
+			result.Verified = result.Reason == ""
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// verifyImported returns the reason why the supplied imported resource is
+// not verified, or an empty string if it is.
+func verifyImported(manifest, imported *k8sunstructured.Unstructured) (string, error) {
+	if !strings.EqualFold(imported.GetAnnotations()[ackv1alpha1.AnnotationAdopted], "true") {
+		return "resource not adopted", nil
+	}
+	terminal, err := ackunstructured.GetCondition(imported, ackv1alpha1.ConditionTypeTerminal)
+	if err != nil {
+		return "", err
+	}
+	if terminal != nil && terminal.Status == corev1.ConditionTrue {
+		return fmt.Sprintf("resource is terminal: %s", conditionMessage(terminal)), nil
+	}
+	synced, err := ackunstructured.GetCondition(imported, ackv1alpha1.ConditionTypeResourceSynced)
+	if err != nil {
+		return "", err
+	}
+	if synced == nil || synced.Status != corev1.ConditionTrue {
+		return fmt.Sprintf("resource not synced: %s", conditionMessage(synced)), nil
+	}
+	fields := map[string]string{}
+	if raw, ok := manifest.GetAnnotations()[ackv1alpha1.AnnotationAdoptionFields]; ok {
+		if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+			return "", fmt.Errorf("decoding adoption fields: %v", err)
+		}
+	}
+	if expected, ok := fields[adoptionFieldARN]; ok {
+		metadata, err := ackunstructured.GetResourceMetadata(imported)
+		if err != nil {
+			return "", err
+		}
+		if metadata == nil || metadata.ARN == nil || string(*metadata.ARN) != expected {
+			return fmt.Sprintf("resource is not backed by AWS resource %s", expected), nil
+		}
+	}
+	return "", nil
+}
+
+// conditionMessage returns a description of the supplied condition
+func conditionMessage(condition *ackv1alpha1.Condition) string {
+	if condition == nil {
+		return "no condition"
+	}
+	if condition.Message != nil {
+		return *condition.Message
+	}
+	if condition.Reason != nil {
+		return *condition.Reason
+	}
+	return string(condition.Status)
+}
@@ -0,0 +1,151 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package migration_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/migration"
+	ackunstructured "github.com/aws-controllers-k8s/runtime/pkg/unstructured"
+)
+
+var bookGVK = schema.GroupVersionKind{
+	Group:   "bookstore.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Book",
+}
+
+func newBook(t *testing.T, name string, arn string) *k8sunstructured.Unstructured {
+	u := &k8sunstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"title": name},
+	}}
+	u.SetGroupVersionKind(bookGVK)
+	u.SetNamespace("default")
+	u.SetName(name)
+	u.SetFinalizers([]string{"finalizers.bookstore.services.k8s.aws/Book"})
+	u.SetAnnotations(map[string]string{
+		ackv1alpha1.AnnotationAdopted: "true",
+		"team":                        "books",
+	})
+	if arn != "" {
+		resourceARN := ackv1alpha1.AWSResourceName(arn)
+		accountID := ackv1alpha1.AWSAccountID("123456789012")
+		region := ackv1alpha1.AWSRegion("us-west-2")
+		require.Nil(t, ackunstructured.SetResourceMetadata(u, &ackv1alpha1.ResourceMetadata{
+			ARN:            &resourceARN,
+			OwnerAccountID: &accountID,
+			Region:         &region,
+		}))
+	}
+	return u
+}
+
+func newScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	scheme.AddKnownTypeWithName(bookGVK, &k8sunstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(
+		bookGVK.GroupVersion().WithKind("BookList"), &k8sunstructured.UnstructuredList{},
+	)
+	metav1.AddToGroupVersion(scheme, bookGVK.GroupVersion())
+	return scheme
+}
+
+func TestExportImportVerify(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	arn := "arn:aws:bookstore:us-west-2:123456789012:book/dune"
+	source := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		newBook(t, "dune", arn),
+		newBook(t, "emma", ""),
+	).Build()
+
+	manifests, err := migration.Export(ctx, source, []schema.GroupVersionKind{bookGVK}, migration.ExportOptions{})
+	require.Nil(err)
+	require.Len(manifests, 2)
+
+	dune := manifests[0]
+	require.Equal("dune", dune.GetName())
+	require.Empty(dune.GetFinalizers())
+	require.Empty(dune.GetResourceVersion())
+	_, hasStatus := dune.Object["status"]
+	require.False(hasStatus)
+	require.Equal(map[string]string{
+		"team":                               "books",
+		ackv1alpha1.AnnotationAdoptionPolicy: "adopt",
+		ackv1alpha1.AnnotationAdoptionFields: `{"arn":"` + arn + `"}`,
+		ackv1alpha1.AnnotationOwnerAccountID: "123456789012",
+		ackv1alpha1.AnnotationRegion:         "us-west-2",
+		ackv1alpha1.AnnotationTakeOver:       "true",
+	}, dune.GetAnnotations())
+
+	emma := manifests[1]
+	require.Equal("adopt-or-create", emma.GetAnnotations()[ackv1alpha1.AnnotationAdoptionPolicy])
+	_, hasFields := emma.GetAnnotations()[ackv1alpha1.AnnotationAdoptionFields]
+	require.False(hasFields)
+
+	var buf bytes.Buffer
+	require.Nil(migration.WriteManifests(&buf, manifests))
+	read, err := migration.ReadManifests(&buf)
+	require.Nil(err)
+	require.Equal(manifests, read)
+
+	target := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	require.Nil(migration.Import(ctx, target, read))
+	// Importing again leaves the existing resources untouched
+	require.Nil(migration.Import(ctx, target, read))
+
+	results, err := migration.Verify(ctx, target, read)
+	require.Nil(err)
+	require.Len(results, 2)
+	require.False(results[0].Verified)
+	require.Equal("resource not adopted", results[0].Reason)
+
+	// Simulate the adoption of the AWS resource by the new controller
+	imported := &k8sunstructured.Unstructured{}
+	imported.SetGroupVersionKind(bookGVK)
+	require.Nil(target.Get(ctx, client.ObjectKey{Namespace: "default", Name: "dune"}, imported))
+	annotations := imported.GetAnnotations()
+	annotations[ackv1alpha1.AnnotationAdopted] = "true"
+	imported.SetAnnotations(annotations)
+	require.Nil(ackunstructured.SetCondition(imported, &ackv1alpha1.Condition{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionTrue,
+	}))
+	require.Nil(target.Update(ctx, imported))
+
+	results, err = migration.Verify(ctx, target, read)
+	require.Nil(err)
+	require.Equal("resource is not backed by AWS resource "+arn, results[0].Reason)
+
+	resourceARN := ackv1alpha1.AWSResourceName(arn)
+	require.Nil(ackunstructured.SetResourceMetadata(imported, &ackv1alpha1.ResourceMetadata{ARN: &resourceARN}))
+	require.Nil(target.Update(ctx, imported))
+	results, err = migration.Verify(ctx, target, read)
+	require.Nil(err)
+	require.True(results[0].Verified)
+	require.False(results[1].Verified)
+}
@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package requeue
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DefaultBackoff is the backoff of NeededWithBackoff errors that do not
+// configure one: 5s, 10s, 20s... up to 5 minutes, give or take 20%.
+var DefaultBackoff = Backoff{
+	Base:   5 * time.Second,
+	Factor: 2,
+	Max:    5 * time.Minute,
+	Jitter: 0.2,
+}
+
+// Backoff configures the exponentially increasing delays after which an item
+// failing repeatedly is requeued
+type Backoff struct {
+	// Base is the delay of the first attempt
+	Base time.Duration
+	// Factor multiplies the delay on every attempt. Defaults to 2.
+	Factor float64
+	// Max caps the delay, jitter excluded. No cap but the maximum duration if
+	// zero.
+	Max time.Duration
+	// Jitter is the fraction, between 0 and 1, by which the delay is
+	// randomly increased or decreased, so that items failing together are
+	// not all requeued at the same time
+	Jitter float64
+}
+
+// Delay returns the delay after which an item is requeued on its supplied
+// attempt, starting at 1. A Backoff without base delay is the DefaultBackoff.
+func (b Backoff) Delay(attempt int) time.Duration {
+	if b.Base <= 0 {
+		b = DefaultBackoff
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+	factor := b.Factor
+	if factor <= 0 {
+		factor = 2
+	}
+	delay := float64(b.Base) * math.Pow(factor, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		jitter := math.Min(b.Jitter, 1)
+		delay *= 1 + jitter*(2*rand.Float64()-1)
+	}
+	// Without cap, the delay eventually exceeds the maximum duration, which
+	// would overflow to a negative duration
+	if delay >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// NeededWithBackoff returns a new RequeueNeededWithBackoff to instruct the ACK
+// runtime to requeue the processing item, without been logged as error, after
+// a delay growing exponentially with the number of consecutive reconciliations
+// of the item that returned a RequeueNeededWithBackoff. The count is reset
+// once a reconciliation of the item returns any other result.
+func NeededWithBackoff(
+	err error,
+	backoff Backoff,
+) *RequeueNeededWithBackoff {
+	return &RequeueNeededWithBackoff{
+		RequeueNeeded{
+			err: err,
+		},
+		backoff,
+	}
+}
+
+// RequeueNeededWithBackoff instructs the ACK runtime to requeue the processing
+// item after an exponentially increasing delay without been logged as error.
+// This should be used instead of RequeueNeededAfter when a condition may take
+// a while to resolve, e.g. a dependency that is not ready yet, so that the
+// item is checked often at first, and rarely once it has been waiting long.
+type RequeueNeededWithBackoff struct {
+	RequeueNeeded
+	backoff Backoff
+}
+
+func (e *RequeueNeededWithBackoff) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+	return e.err.Error()
+}
+
+// Backoff returns the backoff of the delays after which the item is
+// requeued
+func (e *RequeueNeededWithBackoff) Backoff() Backoff {
+	if e == nil {
+		return DefaultBackoff
+	}
+	return e.backoff
+}
+
+func (e *RequeueNeededWithBackoff) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.err
+}
+
+// Ensure RequeueNeededWithBackoff implements the error interface
+var _ error = &RequeueNeededWithBackoff{}
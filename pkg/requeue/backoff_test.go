@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package requeue_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	assert := assert.New(t)
+
+	backoff := requeue.Backoff{Base: time.Second, Max: 10 * time.Second}
+	assert.Equal(time.Second, backoff.Delay(0))
+	assert.Equal(time.Second, backoff.Delay(1))
+	assert.Equal(2*time.Second, backoff.Delay(2))
+	assert.Equal(8*time.Second, backoff.Delay(4))
+	assert.Equal(10*time.Second, backoff.Delay(5))
+	assert.Equal(10*time.Second, backoff.Delay(100))
+
+	backoff = requeue.Backoff{Base: time.Second, Factor: 3}
+	assert.Equal(9*time.Second, backoff.Delay(3))
+
+	// Without cap, the delay never overflows
+	backoff = requeue.Backoff{Base: time.Second}
+	assert.Equal(time.Duration(math.MaxInt64), backoff.Delay(100))
+	assert.Equal(time.Duration(math.MaxInt64), backoff.Delay(math.MaxInt32))
+	backoff = requeue.Backoff{Base: time.Second, Jitter: 0.5}
+	assert.Greater(backoff.Delay(1000), time.Duration(0))
+
+	backoff = requeue.Backoff{}
+	assert.LessOrEqual(backoff.Delay(100), 6*time.Minute)
+
+	backoff = requeue.Backoff{Base: 10 * time.Second, Max: time.Minute, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		delay := backoff.Delay(10)
+		assert.GreaterOrEqual(delay, 30*time.Second)
+		assert.LessOrEqual(delay, 90*time.Second)
+	}
+}
+
+func TestNeededWithBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	err := requeue.NeededWithBackoff(errors.New("dependency not ready"), requeue.DefaultBackoff)
+	assert.Equal("dependency not ready", err.Error())
+	assert.EqualError(err.Unwrap(), "dependency not ready")
+	assert.Equal(requeue.DefaultBackoff, err.Backoff())
+
+	var nilErr *requeue.RequeueNeededWithBackoff
+	assert.Empty(nilErr.Error())
+	assert.Nil(nilErr.Unwrap())
+	assert.Equal(requeue.DefaultBackoff, nilErr.Backoff())
+}
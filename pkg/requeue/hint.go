@@ -0,0 +1,69 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package requeue
+
+import (
+	"time"
+)
+
+// SyncState describes the observed state of a resource, from the point of view
+// of how often it needs to be re-checked.
+type SyncState string
+
+const (
+	// SyncStateStable is the state of a resource that is not expected to
+	// change on its own.
+	SyncStateStable SyncState = "Stable"
+	// SyncStateTransitioning is the state of a resource that is expected to
+	// change soon, e.g. a resource being created or modified asynchronously
+	// by the AWS service.
+	SyncStateTransitioning SyncState = "Transitioning"
+)
+
+// SyncHint is a structured hint, returned by a resource manager, about when a
+// resource should next be reconciled. The runtime converts sync hints into
+// requeue scheduling, so that the reconciliation cadence of a resource can
+// depend on its state and not only on its kind.
+type SyncHint struct {
+	// State is the observed state of the resource
+	State SyncState
+	// After is the duration after which the resource should be reconciled
+	// again. A zero value lets the runtime use its default for the state.
+	After time.Duration
+	// Reason is an optional human readable explanation of the hint, used
+	// in logs.
+	Reason string
+}
+
+// Stable returns a SyncHint for a stable resource that should be checked again
+// after the supplied duration.
+func Stable(after time.Duration, reason string) *SyncHint {
+	return &SyncHint{State: SyncStateStable, After: after, Reason: reason}
+}
+
+// Transitioning returns a SyncHint for a transitioning resource that should be
+// checked again after the supplied duration.
+func Transitioning(after time.Duration, reason string) *SyncHint {
+	return &SyncHint{State: SyncStateTransitioning, After: after, Reason: reason}
+}
+
+// AfterOr returns the duration after which the resource should be reconciled
+// again, or the supplied default duration if the hint is nil or does not
+// specify one.
+func (h *SyncHint) AfterOr(defaultDuration time.Duration) time.Duration {
+	if h == nil || h.After <= 0 {
+		return defaultDuration
+	}
+	return h.After
+}
@@ -120,3 +120,16 @@ func TestRequeueNeededAfter_Nil(t *testing.T) {
 	assert.Empty(nilRequeueNeeded.Error())
 	assert.Nil(nilRequeueNeeded.Unwrap())
 }
+
+func TestSyncHint_AfterOr(t *testing.T) {
+	var nilHint *requeue.SyncHint
+	assert.Equal(t, 30*time.Second, nilHint.AfterOr(30*time.Second))
+
+	stable := requeue.Stable(time.Hour, "resource is available")
+	assert.Equal(t, requeue.SyncStateStable, stable.State)
+	assert.Equal(t, time.Hour, stable.AfterOr(30*time.Second))
+
+	transitioning := requeue.Transitioning(0, "resource is being modified")
+	assert.Equal(t, requeue.SyncStateTransitioning, transitioning.State)
+	assert.Equal(t, 30*time.Second, transitioning.AfterOr(30*time.Second))
+}
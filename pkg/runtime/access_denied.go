@@ -0,0 +1,125 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// accountIDRegex matches the AWS account IDs redacted from the access denied
+// explanations, which are visible to the users of the namespace
+var accountIDRegex = regexp.MustCompile(`\b\d{12}\b`)
+
+// decodeAuthorizationMessage decodes the encoded authorization message of an
+// access denied error with STS DecodeAuthorizationMessage, using the supplied
+// AWS configuration
+func decodeAuthorizationMessage(
+	ctx context.Context,
+	cfg aws.Config,
+	encoded string,
+) (string, error) {
+	out, err := sts.NewFromConfig(cfg).DecodeAuthorizationMessage(ctx, &sts.DecodeAuthorizationMessageInput{
+		EncodedMessage: aws.String(encoded),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.DecodedMessage), nil
+}
+
+// decodedAuthorizationMessage contains the fields of a decoded authorization
+// message that are included in access denied explanations
+type decodedAuthorizationMessage struct {
+	Allowed      bool `json:"allowed"`
+	ExplicitDeny bool `json:"explicitDeny"`
+	Context      struct {
+		Action   string `json:"action"`
+		Resource string `json:"resource"`
+	} `json:"context"`
+}
+
+// explainAccessDenied emits, when the --explain-access-denied flag is set and
+// the supplied error is an access denied error, an event explaining the
+// denied call: the action, the resource ARN and, if the AWS service API
+// returned an encoded authorization message, whether the call was explicitly
+// denied. Account IDs are redacted.
+func (r *resourceReconciler) explainAccessDenied(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	clientConfig aws.Config,
+	err error,
+) {
+	if !r.cfg.ExplainAccessDenied {
+		return
+	}
+	details, ok := ackerr.AccessDenied(err)
+	if !ok {
+		return
+	}
+	rlog := ackrtlog.FromContext(ctx)
+
+	action, resource := details.Action, ""
+	if ids := desired.Identifiers(); !ackcompare.IsNil(ids) && ids.ARN() != nil {
+		resource = string(*ids.ARN())
+	}
+	explicitDeny := ""
+	if details.EncodedAuthorizationMessage != "" {
+		decoded, decodeErr := decodeAuthorizationMessage(ctx, clientConfig, details.EncodedAuthorizationMessage)
+		msg := decodedAuthorizationMessage{}
+		if decodeErr == nil {
+			decodeErr = json.Unmarshal([]byte(decoded), &msg)
+		}
+		if decodeErr != nil {
+			rlog.Info("unable to decode authorization message", "error", decodeErr)
+		} else {
+			if msg.Context.Action != "" {
+				action = msg.Context.Action
+			}
+			if msg.Context.Resource != "" {
+				resource = msg.Context.Resource
+			}
+			explicitDeny = fmt.Sprintf("%t", msg.ExplicitDeny)
+		}
+	}
+
+	parts := []string{}
+	if action != "" {
+		parts = append(parts, "action: "+action)
+	}
+	if resource != "" {
+		parts = append(parts, "resource: "+accountIDRegex.ReplaceAllString(resource, "*"))
+	}
+	if explicitDeny != "" {
+		parts = append(parts, "explicit deny: "+explicitDeny)
+	}
+	message := "AWS denied a call of the controller"
+	if len(parts) > 0 {
+		message += " (" + strings.Join(parts, ", ") + ")"
+	}
+	rlog.Info("access denied", "action", action, "resource", resource, "explicit_deny", explicitDeny)
+	r.recordEvent(desired.RuntimeObject(), ackevents.ReasonAccessDenied, message)
+}
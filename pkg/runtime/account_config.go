@@ -0,0 +1,195 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+)
+
+const (
+	// accountConfigInterval is the interval at which the AccountConfigs whose
+	// spec changed are validated
+	accountConfigInterval = time.Minute
+	// accountConfigRevalidationPeriod is the period after which the IAM role
+	// of an AccountConfig is assumed again, to detect that it no longer is
+	// assumable
+	accountConfigRevalidationPeriod = 10 * time.Minute
+)
+
+// accountConfigValidator validates the AccountConfigs, checking that their
+// IAM role belongs to their AWS account and can be assumed, and writes the
+// outcome in the ACK.ResourceSynced condition of their status. Like the
+// ControllerConfig watcher, it writes to the Kubernetes API and only runs on
+// the leader.
+type accountConfigValidator struct {
+	log    logr.Logger
+	kc     client.Client
+	reader client.Reader
+	c      *serviceController
+	region ackv1alpha1.AWSRegion
+	// validatedAt maps the names of the AccountConfigs to the time their
+	// IAM role was last assumed
+	validatedAt map[string]time.Time
+}
+
+// Start validates the AccountConfigs until the supplied context is done
+func (v *accountConfigValidator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(accountConfigInterval)
+	defer ticker.Stop()
+	for {
+		if err := v.sync(ctx); err != nil {
+			v.log.Error(err, "unable to validate the account configs")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync validates the AccountConfigs whose generation changed since their
+// last validation, or which were last validated more than
+// accountConfigRevalidationPeriod ago
+func (v *accountConfigValidator) sync(ctx context.Context) error {
+	list := &ackv1alpha1.AccountConfigList{}
+	if err := v.reader.List(ctx, list); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(list.Items))
+	for i := range list.Items {
+		ac := &list.Items[i]
+		seen[ac.Name] = struct{}{}
+		validatedAt, ok := v.validatedAt[ac.Name]
+		if ok && ac.Status.ObservedGeneration == ac.Generation &&
+			time.Since(validatedAt) < accountConfigRevalidationPeriod {
+			continue
+		}
+		if err := v.validate(ctx, ac); err != nil {
+			v.log.Error(err, "unable to write the status of the account config", "name", ac.Name)
+			continue
+		}
+		v.validatedAt[ac.Name] = time.Now()
+	}
+	for name := range v.validatedAt {
+		if _, ok := seen[name]; !ok {
+			delete(v.validatedAt, name)
+		}
+	}
+	return nil
+}
+
+// validate checks the IAM role of the supplied AccountConfig and writes the
+// outcome in its status, if it changed
+func (v *accountConfigValidator) validate(ctx context.Context, ac *ackv1alpha1.AccountConfig) error {
+	synced := &ackv1alpha1.Condition{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionTrue,
+	}
+	if err := v.assumeRole(ctx, ac.Spec); err != nil {
+		v.log.Info("invalid account config", "name", ac.Name, "error", err.Error())
+		msg := err.Error()
+		synced.Status = corev1.ConditionFalse
+		synced.Message = &msg
+	}
+	status := ac.Status.DeepCopy()
+	status.ObservedGeneration = ac.Generation
+	status.Conditions = setAccountConfigCondition(status.Conditions, synced)
+	if reflect.DeepEqual(status, &ac.Status) {
+		return nil
+	}
+	patch := client.MergeFrom(ac.DeepCopy())
+	ac.Status = *status
+	return v.kc.Status().Patch(ctx, ac, patch)
+}
+
+// assumeRole returns an error if the IAM role of the supplied AccountConfig
+// spec does not belong to its AWS account or cannot be assumed
+func (v *accountConfigValidator) assumeRole(ctx context.Context, spec ackv1alpha1.AccountConfigSpec) error {
+	parsed, err := arn.Parse(string(spec.RoleARN))
+	if err != nil || parsed.Service != "iam" {
+		return fmt.Errorf("role ARN %q is not the ARN of an IAM role", spec.RoleARN)
+	}
+	if parsed.AccountID != string(spec.AccountID) {
+		return fmt.Errorf(
+			"role ARN %q does not belong to account %q", spec.RoleARN, spec.AccountID,
+		)
+	}
+	region := v.region
+	if spec.Region != nil && *spec.Region != "" {
+		region = *spec.Region
+	}
+	awsCfg, err := v.c.NewAWSConfig(
+		ctx, region, spec.EndpointURL, spec.RoleARN,
+		ackv1alpha1.GroupVersion.WithKind("AccountConfig"),
+	)
+	if err == nil {
+		_, err = awsCfg.Credentials.Retrieve(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to assume role %q: %v", spec.RoleARN, err)
+	}
+	return nil
+}
+
+// setAccountConfigCondition replaces the condition of the same type as the
+// supplied one, keeping its transition time if its status did not change
+func setAccountConfigCondition(
+	conditions []*ackv1alpha1.Condition,
+	condition *ackv1alpha1.Condition,
+) []*ackv1alpha1.Condition {
+	now := metav1.Now()
+	for i, c := range conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		condition.LastTransitionTime = c.LastTransitionTime
+		if c.Status != condition.Status || condition.LastTransitionTime == nil {
+			condition.LastTransitionTime = &now
+		}
+		conditions[i] = condition
+		return conditions
+	}
+	condition.LastTransitionTime = &now
+	return append(conditions, condition)
+}
+
+// addAccountConfigValidator adds to the supplied manager the validator of
+// the AccountConfigs
+func (c *serviceController) addAccountConfigValidator(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+) error {
+	return mgr.Add(&accountConfigValidator{
+		log:         c.log.WithName("account-config"),
+		kc:          mgr.GetClient(),
+		reader:      mgr.GetAPIReader(),
+		c:           c,
+		region:      ackv1alpha1.AWSRegion(cfg.Region),
+		validatedAt: map[string]time.Time{},
+	})
+}
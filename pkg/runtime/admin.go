@@ -0,0 +1,106 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/admin"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+// adminEnqueueTimeout is the time the admin service waits for a controller to
+// accept a forced reconciliation. Controllers of replicas that are not the
+// leader never accept them.
+const adminEnqueueTimeout = 5 * time.Second
+
+// adminKind returns the description of the kind of the reconciler used by the
+// admin service
+func (r *resourceReconciler) adminKind() admin.Kind {
+	kind := admin.Kind{
+		GroupVersionKind: r.rd.GroupVersionKind(),
+		Enqueue: func(ctx context.Context, namespace, name string) error {
+			ctx, cancel := context.WithTimeout(ctx, adminEnqueueTimeout)
+			defer cancel()
+			if !r.enqueue(ctx, namespace, name) {
+				return errors.New(
+					"the reconciler did not accept the request, is this replica the leader?",
+				)
+			}
+			return nil
+		},
+		State: func(namespace, name string) map[string]interface{} {
+			return map[string]interface{}{
+				"deferredOperations": r.scheduler.ScheduledFor(namespace, name),
+				"resyncPeriod":       r.resyncPeriod.String(),
+			}
+		},
+	}
+	if r.cache.BackReferences != nil {
+		kind.Referrers = r.referrers
+	}
+	return kind
+}
+
+// addAdminServer adds the admin service, introspecting the reconcilers and
+// the supplied caches, to the supplied manager
+func (c *serviceController) addAdminServer(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+	caches ackrtcache.Caches,
+) error {
+	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	kinds := []admin.Kind{}
+	for _, rec := range c.reconcilers {
+		if r, ok := rec.(*resourceReconciler); ok {
+			kind := r.adminKind()
+			gvk := kind.GroupVersionKind
+			mapping, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				return fmt.Errorf("unable to map kind %s to its resource: %v", gvk.Kind, err)
+			}
+			kind.Resource = mapping.Resource.Resource
+			kinds = append(kinds, kind)
+		}
+	}
+	// The service controllers bound to the same manager are served by the
+	// admin service of the first of them
+	if srv := c.shared.getAdminServer(); srv != nil {
+		srv.WithKinds(kinds...)
+		return nil
+	}
+	srv := admin.NewServer(
+		c.log.WithName("admin"), cfg.AdminBindAddress, mgr.GetAPIReader(),
+		admin.NewTokenReviewAuthenticator(clientSet.AuthenticationV1().TokenReviews(), cfg.AdminTokenAudiences),
+		admin.NewSubjectAccessReviewAuthorizer(clientSet.AuthorizationV1().SubjectAccessReviews()),
+		kinds,
+		func() interface{} { return caches.Snapshot() },
+		func() interface{} { return c.stateDump(caches) },
+	).WithTLS(cfg.AdminTLSCertFile, cfg.AdminTLSKeyFile).WithSupportBundle(func(ctx context.Context, w io.Writer) error {
+		return c.writeSupportBundle(ctx, w, mgr, cfg, caches)
+	})
+	c.shared.setAdminServer(srv)
+	return mgr.Add(srv)
+}
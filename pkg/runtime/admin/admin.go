@@ -0,0 +1,662 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package admin contains the admin service of the controller, a REST API
+// letting platform operators introspect a running controller: list the
+// resources it tracks, force their reconciliation, inspect its caches and
+// dump the runtime state of a resource. The API is served over TLS.
+// Requests are authenticated with Kubernetes bearer tokens, validated with
+// the TokenReview API for the audiences of the admin service, and authorized
+// with the SubjectAccessReview API, so that callers need RBAC permissions on
+// what they introspect.
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/logschema"
+)
+
+// shutdownTimeout is the time given to in flight requests to complete when
+// the server stops
+const shutdownTimeout = 5 * time.Second
+
+var (
+	// ErrUnauthenticated is returned by an Authenticator when the supplied
+	// token is not valid
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrTLSRequired is returned by Server.Start when no serving certificate
+	// was set
+	ErrTLSRequired = errors.New("the admin service requires a TLS serving certificate")
+)
+
+// Authenticator authenticates the bearer tokens of admin requests
+type Authenticator interface {
+	// Authenticate returns the user of the supplied token, or
+	// ErrUnauthenticated if the token is not valid
+	Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, error)
+}
+
+// TokenReviewAuthenticator is an Authenticator validating tokens with the
+// Kubernetes TokenReview API
+type TokenReviewAuthenticator struct {
+	client    authenticationv1client.TokenReviewInterface
+	audiences []string
+}
+
+// NewTokenReviewAuthenticator returns an Authenticator using the supplied
+// TokenReview client, only accepting the tokens issued for one of the
+// supplied audiences, e.g. the ones of `kubectl create token --audience`
+func NewTokenReviewAuthenticator(
+	client authenticationv1client.TokenReviewInterface,
+	audiences []string,
+) *TokenReviewAuthenticator {
+	return &TokenReviewAuthenticator{client: client, audiences: audiences}
+}
+
+// Authenticate returns the user of the supplied token
+func (a *TokenReviewAuthenticator) Authenticate(
+	ctx context.Context,
+	token string,
+) (authenticationv1.UserInfo, error) {
+	review, err := a.client.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: a.audiences},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, ErrUnauthenticated
+	}
+	// The API server returns the audiences the token is valid for among the
+	// requested ones. An authenticator ignoring the audiences returns none.
+	if len(a.audiences) > 0 && !intersects(a.audiences, review.Status.Audiences) {
+		return authenticationv1.UserInfo{}, ErrUnauthenticated
+	}
+	return review.Status.User, nil
+}
+
+// intersects returns true if the supplied lists have a value in common
+func intersects(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Action is what an admin request does, authorized against the Kubernetes
+// RBAC permissions of its caller. Requests about the controller itself, e.g.
+// the dumps of its caches, are a verb on their path, granted with the
+// nonResourceURLs of a ClusterRole. Requests about resources are a verb on
+// the resources of their kind.
+type Action struct {
+	// Verb is the Kubernetes verb of the action, e.g. get or list
+	Verb string
+	// Path is the path of the requests about the controller itself
+	Path string
+	// Group is the API group of the resources
+	Group string
+	// Resource is the plural resource name of the resources
+	Resource string
+	// Namespace of the resources, empty for all namespaces
+	Namespace string
+	// Name of the resource, empty for all resources
+	Name string
+}
+
+// Authorizer authorizes the actions of the authenticated admin requests
+type Authorizer interface {
+	// Authorize returns true if the supplied user may perform the supplied
+	// action
+	Authorize(ctx context.Context, user authenticationv1.UserInfo, action Action) (bool, error)
+}
+
+// SubjectAccessReviewAuthorizer is an Authorizer checking the RBAC
+// permissions of the users with the Kubernetes SubjectAccessReview API
+type SubjectAccessReviewAuthorizer struct {
+	client authorizationv1client.SubjectAccessReviewInterface
+}
+
+// NewSubjectAccessReviewAuthorizer returns an Authorizer using the supplied
+// SubjectAccessReview client
+func NewSubjectAccessReviewAuthorizer(
+	client authorizationv1client.SubjectAccessReviewInterface,
+) *SubjectAccessReviewAuthorizer {
+	return &SubjectAccessReviewAuthorizer{client: client}
+}
+
+// Authorize returns true if the supplied user may perform the supplied
+// action
+func (a *SubjectAccessReviewAuthorizer) Authorize(
+	ctx context.Context,
+	user authenticationv1.UserInfo,
+	action Action,
+) (bool, error) {
+	spec := authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		Groups: user.Groups,
+		UID:    user.UID,
+	}
+	if len(user.Extra) > 0 {
+		spec.Extra = make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for key, value := range user.Extra {
+			spec.Extra[key] = authorizationv1.ExtraValue(value)
+		}
+	}
+	if action.Resource == "" {
+		spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{
+			Path: action.Path,
+			Verb: action.Verb,
+		}
+	} else {
+		spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+			Verb:      action.Verb,
+			Group:     action.Group,
+			Resource:  action.Resource,
+			Namespace: action.Namespace,
+			Name:      action.Name,
+		}
+	}
+	review, err := a.client.Create(ctx, &authorizationv1.SubjectAccessReview{Spec: spec}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// Kind is a kind of resource reconciled by the controller
+type Kind struct {
+	// GroupVersionKind is the group, version and kind of the resources
+	GroupVersionKind schema.GroupVersionKind
+	// Resource is the plural resource name of the resources, used to
+	// authorize the requests about them
+	Resource string
+	// Enqueue forces the reconciliation of the resource with the supplied
+	// namespace and name
+	Enqueue func(ctx context.Context, namespace, name string) error
+	// State returns the runtime state of the resource with the supplied
+	// namespace and name that is not stored on the resource itself
+	State func(namespace, name string) map[string]interface{}
+	// Referrers returns the resources referencing the resource with the
+	// supplied namespace and name
+	Referrers func(namespace, name string) []string
+}
+
+// ResourceSummary describes a resource in the listings of the admin service
+type ResourceSummary struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+	Synced     string `json:"synced,omitempty"`
+}
+
+// Server serves the admin API:
+//
+//	GET  /v1/kinds                                     the reconciled kinds
+//	GET  /v1/caches                                    the contents of the caches
+//	GET  /v1/state                                     the internal state of the controller
+//	GET  /v1/support-bundle                            a support bundle of the controller
+//	GET  /v1/log-schema                                the JSON Schema of the log lines of --log-format=json
+//	GET  /v1/resources/{kind}[?namespace=]             the resources of a kind
+//	GET  /v1/resources/{kind}/{namespace}/{name}       a resource and its runtime state
+//	GET  /v1/resources/{kind}/{namespace}/{name}/referrers  the resources referencing a resource
+//	POST /v1/resources/{kind}/{namespace}/{name}/reconcile  forces a reconciliation
+//
+// The requests about the controller itself need the permission of the verb
+// (get or create) of their method on their path, and the requests about
+// resources the permission to list, get or, to force their reconciliation,
+// update them.
+//
+// Server is a manager.Runnable, run on every replica of the controller.
+type Server struct {
+	log    logr.Logger
+	addr   string
+	reader client.Reader
+	authn  Authenticator
+	authz  Authorizer
+	kinds  map[string]Kind
+	caches func() interface{}
+	state  func() interface{}
+	// supportBundle writes a support bundle of the controller
+	supportBundle func(ctx context.Context, w io.Writer) error
+	// certFile and keyFile are the paths of the PEM encoded serving
+	// certificate and key
+	certFile string
+	keyFile  string
+}
+
+// NewServer returns a Server listening on the supplied address. caches
+// returns the contents of the caches of the controller, and state its
+// internal state, e.g. its work queues and in-flight reconciliations.
+func NewServer(
+	log logr.Logger,
+	addr string,
+	reader client.Reader,
+	authn Authenticator,
+	authz Authorizer,
+	kinds []Kind,
+	caches func() interface{},
+	state func() interface{},
+) *Server {
+	byName := make(map[string]Kind, len(kinds))
+	for _, kind := range kinds {
+		byName[strings.ToLower(kind.GroupVersionKind.Kind)] = kind
+	}
+	return &Server{
+		log:    log,
+		addr:   addr,
+		reader: reader,
+		authn:  authn,
+		authz:  authz,
+		kinds:  byName,
+		caches: caches,
+		state:  state,
+	}
+}
+
+// WithKinds adds the supplied kinds to the kinds served by the Server, e.g.
+// the kinds of another service controller running in the same binary. It
+// must be called before the Server is started.
+func (s *Server) WithKinds(kinds ...Kind) *Server {
+	for _, kind := range kinds {
+		s.kinds[strings.ToLower(kind.GroupVersionKind.Kind)] = kind
+	}
+	return s
+}
+
+// WithSupportBundle sets the function writing the support bundles of the
+// controller served by the Server, as gzipped tar archives
+func (s *Server) WithSupportBundle(
+	bundle func(ctx context.Context, w io.Writer) error,
+) *Server {
+	s.supportBundle = bundle
+	return s
+}
+
+// WithTLS sets the paths of the PEM encoded serving certificate and key of
+// the Server, which refuses to start without them
+func (s *Server) WithTLS(certFile, keyFile string) *Server {
+	s.certFile = certFile
+	s.keyFile = keyFile
+	return s
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The admin
+// service runs on every replica, so that all of them can be introspected.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the admin API until the supplied context is done
+func (s *Server) Start(ctx context.Context) error {
+	if s.certFile == "" || s.keyFile == "" {
+		return ErrTLSRequired
+	}
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		s.log.Info("starting admin service", "address", s.addr)
+		errCh <- srv.ListenAndServeTLS(s.certFile, s.keyFile)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// Handler returns the authenticated and authorized handler of the admin API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /v1/kinds", s.authorize(pathAction, s.listKinds))
+	mux.Handle("GET /v1/caches", s.authorize(pathAction, s.getCaches))
+	mux.Handle("GET /v1/state", s.authorize(pathAction, s.getState))
+	mux.Handle("GET /v1/support-bundle", s.authorize(pathAction, s.getSupportBundle))
+	mux.Handle("GET /v1/log-schema", s.authorize(pathAction, s.getLogSchema))
+	mux.Handle("GET /v1/resources/{kind}", s.authorize(s.kindAction("list"), s.listResources))
+	mux.Handle("GET /v1/resources/{kind}/{namespace}/{name}", s.authorize(s.kindAction("get"), s.getResource))
+	mux.Handle("GET /v1/resources/{kind}/{namespace}/{name}/referrers", s.authorize(s.kindAction("get"), s.listReferrers))
+	mux.Handle("POST /v1/resources/{kind}/{namespace}/{name}/reconcile", s.authorize(s.kindAction("update"), s.reconcileResource))
+	return s.authenticate(mux)
+}
+
+// userKey is the context key of the authenticated user of a request
+type userKey struct{}
+
+// pathAction returns the action of a request about the controller itself:
+// the verb of its method on its path
+func pathAction(req *http.Request) (Action, bool) {
+	verb := "get"
+	if req.Method == http.MethodPost {
+		verb = "create"
+	}
+	return Action{Verb: verb, Path: req.URL.Path}, true
+}
+
+// kindAction returns the function returning the action of a request about
+// the resources of a kind: the supplied verb on the resources of the kind,
+// in the namespace of the request. The function returns false if the
+// controller does not reconcile the kind.
+func (s *Server) kindAction(verb string) func(req *http.Request) (Action, bool) {
+	return func(req *http.Request) (Action, bool) {
+		kind, ok := s.kinds[strings.ToLower(req.PathValue("kind"))]
+		if !ok {
+			return Action{}, false
+		}
+		namespace := req.PathValue("namespace")
+		if namespace == "" {
+			namespace = req.URL.Query().Get("namespace")
+		}
+		return Action{
+			Verb:      verb,
+			Group:     kind.GroupVersionKind.Group,
+			Resource:  kind.Resource,
+			Namespace: namespace,
+			Name:      req.PathValue("name"),
+		}, true
+	}
+}
+
+// authorize rejects the requests whose authenticated user is not allowed
+// the action of the request returned by the supplied function. The requests
+// about kinds the controller does not reconcile are passed through, to be
+// answered with a NotFound error.
+func (s *Server) authorize(
+	action func(req *http.Request) (Action, bool),
+	next http.HandlerFunc,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a, ok := action(req)
+		if !ok {
+			next.ServeHTTP(w, req)
+			return
+		}
+		user, _ := req.Context().Value(userKey{}).(authenticationv1.UserInfo)
+		allowed, err := s.authz.Authorize(req.Context(), user, a)
+		if err != nil {
+			s.log.Error(err, "unable to authorize admin request")
+			writeError(w, http.StatusInternalServerError, "unable to authorize the request")
+			return
+		}
+		if !allowed {
+			s.log.Info(
+				"forbidden admin request",
+				"user", user.Username, "method", req.Method, "path", req.URL.Path,
+			)
+			writeError(w, http.StatusForbidden, fmt.Sprintf("user %q is not allowed to %s %s", user.Username, a.Verb, a.target()))
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// target returns the description of what the action is performed on, for
+// the error messages
+func (a Action) target() string {
+	if a.Resource == "" {
+		return a.Path
+	}
+	target := a.Resource
+	if a.Group != "" {
+		target += "." + a.Group
+	}
+	if a.Namespace != "" {
+		target += " in namespace " + a.Namespace
+	}
+	return target
+}
+
+// authenticate rejects the requests without a valid bearer token, and passes
+// the authenticated user of the others in their context
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "a bearer token is required")
+			return
+		}
+		user, err := s.authn.Authenticate(req.Context(), token)
+		if errors.Is(err, ErrUnauthenticated) {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if err != nil {
+			s.log.Error(err, "unable to authenticate admin request")
+			writeError(w, http.StatusInternalServerError, "unable to authenticate the request")
+			return
+		}
+		s.log.V(1).Info("admin request", "user", user.Username, "method", req.Method, "path", req.URL.Path)
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userKey{}, user)))
+	})
+}
+
+func (s *Server) listKinds(w http.ResponseWriter, req *http.Request) {
+	kinds := make([]string, 0, len(s.kinds))
+	for _, kind := range s.kinds {
+		kinds = append(kinds, kind.GroupVersionKind.String())
+	}
+	sort.Strings(kinds)
+	writeJSON(w, http.StatusOK, kinds)
+}
+
+func (s *Server) getCaches(w http.ResponseWriter, req *http.Request) {
+	if s.caches == nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	writeJSON(w, http.StatusOK, s.caches())
+}
+
+func (s *Server) getState(w http.ResponseWriter, req *http.Request) {
+	if s.state == nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	writeJSON(w, http.StatusOK, s.state())
+}
+
+func (s *Server) getSupportBundle(w http.ResponseWriter, req *http.Request) {
+	if s.supportBundle == nil {
+		writeError(w, http.StatusNotImplemented, "support bundles are not supported by this controller")
+		return
+	}
+	// The bundle is buffered, so that a failure can still be reported with
+	// an error status
+	var buf bytes.Buffer
+	if err := s.supportBundle(req.Context(), &buf); err != nil {
+		s.log.Error(err, "unable to collect the support bundle")
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", "ack-support-bundle-"+time.Now().UTC().Format("20060102T150405Z")+".tar.gz"),
+	)
+	w.WriteHeader(http.StatusOK)
+	_, _ = buf.WriteTo(w)
+}
+
+func (s *Server) getLogSchema(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, logschema.Schema)
+}
+
+func (s *Server) listResources(w http.ResponseWriter, req *http.Request) {
+	kind, ok := s.kind(w, req)
+	if !ok {
+		return
+	}
+	list := &k8sunstructured.UnstructuredList{}
+	list.SetGroupVersionKind(kind.GroupVersionKind.GroupVersion().WithKind(kind.GroupVersionKind.Kind + "List"))
+	opts := []client.ListOption{}
+	if ns := req.URL.Query().Get("namespace"); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	if err := s.reader.List(req.Context(), list, opts...); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	resources := make([]ResourceSummary, 0, len(list.Items))
+	for i := range list.Items {
+		u := &list.Items[i]
+		resources = append(resources, ResourceSummary{
+			Namespace:  u.GetNamespace(),
+			Name:       u.GetName(),
+			Generation: u.GetGeneration(),
+			Synced:     conditionStatus(u, ackv1alpha1.ConditionTypeResourceSynced),
+		})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Namespace != resources[j].Namespace {
+			return resources[i].Namespace < resources[j].Namespace
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	writeJSON(w, http.StatusOK, resources)
+}
+
+func (s *Server) getResource(w http.ResponseWriter, req *http.Request) {
+	kind, ok := s.kind(w, req)
+	if !ok {
+		return
+	}
+	namespace, name := req.PathValue("namespace"), req.PathValue("name")
+	u := &k8sunstructured.Unstructured{}
+	u.SetGroupVersionKind(kind.GroupVersionKind)
+	err := s.reader.Get(req.Context(), client.ObjectKey{Namespace: namespace, Name: name}, u)
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	state := map[string]interface{}{}
+	if kind.State != nil {
+		state = kind.State(namespace, name)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"object": u.Object,
+		"state":  state,
+	})
+}
+
+func (s *Server) listReferrers(w http.ResponseWriter, req *http.Request) {
+	kind, ok := s.kind(w, req)
+	if !ok {
+		return
+	}
+	if kind.Referrers == nil {
+		writeError(w, http.StatusNotImplemented, "the index of the referrers is not enabled for this kind")
+		return
+	}
+	referrers := kind.Referrers(req.PathValue("namespace"), req.PathValue("name"))
+	if referrers == nil {
+		referrers = []string{}
+	}
+	writeJSON(w, http.StatusOK, referrers)
+}
+
+func (s *Server) reconcileResource(w http.ResponseWriter, req *http.Request) {
+	kind, ok := s.kind(w, req)
+	if !ok {
+		return
+	}
+	if kind.Enqueue == nil {
+		writeError(w, http.StatusNotImplemented, "forcing reconciliations is not supported for this kind")
+		return
+	}
+	namespace, name := req.PathValue("namespace"), req.PathValue("name")
+	if err := kind.Enqueue(req.Context(), namespace, name); err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	s.log.Info(
+		"forced reconciliation from the admin service",
+		"kind", kind.GroupVersionKind.Kind, "namespace", namespace, "name", name,
+	)
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "enqueued"})
+}
+
+// kind returns the Kind named in the request path, writing a NotFound error
+// if the controller does not reconcile it
+func (s *Server) kind(w http.ResponseWriter, req *http.Request) (Kind, bool) {
+	kind, ok := s.kinds[strings.ToLower(req.PathValue("kind"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown kind "+req.PathValue("kind"))
+	}
+	return kind, ok
+}
+
+// conditionStatus returns the status of the condition of the supplied type of
+// a resource, or Unknown if the resource has no such condition
+func conditionStatus(u *k8sunstructured.Unstructured, conditionType ackv1alpha1.ConditionType) string {
+	conditions, _, _ := k8sunstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cm["type"] == string(conditionType) {
+			status, _ := cm["status"].(string)
+			return status
+		}
+	}
+	return string(corev1.ConditionUnknown)
+}
+
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"error": message})
+}
@@ -0,0 +1,384 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/logschema"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/admin"
+)
+
+var bookGVK = schema.GroupVersionKind{
+	Group:   "bookstore.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Book",
+}
+
+func newBook(namespace, name, synced string) *k8sunstructured.Unstructured {
+	u := &k8sunstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetGroupVersionKind(bookGVK)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "ACK.ResourceSynced", "status": synced},
+		},
+	}
+	return u
+}
+
+type tokenAuthenticator map[string]string
+
+func (a tokenAuthenticator) Authenticate(_ context.Context, token string) (authenticationv1.UserInfo, error) {
+	if user, ok := a[token]; ok {
+		return authenticationv1.UserInfo{Username: user}, nil
+	}
+	return authenticationv1.UserInfo{}, admin.ErrUnauthenticated
+}
+
+// rbac allows the users the actions matching one of their rules, the
+// zero-valued fields of the rules matching any value
+type rbac map[string][]admin.Action
+
+func (a rbac) Authorize(_ context.Context, user authenticationv1.UserInfo, action admin.Action) (bool, error) {
+	for _, rule := range a[user.Username] {
+		if matches(rule.Verb, action.Verb) && matches(rule.Path, action.Path) &&
+			matches(rule.Group, action.Group) && matches(rule.Resource, action.Resource) &&
+			matches(rule.Namespace, action.Namespace) && matches(rule.Name, action.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matches(rule, value string) bool {
+	return rule == "" || rule == value
+}
+
+// allowAll allows the sre user everything
+var allowAll = rbac{"sre": {{}}}
+
+func newServer(authz admin.Authorizer, enqueue func(context.Context, string, string) error) http.Handler {
+	scheme := k8sruntime.NewScheme()
+	scheme.AddKnownTypeWithName(bookGVK, &k8sunstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(
+		bookGVK.GroupVersion().WithKind("BookList"), &k8sunstructured.UnstructuredList{},
+	)
+	metav1.AddToGroupVersion(scheme, bookGVK.GroupVersion())
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newBook("ns-b", "b", "True"),
+		newBook("ns-a", "a", "False"),
+	).Build()
+	return admin.NewServer(
+		logr.Discard(), ":0", reader,
+		tokenAuthenticator{"secret": "sre", "tenant": "system:serviceaccount:ns-a:default"},
+		authz,
+		[]admin.Kind{{
+			GroupVersionKind: bookGVK,
+			Resource:         "books",
+			Enqueue:          enqueue,
+			State: func(namespace, name string) map[string]interface{} {
+				return map[string]interface{}{"resyncPeriod": "10h0m0s"}
+			},
+			Referrers: func(namespace, name string) []string {
+				if name == "a" {
+					return []string{"Shelf.bookstore.services.k8s.aws/" + namespace + "/top"}
+				}
+				return nil
+			},
+		}},
+		func() interface{} { return map[string]string{"accounts": "none"} },
+		func() interface{} { return map[string]int{"queueLength": 2} },
+	).Handler()
+}
+
+func do(h http.Handler, method, path, token string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	body := map[string]interface{}{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	return rec, body
+}
+
+func TestServer_Authentication(t *testing.T) {
+	require := require.New(t)
+
+	h := newServer(allowAll, nil)
+	rec, _ := do(h, http.MethodGet, "/v1/kinds", "")
+	require.Equal(http.StatusUnauthorized, rec.Code)
+	rec, _ = do(h, http.MethodGet, "/v1/kinds", "wrong")
+	require.Equal(http.StatusUnauthorized, rec.Code)
+	rec, _ = do(h, http.MethodGet, "/v1/kinds", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.JSONEq(`["bookstore.services.k8s.aws/v1alpha1, Kind=Book"]`, rec.Body.String())
+}
+
+func TestServer_Resources(t *testing.T) {
+	require := require.New(t)
+
+	enqueued := []string{}
+	h := newServer(allowAll, func(_ context.Context, namespace, name string) error {
+		if name == "stuck" {
+			return errors.New("not the leader")
+		}
+		enqueued = append(enqueued, namespace+"/"+name)
+		return nil
+	})
+
+	rec, _ := do(h, http.MethodGet, "/v1/resources/book", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.JSONEq(`[
+		{"namespace": "ns-a", "name": "a", "generation": 0, "synced": "False"},
+		{"namespace": "ns-b", "name": "b", "generation": 0, "synced": "True"}
+	]`, rec.Body.String())
+
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book?namespace=ns-b", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.JSONEq(`[{"namespace": "ns-b", "name": "b", "generation": 0, "synced": "True"}]`, rec.Body.String())
+
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Author", "secret")
+	require.Equal(http.StatusNotFound, rec.Code)
+
+	rec, body := do(h, http.MethodGet, "/v1/resources/Book/ns-a/a", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.Equal(map[string]interface{}{"resyncPeriod": "10h0m0s"}, body["state"])
+	require.Equal("a", body["object"].(map[string]interface{})["metadata"].(map[string]interface{})["name"])
+
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book/ns-a/missing", "secret")
+	require.Equal(http.StatusNotFound, rec.Code)
+
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book/ns-a/a/referrers", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.JSONEq(`["Shelf.bookstore.services.k8s.aws/ns-a/top"]`, rec.Body.String())
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book/ns-b/b/referrers", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.JSONEq(`[]`, rec.Body.String())
+
+	rec, _ = do(h, http.MethodPost, "/v1/resources/Book/ns-a/a/reconcile", "secret")
+	require.Equal(http.StatusAccepted, rec.Code)
+	require.Equal([]string{"ns-a/a"}, enqueued)
+	rec, _ = do(h, http.MethodPost, "/v1/resources/Book/ns-a/stuck/reconcile", "secret")
+	require.Equal(http.StatusServiceUnavailable, rec.Code)
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book/ns-a/a/reconcile", "secret")
+	require.Equal(http.StatusMethodNotAllowed, rec.Code)
+
+	rec, body = do(h, http.MethodGet, "/v1/caches", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.Equal(map[string]interface{}{"accounts": "none"}, body)
+
+	rec, body = do(h, http.MethodGet, "/v1/state", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.Equal(map[string]interface{}{"queueLength": float64(2)}, body)
+}
+
+func TestServer_SupportBundle(t *testing.T) {
+	require := require.New(t)
+
+	srv := admin.NewServer(
+		logr.Discard(), ":0", fake.NewClientBuilder().Build(), tokenAuthenticator{"secret": "sre"},
+		allowAll, nil, nil, nil,
+	)
+	rec, _ := do(srv.Handler(), http.MethodGet, "/v1/support-bundle", "secret")
+	require.Equal(http.StatusNotImplemented, rec.Code)
+
+	fail := false
+	srv.WithSupportBundle(func(_ context.Context, w io.Writer) error {
+		if fail {
+			return errors.New("unable to list resources")
+		}
+		_, err := w.Write([]byte("bundle"))
+		return err
+	})
+	rec, _ = do(srv.Handler(), http.MethodGet, "/v1/support-bundle", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.Equal("application/gzip", rec.Header().Get("Content-Type"))
+	require.Contains(rec.Header().Get("Content-Disposition"), "ack-support-bundle-")
+	require.Equal("bundle", rec.Body.String())
+
+	fail = true
+	rec, body := do(srv.Handler(), http.MethodGet, "/v1/support-bundle", "secret")
+	require.Equal(http.StatusInternalServerError, rec.Code)
+	require.Equal("unable to list resources", body["error"])
+}
+
+func TestServer_LogSchema(t *testing.T) {
+	require := require.New(t)
+
+	rec, body := do(newServer(allowAll, nil), http.MethodGet, "/v1/log-schema", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	require.Equal("application/schema+json", rec.Header().Get("Content-Type"))
+	require.Equal(logschema.Version, body["$id"])
+}
+
+func TestServer_Authorization(t *testing.T) {
+	require := require.New(t)
+
+	// The tenant may only read the books of its namespace
+	h := newServer(rbac{
+		"system:serviceaccount:ns-a:default": {
+			{Verb: "get", Group: bookGVK.Group, Resource: "books", Namespace: "ns-a"},
+			{Verb: "list", Group: bookGVK.Group, Resource: "books", Namespace: "ns-a"},
+		},
+	}, func(context.Context, string, string) error { return nil })
+
+	rec, _ := do(h, http.MethodGet, "/v1/resources/Book/ns-a/a", "tenant")
+	require.Equal(http.StatusOK, rec.Code)
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book?namespace=ns-a", "tenant")
+	require.Equal(http.StatusOK, rec.Code)
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book/ns-a/a/referrers", "tenant")
+	require.Equal(http.StatusOK, rec.Code)
+
+	rec, body := do(h, http.MethodGet, "/v1/resources/Book/ns-b/b", "tenant")
+	require.Equal(http.StatusForbidden, rec.Code)
+	require.Equal(
+		`user "system:serviceaccount:ns-a:default" is not allowed to get books.bookstore.services.k8s.aws in namespace ns-b`,
+		body["error"],
+	)
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Book", "tenant")
+	require.Equal(http.StatusForbidden, rec.Code)
+	rec, _ = do(h, http.MethodPost, "/v1/resources/Book/ns-a/a/reconcile", "tenant")
+	require.Equal(http.StatusForbidden, rec.Code)
+	for _, path := range []string{"/v1/kinds", "/v1/caches", "/v1/state", "/v1/support-bundle", "/v1/log-schema"} {
+		rec, _ = do(h, http.MethodGet, path, "tenant")
+		require.Equal(http.StatusForbidden, rec.Code, path)
+	}
+
+	// Unknown kinds are not found, whatever the permissions
+	rec, _ = do(h, http.MethodGet, "/v1/resources/Author/ns-a/a", "tenant")
+	require.Equal(http.StatusNotFound, rec.Code)
+
+	// The requests about the controller itself are authorized by path
+	h = newServer(rbac{"sre": {{Verb: "get", Path: "/v1/caches"}}}, nil)
+	rec, _ = do(h, http.MethodGet, "/v1/caches", "secret")
+	require.Equal(http.StatusOK, rec.Code)
+	rec, _ = do(h, http.MethodGet, "/v1/state", "secret")
+	require.Equal(http.StatusForbidden, rec.Code)
+}
+
+type failingAuthorizer struct{}
+
+func (failingAuthorizer) Authorize(context.Context, authenticationv1.UserInfo, admin.Action) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestServer_AuthorizationError(t *testing.T) {
+	rec, body := do(newServer(failingAuthorizer{}, nil), http.MethodGet, "/v1/kinds", "secret")
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Equal(t, "unable to authorize the request", body["error"])
+}
+
+func TestServer_StartRequiresTLS(t *testing.T) {
+	srv := admin.NewServer(
+		logr.Discard(), "127.0.0.1:0", fake.NewClientBuilder().Build(), tokenAuthenticator{}, allowAll,
+		nil, nil, nil,
+	)
+	require.ErrorIs(t, srv.Start(context.Background()), admin.ErrTLSRequired)
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	require := require.New(t)
+
+	clientSet := k8sfake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "secret":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "system:serviceaccount:sre:debugger"
+			review.Status.User.Groups = []string{"system:serviceaccounts"}
+			review.Status.Audiences = review.Spec.Audiences
+		case "pod-token":
+			// A token of the API server audience only
+			review.Status.Authenticated = true
+			review.Status.User.Username = "system:serviceaccount:tenant:default"
+		}
+		return true, review, nil
+	})
+	authn := admin.NewTokenReviewAuthenticator(clientSet.AuthenticationV1().TokenReviews(), []string{"ack-admin"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	user, err := authn.Authenticate(ctx, "secret")
+	require.Nil(err)
+	require.Equal("system:serviceaccount:sre:debugger", user.Username)
+	require.Equal([]string{"system:serviceaccounts"}, user.Groups)
+	_, err = authn.Authenticate(ctx, "wrong")
+	require.ErrorIs(err, admin.ErrUnauthenticated)
+	_, err = authn.Authenticate(ctx, "pod-token")
+	require.ErrorIs(err, admin.ErrUnauthenticated)
+}
+
+func TestSubjectAccessReviewAuthorizer(t *testing.T) {
+	require := require.New(t)
+
+	reviews := []authorizationv1.SubjectAccessReviewSpec{}
+	clientSet := k8sfake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviews = append(reviews, review.Spec)
+		review.Status.Allowed = review.Spec.ResourceAttributes != nil &&
+			review.Spec.ResourceAttributes.Namespace == "ns-a"
+		return true, review, nil
+	})
+	authz := admin.NewSubjectAccessReviewAuthorizer(clientSet.AuthorizationV1().SubjectAccessReviews())
+	user := authenticationv1.UserInfo{
+		Username: "jane",
+		Groups:   []string{"sre"},
+		Extra:    map[string]authenticationv1.ExtraValue{"scopes": {"admin"}},
+	}
+
+	ctx := context.Background()
+	allowed, err := authz.Authorize(ctx, user, admin.Action{
+		Verb: "get", Group: bookGVK.Group, Resource: "books", Namespace: "ns-a", Name: "a",
+	})
+	require.Nil(err)
+	require.True(allowed)
+	require.Equal("jane", reviews[0].User)
+	require.Equal([]string{"sre"}, reviews[0].Groups)
+	require.Equal(authorizationv1.ExtraValue{"admin"}, reviews[0].Extra["scopes"])
+	require.Equal(&authorizationv1.ResourceAttributes{
+		Verb: "get", Group: bookGVK.Group, Resource: "books", Namespace: "ns-a", Name: "a",
+	}, reviews[0].ResourceAttributes)
+	require.Nil(reviews[0].NonResourceAttributes)
+
+	allowed, err = authz.Authorize(ctx, user, admin.Action{Verb: "get", Path: "/v1/caches"})
+	require.Nil(err)
+	require.False(allowed)
+	require.Equal(&authorizationv1.NonResourceAttributes{Verb: "get", Path: "/v1/caches"}, reviews[1].NonResourceAttributes)
+	require.Nil(reviews[1].ResourceAttributes)
+}
@@ -33,6 +33,7 @@ import (
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
 	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
 	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
 	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
@@ -58,6 +59,10 @@ type adoptionReconciler struct {
 func (r *adoptionReconciler) BindControllerManager(mgr ctrlrt.Manager) error {
 	r.kc = mgr.GetClient()
 	r.apiReader = mgr.GetAPIReader()
+	r.recorder = r.newEventRecorder(mgr)
+	r.rateLimiter = sharedBindingFor(mgr).rateLimiterFor(r.cfg)
+	r.circuitBreaker = sharedBindingFor(mgr).circuitBreakerFor(r.sc.GetMetadata().ServiceAlias, r.cfg, r.metrics)
+	r.tracer = sharedBindingFor(mgr).getTracer()
 	return ctrlrt.NewControllerManagedBy(
 		mgr,
 	).For(
@@ -65,6 +70,8 @@ func (r *adoptionReconciler) BindControllerManager(mgr ctrlrt.Manager) error {
 		&ackv1alpha1.AdoptedResource{},
 	).WithEventFilter(
 		predicate.GenerationChangedPredicate{},
+	).Named(
+		sharedBindingFor(mgr).controllerName(r.sc.GetMetadata().ServiceAlias, "adoptedresource"),
 	).Complete(r)
 }
 
@@ -145,7 +152,7 @@ func (r *adoptionReconciler) reconcile(ctx context.Context, req ctrlrt.Request)
 	}
 
 	region := r.getRegion(res)
-	targetDescriptor := rmf.ResourceDescriptor()
+	targetDescriptor := WithFinalizer(rmf.ResourceDescriptor(), r.cfg.FinalizerName, r.cfg.MigrateDefaultFinalizer)
 	endpointURL := r.getEndpointURL(res)
 	gvk := targetDescriptor.GroupVersionKind()
 
@@ -153,6 +160,10 @@ func (r *adoptionReconciler) reconcile(ctx context.Context, req ctrlrt.Request)
 	if err != nil {
 		return err
 	}
+	ctx = r.withNamespaceRetry(ctx, res.Namespace)
+	awsconfig = r.withRateLimit(awsconfig, acctID, region)
+	awsconfig = r.withCircuitBreaker(awsconfig, acctID, region)
+	awsconfig = r.withTracing(awsconfig, acctID, region)
 
 	ackrtlog.InfoAdoptedResource(r.log, res, "starting adoption reconciliation")
 
@@ -181,6 +192,13 @@ func (r *adoptionReconciler) Sync(
 	rm acktypes.AWSResourceManager,
 	desired *ackv1alpha1.AdoptedResource,
 ) error {
+	// Reject malformed identifiers before they reach the resource manager.
+	// They never become valid without a change of the spec.
+	if err := desired.Spec.AWS.Validate(); err != nil {
+		_ = r.onError(ctx, desired, fmt.Errorf("invalid AWS identifiers: %v", err))
+		return ackerr.Terminal
+	}
+
 	// Create empty resource with spec/status fields set for ReadOne
 	readableResource := targetDescriptor.ResourceFromRuntimeObject(targetDescriptor.EmptyRuntimeObject())
 	if err := readableResource.SetIdentifiers(desired.Spec.AWS); err != nil {
@@ -246,6 +264,12 @@ func (r *adoptionReconciler) Sync(
 	}
 
 	described.SetObjectMeta(*targetMeta)
+
+	// Refuse to adopt AWS resources managed by the controller of another
+	// cluster, unless the adopted resource is annotated to take them over.
+	if _, err := checkClusterOwnership(r.cfg, rm, described, IsTakeOver(described)); err != nil {
+		return r.onError(ctx, desired, err)
+	}
 	targetDescriptor.MarkManaged(described)
 	targetDescriptor.MarkAdopted(described)
 
@@ -281,6 +305,8 @@ func (r *adoptionReconciler) Sync(
 		return r.onError(ctx, desired, err)
 	}
 
+	r.recordEvent(desired, ackevents.ReasonAdopted, "Adopted existing AWS resource")
+
 	// Don't attempt to patch conditions again, directly return result of
 	// 'r.onSuccess'
 	return r.onSuccess(ctx, desired)
@@ -535,30 +561,15 @@ func (r *adoptionReconciler) getRoleARN(id string, cacheName string) (ackv1alpha
 	return ackv1alpha1.AWSResourceName(roleARN), nil
 }
 
-// getRegion returns the AWS region that the given resource is in or should be
-// created in. If the CR have a region associated with it, it is used. Otherwise
-// we look for the namespace associated region, if that is set we use it. Finally
-// if none of these annotations are set we use the use the region specified in the
-// configuration is used
+// getRegion returns the AWS region that the given resource is in, resolved
+// with the same order of precedence as the regions of the resources: the
+// region annotation of the AdoptedResource, the default region annotation of
+// its namespace, then the default region of the controller
 func (r *adoptionReconciler) getRegion(
 	res *ackv1alpha1.AdoptedResource,
 ) ackv1alpha1.AWSRegion {
-	// look for region in CR metadata annotations
-	resAnnotations := res.GetAnnotations()
-	region, ok := resAnnotations[ackv1alpha1.AnnotationRegion]
-	if ok {
-		return ackv1alpha1.AWSRegion(region)
-	}
-
-	// look for default region in namespace metadata annotations
-	ns := res.GetNamespace()
-	defaultRegion, ok := r.cache.Namespaces.GetDefaultRegion(ns)
-	if ok {
-		return ackv1alpha1.AWSRegion(defaultRegion)
-	}
-
-	// use controller configuration region
-	return ackv1alpha1.AWSRegion(r.cfg.Region)
+	region, _ := r.regionFor(res)
+	return region
 }
 
 // patchMetadataAndSpec patches the Metadata and Spec for AdoptedResource into
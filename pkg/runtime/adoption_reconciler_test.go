@@ -32,6 +32,7 @@ import (
 	ctrlrtclientmock "github.com/aws-controllers-k8s/runtime/mocks/controller-runtime/pkg/client"
 	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
 	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
 	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
 	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
@@ -188,6 +189,39 @@ func TestSync_FailureInSettingIdentifiers(t *testing.T) {
 	assertAdoptedCondition("False", require, t, ctx, kc, statusWriter, adoptedRes)
 }
 
+func TestSync_InvalidIdentifiers(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	// Mock resource creation
+	r, kc, _ := mockAdoptionReconciler()
+	descriptor, res, resDeepCopy := mockDescriptorAndAWSResource()
+	manager := mockManager()
+	adoptedRes := adoptedResource(AdoptedResourceNamespace, AdoptedResourceName)
+	invalidARN := ackv1alpha1.AWSResourceName("arn:aws:s3")
+	adoptedRes.Spec.AWS = &ackv1alpha1.AWSIdentifiers{ARN: &invalidARN}
+	ctx := context.TODO()
+	statusWriter := &ctrlrtclientmock.SubResourceWriter{}
+
+	//Mock behavior setup
+	setupMockAwsResource(res, resDeepCopy, adoptedRes)
+	setupMockClientForAdoptedResource(kc, statusWriter, ctx, adoptedRes)
+
+	// Call
+	err := r.Sync(ctx, descriptor, manager, adoptedRes)
+
+	// Assertions
+	// identifiers are rejected before reaching the resource
+	require.Equal(ackerr.Terminal, err)
+	res.AssertNotCalled(t, "SetIdentifiers", adoptedRes.Spec.AWS)
+	manager.AssertNotCalled(t, "ReadOne", ctx, res)
+	assertAdoptedCondition("False", require, t, ctx, kc, statusWriter, adoptedRes)
+	require.Contains(*adoptedRes.Status.Conditions[0].Message, "invalid AWS identifiers")
+
+	// empty identifiers are rejected too
+	adoptedRes.Spec.AWS = &ackv1alpha1.AWSIdentifiers{}
+	require.Equal(ackerr.Terminal, r.Sync(ctx, descriptor, manager, adoptedRes))
+}
+
 func TestSync_FailureInReadOne(t *testing.T) {
 	// Setup
 	require := require.New(t)
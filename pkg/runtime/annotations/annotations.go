@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package annotations lists the annotations of the resources that change how
+// the runtime reconciles them. Annotating a resource does not change its
+// generation, so the updates of these annotations are watched for, rather
+// than picked up at the next resync.
+package annotations
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// Watched are the annotations of the resources read by the runtime when
+// reconciling them. The annotations written by the runtime itself, e.g.
+// services.k8s.aws/spec-hash, are not watched, so that writing them does not
+// reconcile the resources again. The services.k8s.aws/plan and
+// services.k8s.aws/paused annotations have their own predicates.
+var Watched = []string{
+	ackv1alpha1.AnnotationOwnerRoleARN,
+	ackv1alpha1.AnnotationRegion,
+	ackv1alpha1.AnnotationDeletionPolicy,
+	ackv1alpha1.AnnotationFinalizerPolicy,
+	ackv1alpha1.AnnotationReconcileInterval,
+	ackv1alpha1.AnnotationReadOnly,
+	ackv1alpha1.AnnotationAdoptionPolicy,
+	ackv1alpha1.AnnotationAdoptionFields,
+	ackv1alpha1.AnnotationTakeOver,
+	ackv1alpha1.AnnotationMigrateTo,
+	ackv1alpha1.AnnotationProgressDeadline,
+	ackv1alpha1.AnnotationCreateProgressDeadline,
+	ackv1alpha1.AnnotationUpdateProgressDeadline,
+	ackv1alpha1.AnnotationDeleteProgressDeadline,
+}
+
+// ChangedPredicate returns the predicate accepting the updates of the
+// resources for which any of the supplied annotations was added, removed or
+// changed, the other updates of their metadata being filtered out
+func ChangedPredicate(keys ...string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			oldAnnotations := e.ObjectOld.GetAnnotations()
+			newAnnotations := e.ObjectNew.GetAnnotations()
+			for _, key := range keys {
+				oldValue, oldOK := oldAnnotations[key]
+				newValue, newOK := newAnnotations[key]
+				if oldOK != newOK || oldValue != newValue {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package annotations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/annotations"
+)
+
+func TestChangedPredicate(t *testing.T) {
+	annotated := func(annotations map[string]string) client.Object {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+	interval := map[string]string{ackv1alpha1.AnnotationReconcileInterval: "60s"}
+
+	for _, tc := range []struct {
+		name string
+		old  client.Object
+		new  client.Object
+		want bool
+	}{
+		{
+			name: "annotation added",
+			old:  annotated(nil),
+			new:  annotated(interval),
+			want: true,
+		},
+		{
+			name: "annotation removed",
+			old:  annotated(interval),
+			new:  annotated(nil),
+			want: true,
+		},
+		{
+			name: "annotation changed",
+			old:  annotated(interval),
+			new:  annotated(map[string]string{ackv1alpha1.AnnotationReconcileInterval: "5m"}),
+			want: true,
+		},
+		{
+			name: "empty annotation added",
+			old:  annotated(nil),
+			new:  annotated(map[string]string{ackv1alpha1.AnnotationTakeOver: ""}),
+			want: true,
+		},
+		{
+			name: "other watched annotation changed",
+			old:  annotated(map[string]string{ackv1alpha1.AnnotationDeleteProgressDeadline: "1h"}),
+			new:  annotated(map[string]string{ackv1alpha1.AnnotationDeleteProgressDeadline: "2h"}),
+			want: true,
+		},
+		{
+			name: "annotation unchanged",
+			old:  annotated(interval),
+			new:  annotated(map[string]string{ackv1alpha1.AnnotationReconcileInterval: "60s", "team": "a"}),
+			want: false,
+		},
+		{
+			name: "runtime annotation changed",
+			old:  annotated(map[string]string{ackv1alpha1.AnnotationSpecHash: "a"}),
+			new:  annotated(map[string]string{ackv1alpha1.AnnotationSpecHash: "b"}),
+			want: false,
+		},
+		{
+			name: "missing object",
+			old:  nil,
+			new:  annotated(interval),
+			want: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := annotations.ChangedPredicate(annotations.Watched...).Update(
+				event.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new},
+			)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
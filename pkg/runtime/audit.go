@@ -0,0 +1,136 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/audit"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// addAuditor adds to the supplied manager the auditor writing the audit
+// records of the changes of the AWS resources of the service controllers to
+// the sink of the --audit-log-sink flag
+func (c *serviceController) addAuditor(mgr ctrlrt.Manager, cfg ackcfg.Config) error {
+	auditor, err := audit.New(c.log.WithName("audit"), cfg.AuditLogSink)
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(auditor); err != nil {
+		return err
+	}
+	c.shared.setAuditor(auditor)
+	return nil
+}
+
+// withAudit returns a copy of the supplied configuration of the AWS service
+// clients collecting the IDs of the requests of the audited operations
+func (r *reconciler) withAudit(cfg aws.Config) aws.Config {
+	if r.auditor == nil {
+		return cfg
+	}
+	apiOptions := make([]func(*middleware.Stack) error, 0, len(cfg.APIOptions)+1)
+	apiOptions = append(apiOptions, cfg.APIOptions...)
+	cfg.APIOptions = append(apiOptions, audit.Middleware())
+	return cfg
+}
+
+// withAuditIdentity returns a copy of the supplied context of a
+// reconciliation carrying the account, region and IAM identity its audit
+// records are attributed to: the supplied role of the cross account resource
+// management binding of the resource, or the identity of the controller
+func (r *reconciler) withAuditIdentity(
+	ctx context.Context,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+	roleARN ackv1alpha1.AWSResourceName,
+) context.Context {
+	if r.auditor == nil {
+		return ctx
+	}
+	caller := string(roleARN)
+	if caller == "" {
+		caller = r.cfg.CallerARN
+	}
+	return audit.WithIdentity(ctx, audit.Identity{
+		AccountID: string(acctID),
+		Region:    string(region),
+		Caller:    caller,
+	})
+}
+
+// beginAudit returns a copy of the supplied context collecting the IDs of
+// the requests to the AWS service APIs of an audited operation
+func (r *resourceReconciler) beginAudit(ctx context.Context) context.Context {
+	if r.auditor == nil {
+		return ctx
+	}
+	return audit.WithRequestIDs(ctx)
+}
+
+// audit records the supplied operation of the AWS resource of the supplied
+// resource, made with the supplied context returned by beginAudit, its
+// latest state being the supplied one, the changes made the supplied ones
+// and its error the supplied one
+func (r *resourceReconciler) audit(
+	ctx context.Context,
+	op acktypes.AWSResourceOperation,
+	res acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	changes []string,
+	err error,
+) {
+	if r.auditor == nil {
+		return
+	}
+	if ackcompare.IsNotNil(latest) {
+		res = latest
+	}
+	gvk := r.rd.GroupVersionKind()
+	mo := res.MetaObject()
+	identity := audit.IdentityFrom(ctx)
+	record := audit.Record{
+		Time:       time.Now().UTC(),
+		Operation:  string(op),
+		Group:      gvk.Group,
+		Kind:       gvk.Kind,
+		Namespace:  mo.GetNamespace(),
+		Name:       mo.GetName(),
+		UID:        string(mo.GetUID()),
+		Generation: mo.GetGeneration(),
+		AccountID:  identity.AccountID,
+		Region:     identity.Region,
+		Caller:     identity.Caller,
+		RequestIDs: audit.RequestIDs(ctx),
+		Changes:    changes,
+		Outcome:    audit.OutcomeSucceeded,
+	}
+	if arn := res.Identifiers().ARN(); arn != nil {
+		record.ARN = string(*arn)
+	}
+	if err != nil {
+		record.Outcome = audit.OutcomeFailed
+		record.Error = r.redactMessage(res.RuntimeObject(), err.Error())
+	}
+	r.auditor.Record(record)
+}
@@ -0,0 +1,318 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package audit records every creation, update and deletion of an AWS
+// resource made by the controller, as a JSON document written to a sink: the
+// standard output, an append-only file, or a webhook. The records identify
+// the resource, its account and region, the IAM identity making the calls,
+// the IDs of the requests to the AWS service APIs and the changes made, so
+// that compliance teams get a record of the changes independent of the
+// latency of CloudTrail.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/go-logr/logr"
+)
+
+const (
+	// SinkStdout is the sink writing the records to the standard output
+	SinkStdout = "stdout"
+	// queueSize is the number of records buffered by a webhook sink.
+	// Records sent while the buffer is full are dropped.
+	queueSize = 1000
+	// sendTimeout is the timeout of a single webhook request
+	sendTimeout = 10 * time.Second
+	// middlewareID is the ID of the middleware collecting the IDs of the
+	// requests to the AWS service APIs
+	middlewareID = "ACKAudit"
+)
+
+// Outcome is the outcome of an audited operation
+type Outcome string
+
+const (
+	// OutcomeSucceeded is the outcome of the operations that succeeded
+	OutcomeSucceeded Outcome = "Succeeded"
+	// OutcomeFailed is the outcome of the operations that failed
+	OutcomeFailed Outcome = "Failed"
+)
+
+// Record is the audit record of a creation, update or deletion of an AWS
+// resource
+type Record struct {
+	Time       time.Time `json:"time"`
+	Operation  string    `json:"operation"`
+	Group      string    `json:"group"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	UID        string    `json:"uid,omitempty"`
+	Generation int64     `json:"generation"`
+	ARN        string    `json:"arn,omitempty"`
+	AccountID  string    `json:"accountID"`
+	Region     string    `json:"region"`
+	// Caller is the IAM identity making the calls to the AWS service APIs:
+	// the role of the cross account resource management binding of the
+	// namespace, or the identity of the controller
+	Caller string `json:"caller,omitempty"`
+	// RequestIDs are the IDs of the requests to the AWS service APIs made by
+	// the operation
+	RequestIDs []string `json:"requestIDs,omitempty"`
+	// Changes summarize the changes of the spec made by an update, with the
+	// values of the sensitive fields redacted
+	Changes []string `json:"changes,omitempty"`
+	Outcome Outcome  `json:"outcome"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// identityKey is the key of the identity of the reconciliation in a context
+type identityKey struct{}
+
+// Identity is where and as whom a reconciliation calls the AWS service APIs
+type Identity struct {
+	AccountID string
+	Region    string
+	Caller    string
+}
+
+// WithIdentity returns a copy of the supplied context carrying the supplied
+// identity of the reconciliation
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFrom returns the identity of the reconciliation of the supplied
+// context, empty if it has none
+func IdentityFrom(ctx context.Context) Identity {
+	identity, _ := ctx.Value(identityKey{}).(Identity)
+	return identity
+}
+
+// requestsKey is the key of the collected request IDs in a context
+type requestsKey struct{}
+
+// requests collects the IDs of the requests to the AWS service APIs made
+// with a context
+type requests struct {
+	sync.Mutex
+	ids []string
+}
+
+// WithRequestIDs returns a copy of the supplied context collecting the IDs
+// of the requests to the AWS service APIs made with it, returned by
+// RequestIDs
+func WithRequestIDs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestsKey{}, &requests{})
+}
+
+// RequestIDs returns the IDs of the requests to the AWS service APIs made
+// with the supplied context since WithRequestIDs
+func RequestIDs(ctx context.Context) []string {
+	reqs, ok := ctx.Value(requestsKey{}).(*requests)
+	if !ok {
+		return nil
+	}
+	reqs.Lock()
+	defer reqs.Unlock()
+	return append([]string(nil), reqs.ids...)
+}
+
+// Middleware returns the API option collecting the IDs of the requests to
+// the AWS service APIs made with the contexts returned by WithRequestIDs
+func Middleware() func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(
+			middlewareID,
+			func(
+				ctx context.Context,
+				in middleware.InitializeInput,
+				next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				out, md, err := next.HandleInitialize(ctx, in)
+				if reqs, ok := ctx.Value(requestsKey{}).(*requests); ok {
+					if requestID, ok := awsmiddleware.GetRequestIDMetadata(md); ok {
+						reqs.Lock()
+						reqs.ids = append(reqs.ids, requestID)
+						reqs.Unlock()
+					}
+				}
+				return out, md, err
+			},
+		), middleware.After)
+	}
+}
+
+// ValidateSink returns an error if the supplied sink is not "stdout", the
+// absolute path of a file, or an HTTP(S) URL
+func ValidateSink(sink string) error {
+	if sink == SinkStdout || filepath.IsAbs(sink) {
+		return nil
+	}
+	if u, err := url.Parse(sink); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return nil
+	}
+	return fmt.Errorf("expected %q, the absolute path of a file, or an HTTP(S) URL, got %q", SinkStdout, sink)
+}
+
+// Auditor writes the audit records to its sink. The records written to the
+// standard output or to a file are written synchronously, one JSON document
+// per line, the file being only appended to. The records sent to a webhook
+// are queued and sent in order, in the background, failed requests being
+// logged. Auditor implements the controller-runtime manager.Runnable
+// interface. A nil Auditor drops every record.
+type Auditor struct {
+	log logr.Logger
+	// w is the writer of the standard output or file sinks, nil for a
+	// webhook sink
+	w     io.Writer
+	wLock sync.Mutex
+	// closer closes the file of a file sink
+	closer io.Closer
+	url    string
+	client *http.Client
+	queue  chan Record
+}
+
+// New returns an Auditor writing the records to the supplied sink, as
+// validated by ValidateSink
+func New(log logr.Logger, sink string) (*Auditor, error) {
+	if err := ValidateSink(sink); err != nil {
+		return nil, err
+	}
+	a := &Auditor{log: log}
+	switch {
+	case sink == SinkStdout:
+		a.w = os.Stdout
+	case filepath.IsAbs(sink):
+		f, err := os.OpenFile(sink, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open audit log file: %v", err)
+		}
+		a.w, a.closer = f, f
+	default:
+		a.url = sink
+		a.client = &http.Client{Timeout: sendTimeout}
+		a.queue = make(chan Record, queueSize)
+	}
+	return a, nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Records are
+// only produced by the reconcilers of the leader.
+func (a *Auditor) NeedLeaderElection() bool {
+	return false
+}
+
+// Record writes the supplied record to the sink of the Auditor
+func (a *Auditor) Record(record Record) {
+	if a == nil {
+		return
+	}
+	if a.queue != nil {
+		select {
+		case a.queue <- record:
+		default:
+			a.log.Info(
+				"dropping audit record, webhook queue is full",
+				"operation", record.Operation,
+				"kind", record.Kind,
+				"namespace", record.Namespace,
+				"name", record.Name,
+			)
+		}
+		return
+	}
+	line, err := json.Marshal(record)
+	if err == nil {
+		a.wLock.Lock()
+		_, err = a.w.Write(append(line, '\n'))
+		a.wLock.Unlock()
+	}
+	if err != nil {
+		a.log.Error(
+			err, "unable to write audit record",
+			"operation", record.Operation,
+			"kind", record.Kind,
+			"namespace", record.Namespace,
+			"name", record.Name,
+		)
+	}
+}
+
+// Start sends the queued records of a webhook sink until the supplied
+// context is done, and then closes the file of a file sink
+func (a *Auditor) Start(ctx context.Context) error {
+	if a.queue == nil {
+		<-ctx.Done()
+		if a.closer != nil {
+			a.wLock.Lock()
+			defer a.wLock.Unlock()
+			return a.closer.Close()
+		}
+		return nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case record := <-a.queue:
+			if err := a.post(ctx, record); err != nil {
+				a.log.Error(
+					err, "unable to send audit record",
+					"operation", record.Operation,
+					"kind", record.Kind,
+					"namespace", record.Namespace,
+					"name", record.Name,
+				)
+			}
+		}
+	}
+}
+
+// post sends the supplied record to the webhook endpoint
+func (a *Auditor) post(ctx context.Context, record Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
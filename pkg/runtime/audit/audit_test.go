@@ -0,0 +1,168 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package audit_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/audit"
+)
+
+func TestMiddleware(t *testing.T) {
+	require := require.New(t)
+
+	stack := middleware.NewStack("test", func() interface{} { return nil })
+	require.Nil(audit.Middleware()(stack))
+	requestID := "req-1"
+	handler := middleware.DecorateHandler(
+		middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+			md := middleware.Metadata{}
+			awsmiddleware.SetRequestIDMetadata(&md, requestID)
+			return nil, md, nil
+		}),
+		stack,
+	)
+
+	// The request IDs are only collected with the contexts of the audited
+	// operations
+	ctx := context.Background()
+	_, _, err := handler.Handle(ctx, nil)
+	require.Nil(err)
+	require.Empty(audit.RequestIDs(ctx))
+
+	ctx = audit.WithRequestIDs(ctx)
+	_, _, err = handler.Handle(ctx, nil)
+	require.Nil(err)
+	requestID = "req-2"
+	_, _, err = handler.Handle(ctx, nil)
+	require.Nil(err)
+	require.Equal([]string{"req-1", "req-2"}, audit.RequestIDs(ctx))
+}
+
+func TestValidateSink(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(audit.ValidateSink("stdout"))
+	require.Nil(audit.ValidateSink("/var/log/ack/audit.log"))
+	require.Nil(audit.ValidateSink("https://audit.example.com/records"))
+	require.NotNil(audit.ValidateSink("audit.log"))
+	require.NotNil(audit.ValidateSink("ftp://audit.example.com"))
+	require.NotNil(audit.ValidateSink("https://"))
+}
+
+func TestAuditor_File(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.Nil(os.WriteFile(path, []byte("{}\n"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	auditor, err := audit.New(logr.Discard(), path)
+	require.Nil(err)
+	done := make(chan error)
+	go func() { done <- auditor.Start(ctx) }()
+
+	auditor.Record(audit.Record{
+		Operation:  "Create",
+		Kind:       "Bucket",
+		Namespace:  "ns",
+		Name:       "my-bucket",
+		AccountID:  "111111111111",
+		Region:     "us-west-2",
+		Caller:     "arn:aws:iam::111111111111:role/ack",
+		RequestIDs: []string{"req-1"},
+		Outcome:    audit.OutcomeSucceeded,
+	})
+	auditor.Record(audit.Record{
+		Operation: "Delete",
+		Kind:      "Bucket",
+		Namespace: "ns",
+		Name:      "my-bucket",
+		Outcome:   audit.OutcomeFailed,
+		Error:     "access denied",
+	})
+	cancel()
+	require.Nil(<-done)
+
+	// The records are appended to the file, one per line
+	f, err := os.Open(path)
+	require.Nil(err)
+	defer f.Close()
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.Len(lines, 3)
+	record := audit.Record{}
+	require.Nil(json.Unmarshal([]byte(lines[1]), &record))
+	require.Equal("Create", record.Operation)
+	require.Equal("arn:aws:iam::111111111111:role/ack", record.Caller)
+	require.Equal([]string{"req-1"}, record.RequestIDs)
+	require.Nil(json.Unmarshal([]byte(lines[2]), &record))
+	require.Equal(audit.OutcomeFailed, record.Outcome)
+	require.Equal("access denied", record.Error)
+}
+
+func TestAuditor_Webhook(t *testing.T) {
+	require := require.New(t)
+
+	received := make(chan audit.Record, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record audit.Record
+		if err := json.NewDecoder(r.Body).Decode(&record); err == nil {
+			received <- record
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	auditor, err := audit.New(logr.Discard(), server.URL)
+	require.Nil(err)
+	go func() { _ = auditor.Start(ctx) }()
+
+	auditor.Record(audit.Record{
+		Operation: "Update",
+		Kind:      "Bucket",
+		Name:      "my-bucket",
+		Changes:   []string{`Spec.Versioning: "Suspended" => "Enabled"`},
+		Outcome:   audit.OutcomeSucceeded,
+	})
+	record := <-received
+	require.Equal("Update", record.Operation)
+	require.Equal([]string{`Spec.Versioning: "Suspended" => "Enabled"`}, record.Changes)
+
+	var nilAuditor *audit.Auditor
+	nilAuditor.Record(record)
+}
+
+func TestIdentity(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(audit.Identity{}, audit.IdentityFrom(context.Background()))
+	identity := audit.Identity{AccountID: "111111111111", Region: "us-west-2", Caller: "arn:aws:iam::111111111111:role/ack"}
+	require.Equal(identity, audit.IdentityFrom(audit.WithIdentity(context.Background(), identity)))
+}
@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtawsretry "github.com/aws-controllers-k8s/runtime/pkg/runtime/awsretry"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+)
+
+// awsRetryOptions returns the options of the AWS SDK configurations applying
+// the retry strategy of the --aws-retry-mode, --aws-retry-max-attempts and
+// --aws-operation-retry-max-attempts flags to the service clients, and the
+// retry strategy of the namespace annotations to the calls made for the
+// resources of the namespaces
+func awsRetryOptions(cfg ackcfg.Config) []func(*config.LoadOptions) error {
+	options := []func(*config.LoadOptions) error{}
+	mode, err := aws.ParseRetryMode(cfg.AWSRetryMode)
+	if err == nil {
+		options = append(options, config.WithRetryMode(mode))
+	}
+	if cfg.AWSRetryMaxAttempts > 0 {
+		options = append(options, config.WithRetryMaxAttempts(cfg.AWSRetryMaxAttempts))
+	}
+	retryers := ackrtawsretry.New(mode, cfg.GetAWSOperationRetryMaxAttempts())
+	return append(options, config.WithAPIOptions([]func(*middleware.Stack) error{
+		retryers.APIOption,
+	}))
+}
+
+// withNamespaceRetry returns a copy of the supplied context whose AWS service
+// calls are retried with the retry mode and maximum number of attempts of the
+// annotations of the supplied namespace, which take precedence over the
+// --aws-retry-mode and --aws-retry-max-attempts flags. The strategy is carried
+// by the context rather than by the configuration of the service clients,
+// since the clients are shared by the resources of all the namespaces.
+// Invalid annotations are logged and ignored.
+func (r *reconciler) withNamespaceRetry(
+	ctx context.Context,
+	namespace string,
+) context.Context {
+	rlog := ackrtlog.FromContext(ctx)
+	strategy := ackrtawsretry.Strategy{MaxAttempts: r.cfg.AWSRetryMaxAttempts}
+	if mode, err := aws.ParseRetryMode(r.cfg.AWSRetryMode); err == nil {
+		strategy.Mode = mode
+	}
+	overridden := false
+	if value, ok := r.cache.Namespaces.GetAWSRetryMode(namespace); ok {
+		mode, err := aws.ParseRetryMode(value)
+		if err != nil {
+			rlog.Info("ignoring invalid namespace AWS retry mode", "value", value, "error", err)
+		} else {
+			strategy.Mode = mode
+			overridden = true
+		}
+	}
+	if value, ok := r.cache.Namespaces.GetAWSRetryMaxAttempts(namespace); ok {
+		maxAttempts, err := strconv.Atoi(value)
+		if err != nil || maxAttempts < 1 {
+			rlog.Info(
+				"ignoring invalid namespace AWS retry max attempts, expected a positive integer",
+				"value", value,
+			)
+		} else {
+			strategy.MaxAttempts = maxAttempts
+			overridden = true
+		}
+	}
+	if !overridden {
+		return ctx
+	}
+	return ackrtawsretry.WithStrategy(ctx, strategy)
+}
@@ -0,0 +1,158 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package awsretry applies to the calls of the AWS service clients the retry
+// strategies selected by the flags of the controller and by the annotations
+// of the namespaces. The clients, and their retryers, are shared by all the
+// resources reconciled with the same resource manager, whatever their
+// namespace, so the retry strategy of the namespace of a resource is carried
+// by the context of the calls made to reconcile it.
+package awsretry
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// MiddlewareID is the ID of the middleware of the AWS SDK retrying the
+// failed attempts of the calls
+const MiddlewareID = "Retry"
+
+// Strategy is a retry strategy of the AWS SDK
+type Strategy struct {
+	// Mode is the retry mode, the standard mode if empty
+	Mode aws.RetryMode
+	// MaxAttempts is the maximum number of attempts of the calls, the
+	// default of the retry mode if zero
+	MaxAttempts int
+}
+
+// strategyContextKey is the context key of the retry strategy of the calls
+type strategyContextKey struct{}
+
+// WithStrategy returns a copy of the supplied context whose AWS service calls
+// are retried with the supplied strategy
+func WithStrategy(ctx context.Context, strategy Strategy) context.Context {
+	return context.WithValue(ctx, strategyContextKey{}, strategy)
+}
+
+// StrategyFromContext returns the retry strategy of the AWS service calls
+// made with the supplied context, if any
+func StrategyFromContext(ctx context.Context) (Strategy, bool) {
+	strategy, ok := ctx.Value(strategyContextKey{}).(Strategy)
+	return strategy, ok
+}
+
+// Retryers holds the retryers of the AWS service calls. The retryers are
+// shared by all the clients, so that the client side rate limiting of the
+// adaptive mode applies across reconciliations.
+type Retryers struct {
+	sync.Mutex
+	// operations are the retryers of the operations with their own maximum
+	// number of attempts, by operation name
+	operations map[string]aws.Retryer
+	// strategies are the retryers of the strategies of the contexts of the
+	// calls, constructed on first use
+	strategies map[Strategy]aws.Retryer
+}
+
+// New returns the Retryers of the calls of the clients whose retry mode is
+// the supplied mode, the operations with the supplied names being retried up
+// to their supplied maximum number of attempts
+func New(mode aws.RetryMode, operationMaxAttempts map[string]int) *Retryers {
+	operations := make(map[string]aws.Retryer, len(operationMaxAttempts))
+	for operation, maxAttempts := range operationMaxAttempts {
+		operations[operation] = newRetryer(Strategy{Mode: mode, MaxAttempts: maxAttempts})
+	}
+	return &Retryers{
+		operations: operations,
+		strategies: map[Strategy]aws.Retryer{},
+	}
+}
+
+// APIOption replaces the retry middleware of the supplied stack of an AWS SDK
+// operation call. The operations with their own maximum number of attempts
+// are retried with their retryer. The other operations are retried with the
+// retryer of the strategy of the context of the call, if any, and by the
+// retryer of their client otherwise. The ID of the stacks is the name of
+// their operation.
+func (r *Retryers) APIOption(stack *middleware.Stack) error {
+	original, ok := stack.Finalize.Get(MiddlewareID)
+	if !ok {
+		return nil
+	}
+	if retryer, ok := r.operations[stack.ID()]; ok {
+		_, err := stack.Finalize.Swap(
+			MiddlewareID, retry.NewAttemptMiddleware(retryer, smithyhttp.RequestCloner),
+		)
+		return err
+	}
+	_, err := stack.Finalize.Swap(MiddlewareID, &strategyMiddleware{retryers: r, client: original})
+	return err
+}
+
+// retryer returns the retryer of the supplied strategy
+func (r *Retryers) retryer(strategy Strategy) aws.Retryer {
+	r.Lock()
+	defer r.Unlock()
+	retryer, ok := r.strategies[strategy]
+	if !ok {
+		retryer = newRetryer(strategy)
+		r.strategies[strategy] = retryer
+	}
+	return retryer
+}
+
+// strategyMiddleware retries the calls with the retryer of the strategy of
+// their context, if any, and with the retry middleware of their client
+// otherwise
+type strategyMiddleware struct {
+	retryers *Retryers
+	client   middleware.FinalizeMiddleware
+}
+
+// ID returns the ID of the middleware
+func (m *strategyMiddleware) ID() string {
+	return MiddlewareID
+}
+
+// HandleFinalize retries the call
+func (m *strategyMiddleware) HandleFinalize(
+	ctx context.Context,
+	in middleware.FinalizeInput,
+	next middleware.FinalizeHandler,
+) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	strategy, ok := StrategyFromContext(ctx)
+	if !ok {
+		return m.client.HandleFinalize(ctx, in, next)
+	}
+	attempt := retry.NewAttemptMiddleware(m.retryers.retryer(strategy), smithyhttp.RequestCloner)
+	return attempt.HandleFinalize(ctx, in, next)
+}
+
+// newRetryer returns a retryer of the supplied strategy
+func newRetryer(strategy Strategy) aws.Retryer {
+	var retryer aws.Retryer = retry.NewStandard()
+	if strategy.Mode == aws.RetryModeAdaptive {
+		retryer = retry.NewAdaptiveMode()
+	}
+	if strategy.MaxAttempts > 0 {
+		retryer = retry.AddWithMaxAttempts(retryer, strategy.MaxAttempts)
+	}
+	return retryer
+}
@@ -0,0 +1,130 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package awsretry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/awsretry"
+)
+
+// errUnavailable is a retryable error of an AWS service API
+var errUnavailable = &smithyhttp.ResponseError{
+	Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}},
+	Err:      errors.New("service unavailable"),
+}
+
+// call calls the operation with the supplied name through a stack whose
+// retry middleware is replaced by the supplied retryers, the handler of the
+// operation failing with the supplied error. It returns the number of calls
+// of the retry middleware of the client and of the handler.
+func call(
+	t *testing.T,
+	ctx context.Context,
+	retryers *awsretry.Retryers,
+	operation string,
+	err error,
+) (clientRetries int, attempts int) {
+	stack := middleware.NewStack(operation, smithyhttp.NewStackRequest)
+	_ = stack.Finalize.Add(middleware.FinalizeMiddlewareFunc(
+		awsretry.MiddlewareID,
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+			middleware.FinalizeOutput, middleware.Metadata, error,
+		) {
+			clientRetries++
+			return next.HandleFinalize(ctx, in)
+		},
+	), middleware.After)
+	require.NoError(t, retryers.APIOption(stack))
+	handler := middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+		attempts++
+		return nil, middleware.Metadata{}, err
+	})
+	_, _, _ = middleware.DecorateHandler(handler, stack).Handle(ctx, struct{}{})
+	return clientRetries, attempts
+}
+
+func TestRetryers(t *testing.T) {
+	retryers := awsretry.New("", map[string]int{"CreateBucket": 1})
+	namespaced := awsretry.WithStrategy(context.TODO(), awsretry.Strategy{MaxAttempts: 2})
+
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		operation         string
+		err               error
+		wantClientRetries int
+		wantAttempts      int
+	}{{
+		name:              "without strategy",
+		ctx:               context.TODO(),
+		operation:         "GetBucket",
+		err:               errUnavailable,
+		wantClientRetries: 1,
+		wantAttempts:      1,
+	}, {
+		name:         "with strategy",
+		ctx:          namespaced,
+		operation:    "GetBucket",
+		err:          errUnavailable,
+		wantAttempts: 2,
+	}, {
+		name:         "with strategy and non retryable error",
+		ctx:          namespaced,
+		operation:    "GetBucket",
+		err:          errors.New("validation"),
+		wantAttempts: 1,
+	}, {
+		name:         "operation max attempts",
+		ctx:          namespaced,
+		operation:    "CreateBucket",
+		err:          errUnavailable,
+		wantAttempts: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientRetries, attempts := call(t, tt.ctx, retryers, tt.operation, tt.err)
+			require.Equal(t, tt.wantClientRetries, clientRetries)
+			require.Equal(t, tt.wantAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryers_WithoutRetryMiddleware(t *testing.T) {
+	require := require.New(t)
+
+	stack := middleware.NewStack("GetBucket", smithyhttp.NewStackRequest)
+	require.NoError(awsretry.New("", nil).APIOption(stack))
+	_, ok := stack.Finalize.Get(awsretry.MiddlewareID)
+	require.False(ok)
+}
+
+func TestStrategyFromContext(t *testing.T) {
+	require := require.New(t)
+
+	_, ok := awsretry.StrategyFromContext(context.TODO())
+	require.False(ok)
+
+	strategy := awsretry.Strategy{Mode: "adaptive", MaxAttempts: 5}
+	got, ok := awsretry.StrategyFromContext(awsretry.WithStrategy(context.TODO(), strategy))
+	require.True(ok)
+	require.Equal(strategy, got)
+}
@@ -0,0 +1,180 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtreferents "github.com/aws-controllers-k8s/runtime/pkg/runtime/referents"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// referencedDeleteRequeueAfter is the delay after which the deletion of
+	// a resource that is still referenced is attempted again
+	referencedDeleteRequeueAfter = 30 * time.Second
+	// referrerEnqueueTimeout is the time given to the controller of a
+	// referrer to accept its reconciliation
+	referrerEnqueueTimeout = 5 * time.Second
+)
+
+// errStillReferenced is the error of the deletions of the resources that are
+// still referenced by other resources
+var errStillReferenced = errors.New("resource is still referenced by other resources")
+
+// objectRef returns the reference of the supplied resource of the kind of the
+// reconciler in the back reference index
+func (r *resourceReconciler) objectRef(namespace, name string) ackrtcache.ObjectRef {
+	return ackrtcache.ObjectRef{
+		GroupKind:      r.rd.GroupVersionKind().GroupKind(),
+		NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
+	}
+}
+
+// recordBackReferences records in the back reference index the ACK resources
+// read while resolving the references of the supplied resource
+func (r *resourceReconciler) recordBackReferences(
+	res acktypes.AWSResource,
+	recorder *ackrtreferents.Recorder,
+) {
+	if r.cache.BackReferences == nil || recorder == nil {
+		return
+	}
+	referents := []ackrtcache.ObjectRef{}
+	for _, referent := range recorder.Referents(r.kc.Scheme()) {
+		obj := referent.Object
+		referents = append(referents, ackrtcache.ObjectRef{
+			GroupKind:      referent.GVK.GroupKind(),
+			NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()},
+		})
+	}
+	metaObj := res.MetaObject()
+	r.cache.BackReferences.Record(r.objectRef(metaObj.GetNamespace(), metaObj.GetName()), referents)
+}
+
+// forgetBackReferences removes the supplied deleted resource from the back
+// reference index, and reconciles the resources it referenced, so that the
+// deletion of the ones that are being deleted does not wait for their next
+// requeue
+func (r *resourceReconciler) forgetBackReferences(
+	ctx context.Context,
+	namespace string,
+	name string,
+) {
+	referents := r.cache.BackReferences.Forget(r.objectRef(namespace, name))
+	r.enqueueObjects(ctx, referents)
+}
+
+// referrers returns the resources referencing the supplied resource, as
+// recorded in the back reference index
+func (r *resourceReconciler) referrers(namespace, name string) []string {
+	refs := r.cache.BackReferences.Referrers(r.objectRef(namespace, name))
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.String())
+	}
+	return names
+}
+
+// blockReferencedDelete keeps the supplied resource from being deleted while
+// other resources reference it, reporting them in its ResourceSynced
+// condition. It returns nil if the resource can be deleted.
+func (r *resourceReconciler) blockReferencedDelete(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	metaObj := res.MetaObject()
+	referrers := r.referrers(metaObj.GetNamespace(), metaObj.GetName())
+	if len(referrers) == 0 {
+		return nil, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	rlog.Info("AWS resource will not be deleted - still referenced", "referrers", referrers)
+	reason := fmt.Sprintf(
+		"the AWS resource is not deleted while it is referenced by %s",
+		strings.Join(referrers, ", "),
+	)
+	r.recordEvent(res.RuntimeObject(), ackevents.ReasonDeleteBlocked, reason)
+	latest := res.DeepCopy()
+	ackcondition.SetSynced(latest, corev1.ConditionFalse, &ackcondition.NotSyncedMessage, &reason)
+	return latest, requeue.NeededAfter(errStillReferenced, referencedDeleteRequeueAfter)
+}
+
+// fanOutToReferrers reconciles the resources referencing the supplied
+// resource when its ResourceSynced condition changed, so that they pick up
+// the change without waiting for their next resync
+func (r *resourceReconciler) fanOutToReferrers(
+	ctx context.Context,
+	latest acktypes.AWSResource,
+	previousConditions []*ackv1alpha1.Condition,
+) {
+	if r.cache.BackReferences == nil || ackcompare.IsNil(latest) {
+		return
+	}
+	var previous, current *ackv1alpha1.Condition
+	for _, c := range previousConditions {
+		if c.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			previous = c
+		}
+	}
+	current = ackcondition.Synced(latest)
+	if current == nil || (previous != nil && previous.Status == current.Status) {
+		return
+	}
+	metaObj := latest.MetaObject()
+	refs := r.cache.BackReferences.Referrers(r.objectRef(metaObj.GetNamespace(), metaObj.GetName()))
+	r.enqueueObjects(ctx, refs)
+}
+
+// enqueueObjects reconciles the supplied resources of the kinds reconciled by
+// the service controller, in the background
+func (r *resourceReconciler) enqueueObjects(ctx context.Context, refs []ackrtcache.ObjectRef) {
+	if len(refs) == 0 || r.sc == nil {
+		return
+	}
+	reconcilers := map[string]*resourceReconciler{}
+	for _, rec := range r.sc.GetReconcilers() {
+		if rr, ok := rec.(*resourceReconciler); ok {
+			reconcilers[rr.rd.GroupVersionKind().GroupKind().String()] = rr
+		}
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	for _, ref := range refs {
+		rr, ok := reconcilers[ref.GroupKind.String()]
+		if !ok {
+			continue
+		}
+		go func(rr *resourceReconciler, ref ackrtcache.ObjectRef) {
+			enqueueCtx, cancel := context.WithTimeout(context.Background(), referrerEnqueueTimeout)
+			defer cancel()
+			if !rr.enqueue(enqueueCtx, ref.Namespace, ref.Name) {
+				rlog.Debug("unable to enqueue referencing resource", "resource", ref.String())
+			}
+		}(rr, ref)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// backoffAttempts counts, for each resource, the consecutive reconciliations
+// that returned a RequeueNeededWithBackoff error. A nil backoffAttempts
+// counts nothing.
+type backoffAttempts struct {
+	sync.Mutex
+	counts map[types.NamespacedName]int
+}
+
+// newBackoffAttempts returns an empty backoffAttempts
+func newBackoffAttempts() *backoffAttempts {
+	return &backoffAttempts{counts: map[types.NamespacedName]int{}}
+}
+
+// next counts a new attempt of the supplied resource and returns its number,
+// starting at 1
+func (a *backoffAttempts) next(key types.NamespacedName) int {
+	if a == nil {
+		return 1
+	}
+	a.Lock()
+	defer a.Unlock()
+	a.counts[key]++
+	return a.counts[key]
+}
+
+// reset forgets the attempts of the supplied resource
+func (a *backoffAttempts) reset(key types.NamespacedName) {
+	if a == nil {
+		return
+	}
+	a.Lock()
+	defer a.Unlock()
+	delete(a.counts, key)
+}
+
+// backoffDelay returns the delay after which the supplied resource is
+// requeued if the supplied reconciliation error is a RequeueNeededWithBackoff
+// error. The attempts of the resource are reset on any other result.
+func (r *resourceReconciler) backoffDelay(
+	res acktypes.AWSResource,
+	err error,
+) (time.Duration, bool) {
+	if ackcompare.IsNil(res) {
+		return 0, false
+	}
+	var requeueNeededWithBackoff *requeue.RequeueNeededWithBackoff
+	if !errors.As(err, &requeueNeededWithBackoff) {
+		r.resetBackoff(res)
+		return 0, false
+	}
+	attempt := r.backoff.next(backoffKey(res))
+	return requeueNeededWithBackoff.Backoff().Delay(attempt), true
+}
+
+// resetBackoff forgets the attempts of the supplied resource
+func (r *resourceReconciler) resetBackoff(res acktypes.AWSResource) {
+	if ackcompare.IsNil(res) {
+		return
+	}
+	r.backoff.reset(backoffKey(res))
+}
+
+// backoffKey returns the key of the attempts of the supplied resource
+func backoffKey(res acktypes.AWSResource) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: res.MetaObject().GetNamespace(),
+		Name:      res.MetaObject().GetName(),
+	}
+}
@@ -55,6 +55,9 @@ type CARMMap struct {
 	data             map[string]string
 	configMapCreated bool
 	hasSynced        func() bool
+	// fallback returns the role ARN of the keys missing from the CARM
+	// configmap, bound by the AccountConfigs
+	fallback func(key string) (string, bool)
 }
 
 // NewCARMMapCache instanciate a new CARMMap.
@@ -124,8 +127,24 @@ func (c *CARMMap) Run(name string, clientSet kubernetes.Interface, stopCh <-chan
 // configmap is not found, the key is not found or the value
 // is empty.
 //
+// The keys missing from the configmap fall back to the role ARNs bound by
+// the AccountConfigs.
+//
 // This function is thread safe.
 func (c *CARMMap) GetValue(key string) (string, error) {
+	roleARN, err := c.getValue(key)
+	if err == nil || err == ErrEmptyValue || c.fallback == nil {
+		return roleARN, err
+	}
+	if roleARN, ok := c.fallback(key); ok {
+		return roleARN, nil
+	}
+	return "", err
+}
+
+// getValue queries the value from the cached CARM configmap. This function
+// is thread safe.
+func (c *CARMMap) getValue(key string) (string, error) {
 	c.RLock()
 	defer c.RUnlock()
 
@@ -142,6 +161,21 @@ func (c *CARMMap) GetValue(key string) (string, error) {
 	return roleARN, nil
 }
 
+// snapshot returns a copy of the cached CARM map. This function is thread
+// safe.
+func (c *CARMMap) snapshot() map[string]string {
+	if c == nil {
+		return nil
+	}
+	c.RLock()
+	defer c.RUnlock()
+	data := make(map[string]string, len(c.data))
+	for key, value := range c.data {
+		data[key] = value
+	}
+	return data
+}
+
 // updateData updates the CARM map. This function is thread safe.
 func (c *CARMMap) updateData(exist bool, data map[string]string) {
 	c.Lock()
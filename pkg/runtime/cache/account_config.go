@@ -0,0 +1,261 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	k8scache "k8s.io/client-go/tools/cache"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// AccountConfigCache is responsible for caching the AccountConfigs, the
+// cluster-scoped bindings of namespaces and teams to AWS accounts. It is
+// listening to all the events related to the AccountConfigs, so that their
+// changes are taken into account by the next reconciliations.
+//
+// When several AccountConfigs bind the same namespace, team or account, the
+// first one by name wins.
+type AccountConfigCache struct {
+	sync.RWMutex
+	log logr.Logger
+	// specs maps the names of the AccountConfigs to their specs
+	specs map[string]ackv1alpha1.AccountConfigSpec
+	// hasSynced is a function that will return true if the AccountConfig
+	// informer has received "at least" once the full list of the
+	// AccountConfigs.
+	hasSynced func() bool
+}
+
+// NewAccountConfigCache instanciate a new AccountConfigCache.
+func NewAccountConfigCache(log logr.Logger) *AccountConfigCache {
+	return &AccountConfigCache{
+		log:   log.WithName("cache.accountconfig"),
+		specs: make(map[string]ackv1alpha1.AccountConfigSpec),
+	}
+}
+
+// Run instantiate a new shared informer for AccountConfigs and runs it to
+// begin processing items.
+func (c *AccountConfigCache) Run(client dynamic.Interface, stopCh <-chan struct{}) {
+	c.log.V(1).Info("Starting shared informer for account configs cache")
+	informer := dynamicinformer.NewFilteredDynamicInformer(
+		client,
+		ackv1alpha1.GroupVersion.WithResource("accountconfigs"),
+		"",
+		informerResyncPeriod,
+		k8scache.Indexers{},
+		nil,
+	).Informer()
+	informer.AddEventHandler(k8scache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if ac, ok := toAccountConfig(obj); ok {
+				c.setSpec(ac.Name, ac.Spec)
+				c.log.V(1).Info("created account config", "name", ac.Name)
+			}
+		},
+		UpdateFunc: func(orig, desired interface{}) {
+			if ac, ok := toAccountConfig(desired); ok {
+				c.setSpec(ac.Name, ac.Spec)
+				c.log.V(1).Info("updated account config", "name", ac.Name)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(k8scache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if ac, ok := toAccountConfig(obj); ok {
+				c.deleteSpec(ac.Name)
+				c.log.V(1).Info("deleted account config", "name", ac.Name)
+			}
+		},
+	})
+	go informer.Run(stopCh)
+	c.hasSynced = informer.HasSynced
+}
+
+// Running returns true if the AccountConfigs cache is run
+func (c *AccountConfigCache) Running() bool {
+	return c != nil && c.hasSynced != nil
+}
+
+// toAccountConfig converts the supplied unstructured object received by the
+// informer to an AccountConfig
+func toAccountConfig(obj interface{}) (*ackv1alpha1.AccountConfig, bool) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, false
+	}
+	ac := &ackv1alpha1.AccountConfig{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ac); err != nil {
+		return nil, false
+	}
+	return ac, true
+}
+
+// GetOwnerAccountID returns the AWS account the supplied namespace is bound
+// to, if it is bound to one
+func (c *AccountConfigCache) GetOwnerAccountID(namespace string) (string, bool) {
+	spec, ok := c.forNamespace(namespace)
+	if !ok {
+		return "", false
+	}
+	return string(spec.AccountID), true
+}
+
+// GetDefaultRegion returns the default region of the supplied namespace, if
+// the AccountConfig it is bound to sets one
+func (c *AccountConfigCache) GetDefaultRegion(namespace string) (string, bool) {
+	spec, ok := c.forNamespace(namespace)
+	if !ok || spec.Region == nil || *spec.Region == "" {
+		return "", false
+	}
+	return string(*spec.Region), true
+}
+
+// GetEndpointURL returns the endpoint URL of the supplied namespace, if the
+// AccountConfig it is bound to sets one
+func (c *AccountConfigCache) GetEndpointURL(namespace string) (string, bool) {
+	spec, ok := c.forNamespace(namespace)
+	if !ok || spec.EndpointURL == nil || *spec.EndpointURL == "" {
+		return "", false
+	}
+	return *spec.EndpointURL, true
+}
+
+// GetAccountRoleARN returns the IAM role the resources of the supplied AWS
+// account are managed with, if an AccountConfig binds it
+func (c *AccountConfigCache) GetAccountRoleARN(accountID string) (string, bool) {
+	return c.roleARN(func(spec ackv1alpha1.AccountConfigSpec) bool {
+		return string(spec.AccountID) == accountID
+	})
+}
+
+// GetTeamRoleARN returns the IAM role the resources of the supplied team are
+// managed with, if an AccountConfig binds it
+func (c *AccountConfigCache) GetTeamRoleARN(teamID string) (string, bool) {
+	return c.roleARN(func(spec ackv1alpha1.AccountConfigSpec) bool {
+		return contains(spec.TeamIDs, teamID)
+	})
+}
+
+// forNamespace returns the spec of the AccountConfig binding the supplied
+// namespace. This function is thread safe.
+func (c *AccountConfigCache) forNamespace(namespace string) (ackv1alpha1.AccountConfigSpec, bool) {
+	return c.find(func(spec ackv1alpha1.AccountConfigSpec) bool {
+		return contains(spec.Namespaces, namespace)
+	})
+}
+
+// roleARN returns the non-empty IAM role of the spec of the first
+// AccountConfig matching the supplied function. This function is thread
+// safe.
+func (c *AccountConfigCache) roleARN(match func(ackv1alpha1.AccountConfigSpec) bool) (string, bool) {
+	spec, ok := c.find(match)
+	if !ok || spec.RoleARN == "" {
+		return "", false
+	}
+	return string(spec.RoleARN), true
+}
+
+// find returns the spec of the first AccountConfig, by name, matching the
+// supplied function. This function is thread safe.
+func (c *AccountConfigCache) find(match func(ackv1alpha1.AccountConfigSpec) bool) (ackv1alpha1.AccountConfigSpec, bool) {
+	if c == nil || match == nil {
+		return ackv1alpha1.AccountConfigSpec{}, false
+	}
+	c.RLock()
+	defer c.RUnlock()
+	names := make([]string, 0, len(c.specs))
+	for name := range c.specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if match(c.specs[name]) {
+			return c.specs[name], true
+		}
+	}
+	return ackv1alpha1.AccountConfigSpec{}, false
+}
+
+// AccountConfigSnapshot is a copy of the cached spec of an AccountConfig
+type AccountConfigSnapshot struct {
+	AccountID   string   `json:"accountID"`
+	RoleARN     string   `json:"roleARN"`
+	Namespaces  []string `json:"namespaces,omitempty"`
+	TeamIDs     []string `json:"teamIDs,omitempty"`
+	Region      string   `json:"region,omitempty"`
+	EndpointURL string   `json:"endpointURL,omitempty"`
+}
+
+// snapshot returns a copy of the cached AccountConfigs. This function is
+// thread safe.
+func (c *AccountConfigCache) snapshot() map[string]AccountConfigSnapshot {
+	if c == nil {
+		return nil
+	}
+	c.RLock()
+	defer c.RUnlock()
+	configs := make(map[string]AccountConfigSnapshot, len(c.specs))
+	for name, spec := range c.specs {
+		s := AccountConfigSnapshot{
+			AccountID:  string(spec.AccountID),
+			RoleARN:    string(spec.RoleARN),
+			Namespaces: append([]string{}, spec.Namespaces...),
+			TeamIDs:    append([]string{}, spec.TeamIDs...),
+		}
+		if spec.Region != nil {
+			s.Region = string(*spec.Region)
+		}
+		if spec.EndpointURL != nil {
+			s.EndpointURL = *spec.EndpointURL
+		}
+		configs[name] = s
+	}
+	return configs
+}
+
+// setSpec caches the spec of the supplied AccountConfig. This function is
+// thread safe.
+func (c *AccountConfigCache) setSpec(name string, spec ackv1alpha1.AccountConfigSpec) {
+	c.Lock()
+	defer c.Unlock()
+	c.specs[name] = *spec.DeepCopy()
+}
+
+// deleteSpec removes the spec of the supplied AccountConfig from the cache.
+// This function is thread safe.
+func (c *AccountConfigCache) deleteSpec(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.specs, name)
+}
+
+// contains returns true if the supplied values contain the supplied value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
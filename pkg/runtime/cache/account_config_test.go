@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+// newAccountConfig returns the unstructured AccountConfig with the supplied
+// name and spec
+func newAccountConfig(t *testing.T, name string, spec ackv1alpha1.AccountConfigSpec) *unstructured.Unstructured {
+	ac := &ackv1alpha1.AccountConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ackv1alpha1.GroupVersion.String(), Kind: "AccountConfig"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ac)
+	require.Nil(t, err)
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func TestAccountConfigCache(t *testing.T) {
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	fakeLogger := ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions))
+
+	region := ackv1alpha1.AWSRegion("eu-west-1")
+	endpointURL := "https://s3.example.com"
+	gvr := ackv1alpha1.GroupVersion.WithResource("accountconfigs")
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "AccountConfigList"},
+		newAccountConfig(t, "payments", ackv1alpha1.AccountConfigSpec{
+			AccountID:   testAccount1,
+			RoleARN:     testAccountARN1,
+			Namespaces:  []string{"payments"},
+			TeamIDs:     []string{"team-payments"},
+			Region:      &region,
+			EndpointURL: &endpointURL,
+		}),
+	)
+	k8sClient := k8sfake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "annotated",
+			Annotations: map[string]string{
+				ackv1alpha1.AnnotationOwnerAccountID: testAccount2,
+			},
+		},
+	})
+
+	caches := ackrtcache.New(fakeLogger, ackrtcache.Config{}, featuregate.FeatureGates{
+		featuregate.TeamLevelCARM: {Enabled: true},
+	})
+	caches.Run(k8sClient)
+	caches.RunAccountConfigs(dynamicClient)
+	require.True(t, caches.WaitForCachesToSync(context.Background()))
+	require.True(t, caches.AccountConfigs.Running())
+
+	// The bound namespace falls back to the AccountConfig
+	accountID, ok := caches.Namespaces.GetOwnerAccountID("payments")
+	require.True(t, ok)
+	require.Equal(t, testAccount1, accountID)
+	defaultRegion, ok := caches.Namespaces.GetDefaultRegion("payments")
+	require.True(t, ok)
+	require.Equal(t, "eu-west-1", defaultRegion)
+	_, source, ok := caches.Namespaces.GetDefaultRegionWithSource("payments")
+	require.True(t, ok)
+	require.Equal(t, ackv1alpha1.AWSRegionSourceAccountConfig, source)
+	url, ok := caches.Namespaces.GetEndpointURL("payments")
+	require.True(t, ok)
+	require.Equal(t, endpointURL, url)
+
+	// The annotations of the namespaces take precedence
+	accountID, ok = caches.Namespaces.GetOwnerAccountID("annotated")
+	require.True(t, ok)
+	require.Equal(t, testAccount2, accountID)
+
+	// Unbound namespaces have no owner account
+	_, ok = caches.Namespaces.GetOwnerAccountID("unbound")
+	require.False(t, ok)
+
+	// The CARM maps fall back to the role of the AccountConfig
+	roleARN, err := caches.Accounts.GetValue(testAccount1)
+	require.Nil(t, err)
+	require.Equal(t, testAccountARN1, roleARN)
+	roleARN, err = caches.Teams.GetValue("team-payments")
+	require.Nil(t, err)
+	require.Equal(t, testAccountARN1, roleARN)
+	_, err = caches.Accounts.GetValue(testAccount2)
+	require.Equal(t, ackrtcache.ErrCARMConfigMapNotFound, err)
+
+	// Changes of the AccountConfigs are reloaded
+	updated := newAccountConfig(t, "payments", ackv1alpha1.AccountConfigSpec{
+		AccountID:  testAccount3,
+		RoleARN:    "arn:aws:iam::321987654321:role/Payments",
+		Namespaces: []string{"payments"},
+	})
+	_, err = dynamicClient.Resource(gvr).Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.Nil(t, err)
+	time.Sleep(time.Second)
+
+	accountID, ok = caches.Namespaces.GetOwnerAccountID("payments")
+	require.True(t, ok)
+	require.Equal(t, testAccount3, accountID)
+	_, ok = caches.Namespaces.GetDefaultRegion("payments")
+	require.False(t, ok)
+	_, err = caches.Teams.GetValue("team-payments")
+	require.Equal(t, ackrtcache.ErrCARMConfigMapNotFound, err)
+
+	// Deleted AccountConfigs no longer bind their namespaces
+	err = dynamicClient.Resource(gvr).Delete(context.Background(), "payments", metav1.DeleteOptions{})
+	require.Nil(t, err)
+	time.Sleep(time.Second)
+
+	_, ok = caches.Namespaces.GetOwnerAccountID("payments")
+	require.False(t, ok)
+	require.Empty(t, caches.Snapshot().AccountConfigs)
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"sync"
+)
+
+// AssumeRoleFailureThreshold is the number of consecutive failures to assume
+// the IAM role of a cross account resource management binding after which
+// the binding is considered broken.
+const AssumeRoleFailureThreshold = 3
+
+// assumeRoleKey identifies the IAM role assumed for the resources of a
+// namespace
+type assumeRoleKey struct {
+	namespace string
+	roleARN   string
+}
+
+// AssumeRoleFailures counts the consecutive failures to assume the IAM roles
+// of cross account resource management bindings, per namespace and role.
+// It is shared by the reconcilers of all the resource kinds, so that a broken
+// binding is reported once for the namespace instead of once per resource.
+type AssumeRoleFailures struct {
+	sync.Mutex
+	failures map[assumeRoleKey]int
+}
+
+// NewAssumeRoleFailures returns an empty AssumeRoleFailures
+func NewAssumeRoleFailures() *AssumeRoleFailures {
+	return &AssumeRoleFailures{
+		failures: map[assumeRoleKey]int{},
+	}
+}
+
+// RecordFailure records a failure to assume the supplied role for the
+// resources of the supplied namespace, and returns the number of consecutive
+// failures.
+func (f *AssumeRoleFailures) RecordFailure(namespace, roleARN string) int {
+	if f == nil {
+		return 0
+	}
+	f.Lock()
+	defer f.Unlock()
+	key := assumeRoleKey{namespace, roleARN}
+	f.failures[key]++
+	return f.failures[key]
+}
+
+// RecordSuccess records a successful use of the supplied role for the
+// resources of the supplied namespace, and returns the number of consecutive
+// failures that preceded it.
+func (f *AssumeRoleFailures) RecordSuccess(namespace, roleARN string) int {
+	if f == nil {
+		return 0
+	}
+	f.Lock()
+	defer f.Unlock()
+	key := assumeRoleKey{namespace, roleARN}
+	failures := f.failures[key]
+	delete(f.failures, key)
+	return failures
+}
@@ -0,0 +1,39 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+func TestAssumeRoleFailures(t *testing.T) {
+	require := require.New(t)
+
+	role := "arn:aws:iam::123456789012:role/ack"
+	failures := ackrtcache.NewAssumeRoleFailures()
+	require.Equal(1, failures.RecordFailure("ns-a", role))
+	require.Equal(2, failures.RecordFailure("ns-a", role))
+	require.Equal(1, failures.RecordFailure("ns-b", role))
+	require.Equal(2, failures.RecordSuccess("ns-a", role))
+	require.Equal(0, failures.RecordSuccess("ns-a", role))
+	require.Equal(1, failures.RecordFailure("ns-a", role))
+
+	var nilFailures *ackrtcache.AssumeRoleFailures
+	require.Equal(0, nilFailures.RecordFailure("ns-a", role))
+	require.Equal(0, nilFailures.RecordSuccess("ns-a", role))
+}
@@ -0,0 +1,149 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ObjectRef identifies a Kubernetes object in the BackReferenceIndex
+type ObjectRef struct {
+	schema.GroupKind
+	types.NamespacedName
+}
+
+// String returns the kind, group, namespace and name of the object, e.g.
+// "SecurityGroup.ec2.services.k8s.aws/default/web"
+func (o ObjectRef) String() string {
+	return o.GroupKind.String() + "/" + o.NamespacedName.String()
+}
+
+// BackReferenceIndex is the reverse index of the references between
+// resources: it answers which resources reference a given resource.
+//
+// The index is built from the references resolved by the reconcilers of the
+// controller, so it only knows about the resources reconciled since the
+// controller started, and about the references of the kinds it reconciles.
+// A nil BackReferenceIndex indexes nothing.
+type BackReferenceIndex struct {
+	sync.RWMutex
+	// referents holds the objects referenced by each referrer
+	referents map[ObjectRef]map[ObjectRef]struct{}
+	// referrers holds the referrers of each referenced object
+	referrers map[ObjectRef]map[ObjectRef]struct{}
+}
+
+// NewBackReferenceIndex returns an empty BackReferenceIndex
+func NewBackReferenceIndex() *BackReferenceIndex {
+	return &BackReferenceIndex{
+		referents: map[ObjectRef]map[ObjectRef]struct{}{},
+		referrers: map[ObjectRef]map[ObjectRef]struct{}{},
+	}
+}
+
+// Record records that the supplied referrer references exactly the supplied
+// referents, replacing the referents previously recorded for it
+func (i *BackReferenceIndex) Record(referrer ObjectRef, referents []ObjectRef) {
+	if i == nil {
+		return
+	}
+	i.Lock()
+	defer i.Unlock()
+	i.forget(referrer)
+	if len(referents) == 0 {
+		return
+	}
+	set := make(map[ObjectRef]struct{}, len(referents))
+	for _, referent := range referents {
+		if referent == referrer {
+			continue
+		}
+		set[referent] = struct{}{}
+		if i.referrers[referent] == nil {
+			i.referrers[referent] = map[ObjectRef]struct{}{}
+		}
+		i.referrers[referent][referrer] = struct{}{}
+	}
+	i.referents[referrer] = set
+}
+
+// Forget removes the supplied referrer, e.g. once it is deleted, from the
+// index and returns the objects it referenced
+func (i *BackReferenceIndex) Forget(referrer ObjectRef) []ObjectRef {
+	if i == nil {
+		return nil
+	}
+	i.Lock()
+	defer i.Unlock()
+	return i.forget(referrer)
+}
+
+func (i *BackReferenceIndex) forget(referrer ObjectRef) []ObjectRef {
+	referents := sortedRefs(i.referents[referrer])
+	for _, referent := range referents {
+		delete(i.referrers[referent], referrer)
+		if len(i.referrers[referent]) == 0 {
+			delete(i.referrers, referent)
+		}
+	}
+	delete(i.referents, referrer)
+	return referents
+}
+
+// Referrers returns the objects referencing the supplied one, sorted
+func (i *BackReferenceIndex) Referrers(referent ObjectRef) []ObjectRef {
+	if i == nil {
+		return nil
+	}
+	i.RLock()
+	defer i.RUnlock()
+	return sortedRefs(i.referrers[referent])
+}
+
+// Snapshot returns the referrers of every referenced object, keyed by the
+// string representation of the referenced object
+func (i *BackReferenceIndex) Snapshot() map[string][]string {
+	snapshot := map[string][]string{}
+	if i == nil {
+		return snapshot
+	}
+	i.RLock()
+	defer i.RUnlock()
+	for referent, referrers := range i.referrers {
+		refs := sortedRefs(referrers)
+		names := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			names = append(names, ref.String())
+		}
+		snapshot[referent.String()] = names
+	}
+	return snapshot
+}
+
+// sortedRefs returns the objects of the supplied set, sorted by their string
+// representation
+func sortedRefs(set map[ObjectRef]struct{}) []ObjectRef {
+	refs := make([]ObjectRef, 0, len(set))
+	for ref := range set {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(a, b int) bool {
+		return refs[a].String() < refs[b].String()
+	})
+	return refs
+}
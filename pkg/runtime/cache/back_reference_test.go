@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+func objectRef(kind, name string) ackrtcache.ObjectRef {
+	return ackrtcache.ObjectRef{
+		GroupKind:      schema.GroupKind{Group: "ec2.services.k8s.aws", Kind: kind},
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+	}
+}
+
+func TestBackReferenceIndex(t *testing.T) {
+	require := require.New(t)
+
+	sg := objectRef("SecurityGroup", "web")
+	vpc := objectRef("VPC", "main")
+	instanceA := objectRef("Instance", "a")
+	instanceB := objectRef("Instance", "b")
+
+	index := ackrtcache.NewBackReferenceIndex()
+	index.Record(instanceA, []ackrtcache.ObjectRef{sg, vpc})
+	index.Record(instanceB, []ackrtcache.ObjectRef{sg})
+	// A resource never references itself
+	index.Record(sg, []ackrtcache.ObjectRef{sg, vpc})
+
+	require.Equal([]ackrtcache.ObjectRef{instanceA, instanceB}, index.Referrers(sg))
+	require.Equal([]ackrtcache.ObjectRef{instanceA, sg}, index.Referrers(vpc))
+	require.Empty(index.Referrers(instanceA))
+	require.Equal(map[string][]string{
+		"SecurityGroup.ec2.services.k8s.aws/default/web": {
+			"Instance.ec2.services.k8s.aws/default/a",
+			"Instance.ec2.services.k8s.aws/default/b",
+		},
+		"VPC.ec2.services.k8s.aws/default/main": {
+			"Instance.ec2.services.k8s.aws/default/a",
+			"SecurityGroup.ec2.services.k8s.aws/default/web",
+		},
+	}, index.Snapshot())
+
+	// Recording the referents again replaces the previous ones
+	index.Record(instanceA, []ackrtcache.ObjectRef{vpc})
+	require.Equal([]ackrtcache.ObjectRef{instanceB}, index.Referrers(sg))
+
+	require.Equal([]ackrtcache.ObjectRef{sg}, index.Forget(instanceB))
+	require.Empty(index.Referrers(sg))
+	require.Empty(index.Forget(instanceB))
+
+	// A nil index indexes nothing
+	var nilIndex *ackrtcache.BackReferenceIndex
+	nilIndex.Record(instanceA, []ackrtcache.ObjectRef{sg})
+	require.Nil(nilIndex.Referrers(sg))
+	require.Empty(nilIndex.Snapshot())
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/jaypipes/envutil"
+	"k8s.io/client-go/dynamic"
 	kubernetes "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
@@ -53,6 +54,12 @@ const (
 // configuration (ConfigMaps, etc)
 var ackSystemNamespace string
 
+// SystemNamespace returns the namespace in which ACK system configuration and
+// status ConfigMaps are stored
+func SystemNamespace() string {
+	return ackSystemNamespace
+}
+
 func init() {
 	ackSystemNamespace = envutil.WithDefault(
 		envVarACKSystemNamespace, envutil.WithDefault(
@@ -84,18 +91,48 @@ type Caches struct {
 
 	// Namespaces cache
 	Namespaces *NamespaceCache
+
+	// References cache. Only set when the ReferenceCaching feature gate is
+	// enabled.
+	References *ReferenceCache
+
+	// BackReferences indexes the referrers of the referenced resources. Only
+	// set when the ReferenceIndex feature gate is enabled.
+	BackReferences *BackReferenceIndex
+
+	// AssumeRoleFailures tracks the consecutive failures to assume the IAM
+	// roles of cross account resource management bindings
+	AssumeRoleFailures *AssumeRoleFailures
+
+	// AccountConfigs cache. The Accounts, Teams and Namespaces caches fall
+	// back to it. Only run when the AccountConfig CRD is installed.
+	AccountConfigs *AccountConfigCache
 }
 
 // New instantiate a new Caches object.
 func New(log logr.Logger, config Config, features featuregate.FeatureGates) Caches {
+	accountConfigs := NewAccountConfigCache(log)
+	accounts := NewCARMMapCache(log)
+	accounts.fallback = accountConfigs.GetAccountRoleARN
 	var teams *CARMMap
 	if features.IsEnabled(featuregate.TeamLevelCARM) {
 		teams = NewCARMMapCache(log)
+		teams.fallback = accountConfigs.GetTeamRoleARN
+	}
+	namespaces := NewNamespaceCache(log, config.WatchScope, config.Ignored)
+	namespaces.accountConfigs = accountConfigs
+	var backReferences *BackReferenceIndex
+	if features.IsEnabled(featuregate.ReferenceIndex) {
+		backReferences = NewBackReferenceIndex()
 	}
 	return Caches{
-		Accounts:   NewCARMMapCache(log),
+		Accounts:   accounts,
 		Teams:      teams,
-		Namespaces: NewNamespaceCache(log, config.WatchScope, config.Ignored),
+		Namespaces: namespaces,
+
+		AssumeRoleFailures: NewAssumeRoleFailures(),
+		BackReferences:     backReferences,
+		AccountConfigs:     accountConfigs,
 	}
 }
 
@@ -113,11 +150,19 @@ func (c Caches) Run(clientSet kubernetes.Interface) {
 	}
 }
 
+// RunAccountConfigs runs the AccountConfigs cache, watching the
+// AccountConfigs with the supplied client
+func (c Caches) RunAccountConfigs(client dynamic.Interface) {
+	if c.AccountConfigs != nil {
+		c.AccountConfigs.Run(client, make(chan struct{}))
+	}
+}
+
 // WaitForCachesToSync waits for both of the namespace and configMap
 // informers to sync - by checking their hasSynced functions.
 func (c Caches) WaitForCachesToSync(ctx context.Context) bool {
 	// if the cache is not initialized, sync status should be true
-	namespaceSynced, accountSynced, carmSynced := true, true, true
+	namespaceSynced, accountSynced, carmSynced, accountConfigSynced := true, true, true, true
 	// otherwise check their hasSynced functions
 	if c.Namespaces != nil {
 		namespaceSynced = cache.WaitForCacheSync(ctx.Done(), c.Namespaces.hasSynced)
@@ -128,7 +173,37 @@ func (c Caches) WaitForCachesToSync(ctx context.Context) bool {
 	if c.Teams != nil {
 		carmSynced = cache.WaitForCacheSync(ctx.Done(), c.Teams.hasSynced)
 	}
-	return namespaceSynced && accountSynced && carmSynced
+	if c.AccountConfigs != nil && c.AccountConfigs.hasSynced != nil {
+		accountConfigSynced = cache.WaitForCacheSync(ctx.Done(), c.AccountConfigs.hasSynced)
+	}
+	return namespaceSynced && accountSynced && carmSynced && accountConfigSynced
+}
+
+// Snapshot is a point in time copy of the contents of the caches, used for
+// introspection
+type Snapshot struct {
+	// Accounts maps AWS account IDs to the IAM roles of their bindings
+	Accounts map[string]string `json:"accounts,omitempty"`
+	// Teams maps team IDs to the IAM roles of their bindings
+	Teams map[string]string `json:"teams,omitempty"`
+	// Namespaces maps namespace names to their cached annotations
+	Namespaces map[string]NamespaceSnapshot `json:"namespaces,omitempty"`
+	// BackReferences maps the referenced resources to their referrers
+	BackReferences map[string][]string `json:"backReferences,omitempty"`
+	// AccountConfigs maps the names of the AccountConfigs to their specs
+	AccountConfigs map[string]AccountConfigSnapshot `json:"accountConfigs,omitempty"`
+}
+
+// Snapshot returns a copy of the contents of the caches
+func (c Caches) Snapshot() Snapshot {
+	return Snapshot{
+		Accounts:   c.Accounts.snapshot(),
+		Teams:      c.Teams.snapshot(),
+		Namespaces: c.Namespaces.snapshot(),
+
+		BackReferences: c.BackReferences.Snapshot(),
+		AccountConfigs: c.AccountConfigs.snapshot(),
+	}
 }
 
 // Stop closes the stop channel and cause all the SharedInformers
@@ -36,6 +36,18 @@ type namespaceInfo struct {
 	teamID string
 	// services.k8s.aws/endpoint-url Annotation
 	endpointURL string
+	// services.k8s.aws/reconcile-interval Annotation
+	reconcileInterval string
+	// services.k8s.aws/aws-retry-mode Annotation
+	awsRetryMode string
+	// services.k8s.aws/aws-retry-max-attempts Annotation
+	awsRetryMaxAttempts string
+	// services.k8s.aws/mutators Annotation
+	mutators string
+	// services.k8s.aws/paused Annotation
+	paused string
+	// services.k8s.aws/maintenance-window Annotation
+	maintenanceWindow string
 	// {service}.services.k8s.aws/deletion-policy Annotations (keyed by service)
 	deletionPolicies map[string]string
 }
@@ -72,6 +84,57 @@ func (n *namespaceInfo) getEndpointURL() string {
 	return n.endpointURL
 }
 
+// getReconcileInterval returns the namespace reconcile interval
+func (n *namespaceInfo) getReconcileInterval() string {
+	if n == nil {
+		return ""
+	}
+	return n.reconcileInterval
+}
+
+// getAWSRetryMode returns the namespace AWS SDK retry mode
+func (n *namespaceInfo) getAWSRetryMode() string {
+	if n == nil {
+		return ""
+	}
+	return n.awsRetryMode
+}
+
+// getAWSRetryMaxAttempts returns the namespace AWS SDK maximum number of
+// attempts
+func (n *namespaceInfo) getAWSRetryMaxAttempts() string {
+	if n == nil {
+		return ""
+	}
+	return n.awsRetryMaxAttempts
+}
+
+// getMutators returns the namespace desired state mutators
+func (n *namespaceInfo) getMutators() string {
+	if n == nil {
+		return ""
+	}
+	return n.mutators
+}
+
+// getPaused returns whether the reconciliations of the namespace resources
+// are paused
+func (n *namespaceInfo) getPaused() string {
+	if n == nil {
+		return ""
+	}
+	return n.paused
+}
+
+// getMaintenanceWindow returns the maintenance window of the namespace
+// resources
+func (n *namespaceInfo) getMaintenanceWindow() string {
+	if n == nil {
+		return ""
+	}
+	return n.maintenanceWindow
+}
+
 // getDeletionPolicy returns the namespace deletion policy for a given service
 func (n *namespaceInfo) getDeletionPolicy(service string) string {
 	if n == nil {
@@ -97,6 +160,10 @@ type NamespaceCache struct {
 	// hasSynced is a function that will return true if namespace informer
 	// has received "at least" once the full list of the namespaces.
 	hasSynced func() bool
+	// accountConfigs holds the AccountConfigs the owner account IDs, default
+	// regions and endpoint URLs of the namespaces fall back to, when they are
+	// not annotated with them
+	accountConfigs *AccountConfigCache
 }
 
 // NewNamespaceCache instanciate a new NamespaceCache.
@@ -178,22 +245,37 @@ func (c *NamespaceCache) Run(clientSet kubernetes.Interface, stopCh <-chan struc
 
 // GetDefaultRegion returns the default region if it it exists
 func (c *NamespaceCache) GetDefaultRegion(namespace string) (string, bool) {
+	region, _, ok := c.GetDefaultRegionWithSource(namespace)
+	return region, ok
+}
+
+// GetDefaultRegionWithSource returns the default region if it exists, along
+// with where it was set: the default-region annotation of the namespace, or
+// the AccountConfig binding the namespace
+func (c *NamespaceCache) GetDefaultRegionWithSource(
+	namespace string,
+) (string, ackv1alpha1.AWSRegionSource, bool) {
 	info, ok := c.getNamespaceInfo(namespace)
 	if ok {
-		r := info.getDefaultRegion()
-		return r, r != ""
+		if r := info.getDefaultRegion(); r != "" {
+			return r, ackv1alpha1.AWSRegionSourceNamespaceAnnotation, true
+		}
 	}
-	return "", false
+	if r, ok := c.getAccountConfigs().GetDefaultRegion(namespace); ok {
+		return r, ackv1alpha1.AWSRegionSourceAccountConfig, true
+	}
+	return "", "", false
 }
 
 // GetOwnerAccountID returns the owner account ID if it exists
 func (c *NamespaceCache) GetOwnerAccountID(namespace string) (string, bool) {
 	info, ok := c.getNamespaceInfo(namespace)
 	if ok {
-		a := info.getOwnerAccountID()
-		return a, a != ""
+		if a := info.getOwnerAccountID(); a != "" {
+			return a, true
+		}
 	}
-	return "", false
+	return c.getAccountConfigs().GetOwnerAccountID(namespace)
 }
 
 // GetTeamID returns the team-id if it exists
@@ -210,8 +292,72 @@ func (c *NamespaceCache) GetTeamID(namespace string) (string, bool) {
 func (c *NamespaceCache) GetEndpointURL(namespace string) (string, bool) {
 	info, ok := c.getNamespaceInfo(namespace)
 	if ok {
-		e := info.getEndpointURL()
-		return e, e != ""
+		if e := info.getEndpointURL(); e != "" {
+			return e, true
+		}
+	}
+	return c.getAccountConfigs().GetEndpointURL(namespace)
+}
+
+// GetReconcileInterval returns the reconcile interval if it exists
+func (c *NamespaceCache) GetReconcileInterval(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		i := info.getReconcileInterval()
+		return i, i != ""
+	}
+	return "", false
+}
+
+// GetAWSRetryMode returns the AWS SDK retry mode if it exists
+func (c *NamespaceCache) GetAWSRetryMode(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		m := info.getAWSRetryMode()
+		return m, m != ""
+	}
+	return "", false
+}
+
+// GetAWSRetryMaxAttempts returns the AWS SDK maximum number of attempts if it
+// exists
+func (c *NamespaceCache) GetAWSRetryMaxAttempts(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		m := info.getAWSRetryMaxAttempts()
+		return m, m != ""
+	}
+	return "", false
+}
+
+// GetMutators returns the desired state mutators if they exist
+func (c *NamespaceCache) GetMutators(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		m := info.getMutators()
+		return m, m != ""
+	}
+	return "", false
+}
+
+// GetPaused returns whether the reconciliations of the resources of the
+// namespace are paused, if it is set
+func (c *NamespaceCache) GetPaused(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		p := info.getPaused()
+		return p, p != ""
+	}
+	return "", false
+}
+
+// GetMaintenanceWindow returns the maintenance window of the resources of the
+// namespace, if it is set
+func (c *NamespaceCache) GetMaintenanceWindow(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		w := info.getMaintenanceWindow()
+		return w, w != ""
 	}
 	return "", false
 }
@@ -226,16 +372,73 @@ func (c *NamespaceCache) GetDeletionPolicy(namespace string, service string) (st
 	return "", false
 }
 
+// getAccountConfigs returns the AccountConfigs the namespaces fall back to
+func (c *NamespaceCache) getAccountConfigs() *AccountConfigCache {
+	if c == nil {
+		return nil
+	}
+	return c.accountConfigs
+}
+
 // getNamespaceInfo reads a namespace cached annotations and
 // return a given namespace default aws region, owner account id and endpoint url.
 // This function is thread safe.
 func (c *NamespaceCache) getNamespaceInfo(ns string) (*namespaceInfo, bool) {
+	if c == nil {
+		return nil, false
+	}
 	c.RLock()
 	defer c.RUnlock()
 	namespaceInfo, ok := c.namespaceInfos[ns]
 	return namespaceInfo, ok
 }
 
+// NamespaceSnapshot is a copy of the cached annotations of a namespace
+type NamespaceSnapshot struct {
+	DefaultRegion       string            `json:"defaultRegion,omitempty"`
+	OwnerAccountID      string            `json:"ownerAccountID,omitempty"`
+	TeamID              string            `json:"teamID,omitempty"`
+	EndpointURL         string            `json:"endpointURL,omitempty"`
+	ReconcileInterval   string            `json:"reconcileInterval,omitempty"`
+	AWSRetryMode        string            `json:"awsRetryMode,omitempty"`
+	AWSRetryMaxAttempts string            `json:"awsRetryMaxAttempts,omitempty"`
+	Mutators            string            `json:"mutators,omitempty"`
+	Paused              string            `json:"paused,omitempty"`
+	MaintenanceWindow   string            `json:"maintenanceWindow,omitempty"`
+	DeletionPolicies    map[string]string `json:"deletionPolicies,omitempty"`
+}
+
+// snapshot returns a copy of the cached namespace annotations. This function
+// is thread safe.
+func (c *NamespaceCache) snapshot() map[string]NamespaceSnapshot {
+	if c == nil {
+		return nil
+	}
+	c.RLock()
+	defer c.RUnlock()
+	namespaces := make(map[string]NamespaceSnapshot, len(c.namespaceInfos))
+	for ns, info := range c.namespaceInfos {
+		policies := make(map[string]string, len(info.deletionPolicies))
+		for service, policy := range info.deletionPolicies {
+			policies[service] = policy
+		}
+		namespaces[ns] = NamespaceSnapshot{
+			DefaultRegion:       info.defaultRegion,
+			OwnerAccountID:      info.ownerAccountID,
+			TeamID:              info.teamID,
+			EndpointURL:         info.endpointURL,
+			ReconcileInterval:   info.reconcileInterval,
+			AWSRetryMode:        info.awsRetryMode,
+			AWSRetryMaxAttempts: info.awsRetryMaxAttempts,
+			Mutators:            info.mutators,
+			Paused:              info.paused,
+			MaintenanceWindow:   info.maintenanceWindow,
+			DeletionPolicies:    policies,
+		}
+	}
+	return namespaces
+}
+
 // setNamespaceInfoFromK8sObject takes a corev1.Namespace object and sets the
 // namespace ACK related annotations in the cache map
 func (c *NamespaceCache) setNamespaceInfoFromK8sObject(ns *corev1.Namespace) {
@@ -257,6 +460,30 @@ func (c *NamespaceCache) setNamespaceInfoFromK8sObject(ns *corev1.Namespace) {
 	if ok {
 		nsInfo.endpointURL = EndpointURL
 	}
+	ReconcileInterval, ok := nsa[ackv1alpha1.AnnotationReconcileInterval]
+	if ok {
+		nsInfo.reconcileInterval = ReconcileInterval
+	}
+	AWSRetryMode, ok := nsa[ackv1alpha1.AnnotationAWSRetryMode]
+	if ok {
+		nsInfo.awsRetryMode = AWSRetryMode
+	}
+	AWSRetryMaxAttempts, ok := nsa[ackv1alpha1.AnnotationAWSRetryMaxAttempts]
+	if ok {
+		nsInfo.awsRetryMaxAttempts = AWSRetryMaxAttempts
+	}
+	Mutators, ok := nsa[ackv1alpha1.AnnotationMutators]
+	if ok {
+		nsInfo.mutators = Mutators
+	}
+	Paused, ok := nsa[ackv1alpha1.AnnotationPaused]
+	if ok {
+		nsInfo.paused = Paused
+	}
+	MaintenanceWindow, ok := nsa[ackv1alpha1.AnnotationMaintenanceWindow]
+	if ok {
+		nsInfo.maintenanceWindow = MaintenanceWindow
+	}
 
 	nsInfo.deletionPolicies = map[string]string{}
 	nsDeletionPolicySuffix := "." + ackv1alpha1.AnnotationDeletionPolicy
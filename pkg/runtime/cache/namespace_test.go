@@ -157,9 +157,15 @@ func TestNamespaceCacheWithRoleARN(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "production",
 				Annotations: map[string]string{
-					ackv1alpha1.AnnotationDefaultRegion: "us-west-2",
-					ackv1alpha1.AnnotationTeamID:        "team-a",
-					ackv1alpha1.AnnotationEndpointURL:   "https://amazon-service.region.amazonaws.com",
+					ackv1alpha1.AnnotationDefaultRegion:       "us-west-2",
+					ackv1alpha1.AnnotationTeamID:              "team-a",
+					ackv1alpha1.AnnotationEndpointURL:         "https://amazon-service.region.amazonaws.com",
+					ackv1alpha1.AnnotationReconcileInterval:   "1m",
+					ackv1alpha1.AnnotationAWSRetryMode:        "adaptive",
+					ackv1alpha1.AnnotationAWSRetryMaxAttempts: "5",
+					ackv1alpha1.AnnotationMutators:            `[{"name":"name-prefix","params":{"prefix":"prod-"}}]`,
+					ackv1alpha1.AnnotationPaused:              "true",
+					ackv1alpha1.AnnotationMaintenanceWindow:   "0 2 * * SAT 4h",
 				},
 			},
 		},
@@ -181,6 +187,30 @@ func TestNamespaceCacheWithRoleARN(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "https://amazon-service.region.amazonaws.com", endpointURL)
 
+	reconcileInterval, ok := namespaceCache.GetReconcileInterval("production")
+	require.True(t, ok)
+	require.Equal(t, "1m", reconcileInterval)
+
+	retryMode, ok := namespaceCache.GetAWSRetryMode("production")
+	require.True(t, ok)
+	require.Equal(t, "adaptive", retryMode)
+
+	retryMaxAttempts, ok := namespaceCache.GetAWSRetryMaxAttempts("production")
+	require.True(t, ok)
+	require.Equal(t, "5", retryMaxAttempts)
+
+	mutators, ok := namespaceCache.GetMutators("production")
+	require.True(t, ok)
+	require.Equal(t, `[{"name":"name-prefix","params":{"prefix":"prod-"}}]`, mutators)
+
+	paused, ok := namespaceCache.GetPaused("production")
+	require.True(t, ok)
+	require.Equal(t, "true", paused)
+
+	maintenanceWindow, ok := namespaceCache.GetMaintenanceWindow("production")
+	require.True(t, ok)
+	require.Equal(t, "0 2 * * SAT 4h", maintenanceWindow)
+
 	// Test update events
 	_, err = k8sClient.CoreV1().Namespaces().Update(
 		context.Background(),
@@ -212,6 +242,12 @@ func TestNamespaceCacheWithRoleARN(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "https://amazon-other-service.region.amazonaws.com", endpointURL)
 
+	_, ok = namespaceCache.GetReconcileInterval("production")
+	require.False(t, ok)
+
+	_, ok = namespaceCache.GetAWSRetryMode("production")
+	require.False(t, ok)
+
 	// Test delete events
 	err = k8sClient.CoreV1().Namespaces().Delete(
 		context.Background(),
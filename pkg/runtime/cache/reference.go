@@ -0,0 +1,267 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/go-logr/logr"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	ctrlrtcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// referenceKey uniquely identifies a referenced Kubernetes object.
+type referenceKey struct {
+	gvk schema.GroupVersionKind
+	nsn types.NamespacedName
+}
+
+// ReferenceCache caches the Kubernetes objects that are read while resolving
+// resource references.
+//
+// Resource managers resolve references by reading every referent from the
+// Kubernetes API server on every reconciliation loop. For deep, but stable,
+// dependency graphs this adds multiple API round-trips per reconcile. The
+// ReferenceCache keeps a copy of each referent it has read and serves
+// subsequent reads from memory. Cached entries are invalidated by watching
+// the referent kinds: whenever a referent is added, updated (its
+// resourceVersion changes) or deleted, the cached copy is dropped and the
+// next read goes back to the API server.
+//
+// Objects of a given kind are only cached once the informer for that kind
+// has synced. Until then, and for any kind we fail to watch (for example
+// because of missing RBAC permissions), reads are passed straight through to
+// the underlying reader.
+type ReferenceCache struct {
+	sync.RWMutex
+	log logr.Logger
+
+	// informers is used to watch the referent kinds
+	informers ctrlrtcache.Informers
+	// scheme is used to determine the GroupVersionKind of referents
+	scheme *k8sruntime.Scheme
+
+	// entries holds the cached referents
+	entries map[referenceKey]client.Object
+	// watched holds the informers of the referent kinds we are watching
+	watched map[schema.GroupVersionKind]ctrlrtcache.Informer
+	// epoch is incremented on every watch event. It is used to avoid caching
+	// an object that was modified while it was being read.
+	epoch uint64
+}
+
+// NewReferenceCache instantiates a new ReferenceCache that uses the supplied
+// informers to watch for changes in referenced objects.
+func NewReferenceCache(
+	log logr.Logger,
+	informers ctrlrtcache.Informers,
+	scheme *k8sruntime.Scheme,
+) *ReferenceCache {
+	return &ReferenceCache{
+		log:       log.WithName("cache.reference"),
+		informers: informers,
+		scheme:    scheme,
+		entries:   make(map[referenceKey]client.Object),
+		watched:   make(map[schema.GroupVersionKind]ctrlrtcache.Informer),
+	}
+}
+
+// Reader returns a client.Reader that serves Get calls from the cache, falling
+// back to the supplied reader when the object is not cached. List calls are
+// always passed through to the supplied reader.
+func (c *ReferenceCache) Reader(reader client.Reader) client.Reader {
+	return &referenceReader{cache: c, reader: reader}
+}
+
+// Invalidate drops the cached copy of the object with the supplied
+// GroupVersionKind and namespaced name, if any.
+func (c *ReferenceCache) Invalidate(
+	gvk schema.GroupVersionKind,
+	nsn types.NamespacedName,
+) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.entries, referenceKey{gvk: gvk, nsn: nsn})
+}
+
+// get copies the cached object identified by key into obj. It returns false
+// if the object is not cached.
+func (c *ReferenceCache) get(key referenceKey, obj client.Object) bool {
+	c.RLock()
+	defer c.RUnlock()
+	cached, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	outVal := reflect.ValueOf(obj)
+	cachedVal := reflect.ValueOf(cached.DeepCopyObject())
+	if outVal.Type() != cachedVal.Type() {
+		return false
+	}
+	outVal.Elem().Set(cachedVal.Elem())
+	return true
+}
+
+// currentEpoch returns the current watch event epoch.
+func (c *ReferenceCache) currentEpoch() uint64 {
+	c.RLock()
+	defer c.RUnlock()
+	return c.epoch
+}
+
+// set stores a copy of the supplied object under key, unless a watch event
+// was received since epoch.
+func (c *ReferenceCache) set(key referenceKey, obj client.Object, epoch uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if c.epoch != epoch {
+		return
+	}
+	c.entries[key] = obj.DeepCopyObject().(client.Object)
+}
+
+// watching ensures an informer is watching the kind of the supplied object
+// and returns true once that informer has synced.
+func (c *ReferenceCache) watching(
+	ctx context.Context,
+	gvk schema.GroupVersionKind,
+	obj client.Object,
+) bool {
+	c.RLock()
+	informer, ok := c.watched[gvk]
+	c.RUnlock()
+	if ok {
+		return informer != nil && informer.HasSynced()
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if informer, ok := c.watched[gvk]; ok {
+		return informer != nil && informer.HasSynced()
+	}
+	// Do not block the reconciliation loop waiting for the informer to
+	// sync. Until it is, reads are passed through to the API server.
+	informer, err := c.informers.GetInformer(
+		ctx, obj, ctrlrtcache.BlockUntilSynced(false),
+	)
+	if err != nil {
+		c.log.V(1).Info(
+			"unable to watch referenced kind, references will not be cached",
+			"gvk", gvk.String(), "error", err,
+		)
+		// Remember the failure so we don't retry on every read
+		c.watched[gvk] = nil
+		return false
+	}
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.invalidateObject(gvk, obj, false)
+		},
+		UpdateFunc: func(orig, desired interface{}) {
+			c.invalidateObject(gvk, desired, false)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.invalidateObject(gvk, obj, true)
+		},
+	})
+	if err != nil {
+		c.log.V(1).Info(
+			"unable to watch referenced kind, references will not be cached",
+			"gvk", gvk.String(), "error", err,
+		)
+		c.watched[gvk] = nil
+		return false
+	}
+	c.watched[gvk] = informer
+	return informer.HasSynced()
+}
+
+// invalidateObject drops the cached copy of the supplied object. Unless force
+// is true, the cached copy is kept if it has the same UID and resourceVersion
+// as the supplied object.
+func (c *ReferenceCache) invalidateObject(
+	gvk schema.GroupVersionKind,
+	obj interface{},
+	force bool,
+) {
+	o, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+	key := referenceKey{
+		gvk: gvk,
+		nsn: types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()},
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.epoch++
+	cached, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	if !force && cached.GetUID() == o.GetUID() &&
+		cached.GetResourceVersion() == o.GetResourceVersion() {
+		return
+	}
+	delete(c.entries, key)
+}
+
+// referenceReader is a client.Reader backed by a ReferenceCache.
+type referenceReader struct {
+	cache  *ReferenceCache
+	reader client.Reader
+}
+
+// Get retrieves an object from the cache if it is present, and from the
+// underlying reader otherwise.
+func (r *referenceReader) Get(
+	ctx context.Context,
+	nsn client.ObjectKey,
+	obj client.Object,
+	opts ...client.GetOption,
+) error {
+	gvk, err := apiutil.GVKForObject(obj, r.cache.scheme)
+	if err != nil || len(opts) > 0 || !r.cache.watching(ctx, gvk, obj) {
+		return r.reader.Get(ctx, nsn, obj, opts...)
+	}
+	key := referenceKey{gvk: gvk, nsn: nsn}
+	if r.cache.get(key, obj) {
+		return nil
+	}
+	epoch := r.cache.currentEpoch()
+	if err := r.reader.Get(ctx, nsn, obj); err != nil {
+		return err
+	}
+	r.cache.set(key, obj, epoch)
+	return nil
+}
+
+// List retrieves a list of objects from the underlying reader.
+func (r *referenceReader) List(
+	ctx context.Context,
+	list client.ObjectList,
+	opts ...client.ListOption,
+) error {
+	return r.reader.List(ctx, list, opts...)
+}
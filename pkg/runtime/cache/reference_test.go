@@ -0,0 +1,176 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrlrtcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+// countingReader is a client.Reader returning a fixed ConfigMap and counting
+// the number of Get calls it receives.
+type countingReader struct {
+	client.Reader
+	gets int
+	cm   *corev1.ConfigMap
+}
+
+func (r *countingReader) Get(
+	ctx context.Context,
+	key client.ObjectKey,
+	obj client.Object,
+	opts ...client.GetOption,
+) error {
+	r.gets++
+	r.cm.DeepCopyInto(obj.(*corev1.ConfigMap))
+	return nil
+}
+
+// fakeInformer records the event handlers added to it.
+type fakeInformer struct {
+	ctrlrtcache.Informer
+	synced   bool
+	handlers []toolscache.ResourceEventHandler
+}
+
+func (i *fakeInformer) AddEventHandler(
+	handler toolscache.ResourceEventHandler,
+) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.handlers = append(i.handlers, handler)
+	return nil, nil
+}
+
+func (i *fakeInformer) HasSynced() bool {
+	return i.synced
+}
+
+// fakeInformers returns the same informer for every kind.
+type fakeInformers struct {
+	ctrlrtcache.Informers
+	informer *fakeInformer
+	err      error
+}
+
+func (i *fakeInformers) GetInformer(
+	ctx context.Context,
+	obj client.Object,
+	opts ...ctrlrtcache.InformerGetOption,
+) (ctrlrtcache.Informer, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	return i.informer, nil
+}
+
+func TestReferenceCache(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	nsn := types.NamespacedName{Namespace: "default", Name: "my-cm"}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       nsn.Namespace,
+			Name:            nsn.Name,
+			UID:             "uid-1",
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{"key": "value"},
+	}
+	reader := &countingReader{cm: cm.DeepCopy()}
+	informer := &fakeInformer{}
+	refCache := ackrtcache.NewReferenceCache(
+		logr.Discard(), &fakeInformers{informer: informer}, clientgoscheme.Scheme,
+	)
+	cachedReader := refCache.Reader(reader)
+
+	// Informer is not synced yet, reads are passed through
+	got := &corev1.ConfigMap{}
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(2, reader.gets)
+	require.Len(informer.handlers, 1)
+
+	// Once synced, the referent is read only once
+	informer.synced = true
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(3, reader.gets)
+	require.Equal("value", got.Data["key"])
+
+	// Modifying the returned copy doesn't modify the cached copy
+	got.Data["key"] = "modified"
+	got = &corev1.ConfigMap{}
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal("value", got.Data["key"])
+	require.Equal(3, reader.gets)
+
+	// An update event with the same resourceVersion keeps the cached copy
+	informer.handlers[0].OnUpdate(cm, cm)
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(3, reader.gets)
+
+	// An update event with a new resourceVersion invalidates the cached copy
+	updated := cm.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Data["key"] = "new-value"
+	reader.cm = updated.DeepCopy()
+	informer.handlers[0].OnUpdate(cm, updated)
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(4, reader.gets)
+	require.Equal("new-value", got.Data["key"])
+
+	// A delete event invalidates the cached copy
+	informer.handlers[0].OnDelete(toolscache.DeletedFinalStateUnknown{Obj: updated})
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(5, reader.gets)
+
+	// Explicit invalidation
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	refCache.Invalidate(gvk, nsn)
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(6, reader.gets)
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(6, reader.gets)
+}
+
+func TestReferenceCache_WatchFailure(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	nsn := types.NamespacedName{Namespace: "default", Name: "my-cm"}
+	reader := &countingReader{cm: &corev1.ConfigMap{}}
+	refCache := ackrtcache.NewReferenceCache(
+		logr.Discard(),
+		&fakeInformers{err: errors.New("forbidden")},
+		clientgoscheme.Scheme,
+	)
+	cachedReader := refCache.Reader(reader)
+
+	// Unwatchable kinds are never cached
+	got := &corev1.ConfigMap{}
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Nil(cachedReader.Get(ctx, nsn, got))
+	require.Equal(2, reader.gets)
+}
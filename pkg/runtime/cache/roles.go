@@ -0,0 +1,78 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+const (
+	// SecondaryRoleKeySuffix is the suffix of the CARM configmap keys whose
+	// value is the secondary IAM role of an account or team, e.g.
+	// "012345678912.secondary". During the replacement of an IAM role, the
+	// controller fails over to the secondary role when the active one cannot
+	// be assumed anymore.
+	SecondaryRoleKeySuffix = ".secondary"
+	// ActiveRoleKeySuffix is the suffix of the CARM configmap keys selecting
+	// the active IAM role of an account or team, ActiveRolePrimary (the
+	// default) or ActiveRoleSecondary. Setting it to ActiveRoleSecondary cuts
+	// over to the secondary role.
+	ActiveRoleKeySuffix = ".active"
+	// ActiveRolePrimary selects the primary IAM role
+	ActiveRolePrimary = "primary"
+	// ActiveRoleSecondary selects the secondary IAM role
+	ActiveRoleSecondary = "secondary"
+)
+
+// Roles are the primary and secondary IAM roles of a cross account resource
+// management binding
+type Roles struct {
+	// Primary is the IAM role ARN of the account or team key, if any
+	Primary string
+	// Secondary is the IAM role ARN of the SecondaryRoleKeySuffix key
+	Secondary string
+	// SecondaryActive is true if the ActiveRoleKeySuffix key selects the
+	// secondary role
+	SecondaryActive bool
+}
+
+// Failures returns the number of consecutive failures to assume the supplied
+// role for the resources of the supplied namespace
+func (f *AssumeRoleFailures) Failures(namespace, roleARN string) int {
+	if f == nil {
+		return 0
+	}
+	f.Lock()
+	defer f.Unlock()
+	return f.failures[assumeRoleKey{namespace, roleARN}]
+}
+
+// Select returns the role to assume for the resources of the supplied
+// namespace, and whether it is a failover from the active role. The active
+// role is selected unless it is missing, or it could not be assumed
+// AssumeRoleFailureThreshold consecutive times while the standby role can
+// still be assumed.
+func (f *AssumeRoleFailures) Select(namespace string, roles Roles) (string, bool) {
+	active, standby := roles.Primary, roles.Secondary
+	if roles.SecondaryActive {
+		active, standby = standby, active
+	}
+	if standby == "" {
+		return active, false
+	}
+	if active == "" {
+		return standby, false
+	}
+	if f.Failures(namespace, active) >= AssumeRoleFailureThreshold &&
+		f.Failures(namespace, standby) < AssumeRoleFailureThreshold {
+		return standby, true
+	}
+	return active, false
+}
@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+func TestAssumeRoleFailuresSelect(t *testing.T) {
+	require := require.New(t)
+
+	blue := "arn:aws:iam::123456789012:role/ack-blue"
+	green := "arn:aws:iam::123456789012:role/ack-green"
+	failures := ackrtcache.NewAssumeRoleFailures()
+
+	role, failover := failures.Select("ns", ackrtcache.Roles{Primary: blue})
+	require.Equal(blue, role)
+	require.False(failover)
+	role, _ = failures.Select("ns", ackrtcache.Roles{Secondary: green})
+	require.Equal(green, role)
+
+	roles := ackrtcache.Roles{Primary: blue, Secondary: green}
+	role, failover = failures.Select("ns", roles)
+	require.Equal(blue, role)
+	require.False(failover)
+
+	// Cutover
+	roles.SecondaryActive = true
+	role, failover = failures.Select("ns", roles)
+	require.Equal(green, role)
+	require.False(failover)
+
+	// Automatic failover once the active role is broken
+	for i := 0; i < ackrtcache.AssumeRoleFailureThreshold; i++ {
+		failures.RecordFailure("ns", green)
+	}
+	require.Equal(ackrtcache.AssumeRoleFailureThreshold, failures.Failures("ns", green))
+	role, failover = failures.Select("ns", roles)
+	require.Equal(blue, role)
+	require.True(failover)
+	role, _ = failures.Select("other-ns", roles)
+	require.Equal(green, role)
+
+	// The active role is kept when both roles are broken
+	for i := 0; i < ackrtcache.AssumeRoleFailureThreshold; i++ {
+		failures.RecordFailure("ns", blue)
+	}
+	role, failover = failures.Select("ns", roles)
+	require.Equal(green, role)
+	require.False(failover)
+
+	var nilFailures *ackrtcache.AssumeRoleFailures
+	require.Equal(0, nilFailures.Failures("ns", blue))
+	role, _ = nilFailures.Select("ns", roles)
+	require.Equal(green, role)
+}
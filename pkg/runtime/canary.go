@@ -0,0 +1,69 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/canary"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// flagCanaryPercent returns the canary percentage of the kind of the
+// reconciler from the --reconcile-resource-canary-percent flag, nil if the
+// kind has no rollout in progress
+func (r *resourceReconciler) flagCanaryPercent() *int {
+	percent, ok := r.cfg.GetReconcileResourceCanaryPercent(r.rd.GroupVersionKind().Kind)
+	if !ok {
+		return nil
+	}
+	return &percent
+}
+
+// canaryPercent returns the canary percentage of the kind of the reconciler,
+// from its ControllerConfig, else its flag, and false if the kind has no
+// rollout in progress
+func (r *resourceReconciler) canaryPercent() (int, bool) {
+	if percent, ok := r.tuning.CanaryPercent(r.rd.GroupVersionKind().Kind); ok {
+		return percent, true
+	}
+	if percent := r.flagCanaryPercent(); percent != nil {
+		return *percent, true
+	}
+	return 0, false
+}
+
+// withCanaryCohort returns a copy of the supplied context holding the cohort
+// of the supplied resource, which the resource manager checks to give the
+// resource the new behavior or not, if its kind has a rollout in progress
+func (r *resourceReconciler) withCanaryCohort(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) context.Context {
+	percent, ok := r.canaryPercent()
+	if !ok {
+		return ctx
+	}
+	cohort := canary.CohortOf(res.MetaObject().GetUID(), percent)
+	r.metrics.RecordCanaryReconcile(r.rd.GroupVersionKind().Kind, string(cohort))
+	return canary.WithCohort(ctx, cohort)
+}
+
+// recordCanaryUpdate records the update of the AWS resource of the cohort of
+// the supplied context, if its kind has a rollout in progress
+func (r *resourceReconciler) recordCanaryUpdate(ctx context.Context) {
+	if cohort, ok := canary.FromContext(ctx); ok {
+		r.metrics.RecordCanaryUpdate(r.rd.GroupVersionKind().Kind, string(cohort))
+	}
+}
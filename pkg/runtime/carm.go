@@ -0,0 +1,180 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// handleAssumeRoleResult records the outcome of the use of the IAM role of the
+// cross account resource management binding of the supplied resource, so that
+// tenants can tell a broken account binding from an invalid resource spec.
+//
+// Failures to assume the role are counted in the CARM metrics, emitted as
+// events on the resource and, once they persist, on its namespace. The
+// ResourceSynced condition of the returned resource points at the account
+// binding.
+func (r *resourceReconciler) handleAssumeRoleResult(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	roleARN ackv1alpha1.AWSResourceName,
+	err error,
+) acktypes.AWSResource {
+	if roleARN == "" {
+		return latest
+	}
+	namespace := desired.MetaObject().GetNamespace()
+	reason, failed := ackerr.AssumeRoleFailure(err)
+	if !failed {
+		// Credentials are only known to be valid when the reconciliation
+		// succeeded or the AWS service API responded.
+		if _, isAWSError := ackerr.AWSError(err); err != nil && !isAWSError {
+			return latest
+		}
+		if r.metrics != nil {
+			r.metrics.RecordAssumeRoleResult(string(roleARN), namespace, "")
+		}
+		if r.cache.AssumeRoleFailures.RecordSuccess(namespace, string(roleARN)) >= ackrtcache.AssumeRoleFailureThreshold {
+			r.recordNamespaceEvent(
+				ctx, namespace, ackevents.ReasonAccountBindingRecovered,
+				fmt.Sprintf("IAM role %s can be assumed again", roleARN),
+			)
+		}
+		return latest
+	}
+
+	rlog := ackrtlog.FromContext(ctx)
+	rlog.Info("unable to assume IAM role", "role", roleARN, "reason", reason)
+	if r.metrics != nil {
+		r.metrics.RecordAssumeRoleResult(string(roleARN), namespace, reason)
+	}
+	r.recordEvent(
+		desired.RuntimeObject(), ackevents.ReasonAssumeRoleFailed,
+		fmt.Sprintf("Unable to assume IAM role %s: %s", roleARN, reason),
+	)
+	if r.cache.AssumeRoleFailures.RecordFailure(namespace, string(roleARN)) == ackrtcache.AssumeRoleFailureThreshold {
+		r.recordNamespaceEvent(
+			ctx, namespace, ackevents.ReasonAccountBindingBroken,
+			fmt.Sprintf(
+				"IAM role %s could not be assumed %d consecutive times: %s",
+				roleARN, ackrtcache.AssumeRoleFailureThreshold, reason,
+			),
+		)
+	}
+
+	if latest == nil {
+		latest = desired.DeepCopy()
+	}
+	errMessage := err.Error()
+	condition.SetSynced(latest, corev1.ConditionFalse, &condition.AssumeRoleFailedMessage, &errMessage)
+	return latest
+}
+
+// getBindingRoleARN returns the IAM role ARN to assume for the resources of
+// the supplied namespace bound to the supplied account or team ID. Bindings
+// with a secondary role, used during the replacement of an IAM role, resolve
+// to the active role, and fail over to the standby one when the active role
+// cannot be assumed anymore. The failover lasts until the active role is cut
+// over, or the controller restarts.
+func (r *resourceReconciler) getBindingRoleARN(
+	ctx context.Context,
+	namespace string,
+	id string,
+	cacheName string,
+) (ackv1alpha1.AWSResourceName, error) {
+	primary, err := r.getRoleARN(id, cacheName)
+	secondary, secondaryErr := r.getRoleARN(id+ackrtcache.SecondaryRoleKeySuffix, cacheName)
+	if secondaryErr != nil {
+		return primary, err
+	}
+	roles := ackrtcache.Roles{
+		Primary:   string(primary),
+		Secondary: string(secondary),
+	}
+	// The active role is looked up like the roles, so that it can also be
+	// selected per service
+	if active, err := r.getRoleARN(id+ackrtcache.ActiveRoleKeySuffix, cacheName); err == nil {
+		roles.SecondaryActive = string(active) == ackrtcache.ActiveRoleSecondary
+	}
+	roleARN, failover := r.cache.AssumeRoleFailures.Select(namespace, roles)
+	if failover {
+		ackrtlog.FromContext(ctx).Debug(
+			"failing over to the standby IAM role",
+			"id", id,
+			"role", roleARN,
+		)
+	}
+	return ackv1alpha1.AWSResourceName(roleARN), nil
+}
+
+// getOwnerRoleARN returns the IAM role set with the owner role ARN annotation
+// of the supplied resource and the AWS account the role belongs to, or an
+// empty role if the annotation is not set. An error is returned if the role
+// is not an IAM role ARN, or is not allowed by the --allowed-owner-role-arns
+// flag.
+func (r *resourceReconciler) getOwnerRoleARN(
+	res acktypes.AWSResource,
+) (ackv1alpha1.AWSResourceName, ackv1alpha1.AWSAccountID, error) {
+	roleARN := res.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationOwnerRoleARN]
+	if roleARN == "" {
+		return "", "", nil
+	}
+	parsedARN, err := arn.Parse(roleARN)
+	if err != nil || parsedARN.Service != "iam" || parsedARN.AccountID == "" {
+		return "", "", fmt.Errorf(
+			"the %s annotation %q is not an IAM role ARN", ackv1alpha1.AnnotationOwnerRoleARN, roleARN,
+		)
+	}
+	namespace := res.MetaObject().GetNamespace()
+	if !r.cfg.IsOwnerRoleARNAllowed(namespace, roleARN) {
+		return "", "", fmt.Errorf(
+			"IAM role %s of the %s annotation is not allowed in namespace %s by the --allowed-owner-role-arns flag of the controller",
+			roleARN, ackv1alpha1.AnnotationOwnerRoleARN, namespace,
+		)
+	}
+	return ackv1alpha1.AWSResourceName(roleARN), ackv1alpha1.AWSAccountID(parsedARN.AccountID), nil
+}
+
+// handleOwnerRoleARNError sets the Terminal condition of a resource whose
+// owner role ARN annotation is invalid or not allowed. Such errors do not go
+// away on retry, so the resource is only reconciled again at the resync
+// period, or once its spec changes.
+func (r *resourceReconciler) handleOwnerRoleARNError(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	err error,
+) (ctrlrt.Result, error) {
+	ackrtlog.FromContext(ctx).Info("owner role ARN not allowed", "error", err)
+	reason := err.Error()
+	latest := desired.DeepCopy()
+	condition.SetTerminal(latest, corev1.ConditionTrue, &condition.OwnerRoleNotAllowedMessage, &reason)
+	condition.SetSynced(latest, corev1.ConditionFalse, &condition.NotSyncedMessage, &reason)
+	return r.HandleReconcileError(ctx, desired, latest, requeue.NeededAfter(ackerr.Terminal, r.resyncPeriod))
+}
@@ -0,0 +1,95 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/circuitbreaker"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// circuitBreakerKey returns the key of the circuit of the calls of the
+// reconciler to the API of its AWS service in the supplied account and region
+func (r *reconciler) circuitBreakerKey(
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) circuitbreaker.Key {
+	return circuitbreaker.Key{
+		Account: string(acctID),
+		Region:  string(region),
+		Service: r.sc.GetMetadata().ServiceAlias,
+	}
+}
+
+// withCircuitBreaker returns a copy of the supplied configuration of the AWS
+// service clients recording the outcome of their calls in the circuit
+// breaker of the supplied account and region, and stopping them while its
+// circuit is open
+func (r *reconciler) withCircuitBreaker(
+	cfg aws.Config,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) aws.Config {
+	if r.circuitBreaker == nil {
+		return cfg
+	}
+	apiOptions := make([]func(*middleware.Stack) error, 0, len(cfg.APIOptions)+1)
+	apiOptions = append(apiOptions, cfg.APIOptions...)
+	cfg.APIOptions = append(apiOptions, r.circuitBreaker.Middleware(r.circuitBreakerKey(acctID, region)))
+	return cfg
+}
+
+// pauseOpenCircuit returns true, and the duration after which to reconcile
+// the supplied resource again, if the circuit of the calls to the AWS service
+// API of the supplied account and region is open: the calls persistently
+// failed, throttled or with 5XX responses, and reconciling the resource would
+// only hammer the unhealthy API further. The namespace of the resource gets
+// an event, once per trip of the circuit.
+func (r *resourceReconciler) pauseOpenCircuit(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) (time.Duration, bool) {
+	if r.circuitBreaker == nil {
+		return 0, false
+	}
+	namespace := res.MetaObject().GetNamespace()
+	key := r.circuitBreakerKey(acctID, region)
+	after, notify := r.circuitBreaker.Open(key, namespace)
+	if after <= 0 {
+		return 0, false
+	}
+	ackrtlog.FromContext(ctx).Debug("circuit breaker open, pausing reconciliation", "after", after)
+	if notify {
+		r.recordNamespaceEvent(
+			ctx, namespace, ackevents.ReasonCircuitBreakerOpen,
+			fmt.Sprintf(
+				"The calls to the %s API of account %s in region %s persistently failed, "+
+					"the reconciliations of the %s resources are paused for %s",
+				key.Service, acctID, region, r.rd.GroupVersionKind().Kind, after.Round(time.Second),
+			),
+		)
+	}
+	return after, true
+}
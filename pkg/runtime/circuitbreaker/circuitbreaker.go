@@ -0,0 +1,221 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package circuitbreaker stops the calls of a controller to the API of an AWS
+// service in an AWS account and region once they persistently fail, throttled
+// or with 5XX responses, so that the resources of the controller do not keep
+// hammering an unhealthy API. The calls resume after a cool-down period.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+)
+
+// middlewareID is the ID of the middleware recording the outcome of the
+// calls to the AWS service APIs and stopping them while the circuit is open
+const middlewareID = "ACKCircuitBreaker"
+
+// ErrOpen is returned by the calls to the AWS service APIs stopped because
+// their circuit is open
+var ErrOpen = errors.New("circuit breaker open")
+
+// Key identifies the circuit of the calls to the API of an AWS service in an
+// AWS account and region
+type Key struct {
+	Account string
+	Region  string
+	Service string
+}
+
+// circuit is the state of the calls of a Key
+type circuit struct {
+	// failures is the number of consecutive failed calls
+	failures int
+	// openUntil is the end of the cool-down of the circuit, zero if it
+	// never tripped
+	openUntil time.Time
+	// namespaces are the namespaces notified of the current trip
+	namespaces map[string]struct{}
+}
+
+// Breaker tracks the circuits of the calls of a controller to the AWS service
+// APIs. A circuit trips, or opens, after a threshold number of consecutive
+// failed calls, and stays open for a cool-down period during which the calls
+// are stopped. The first call after the cool-down closes the circuit if it
+// succeeds, and trips it again otherwise. A nil Breaker never trips.
+type Breaker struct {
+	sync.Mutex
+	threshold int
+	coolDown  time.Duration
+	circuits  map[Key]*circuit
+	// onTrip is called, without the lock held, each time a circuit trips
+	onTrip func(Key)
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewBreaker returns a Breaker tripping the circuits after the supplied
+// number of consecutive failed calls, for the supplied cool-down period. It
+// returns nil if the threshold is not positive.
+func NewBreaker(threshold int, coolDown time.Duration) *Breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &Breaker{
+		threshold: threshold,
+		coolDown:  coolDown,
+		circuits:  map[Key]*circuit{},
+		now:       time.Now,
+	}
+}
+
+// WithClock replaces the clock of the Breaker
+func (b *Breaker) WithClock(now func() time.Time) *Breaker {
+	b.now = now
+	return b
+}
+
+// OnTrip sets the function called each time a circuit trips, e.g. to record
+// a metric
+func (b *Breaker) OnTrip(onTrip func(Key)) *Breaker {
+	b.onTrip = onTrip
+	return b
+}
+
+// IsFailure returns true if the supplied error of a call to an AWS service
+// API is a sign of an unhealthy API: the call was throttled or got a 5XX
+// response
+func IsFailure(err error) bool {
+	if err == nil || errors.Is(err, ErrOpen) {
+		return false
+	}
+	if retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err).Bool() {
+		return true
+	}
+	status := ackerr.HTTPStatusCode(err)
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// Record records the outcome of a call of the supplied key, and returns true
+// if the call tripped its circuit. The errors that are not failures, e.g.
+// validation errors, count as successful calls.
+func (b *Breaker) Record(key Key, err error) bool {
+	if b == nil {
+		return false
+	}
+	tripped := b.record(key, err)
+	if tripped && b.onTrip != nil {
+		b.onTrip(key)
+	}
+	return tripped
+}
+
+func (b *Breaker) record(key Key, err error) bool {
+	b.Lock()
+	defer b.Unlock()
+	c, ok := b.circuits[key]
+	if !IsFailure(err) {
+		if ok {
+			delete(b.circuits, key)
+		}
+		return false
+	}
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	now := b.now()
+	// The calls sent before the circuit tripped may fail while it is open
+	if now.Before(c.openUntil) {
+		return false
+	}
+	c.failures++
+	if c.failures < b.threshold {
+		return false
+	}
+	c.openUntil = now.Add(b.coolDown)
+	c.namespaces = map[string]struct{}{}
+	return true
+}
+
+// Open returns the remaining cool-down of the circuit of the supplied key,
+// zero if it is closed. It also returns whether the supplied namespace was
+// not notified of the current trip yet.
+func (b *Breaker) Open(key Key, namespace string) (after time.Duration, notify bool) {
+	if b == nil {
+		return 0, false
+	}
+	b.Lock()
+	defer b.Unlock()
+	after = b.remaining(key)
+	if after <= 0 {
+		return 0, false
+	}
+	c := b.circuits[key]
+	if _, ok := c.namespaces[namespace]; ok {
+		return after, false
+	}
+	c.namespaces[namespace] = struct{}{}
+	return after, true
+}
+
+// remaining returns the remaining cool-down of the circuit of the supplied
+// key, zero or negative if it is closed
+func (b *Breaker) remaining(key Key) time.Duration {
+	c, ok := b.circuits[key]
+	if !ok {
+		return 0
+	}
+	return c.openUntil.Sub(b.now())
+}
+
+// Middleware returns the API option recording in the Breaker the outcome of
+// the calls of the supplied key, retries included, and stopping them with
+// ErrOpen while its circuit is open
+func (b *Breaker) Middleware(key Key) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(
+			middlewareID,
+			func(
+				ctx context.Context,
+				in middleware.InitializeInput,
+				next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				b.Lock()
+				after := b.remaining(key)
+				b.Unlock()
+				if after > 0 {
+					return middleware.InitializeOutput{}, middleware.Metadata{}, fmt.Errorf(
+						"%w: calls to %s in account %s and region %s resume in %s",
+						ErrOpen, key.Service, key.Account, key.Region, after.Round(time.Second),
+					)
+				}
+				out, md, err := next.HandleInitialize(ctx, in)
+				if !errors.Is(err, context.Canceled) {
+					b.Record(key, err)
+				}
+				return out, md, err
+			},
+		), middleware.Before)
+	}
+}
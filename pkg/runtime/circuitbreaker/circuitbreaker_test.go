@@ -0,0 +1,140 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/circuitbreaker"
+)
+
+func responseError(status int) error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+		Err:      errors.New("service error"),
+	}
+}
+
+func TestIsFailure(t *testing.T) {
+	require := require.New(t)
+
+	require.False(circuitbreaker.IsFailure(nil))
+	require.False(circuitbreaker.IsFailure(errors.New("validation")))
+	require.False(circuitbreaker.IsFailure(responseError(http.StatusBadRequest)))
+	require.False(circuitbreaker.IsFailure(circuitbreaker.ErrOpen))
+	require.True(circuitbreaker.IsFailure(responseError(http.StatusServiceUnavailable)))
+	require.True(circuitbreaker.IsFailure(responseError(http.StatusTooManyRequests)))
+	require.True(circuitbreaker.IsFailure(&smithy.GenericAPIError{Code: "ThrottlingException"}))
+}
+
+func TestBreaker(t *testing.T) {
+	require := require.New(t)
+	key := circuitbreaker.Key{Account: "111111111111", Region: "us-west-2", Service: "s3"}
+	throttled := &smithy.GenericAPIError{Code: "Throttling"}
+
+	var nilBreaker *circuitbreaker.Breaker
+	require.False(nilBreaker.Record(key, throttled))
+	after, _ := nilBreaker.Open(key, "default")
+	require.Zero(after)
+	require.Nil(circuitbreaker.NewBreaker(0, time.Minute))
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	trips := []circuitbreaker.Key{}
+	breaker := circuitbreaker.NewBreaker(3, time.Minute).
+		WithClock(func() time.Time { return now }).
+		OnTrip(func(key circuitbreaker.Key) { trips = append(trips, key) })
+
+	// Successful calls, or errors of the requests, reset the failures
+	require.False(breaker.Record(key, throttled))
+	require.False(breaker.Record(key, throttled))
+	require.False(breaker.Record(key, errors.New("validation")))
+	require.False(breaker.Record(key, throttled))
+	require.False(breaker.Record(key, responseError(http.StatusInternalServerError)))
+	after, _ = breaker.Open(key, "default")
+	require.Zero(after)
+
+	require.True(breaker.Record(key, throttled))
+	require.Equal([]circuitbreaker.Key{key}, trips)
+	after, notify := breaker.Open(key, "default")
+	require.Equal(time.Minute, after)
+	require.True(notify)
+	_, notify = breaker.Open(key, "default")
+	require.False(notify)
+	_, notify = breaker.Open(key, "production")
+	require.True(notify)
+
+	// The calls of other accounts, regions and services are not stopped
+	after, _ = breaker.Open(circuitbreaker.Key{Account: "111111111111", Region: "us-east-1", Service: "s3"}, "default")
+	require.Zero(after)
+
+	// The calls failing while the circuit is open do not extend the cool-down
+	now = now.Add(30 * time.Second)
+	require.False(breaker.Record(key, throttled))
+	after, _ = breaker.Open(key, "default")
+	require.Equal(30*time.Second, after)
+
+	// The first call after the cool-down trips the circuit again if it fails
+	now = now.Add(30 * time.Second)
+	after, _ = breaker.Open(key, "default")
+	require.Zero(after)
+	require.True(breaker.Record(key, throttled))
+	after, notify = breaker.Open(key, "default")
+	require.Equal(time.Minute, after)
+	require.True(notify)
+
+	// and closes it if it succeeds
+	now = now.Add(time.Minute)
+	require.False(breaker.Record(key, nil))
+	require.False(breaker.Record(key, throttled))
+	after, _ = breaker.Open(key, "default")
+	require.Zero(after)
+	require.Len(trips, 2)
+}
+
+func TestBreaker_Middleware(t *testing.T) {
+	require := require.New(t)
+	key := circuitbreaker.Key{Account: "111111111111", Region: "us-west-2", Service: "s3"}
+
+	breaker := circuitbreaker.NewBreaker(2, time.Minute)
+	stack := middleware.NewStack("test", func() interface{} { return nil })
+	require.Nil(breaker.Middleware(key)(stack))
+
+	calls := 0
+	handler := middleware.DecorateHandler(
+		middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+			calls++
+			return nil, middleware.Metadata{}, responseError(http.StatusServiceUnavailable)
+		}),
+		stack,
+	)
+	for i := 0; i < 2; i++ {
+		_, _, err := handler.Handle(context.Background(), nil)
+		require.NotNil(err)
+	}
+	require.Equal(2, calls)
+
+	// The circuit is open, hence the next call is not sent
+	_, _, err := handler.Handle(context.Background(), nil)
+	require.True(errors.Is(err, circuitbreaker.ErrOpen))
+	require.Equal(2, calls)
+}
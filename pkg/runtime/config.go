@@ -23,9 +23,11 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/rolecreds"
 )
 
 const appName = "aws-controllers-k8s"
@@ -59,15 +61,20 @@ func (c *serviceController) NewAWSConfig(
 	)
 
 	client := &clientWithUserAgent{
-		client:    &http.Client{},
+		client:    &http.Client{Transport: c.httpTransport},
 		userAgent: val,
 	}
 
-	awsCfg, err := config.LoadDefaultConfig(
-		ctx,
+	options := append([]func(*config.LoadOptions) error{
 		config.WithRegion(string(region)),
 		config.WithHTTPClient(client),
-	)
+	}, c.awsConfigOptions...)
+	if c.metrics != nil {
+		options = append(options, config.WithAPIOptions([]func(*middleware.Stack) error{
+			c.metrics.AWSAPICallMiddleware(),
+		}))
+	}
+	awsCfg, err := config.LoadDefaultConfig(ctx, options...)
 	if err != nil {
 		return awsCfg, err
 	}
@@ -77,9 +84,20 @@ func (c *serviceController) NewAWSConfig(
 	}
 
 	if roleARN != "" {
-		client := sts.NewFromConfig(awsCfg)
-		creds := stscreds.NewAssumeRoleProvider(client, string(roleARN))
-		awsCfg.Credentials = aws.NewCredentialsCache(creds)
+		newProvider := func() aws.CredentialsProvider {
+			client := sts.NewFromConfig(awsCfg)
+			creds := stscreds.NewAssumeRoleProvider(client, string(roleARN))
+			return aws.NewCredentialsCache(creds)
+		}
+		if c.roleCredentials != nil {
+			key := rolecreds.Key{RoleARN: string(roleARN), Region: awsCfg.Region}
+			if awsCfg.BaseEndpoint != nil {
+				key.EndpointURL = *awsCfg.BaseEndpoint
+			}
+			awsCfg.Credentials = c.roleCredentials.ProviderFor(key, newProvider)
+		} else {
+			awsCfg.Credentials = newProvider()
+		}
 	}
 	return awsCfg, nil
 }
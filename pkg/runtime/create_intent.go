@@ -0,0 +1,173 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// getFinalizerPolicy returns the finalizer policy of the supplied resource:
+// the one of its annotation, else the one of its kind, else the one of the
+// controller configuration
+func (r *resourceReconciler) getFinalizerPolicy(
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+) ackv1alpha1.FinalizerPolicy {
+	policy, ok := res.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationFinalizerPolicy]
+	if ok {
+		return ackv1alpha1.FinalizerPolicy(policy)
+	}
+	if describer, ok := rm.(acktypes.AWSResourceFinalizerPolicyDescriber); ok {
+		if policy := describer.FinalizerPolicy(); policy != "" {
+			return policy
+		}
+	}
+	return r.cfg.FinalizerPolicy
+}
+
+// hasCreateIntent returns true if the supplied resource records an intent to
+// create its AWS resource
+func hasCreateIntent(res acktypes.AWSResource) bool {
+	_, ok := res.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationCreateIntent]
+	return ok
+}
+
+// setCreateIntent records on the supplied resource the intent to create its
+// AWS resource
+func setCreateIntent(res acktypes.AWSResource, now time.Time) {
+	mo := res.MetaObject()
+	annotations := map[string]string{}
+	for k, v := range mo.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[ackv1alpha1.AnnotationCreateIntent] = now.UTC().Format(time.RFC3339)
+	mo.SetAnnotations(annotations)
+}
+
+// clearCreateIntent removes from the supplied resource the intent to create
+// its AWS resource
+func clearCreateIntent(res acktypes.AWSResource) {
+	if !hasCreateIntent(res) {
+		return
+	}
+	mo := res.MetaObject()
+	annotations := map[string]string{}
+	for k, v := range mo.GetAnnotations() {
+		if k != ackv1alpha1.AnnotationCreateIntent {
+			annotations[k] = v
+		}
+	}
+	mo.SetAnnotations(annotations)
+}
+
+// recordCreateIntent persists, before the AWS resource of the supplied
+// resource is created, the finalizer marking the resource as managed or, if
+// its finalizer policy is "after-create", the intent to create the AWS
+// resource. It returns true if the resource was patched.
+func (r *resourceReconciler) recordCreateIntent(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+) (bool, error) {
+	if r.getFinalizerPolicy(rm, res) != ackv1alpha1.FinalizerPolicyAfterCreate {
+		if r.rd.IsManaged(res) {
+			return false, nil
+		}
+		return true, r.setResourceManaged(ctx, rm, res)
+	}
+	if hasCreateIntent(res) {
+		return false, nil
+	}
+	var err error
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("r.recordCreateIntent")
+	defer func() {
+		exit(err)
+	}()
+
+	orig := res.DeepCopy().RuntimeObject()
+	setCreateIntent(res, time.Now())
+	_, err = r.patchResourceMetadataAndSpec(ctx, rm, r.rd.ResourceFromRuntimeObject(orig), res)
+	if err != nil {
+		return false, err
+	}
+	// The intent must be persisted before the AWS resource is created
+	if err = r.flushPatchBatch(ctx); err != nil {
+		return false, err
+	}
+	rlog.Debug("recorded create intent")
+	return true, nil
+}
+
+// abandonCreateIntent removes the finalizer of the supplied resource, along
+// with the intent to create its AWS resource, once the AWS service API
+// rejected the creation
+func (r *resourceReconciler) abandonCreateIntent(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+) error {
+	if !hasCreateIntent(res) {
+		return r.setResourceUnmanaged(ctx, rm, res)
+	}
+	orig := res.DeepCopy().RuntimeObject()
+	clearCreateIntent(res)
+	r.rd.MarkUnmanaged(res)
+	_, err := r.patchResourceMetadataAndSpec(ctx, rm, r.rd.ResourceFromRuntimeObject(orig), res)
+	return err
+}
+
+// completeCreateIntent completes the interrupted creation of the AWS
+// resource of the supplied resource, found by ReadOne while the resource
+// still records the intent to create it: the controller stopped between the
+// creation and its recording. The finalizer, missing if the finalizer policy
+// is "after-create", is added and the intent is removed.
+func (r *resourceReconciler) completeCreateIntent(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	latest acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	if !hasCreateIntent(latest) {
+		return latest, nil
+	}
+	var err error
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("r.completeCreateIntent")
+	defer func() {
+		exit(err)
+	}()
+
+	orig := latest.DeepCopy()
+	clearCreateIntent(latest)
+	r.rd.MarkManaged(latest)
+	latest, err = r.patchResourceMetadataAndSpec(ctx, rm, orig, latest)
+	if err != nil {
+		return latest, err
+	}
+	if err = r.flushPatchBatch(ctx); err != nil {
+		return latest, err
+	}
+	rlog.Info("completed interrupted creation of AWS resource")
+	r.recordEvent(
+		latest.RuntimeObject(), ackevents.ReasonCreateRecovered,
+		"Completed the interrupted creation of the AWS resource",
+	)
+	return latest, nil
+}
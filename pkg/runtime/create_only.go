@@ -0,0 +1,144 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// fillCreateOnlyFields fills the create-only fields of the supplied latest
+// observed state of an adopted resource that were not read back from the AWS
+// service API. The values are taken from the spec of the desired resource,
+// then from its adoption fields, keyed by their path relative to the spec
+// (e.g. "masterUserPassword"). The fields whose value is not found are
+// annotated as unknowable.
+func (r *resourceReconciler) fillCreateOnlyFields(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) acktypes.AWSResource {
+	describer, ok := rm.(acktypes.AWSResourceCreateOnlyFieldDescriber)
+	if !ok || ackcompare.IsNil(latest) {
+		return latest
+	}
+	paths := describer.CreateOnlyFields()
+	if len(paths) == 0 {
+		return latest
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	desiredObj, err := UnstructuredConverter.ToUnstructured(desired.RuntimeObject())
+	if err != nil {
+		rlog.Debug("unable to fill create-only fields", "error", err)
+		return latest
+	}
+	latestObj, err := UnstructuredConverter.ToUnstructured(latest.RuntimeObject())
+	if err != nil {
+		rlog.Debug("unable to fill create-only fields", "error", err)
+		return latest
+	}
+	// The adoption fields are optional when the spec holds the identifiers
+	adoptionFields, _ := ExtractAdoptionFields(desired)
+
+	unknowable := GetUnknowableFields(desired)
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		if value, found, _ := unstructured.NestedFieldNoCopy(latestObj, fields...); found && value != nil {
+			continue
+		}
+		var value interface{}
+		if v, found, _ := unstructured.NestedFieldNoCopy(desiredObj, fields...); found && v != nil {
+			value = k8sruntime.DeepCopyJSONValue(v)
+		} else if v, ok := adoptionFields[strings.TrimPrefix(path, "spec.")]; ok {
+			value = v
+		}
+		if value == nil {
+			unknowable = append(unknowable, path)
+			continue
+		}
+		if err = unstructured.SetNestedField(latestObj, value, fields...); err != nil {
+			rlog.Debug("unable to fill create-only field", "field", path, "error", err)
+			unknowable = append(unknowable, path)
+		}
+	}
+
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(latestObj, ro); err != nil {
+		rlog.Debug("unable to fill create-only fields", "error", err)
+		return latest
+	}
+	filled := r.rd.ResourceFromRuntimeObject(ro)
+	if len(unknowable) > 0 {
+		mo := filled.MetaObject()
+		annotations := mo.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ackv1alpha1.AnnotationUnknowableFields] = strings.Join(uniqueSorted(unknowable), ",")
+		mo.SetAnnotations(annotations)
+		rlog.Info("create-only fields of the adopted resource are unknowable", "fields", unknowable)
+	}
+	return filled
+}
+
+// excludeUnknowableFields returns a copy of the supplied resource without the
+// supplied unknowable fields, so that they are excluded from drift detection
+func (r *resourceReconciler) excludeUnknowableFields(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	paths []string,
+) acktypes.AWSResource {
+	if len(paths) == 0 || ackcompare.IsNil(res) {
+		return res
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	obj, err := UnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		rlog.Debug("unable to exclude unknowable fields", "error", err)
+		return res
+	}
+	for _, path := range paths {
+		unstructured.RemoveNestedField(obj, strings.Split(path, ".")...)
+	}
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		rlog.Debug("unable to exclude unknowable fields", "error", err)
+		return res
+	}
+	return r.rd.ResourceFromRuntimeObject(ro)
+}
+
+// uniqueSorted returns the sorted unique values of the supplied strings
+func uniqueSorted(values []string) []string {
+	set := make(map[string]struct{}, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := set[value]; !ok {
+			set[value] = struct{}{}
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
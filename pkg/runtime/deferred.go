@@ -0,0 +1,192 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtscheduler "github.com/aws-controllers-k8s/runtime/pkg/runtime/scheduler"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// bindScheduler adds the scheduler of the deferred operations to the supplied
+// controller manager. The manager only runs it on the leader, which
+// schedules again the deferred operations persisted on the resources when
+// it first reconciles them.
+func (r *resourceReconciler) bindScheduler(mgr ctrlrt.Manager) error {
+	r.deferred = make(chan event.GenericEvent)
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return r.scheduler.Run(ctx, func(key ackrtscheduler.Key) {
+			r.fireDeferredOperation(ctx, key)
+		})
+	}))
+}
+
+// fireDeferredOperation triggers the reconciliation of the resource of a due
+// deferred operation
+func (r *resourceReconciler) fireDeferredOperation(
+	ctx context.Context,
+	key ackrtscheduler.Key,
+) {
+	r.enqueue(ctx, key.Namespace, key.Name)
+}
+
+// enqueue triggers the reconciliation of the resource with the supplied
+// namespace and name, blocking until the controller accepts the request or
+// the supplied context is done. It returns whether the request was accepted.
+func (r *resourceReconciler) enqueue(
+	ctx context.Context,
+	namespace string,
+	name string,
+) bool {
+	obj, ok := r.rd.EmptyRuntimeObject().(client.Object)
+	if !ok || r.deferred == nil {
+		return false
+	}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	select {
+	case r.deferred <- event.GenericEvent{Object: obj}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// getDeferredOperations returns the deferred operations persisted on the
+// supplied resource
+func getDeferredOperations(
+	res acktypes.AWSResource,
+) (map[ackrtscheduler.Operation]time.Time, error) {
+	value, ok := res.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationDeferredOperations]
+	if !ok || value == "" {
+		return map[ackrtscheduler.Operation]time.Time{}, nil
+	}
+	return ackrtscheduler.Decode(value)
+}
+
+// setDeferredOperations persists the supplied deferred operations on the
+// supplied resource
+func setDeferredOperations(
+	res acktypes.AWSResource,
+	operations map[ackrtscheduler.Operation]time.Time,
+) {
+	mo := res.MetaObject()
+	annotations := mo.GetAnnotations()
+	if len(operations) == 0 {
+		if _, ok := annotations[ackv1alpha1.AnnotationDeferredOperations]; ok {
+			delete(annotations, ackv1alpha1.AnnotationDeferredOperations)
+			mo.SetAnnotations(annotations)
+		}
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ackv1alpha1.AnnotationDeferredOperations] = ackrtscheduler.Encode(operations)
+	mo.SetAnnotations(annotations)
+}
+
+// deferredOperationKey returns the scheduler key of an operation deferred
+// for the supplied resource
+func deferredOperationKey(
+	res acktypes.AWSResource,
+	op ackrtscheduler.Operation,
+) ackrtscheduler.Key {
+	mo := res.MetaObject()
+	return ackrtscheduler.Key{
+		Namespace: mo.GetNamespace(),
+		Name:      mo.GetName(),
+		Operation: op,
+	}
+}
+
+// restoreDeferredOperations schedules the deferred operations persisted on
+// the supplied resource that are not scheduled yet, e.g. after a leader
+// change.
+func (r *resourceReconciler) restoreDeferredOperations(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) {
+	operations, err := getDeferredOperations(res)
+	if err != nil {
+		rlog := ackrtlog.FromContext(ctx)
+		rlog.Info("ignoring deferred operations", "error", err)
+		return
+	}
+	for op, at := range operations {
+		key := deferredOperationKey(res, op)
+		if _, ok := r.scheduler.Scheduled(key); !ok {
+			r.scheduler.Schedule(key, at)
+		}
+	}
+}
+
+// deferOperation schedules the reconciliation of the supplied resource at
+// the supplied time for the supplied operation, and persists the operation in
+// the resource annotations. Callers are responsible for patching the
+// resource metadata.
+func (r *resourceReconciler) deferOperation(
+	res acktypes.AWSResource,
+	op ackrtscheduler.Operation,
+	at time.Time,
+) error {
+	operations, err := getDeferredOperations(res)
+	if err != nil {
+		return err
+	}
+	operations[op] = at
+	setDeferredOperations(res, operations)
+	r.scheduler.Schedule(deferredOperationKey(res, op), at)
+	return nil
+}
+
+// deferredOperationDue returns whether the supplied operation was deferred
+// for the supplied resource and is now due
+func deferredOperationDue(
+	res acktypes.AWSResource,
+	op ackrtscheduler.Operation,
+) bool {
+	operations, err := getDeferredOperations(res)
+	if err != nil {
+		return false
+	}
+	at, ok := operations[op]
+	return ok && !at.After(time.Now())
+}
+
+// completeDeferredOperation removes the supplied operation from the schedule
+// and from the resource annotations. Callers are responsible for patching
+// the resource metadata.
+func (r *resourceReconciler) completeDeferredOperation(
+	res acktypes.AWSResource,
+	op ackrtscheduler.Operation,
+) error {
+	operations, err := getDeferredOperations(res)
+	if err != nil {
+		return err
+	}
+	delete(operations, op)
+	setDeferredOperations(res, operations)
+	r.scheduler.Cancel(deferredOperationKey(res, op))
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// dependencyRepairInterval is the minimum interval between two
+	// reconciliations of the same referenced resource triggered by its
+	// dependents, so that a referenced resource failing to sync keeps its
+	// backoff
+	dependencyRepairInterval = 30 * time.Second
+	// dependencyRepairPriority is the priority of the reconciliations of the
+	// referenced resources triggered by their dependents, when the manager
+	// uses the controller-runtime priority queue
+	dependencyRepairPriority = 100
+	// dependencyRepairQueueSize is the number of reconciliations triggered
+	// by dependents the reconciler accepts before dropping them
+	dependencyRepairQueueSize = 100
+)
+
+// dependencyRepair triggers the reconciliation of the resources of the kind
+// of a reconciler that resources waiting on them reference, ahead of the
+// other resources, so that chains of dependencies resolve depth-first
+// instead of in arbitrary order.
+type dependencyRepair struct {
+	sync.Mutex
+	// shared is the shared binding of the manager, holding the reconcilers
+	// of the referenced kinds
+	shared *sharedBinding
+	// requests receives the referenced resources to reconcile
+	requests chan event.GenericEvent
+	// repaired holds when the reconciliation of the referenced resources was
+	// last triggered
+	repaired map[types.NamespacedName]time.Time
+}
+
+// bindDependencyRepair sets up the reconciliations of the resources of the
+// kind of the reconciler triggered by their dependents, and returns their
+// watch source
+func (r *resourceReconciler) bindDependencyRepair(mgr ctrlrt.Manager) source.Source {
+	r.repair = &dependencyRepair{
+		shared:   sharedBindingFor(mgr),
+		requests: make(chan event.GenericEvent, dependencyRepairQueueSize),
+		repaired: map[types.NamespacedName]time.Time{},
+	}
+	return source.Channel(r.repair.requests, dependencyRepairHandler())
+}
+
+// dependencyRepairHandler returns the handler adding the referenced resources
+// to the work queue of their reconciler, with a high priority if the queue is
+// a priority queue. Otherwise they are added immediately, skipping their
+// backoff.
+func dependencyRepairHandler() handler.EventHandler {
+	return handler.Funcs{
+		GenericFunc: func(
+			_ context.Context,
+			e event.GenericEvent,
+			q workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.Object)}
+			if pq, ok := q.(priorityqueue.PriorityQueue[reconcile.Request]); ok {
+				pq.AddWithOpts(priorityqueue.AddOpts{Priority: dependencyRepairPriority}, req)
+				return
+			}
+			q.Add(req)
+		},
+	}
+}
+
+// repairDependencies triggers the reconciliation of the referenced resources
+// not synced yet that the supplied error, returned by the resolution of the
+// references of the supplied resource, reports
+func (r *resourceReconciler) repairDependencies(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	err error,
+) {
+	refs := ackerr.ResourceReferencesNotSynced(err)
+	if len(refs) == 0 || r.repair == nil {
+		return
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	for _, ref := range refs {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = res.MetaObject().GetNamespace()
+		}
+		key := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+		for _, referenced := range r.repair.reconcilersOf(ref.Resource) {
+			if referenced.repair.trigger(referenced.rd, key) {
+				rlog.Debug("hastening the reconciliation of the referenced resource", "kind", ref.Resource, "name", key.String())
+			}
+		}
+	}
+}
+
+// reconcilersOf returns the bound reconcilers of the supplied kind, of all
+// the service controllers bound to the manager
+func (d *dependencyRepair) reconcilersOf(kind string) []*resourceReconciler {
+	reconcilers := []*resourceReconciler{}
+	for _, c := range d.shared.serviceControllers(nil) {
+		for _, rec := range c.reconcilers {
+			r, ok := rec.(*resourceReconciler)
+			if ok && r.repair != nil && strings.EqualFold(r.rd.GroupVersionKind().Kind, kind) {
+				reconcilers = append(reconcilers, r)
+			}
+		}
+	}
+	return reconcilers
+}
+
+// trigger sends the resource with the supplied key to the work queue of the
+// reconciler, unless its reconciliation was triggered less than
+// dependencyRepairInterval ago or too many are pending. It returns whether
+// the reconciliation was triggered.
+func (d *dependencyRepair) trigger(
+	rd acktypes.AWSResourceDescriptor,
+	key types.NamespacedName,
+) bool {
+	d.Lock()
+	defer d.Unlock()
+	now := time.Now()
+	if last, ok := d.repaired[key]; ok && now.Sub(last) < dependencyRepairInterval {
+		return false
+	}
+	obj, ok := rd.EmptyRuntimeObject().(client.Object)
+	if !ok {
+		return false
+	}
+	obj.SetNamespace(key.Namespace)
+	obj.SetName(key.Name)
+	select {
+	case d.requests <- event.GenericEvent{Object: obj}:
+	default:
+		return false
+	}
+	for k, last := range d.repaired {
+		if now.Sub(last) >= dependencyRepairInterval {
+			delete(d.repaired, k)
+		}
+	}
+	d.repaired[key] = now
+	return true
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sjson "k8s.io/apimachinery/pkg/util/json"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// deriveStatus returns a copy of the supplied latest observed resource with
+// the derived status fields of the resource manager computed again. If the
+// resource manager doesn't implement AWSResourceStatusDeriver, the supplied
+// resource is returned as is. The fields whose computation fails keep their
+// previous value.
+func (r *resourceReconciler) deriveStatus(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	latest acktypes.AWSResource,
+) acktypes.AWSResource {
+	deriver, ok := rm.(acktypes.AWSResourceStatusDeriver)
+	if !ok || ackcompare.IsNil(latest) {
+		return latest
+	}
+	fields := deriver.DerivedStatusFields()
+	if len(fields) == 0 {
+		return latest
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	obj, err := UnstructuredConverter.ToUnstructured(latest.RuntimeObject())
+	if err != nil {
+		rlog.Debug("unable to derive status fields", "error", err)
+		return latest
+	}
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := setDerivedField(obj, path, fields[path], latest); err != nil {
+			rlog.Info("unable to derive status field", "field", path, "error", err)
+		}
+	}
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		rlog.Debug("unable to derive status fields", "error", err)
+		return latest
+	}
+	return r.rd.ResourceFromRuntimeObject(ro)
+}
+
+// setDerivedField sets, in place, the status field with the supplied path of
+// the supplied unstructured resource to the value computed from the supplied
+// resource, or removes it if the value is nil
+func setDerivedField(
+	obj map[string]interface{},
+	path string,
+	derive acktypes.DerivedStatusField,
+	res acktypes.AWSResource,
+) error {
+	value, err := derive(res)
+	if err != nil {
+		return err
+	}
+	fields := append([]string{"status"}, strings.Split(path, ".")...)
+	if value == nil {
+		k8sunstructured.RemoveNestedField(obj, fields...)
+		return nil
+	}
+	// Round trip the value through JSON so that it only holds the types of
+	// the unstructured representation of the resources
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var unstructured interface{}
+	if err = k8sjson.Unmarshal(encoded, &unstructured); err != nil {
+		return err
+	}
+	return k8sunstructured.SetNestedField(obj, unstructured, fields...)
+}
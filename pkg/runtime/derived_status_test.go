@@ -0,0 +1,133 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	k8sobj "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	ctrlrtclientmock "github.com/aws-controllers-k8s/runtime/mocks/controller-runtime/pkg/client"
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// derivingResourceManager is a resource manager deriving status fields
+type derivingResourceManager struct {
+	*ackmocks.AWSResourceManager
+	*ackmocks.AWSResourceStatusDeriver
+}
+
+func TestReconcilerSync_DerivedStatus(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	// Other tests replace the converter with a mock
+	conv := ackrt.UnstructuredConverter
+	ackrt.UnstructuredConverter = k8sruntime.DefaultUnstructuredConverter
+	defer func() { ackrt.UnstructuredConverter = conv }()
+
+	desired := unstructuredResourceMock()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+	latest := unstructuredResourceMock()
+	latest.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	latest.On("ReplaceConditions", mock.Anything).Return()
+	latestObj := latest.RuntimeObject().(*k8sobj.Unstructured)
+	require.Nil(k8sobj.SetNestedField(latestObj.Object, "mybook.example.com", "status", "host"))
+	require.Nil(k8sobj.SetNestedField(latestObj.Object, "stale", "status", "previous"))
+	require.Nil(k8sobj.SetNestedField(latestObj.Object, "kept", "status", "failing"))
+
+	baseRM := &ackmocks.AWSResourceManager{}
+	baseRM.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+	baseRM.On("ClearResolvedReferences", mock.Anything).Return(func(res acktypes.AWSResource) acktypes.AWSResource {
+		return res
+	})
+	baseRM.On("ReadOne", ctx, desired).Return(latest, nil)
+	baseRM.On("IsSynced", ctx, latest).Return(true, nil)
+	baseRM.On("LateInitialize", ctx, latest).Return(latest, nil)
+
+	deriver := &ackmocks.AWSResourceStatusDeriver{}
+	deriver.On("DerivedStatusFields").Return(map[string]acktypes.DerivedStatusField{
+		"endpoint.url": func(res acktypes.AWSResource) (interface{}, error) {
+			host, _, _ := k8sobj.NestedString(res.RuntimeObject().(*k8sobj.Unstructured).Object, "status", "host")
+			return "https://" + host, nil
+		},
+		"endpoint.port": func(acktypes.AWSResource) (interface{}, error) {
+			return 443, nil
+		},
+		"previous": func(acktypes.AWSResource) (interface{}, error) {
+			return nil, nil
+		},
+		"failing": func(acktypes.AWSResource) (interface{}, error) {
+			return nil, errors.New("unable to derive")
+		},
+	})
+	rm := &derivingResourceManager{baseRM, deriver}
+
+	derived := unstructuredResourceMock()
+	rd := &ackmocks.AWSResourceDescriptor{}
+	rd.On("GroupVersionKind").Return(latestObj.GroupVersionKind())
+	rd.On("IsManaged", mock.Anything).Return(true)
+	rd.On("Delta", mock.Anything, mock.Anything).Return(ackcompare.NewDelta())
+	derivedObj := &k8sobj.Unstructured{}
+	rd.On("EmptyRuntimeObject").Return(derivedObj)
+	rd.On("ResourceFromRuntimeObject", derivedObj).Return(derived)
+	rmf := &ackmocks.AWSResourceManagerFactory{}
+	rmf.On("ResourceDescriptor").Return(rd)
+	rmf.On("RequeueOnSuccessSeconds").Return(0)
+
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	sc := &ackmocks.ServiceController{}
+	scmd := acktypes.ServiceControllerMetadata{}
+	sc.On("GetMetadata").Return(scmd)
+	kc := &ctrlrtclientmock.Client{}
+	kc.On("Patch", withoutCancelContextMatcher, mock.Anything, mock.Anything).Return(nil)
+	r := ackrt.NewReconcilerWithClient(
+		sc, kc, rmf, ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions)),
+		ackcfg.Config{}, ackmetrics.NewMetrics("bookstore"), ackrtcache.Caches{},
+	)
+	baseRM.On("EnsureTags", ctx, desired, scmd).Return(nil)
+
+	// The derived fields are computed from the latest observed state, the
+	// ones whose computation fails keeping their value
+	res, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	require.Equal(derived, res)
+	status, _, _ := k8sobj.NestedMap(derivedObj.Object, "status")
+	require.Equal(map[string]interface{}{
+		"host": "mybook.example.com",
+		"endpoint": map[string]interface{}{
+			"url":  "https://mybook.example.com",
+			"port": int64(443),
+		},
+		"failing": "kept",
+	}, status)
+}
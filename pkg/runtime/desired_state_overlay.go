@@ -0,0 +1,90 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtoverlay "github.com/aws-controllers-k8s/runtime/pkg/runtime/overlay"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// newDesiredStateOverlay returns the break-glass desired state overlay
+// configured with the --desired-state-overlay-file flag, or nil
+func newDesiredStateOverlay(cfg ackcfg.Config) *ackrtoverlay.Overlay {
+	if cfg.DesiredStateOverlayFile == "" {
+		return nil
+	}
+	return ackrtoverlay.New(cfg.DesiredStateOverlayFile)
+}
+
+// applyDesiredStateOverlay returns a copy of the supplied desired resource
+// with the break-glass overlay of the resource, if any, applied to its spec.
+// Applied overlays are reported with a Warning event on the resource, and
+// their fields are never persisted in the spec of the custom resource.
+//
+// Invalid overlays are ignored, so that a broken overlay file does not stop
+// the reconciliation of all resources.
+func (r *resourceReconciler) applyDesiredStateOverlay(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+) acktypes.AWSResource {
+	if r.overlay == nil {
+		return desired
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	mo := desired.MetaObject()
+	key := ackrtoverlay.Key(r.rd.GroupVersionKind().Kind, mo.GetNamespace(), mo.GetName())
+
+	obj, err := UnstructuredConverter.ToUnstructured(desired.RuntimeObject())
+	if err != nil {
+		rlog.Info("unable to apply desired state overlay", "error", err)
+		return desired
+	}
+	spec, _ := json.Marshal(obj["spec"])
+	patched, applied, err := r.overlay.Apply(key, spec)
+	if err != nil {
+		rlog.Info("unable to apply desired state overlay", "error", err)
+		return desired
+	}
+	if !applied {
+		return desired
+	}
+	var patchedSpec interface{}
+	if err = json.Unmarshal(patched, &patchedSpec); err != nil {
+		rlog.Info("unable to apply desired state overlay", "error", err)
+		return desired
+	}
+	obj["spec"] = patchedSpec
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		rlog.Info("unable to apply desired state overlay", "error", err)
+		return desired
+	}
+
+	rlog.Info("applied break-glass desired state overlay", "file", r.overlay.Path())
+	r.recordEvent(
+		desired.RuntimeObject(), ackevents.ReasonDesiredStateOverlaid,
+		fmt.Sprintf(
+			"Applied the break-glass overlay of %s from %s on top of the desired state: %s",
+			key, r.overlay.Path(), patched,
+		),
+	)
+	return r.rd.ResourceFromRuntimeObject(ro)
+}
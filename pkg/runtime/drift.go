@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrtdrift "github.com/aws-controllers-k8s/runtime/pkg/runtime/drift"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// driftSummary returns the human-readable differences of the spec of the
+// supplied delta between the desired and the latest observed states of a
+// resource, with the values of the sensitive fields of the kind of the
+// reconciler redacted
+func (r *resourceReconciler) driftSummary(delta *ackcompare.Delta) []string {
+	return ackrtdrift.Summary(r.rd.GroupVersionKind().GroupKind(), delta.Differences)
+}
+
+// recordLastDrift returns a copy of the supplied resource whose
+// status.ackResourceMetadata.lastDrift field describes the supplied changes,
+// if the --record-last-drift flag is set
+func (r *resourceReconciler) recordLastDrift(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	changes []string,
+) acktypes.AWSResource {
+	if !r.cfg.RecordLastDrift || ackcompare.IsNil(res) || len(changes) == 0 {
+		return res
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	obj, err := UnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		rlog.Debug("unable to record last drift", "error", err)
+		return res
+	}
+	detectedAt, err := metav1.Now().MarshalQueryParameter()
+	if err != nil {
+		rlog.Debug("unable to record last drift", "error", err)
+		return res
+	}
+	recorded, err := ackrtdrift.Record(obj, changes, detectedAt)
+	if err != nil {
+		rlog.Debug("unable to record last drift", "error", err)
+		return res
+	}
+	if !recorded {
+		return res
+	}
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		rlog.Debug("unable to record last drift", "error", err)
+		return res
+	}
+	return r.rd.ResourceFromRuntimeObject(ro)
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package drift summarizes the differences between the desired and the latest
+// observed states of the resources, for the events and the
+// status.ackResourceMetadata.lastDrift field of the drifted resources.
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/redact"
+)
+
+// ValueMaxLength is the maximum length of the values of the fields in the
+// summaries, longer values being truncated
+const ValueMaxLength = 64
+
+// Summary returns the human-readable differences of the spec of a resource
+// of the supplied kind, between its desired and its latest observed states,
+// e.g. `Spec.Versioning: "Suspended" => "Enabled"`. The values of the
+// sensitive fields are redacted, as are the values of the fields containing
+// sensitive fields whose nested values could not be redacted.
+func Summary(gk schema.GroupKind, diffs []*ackcompare.Difference) []string {
+	changes := []string{}
+	for _, diff := range diffs {
+		path := diff.Path.String()
+		if !diff.Path.Contains("Spec") {
+			continue
+		}
+		if redact.IsSensitive(gk, path) {
+			changes = append(changes, fmt.Sprintf("%s: %s", path, redact.Redacted))
+			continue
+		}
+		changes = append(changes, fmt.Sprintf(
+			"%s: %s => %s", path, value(gk, path, diff.B), value(gk, path, diff.A),
+		))
+	}
+	return changes
+}
+
+// Record records, in the `status.ackResourceMetadata.lastDrift` field of the
+// supplied resource, as unstructured content, the supplied changes detected
+// at the supplied time. It returns false if the resource has no resource
+// metadata in its status.
+func Record(obj map[string]interface{}, changes []string, detectedAt string) (bool, error) {
+	if _, found, _ := k8sunstructured.NestedMap(obj, "status", "ackResourceMetadata"); !found {
+		return false, nil
+	}
+	items := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		items = append(items, change)
+	}
+	err := k8sunstructured.SetNestedMap(obj, map[string]interface{}{
+		"detectedAt": detectedAt,
+		"changes":    items,
+	}, "status", "ackResourceMetadata", "lastDrift")
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// value returns the encoding of the supplied value of the field with the
+// supplied path, with its nested sensitive fields redacted. The whole value
+// is redacted if the field contains sensitive fields but none of them could
+// be redacted, e.g. if the JSON names of the nested fields differ from their
+// registered paths.
+func value(gk schema.GroupKind, path string, v interface{}) string {
+	redacted := encode(redact.Value(gk, path, v))
+	if v != nil && redact.HasSensitiveFields(gk, path) && !strings.Contains(redacted, redact.Redacted) {
+		redacted = encode(redact.Redacted)
+	}
+	if len(redacted) > ValueMaxLength {
+		return redacted[:ValueMaxLength] + "..."
+	}
+	return redacted
+}
+
+// encode returns the JSON encoding of the supplied value
+func encode(v interface{}) string {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(encoded)
+}
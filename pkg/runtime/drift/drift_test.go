@@ -0,0 +1,158 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package drift_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/drift"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/redact"
+)
+
+var dbCluster = schema.GroupKind{Group: "rds.services.k8s.aws", Kind: "DBCluster"}
+
+func init() {
+	redact.Register(dbCluster, "spec.masterUserPassword", "spec.credentials.secretKey", "spec.auth.token")
+}
+
+type credentials struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+// auth has a sensitive nested field whose JSON name differs from its
+// registered path
+type auth struct {
+	User  string `json:"user"`
+	Token string `json:"authToken"`
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		diff *ackcompare.Difference
+		want []string
+	}{{
+		name: "spec field",
+		diff: &ackcompare.Difference{Path: ackcompare.NewPath("Spec.Versioning"), A: "Enabled", B: "Suspended"},
+		want: []string{`Spec.Versioning: "Suspended" => "Enabled"`},
+	}, {
+		name: "status field",
+		diff: &ackcompare.Difference{Path: ackcompare.NewPath("Status.State"), A: "available", B: "creating"},
+		want: []string{},
+	}, {
+		name: "sensitive field",
+		diff: &ackcompare.Difference{Path: ackcompare.NewPath("Spec.MasterUserPassword"), A: "new-password", B: "old-password"},
+		want: []string{"Spec.MasterUserPassword: REDACTED"},
+	}, {
+		name: "parent of a sensitive field",
+		diff: &ackcompare.Difference{
+			Path: ackcompare.NewPath("Spec.Credentials"),
+			A:    &credentials{AccessKey: "AKIA2", SecretKey: "new-secret"},
+			B:    &credentials{AccessKey: "AKIA1", SecretKey: "old-secret"},
+		},
+		want: []string{
+			`Spec.Credentials: {"accessKey":"AKIA1","secretKey":"REDACTED"} => {"accessKey":"AKIA2","secretKey":"REDACTED"}`,
+		},
+	}, {
+		name: "grandparent of a sensitive field",
+		diff: &ackcompare.Difference{
+			Path: ackcompare.NewPath("Spec"),
+			A:    map[string]interface{}{"credentials": []interface{}{map[string]interface{}{"secretKey": "new-secret"}}},
+			B:    map[string]interface{}{},
+		},
+		want: []string{`Spec: "REDACTED" => {"credentials":[{"secretKey":"REDACTED"}]}`},
+	}, {
+		name: "parent of a sensitive field that cannot be redacted",
+		diff: &ackcompare.Difference{
+			Path: ackcompare.NewPath("Spec.Auth"),
+			A:    &auth{User: "admin", Token: "new-token"},
+			B:    nil,
+		},
+		want: []string{`Spec.Auth: null => "REDACTED"`},
+	}, {
+		name: "long value",
+		diff: &ackcompare.Difference{Path: ackcompare.NewPath("Spec.Description"), A: strings.Repeat("a", 100), B: ""},
+		want: []string{`Spec.Description: "" => "` + strings.Repeat("a", drift.ValueMaxLength-1) + "..."},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := drift.Summary(dbCluster, []*ackcompare.Difference{tt.diff})
+			require.Equal(t, tt.want, changes)
+			for _, change := range changes {
+				for _, secret := range []string{"old-password", "new-password", "old-secret", "new-secret", "new-token"} {
+					require.NotContains(t, change, secret)
+				}
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	changes := []string{`Spec.Versioning: "Suspended" => "Enabled"`, "Spec.MasterUserPassword: REDACTED"}
+	tests := []struct {
+		name         string
+		obj          map[string]interface{}
+		wantRecorded bool
+		wantRegion   interface{}
+	}{{
+		name: "resource metadata",
+		obj: map[string]interface{}{
+			"status": map[string]interface{}{"ackResourceMetadata": map[string]interface{}{"region": "us-west-2"}},
+		},
+		wantRecorded: true,
+		wantRegion:   "us-west-2",
+	}, {
+		name: "previous drift",
+		obj: map[string]interface{}{
+			"status": map[string]interface{}{"ackResourceMetadata": map[string]interface{}{
+				"lastDrift": map[string]interface{}{
+					"detectedAt": "2026-01-01T00:00:00Z",
+					"changes":    []interface{}{"Spec.Tags: {} => {}"},
+				},
+			}},
+		},
+		wantRecorded: true,
+	}, {
+		name: "no resource metadata",
+		obj:  map[string]interface{}{"status": map[string]interface{}{}},
+	}, {
+		name: "no status",
+		obj:  map[string]interface{}{"spec": map[string]interface{}{}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			recorded, err := drift.Record(tt.obj, changes, "2026-10-15T00:00:00Z")
+			require.NoError(err)
+			require.Equal(tt.wantRecorded, recorded)
+			if !tt.wantRecorded {
+				return
+			}
+			status := tt.obj["status"].(map[string]interface{})
+			metadata := status["ackResourceMetadata"].(map[string]interface{})
+			require.Equal(map[string]interface{}{
+				"detectedAt": "2026-10-15T00:00:00Z",
+				"changes":    []interface{}{changes[0], changes[1]},
+			}, metadata["lastDrift"])
+			require.Equal(tt.wantRegion, metadata["region"])
+		})
+	}
+}
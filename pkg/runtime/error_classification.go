@@ -0,0 +1,169 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// eventualConsistencyRequeueAfter is the delay after which a resource whose
+// reconciliation conflicted with a transient state of AWS is reconciled again
+const eventualConsistencyRequeueAfter = 15 * time.Second
+
+// errorClassificationFile is the error classification file of the
+// --error-classification-file flag, read again whenever its modification time
+// changes. A nil errorClassificationFile classifies nothing.
+type errorClassificationFile struct {
+	sync.Mutex
+	path     string
+	modTime  time.Time
+	registry *ackerr.ClassificationRegistry
+}
+
+// newErrorClassificationFile returns the error classification file
+// configured with the --error-classification-file flag, or nil
+func newErrorClassificationFile(cfg ackcfg.Config) *errorClassificationFile {
+	if cfg.ErrorClassificationFile == "" {
+		return nil
+	}
+	return &errorClassificationFile{path: cfg.ErrorClassificationFile}
+}
+
+// load returns the classifications of the file, reading it again if it
+// changed since it was last read. A missing file has no classifications, so
+// that it can be mounted from an optional ConfigMap. When the file cannot be
+// read or parsed, the classifications last read are returned along with the
+// error.
+func (f *errorClassificationFile) load() (*ackerr.ClassificationRegistry, error) {
+	if f == nil {
+		return nil, nil
+	}
+	f.Lock()
+	defer f.Unlock()
+	info, err := os.Stat(f.path)
+	if os.IsNotExist(err) {
+		f.registry, f.modTime = nil, time.Time{}
+		return nil, nil
+	}
+	if err != nil {
+		return f.registry, err
+	}
+	if f.registry != nil && info.ModTime().Equal(f.modTime) {
+		return f.registry, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return f.registry, err
+	}
+	registry, err := ackerr.ParseClassificationRegistry(data)
+	if err != nil {
+		return f.registry, err
+	}
+	f.registry, f.modTime = registry, info.ModTime()
+	return registry, nil
+}
+
+// classifyError returns the class of the supplied reconciliation error, from
+// the classifications of the --error-classification-file flag, then the ones
+// registered by the service controller, then the built-in ones. For the
+// errors classified as ClassificationRequeue, it also returns the delay after
+// which the resource is reconciled again.
+func (r *resourceReconciler) classifyError(
+	ctx context.Context,
+	err error,
+) (ackerr.Classification, time.Duration) {
+	registry, loadErr := r.errorClassifications.load()
+	if loadErr != nil {
+		rlog := ackrtlog.FromContext(ctx)
+		rlog.Info("unable to read error classification file", "error", loadErr)
+	}
+	kind := r.rd.GroupVersionKind().Kind
+	class := ackerr.ClassifyFor(kind, err, registry)
+	if class != ackerr.ClassificationRequeue {
+		return class, 0
+	}
+	if after, ok := ackerr.RequeueAfterFor(kind, err, registry); ok {
+		return class, after
+	}
+	return class, eventualConsistencyRequeueAfter
+}
+
+// isRequeueError returns true if the supplied error already tells the
+// reconciler when to requeue the resource
+func isRequeueError(err error) bool {
+	var requeueNeeded *requeue.RequeueNeeded
+	var requeueNeededAfter *requeue.RequeueNeededAfter
+	var requeueNeededWithBackoff *requeue.RequeueNeededWithBackoff
+	return errors.As(err, &requeueNeeded) ||
+		errors.As(err, &requeueNeededAfter) ||
+		errors.As(err, &requeueNeededWithBackoff)
+}
+
+// handleClassifiedError decides how the reconciliation of the supplied
+// resource that failed with the supplied error is retried, from the
+// classification of the error, which operators and service controllers can
+// tune per error code and resource kind:
+//
+//   - transient faults are retried with backoff
+//   - conflicts with a transient state of AWS are retried after a delay,
+//     which can be set per error code
+//   - terminal errors set the Terminal condition of the resource, which is
+//     not reconciled again before its next resync or change
+//
+// Errors that already tell when to requeue the resource, and errors of
+// unknown classes, are returned as is.
+func (r *resourceReconciler) handleClassifiedError(
+	ctx context.Context,
+	latest acktypes.AWSResource,
+	err error,
+) error {
+	if err == nil || err == ackerr.Terminal || isRequeueError(err) {
+		return err
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	class, requeueAfter := r.classifyError(ctx, err)
+	switch class {
+	case ackerr.ClassificationRetryable:
+		return requeue.NeededWithBackoff(err, requeue.DefaultBackoff)
+	case ackerr.ClassificationRequeue:
+		return requeue.NeededAfter(err, requeueAfter)
+	case ackerr.ClassificationTerminal:
+		if ackcompare.IsNil(latest) {
+			return err
+		}
+		rlog.Info("reconciliation failed with a terminal error", "error", err)
+		if terminal := ackcondition.Terminal(latest); terminal == nil || terminal.Status != corev1.ConditionTrue {
+			message := err.Error()
+			ackcondition.SetTerminal(latest, corev1.ConditionTrue, &message, nil)
+		}
+		reason := err.Error()
+		ackcondition.SetSynced(latest, corev1.ConditionFalse, &ackcondition.NotSyncedMessage, &reason)
+		return ackerr.Terminal
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+)
+
+// eventDeduplicationWindow is the duration during which the events identical
+// to an event emitted on a resource are dropped
+const eventDeduplicationWindow = 5 * time.Minute
+
+// newEventRecorder returns the recorder of the events of the reconciler,
+// emitted through the supplied manager, and also written as log lines with
+// --log-format=json
+func (r *reconciler) newEventRecorder(mgr ctrlrt.Manager) *ackevents.Recorder {
+	recorder := ackevents.NewRecorder(mgr.GetEventRecorderFor(r.eventSource())).
+		WithDeduplication(eventDeduplicationWindow)
+	if r.cfg.LogFormat == ackcfg.LogFormatJSON {
+		recorder.WithLogger(r.log.WithName("events"))
+	}
+	return recorder
+}
+
+// eventSource returns the name of the component emitting the events of the
+// service controller, e.g. "ack-s3-controller"
+func (r *reconciler) eventSource() string {
+	return "ack-" + r.sc.GetMetadata().ServiceAlias + "-controller"
+}
+
+// recordEvent emits an event with a registered reason on the supplied object,
+// if the reconciler has been bound to a controller manager.
+func (r *reconciler) recordEvent(
+	obj runtime.Object,
+	reason ackevents.Reason,
+	message string,
+) {
+	if r.recorder == nil {
+		return
+	}
+	message = r.redactMessage(obj, message)
+	if info, ok := ackevents.Lookup(reason); ok && info.Type == corev1.EventTypeWarning {
+		if mo, ok := obj.(metav1.Object); ok && r.eventFingerprints.seen(mo, reason, message) {
+			return
+		}
+	}
+	r.recorder.Event(obj, reason, message)
+}
+
+// recordNamespaceEvent emits an event with a registered reason on the
+// supplied namespace
+func (r *reconciler) recordNamespaceEvent(
+	ctx context.Context,
+	namespace string,
+	reason ackevents.Reason,
+	message string,
+) {
+	if r.recorder == nil || r.apiReader == nil {
+		return
+	}
+	ns := &corev1.Namespace{}
+	if err := r.apiReader.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		rlog := ackrtlog.FromContext(ctx)
+		rlog.Debug("unable to get namespace to record event", "error", err)
+		return
+	}
+	r.recorder.Event(ns, reason, message)
+}
+
+// deltaPaths returns the comma separated paths of the differences in the
+// supplied Delta, used in event messages
+func deltaPaths(delta *ackcompare.Delta) string {
+	paths := make([]string, 0, len(delta.Differences))
+	for _, diff := range delta.Differences {
+		paths = append(paths, diff.Path.String())
+	}
+	return strings.Join(paths, ", ")
+}
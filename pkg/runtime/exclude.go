@@ -0,0 +1,42 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// excluded returns true if the supplied labels match one of the
+// --reconcile-exclude-selector or --reconcile-resource-exclude-selector
+// selectors of the kind of the reconciler. Excluded resources are owned by
+// another instance of the controller, and are left untouched: the reconciler
+// neither adds a finalizer to them nor sets their conditions.
+func (r *resourceReconciler) excluded(resourceLabels map[string]string) bool {
+	for _, selector := range r.excludeSelectors {
+		if selector.Matches(labels.Set(resourceLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludePredicate returns the predicate filtering out the events of the
+// excluded resources, so that they are not even enqueued
+func (r *resourceReconciler) excludePredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return !r.excluded(obj.GetLabels())
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
+)
+
+// checkClusterOwnership returns a terminal error if the supplied AWS resource
+// is tagged as managed by the controller of another cluster, preventing the
+// controllers of two clusters from managing the same AWS resource.
+//
+// The check is only performed when the controller is configured with a
+// cluster identifier and the resource manager exposes the resource tags. The
+// resources of the clusters listed in --fencing-allowed-cluster-ids, and the
+// resources whose ownership is being taken over, are always allowed.
+//
+// It returns the identifier of the cluster that previously managed the
+// resource, if any.
+func checkClusterOwnership(
+	cfg ackcfg.Config,
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+	takeOver bool,
+) (string, error) {
+	if cfg.ClusterID == "" || res == nil {
+		return "", nil
+	}
+	getter, ok := rm.(acktypes.AWSResourceTagGetter)
+	if !ok {
+		return "", nil
+	}
+	owner := getter.GetTags(res)[acktags.ClusterIDTagKey]
+	if owner == "" || owner == cfg.ClusterID {
+		return "", nil
+	}
+	if takeOver || ackutil.InStrings(owner, cfg.FencingAllowedClusterIDs) {
+		return owner, nil
+	}
+	return owner, ackerr.NewTerminalError(fmt.Errorf(
+		"%w: AWS resource is tagged with %s=%s, annotate the resource with %s=true to take over its ownership",
+		ackerr.ManagedByAnotherCluster, acktags.ClusterIDTagKey, owner, ackv1alpha1.AnnotationTakeOver,
+	))
+}
+
+// ensureClusterOwnership checks that the supplied latest observed resource is
+// not managed by the controller of another cluster. Read-only resources are
+// never modified and are not checked.
+func (r *resourceReconciler) ensureClusterOwnership(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	latest acktypes.AWSResource,
+	isReadOnly bool,
+) error {
+	if isReadOnly {
+		return nil
+	}
+	owner, err := checkClusterOwnership(r.cfg, rm, latest, IsTakeOver(latest))
+	if err != nil {
+		return err
+	}
+	if owner != "" {
+		rlog := ackrtlog.FromContext(ctx)
+		rlog.Info(
+			"managing AWS resource previously managed by another cluster",
+			"previous_cluster_id", owner,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	ctrlrtclientmock "github.com/aws-controllers-k8s/runtime/mocks/controller-runtime/pkg/client"
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// taggedResourceManager is a resource manager exposing the AWS tags of the
+// resources it manages
+type taggedResourceManager struct {
+	*ackmocks.AWSResourceManager
+	*ackmocks.AWSResourceTagGetter
+}
+
+func TestReconcilerUpdate_ClusterOwnership(t *testing.T) {
+	tests := []struct {
+		name        string
+		ownerTag    string
+		takeOver    bool
+		allowed     []string
+		expectFence bool
+	}{
+		{"untagged", "", false, nil, false},
+		{"same cluster", "blue", false, nil, false},
+		{"other cluster", "green", false, nil, true},
+		{"other cluster taken over", "green", true, nil, false},
+		{"allowed cluster", "green", false, []string{"green"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.TODO()
+
+			delta := ackcompare.NewDelta()
+			delta.Add("Spec.A", "val1", "val2")
+
+			desired, _, desiredMetaObj := resourceMocks()
+			desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+			if tt.takeOver {
+				desiredMetaObj.SetAnnotations(map[string]string{
+					ackv1alpha1.AnnotationTakeOver: "true",
+				})
+			}
+
+			latest, _, latestMetaObj := resourceMocks()
+			latestMetaObj.SetAnnotations(desiredMetaObj.GetAnnotations())
+			latest.On("Conditions").Return([]*ackv1alpha1.Condition{})
+			latest.On("ReplaceConditions", mock.Anything).Return()
+
+			base := &ackmocks.AWSResourceManager{}
+			base.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+			base.On("ClearResolvedReferences", desired).Return(desired)
+			base.On("ClearResolvedReferences", latest).Return(latest)
+			base.On("ReadOne", ctx, desired).Return(latest, nil)
+			base.On("Update", ctx, desired, latest, delta).Return(latest, nil)
+			base.On("LateInitialize", ctx, latest).Return(latest, nil)
+			base.On("IsSynced", ctx, latest).Return(true, nil)
+			tagGetter := &ackmocks.AWSResourceTagGetter{}
+			tagGetter.On("GetTags", latest).Return(acktags.Tags{
+				acktags.ClusterIDTagKey: tt.ownerTag,
+			})
+			tagGetter.On("GetTags", desired).Return(acktags.Tags{
+				acktags.ClusterIDTagKey: tt.ownerTag,
+			})
+			rm := &taggedResourceManager{base, tagGetter}
+
+			rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+			rd.On("IsManaged", desired).Return(true)
+			rd.On("Delta", desired, latest).Return(delta).Once()
+			rd.On("Delta", desired, latest).Return(ackcompare.NewDelta())
+			rd.On("Delta", latest, latest).Return(ackcompare.NewDelta())
+
+			zapOptions := ctrlrtzap.Options{
+				Development: true,
+				Level:       zapcore.InfoLevel,
+			}
+			cfg := ackcfg.Config{
+				ClusterID:                "blue",
+				FencingAllowedClusterIDs: tt.allowed,
+			}
+			sc := &ackmocks.ServiceController{}
+			scmd := acktypes.ServiceControllerMetadata{}
+			sc.On("GetMetadata").Return(scmd)
+			kc := &ctrlrtclientmock.Client{}
+			r := ackrt.NewReconcilerWithClient(
+				sc, kc, rmf, ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions)),
+				cfg, ackmetrics.NewMetrics("bookstore"), ackrtcache.Caches{},
+			)
+			base.On("EnsureTags", ctx, desired, scmd).Return(nil)
+
+			_, err := r.Sync(ctx, rm, desired)
+			if tt.expectFence {
+				require.NotNil(err)
+				require.True(errors.Is(err, ackerr.ManagedByAnotherCluster))
+				var terminalErr *ackerr.TerminalError
+				require.True(errors.As(err, &terminalErr))
+				base.AssertNotCalled(t, "Update", ctx, desired, latest, delta)
+				return
+			}
+			require.Nil(err)
+			base.AssertCalled(t, "Update", ctx, desired, latest, delta)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/fieldcrypt"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// fieldDecryptionRequeueDelay is the delay after which the reconciliation of
+// a resource whose sensitive fields could not be decrypted is retried
+const fieldDecryptionRequeueDelay = 30 * time.Second
+
+// newFieldEncrypter returns the encrypter of the sensitive fields of the
+// resources, using the KMS key of the --field-encryption-kms-key-id flag in
+// the region of the controller, or nil if the flag is not set
+func (c *serviceController) newFieldEncrypter(
+	ctx context.Context,
+	cfg ackcfg.Config,
+) (*fieldcrypt.Encrypter, error) {
+	if cfg.FieldEncryptionKMSKeyID == "" {
+		return nil, nil
+	}
+	options := append([]func(*config.LoadOptions) error{
+		config.WithRegion(cfg.Region),
+		config.WithHTTPClient(&http.Client{Transport: c.httpTransport}),
+	}, c.awsConfigOptions...)
+	awsCfg, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return fieldcrypt.NewEncrypter(
+		fieldcrypt.NewKMSKeyProvider(awsCfg, cfg.FieldEncryptionKMSKeyID),
+	), nil
+}
+
+// decryptSensitiveFields returns a copy of the supplied resource with the
+// encrypted values of its sensitive fields decrypted, or the resource itself
+// if none of them is encrypted. The decrypted values are never written back
+// to the custom resource: the patches of the spec only carry the fields that
+// differ from the desired resource, and the mutating webhook encrypts them
+// again anyway.
+func (r *resourceReconciler) decryptSensitiveFields(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	describer, ok := r.rmf.(acktypes.AWSResourceSensitiveFieldDescriber)
+	if !ok {
+		return res, nil
+	}
+	fields := describer.SensitiveFields()
+	if len(fields) == 0 {
+		return res, nil
+	}
+	obj, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		return res, err
+	}
+	changed, err := r.encrypter.DecryptFields(ctx, obj, fields)
+	if err != nil || !changed {
+		return res, err
+	}
+	decrypted := r.rd.EmptyRuntimeObject()
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj, decrypted); err != nil {
+		return res, err
+	}
+	return r.rd.ResourceFromRuntimeObject(decrypted), nil
+}
+
+// handleFieldDecryptionError reports, in the ResourceSynced condition of the
+// supplied resource, that its sensitive fields could not be decrypted, and
+// requeues it. The resource is not reconciled meanwhile, so that encrypted
+// values are never sent to AWS.
+func (r *resourceReconciler) handleFieldDecryptionError(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	err error,
+) (ctrlrt.Result, error) {
+	reason := fmt.Sprintf("unable to decrypt the sensitive fields of the resource: %v", err)
+	latest := desired.DeepCopy()
+	ackcondition.SetSynced(latest, corev1.ConditionFalse, &ackcondition.NotSyncedMessage, &reason)
+	return r.HandleReconcileError(ctx, desired, latest, requeue.NeededAfter(err, fieldDecryptionRequeueDelay))
+}
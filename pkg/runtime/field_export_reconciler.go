@@ -72,6 +72,8 @@ func (r *fieldExportReconciler) BindControllerManager(mgr ctrlrt.Manager) error
 		&ackv1alpha1.FieldExport{},
 	).WithEventFilter(
 		predicate.GenerationChangedPredicate{},
+	).Named(
+		sharedBindingFor(mgr).controllerName(r.sc.GetMetadata().ServiceAlias, "fieldexport"),
 	).Complete(r)
 }
 
@@ -0,0 +1,265 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package fieldcrypt encrypts the sensitive string fields of custom
+// resources at rest, with envelope encryption: each write encrypts the fields
+// with a fresh AES-256-GCM data key, itself encrypted with a KMS key and
+// stored next to the ciphertext. An encrypted field has the form
+//
+//	ackenc:v1:<base64 encrypted data key>:<base64 nonce and ciphertext>
+package fieldcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// prefix is the prefix of the encrypted values
+	prefix = "ackenc:v1:"
+	// maxCachedKeys bounds the number of decrypted data keys kept in memory
+	maxCachedKeys = 1024
+)
+
+var (
+	// ErrMalformed is returned when decrypting a value that is not a valid
+	// encrypted value
+	ErrMalformed = errors.New("malformed encrypted value")
+	// ErrNoKeyProvider is returned when decrypting a value without a key
+	// provider, e.g. when field encryption is not configured
+	ErrNoKeyProvider = errors.New("field encryption is not configured")
+)
+
+// KeyProvider generates and decrypts the data keys of the encrypted fields
+type KeyProvider interface {
+	// GenerateDataKey returns a new 256-bit data key, in plaintext and
+	// encrypted
+	GenerateDataKey(ctx context.Context) (plaintext []byte, encrypted []byte, err error)
+	// Decrypt returns the plaintext of the supplied encrypted data key
+	Decrypt(ctx context.Context, encrypted []byte) ([]byte, error)
+}
+
+// kmsAPI is the subset of the KMS API used by KMSKeyProvider
+type kmsAPI interface {
+	GenerateDataKey(context.Context, *kms.GenerateDataKeyInput, ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error)
+	Decrypt(context.Context, *kms.DecryptInput, ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// KMSKeyProvider is a KeyProvider generating its data keys with a KMS key
+type KMSKeyProvider struct {
+	client kmsAPI
+	keyID  string
+}
+
+// NewKMSKeyProvider returns a KeyProvider generating data keys with the KMS
+// key of the supplied ID, ARN or alias
+func NewKMSKeyProvider(cfg aws.Config, keyID string) *KMSKeyProvider {
+	return &KMSKeyProvider{client: kms.NewFromConfig(cfg), keyID: keyID}
+}
+
+// GenerateDataKey returns a new data key encrypted with the KMS key
+func (p *KMSKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	out, err := p.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(p.keyID),
+		KeySpec: kmstypes.DataKeySpecAes256,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out.Plaintext, out.CiphertextBlob, nil
+}
+
+// Decrypt returns the plaintext of a data key encrypted with a KMS key
+func (p *KMSKeyProvider) Decrypt(ctx context.Context, encrypted []byte) ([]byte, error) {
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: encrypted})
+	if err != nil {
+		return nil, err
+	}
+	return out.Plaintext, nil
+}
+
+// Encrypter encrypts and decrypts the sensitive fields of custom resources. A
+// nil Encrypter encrypts nothing, and fails to decrypt encrypted values.
+type Encrypter struct {
+	keys KeyProvider
+	// plaintextKeys caches the decrypted data keys, keyed by their
+	// encrypted form, so that KMS is not called on every reconciliation
+	sync.Mutex
+	plaintextKeys map[string][]byte
+}
+
+// NewEncrypter returns an Encrypter using the data keys of the supplied
+// provider
+func NewEncrypter(keys KeyProvider) *Encrypter {
+	return &Encrypter{keys: keys, plaintextKeys: map[string][]byte{}}
+}
+
+// IsEncrypted returns true if the supplied value is encrypted
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// EncryptFields encrypts, in place, the plaintext values of the supplied
+// fields of the supplied object, given as dot-separated paths. It returns
+// whether any field was encrypted.
+func (e *Encrypter) EncryptFields(
+	ctx context.Context,
+	obj map[string]interface{},
+	paths []string,
+) (bool, error) {
+	if e == nil {
+		return false, nil
+	}
+	var aead cipher.AEAD
+	var encryptedKey string
+	changed := false
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		value, found, err := k8sunstructured.NestedString(obj, fields...)
+		if err != nil || !found || value == "" || IsEncrypted(value) {
+			continue
+		}
+		if aead == nil {
+			// A single data key is generated for all the fields of a write
+			plaintext, encrypted, err := e.keys.GenerateDataKey(ctx)
+			if err != nil {
+				return false, fmt.Errorf("unable to generate a data key: %v", err)
+			}
+			if aead, err = newAEAD(plaintext); err != nil {
+				return false, err
+			}
+			encryptedKey = base64.StdEncoding.EncodeToString(encrypted)
+			e.cacheKey(encryptedKey, plaintext)
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return false, err
+		}
+		sealed := aead.Seal(nonce, nonce, []byte(value), []byte(path))
+		encryptedValue := prefix + encryptedKey + ":" + base64.StdEncoding.EncodeToString(sealed)
+		if err := k8sunstructured.SetNestedField(obj, encryptedValue, fields...); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// DecryptFields decrypts, in place, the encrypted values of the supplied
+// fields of the supplied object, given as dot-separated paths. It returns
+// whether any field was decrypted.
+func (e *Encrypter) DecryptFields(
+	ctx context.Context,
+	obj map[string]interface{},
+	paths []string,
+) (bool, error) {
+	changed := false
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		value, found, err := k8sunstructured.NestedString(obj, fields...)
+		if err != nil || !found || !IsEncrypted(value) {
+			continue
+		}
+		if e == nil {
+			return false, ErrNoKeyProvider
+		}
+		plaintext, err := e.decrypt(ctx, value, path)
+		if err != nil {
+			return false, fmt.Errorf("unable to decrypt %s: %w", path, err)
+		}
+		if err := k8sunstructured.SetNestedField(obj, plaintext, fields...); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// decrypt returns the plaintext of the supplied encrypted value of the field
+// with the supplied path
+func (e *Encrypter) decrypt(ctx context.Context, value string, path string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(value, prefix), ":", 2)
+	if len(parts) != 2 {
+		return "", ErrMalformed
+	}
+	key, err := e.dataKey(ctx, parts[0])
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(path))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// dataKey returns the plaintext of the supplied base64 encoded encrypted data
+// key
+func (e *Encrypter) dataKey(ctx context.Context, encryptedKey string) ([]byte, error) {
+	e.Lock()
+	key, ok := e.plaintextKeys[encryptedKey]
+	e.Unlock()
+	if ok {
+		return key, nil
+	}
+	encrypted, err := base64.StdEncoding.DecodeString(encryptedKey)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	key, err = e.keys.Decrypt(ctx, encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the data key: %v", err)
+	}
+	e.cacheKey(encryptedKey, key)
+	return key, nil
+}
+
+// cacheKey caches the plaintext of the supplied encrypted data key
+func (e *Encrypter) cacheKey(encryptedKey string, plaintext []byte) {
+	e.Lock()
+	defer e.Unlock()
+	if len(e.plaintextKeys) >= maxCachedKeys {
+		e.plaintextKeys = map[string][]byte{}
+	}
+	e.plaintextKeys[encryptedKey] = plaintext
+}
+
+// newAEAD returns the AES-GCM cipher of the supplied data key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
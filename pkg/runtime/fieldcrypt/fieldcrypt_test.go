@@ -0,0 +1,157 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fieldcrypt_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/fieldcrypt"
+)
+
+// fakeKeys is a KeyProvider "encrypting" data keys by reversing them
+type fakeKeys struct {
+	generated int
+	decrypted int
+}
+
+func (k *fakeKeys) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	k.generated++
+	key := bytes.Repeat([]byte{byte(k.generated)}, 31)
+	key = append(key, 0xff)
+	return key, reverse(key), nil
+}
+
+func (k *fakeKeys) Decrypt(ctx context.Context, encrypted []byte) ([]byte, error) {
+	k.decrypted++
+	return reverse(encrypted), nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+type failingKeys struct{}
+
+func (failingKeys) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	return nil, nil, errors.New("AccessDeniedException")
+}
+
+func (failingKeys) Decrypt(ctx context.Context, encrypted []byte) ([]byte, error) {
+	return nil, errors.New("AccessDeniedException")
+}
+
+func newObject() map[string]interface{} {
+	return map[string]interface{}{
+		"spec": map[string]interface{}{
+			"name":               "db",
+			"masterUserPassword": "hunter2",
+			"auth":               map[string]interface{}{"token": "s3cr3t"},
+		},
+	}
+}
+
+func TestEncryptFields_RoundTrip(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	paths := []string{"spec.masterUserPassword", "spec.auth.token", "spec.missing"}
+
+	keys := &fakeKeys{}
+	e := fieldcrypt.NewEncrypter(keys)
+	obj := newObject()
+	changed, err := e.EncryptFields(ctx, obj, paths)
+	require.Nil(err)
+	require.True(changed)
+	require.Equal(1, keys.generated)
+	spec := obj["spec"].(map[string]interface{})
+	require.Equal("db", spec["name"])
+	password := spec["masterUserPassword"].(string)
+	require.True(fieldcrypt.IsEncrypted(password))
+	require.NotContains(password, "hunter2")
+	require.True(fieldcrypt.IsEncrypted(spec["auth"].(map[string]interface{})["token"].(string)))
+
+	// Encrypted values are left alone
+	changed, err = e.EncryptFields(ctx, obj, paths)
+	require.Nil(err)
+	require.False(changed)
+	require.Equal(password, spec["masterUserPassword"])
+
+	// Another encrypter, e.g. after a restart, decrypts the data key once
+	keys = &fakeKeys{}
+	changed, err = fieldcrypt.NewEncrypter(keys).DecryptFields(ctx, obj, paths)
+	require.Nil(err)
+	require.True(changed)
+	require.Equal(1, keys.decrypted)
+	require.Equal(newObject(), obj)
+}
+
+func TestDecryptFields_BoundToPath(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	e := fieldcrypt.NewEncrypter(&fakeKeys{})
+	obj := newObject()
+	_, err := e.EncryptFields(ctx, obj, []string{"spec.masterUserPassword"})
+	require.Nil(err)
+
+	// A value copied to another field does not decrypt
+	spec := obj["spec"].(map[string]interface{})
+	spec["name"] = spec["masterUserPassword"]
+	_, err = e.DecryptFields(ctx, obj, []string{"spec.name"})
+	require.NotNil(err)
+
+	spec["name"] = "ackenc:v1:garbage"
+	_, err = e.DecryptFields(ctx, obj, []string{"spec.name"})
+	require.ErrorIs(err, fieldcrypt.ErrMalformed)
+}
+
+func TestEncrypter_Nil(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	var e *fieldcrypt.Encrypter
+	obj := newObject()
+	changed, err := e.EncryptFields(ctx, obj, []string{"spec.masterUserPassword"})
+	require.Nil(err)
+	require.False(changed)
+	changed, err = e.DecryptFields(ctx, obj, []string{"spec.masterUserPassword"})
+	require.Nil(err)
+	require.False(changed)
+
+	_, err = fieldcrypt.NewEncrypter(&fakeKeys{}).EncryptFields(ctx, obj, []string{"spec.masterUserPassword"})
+	require.Nil(err)
+	_, err = e.DecryptFields(ctx, obj, []string{"spec.masterUserPassword"})
+	require.ErrorIs(err, fieldcrypt.ErrNoKeyProvider)
+}
+
+func TestEncryptFields_KeyProviderError(t *testing.T) {
+	require := require.New(t)
+
+	obj := newObject()
+	_, err := fieldcrypt.NewEncrypter(failingKeys{}).EncryptFields(
+		context.TODO(), obj, []string{"spec.masterUserPassword"},
+	)
+	require.NotNil(err)
+	require.True(strings.Contains(err.Error(), "AccessDeniedException"))
+	require.Equal(newObject(), obj)
+}
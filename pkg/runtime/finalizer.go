@@ -0,0 +1,90 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	k8sctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// finalizerDescriptor is an AWSResourceDescriptor marking resources as
+// managed with a custom finalizer instead of the default, generated, one. It
+// lets several installations of a controller, each with its own finalizer,
+// reconcile the same kinds without removing each other's finalizers.
+type finalizerDescriptor struct {
+	acktypes.AWSResourceDescriptor
+	finalizer string
+	// migrate is true when resources holding the default finalizer are
+	// managed by this installation, their default finalizer being replaced
+	// with the custom one
+	migrate bool
+}
+
+// WithFinalizer returns a resource descriptor marking resources as managed
+// with the supplied finalizer, or the supplied descriptor if the finalizer is
+// empty. When migrate is true, resources holding the default finalizer of the
+// descriptor are also considered managed, and their default finalizer is
+// replaced with the supplied one when they are marked as managed.
+func WithFinalizer(
+	rd acktypes.AWSResourceDescriptor,
+	finalizer string,
+	migrate bool,
+) acktypes.AWSResourceDescriptor {
+	if finalizer == "" {
+		return rd
+	}
+	return &finalizerDescriptor{
+		AWSResourceDescriptor: rd,
+		finalizer:             finalizer,
+		migrate:               migrate,
+	}
+}
+
+// IsManaged returns true if the supplied resource holds the custom
+// finalizer, or the default one when migrating from it
+func (d *finalizerDescriptor) IsManaged(res acktypes.AWSResource) bool {
+	if k8sctrlutil.ContainsFinalizer(res.RuntimeObject(), d.finalizer) {
+		return true
+	}
+	return d.migrate && d.AWSResourceDescriptor.IsManaged(res)
+}
+
+// MarkManaged adds the custom finalizer to the supplied resource, removing
+// the default one when migrating from it
+func (d *finalizerDescriptor) MarkManaged(res acktypes.AWSResource) {
+	k8sctrlutil.AddFinalizer(res.RuntimeObject(), d.finalizer)
+	if d.migrate {
+		d.AWSResourceDescriptor.MarkUnmanaged(res)
+	}
+}
+
+// MarkUnmanaged removes the custom finalizer from the supplied resource, and
+// the default one when migrating from it
+func (d *finalizerDescriptor) MarkUnmanaged(res acktypes.AWSResource) {
+	k8sctrlutil.RemoveFinalizer(res.RuntimeObject(), d.finalizer)
+	if d.migrate {
+		d.AWSResourceDescriptor.MarkUnmanaged(res)
+	}
+}
+
+// needsFinalizerMigration returns true if the supplied resource holds the
+// default finalizer that the supplied descriptor migrates from
+func needsFinalizerMigration(
+	rd acktypes.AWSResourceDescriptor,
+	res acktypes.AWSResource,
+) bool {
+	d, ok := rd.(*finalizerDescriptor)
+	return ok && d.migrate && d.AWSResourceDescriptor.IsManaged(res)
+}
@@ -0,0 +1,85 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	defaultFinalizer = "finalizers.bookstore.services.k8s.aws/Book"
+	customFinalizer  = "team-a.services.k8s.aws/finalizer"
+)
+
+func finalizerResource(finalizers ...string) (*mocks.AWSResource, *k8sunstructured.Unstructured) {
+	obj := &k8sunstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetFinalizers(finalizers)
+	res := &mocks.AWSResource{}
+	res.On("RuntimeObject").Return(obj)
+	return res, obj
+}
+
+func finalizerResourceDescriptor() *mocks.AWSResourceDescriptor {
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("IsManaged", mock.Anything).Return(func(res acktypes.AWSResource) bool {
+		return k8sctrlutil.ContainsFinalizer(res.RuntimeObject(), defaultFinalizer)
+	})
+	rd.On("MarkUnmanaged", mock.Anything).Run(func(args mock.Arguments) {
+		k8sctrlutil.RemoveFinalizer(args.Get(0).(acktypes.AWSResource).RuntimeObject(), defaultFinalizer)
+	})
+	return rd
+}
+
+func TestWithFinalizer(t *testing.T) {
+	require := require.New(t)
+
+	rd := finalizerResourceDescriptor()
+	require.Equal(rd, ackrt.WithFinalizer(rd, "", false))
+
+	custom := ackrt.WithFinalizer(rd, customFinalizer, false)
+	res, obj := finalizerResource(defaultFinalizer)
+	// The finalizers of other installations are ignored
+	require.False(custom.IsManaged(res))
+	custom.MarkManaged(res)
+	require.True(custom.IsManaged(res))
+	require.Equal([]string{defaultFinalizer, customFinalizer}, obj.GetFinalizers())
+	custom.MarkUnmanaged(res)
+	require.False(custom.IsManaged(res))
+	require.Equal([]string{defaultFinalizer}, obj.GetFinalizers())
+}
+
+func TestWithFinalizer_Migrate(t *testing.T) {
+	require := require.New(t)
+
+	migrating := ackrt.WithFinalizer(finalizerResourceDescriptor(), customFinalizer, true)
+	res, obj := finalizerResource(defaultFinalizer)
+	require.True(migrating.IsManaged(res))
+	migrating.MarkManaged(res)
+	require.True(migrating.IsManaged(res))
+	require.Equal([]string{customFinalizer}, obj.GetFinalizers())
+
+	res, obj = finalizerResource(defaultFinalizer)
+	migrating.MarkUnmanaged(res)
+	require.False(migrating.IsManaged(res))
+	require.Empty(obj.GetFinalizers())
+}
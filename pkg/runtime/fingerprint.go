@@ -0,0 +1,226 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// errorLastSeenInterval is the minimum interval between two updates of the
+// last seen time of the error of a resource, bounding the metadata patches
+// of resources failing with the same error
+const errorLastSeenInterval = 5 * time.Minute
+
+// errorRecord is the value of the error fingerprint annotation of a resource
+type errorRecord struct {
+	Fingerprint string    `json:"fingerprint"`
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+}
+
+// eventFingerprints tracks the Warning events emitted for the resources since
+// the error they fail with last changed, so that an event is only emitted
+// once per error. A nil eventFingerprints suppresses no event.
+type eventFingerprints struct {
+	sync.Mutex
+	emitted map[types.NamespacedName]map[string]struct{}
+}
+
+// newEventFingerprints returns an empty eventFingerprints, or nil if error
+// fingerprinting is disabled
+func newEventFingerprints(cfg ackcfg.Config) *eventFingerprints {
+	if !cfg.FingerprintErrors {
+		return nil
+	}
+	return &eventFingerprints{emitted: map[types.NamespacedName]map[string]struct{}{}}
+}
+
+// seen returns true if an event with the supplied reason and message, once
+// normalized, was already emitted for the supplied object since its error
+// last changed, and records the event otherwise
+func (f *eventFingerprints) seen(
+	obj metav1.Object,
+	reason ackevents.Reason,
+	message string,
+) bool {
+	if f == nil {
+		return false
+	}
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	fingerprint := string(reason) + "/" + ackerr.NormalizeMessage(message)
+	f.Lock()
+	defer f.Unlock()
+	emitted, ok := f.emitted[key]
+	if !ok {
+		emitted = map[string]struct{}{}
+		f.emitted[key] = emitted
+	}
+	if _, ok := emitted[fingerprint]; ok {
+		return true
+	}
+	emitted[fingerprint] = struct{}{}
+	return false
+}
+
+// reset forgets the events emitted for the supplied resource
+func (f *eventFingerprints) reset(key types.NamespacedName) {
+	if f == nil {
+		return
+	}
+	f.Lock()
+	defer f.Unlock()
+	delete(f.emitted, key)
+}
+
+// reconcileError returns the error a reconciliation failed with, unwrapped
+// from the requeue instructions, or nil if it did not fail. The error of a
+// resource in a terminal state is the reason of its Terminal condition.
+func reconcileError(latest acktypes.AWSResource, err error) error {
+	for {
+		switch err.(type) {
+		case *requeue.NoRequeue, *requeue.RequeueNeeded, *requeue.RequeueNeededAfter:
+			err = errors.Unwrap(err)
+			continue
+		}
+		break
+	}
+	if err == nil || err == ackerr.TemporaryOutOfSync {
+		return nil
+	}
+	if err == ackerr.Terminal && ackcompare.IsNotNil(latest) {
+		if c := ackcondition.Terminal(latest); c != nil && c.Reason != nil {
+			return errors.New(*c.Reason)
+		}
+	}
+	return err
+}
+
+// recordErrorFingerprint maintains the error fingerprint annotation of the
+// supplied resource. The annotation is written when the error of the resource
+// changes and, at most every errorLastSeenInterval, to update the last seen
+// time of a recurring error. It is removed once the error is resolved. The
+// events emitted for the resource are reset whenever its error changes.
+func (r *resourceReconciler) recordErrorFingerprint(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	err error,
+) {
+	mo := desired.MetaObject()
+	key := types.NamespacedName{Namespace: mo.GetNamespace(), Name: mo.GetName()}
+	previous := errorRecord{}
+	if value, ok := mo.GetAnnotations()[ackv1alpha1.AnnotationErrorFingerprint]; ok {
+		_ = json.Unmarshal([]byte(value), &previous)
+	}
+	fingerprint := ackerr.Fingerprint(reconcileError(latest, err))
+	now := time.Now().UTC().Truncate(time.Second)
+
+	record := previous
+	switch {
+	case fingerprint == "" && previous.Fingerprint == "":
+		return
+	case fingerprint != previous.Fingerprint:
+		r.eventFingerprints.reset(key)
+		record = errorRecord{Fingerprint: fingerprint, FirstSeen: now, LastSeen: now}
+	case now.Sub(previous.LastSeen) >= errorLastSeenInterval:
+		record.LastSeen = now
+	default:
+		return
+	}
+
+	if res := desired.RuntimeObject(); res.GetDeletionTimestamp() != nil && len(res.GetFinalizers()) == 0 {
+		// The resource is gone once its last finalizer is removed
+		return
+	}
+	updated := pendingOrCopy(ctx, desired)
+	umo := updated.MetaObject()
+	annotations := umo.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if fingerprint == "" {
+		delete(annotations, ackv1alpha1.AnnotationErrorFingerprint)
+	} else {
+		value, _ := json.Marshal(record)
+		annotations[ackv1alpha1.AnnotationErrorFingerprint] = string(value)
+	}
+	umo.SetAnnotations(annotations)
+	if _, err := r.patchResourceMetadataAndSpec(ctx, rm, desired, updated); err != nil {
+		ackrtlog.FromContext(ctx).Info("unable to record error fingerprint", "error", err)
+	}
+}
+
+// preserveConditionTransitions keeps the previous version of the conditions
+// of the supplied resource that did not change, once their volatile parts are
+// normalized, so that reconciling a resource failing with the same error
+// does not rewrite its status.
+func preserveConditionTransitions(
+	previous []*ackv1alpha1.Condition,
+	latest acktypes.AWSResource,
+) {
+	if ackcompare.IsNil(latest) || len(previous) == 0 {
+		return
+	}
+	byType := make(map[ackv1alpha1.ConditionType]*ackv1alpha1.Condition, len(previous))
+	for _, c := range previous {
+		byType[c.Type] = c
+	}
+	conditions := latest.Conditions()
+	for i, c := range conditions {
+		prev, ok := byType[c.Type]
+		if !ok || prev.Status != c.Status ||
+			normalizedValue(prev.Message) != normalizedValue(c.Message) ||
+			normalizedValue(prev.Reason) != normalizedValue(c.Reason) {
+			continue
+		}
+		conditions[i] = prev.DeepCopy()
+	}
+	latest.ReplaceConditions(conditions)
+}
+
+// normalizedValue returns the normalized value of an optional condition
+// message or reason
+func normalizedValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return ackerr.NormalizeMessage(*value)
+}
+
+// copyConditions returns a deep copy of the supplied conditions
+func copyConditions(conditions []*ackv1alpha1.Condition) []*ackv1alpha1.Condition {
+	copied := make([]*ackv1alpha1.Condition, 0, len(conditions))
+	for _, c := range conditions {
+		copied = append(copied, c.DeepCopy())
+	}
+	return copied
+}
@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// infrastructureFaultEndpointConnection is the fault of the
+	// reconciliations that could not reach the endpoint of an AWS service
+	infrastructureFaultEndpointConnection = "endpoint_connection"
+	// infrastructureFaultClockSkew is the fault of the reconciliations
+	// whose requests were rejected because the clock of the controller is
+	// skewed
+	infrastructureFaultClockSkew = "clock_skew"
+)
+
+// infrastructureFault returns the fault of the infrastructure that caused the
+// supplied reconciliation error, or an empty string if the error is not
+// caused by the infrastructure
+func infrastructureFault(err error) string {
+	switch {
+	case ackerr.IsEndpointConnection(err):
+		return infrastructureFaultEndpointConnection
+	case ackerr.IsClockSkew(err):
+		return infrastructureFaultClockSkew
+	}
+	return ""
+}
+
+// handleInfrastructureFault restores the conditions the supplied resource
+// had before the reconciliation if the reconciliation failed because of a
+// fault of the infrastructure, e.g. a network blip. Such faults say nothing
+// about the resource, so they are reported in the metrics and logs of the
+// controller rather than in the status of the resource. The reconciliation is
+// still retried with backoff.
+func (r *resourceReconciler) handleInfrastructureFault(
+	ctx context.Context,
+	latest acktypes.AWSResource,
+	previousConditions []*ackv1alpha1.Condition,
+	err error,
+) {
+	fault := infrastructureFault(err)
+	if fault == "" {
+		return
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	rlog.Info("reconciliation failed because of an infrastructure fault", "fault", fault, "error", err)
+	if r.metrics != nil {
+		r.metrics.RecordInfrastructureFault(r.rd.GroupVersionKind().Kind, fault)
+	}
+	if ackcompare.IsNil(latest) {
+		return
+	}
+	latest.ReplaceConditions(copyConditions(previousConditions))
+}
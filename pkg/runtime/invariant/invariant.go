@@ -0,0 +1,274 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package invariant contains the cross-resource invariants defined by the
+// operators of a controller: CEL rules spanning several resources, e.g. the
+// total provisioned IOPS of the DB instances of a namespace, that the
+// reconciler checks, against its cache, before creating or updating an AWS
+// resource. Guardrails needing the context of the fleet cannot be enforced
+// by the admission of each object.
+package invariant
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"sigs.k8s.io/yaml"
+)
+
+// Scope is the scope of the resources an invariant spans
+type Scope string
+
+const (
+	// ScopeNamespace spans the resources of the namespace of the mutated
+	// resource. It is the default scope.
+	ScopeNamespace Scope = "Namespace"
+	// ScopeCluster spans the resources of all the namespaces
+	ScopeCluster Scope = "Cluster"
+)
+
+// Invariant is a CEL rule that must hold for the resources of its kinds, and
+// is checked before the AWS resources of these kinds are created or updated.
+// The rule is evaluated with the variables:
+//
+//   - object: the desired state of the mutated resource
+//   - resources: a map of the kinds of the invariant to the lists of their
+//     resources in its scope, the mutated resource being in its desired state
+//
+// along with the sum() function of the lists of numbers, e.g.:
+//
+//	name: max-iops-per-namespace
+//	kinds: [DBInstance]
+//	rule: 'sum(resources.DBInstance.map(r, has(r.spec.iops) ? r.spec.iops : 0)) <= 40000'
+//	message: the DB instances of a namespace provision at most 40000 IOPS
+type Invariant struct {
+	// Name identifies the invariant
+	Name string `json:"name"`
+	// Kinds are the kinds of the resources the invariant spans
+	Kinds []string `json:"kinds"`
+	// Scope is the scope of the resources the invariant spans, Namespace by
+	// default
+	Scope Scope `json:"scope,omitempty"`
+	// Rule is the CEL expression, evaluating to a boolean, that must be true
+	Rule string `json:"rule"`
+	// Message describes the invariant to the owners of the resources
+	// violating it
+	Message string `json:"message,omitempty"`
+
+	program cel.Program
+}
+
+// Applies returns true if the invariant spans the resources of the supplied
+// kind
+func (i *Invariant) Applies(kind string) bool {
+	for _, k := range i.Kinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Holds returns true if the invariant holds for the supplied mutated
+// resource and the supplied resources of its kinds, keyed by kind, all in
+// their unstructured representation
+func (i *Invariant) Holds(
+	object map[string]interface{},
+	resources map[string][]interface{},
+) (bool, error) {
+	out, _, err := i.program.Eval(map[string]interface{}{
+		"object":    object,
+		"resources": resources,
+	})
+	if err != nil {
+		return false, fmt.Errorf("evaluating invariant %q: %v", i.Name, err)
+	}
+	holds, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("evaluating invariant %q: rule did not return a boolean", i.Name)
+	}
+	return holds, nil
+}
+
+// Violation returns the message describing the violation of the invariant
+func (i *Invariant) Violation() string {
+	if i.Message == "" {
+		return fmt.Sprintf("invariant %q is violated: %s", i.Name, i.Rule)
+	}
+	return fmt.Sprintf("invariant %q is violated: %s", i.Name, i.Message)
+}
+
+// file is the format of the invariants file
+type file struct {
+	Invariants []*Invariant `json:"invariants"`
+}
+
+// Parse returns the invariants of the supplied YAML document:
+//
+//	invariants:
+//	- name: single-cluster-per-namespace
+//	  kinds: [DBCluster]
+//	  rule: size(resources.DBCluster) <= 1
+func Parse(data []byte) ([]*Invariant, error) {
+	f := file{}
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, err
+	}
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]struct{}{}
+	for _, i := range f.Invariants {
+		if i.Name == "" {
+			return nil, fmt.Errorf("invariant without a name")
+		}
+		if _, ok := names[i.Name]; ok {
+			return nil, fmt.Errorf("invariant %q is defined more than once", i.Name)
+		}
+		names[i.Name] = struct{}{}
+		if len(i.Kinds) == 0 {
+			return nil, fmt.Errorf("invariant %q has no kinds", i.Name)
+		}
+		switch i.Scope {
+		case "":
+			i.Scope = ScopeNamespace
+		case ScopeNamespace, ScopeCluster:
+		default:
+			return nil, fmt.Errorf("invariant %q has an invalid scope %q", i.Name, i.Scope)
+		}
+		ast, issues := env.Compile(i.Rule)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invariant %q has an invalid rule: %v", i.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return nil, fmt.Errorf("invariant %q has a rule returning %s instead of a boolean", i.Name, ast.OutputType())
+		}
+		if i.program, err = env.Program(ast); err != nil {
+			return nil, fmt.Errorf("invariant %q has an invalid rule: %v", i.Name, err)
+		}
+	}
+	return f.Invariants, nil
+}
+
+// newEnv returns the CEL environment of the rules of the invariants
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("resources", cel.MapType(cel.StringType, cel.ListType(cel.DynType))),
+		cel.Function("sum",
+			cel.Overload("sum_list", []*cel.Type{cel.ListType(cel.DynType)}, cel.DynType,
+				cel.UnaryBinding(sum),
+			),
+		),
+	)
+}
+
+// sum returns the sum of the supplied list of numbers, an int if they are all
+// integers, a double otherwise
+func sum(value ref.Val) ref.Val {
+	list, ok := value.(traits.Lister)
+	if !ok {
+		return types.NewErr("sum() expects a list")
+	}
+	var intSum int64
+	var doubleSum float64
+	isDouble := false
+	it := list.Iterator()
+	for it.HasNext() == types.True {
+		item := it.Next()
+		switch item.Type() {
+		case types.IntType:
+			intSum += int64(item.(types.Int))
+		case types.UintType:
+			intSum += int64(item.(types.Uint))
+		case types.DoubleType:
+			isDouble = true
+			doubleSum += float64(item.(types.Double))
+		default:
+			return types.NewErr("sum() expects a list of numbers, got %s", item.Type().TypeName())
+		}
+	}
+	if isDouble {
+		return types.Double(doubleSum + float64(intSum))
+	}
+	return types.Int(intSum)
+}
+
+// File is the invariants file of the --invariants-file flag, read again
+// whenever its modification time changes. A missing file has no invariants,
+// so that it can be mounted from an optional ConfigMap. A nil File has no
+// invariants.
+type File struct {
+	sync.Mutex
+	path       string
+	modTime    time.Time
+	invariants []*Invariant
+}
+
+// NewFile returns the invariants file with the supplied path
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// For returns the invariants spanning the resources of the supplied kind.
+// When the file cannot be read or parsed, the invariants last read are
+// returned along with the error.
+func (f *File) For(kind string) ([]*Invariant, error) {
+	if f == nil {
+		return nil, nil
+	}
+	f.Lock()
+	defer f.Unlock()
+	err := f.load()
+	invariants := []*Invariant{}
+	for _, i := range f.invariants {
+		if i.Applies(kind) {
+			invariants = append(invariants, i)
+		}
+	}
+	return invariants, err
+}
+
+// load reads the invariants file again if it changed since it was last read.
+// The caller must hold the lock.
+func (f *File) load() error {
+	info, err := os.Stat(f.path)
+	if os.IsNotExist(err) {
+		f.invariants, f.modTime = nil, time.Time{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if f.invariants != nil && info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	invariants, err := Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing invariants file %s: %v", f.path, err)
+	}
+	f.invariants, f.modTime = invariants, info.ModTime()
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package invariant_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/invariant"
+)
+
+func dbInstance(name string, iops interface{}) map[string]interface{} {
+	spec := map[string]interface{}{}
+	if iops != nil {
+		spec["iops"] = iops
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name},
+		"spec":     spec,
+	}
+}
+
+func TestInvariantHolds(t *testing.T) {
+	require := require.New(t)
+
+	invariants, err := invariant.Parse([]byte(`
+invariants:
+- name: max-iops-per-namespace
+  kinds: [DBInstance]
+  rule: 'sum(resources.DBInstance.map(r, has(r.spec.iops) ? r.spec.iops : 0)) <= 1000'
+  message: the DB instances of a namespace provision at most 1000 IOPS
+- name: single-cluster-per-namespace
+  kinds: [DBCluster]
+  scope: Cluster
+  rule: size(resources.DBCluster) <= 1
+`))
+	require.Nil(err)
+	require.Len(invariants, 2)
+	iops := invariants[0]
+	require.True(iops.Applies("dbinstance"))
+	require.False(iops.Applies("DBCluster"))
+	require.Equal(invariant.ScopeNamespace, iops.Scope)
+	require.Equal(invariant.ScopeCluster, invariants[1].Scope)
+
+	first := dbInstance("first", int64(400))
+	holds, err := iops.Holds(first, map[string][]interface{}{
+		"DBInstance": {first, dbInstance("second", int64(500)), dbInstance("third", nil)},
+	})
+	require.Nil(err)
+	require.True(holds)
+
+	first = dbInstance("first", float64(600))
+	holds, err = iops.Holds(first, map[string][]interface{}{
+		"DBInstance": {first, dbInstance("second", int64(500))},
+	})
+	require.Nil(err)
+	require.False(holds)
+	require.Equal(
+		`invariant "max-iops-per-namespace" is violated: the DB instances of a namespace provision at most 1000 IOPS`,
+		iops.Violation(),
+	)
+
+	_, err = iops.Holds(first, map[string][]interface{}{
+		"DBInstance": {first, dbInstance("second", "500")},
+	})
+	require.NotNil(err)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{
+		"invariants:\n- kinds: [Bucket]\n  rule: 'true'",
+		"invariants:\n- name: a\n  rule: 'true'",
+		"invariants:\n- name: a\n  kinds: [Bucket]\n  rule: 'true'\n- name: a\n  kinds: [Bucket]\n  rule: 'true'",
+		"invariants:\n- name: a\n  kinds: [Bucket]\n  scope: Region\n  rule: 'true'",
+		"invariants:\n- name: a\n  kinds: [Bucket]\n  rule: 'size('",
+		"invariants:\n- name: a\n  kinds: [Bucket]\n  rule: '1 + 1'",
+		"invariants:\n- name: a\n  kinds: [Bucket]\n  rules: 'true'",
+	} {
+		_, err := invariant.Parse([]byte(data))
+		require.NotNil(t, err, data)
+	}
+}
+
+func TestFile(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "invariants.yaml")
+
+	// A missing file has no invariants
+	f := invariant.NewFile(path)
+	invariants, err := f.For("Bucket")
+	require.Nil(err)
+	require.Empty(invariants)
+
+	require.Nil(os.WriteFile(path, []byte(`
+invariants:
+- name: single-bucket
+  kinds: [Bucket]
+  rule: size(resources.Bucket) <= 1
+`), 0o600))
+	invariants, err = f.For("Bucket")
+	require.Nil(err)
+	require.Len(invariants, 1)
+	invariants, err = f.For("Queue")
+	require.Nil(err)
+	require.Empty(invariants)
+
+	var nilFile *invariant.File
+	invariants, err = nilFile.For("Bucket")
+	require.Nil(err)
+	require.Empty(invariants)
+}
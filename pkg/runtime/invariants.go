@@ -0,0 +1,205 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/invariant"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// PolicyViolationReason is the reason of the ACK.PolicyViolation and
+	// ACK.ResourceSynced conditions of the resources violating an invariant
+	PolicyViolationReason = "PolicyViolation"
+	// policyViolationRequeueAfter is the delay after which the invariants
+	// violated by a resource are checked again, the other resources they
+	// span having possibly changed meanwhile
+	policyViolationRequeueAfter = 5 * time.Minute
+)
+
+// errPolicyViolation is the error of the creations and updates of the AWS
+// resources that would violate an invariant
+var errPolicyViolation = errors.New("policy violation")
+
+// newInvariantsFile returns the invariants file of the --invariants-file
+// flag, or nil
+func newInvariantsFile(cfg ackcfg.Config) *invariant.File {
+	if cfg.InvariantsFile == "" {
+		return nil
+	}
+	return invariant.NewFile(cfg.InvariantsFile)
+}
+
+// checkInvariants checks the invariants of the kind of the supplied desired
+// resource, against the resources of their kinds in the cache, before its AWS
+// resource is created or updated. If an invariant is violated, it returns a
+// copy of the supplied latest resource with its ACK.PolicyViolation
+// condition set, and a RequeueNeededAfter error checking the invariants again
+// later. It returns nil and no error if the AWS resource can be changed,
+// removing the ACK.PolicyViolation condition of the latest resource.
+func (r *resourceReconciler) checkInvariants(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	if r.invariants == nil {
+		return nil, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	invariants, err := r.invariants.For(r.rd.GroupVersionKind().Kind)
+	if err != nil {
+		rlog.Info("unable to read invariants file", "error", err)
+	}
+	if len(invariants) == 0 {
+		ackcondition.RemovePolicyViolation(latest)
+		return nil, nil
+	}
+	object, err := UnstructuredConverter.ToUnstructured(desired.RuntimeObject())
+	if err != nil {
+		return latest, err
+	}
+	for _, inv := range invariants {
+		resources, err := r.invariantResources(ctx, inv, desired, object)
+		if err != nil {
+			return latest, err
+		}
+		holds, err := inv.Holds(object, resources)
+		if err != nil {
+			return latest, err
+		}
+		if holds {
+			continue
+		}
+		message := inv.Violation()
+		reason := PolicyViolationReason
+		rlog.Info("invariant violated", "invariant", inv.Name)
+		r.recordEvent(desired.RuntimeObject(), ackevents.ReasonPolicyViolation, message)
+		violated := latest.DeepCopy()
+		ackcondition.SetPolicyViolation(violated, &message, &reason)
+		ackcondition.SetSynced(violated, corev1.ConditionFalse, &ackcondition.NotSyncedMessage, &reason)
+		return violated, requeue.NeededAfter(
+			fmt.Errorf("%w: %s", errPolicyViolation, message), policyViolationRequeueAfter,
+		)
+	}
+	ackcondition.RemovePolicyViolation(latest)
+	return nil, nil
+}
+
+// invariantResources returns the resources, in their unstructured
+// representation, of the kinds of the supplied invariant in its scope, keyed
+// by kind. The resources are read from the cache, the supplied desired
+// resource, whose unstructured representation is supplied, replacing its
+// cached state.
+func (r *resourceReconciler) invariantResources(
+	ctx context.Context,
+	inv *invariant.Invariant,
+	desired acktypes.AWSResource,
+	object map[string]interface{},
+) (map[string][]interface{}, error) {
+	namespace := desired.MetaObject().GetNamespace()
+	if inv.Scope == invariant.ScopeCluster {
+		namespace = ""
+	}
+	kind := r.rd.GroupVersionKind().Kind
+	resources := make(map[string][]interface{}, len(inv.Kinds))
+	for _, k := range inv.Kinds {
+		items, err := r.listResources(ctx, k, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("listing the resources of invariant %q: %v", inv.Name, err)
+		}
+		if strings.EqualFold(k, kind) {
+			items = replaceResource(items, desired, object)
+		}
+		resources[k] = items
+	}
+	return resources, nil
+}
+
+// listResources returns the resources, in their unstructured representation,
+// of the supplied kind of the API group of the reconciler in the supplied
+// namespace, or in all the namespaces if it is empty, from the cache
+func (r *resourceReconciler) listResources(
+	ctx context.Context,
+	kind string,
+	namespace string,
+) ([]interface{}, error) {
+	gv := r.rd.GroupVersionKind().GroupVersion()
+	obj, err := r.kc.Scheme().New(gv.WithKind(kind + "List"))
+	if err != nil {
+		return nil, err
+	}
+	list, ok := obj.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", gv.WithKind(kind+"List"))
+	}
+	opts := []client.ListOption{}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err = r.kc.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, 0, len(objs))
+	for _, o := range objs {
+		item, err := UnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// replaceResource returns the supplied unstructured resources with the
+// supplied one, in its supplied unstructured representation, replacing its
+// cached state, or appended if it is not cached yet
+func replaceResource(
+	items []interface{},
+	res acktypes.AWSResource,
+	object map[string]interface{},
+) []interface{} {
+	for i, item := range items {
+		content, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := k8sunstructured.NestedString(content, "metadata", "name")
+		namespace, _, _ := k8sunstructured.NestedString(content, "metadata", "namespace")
+		if name == res.MetaObject().GetName() && namespace == res.MetaObject().GetNamespace() {
+			items[i] = object
+			return items
+		}
+	}
+	return append(items, object)
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/inventory"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// addInventoryExporter adds to the supplied controller manager the exporter
+// of the inventory of the resources of the supplied kinds. S3 destinations
+// are written with the default AWS configuration of the controller.
+func (c *serviceController) addInventoryExporter(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+	rmfs map[string]acktypes.AWSResourceManagerFactory,
+) error {
+	gvks := make([]schema.GroupVersionKind, 0, len(rmfs))
+	for _, rmf := range rmfs {
+		gvks = append(gvks, rmf.ResourceDescriptor().GroupVersionKind())
+	}
+	if len(gvks) == 0 {
+		return nil
+	}
+	sink, err := inventory.ParseDestination(cfg.InventoryDestination, func() (aws.Config, error) {
+		return c.NewAWSConfig(context.Background(), ackv1alpha1.AWSRegion(cfg.Region), nil, "", gvks[0])
+	})
+	if err != nil {
+		return err
+	}
+	// The format was validated with the configuration
+	format, _ := inventory.ParseFormat(cfg.InventoryFormat)
+	return mgr.Add(inventory.NewExporter(
+		c.log.WithName("inventory"), mgr.GetAPIReader(), sink, format,
+		time.Duration(cfg.InventoryIntervalSeconds)*time.Second, gvks,
+	))
+}
@@ -0,0 +1,276 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package inventory contains a periodic exporter of a normalized inventory of
+// the AWS resources managed by a controller, for asset inventory and CMDB
+// systems.
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// Format is the format of an exported inventory
+type Format string
+
+const (
+	// FormatJSON exports the inventory as a JSON document
+	FormatJSON Format = "json"
+	// FormatJSONLines exports the inventory as one JSON document per
+	// resource and per line
+	FormatJSONLines Format = "jsonl"
+)
+
+// ParseFormat parses the supplied inventory format
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(value); format {
+	case FormatJSON, FormatJSONLines:
+		return format, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid inventory format %q: expected %q or %q", value, FormatJSON, FormatJSONLines,
+		)
+	}
+}
+
+// SyncState is the synchronization state of an inventoried resource
+type SyncState string
+
+const (
+	// SyncStateSynced is the state of a resource whose ACK.ResourceSynced
+	// condition is True
+	SyncStateSynced SyncState = "Synced"
+	// SyncStateNotSynced is the state of a resource whose
+	// ACK.ResourceSynced condition is False
+	SyncStateNotSynced SyncState = "NotSynced"
+	// SyncStateTerminal is the state of a resource whose ACK.Terminal
+	// condition is True
+	SyncStateTerminal SyncState = "Terminal"
+	// SyncStateUnknown is the state of a resource without, or with an
+	// Unknown, ACK.ResourceSynced condition
+	SyncStateUnknown SyncState = "Unknown"
+)
+
+// Item is an inventoried resource
+type Item struct {
+	Kind      string            `json:"kind"`
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	ARN       string            `json:"arn,omitempty"`
+	AccountID string            `json:"accountID,omitempty"`
+	Region    string            `json:"region,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	SyncState SyncState         `json:"syncState"`
+}
+
+// Inventory is an exported inventory
+type Inventory struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Items       []Item    `json:"items"`
+}
+
+// ItemFromUnstructured returns the inventory item of the supplied resource
+func ItemFromUnstructured(u *k8sunstructured.Unstructured) Item {
+	item := Item{
+		Kind:      u.GetKind(),
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+		Tags:      tags(u),
+		SyncState: syncState(u),
+	}
+	item.ARN, _, _ = k8sunstructured.NestedString(u.Object, "status", "ackResourceMetadata", "arn")
+	item.AccountID, _, _ = k8sunstructured.NestedString(u.Object, "status", "ackResourceMetadata", "ownerAccountID")
+	item.Region, _, _ = k8sunstructured.NestedString(u.Object, "status", "ackResourceMetadata", "region")
+	return item
+}
+
+// tags returns the tags in the spec of the supplied resource. ACK resources
+// hold their tags either in a map or in a list of key/value pairs.
+func tags(u *k8sunstructured.Unstructured) map[string]string {
+	value, found, _ := k8sunstructured.NestedFieldNoCopy(u.Object, "spec", "tags")
+	if !found {
+		return nil
+	}
+	tags := map[string]string{}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if s, ok := v.(string); ok {
+				tags[k] = s
+			}
+		}
+	case []interface{}:
+		for _, tag := range value {
+			tm, ok := tag.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if k, ok := tm["key"].(string); ok {
+				v, _ := tm["value"].(string)
+				tags[k] = v
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+// syncState returns the synchronization state of the supplied resource
+func syncState(u *k8sunstructured.Unstructured) SyncState {
+	statuses := map[string]interface{}{}
+	conditions, _, _ := k8sunstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		if cm, ok := c.(map[string]interface{}); ok {
+			if t, ok := cm["type"].(string); ok {
+				statuses[t] = cm["status"]
+			}
+		}
+	}
+	if statuses[string(ackv1alpha1.ConditionTypeTerminal)] == string(corev1.ConditionTrue) {
+		return SyncStateTerminal
+	}
+	switch statuses[string(ackv1alpha1.ConditionTypeResourceSynced)] {
+	case string(corev1.ConditionTrue):
+		return SyncStateSynced
+	case string(corev1.ConditionFalse):
+		return SyncStateNotSynced
+	default:
+		return SyncStateUnknown
+	}
+}
+
+// Encode returns the supplied inventory in the supplied format, with the
+// content type of the format
+func Encode(inventory Inventory, format Format) ([]byte, string, error) {
+	switch format {
+	case FormatJSONLines:
+		buf := &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		for _, item := range inventory.Items {
+			if err := enc.Encode(item); err != nil {
+				return nil, "", err
+			}
+		}
+		return buf.Bytes(), "application/x-ndjson", nil
+	default:
+		body, err := json.Marshal(inventory)
+		return body, "application/json", err
+	}
+}
+
+// Sink receives the exported inventories
+type Sink interface {
+	// Write writes the supplied encoded inventory
+	Write(ctx context.Context, body []byte, contentType string) error
+}
+
+// Exporter periodically exports the inventory of the resources of a set of
+// kinds. Exporter implements the controller-runtime manager.Runnable
+// interface and only runs on the leader, so that a single inventory is
+// exported per controller installation.
+type Exporter struct {
+	log      logr.Logger
+	reader   client.Reader
+	sink     Sink
+	format   Format
+	interval time.Duration
+	gvks     []schema.GroupVersionKind
+}
+
+// NewExporter returns an Exporter writing, at the supplied interval, the
+// inventory of the resources of the supplied kinds to the supplied sink
+func NewExporter(
+	log logr.Logger,
+	reader client.Reader,
+	sink Sink,
+	format Format,
+	interval time.Duration,
+	gvks []schema.GroupVersionKind,
+) *Exporter {
+	return &Exporter{
+		log:      log,
+		reader:   reader,
+		sink:     sink,
+		format:   format,
+		interval: interval,
+		gvks:     gvks,
+	}
+}
+
+// Start exports the inventory until the supplied context is done
+func (e *Exporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Export(ctx); err != nil {
+			e.log.Error(err, "unable to export inventory")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Export lists the resources of the kinds of the exporter and writes their
+// inventory to the sink
+func (e *Exporter) Export(ctx context.Context) error {
+	inventory := Inventory{GeneratedAt: time.Now().UTC(), Items: []Item{}}
+	for _, gvk := range e.gvks {
+		list := &k8sunstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := e.reader.List(ctx, list); err != nil {
+			return fmt.Errorf("listing %s resources: %v", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			u := &list.Items[i]
+			u.SetGroupVersionKind(gvk)
+			inventory.Items = append(inventory.Items, ItemFromUnstructured(u))
+		}
+	}
+	sort.Slice(inventory.Items, func(i, j int) bool {
+		a, b := inventory.Items[i], inventory.Items[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	body, contentType, err := Encode(inventory, e.format)
+	if err != nil {
+		return err
+	}
+	if err = e.sink.Write(ctx, body, contentType); err != nil {
+		return fmt.Errorf("writing inventory: %v", err)
+	}
+	e.log.V(1).Info("exported inventory", "resources", len(inventory.Items))
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package inventory_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/inventory"
+)
+
+var bookGVK = schema.GroupVersionKind{
+	Group:   "bookstore.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Book",
+}
+
+func newBook(namespace, name string, tags interface{}, synced string) *k8sunstructured.Unstructured {
+	u := &k8sunstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetGroupVersionKind(bookGVK)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.Object["spec"] = map[string]interface{}{"tags": tags}
+	u.Object["status"] = map[string]interface{}{
+		"ackResourceMetadata": map[string]interface{}{
+			"arn":            "arn:aws:bookstore:us-west-2:111111111111:book/" + name,
+			"ownerAccountID": "111111111111",
+			"region":         "us-west-2",
+		},
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "ACK.ResourceSynced", "status": synced},
+		},
+	}
+	return u
+}
+
+func newScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	scheme.AddKnownTypeWithName(bookGVK, &k8sunstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(
+		bookGVK.GroupVersion().WithKind("BookList"), &k8sunstructured.UnstructuredList{},
+	)
+	metav1.AddToGroupVersion(scheme, bookGVK.GroupVersion())
+	return scheme
+}
+
+func TestItemFromUnstructured(t *testing.T) {
+	require := require.New(t)
+
+	item := inventory.ItemFromUnstructured(newBook("ns", "a", map[string]interface{}{"team": "x"}, "True"))
+	require.Equal(inventory.Item{
+		Kind:      "Book",
+		Namespace: "ns",
+		Name:      "a",
+		ARN:       "arn:aws:bookstore:us-west-2:111111111111:book/a",
+		AccountID: "111111111111",
+		Region:    "us-west-2",
+		Tags:      map[string]string{"team": "x"},
+		SyncState: inventory.SyncStateSynced,
+	}, item)
+
+	item = inventory.ItemFromUnstructured(newBook("ns", "b", []interface{}{
+		map[string]interface{}{"key": "team", "value": "y"},
+	}, "False"))
+	require.Equal(map[string]string{"team": "y"}, item.Tags)
+	require.Equal(inventory.SyncStateNotSynced, item.SyncState)
+
+	item = inventory.ItemFromUnstructured(newBook("ns", "c", nil, "Unknown"))
+	require.Nil(item.Tags)
+	require.Equal(inventory.SyncStateUnknown, item.SyncState)
+}
+
+func TestExporter(t *testing.T) {
+	require := require.New(t)
+
+	reader := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		newBook("ns", "b", nil, "True"),
+		newBook("ns", "a", nil, "False"),
+	).Build()
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	exporter := inventory.NewExporter(
+		logr.Discard(), reader, &inventory.FileSink{Path: path}, inventory.FormatJSON,
+		time.Hour, []schema.GroupVersionKind{bookGVK},
+	)
+	require.Nil(exporter.Export(context.Background()))
+
+	body, err := os.ReadFile(path)
+	require.Nil(err)
+	exported := inventory.Inventory{}
+	require.Nil(json.Unmarshal(body, &exported))
+	require.Len(exported.Items, 2)
+	require.Equal("a", exported.Items[0].Name)
+	require.Equal("b", exported.Items[1].Name)
+
+	body, contentType, err := inventory.Encode(exported, inventory.FormatJSONLines)
+	require.Nil(err)
+	require.Equal("application/x-ndjson", contentType)
+	require.Len(strings.Split(strings.TrimSpace(string(body)), "\n"), 2)
+}
+
+func TestParseDestination(t *testing.T) {
+	require := require.New(t)
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = r.Method + " " + string(body)
+	}))
+	defer server.Close()
+	sink, err := inventory.ParseDestination(server.URL, nil)
+	require.Nil(err)
+	require.Nil(sink.Write(context.Background(), []byte("{}"), "application/json"))
+	require.Equal("PUT {}", received)
+
+	sink, err = inventory.ParseDestination("/var/lib/inventory.json", nil)
+	require.Nil(err)
+	require.Equal(&inventory.FileSink{Path: "/var/lib/inventory.json"}, sink)
+
+	sink, err = inventory.ParseDestination("s3://bucket/ack/inventory.json", func() (aws.Config, error) {
+		return aws.Config{Region: "us-west-2"}, nil
+	})
+	require.Nil(err)
+	require.Equal("bucket", sink.(*inventory.S3Sink).Bucket)
+	require.Equal("ack/inventory.json", sink.(*inventory.S3Sink).Key)
+
+	for _, destination := range []string{"inventory.json", "s3://bucket", "ftp://host/inventory"} {
+		_, err = inventory.ParseDestination(destination, nil)
+		require.NotNil(err, destination)
+	}
+	_, err = inventory.ParseDestination("s3://bucket/key", nil)
+	require.NotNil(err)
+	_, err = inventory.ParseFormat("csv")
+	require.NotNil(err)
+}
@@ -0,0 +1,173 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// sinkTimeout is the timeout of a single write to an HTTP or S3 sink
+const sinkTimeout = 30 * time.Second
+
+// ParseDestination returns the sink of the supplied destination, which is
+// either an absolute file path, an http(s):// URL receiving the inventory
+// with a PUT request, or an s3://bucket/key URL. The S3 sink signs its
+// requests with the credentials of the supplied AWS configuration, which is
+// only loaded for S3 destinations.
+func ParseDestination(
+	destination string,
+	awsConfig func() (aws.Config, error),
+) (Sink, error) {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inventory destination %q: %v", destination, err)
+	}
+	switch u.Scheme {
+	case "":
+		if !filepath.IsAbs(destination) {
+			return nil, fmt.Errorf("invalid inventory destination %q: file paths must be absolute", destination)
+		}
+		return &FileSink{Path: destination}, nil
+	case "http", "https":
+		return &HTTPSink{URL: destination, Client: &http.Client{Timeout: sinkTimeout}}, nil
+	case "s3":
+		key := strings.TrimPrefix(u.Path, "/")
+		if u.Host == "" || key == "" {
+			return nil, fmt.Errorf("invalid inventory destination %q: expected s3://bucket/key", destination)
+		}
+		if awsConfig == nil {
+			return nil, fmt.Errorf("invalid inventory destination %q: no AWS configuration", destination)
+		}
+		cfg, err := awsConfig()
+		if err != nil {
+			return nil, err
+		}
+		return &S3Sink{Bucket: u.Host, Key: key, Config: cfg}, nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid inventory destination %q: expected a file path, an http(s) URL or an s3 URL", destination,
+		)
+	}
+}
+
+// FileSink writes the inventory to a local file, atomically replacing the
+// previous inventory
+type FileSink struct {
+	Path string
+}
+
+// Write implements Sink
+func (s *FileSink) Write(_ context.Context, body []byte, _ string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// HTTPSink sends the inventory to an HTTP endpoint with a PUT request
+type HTTPSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// Write implements Sink
+func (s *HTTPSink) Write(ctx context.Context, body []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return do(s.Client, req)
+}
+
+// S3Sink writes the inventory to an S3 object
+type S3Sink struct {
+	Bucket string
+	Key    string
+	Config aws.Config
+}
+
+// Write implements Sink
+func (s *S3Sink) Write(ctx context.Context, body []byte, contentType string) error {
+	ctx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+	endpoint := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", s.Bucket, s.Config.Region)
+	if s.Config.BaseEndpoint != nil && *s.Config.BaseEndpoint != "" {
+		endpoint = strings.TrimSuffix(*s.Config.BaseEndpoint, "/") + "/" + s.Bucket
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, endpoint+"/"+(&url.URL{Path: s.Key}).EscapedPath(), bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.Config.Credentials == nil {
+		return fmt.Errorf("no AWS credentials to write to s3://%s/%s", s.Bucket, s.Key)
+	}
+	creds, err := s.Config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving AWS credentials: %v", err)
+	}
+	err = v4.NewSigner().SignHTTP(ctx, creds, req, payloadHash, "s3", s.Config.Region, time.Now())
+	if err != nil {
+		return err
+	}
+	client := s.Config.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	return do(client, req)
+}
+
+// do sends the supplied request and returns an error if it fails or the
+// response status is not successful
+func do(client interface {
+	Do(*http.Request) (*http.Response, error)
+}, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s responded with status %d", req.Method, req.URL.Redacted(), resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"os"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// writeKubeStateMetricsConfig writes to the supplied file the
+// kube-state-metrics CustomResourceState configuration of the kinds of the
+// supplied resource manager factories
+func writeKubeStateMetricsConfig(
+	path string,
+	rmfs map[string]acktypes.AWSResourceManagerFactory,
+) error {
+	gvks := make([]schema.GroupVersionKind, 0, len(rmfs))
+	for _, rmf := range rmfs {
+		gvks = append(gvks, rmf.ResourceDescriptor().GroupVersionKind())
+	}
+	sort.Slice(gvks, func(i, j int) bool {
+		return gvks[i].String() < gvks[j].String()
+	})
+	body, err := ackmetrics.KubeStateMetricsConfig(gvks).Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0o644)
+}
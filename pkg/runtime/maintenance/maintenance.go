@@ -0,0 +1,78 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package maintenance contains the maintenance windows outside of which the
+// reconcilers only read the AWS resources, their creations, updates and
+// deletions being deferred until the next window opens.
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Window is a recurring maintenance window, e.g. "0 2 * * SAT 4h" for four
+// hours every Saturday from 2am
+type Window struct {
+	spec     string
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// Parse parses a maintenance window of the form "<schedule> <duration>",
+// where schedule is a standard cron expression of the times the window
+// opens, optionally prefixed with a CRON_TZ=<time zone> specification or
+// replaced with a descriptor such as @daily, and duration is the Go duration
+// for which the window stays open, e.g. "0 2 * * SAT 4h".
+func Parse(value string) (*Window, error) {
+	value = strings.TrimSpace(value)
+	i := strings.LastIndexAny(value, " \t")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid maintenance window %q: expected \"<schedule> <duration>\"", value)
+	}
+	duration, err := time.ParseDuration(value[i+1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration of maintenance window %q: %v", value, err)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("invalid duration of maintenance window %q: must be greater than 0", value)
+	}
+	schedule, err := cron.ParseStandard(strings.TrimSpace(value[:i]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule of maintenance window %q: %v", value, err)
+	}
+	return &Window{spec: value, schedule: schedule, duration: duration}, nil
+}
+
+// String returns the specification of the window
+func (w *Window) String() string {
+	return w.spec
+}
+
+// Open returns true if the window is open at the supplied time, i.e. the
+// window last opened less than its duration ago
+func (w *Window) Open(now time.Time) bool {
+	return !w.schedule.Next(now.Add(-w.duration)).After(now)
+}
+
+// Next returns the time the window next opens after the supplied time, or
+// the supplied time itself if the window is open
+func (w *Window) Next(now time.Time) time.Time {
+	if w.Open(now) {
+		return now
+	}
+	return w.schedule.Next(now)
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package maintenance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/maintenance"
+)
+
+func TestWindow(t *testing.T) {
+	require := require.New(t)
+
+	// Saturday 2024-06-01, from 2am to 6am UTC
+	window, err := maintenance.Parse("CRON_TZ=UTC 0 2 * * SAT 4h")
+	require.Nil(err)
+	saturday := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	require.False(window.Open(saturday.Add(time.Hour)))
+	require.Equal(saturday.Add(2*time.Hour), window.Next(saturday.Add(time.Hour)))
+
+	require.True(window.Open(saturday.Add(2 * time.Hour)))
+	require.True(window.Open(saturday.Add(5 * time.Hour)))
+	require.Equal(saturday.Add(5*time.Hour), window.Next(saturday.Add(5*time.Hour)))
+
+	require.False(window.Open(saturday.Add(6 * time.Hour)))
+	require.Equal(saturday.Add(7*24*time.Hour+2*time.Hour), window.Next(saturday.Add(6*time.Hour)))
+}
+
+func TestParse(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"4h",
+		"0 2 * * SAT",
+		"0 2 * * SAT -1h",
+		"0 2 * * NOPE 4h",
+	} {
+		_, err := maintenance.Parse(value)
+		require.NotNil(t, err, value)
+	}
+	window, err := maintenance.Parse("@daily 30m")
+	require.Nil(t, err)
+	require.Equal(t, "@daily 30m", window.String())
+}
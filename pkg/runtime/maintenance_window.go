@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/maintenance"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// MaintenanceWindowReason is the reason of the ACK.Deferred condition of the
+// resources whose changes are deferred until their maintenance window
+const MaintenanceWindowReason = "MaintenanceWindow"
+
+// errOutsideMaintenanceWindow is the error of the changes of the AWS
+// resources deferred until their maintenance window opens
+var errOutsideMaintenanceWindow = errors.New("change deferred until the next maintenance window")
+
+// newMaintenanceWindow returns the maintenance window of the
+// --maintenance-window flag, or nil
+func newMaintenanceWindow(cfg ackcfg.Config) *maintenance.Window {
+	if cfg.MaintenanceWindow == "" {
+		return nil
+	}
+	// The flag was validated with the configuration
+	window, _ := maintenance.Parse(cfg.MaintenanceWindow)
+	return window
+}
+
+// getMaintenanceWindow returns the maintenance window of the supplied
+// resource: the one of the services.k8s.aws/maintenance-window annotation of
+// its namespace, else the one of the --maintenance-window flag. It returns
+// nil if the resource has no maintenance window. An invalid annotation is
+// ignored.
+func (r *resourceReconciler) getMaintenanceWindow(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) *maintenance.Window {
+	value, ok := r.cache.Namespaces.GetMaintenanceWindow(res.MetaObject().GetNamespace())
+	if !ok {
+		return r.maintenanceWindow
+	}
+	window, err := maintenance.Parse(value)
+	if err != nil {
+		ackrtlog.FromContext(ctx).Info("ignoring the maintenance window of the namespace", "error", err)
+		return r.maintenanceWindow
+	}
+	return window
+}
+
+// deferChange returns a copy of the supplied resource with its ACK.Deferred
+// condition set, and a RequeueNeededAfter error reconciling it again when its
+// maintenance window opens, if the supplied operation on its AWS resource
+// must wait for the window. It returns nil and no error if the operation can
+// proceed, removing the ACK.Deferred condition of the supplied resource.
+func (r *resourceReconciler) deferChange(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	op acktypes.AWSResourceOperation,
+) (acktypes.AWSResource, error) {
+	window := r.getMaintenanceWindow(ctx, res)
+	if window == nil {
+		return nil, nil
+	}
+	now := time.Now()
+	next := window.Next(now)
+	if !next.After(now) {
+		ackcondition.RemoveDeferred(res)
+		return nil, nil
+	}
+	message := fmt.Sprintf(
+		"%s of the AWS resource deferred until the maintenance window %q opens at %s",
+		op, window, next.UTC().Format(time.RFC3339),
+	)
+	reason := MaintenanceWindowReason
+	ackrtlog.FromContext(ctx).Info("change deferred", "operation", op, "until", next)
+	r.recordEvent(res.RuntimeObject(), ackevents.ReasonChangeDeferred, message)
+	latest := res.DeepCopy()
+	ackcondition.SetDeferred(latest, &message, &reason)
+	ackcondition.SetSynced(latest, corev1.ConditionFalse, &ackcondition.NotSyncedMessage, &reason)
+	return latest, requeue.NeededAfter(errOutsideMaintenanceWindow, next.Sub(now))
+}
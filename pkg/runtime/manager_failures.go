@@ -0,0 +1,124 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/managerbackoff"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// resourceManagerFor returns the resource manager of the supplied account and
+// IAM role whose AWS service clients are constructed from the supplied
+// configuration, and call the endpoints of its region. While the construction of the manager is backed off
+// after a failure, the error of the failure is returned without constructing it
+// again, along with the delay before the next construction. The namespaces
+// of the resources hitting the failure get an event, once per failure.
+func (r *resourceReconciler) resourceManagerFor(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	clientConfig aws.Config,
+	acctID ackv1alpha1.AWSAccountID,
+	roleARN ackv1alpha1.AWSResourceName,
+) (acktypes.AWSResourceManager, time.Duration, error) {
+	key := managerbackoff.NewKey(acctID, clientConfig, roleARN)
+	namespace := desired.MetaObject().GetNamespace()
+	if err, after, notify := r.managerFailures.Blocked(key, namespace); err != nil {
+		if notify {
+			r.recordManagerUnavailable(ctx, namespace, key, err)
+		}
+		return nil, after, err
+	}
+	rm, err := managerbackoff.ManagerFor(r.rmf, r.cfg, clientConfig, r.log, r.metrics, r, key)
+	if err != nil {
+		err = fmt.Errorf(
+			"unable to construct the %s resource manager for %s: %w",
+			r.rd.GroupVersionKind().Kind, managerDescription(key), err,
+		)
+		after, notify := r.managerFailures.RecordFailure(key, namespace, err)
+		ackrtlog.FromContext(ctx).Info(
+			"backing off resource manager construction",
+			"error", err,
+			"after", after,
+		)
+		if notify {
+			r.recordManagerUnavailable(ctx, namespace, key, err)
+		}
+		return nil, after, err
+	}
+	for _, notified := range r.managerFailures.RecordSuccess(key) {
+		r.recordNamespaceEvent(
+			ctx, notified, ackevents.ReasonResourceManagerRecovered,
+			fmt.Sprintf(
+				"The %s resource manager for %s could be constructed again",
+				r.rd.GroupVersionKind().Kind, managerDescription(key),
+			),
+		)
+	}
+	return rm, 0, nil
+}
+
+// recordManagerUnavailable emits an event on the supplied namespace for the
+// failure to construct the resource manager of the supplied key
+func (r *resourceReconciler) recordManagerUnavailable(
+	ctx context.Context,
+	namespace string,
+	key managerbackoff.Key,
+	err error,
+) {
+	r.recordNamespaceEvent(
+		ctx, namespace, ackevents.ReasonResourceManagerUnavailable,
+		fmt.Sprintf(
+			"The %s resources of %s are not reconciled: %v",
+			r.rd.GroupVersionKind().Kind, managerDescription(key), err,
+		),
+	)
+}
+
+// managerDescription returns the description of the resource managers of
+// the supplied key, for the events and errors
+func managerDescription(key managerbackoff.Key) string {
+	description := fmt.Sprintf("account %s in region %s", key.Account, key.Region)
+	if key.RoleARN != "" {
+		description += " with role " + key.RoleARN
+	}
+	return description
+}
+
+// handleManagerUnavailable sets the ResourceSynced condition of the supplied
+// resource, whose resource manager could not be constructed, and requeues it
+// once the construction is not backed off anymore
+func (r *resourceReconciler) handleManagerUnavailable(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	err error,
+	after time.Duration,
+) (ctrlrt.Result, error) {
+	reason := err.Error()
+	latest := desired.DeepCopy()
+	condition.SetSynced(latest, corev1.ConditionFalse, &condition.ResourceManagerUnavailableMessage, &reason)
+	return r.HandleReconcileError(ctx, desired, latest, requeue.NeededAfter(err, after))
+}
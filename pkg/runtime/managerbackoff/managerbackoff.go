@@ -0,0 +1,249 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package managerbackoff tracks, for the reconciler of a kind, the resource
+// managers that could not be constructed, e.g. because of invalid
+// credentials or an unsupported region, and backs off their construction for
+// all the resources sharing them, rather than failing for each of them.
+//
+// The resource managers are identified like the service controllers cache
+// them: by AWS account, region and IAM role, so that a failing role does not
+// block the resources reconciled with the other roles of its account and
+// region. The region is the region of the AWS service clients of the
+// managers, which differs from the region of the resources when the
+// endpoints of a global service fail over to another region.
+package managerbackoff
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrtstatedump "github.com/aws-controllers-k8s/runtime/pkg/runtime/statedump"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// BaseDelay is the delay before a resource manager is constructed again
+	// after a first failure. It doubles on each consecutive failure.
+	BaseDelay = 5 * time.Second
+	// MaxDelay is the maximum delay before a resource manager is constructed
+	// again
+	MaxDelay = 5 * time.Minute
+)
+
+// Key identifies the resource managers of a kind
+type Key struct {
+	Account string
+	Region  string
+	RoleARN string
+}
+
+// NewKey returns the key of the resource manager of the supplied account and
+// IAM role whose AWS service clients are constructed from the supplied
+// configuration. The region of the key is the region of the clients, so that
+// a manager cached with the clients of a region is not reused once the
+// endpoints of its global service fail over to another region.
+func NewKey(
+	account ackv1alpha1.AWSAccountID,
+	clientConfig aws.Config,
+	roleARN ackv1alpha1.AWSResourceName,
+) Key {
+	return Key{Account: string(account), Region: clientConfig.Region, RoleARN: string(roleARN)}
+}
+
+// ManagerFor returns the resource manager of the supplied key, whose AWS
+// service clients are constructed from the supplied configuration, from the
+// supplied factory, which constructs it or returns the one it cached for the
+// key
+func ManagerFor(
+	rmf acktypes.AWSResourceManagerFactory,
+	cfg ackcfg.Config,
+	clientConfig aws.Config,
+	log logr.Logger,
+	metrics *ackmetrics.Metrics,
+	rr acktypes.Reconciler,
+	key Key,
+) (acktypes.AWSResourceManager, error) {
+	return rmf.ManagerFor(
+		cfg, clientConfig, log, metrics, rr,
+		ackv1alpha1.AWSAccountID(key.Account),
+		ackv1alpha1.AWSRegion(key.Region),
+		ackv1alpha1.AWSResourceName(key.RoleARN),
+	)
+}
+
+// failure is the failure to construct a resource manager
+type failure struct {
+	// err is the error of the last construction
+	err error
+	// failures is the number of consecutive failed constructions
+	failures int
+	// retryAt is the time before which the manager is not constructed again
+	retryAt time.Time
+	// namespaces are the namespaces notified of the failure
+	namespaces map[string]struct{}
+}
+
+// Tracker tracks the failed constructions of the resource managers of a
+// kind. A nil Tracker tracks nothing.
+type Tracker struct {
+	sync.Mutex
+	failures map[Key]*failure
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// New returns an empty Tracker
+func New() *Tracker {
+	return &Tracker{
+		failures: map[Key]*failure{},
+		now:      time.Now,
+	}
+}
+
+// WithClock sets the function returning the current time of the Tracker
+func (t *Tracker) WithClock(now func() time.Time) *Tracker {
+	t.now = now
+	return t
+}
+
+// Blocked returns the error of the last failed construction of the resource
+// manager of the supplied key, and the delay before it is constructed again,
+// if it is backed off. It also returns whether the supplied namespace was not
+// notified of the failure yet.
+func (t *Tracker) Blocked(
+	key Key,
+	namespace string,
+) (err error, after time.Duration, notify bool) {
+	if t == nil {
+		return nil, 0, false
+	}
+	t.Lock()
+	defer t.Unlock()
+	f, ok := t.failures[key]
+	if !ok {
+		return nil, 0, false
+	}
+	after = f.retryAt.Sub(t.now())
+	if after <= 0 {
+		return nil, 0, false
+	}
+	return f.err, after, f.notify(namespace)
+}
+
+// RecordFailure records a failed construction of the resource manager of the
+// supplied key for a resource of the supplied namespace. It returns the delay
+// before the manager is constructed again, and whether the namespace was not
+// notified of the failure yet.
+func (t *Tracker) RecordFailure(
+	key Key,
+	namespace string,
+	err error,
+) (after time.Duration, notify bool) {
+	if t == nil {
+		return BaseDelay, false
+	}
+	t.Lock()
+	defer t.Unlock()
+	f, ok := t.failures[key]
+	if !ok {
+		f = &failure{namespaces: map[string]struct{}{}}
+		t.failures[key] = f
+	}
+	f.err = err
+	f.failures++
+	after = MaxDelay
+	if f.failures <= 16 {
+		after = BaseDelay << (f.failures - 1)
+		if after > MaxDelay {
+			after = MaxDelay
+		}
+	}
+	f.retryAt = t.now().Add(after)
+	return after, f.notify(namespace)
+}
+
+// RecordSuccess records a successful construction of the resource manager of
+// the supplied key, and returns the sorted namespaces that were notified of
+// its failure
+func (t *Tracker) RecordSuccess(key Key) []string {
+	if t == nil {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+	f, ok := t.failures[key]
+	if !ok {
+		return nil
+	}
+	delete(t.failures, key)
+	return f.sortedNamespaces()
+}
+
+// Snapshot returns the failures, sorted by account, region and IAM role
+func (t *Tracker) Snapshot() []ackrtstatedump.ManagerFailure {
+	if t == nil {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+	snapshot := make([]ackrtstatedump.ManagerFailure, 0, len(t.failures))
+	for key, f := range t.failures {
+		snapshot = append(snapshot, ackrtstatedump.ManagerFailure{
+			Account:    key.Account,
+			Region:     key.Region,
+			RoleARN:    key.RoleARN,
+			Error:      f.err.Error(),
+			Failures:   f.failures,
+			RetryAt:    f.retryAt,
+			Namespaces: f.sortedNamespaces(),
+		})
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Account != snapshot[j].Account {
+			return snapshot[i].Account < snapshot[j].Account
+		}
+		if snapshot[i].Region != snapshot[j].Region {
+			return snapshot[i].Region < snapshot[j].Region
+		}
+		return snapshot[i].RoleARN < snapshot[j].RoleARN
+	})
+	return snapshot
+}
+
+// notify records that the supplied namespace is notified of the failure, and
+// returns whether it was not already
+func (f *failure) notify(namespace string) bool {
+	if _, ok := f.namespaces[namespace]; ok {
+		return false
+	}
+	f.namespaces[namespace] = struct{}{}
+	return true
+}
+
+// sortedNamespaces returns the sorted namespaces notified of the failure
+func (f *failure) sortedNamespaces() []string {
+	namespaces := make([]string, 0, len(f.namespaces))
+	for namespace := range f.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
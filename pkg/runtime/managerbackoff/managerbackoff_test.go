@@ -0,0 +1,208 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package managerbackoff_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/managerbackoff"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/regionhealth"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	mocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// cachingFactory caches the resource managers it constructs by account,
+// region and IAM role, like the factories of the service controllers
+type cachingFactory struct {
+	mocks.AWSResourceManagerFactory
+	managers map[string]*managerWithClients
+}
+
+// managerWithClients is a resource manager along with the configuration of
+// the AWS service clients it was constructed with
+type managerWithClients struct {
+	mocks.AWSResourceManager
+	clientConfig aws.Config
+}
+
+func (f *cachingFactory) ManagerFor(
+	_ ackcfg.Config,
+	clientConfig aws.Config,
+	_ logr.Logger,
+	_ *ackmetrics.Metrics,
+	_ acktypes.Reconciler,
+	id ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+	roleARN ackv1alpha1.AWSResourceName,
+) (acktypes.AWSResourceManager, error) {
+	key := fmt.Sprintf("%s/%s/%s", id, region, roleARN)
+	if rm, ok := f.managers[key]; ok {
+		return rm, nil
+	}
+	rm := &managerWithClients{clientConfig: clientConfig}
+	f.managers[key] = rm
+	return rm, nil
+}
+
+func TestTracker_Backoff(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := managerbackoff.New().WithClock(func() time.Time { return now })
+	key := managerbackoff.Key{Account: "111122223333", Region: "us-west-2", RoleARN: "arn:aws:iam::111122223333:role/a"}
+	errExpired := errors.New("ExpiredToken")
+
+	err, _, _ := tracker.Blocked(key, "ns-a")
+	require.Nil(err)
+
+	// The delay doubles on each consecutive failure
+	after, notify := tracker.RecordFailure(key, "ns-a", errExpired)
+	require.Equal(managerbackoff.BaseDelay, after)
+	require.True(notify)
+	after, notify = tracker.RecordFailure(key, "ns-a", errExpired)
+	require.Equal(2*managerbackoff.BaseDelay, after)
+	require.False(notify)
+
+	// The namespaces are notified once
+	err, after, notify = tracker.Blocked(key, "ns-b")
+	require.Equal(errExpired, err)
+	require.Equal(2*managerbackoff.BaseDelay, after)
+	require.True(notify)
+	err, _, notify = tracker.Blocked(key, "ns-b")
+	require.Equal(errExpired, err)
+	require.False(notify)
+
+	// The construction is retried once the delay elapsed
+	now = now.Add(2 * managerbackoff.BaseDelay)
+	err, _, _ = tracker.Blocked(key, "ns-a")
+	require.Nil(err)
+
+	// The delay is capped
+	for i := 0; i < 20; i++ {
+		after, _ = tracker.RecordFailure(key, "ns-a", errExpired)
+	}
+	require.Equal(managerbackoff.MaxDelay, after)
+
+	snapshot := tracker.Snapshot()
+	require.Len(snapshot, 1)
+	require.Equal("arn:aws:iam::111122223333:role/a", snapshot[0].RoleARN)
+	require.Equal(22, snapshot[0].Failures)
+	require.Equal([]string{"ns-a", "ns-b"}, snapshot[0].Namespaces)
+	require.Equal(now.Add(managerbackoff.MaxDelay), snapshot[0].RetryAt)
+}
+
+func TestTracker_Reset(t *testing.T) {
+	require := require.New(t)
+
+	tracker := managerbackoff.New()
+	key := managerbackoff.Key{Account: "111122223333", Region: "us-west-2"}
+	require.Nil(tracker.RecordSuccess(key))
+
+	tracker.RecordFailure(key, "ns-b", errors.New("UnrecognizedClientException"))
+	tracker.RecordFailure(key, "ns-a", errors.New("UnrecognizedClientException"))
+
+	// The notified namespaces are returned once the manager is constructed
+	require.Equal([]string{"ns-a", "ns-b"}, tracker.RecordSuccess(key))
+	err, _, _ := tracker.Blocked(key, "ns-a")
+	require.Nil(err)
+	require.Empty(tracker.Snapshot())
+
+	// The backoff starts over after a reset
+	after, notify := tracker.RecordFailure(key, "ns-a", errors.New("UnrecognizedClientException"))
+	require.Equal(managerbackoff.BaseDelay, after)
+	require.True(notify)
+}
+
+func TestTracker_RoleIsolation(t *testing.T) {
+	require := require.New(t)
+
+	tracker := managerbackoff.New()
+	failing := managerbackoff.Key{Account: "111122223333", Region: "us-west-2", RoleARN: "arn:aws:iam::111122223333:role/bad"}
+	healthy := managerbackoff.Key{Account: "111122223333", Region: "us-west-2", RoleARN: "arn:aws:iam::111122223333:role/good"}
+	controller := managerbackoff.Key{Account: "111122223333", Region: "us-west-2"}
+
+	tracker.RecordFailure(failing, "ns-a", errors.New("AccessDenied"))
+
+	// A failing role does not block the other roles of its account and
+	// region, nor the credentials of the controller
+	err, _, _ := tracker.Blocked(failing, "ns-a")
+	require.NotNil(err)
+	err, _, _ = tracker.Blocked(healthy, "ns-a")
+	require.Nil(err)
+	err, _, _ = tracker.Blocked(controller, "ns-a")
+	require.Nil(err)
+}
+
+func TestTracker_Nil(t *testing.T) {
+	require := require.New(t)
+
+	var tracker *managerbackoff.Tracker
+	key := managerbackoff.Key{Account: "111122223333", Region: "us-west-2"}
+	after, notify := tracker.RecordFailure(key, "ns-a", errors.New("AccessDenied"))
+	require.Equal(managerbackoff.BaseDelay, after)
+	require.False(notify)
+	err, _, _ := tracker.Blocked(key, "ns-a")
+	require.Nil(err)
+	require.Nil(tracker.RecordSuccess(key))
+	require.Nil(tracker.Snapshot())
+}
+
+func TestManagerFor_Failover(t *testing.T) {
+	require := require.New(t)
+
+	rmf := &cachingFactory{managers: map[string]*managerWithClients{}}
+	health := regionhealth.NewTracker()
+	failover := []string{"us-west-2"}
+	managerFor := func() *managerWithClients {
+		clientConfig := aws.Config{Region: health.Choose("us-east-1", failover)}
+		key := managerbackoff.NewKey("111122223333", clientConfig, "arn:aws:iam::111122223333:role/a")
+		rm, err := managerbackoff.ManagerFor(rmf, ackcfg.Config{}, clientConfig, logr.Discard(), nil, nil, key)
+		require.NoError(err)
+		return rm.(*managerWithClients)
+	}
+
+	primary := managerFor()
+	require.Equal("us-east-1", primary.clientConfig.Region)
+	require.Same(primary, managerFor())
+
+	// The manager cached with the clients of the failing region is not
+	// reused once the endpoints fail over
+	for i := 0; i < 3; i++ {
+		health.Record("us-east-1", time.Second, &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}},
+			Err:      errors.New("service unavailable"),
+		})
+	}
+	failedOver := managerFor()
+	require.NotSame(primary, failedOver)
+	require.Equal("us-west-2", failedOver.clientConfig.Region)
+	require.Same(failedOver, managerFor())
+
+	// The manager of the region is reused once its endpoints recover
+	health.Record("us-east-1", time.Second, nil)
+	require.Same(primary, managerFor())
+}
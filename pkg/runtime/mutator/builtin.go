@@ -0,0 +1,152 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mutator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// EnforceTags sets the tags of its parameters, keyed by tag key, in the
+	// tags of the spec, overriding the values of the existing ones
+	EnforceTags = "enforce-tags"
+	// EnforceKMS sets the spec field of the "field" parameter, "kmsKeyID" by
+	// default, to the KMS key of the "keyID" parameter
+	EnforceKMS = "enforce-kms"
+	// NamePrefix prefixes the spec field of the "field" parameter, "name" by
+	// default, with the "prefix" parameter, unless it already starts with
+	// it. Resources without the field are left unchanged.
+	NamePrefix = "name-prefix"
+	// SubnetInjection sets the spec field of the "field" parameter,
+	// "subnetIDs" by default, to the comma-separated subnet IDs of the
+	// "subnetIDs" parameter, if it is empty
+	SubnetInjection = "subnet-injection"
+)
+
+func init() {
+	MustRegister(EnforceTags, enforceTags)
+	MustRegister(EnforceKMS, enforceKMS)
+	MustRegister(NamePrefix, namePrefix)
+	MustRegister(SubnetInjection, subnetInjection)
+}
+
+// fieldPath returns the path, in the spec, of the field of the "field"
+// parameter, a dot-separated path, or of the supplied default field
+func fieldPath(params map[string]string, defaultField string) []string {
+	field := params["field"]
+	if field == "" {
+		field = defaultField
+	}
+	return strings.Split(field, ".")
+}
+
+// requiredParam returns the parameter with the supplied name, or an error if
+// it is empty
+func requiredParam(params map[string]string, name string) (string, error) {
+	value := params[name]
+	if value == "" {
+		return "", fmt.Errorf("missing parameter %q", name)
+	}
+	return value, nil
+}
+
+// enforceTags implements EnforceTags. The tags of the spec are either a map,
+// or a list of objects with a key and a value, the default when the spec has
+// no tags.
+func enforceTags(spec map[string]interface{}, params map[string]string) error {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	switch tags := spec["tags"].(type) {
+	case map[string]interface{}:
+		for _, key := range keys {
+			tags[key] = params[key]
+		}
+	case []interface{}:
+		spec["tags"] = enforceTagList(tags, keys, params)
+	case nil:
+		spec["tags"] = enforceTagList(nil, keys, params)
+	default:
+		return fmt.Errorf("unsupported tags of type %T", tags)
+	}
+	return nil
+}
+
+// enforceTagList sets the tags of the supplied keys in the supplied list of
+// tag objects, and returns the list
+func enforceTagList(tags []interface{}, keys []string, params map[string]string) []interface{} {
+	for _, key := range keys {
+		found := false
+		for _, t := range tags {
+			tag, ok := t.(map[string]interface{})
+			if ok && tag["key"] == key {
+				tag["value"] = params[key]
+				found = true
+			}
+		}
+		if !found {
+			tags = append(tags, map[string]interface{}{"key": key, "value": params[key]})
+		}
+	}
+	return tags
+}
+
+// enforceKMS implements EnforceKMS
+func enforceKMS(spec map[string]interface{}, params map[string]string) error {
+	keyID, err := requiredParam(params, "keyID")
+	if err != nil {
+		return err
+	}
+	return k8sunstructured.SetNestedField(spec, keyID, fieldPath(params, "kmsKeyID")...)
+}
+
+// namePrefix implements NamePrefix
+func namePrefix(spec map[string]interface{}, params map[string]string) error {
+	prefix, err := requiredParam(params, "prefix")
+	if err != nil {
+		return err
+	}
+	path := fieldPath(params, "name")
+	name, found, err := k8sunstructured.NestedString(spec, path...)
+	if err != nil || !found || strings.HasPrefix(name, prefix) {
+		return err
+	}
+	return k8sunstructured.SetNestedField(spec, prefix+name, path...)
+}
+
+// subnetInjection implements SubnetInjection
+func subnetInjection(spec map[string]interface{}, params map[string]string) error {
+	value, err := requiredParam(params, "subnetIDs")
+	if err != nil {
+		return err
+	}
+	path := fieldPath(params, "subnetIDs")
+	existing, _, err := k8sunstructured.NestedSlice(spec, path...)
+	if err != nil || len(existing) > 0 {
+		return err
+	}
+	subnetIDs := []interface{}{}
+	for _, subnetID := range strings.Split(value, ",") {
+		if subnetID = strings.TrimSpace(subnetID); subnetID != "" {
+			subnetIDs = append(subnetIDs, subnetID)
+		}
+	}
+	return k8sunstructured.SetNestedSlice(spec, subnetIDs, path...)
+}
@@ -0,0 +1,156 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package mutator contains the desired state mutators: named functions
+// enforcing the guardrails of a tenant, e.g. mandatory tags or a KMS key, on
+// the spec of its resources. Namespaces chain the registered mutators with
+// the services.k8s.aws/mutators annotation, and the reconciler applies the
+// chain of the namespace of a resource to its desired state before comparing
+// it with, or creating, the AWS resource.
+package mutator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// Func mutates the supplied spec of a resource, as unstructured content,
+// with the supplied parameters
+type Func func(spec map[string]interface{}, params map[string]string) error
+
+// Step is a mutator of a chain
+type Step struct {
+	// Name is the name of the registered mutator
+	Name string `json:"name"`
+	// Params are the parameters of the mutator
+	Params map[string]string `json:"params,omitempty"`
+	// Kinds are the kinds of the resources the mutator applies to. It
+	// applies to all the kinds if it is empty.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// appliesTo returns true if the step applies to the resources of the
+// supplied kind
+func (s Step) appliesTo(kind string) bool {
+	if len(s.Kinds) == 0 {
+		return true
+	}
+	for _, k := range s.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Chain is a list of mutators applied in order
+type Chain []Step
+
+// nameRegexp matches valid mutator names
+var nameRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var (
+	registryLock sync.RWMutex
+	// registry holds the registered mutators
+	registry = map[string]Func{}
+)
+
+// Register adds a mutator to the registry, so that namespaces can chain it.
+// Names are lowercase words separated by dashes, e.g. "enforce-tags".
+// Registering a name twice is an error.
+func Register(name string, fn Func) error {
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid mutator name %q: expected lowercase words separated by dashes", name)
+	}
+	if fn == nil {
+		return fmt.Errorf("invalid mutator %q: nil function", name)
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("mutator %q is already registered", name)
+	}
+	registry[name] = fn
+	return nil
+}
+
+// MustRegister is like Register but panics if the mutator cannot be
+// registered
+func MustRegister(name string, fn Func) {
+	if err := Register(name, fn); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the registered mutator with the supplied name
+func Lookup(name string) (Func, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	fn, ok := registry[name]
+	return fn, ok
+}
+
+// Names returns the sorted names of the registered mutators
+func Names() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse parses the supplied value of the services.k8s.aws/mutators
+// annotation, a JSON list of steps, e.g.
+// `[{"name":"enforce-tags","params":{"team":"payments"}}]`. The mutators of
+// the steps must be registered.
+func Parse(value string) (Chain, error) {
+	chain := Chain{}
+	if err := json.Unmarshal([]byte(value), &chain); err != nil {
+		return nil, fmt.Errorf("invalid mutator chain: %v", err)
+	}
+	for i, step := range chain {
+		if _, ok := Lookup(step.Name); !ok {
+			return nil, fmt.Errorf(
+				"invalid mutator chain: step %d: unknown mutator %q, expected one of %v",
+				i, step.Name, Names(),
+			)
+		}
+	}
+	return chain, nil
+}
+
+// Apply applies, in order, the steps of the chain applying to the supplied
+// kind to the supplied spec, and returns the names of the applied mutators
+func (c Chain) Apply(kind string, spec map[string]interface{}) ([]string, error) {
+	applied := []string{}
+	for i, step := range c {
+		if !step.appliesTo(kind) {
+			continue
+		}
+		fn, ok := Lookup(step.Name)
+		if !ok {
+			return applied, fmt.Errorf("step %d: unknown mutator %q", i, step.Name)
+		}
+		if err := fn(spec, step.Params); err != nil {
+			return applied, fmt.Errorf("step %d: mutator %q: %v", i, step.Name, err)
+		}
+		applied = append(applied, step.Name)
+	}
+	return applied, nil
+}
@@ -0,0 +1,117 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mutator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/mutator"
+)
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	chain, err := mutator.Parse(`[{"name":"enforce-tags","params":{"team":"payments"}},{"name":"name-prefix","params":{"prefix":"payments-"},"kinds":["Bucket"]}]`)
+	require.Nil(err)
+	require.Len(chain, 2)
+	require.Equal(mutator.NamePrefix, chain[1].Name)
+	require.Equal([]string{"Bucket"}, chain[1].Kinds)
+
+	_, err = mutator.Parse(`{"name":"enforce-tags"}`)
+	require.NotNil(err)
+	_, err = mutator.Parse(`[{"name":"enforce-everything"}]`)
+	require.NotNil(err)
+	require.Contains(err.Error(), "enforce-kms")
+}
+
+func TestRegister(t *testing.T) {
+	require := require.New(t)
+
+	noop := func(map[string]interface{}, map[string]string) error { return nil }
+	require.NotNil(mutator.Register("Enforce_Tags", noop))
+	require.NotNil(mutator.Register(mutator.EnforceTags, noop))
+	require.NotNil(mutator.Register("noop", nil))
+
+	require.Nil(mutator.Register("test-fail", func(map[string]interface{}, map[string]string) error {
+		return errors.New("oops")
+	}))
+	_, ok := mutator.Lookup("test-fail")
+	require.True(ok)
+	chain, err := mutator.Parse(`[{"name":"enforce-kms","params":{"keyID":"alias/payments"}},{"name":"test-fail"}]`)
+	require.Nil(err)
+	applied, err := chain.Apply("Bucket", map[string]interface{}{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "step 1")
+	require.Equal([]string{mutator.EnforceKMS}, applied)
+}
+
+func TestChain_Apply(t *testing.T) {
+	require := require.New(t)
+
+	chain, err := mutator.Parse(`[
+		{"name":"enforce-tags","params":{"team":"payments","env":"prod"}},
+		{"name":"enforce-kms","params":{"keyID":"alias/payments","field":"encryption.kmsKeyID"}},
+		{"name":"name-prefix","params":{"prefix":"payments-"},"kinds":["Bucket"]},
+		{"name":"subnet-injection","params":{"subnetIDs":"subnet-a, subnet-b"},"kinds":["DBSubnetGroup"]}
+	]`)
+	require.Nil(err)
+
+	spec := map[string]interface{}{
+		"name": "invoices",
+		"tags": []interface{}{
+			map[string]interface{}{"key": "team", "value": "other"},
+			map[string]interface{}{"key": "owner", "value": "alice"},
+		},
+	}
+	applied, err := chain.Apply("Bucket", spec)
+	require.Nil(err)
+	require.Equal([]string{mutator.EnforceTags, mutator.EnforceKMS, mutator.NamePrefix}, applied)
+	require.Equal(map[string]interface{}{
+		"name": "payments-invoices",
+		"tags": []interface{}{
+			map[string]interface{}{"key": "team", "value": "payments"},
+			map[string]interface{}{"key": "owner", "value": "alice"},
+			map[string]interface{}{"key": "env", "value": "prod"},
+		},
+		"encryption": map[string]interface{}{"kmsKeyID": "alias/payments"},
+	}, spec)
+
+	// Mutating again leaves the spec unchanged
+	_, err = chain.Apply("Bucket", spec)
+	require.Nil(err)
+	require.Equal("payments-invoices", spec["name"])
+	require.Len(spec["tags"], 3)
+
+	// Tags may be a map, subnets are only injected if there are none
+	spec = map[string]interface{}{"tags": map[string]interface{}{"owner": "alice"}}
+	applied, err = chain.Apply("DBSubnetGroup", spec)
+	require.Nil(err)
+	require.Equal([]string{mutator.EnforceTags, mutator.EnforceKMS, mutator.SubnetInjection}, applied)
+	require.Equal(map[string]interface{}{"owner": "alice", "team": "payments", "env": "prod"}, spec["tags"])
+	require.Equal([]interface{}{"subnet-a", "subnet-b"}, spec["subnetIDs"])
+
+	spec = map[string]interface{}{"subnetIDs": []interface{}{"subnet-c"}}
+	_, err = chain.Apply("DBSubnetGroup", spec)
+	require.Nil(err)
+	require.Equal([]interface{}{"subnet-c"}, spec["subnetIDs"])
+
+	// Missing parameters fail the chain
+	chain, err = mutator.Parse(`[{"name":"name-prefix"}]`)
+	require.Nil(err)
+	_, err = chain.Apply("Bucket", map[string]interface{}{"name": "invoices"})
+	require.NotNil(err)
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/mutator"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// mutatorRequeueDelay is the delay after which a resource whose namespace
+// mutators failed is reconciled again
+const mutatorRequeueDelay = time.Minute
+
+// applyNamespaceMutators returns a copy of the supplied desired resource with
+// the mutators of the services.k8s.aws/mutators annotation of its namespace
+// applied to its spec, so that the guardrails of the namespace are enforced
+// before the desired state is compared with, or created as, the AWS
+// resource. An invalid chain, or a failing mutator, returns an error rather
+// than reconciling the resource without its guardrails.
+func (r *resourceReconciler) applyNamespaceMutators(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	value, ok := r.cache.Namespaces.GetMutators(desired.MetaObject().GetNamespace())
+	if !ok {
+		return desired, nil
+	}
+	chain, err := mutator.Parse(value)
+	if err != nil {
+		return desired, err
+	}
+	obj, err := UnstructuredConverter.ToUnstructured(desired.RuntimeObject())
+	if err != nil {
+		return desired, err
+	}
+	spec, _ := obj["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	applied, err := chain.Apply(r.rd.GroupVersionKind().Kind, spec)
+	if err != nil {
+		return desired, err
+	}
+	if len(applied) == 0 {
+		return desired, nil
+	}
+	obj["spec"] = spec
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		return desired, fmt.Errorf("converting the mutated resource: %v", err)
+	}
+	ackrtlog.FromContext(ctx).Debug("applied namespace mutators", "mutators", applied)
+	return r.rd.ResourceFromRuntimeObject(ro), nil
+}
+
+// handleMutatorError sets the ResourceSynced condition of the supplied
+// resource, whose namespace mutators failed, and requeues it
+func (r *resourceReconciler) handleMutatorError(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	err error,
+) (ctrlrt.Result, error) {
+	reason := fmt.Sprintf("unable to apply the mutators of the namespace: %v", err)
+	latest := desired.DeepCopy()
+	ackcondition.SetSynced(latest, corev1.ConditionFalse, &ackcondition.NotSyncedMessage, &reason)
+	return r.HandleReconcileError(ctx, desired, latest, requeue.NeededAfter(err, mutatorRequeueDelay))
+}
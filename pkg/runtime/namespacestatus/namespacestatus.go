@@ -0,0 +1,275 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package namespacestatus contains a writer of the aggregate status of the
+// ACK resources of each namespace, for tenants without access to the
+// controller metrics.
+package namespacestatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// Mode is the way the aggregate status of a namespace is written
+type Mode string
+
+const (
+	// ModeDisabled disables the aggregate namespace status
+	ModeDisabled Mode = ""
+	// ModeConfigMap writes the aggregate status of a namespace in a
+	// ConfigMap of the namespace
+	ModeConfigMap Mode = "configmap"
+	// ModeAnnotation writes the aggregate status of a namespace in an
+	// annotation of the Namespace
+	ModeAnnotation Mode = "annotation"
+)
+
+const (
+	// ConfigMapDataKey is the key of the aggregate status in the data of the
+	// ConfigMap written in ModeConfigMap
+	ConfigMapDataKey = "status.json"
+)
+
+// ParseMode parses the supplied status mode
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(value); mode {
+	case ModeDisabled, ModeConfigMap, ModeAnnotation:
+		return mode, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid namespace status mode %q: expected %q, %q or an empty string",
+			value, ModeConfigMap, ModeAnnotation,
+		)
+	}
+}
+
+// KindStatus counts the ACK resources of a kind in a namespace
+type KindStatus struct {
+	// Total is the number of resources
+	Total int `json:"total"`
+	// Synced is the number of resources whose ACK.ResourceSynced condition
+	// is True
+	Synced int `json:"synced"`
+	// Terminal is the number of resources whose ACK.Terminal condition is
+	// True
+	Terminal int `json:"terminal"`
+}
+
+// NamespaceStatus is the aggregate status of the ACK resources of a
+// namespace, keyed by kind
+type NamespaceStatus map[string]KindStatus
+
+// Summarize returns the aggregate status of the supplied resources, keyed by
+// namespace
+func Summarize(resources []k8sunstructured.Unstructured) map[string]NamespaceStatus {
+	summary := map[string]NamespaceStatus{}
+	for i := range resources {
+		u := &resources[i]
+		status, ok := summary[u.GetNamespace()]
+		if !ok {
+			status = NamespaceStatus{}
+			summary[u.GetNamespace()] = status
+		}
+		kind := status[u.GetKind()]
+		kind.Total++
+		if conditionTrue(u, ackv1alpha1.ConditionTypeResourceSynced) {
+			kind.Synced++
+		}
+		if conditionTrue(u, ackv1alpha1.ConditionTypeTerminal) {
+			kind.Terminal++
+		}
+		status[u.GetKind()] = kind
+	}
+	return summary
+}
+
+// conditionTrue returns whether the supplied resource has a condition of the
+// supplied type with a True status
+func conditionTrue(u *k8sunstructured.Unstructured, conditionType ackv1alpha1.ConditionType) bool {
+	conditions, _, _ := k8sunstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cm["type"] == string(conditionType) {
+			return cm["status"] == string(corev1.ConditionTrue)
+		}
+	}
+	return false
+}
+
+// Writer periodically writes the aggregate status of the ACK resources of
+// each namespace. Writer implements the controller-runtime manager.Runnable
+// interface and, as it writes to the Kubernetes API, only runs on the
+// leader.
+type Writer struct {
+	log      logr.Logger
+	kc       client.Client
+	reader   client.Reader
+	mode     Mode
+	name     string
+	interval time.Duration
+	gvks     []schema.GroupVersionKind
+	// written holds the last status written in each namespace, so that
+	// unchanged statuses are not written again
+	written map[string]string
+}
+
+// NewWriter returns a Writer writing, at the supplied interval, the
+// aggregate status of the resources of the supplied kinds. The status is
+// written in the ConfigMap, or the Namespace annotation, with the supplied
+// name.
+func NewWriter(
+	log logr.Logger,
+	kc client.Client,
+	reader client.Reader,
+	mode Mode,
+	name string,
+	interval time.Duration,
+	gvks []schema.GroupVersionKind,
+) *Writer {
+	return &Writer{
+		log:      log,
+		kc:       kc,
+		reader:   reader,
+		mode:     mode,
+		name:     name,
+		interval: interval,
+		gvks:     gvks,
+		written:  map[string]string{},
+	}
+}
+
+// Start writes the aggregate namespace statuses until the supplied context
+// is done
+func (w *Writer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Write(ctx); err != nil {
+			w.log.Error(err, "unable to write namespace status")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Write writes the aggregate status of the namespaces whose status changed
+// since the last call. Namespaces whose resources were all deleted get an
+// empty status.
+func (w *Writer) Write(ctx context.Context) error {
+	resources := []k8sunstructured.Unstructured{}
+	for _, gvk := range w.gvks {
+		list := &k8sunstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := w.reader.List(ctx, list); err != nil {
+			return fmt.Errorf("listing %s resources: %v", gvk.Kind, err)
+		}
+		for _, u := range list.Items {
+			u.SetGroupVersionKind(gvk)
+			resources = append(resources, u)
+		}
+	}
+	summary := Summarize(resources)
+	for namespace := range w.written {
+		if _, ok := summary[namespace]; !ok {
+			summary[namespace] = NamespaceStatus{}
+		}
+	}
+
+	namespaces := make([]string, 0, len(summary))
+	for namespace := range summary {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		// Marshaling a map of structs of ints cannot fail
+		js, _ := json.Marshal(summary[namespace])
+		value := string(js)
+		if w.written[namespace] == value {
+			continue
+		}
+		var err error
+		switch w.mode {
+		case ModeConfigMap:
+			err = w.writeConfigMap(ctx, namespace, value)
+		case ModeAnnotation:
+			err = w.writeAnnotation(ctx, namespace, value)
+		}
+		if err != nil {
+			return fmt.Errorf("writing status of namespace %s: %v", namespace, err)
+		}
+		if len(summary[namespace]) == 0 {
+			delete(w.written, namespace)
+		} else {
+			w.written[namespace] = value
+		}
+	}
+	return nil
+}
+
+// writeConfigMap creates or updates the status ConfigMap of a namespace
+func (w *Writer) writeConfigMap(ctx context.Context, namespace string, value string) error {
+	cm := &corev1.ConfigMap{}
+	err := w.kc.Get(ctx, client.ObjectKey{Namespace: namespace, Name: w.name}, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: w.name},
+			Data:       map[string]string{ConfigMapDataKey: value},
+		}
+		return w.kc.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[ConfigMapDataKey] = value
+	return w.kc.Patch(ctx, cm, patch)
+}
+
+// writeAnnotation sets the status annotation of a namespace
+func (w *Writer) writeAnnotation(ctx context.Context, namespace string, value string) error {
+	ns := &corev1.Namespace{}
+	if err := w.kc.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(ns.DeepCopy())
+	annotations := ns.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[w.name] = value
+	ns.SetAnnotations(annotations)
+	return w.kc.Patch(ctx, ns, patch)
+}
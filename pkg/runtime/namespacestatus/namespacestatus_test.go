@@ -0,0 +1,131 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package namespacestatus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/namespacestatus"
+)
+
+var bookGVK = schema.GroupVersionKind{
+	Group:   "bookstore.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Book",
+}
+
+func newBook(namespace, name string, conditions ...ackv1alpha1.ConditionType) *k8sunstructured.Unstructured {
+	u := &k8sunstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetGroupVersionKind(bookGVK)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	statusConditions := []interface{}{}
+	for _, c := range conditions {
+		statusConditions = append(statusConditions, map[string]interface{}{
+			"type":   string(c),
+			"status": string(corev1.ConditionTrue),
+		})
+	}
+	u.Object["status"] = map[string]interface{}{"conditions": statusConditions}
+	return u
+}
+
+func newScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(bookGVK, &k8sunstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(
+		bookGVK.GroupVersion().WithKind("BookList"), &k8sunstructured.UnstructuredList{},
+	)
+	metav1.AddToGroupVersion(scheme, bookGVK.GroupVersion())
+	return scheme
+}
+
+func TestParseMode(t *testing.T) {
+	require := require.New(t)
+
+	for _, value := range []string{"", "configmap", "annotation"} {
+		mode, err := namespacestatus.ParseMode(value)
+		require.Nil(err)
+		require.Equal(namespacestatus.Mode(value), mode)
+	}
+	_, err := namespacestatus.ParseMode("crd")
+	require.NotNil(err)
+}
+
+func TestSummarize(t *testing.T) {
+	require := require.New(t)
+
+	summary := namespacestatus.Summarize([]k8sunstructured.Unstructured{
+		*newBook("a", "dune", ackv1alpha1.ConditionTypeResourceSynced),
+		*newBook("a", "emma", ackv1alpha1.ConditionTypeTerminal),
+		*newBook("a", "ulysses"),
+		*newBook("b", "dune", ackv1alpha1.ConditionTypeResourceSynced),
+	})
+	require.Equal(map[string]namespacestatus.NamespaceStatus{
+		"a": {"Book": {Total: 3, Synced: 1, Terminal: 1}},
+		"b": {"Book": {Total: 1, Synced: 1}},
+	}, summary)
+}
+
+func TestWriter(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	kc := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		newBook("a", "dune", ackv1alpha1.ConditionTypeResourceSynced),
+	).Build()
+
+	cmWriter := namespacestatus.NewWriter(
+		logr.Discard(), kc, kc, namespacestatus.ModeConfigMap, "ack-bookstore-status",
+		time.Minute, []schema.GroupVersionKind{bookGVK},
+	)
+	require.Nil(cmWriter.Write(ctx))
+	cm := &corev1.ConfigMap{}
+	require.Nil(kc.Get(ctx, client.ObjectKey{Namespace: "a", Name: "ack-bookstore-status"}, cm))
+	require.Equal(`{"Book":{"total":1,"synced":1,"terminal":0}}`, cm.Data[namespacestatus.ConfigMapDataKey])
+
+	annotationWriter := namespacestatus.NewWriter(
+		logr.Discard(), kc, kc, namespacestatus.ModeAnnotation, "services.k8s.aws/bookstore-status",
+		time.Minute, []schema.GroupVersionKind{bookGVK},
+	)
+	require.Nil(annotationWriter.Write(ctx))
+	ns := &corev1.Namespace{}
+	require.Nil(kc.Get(ctx, client.ObjectKey{Name: "a"}, ns))
+	require.Equal(
+		`{"Book":{"total":1,"synced":1,"terminal":0}}`,
+		ns.GetAnnotations()["services.k8s.aws/bookstore-status"],
+	)
+
+	// The status of a namespace whose resources were all deleted is emptied
+	require.Nil(kc.Delete(ctx, newBook("a", "dune")))
+	require.Nil(cmWriter.Write(ctx))
+	require.Nil(kc.Get(ctx, client.ObjectKey{Namespace: "a", Name: "ack-bookstore-status"}, cm))
+	require.Equal(`{}`, cm.Data[namespacestatus.ConfigMapDataKey])
+}
@@ -0,0 +1,129 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package overlay contains the break-glass desired state overlay: a file,
+// usually mounted from a ConfigMap, mapping resource keys to patches of their
+// spec. The reconciler applies the patches on top of the desired state of the
+// resources, letting operators hotfix the desired state when the pipeline
+// managing the resources is down.
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"sigs.k8s.io/yaml"
+)
+
+// Key returns the key of a resource in the overlay file, e.g.
+// "Bucket/default/my-bucket"
+func Key(kind, namespace, name string) string {
+	return strings.Join([]string{kind, namespace, name}, "/")
+}
+
+// Overlay is the break-glass desired state overlay read from a file. The file
+// is a YAML object mapping resource keys, as returned by Key, to JSON merge
+// patches (RFC 7386) of the resource spec:
+//
+//	Bucket/default/my-bucket:
+//	  versioning:
+//	    status: Enabled
+//
+// The file is read again whenever its modification time changes. A nil
+// Overlay has no patches.
+type Overlay struct {
+	sync.Mutex
+	path    string
+	modTime time.Time
+	patches map[string]json.RawMessage
+}
+
+// New returns the Overlay read from the supplied file
+func New(path string) *Overlay {
+	return &Overlay{path: path}
+}
+
+// Path returns the path of the overlay file
+func (o *Overlay) Path() string {
+	if o == nil {
+		return ""
+	}
+	return o.path
+}
+
+// load reads the overlay file again if it changed since it was last read.
+// The caller must hold the lock.
+func (o *Overlay) load() error {
+	info, err := os.Stat(o.path)
+	if os.IsNotExist(err) {
+		// A missing file is an empty overlay, so that the file can be
+		// mounted from an optional ConfigMap
+		o.patches = nil
+		o.modTime = time.Time{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if o.patches != nil && info.ModTime().Equal(o.modTime) {
+		return nil
+	}
+	b, err := os.ReadFile(o.path)
+	if err != nil {
+		return err
+	}
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("parsing desired state overlay %s: %v", o.path, err)
+	}
+	patches := make(map[string]json.RawMessage, len(raw))
+	for key, patch := range raw {
+		if _, ok := patch.(map[string]interface{}); !ok {
+			return fmt.Errorf("invalid desired state overlay of %s: expected an object", key)
+		}
+		// Marshaling a value unmarshaled from YAML cannot fail
+		js, _ := json.Marshal(patch)
+		patches[key] = js
+	}
+	o.patches = patches
+	o.modTime = info.ModTime()
+	return nil
+}
+
+// Apply returns the supplied JSON encoded spec with the patch of the
+// resource with the supplied key applied to it, and whether the resource has
+// a patch.
+func (o *Overlay) Apply(key string, spec []byte) ([]byte, bool, error) {
+	if o == nil {
+		return spec, false, nil
+	}
+	o.Lock()
+	defer o.Unlock()
+	if err := o.load(); err != nil {
+		return spec, false, err
+	}
+	patch, ok := o.patches[key]
+	if !ok {
+		return spec, false, nil
+	}
+	patched, err := jsonpatch.MergePatch(spec, patch)
+	if err != nil {
+		return spec, false, fmt.Errorf("applying desired state overlay of %s: %v", key, err)
+	}
+	return patched, true, nil
+}
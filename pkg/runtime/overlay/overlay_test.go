@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package overlay_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/overlay"
+)
+
+func TestOverlay_Apply(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "overlay.yaml")
+	o := overlay.New(path)
+	key := overlay.Key("Bucket", "default", "my-bucket")
+	require.Equal("Bucket/default/my-bucket", key)
+	spec := []byte(`{"name":"my-bucket","versioning":{"status":"Suspended"}}`)
+
+	// A missing file is an empty overlay
+	patched, applied, err := o.Apply(key, spec)
+	require.Nil(err)
+	require.False(applied)
+	require.Equal(spec, patched)
+
+	require.Nil(os.WriteFile(path, []byte(`
+Bucket/default/my-bucket:
+  versioning:
+    status: Enabled
+  tags: null
+`), 0o600))
+	patched, applied, err = o.Apply(key, spec)
+	require.Nil(err)
+	require.True(applied)
+	require.JSONEq(`{"name":"my-bucket","versioning":{"status":"Enabled"}}`, string(patched))
+
+	_, applied, err = o.Apply(overlay.Key("Bucket", "default", "other"), spec)
+	require.Nil(err)
+	require.False(applied)
+
+	// The file is read again when it changes
+	require.Nil(os.WriteFile(path, []byte(`Bucket/default/other: {}`), 0o600))
+	require.Nil(os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	_, applied, err = o.Apply(key, spec)
+	require.Nil(err)
+	require.False(applied)
+
+	require.Nil(os.WriteFile(path, []byte(`Bucket/default/my-bucket: Enabled`), 0o600))
+	require.Nil(os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	_, _, err = o.Apply(key, spec)
+	require.NotNil(err)
+
+	var nilOverlay *overlay.Overlay
+	patched, applied, err = nilOverlay.Apply(key, spec)
+	require.Nil(err)
+	require.False(applied)
+	require.Equal(spec, patched)
+	require.Equal("", nilOverlay.Path())
+}
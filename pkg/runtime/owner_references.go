@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtreferents "github.com/aws-controllers-k8s/runtime/pkg/runtime/referents"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// ensureOwnerReferences adds an owner reference to every ACK resource
+// referenced by the supplied resource, so that the Kubernetes garbage
+// collector deletes the resource when the resources it references are
+// deleted. Owner references are added to both the desired and resolved
+// copies of the resource, and patched into the API server if any were
+// missing.
+//
+// Owner references are only ever added. Existing owner references, including
+// the ones pointing to resources that are not referenced anymore, are left
+// untouched.
+func (r *resourceReconciler) ensureOwnerReferences(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	recorder *ackrtreferents.Recorder,
+	desired acktypes.AWSResource,
+	resolved acktypes.AWSResource,
+) error {
+	missing := ackrtreferents.MissingOwnerReferences(
+		desired.MetaObject().GetOwnerReferences(),
+		recorder.OwnerReferences(r.kc.Scheme(), desired.MetaObject().GetUID()),
+	)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var err error
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("r.ensureOwnerReferences")
+	defer func() {
+		exit(err)
+	}()
+
+	orig := desired.DeepCopy().RuntimeObject()
+	setOwnerReferences := func(res acktypes.AWSResource) {
+		metaObj := res.MetaObject()
+		metaObj.SetOwnerReferences(append(metaObj.GetOwnerReferences(), missing...))
+	}
+	setOwnerReferences(desired)
+	if resolved.MetaObject() != desired.MetaObject() {
+		setOwnerReferences(resolved)
+	}
+	_, err = r.patchResourceMetadataAndSpec(ctx, rm, r.rd.ResourceFromRuntimeObject(orig), desired)
+	if err != nil {
+		return err
+	}
+	rlog.Debug("added owner references to referenced resources", "count", len(missing))
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// updateGroupResult is the result of the last update of an update group, as
+// stored in the AnnotationUpdateGroups annotation
+type updateGroupResult struct {
+	Succeeded bool      `json:"succeeded"`
+	Message   string    `json:"message,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// updateGroups updates, with the supplied partial updater, the update groups
+// with differences in the supplied Delta, then, with the resource manager,
+// the differences outside of all the groups. A failed group does not prevent
+// the other groups from being updated: the first error is returned once all
+// the groups were attempted, and the result of each group is recorded in the
+// AnnotationUpdateGroups annotation.
+func (r *resourceReconciler) updateGroups(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	updater acktypes.AWSResourcePartialUpdater,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	delta *ackcompare.Delta,
+) (acktypes.AWSResource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	updated := latest
+	results := map[string]updateGroupResult{}
+	var firstErr error
+	now := time.Now().UTC().Truncate(time.Second)
+
+	groupPaths := []string{}
+	for _, group := range updater.UpdateGroups() {
+		groupPaths = append(groupPaths, group.Paths...)
+		groupDelta := delta.Select(group.Paths...)
+		if len(groupDelta.Differences) == 0 {
+			continue
+		}
+		rlog.Enter("rm.UpdateGroup", "group", group.Name)
+		res, err := r.updateWithCompensation(ctx, rm, updated, func(ctx context.Context) (acktypes.AWSResource, error) {
+			return updater.UpdateGroup(ctx, group.Name, desired, updated, groupDelta)
+		})
+		rlog.Exit("rm.UpdateGroup", err, "group", group.Name)
+		if err != nil {
+			results[group.Name] = updateGroupResult{Message: err.Error(), Time: now}
+			if _, ok := ackerr.AWSError(err); ok {
+				r.recordEvent(
+					desired.RuntimeObject(), ackevents.ReasonUpdateFailed,
+					fmt.Sprintf("Unable to update the %s of AWS resource: %s", group.Name, err),
+				)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		results[group.Name] = updateGroupResult{Succeeded: true, Time: now}
+		if !ackcompare.IsNil(res) {
+			updated = res
+		}
+	}
+
+	if firstErr == nil {
+		if remaining := delta.Except(groupPaths...); remaining.DifferentAt("Spec") {
+			rlog.Enter("rm.Update")
+			res, err := r.updateWithCompensation(ctx, rm, updated, func(ctx context.Context) (acktypes.AWSResource, error) {
+				return rm.Update(ctx, desired, updated, remaining)
+			})
+			rlog.Exit("rm.Update", err, "latest", updated)
+			if err != nil {
+				if _, ok := ackerr.AWSError(err); ok {
+					r.recordEvent(
+						desired.RuntimeObject(), ackevents.ReasonUpdateFailed,
+						fmt.Sprintf("Unable to update AWS resource: %s", err),
+					)
+				}
+				firstErr = err
+			} else {
+				updated = res
+			}
+		}
+	}
+
+	if firstErr != nil {
+		// Only the annotation is patched: the metadata and spec of the
+		// resources returned by the update groups are not patched unless all
+		// the updates succeeded, like the resource returned by a failed
+		// Update. Their status is still patched.
+		failed := pendingOrCopy(ctx, desired)
+		setUpdateGroupResults(failed, results)
+		if _, err := r.patchResourceMetadataAndSpec(ctx, rm, desired, failed); err != nil {
+			rlog.Info("unable to record the update group results", "error", err)
+		}
+		return updated, firstErr
+	}
+	setUpdateGroupResults(updated, results)
+	return updated, nil
+}
+
+// setUpdateGroupResults merges the supplied update group results into the
+// AnnotationUpdateGroups annotation of the supplied resource
+func setUpdateGroupResults(
+	res acktypes.AWSResource,
+	results map[string]updateGroupResult,
+) {
+	if len(results) == 0 {
+		return
+	}
+	mo := res.MetaObject()
+	annotations := mo.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	merged := map[string]updateGroupResult{}
+	if value, ok := annotations[ackv1alpha1.AnnotationUpdateGroups]; ok {
+		_ = json.Unmarshal([]byte(value), &merged)
+	}
+	for name, result := range results {
+		merged[name] = result
+	}
+	value, _ := json.Marshal(merged)
+	annotations[ackv1alpha1.AnnotationUpdateGroups] = string(value)
+	mo.SetAnnotations(annotations)
+}
@@ -0,0 +1,124 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// partialResourceManager is a resource manager updating groups of fields
+// separately
+type partialResourceManager struct {
+	*ackmocks.AWSResourceManager
+	*ackmocks.AWSResourcePartialUpdater
+}
+
+func TestReconcilerUpdate_UpdateGroups(t *testing.T) {
+	tests := []struct {
+		name        string
+		groupErr    error
+		expectedErr bool
+	}{
+		{"all groups succeed", nil, false},
+		{"group fails", errors.New("tagging failed"), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := context.TODO()
+			arn := ackv1alpha1.AWSResourceName("mybook-arn")
+
+			delta := ackcompare.NewDelta()
+			delta.Add("Spec.Tags", "val1", "val2")
+			delta.Add("Spec.A", "val1", "val2")
+			tagsDelta := delta.Select("Spec.Tags")
+			remainingDelta := delta.Except("Spec.Tags", "Spec.Logging")
+
+			desired, _, desiredMetaObj := resourceMocks()
+			desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+
+			ids := &ackmocks.AWSResourceIdentifiers{}
+			ids.On("ARN").Return(&arn)
+
+			latest, latestRTObj, latestMetaObj := resourceMocks()
+			latest.On("Identifiers").Return(ids)
+			latest.On("Conditions").Return([]*ackv1alpha1.Condition{})
+			latest.On("ReplaceConditions", mock.AnythingOfType("[]*v1alpha1.Condition")).Return()
+
+			baseRM := &ackmocks.AWSResourceManager{}
+			baseRM.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+			baseRM.On("ClearResolvedReferences", desired).Return(desired)
+			baseRM.On("ClearResolvedReferences", latest).Return(latest)
+			baseRM.On("ReadOne", ctx, desired).Return(latest, nil)
+			baseRM.On("FilterSystemTags", latest)
+			baseRM.On("Update", ctx, desired, latest, remainingDelta).Return(latest, nil)
+			baseRM.On("IsSynced", ctx, latest).Return(true, nil)
+			baseRM.On("LateInitialize", ctx, latest).Return(latest, nil)
+
+			updater := &ackmocks.AWSResourcePartialUpdater{}
+			updater.On("UpdateGroups").Return([]acktypes.AWSResourceUpdateGroup{
+				{Name: "tags", Paths: []string{"Spec.Tags"}},
+				{Name: "logging", Paths: []string{"Spec.Logging"}},
+			})
+			updater.On("UpdateGroup", ctx, "tags", desired, latest, tagsDelta).Return(latest, test.groupErr)
+			rm := &partialResourceManager{baseRM, updater}
+
+			rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+			rd.On("IsManaged", desired).Return(true)
+			rd.On("Delta", desired, latest).Return(delta).Once()
+			rd.On("Delta", desired, latest).Return(ackcompare.NewDelta())
+			rd.On("Delta", latest, latest).Return(ackcompare.NewDelta())
+			rd.On("Delta", desired, desired).Return(ackcompare.NewDelta())
+
+			r, kc, scmd := reconcilerMocks(rmf)
+			baseRM.On("EnsureTags", ctx, desired, scmd).Return(nil)
+			kc.On("Patch", withoutCancelContextMatcher, mock.Anything, mock.AnythingOfType("*client.mergeFromPatch")).Return(nil)
+
+			_, err := r.Sync(ctx, rm, desired)
+			updater.AssertCalled(t, "UpdateGroup", ctx, "tags", desired, latest, tagsDelta)
+			// Groups without differences are not updated
+			updater.AssertNotCalled(t, "UpdateGroup", ctx, "logging", mock.Anything, mock.Anything, mock.Anything)
+
+			results := map[string]map[string]interface{}{}
+			if test.expectedErr {
+				require.NotNil(err)
+				// The differences outside of the groups are not updated
+				// after a group failed
+				baseRM.AssertNotCalled(t, "Update", ctx, desired, latest, remainingDelta)
+				require.Nil(json.Unmarshal([]byte(desiredMetaObj.GetAnnotations()[ackv1alpha1.AnnotationUpdateGroups]), &results))
+				require.Equal(false, results["tags"]["succeeded"])
+				require.Equal("tagging failed", results["tags"]["message"])
+				return
+			}
+			require.Nil(err)
+			baseRM.AssertCalled(t, "Update", ctx, desired, latest, remainingDelta)
+			kc.AssertCalled(t, "Patch", withoutCancelContextMatcher, latestRTObj, mock.AnythingOfType("*client.mergeFromPatch"))
+			require.Nil(json.Unmarshal([]byte(latestMetaObj.GetAnnotations()[ackv1alpha1.AnnotationUpdateGroups]), &results))
+			require.Equal(true, results["tags"]["succeeded"])
+		})
+	}
+}
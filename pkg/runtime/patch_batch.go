@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtpatchbatch "github.com/aws-controllers-k8s/runtime/pkg/runtime/patchbatch"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// withPatchBatch returns a copy of the supplied context holding an empty
+// patch batch, see the patchbatch package
+func withPatchBatch(ctx context.Context) context.Context {
+	return ackrtpatchbatch.NewContext(ctx)
+}
+
+// flushPatchBatch sends the pending patch of the patch batch of the supplied
+// context, if any
+func (r *resourceReconciler) flushPatchBatch(ctx context.Context) error {
+	batch := ackrtpatchbatch.FromContext(ctx)
+	if batch == nil {
+		return nil
+	}
+	return batch.Flush(ctx, r.patchMetadataAndSpec)
+}
+
+// withFlushError returns the error of a reconciliation that returned the
+// supplied error, and whose patch batch failed to flush with the supplied
+// error. A nil or terminal error would not requeue the resource, losing the
+// pending patch, so the flush error takes precedence over them, and the next
+// reconciliation retries the patch.
+func withFlushError(err error, flushErr error) error {
+	if flushErr == nil || (err != nil && err != ackerr.Terminal) {
+		return err
+	}
+	return flushErr
+}
+
+// pendingOrCopy returns a copy of the resource pending in the patch batch of
+// the supplied context, or a copy of the supplied resource if no patch is
+// pending. Mutating, then patching, the returned resource keeps the pending
+// changes of the batch.
+func pendingOrCopy(ctx context.Context, res acktypes.AWSResource) acktypes.AWSResource {
+	return ackrtpatchbatch.PendingOrCopy(ctx, res)
+}
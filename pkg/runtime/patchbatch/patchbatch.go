@@ -0,0 +1,123 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package patchbatch coalesces the metadata and spec patches made during one
+// reconciliation (finalizers, annotations, late initialized fields...) into
+// a single patch, sent when the batch is flushed. Together with the status
+// patch of the reconciler, a reconciliation then makes at most one metadata
+// and spec patch and one status patch, instead of a patch, a new
+// resourceVersion and a watch event per mutation.
+package patchbatch
+
+import (
+	"context"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// contextKey is the context key of the patch batch of a reconciliation
+type contextKey struct{}
+
+// Patcher patches the custom resource in the Kubernetes API from the supplied
+// base resource to the supplied pending resource, and returns the patched
+// resource
+type Patcher func(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	base acktypes.AWSResource,
+	pending acktypes.AWSResource,
+) (acktypes.AWSResource, error)
+
+// Batch holds the metadata and spec patch pending during a reconciliation.
+//
+// The patch is computed between the resource as it was first passed to the
+// batch, i.e. as stored in the Kubernetes API, and the last resource passed
+// to the batch.
+type Batch struct {
+	rm acktypes.AWSResourceManager
+	// base is the resource as stored in the Kubernetes API
+	base acktypes.AWSResource
+	// pending is the resource to persist, nil if no patch is pending
+	pending acktypes.AWSResource
+}
+
+// NewContext returns a copy of the supplied context holding an empty patch
+// batch
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, &Batch{})
+}
+
+// FromContext returns the patch batch of the supplied context, or nil if it
+// has none
+func FromContext(ctx context.Context) *Batch {
+	batch, _ := ctx.Value(contextKey{}).(*Batch)
+	return batch
+}
+
+// Add defers a patch from the supplied desired resource to the supplied
+// latest resource.
+//
+// The pending resource takes the resourceVersion of the base of the batch:
+// once a patch was flushed, the resources passed to the batch still hold the
+// resourceVersion the reconciliation started with, which would otherwise end
+// up in the next patch and make it fail as a conflict.
+func (b *Batch) Add(
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) {
+	if b.base == nil {
+		b.base = desired.DeepCopy()
+	}
+	b.rm = rm
+	b.pending = latest.DeepCopy()
+	b.pending.MetaObject().SetResourceVersion(
+		b.base.MetaObject().GetResourceVersion(),
+	)
+}
+
+// Pending returns whether a patch is pending
+func (b *Batch) Pending() bool {
+	return b != nil && b.pending != nil
+}
+
+// Flush sends the pending patch, if any, with the supplied patcher.
+//
+// After a successful patch, the patched resource, holding the new
+// resourceVersion, becomes the base of the next patches. After a failed
+// patch, the pending changes are kept, so that the next flush retries them,
+// and the error is returned for the reconciliation to be retried if the
+// patch still fails.
+func (b *Batch) Flush(ctx context.Context, patch Patcher) error {
+	if !b.Pending() {
+		return nil
+	}
+	patched, err := patch(ctx, b.rm, b.base, b.pending)
+	if err != nil {
+		return err
+	}
+	b.pending = nil
+	b.base = patched.DeepCopy()
+	return nil
+}
+
+// PendingOrCopy returns a copy of the resource pending in the patch batch of
+// the supplied context, or a copy of the supplied resource if no patch is
+// pending. Mutating, then patching, the returned resource keeps the pending
+// changes of the batch.
+func PendingOrCopy(ctx context.Context, res acktypes.AWSResource) acktypes.AWSResource {
+	if batch := FromContext(ctx); batch.Pending() {
+		return batch.pending.DeepCopy()
+	}
+	return res.DeepCopy()
+}
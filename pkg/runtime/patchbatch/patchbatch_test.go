@@ -0,0 +1,187 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package patchbatch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8sobj "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/patchbatch"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// newResource returns a mocked resource with the supplied name and
+// resourceVersion, whose copies are independent mocked resources
+func newResource(name, resourceVersion string) *ackmocks.AWSResource {
+	metaObj := &k8sobj.Unstructured{}
+	metaObj.SetName(name)
+	metaObj.SetResourceVersion(resourceVersion)
+	return mockedResource(metaObj)
+}
+
+func mockedResource(metaObj *k8sobj.Unstructured) *ackmocks.AWSResource {
+	res := &ackmocks.AWSResource{}
+	res.On("MetaObject").Return(metaObj)
+	res.On("DeepCopy").Return(func() acktypes.AWSResource {
+		return mockedResource(metaObj.DeepCopy())
+	})
+	return res
+}
+
+// patched records the calls to a patcher
+type patched struct {
+	bases    []acktypes.AWSResource
+	pendings []acktypes.AWSResource
+}
+
+// patcher returns a patcher bumping the resourceVersion of the patched
+// resource, or failing with the supplied error
+func (p *patched) patcher(resourceVersion string, err error) patchbatch.Patcher {
+	return func(
+		_ context.Context,
+		_ acktypes.AWSResourceManager,
+		base acktypes.AWSResource,
+		pending acktypes.AWSResource,
+	) (acktypes.AWSResource, error) {
+		p.bases = append(p.bases, base)
+		p.pendings = append(p.pendings, pending)
+		if err != nil {
+			return nil, err
+		}
+		res := pending.DeepCopy()
+		res.MetaObject().SetResourceVersion(resourceVersion)
+		return res, nil
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(patchbatch.FromContext(context.TODO()))
+	require.NotNil(patchbatch.FromContext(patchbatch.NewContext(context.TODO())))
+
+	// Flushing without a batch is a no-op
+	var batch *patchbatch.Batch
+	require.False(batch.Pending())
+	p := &patched{}
+	require.NoError(batch.Flush(context.TODO(), p.patcher("2", nil)))
+	require.Empty(p.bases)
+}
+
+func TestBatch_Coalesce(t *testing.T) {
+	require := require.New(t)
+
+	ctx := patchbatch.NewContext(context.TODO())
+	batch := patchbatch.FromContext(ctx)
+	rm := &ackmocks.AWSResourceManager{}
+
+	stored := newResource("stored", "1")
+	finalized := newResource("finalized", "1")
+	annotated := newResource("annotated", "1")
+	batch.Add(rm, stored, finalized)
+	batch.Add(rm, finalized, annotated)
+	require.True(batch.Pending())
+
+	p := &patched{}
+	require.NoError(batch.Flush(ctx, p.patcher("2", nil)))
+	// A single patch, from the stored resource to the last resource
+	require.Len(p.bases, 1)
+	require.Equal("stored", p.bases[0].MetaObject().GetName())
+	require.Equal("annotated", p.pendings[0].MetaObject().GetName())
+	require.False(batch.Pending())
+
+	// Nothing is left to patch
+	require.NoError(batch.Flush(ctx, p.patcher("3", nil)))
+	require.Len(p.bases, 1)
+}
+
+func TestBatch_ResourceVersion(t *testing.T) {
+	require := require.New(t)
+
+	ctx := patchbatch.NewContext(context.TODO())
+	batch := patchbatch.FromContext(ctx)
+	rm := &ackmocks.AWSResourceManager{}
+
+	p := &patched{}
+	batch.Add(rm, newResource("stored", "1"), newResource("finalized", "1"))
+	require.NoError(batch.Flush(ctx, p.patcher("2", nil)))
+
+	// The resources of the reconciliation still hold the resourceVersion it
+	// started with, the next patch is computed from the patched resource and
+	// never carries the stale resourceVersion
+	latest := newResource("annotated", "1")
+	batch.Add(rm, newResource("finalized", "1"), latest)
+	require.NoError(batch.Flush(ctx, p.patcher("3", nil)))
+	require.Len(p.bases, 2)
+	require.Equal("finalized", p.bases[1].MetaObject().GetName())
+	require.Equal("2", p.bases[1].MetaObject().GetResourceVersion())
+	require.Equal("annotated", p.pendings[1].MetaObject().GetName())
+	require.Equal("2", p.pendings[1].MetaObject().GetResourceVersion())
+	// The supplied resources are left untouched
+	require.Equal("1", latest.MetaObject().GetResourceVersion())
+}
+
+func TestBatch_FlushError(t *testing.T) {
+	require := require.New(t)
+
+	ctx := patchbatch.NewContext(context.TODO())
+	batch := patchbatch.FromContext(ctx)
+	rm := &ackmocks.AWSResourceManager{}
+
+	p := &patched{}
+	batch.Add(rm, newResource("stored", "1"), newResource("finalized", "1"))
+	err := errors.New("conflict")
+	require.Equal(err, batch.Flush(ctx, p.patcher("", err)))
+	// The failed changes are kept, and sent again by the next flush
+	require.True(batch.Pending())
+	require.Equal("finalized", patchbatch.PendingOrCopy(ctx, nil).MetaObject().GetName())
+	require.NoError(batch.Flush(ctx, p.patcher("2", nil)))
+	require.Len(p.bases, 2)
+	require.Equal("stored", p.bases[1].MetaObject().GetName())
+	require.Equal("finalized", p.pendings[1].MetaObject().GetName())
+	require.False(batch.Pending())
+
+	// The changes deferred after a failure are coalesced with the failed
+	// ones
+	batch.Add(rm, newResource("finalized", "1"), newResource("annotated", "1"))
+	require.Equal(err, batch.Flush(ctx, p.patcher("", err)))
+	batch.Add(rm, newResource("annotated", "1"), newResource("labeled", "1"))
+	require.NoError(batch.Flush(ctx, p.patcher("3", nil)))
+	require.Len(p.bases, 4)
+	require.Equal("finalized", p.bases[3].MetaObject().GetName())
+	require.Equal("2", p.bases[3].MetaObject().GetResourceVersion())
+	require.Equal("labeled", p.pendings[3].MetaObject().GetName())
+}
+
+func TestPendingOrCopy(t *testing.T) {
+	require := require.New(t)
+
+	res := newResource("stored", "1")
+	require.Equal("stored", patchbatch.PendingOrCopy(context.TODO(), res).MetaObject().GetName())
+
+	ctx := patchbatch.NewContext(context.TODO())
+	require.Equal("stored", patchbatch.PendingOrCopy(ctx, res).MetaObject().GetName())
+
+	patchbatch.FromContext(ctx).Add(&ackmocks.AWSResourceManager{}, res, newResource("annotated", "1"))
+	pending := patchbatch.PendingOrCopy(ctx, res)
+	require.Equal("annotated", pending.MetaObject().GetName())
+	// The returned resource is a copy
+	pending.MetaObject().SetName("mutated")
+	require.Equal("annotated", patchbatch.PendingOrCopy(ctx, res).MetaObject().GetName())
+}
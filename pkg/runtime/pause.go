@@ -0,0 +1,151 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtpause "github.com/aws-controllers-k8s/runtime/pkg/runtime/pause"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// pausedBy returns what pauses the reconciliations of the supplied resource,
+// or an empty string if they are not paused. The namespace of the resource is
+// read through the client of the controller manager, consistent with the
+// namespace events resuming the resources.
+func (r *resourceReconciler) pausedBy(ctx context.Context, res acktypes.AWSResource) string {
+	var namespaces ackrtpause.NamespacePauses
+	if r.cache.Namespaces != nil {
+		namespaces = r.cache.Namespaces
+	}
+	return ackrtpause.PausedBy(ctx, res.MetaObject(), r.kc, namespaces)
+}
+
+// pauseResource sets the ACK.Paused condition of the supplied resource,
+// paused by the supplied annotation, without reading nor changing its AWS
+// resource. Resuming the resource reconciles it immediately, so it is only
+// requeued after the resync period, in case the resumption was missed.
+func (r *resourceReconciler) pauseResource(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	pausedBy string,
+) (ctrlrt.Result, error) {
+	wasPaused := false
+	if c := ackcondition.Paused(desired); c != nil && c.Status == corev1.ConditionTrue {
+		wasPaused = true
+	}
+	reason := fmt.Sprintf("Paused by %s", pausedBy)
+	latest := desired.DeepCopy()
+	ackcondition.SetPaused(latest, &reason)
+	if err := r.patchResourceStatus(ctx, desired, latest); err != nil {
+		return ctrlrt.Result{}, err
+	}
+	if !wasPaused {
+		ackrtlog.FromContext(ctx).Info("reconciliation paused", "paused_by", pausedBy)
+		r.recordEvent(
+			latest.RuntimeObject(), ackevents.ReasonPaused,
+			fmt.Sprintf("Reconciliation paused by %s", pausedBy),
+		)
+	}
+	return ctrlrt.Result{RequeueAfter: r.resyncPeriod}, nil
+}
+
+// resumeResource removes the ACK.Paused condition of the supplied resource,
+// whose reconciliations are no longer paused, and returns the resource
+// without it
+func (r *resourceReconciler) resumeResource(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+) acktypes.AWSResource {
+	if ackcondition.Paused(desired) == nil {
+		return desired
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	latest := desired.DeepCopy()
+	ackcondition.RemovePaused(latest)
+	if err := r.patchResourceStatus(ctx, desired, latest); err != nil {
+		rlog.Debug("unable to remove the paused condition", "error", err)
+		return desired
+	}
+	rlog.Info("reconciliation resumed")
+	r.recordEvent(latest.RuntimeObject(), ackevents.ReasonResumed, "Reconciliation resumed")
+	return latest
+}
+
+// pausePredicate returns the predicate accepting the updates of the resources
+// whose paused annotation changed, the other updates of their metadata being
+// filtered out, so that they are paused and resumed immediately
+func pausePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return e.ObjectOld.GetAnnotations()[ackv1alpha1.AnnotationPaused] !=
+				e.ObjectNew.GetAnnotations()[ackv1alpha1.AnnotationPaused]
+		},
+	}
+}
+
+// namespacePauseSource returns the watch source reconciling the resources of
+// the kind of the reconciler of a namespace whose paused annotation changed,
+// so that they are paused and resumed immediately
+func (r *resourceReconciler) namespacePauseSource(mgr ctrlrt.Manager) source.Source {
+	return NewWatchSource(
+		mgr, &corev1.Namespace{}, r.namespaceResources,
+		predicate.Funcs{
+			CreateFunc:  func(event.CreateEvent) bool { return false },
+			DeleteFunc:  func(event.DeleteEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+			UpdateFunc:  pausePredicate().Update,
+		},
+	)
+}
+
+// namespaceResources returns the requests reconciling the resources of the
+// kind of the reconciler of the supplied namespace
+func (r *resourceReconciler) namespaceResources(
+	ctx context.Context,
+	ns client.Object,
+) []reconcile.Request {
+	gvk := r.rd.GroupVersionKind()
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.apiReader.List(ctx, list, client.InNamespace(ns.GetName())); err != nil {
+		r.log.Error(err, "unable to list the resources of the namespace", "kind", gvk.Kind, "namespace", ns.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package pause resolves what pauses the reconciliations of the resources:
+// the paused annotation of the resources, or of their namespace.
+package pause
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// NamespacePauses returns whether the reconciliations of the resources of the
+// namespaces are paused, e.g. the namespace cache
+type NamespacePauses interface {
+	GetPaused(namespace string) (string, bool)
+}
+
+// PausedBy returns what pauses the reconciliations of the supplied resource,
+// or an empty string if they are not paused. We look for the paused
+// annotation of the resource first, then of its namespace.
+//
+// The namespace is read with the supplied reader, e.g. the client of the
+// controller manager, whose cache also feeds the watch of the namespaces
+// reconciling their resources when their paused annotation changes, so that
+// a resource is not paused again by a stale copy of a namespace that was just
+// resumed. The supplied namespace pauses are only used if the namespace
+// cannot be read.
+func PausedBy(
+	ctx context.Context,
+	obj metav1.Object,
+	reader client.Reader,
+	namespaces NamespacePauses,
+) string {
+	if value, ok := obj.GetAnnotations()[ackv1alpha1.AnnotationPaused]; ok {
+		if value != "true" {
+			return ""
+		}
+		return fmt.Sprintf("the %s annotation of the resource", ackv1alpha1.AnnotationPaused)
+	}
+	namespace := obj.GetNamespace()
+	if value, ok := namespacePaused(ctx, namespace, reader, namespaces); ok && value == "true" {
+		return fmt.Sprintf("the %s annotation of namespace %q", ackv1alpha1.AnnotationPaused, namespace)
+	}
+	return ""
+}
+
+// namespacePaused returns the paused annotation of the supplied namespace, if
+// it is set
+func namespacePaused(
+	ctx context.Context,
+	namespace string,
+	reader client.Reader,
+	namespaces NamespacePauses,
+) (string, bool) {
+	if reader != nil {
+		ns := &corev1.Namespace{}
+		if err := reader.Get(ctx, types.NamespacedName{Name: namespace}, ns); err == nil {
+			value, ok := ns.GetAnnotations()[ackv1alpha1.AnnotationPaused]
+			return value, ok
+		}
+	}
+	if namespaces == nil {
+		return "", false
+	}
+	return namespaces.GetPaused(namespace)
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package pause_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/pause"
+)
+
+// namespacePauses are the paused annotations of namespaces, by namespace
+type namespacePauses map[string]string
+
+func (p namespacePauses) GetPaused(namespace string) (string, bool) {
+	value, ok := p[namespace]
+	return value, ok
+}
+
+func namespace(name string, annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+}
+
+func TestPausedBy(t *testing.T) {
+	reader := fake.NewClientBuilder().WithObjects(
+		namespace("paused", map[string]string{ackv1alpha1.AnnotationPaused: "true"}),
+		// The namespace cache still holds the paused annotation of the
+		// resumed namespace
+		namespace("resumed", map[string]string{ackv1alpha1.AnnotationPaused: "false"}),
+		namespace("plain", nil),
+	).Build()
+	namespaces := namespacePauses{"resumed": "true", "missing": "true"}
+
+	tests := []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		reader      client.Reader
+		want        string
+	}{{
+		name:        "resource paused",
+		namespace:   "plain",
+		annotations: map[string]string{ackv1alpha1.AnnotationPaused: "true"},
+		reader:      reader,
+		want:        `the services.k8s.aws/paused annotation of the resource`,
+	}, {
+		name:        "resource resumed in a paused namespace",
+		namespace:   "paused",
+		annotations: map[string]string{ackv1alpha1.AnnotationPaused: "false"},
+		reader:      reader,
+	}, {
+		name:      "namespace paused",
+		namespace: "paused",
+		reader:    reader,
+		want:      `the services.k8s.aws/paused annotation of namespace "paused"`,
+	}, {
+		name:      "namespace resumed",
+		namespace: "resumed",
+		reader:    reader,
+	}, {
+		name:      "namespace not paused",
+		namespace: "plain",
+		reader:    reader,
+	}, {
+		name:      "namespace not readable",
+		namespace: "missing",
+		reader:    reader,
+		want:      `the services.k8s.aws/paused annotation of namespace "missing"`,
+	}, {
+		name:      "without reader",
+		namespace: "resumed",
+		want:      `the services.k8s.aws/paused annotation of namespace "resumed"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Namespace: tt.namespace, Name: "my-bucket", Annotations: tt.annotations}
+			require.Equal(t, tt.want, pause.PausedBy(context.TODO(), obj, tt.reader, namespaces))
+		})
+	}
+}
+
+func TestPausedBy_WithoutNamespaces(t *testing.T) {
+	obj := &metav1.ObjectMeta{Namespace: "missing", Name: "my-bucket"}
+	require.Empty(t, pause.PausedBy(context.TODO(), obj, fake.NewClientBuilder().Build(), nil))
+}
@@ -0,0 +1,195 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/plan"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// planRequested returns true if the reconciliations of the supplied resource
+// are planned rather than acted on, see plan.Requested
+func (r *resourceReconciler) planRequested(res acktypes.AWSResource) bool {
+	return plan.Requested(r.cfg.DryRun, res.MetaObject().GetAnnotations())
+}
+
+// planResource writes the plan of the reconciliation of the supplied desired
+// resource, what it would do to the AWS resource, to the plan ConfigMap and
+// the status.ackResourceMetadata.lastPlan field of the resource, without
+// acting on the AWS resource. The resource is planned again after the resync
+// period, the AWS resource possibly changing meanwhile. A plan that did not
+// change since the last one is neither written nor recorded again.
+//
+// Deleted resources are planned too, but their finalizer is not removed, as
+// the AWS resource is not deleted: they stay in the Kubernetes API until the
+// plan annotation is removed, or the controller runs without --dry-run, and
+// their AWS resource is deleted as usual.
+func (r *resourceReconciler) planResource(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+) (ctrlrt.Result, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	p := r.computePlan(ctx, rm, desired)
+	r.redactPlan(desired, p)
+	name := plan.ConfigMapName(p.Kind, p.Name)
+	written, err := r.writePlan(ctx, desired, name, p)
+	if err != nil {
+		return ctrlrt.Result{}, fmt.Errorf("writing the plan of the resource: %v", err)
+	}
+	if !written {
+		rlog.Debug("plan of the resource unchanged", "action", p.Action, "changes", len(p.Changes))
+		return ctrlrt.Result{RequeueAfter: r.resyncPeriod}, nil
+	}
+	if err := r.recordLastPlan(ctx, desired, p); err != nil {
+		return ctrlrt.Result{}, fmt.Errorf("recording the plan of the resource: %v", err)
+	}
+	rlog.Info("planned resource", "action", p.Action, "changes", len(p.Changes))
+	message := fmt.Sprintf("Planned action %s on the AWS resource, see ConfigMap %s", p.Action, name)
+	switch {
+	case p.Error != "":
+		message = fmt.Sprintf("Unable to plan the reconciliation of the resource, see ConfigMap %s", name)
+	case desired.IsBeingDeleted():
+		message = fmt.Sprintf(
+			"Planned action %s on the AWS resource, see ConfigMap %s. The finalizer of the resource is "+
+				"kept until the reconciliations of the resource are no longer planned",
+			p.Action, name,
+		)
+	}
+	r.recordEvent(desired.RuntimeObject(), ackevents.ReasonPlanned, message)
+	return ctrlrt.Result{RequeueAfter: r.resyncPeriod}, nil
+}
+
+// computePlan returns the plan of the reconciliation of the supplied desired
+// resource. Like a reconciliation, it resolves the references of the
+// resource, adds its default tags and compares it with the latest observed
+// state of the AWS resource, only reading the AWS resource.
+func (r *resourceReconciler) computePlan(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+) *plan.Plan {
+	meta := desired.MetaObject()
+	p := &plan.Plan{
+		Kind:       r.rd.GroupVersionKind().Kind,
+		Namespace:  meta.GetNamespace(),
+		Name:       meta.GetName(),
+		Generation: meta.GetGeneration(),
+		PlannedAt:  time.Now().UTC(),
+	}
+	isReadOnly := r.cfg.FeatureGates.IsEnabled(featuregate.ReadOnlyResources) && IsReadOnly(desired)
+	if desired.IsBeingDeleted() {
+		p.Action = plan.ActionNone
+		if r.rd.IsManaged(desired) && !isReadOnly &&
+			r.getDeletionPolicy(desired) == ackv1alpha1.DeletionPolicyDelete {
+			p.Action = plan.ActionDelete
+		}
+		return p
+	}
+	resolved, _, err := rm.ResolveReferences(ctx, r.referenceReader(), desired)
+	if err != nil {
+		p.Error = fmt.Sprintf("unable to resolve the references: %v", err)
+		return p
+	}
+	resolved = resolved.DeepCopy()
+	if !isReadOnly {
+		if err = rm.EnsureTags(ctx, resolved, r.sc.GetMetadata()); err != nil {
+			p.Error = fmt.Sprintf("unable to add the default tags: %v", err)
+			return p
+		}
+	}
+	latest, err := rm.ReadOne(ctx, resolved)
+	if err != nil {
+		if !ackerr.IsNotFound(err) {
+			p.Error = fmt.Sprintf("unable to read the AWS resource: %v", err)
+			return p
+		}
+		if isReadOnly || IsAdopted(desired) {
+			p.Error = "the AWS resource of the read-only or adopted resource was not found"
+			return p
+		}
+		obj, err := UnstructuredConverter.ToUnstructured(resolved.RuntimeObject())
+		if err != nil {
+			p.Error = err.Error()
+			return p
+		}
+		spec, _ := obj["spec"].(map[string]interface{})
+		p.Action = plan.ActionCreate
+		p.Changes = plan.SpecChanges(spec)
+		return p
+	}
+	p.Action = plan.ActionNone
+	if isReadOnly {
+		return p
+	}
+	delta := r.resourceDelta(ctx, rm, resolved, latest)
+	if p.Changes = plan.DeltaChanges(delta); len(p.Changes) > 0 {
+		p.Action = plan.ActionUpdate
+	}
+	return p
+}
+
+// recordLastPlan patches the status.ackResourceMetadata.lastPlan field of the
+// supplied resource with the summary of the supplied plan. Resources without
+// resource metadata in their status are left unchanged.
+func (r *resourceReconciler) recordLastPlan(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	p *plan.Plan,
+) error {
+	obj, err := UnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		return err
+	}
+	if ok, err := plan.SetLastPlan(obj, p); !ok || err != nil {
+		return err
+	}
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		return err
+	}
+	return r.patchResourceStatus(ctx, res, r.rd.ResourceFromRuntimeObject(ro))
+}
+
+// writePlan creates or updates the plan ConfigMap with the supplied name of
+// the supplied resource, owned by the resource so that it is deleted with it.
+// It returns false if the ConfigMap already held the plan.
+func (r *resourceReconciler) writePlan(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	name string,
+	p *plan.Plan,
+) (bool, error) {
+	meta := res.MetaObject()
+	gvk := r.rd.GroupVersionKind()
+	owner := metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       meta.GetName(),
+		UID:        meta.GetUID(),
+	}
+	return plan.Write(ctx, r.kc, owner, meta.GetNamespace(), name, p)
+}
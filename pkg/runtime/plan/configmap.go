@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Write creates or updates the plan ConfigMap with the supplied namespace and
+// name, holding the human-readable and JSON descriptions of the supplied
+// plan. The ConfigMap is created owned by the supplied owner, the planned
+// resource, so that it is deleted with it. An existing ConfigMap holding the
+// same plan, but for its planning time, is left unchanged, so that planning
+// an unchanged resource at every resync does not write it again. Write
+// returns true if the ConfigMap was written.
+//
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;patch
+func Write(
+	ctx context.Context,
+	kc client.Client,
+	owner metav1.OwnerReference,
+	namespace string,
+	name string,
+	p *Plan,
+) (bool, error) {
+	encoded, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	data := map[string]string{
+		DataKeyText: p.Render(),
+		DataKeyJSON: string(encoded),
+	}
+	cm := &corev1.ConfigMap{}
+	err = kc.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            name,
+				OwnerReferences: []metav1.OwnerReference{owner},
+			},
+			Data: data,
+		}
+		return true, kc.Create(ctx, cm)
+	}
+	if err != nil {
+		return false, err
+	}
+	if cm.Data[DataKeyText] == data[DataKeyText] && samePlan(cm.Data[DataKeyJSON], data[DataKeyJSON]) {
+		return false, nil
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	cm.Data = data
+	return true, kc.Patch(ctx, cm, patch)
+}
+
+// samePlan returns true if the supplied JSON plans are the same, but for
+// their planning time
+func samePlan(a, b string) bool {
+	normalizedA, errA := withoutPlannedAt(a)
+	normalizedB, errB := withoutPlannedAt(b)
+	return errA == nil && errB == nil && normalizedA == normalizedB
+}
+
+// withoutPlannedAt returns the supplied JSON plan without its planning time,
+// its fields sorted
+func withoutPlannedAt(encoded string) (string, error) {
+	var p map[string]interface{}
+	if err := json.Unmarshal([]byte(encoded), &p); err != nil {
+		return "", err
+	}
+	delete(p, "plannedAt")
+	normalized, err := json.Marshal(p)
+	return string(normalized), err
+}
@@ -0,0 +1,215 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package plan describes what reconciling a resource would do to its AWS
+// resource, without doing it: create, update or delete it, and which fields
+// would change. Plans are computed for the resources annotated with
+// services.k8s.aws/plan, and written next to them for users to review before
+// letting the controller act.
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+const (
+	// DataKeyText is the key of the human-readable plan in the data of the
+	// plan ConfigMap
+	DataKeyText = "plan.txt"
+	// DataKeyJSON is the key of the JSON plan in the data of the plan
+	// ConfigMap
+	DataKeyJSON = "plan.json"
+)
+
+// Requested returns true if the controller was started with --dry-run, as
+// supplied, or the resource with the supplied annotations is annotated with
+// services.k8s.aws/plan set to true, in which case its reconciliations are
+// planned rather than acted on
+func Requested(dryRun bool, annotations map[string]string) bool {
+	return dryRun || annotations[ackv1alpha1.AnnotationPlan] == "true"
+}
+
+// Predicate returns the predicate accepting the updates of the resources
+// whose services.k8s.aws/plan annotation changed, the other updates of their
+// metadata being filtered out, so that they are planned as soon as they are
+// annotated, and acted on as soon as the annotation is removed
+func Predicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return e.ObjectOld.GetAnnotations()[ackv1alpha1.AnnotationPlan] !=
+				e.ObjectNew.GetAnnotations()[ackv1alpha1.AnnotationPlan]
+		},
+	}
+}
+
+// ConfigMapName returns the name of the ConfigMap the plan of the resource of
+// the supplied kind and name is written to, in the namespace of the resource
+func ConfigMapName(kind, name string) string {
+	return "ack-plan-" + strings.ToLower(kind) + "-" + name
+}
+
+// Action is what reconciling a resource would do to its AWS resource
+type Action string
+
+const (
+	// ActionCreate creates the AWS resource, which does not exist
+	ActionCreate Action = "Create"
+	// ActionUpdate updates the AWS resource, whose latest observed state
+	// differs from the desired state
+	ActionUpdate Action = "Update"
+	// ActionDelete deletes the AWS resource of a deleted resource
+	ActionDelete Action = "Delete"
+	// ActionNone leaves the AWS resource unchanged
+	ActionNone Action = "None"
+)
+
+// Change is the change of a field of the AWS resource
+type Change struct {
+	// Path is the path of the field, e.g. "Spec.Tags"
+	Path string `json:"path"`
+	// Current is the latest observed value of the field, nil if the AWS
+	// resource is created
+	Current interface{} `json:"current,omitempty"`
+	// Desired is the desired value of the field
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// Plan is what reconciling a resource would do to its AWS resource
+type Plan struct {
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	Generation int64     `json:"generation"`
+	PlannedAt  time.Time `json:"plannedAt"`
+	Action     Action    `json:"action,omitempty"`
+	Changes    []Change  `json:"changes,omitempty"`
+	// Error is the error that prevented the resource from being planned,
+	// e.g. references that cannot be resolved
+	Error string `json:"error,omitempty"`
+}
+
+// SetLastPlan sets the status.ackResourceMetadata.lastPlan field of the
+// supplied resource, as unstructured content, to the summary of the supplied
+// plan. It returns false, leaving the resource unchanged, if the resource has
+// no resource metadata in its status.
+func SetLastPlan(obj map[string]interface{}, p *Plan) (bool, error) {
+	if _, found, _ := k8sunstructured.NestedMap(obj, "status", "ackResourceMetadata"); !found {
+		return false, nil
+	}
+	plannedAt, err := metav1.NewTime(p.PlannedAt).MarshalQueryParameter()
+	if err != nil {
+		return false, err
+	}
+	paths := make([]interface{}, 0, len(p.Changes))
+	for _, c := range p.Changes {
+		paths = append(paths, c.Path)
+	}
+	summary := map[string]interface{}{
+		"plannedAt": plannedAt,
+		"action":    string(p.Action),
+		"changes":   paths,
+		"error":     p.Error,
+	}
+	if err = k8sunstructured.SetNestedMap(obj, summary, "status", "ackResourceMetadata", "lastPlan"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SpecChanges returns the changes creating the AWS resource of the supplied
+// spec, as unstructured content, sorted by path
+func SpecChanges(spec map[string]interface{}) []Change {
+	changes := make([]Change, 0, len(spec))
+	for field, value := range spec {
+		changes = append(changes, Change{Path: "spec." + field, Desired: value})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// DeltaChanges returns the changes of the spec of the supplied delta between
+// the desired and the latest observed states of a resource, in that order
+func DeltaChanges(delta *ackcompare.Delta) []Change {
+	changes := []Change{}
+	if delta == nil {
+		return changes
+	}
+	for _, diff := range delta.Differences {
+		if !diff.Path.Contains("Spec") {
+			continue
+		}
+		changes = append(changes, Change{
+			Path:    diff.Path.String(),
+			Current: diff.B,
+			Desired: diff.A,
+		})
+	}
+	return changes
+}
+
+// Render returns the human-readable description of the Plan, e.g.
+//
+//	Bucket default/invoices (generation 3) will be updated:
+//	  ~ Spec.Tags: [{"key":"team","value":"a"}] => [{"key":"team","value":"b"}]
+func (p Plan) Render() string {
+	var b strings.Builder
+	resource := fmt.Sprintf("%s %s/%s (generation %d)", p.Kind, p.Namespace, p.Name, p.Generation)
+	switch {
+	case p.Error != "":
+		fmt.Fprintf(&b, "%s could not be planned: %s\n", resource, p.Error)
+	case p.Action == ActionCreate:
+		fmt.Fprintf(&b, "%s will be created:\n", resource)
+		for _, c := range p.Changes {
+			fmt.Fprintf(&b, "  + %s: %s\n", c.Path, renderValue(c.Desired))
+		}
+	case p.Action == ActionUpdate:
+		fmt.Fprintf(&b, "%s will be updated:\n", resource)
+		for _, c := range p.Changes {
+			fmt.Fprintf(&b, "  ~ %s: %s => %s\n", c.Path, renderValue(c.Current), renderValue(c.Desired))
+		}
+	case p.Action == ActionDelete:
+		fmt.Fprintf(&b, "%s will be deleted\n", resource)
+	default:
+		fmt.Fprintf(&b, "%s is up to date, no changes\n", resource)
+	}
+	return b.String()
+}
+
+// renderValue returns the JSON encoding of the supplied value
+func renderValue(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
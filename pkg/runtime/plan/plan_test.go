@@ -0,0 +1,252 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package plan_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/plan"
+)
+
+func TestRequested(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		dryRun      bool
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "not requested",
+			want: false,
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want:   true,
+		},
+		{
+			name:        "annotated",
+			annotations: map[string]string{ackv1alpha1.AnnotationPlan: "true"},
+			want:        true,
+		},
+		{
+			name:        "annotated false",
+			annotations: map[string]string{ackv1alpha1.AnnotationPlan: "false"},
+			want:        false,
+		},
+		{
+			// --dry-run cannot be opted out of by a resource
+			name:        "dry run annotated false",
+			dryRun:      true,
+			annotations: map[string]string{ackv1alpha1.AnnotationPlan: "false"},
+			want:        true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, plan.Requested(tc.dryRun, tc.annotations))
+		})
+	}
+}
+
+func TestPredicate(t *testing.T) {
+	annotated := func(annotations map[string]string) client.Object {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+	planned := map[string]string{ackv1alpha1.AnnotationPlan: "true"}
+
+	for _, tc := range []struct {
+		name string
+		old  client.Object
+		new  client.Object
+		want bool
+	}{
+		{
+			name: "annotation added",
+			old:  annotated(nil),
+			new:  annotated(planned),
+			want: true,
+		},
+		{
+			name: "annotation removed",
+			old:  annotated(planned),
+			new:  annotated(nil),
+			want: true,
+		},
+		{
+			name: "annotation unchanged",
+			old:  annotated(planned),
+			new:  annotated(map[string]string{ackv1alpha1.AnnotationPlan: "true", "team": "a"}),
+			want: false,
+		},
+		{
+			name: "missing object",
+			old:  nil,
+			new:  annotated(planned),
+			want: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := plan.Predicate().Update(event.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new})
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+	kc := fake.NewClientBuilder().Build()
+	owner := metav1.OwnerReference{
+		APIVersion: "bookstore.services.k8s.aws/v1alpha1",
+		Kind:       "Book",
+		Name:       "mybook",
+		UID:        "mybook-uid",
+	}
+	p := &plan.Plan{Kind: "Book", Namespace: "default", Name: "mybook", Action: plan.ActionCreate}
+	name := plan.ConfigMapName(p.Kind, p.Name)
+
+	// The ConfigMap is created, owned by the planned resource
+	written, err := plan.Write(ctx, kc, owner, "default", name, p)
+	require.NoError(err)
+	require.True(written)
+	cm := &corev1.ConfigMap{}
+	require.NoError(kc.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, cm))
+	require.Equal([]metav1.OwnerReference{owner}, cm.OwnerReferences)
+	require.Equal(p.Render(), cm.Data[plan.DataKeyText])
+	decoded := &plan.Plan{}
+	require.NoError(json.Unmarshal([]byte(cm.Data[plan.DataKeyJSON]), decoded))
+	require.Equal(plan.ActionCreate, decoded.Action)
+
+	// The same plan, planned again later, is not written again
+	resourceVersion := cm.ResourceVersion
+	p.PlannedAt = p.PlannedAt.Add(time.Hour)
+	written, err = plan.Write(ctx, kc, owner, "default", name, p)
+	require.NoError(err)
+	require.False(written)
+	cm = &corev1.ConfigMap{}
+	require.NoError(kc.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, cm))
+	require.Equal(resourceVersion, cm.ResourceVersion)
+
+	// The existing ConfigMap is patched with the new plan
+	cm.Labels = map[string]string{"team": "a"}
+	require.NoError(kc.Update(ctx, cm))
+	p.Action = plan.ActionNone
+	written, err = plan.Write(ctx, kc, owner, "default", name, p)
+	require.NoError(err)
+	require.True(written)
+	cm = &corev1.ConfigMap{}
+	require.NoError(kc.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, cm))
+	require.Equal(map[string]string{"team": "a"}, cm.Labels)
+	require.Equal(p.Render(), cm.Data[plan.DataKeyText])
+	require.NoError(json.Unmarshal([]byte(cm.Data[plan.DataKeyJSON]), decoded))
+	require.Equal(plan.ActionNone, decoded.Action)
+}
+
+func TestSetLastPlan(t *testing.T) {
+	require := require.New(t)
+
+	p := &plan.Plan{
+		PlannedAt: time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		Action:    plan.ActionUpdate,
+		Changes: []plan.Change{
+			{Path: "Spec.Versioning", Current: "Suspended", Desired: "Enabled"},
+		},
+	}
+
+	// The resources without resource metadata are left unchanged
+	obj := map[string]interface{}{"status": map[string]interface{}{}}
+	ok, err := plan.SetLastPlan(obj, p)
+	require.NoError(err)
+	require.False(ok)
+	require.Equal(map[string]interface{}{"status": map[string]interface{}{}}, obj)
+
+	obj = map[string]interface{}{
+		"status": map[string]interface{}{
+			"ackResourceMetadata": map[string]interface{}{"region": "us-west-2"},
+		},
+	}
+	ok, err = plan.SetLastPlan(obj, p)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(map[string]interface{}{
+		"region": "us-west-2",
+		"lastPlan": map[string]interface{}{
+			"plannedAt": "2026-10-15T12:00:00Z",
+			"action":    "Update",
+			"changes":   []interface{}{"Spec.Versioning"},
+			"error":     "",
+		},
+	}, obj["status"].(map[string]interface{})["ackResourceMetadata"])
+}
+
+func TestDeltaChanges(t *testing.T) {
+	require := require.New(t)
+
+	require.Empty(plan.DeltaChanges(nil))
+
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.Versioning", "Enabled", "Suspended")
+	delta.Add("Status.State", "", "available")
+	require.Equal([]plan.Change{
+		{Path: "Spec.Versioning", Current: "Suspended", Desired: "Enabled"},
+	}, plan.DeltaChanges(delta))
+}
+
+func TestPlan_Render(t *testing.T) {
+	require := require.New(t)
+
+	p := plan.Plan{Kind: "Bucket", Namespace: "default", Name: "invoices", Generation: 3}
+	require.Equal("Bucket default/invoices (generation 3) is up to date, no changes\n", p.Render())
+
+	p.Action = plan.ActionCreate
+	p.Changes = plan.SpecChanges(map[string]interface{}{
+		"tags": []interface{}{map[string]interface{}{"key": "team", "value": "payments"}},
+		"name": "invoices",
+	})
+	require.Equal(
+		"Bucket default/invoices (generation 3) will be created:\n"+
+			"  + spec.name: \"invoices\"\n"+
+			"  + spec.tags: [{\"key\":\"team\",\"value\":\"payments\"}]\n",
+		p.Render(),
+	)
+
+	p.Action = plan.ActionUpdate
+	p.Changes = []plan.Change{{Path: "Spec.Versioning", Current: "Suspended", Desired: "Enabled"}}
+	require.Equal(
+		"Bucket default/invoices (generation 3) will be updated:\n"+
+			"  ~ Spec.Versioning: \"Suspended\" => \"Enabled\"\n",
+		p.Render(),
+	)
+
+	p.Action = plan.ActionDelete
+	require.Equal("Bucket default/invoices (generation 3) will be deleted\n", p.Render())
+
+	p.Error = "unable to resolve the references: KMSKey default/key not found"
+	require.Contains(p.Render(), "could not be planned: unable to resolve the references")
+
+	require.Equal("ack-plan-bucket-invoices", plan.ConfigMapName("Bucket", "invoices"))
+}
@@ -0,0 +1,120 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/policy"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// PolicyDeniedReason is the reason of the ACK.Blocked, ACK.Terminal and
+	// ACK.ResourceSynced conditions of the resources denied by a policy
+	PolicyDeniedReason = "PolicyDenied"
+	// policyBlockedRequeueAfter is the delay after which the policies
+	// blocking a change of a resource are evaluated again, the policies file
+	// having possibly changed meanwhile
+	policyBlockedRequeueAfter = 5 * time.Minute
+)
+
+// errPolicyDenied is the error of the creations, updates and deletions of
+// the AWS resources blocked by a policy
+var errPolicyDenied = errors.New("denied by policy")
+
+// newPoliciesFile returns the policies file of the --policies-file flag, or
+// nil
+func newPoliciesFile(cfg ackcfg.Config) *policy.File {
+	if cfg.PoliciesFile == "" {
+		return nil
+	}
+	return policy.NewFile(cfg.PoliciesFile)
+}
+
+// checkPolicies evaluates the policies of the supplied operation of the kind
+// of the supplied desired resource, with the supplied delta between its
+// desired and latest states, before its AWS resource is created, updated or
+// deleted. If a policy denies the operation, it returns a copy of the
+// supplied latest resource with its ACK.Blocked or ACK.Terminal condition,
+// depending on the action of the policy, set to the failing rule, and the
+// error requeueing the resource. It returns nil and no error if the
+// operation is allowed.
+func (r *resourceReconciler) checkPolicies(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	op acktypes.AWSResourceOperation,
+	delta *ackcompare.Delta,
+) (acktypes.AWSResource, error) {
+	if r.policies == nil {
+		return nil, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	policies, err := r.policies.For(r.rd.GroupVersionKind().Kind, op)
+	if err != nil {
+		rlog.Info("unable to read policies file", "error", err)
+	}
+	if len(policies) == 0 {
+		ackcondition.RemoveBlocked(latest)
+		return nil, nil
+	}
+	object, err := UnstructuredConverter.ToUnstructured(desired.RuntimeObject())
+	if err != nil {
+		return latest, err
+	}
+	changes, err := policy.Changes(delta)
+	if err != nil {
+		return latest, err
+	}
+	for _, p := range policies {
+		allowed, err := p.Allows(object, changes, op)
+		if err != nil {
+			return latest, err
+		}
+		if allowed {
+			continue
+		}
+		message := p.Denial(op)
+		reason := PolicyDeniedReason
+		rlog.Info("denied by policy", "policy", p.Name, "operation", op, "action", p.Action)
+		r.recordEvent(desired.RuntimeObject(), ackevents.ReasonPolicyDenied, message)
+		denied := latest.DeepCopy()
+		ackcondition.SetSynced(denied, corev1.ConditionFalse, &ackcondition.NotSyncedMessage, &reason)
+		if p.Action == policy.ActionTerminal {
+			// The resource is only reconciled again at the resync period, or
+			// once its spec changes
+			ackcondition.SetTerminal(denied, corev1.ConditionTrue, &message, &reason)
+			return denied, requeue.NeededAfter(ackerr.Terminal, r.resyncPeriod)
+		}
+		ackcondition.SetBlocked(denied, &message, &reason)
+		return denied, requeue.NeededAfter(
+			fmt.Errorf("%w: %s", errPolicyDenied, message), policyBlockedRequeueAfter,
+		)
+	}
+	ackcondition.RemoveBlocked(latest)
+	return nil, nil
+}
@@ -0,0 +1,164 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	k8sobj "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8srtschema "k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	ctrlrtclientmock "github.com/aws-controllers-k8s/runtime/mocks/controller-runtime/pkg/client"
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// unstructuredResourceMock returns a mocked resource whose runtime object
+// is unstructured, so that it can be evaluated by the policies
+func unstructuredResourceMock() *ackmocks.AWSResource {
+	obj := &k8sobj.Unstructured{}
+	obj.SetGroupVersionKind(k8srtschema.GroupVersionKind{
+		Group:   "bookstore.services.k8s.aws",
+		Kind:    "Book",
+		Version: "v1alpha1",
+	})
+	obj.SetAnnotations(map[string]string{})
+	obj.SetNamespace("default")
+	obj.SetName("mybook")
+	obj.SetGeneration(int64(1))
+
+	res := &ackmocks.AWSResource{}
+	res.On("MetaObject").Return(obj)
+	res.On("RuntimeObject").Return(obj)
+	res.On("DeepCopy").Return(res)
+	return res
+}
+
+func TestReconcilerUpdate_DeniedByPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		action        string
+		conditionType ackv1alpha1.ConditionType
+		requeueAfter  time.Duration
+	}{
+		{"Block", ackv1alpha1.ConditionTypeBlocked, 5 * time.Minute},
+		{"Terminal", ackv1alpha1.ConditionTypeTerminal, 0},
+	} {
+		t.Run(tc.action, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.TODO()
+
+			// Other tests replace the converter with a mock
+			conv := ackrt.UnstructuredConverter
+			ackrt.UnstructuredConverter = k8sruntime.DefaultUnstructuredConverter
+			defer func() { ackrt.UnstructuredConverter = conv }()
+
+			path := filepath.Join(t.TempDir(), "policies.yaml")
+			require.Nil(os.WriteFile(path, []byte(`
+policies:
+- name: immutable-a
+  kinds: [fakeBook]
+  action: `+tc.action+`
+  rule: '!changes.exists(c, c.path == "Spec.A")'
+  message: A is immutable
+`), 0o600))
+
+			delta := ackcompare.NewDelta()
+			delta.Add("Spec.A", "val1", "val2")
+
+			desired := unstructuredResourceMock()
+			desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+
+			var conditions []*ackv1alpha1.Condition
+			latest, _, _ := resourceMocks()
+			latest.On("Conditions").Return(func() []*ackv1alpha1.Condition {
+				return conditions
+			})
+			latest.On("ReplaceConditions", mock.Anything).Return().Run(func(args mock.Arguments) {
+				conditions = args.Get(0).([]*ackv1alpha1.Condition)
+			})
+
+			rm := &ackmocks.AWSResourceManager{}
+			rm.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+			rm.On("ClearResolvedReferences", desired).Return(desired)
+			rm.On("ReadOne", ctx, desired).Return(latest, nil)
+
+			rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+			rd.On("IsManaged", desired).Return(true)
+			rd.On("Delta", desired, latest).Return(delta)
+
+			zapOptions := ctrlrtzap.Options{
+				Development: true,
+				Level:       zapcore.InfoLevel,
+			}
+			sc := &ackmocks.ServiceController{}
+			scmd := acktypes.ServiceControllerMetadata{}
+			sc.On("GetMetadata").Return(scmd)
+			kc := &ctrlrtclientmock.Client{}
+			r := ackrt.NewReconcilerWithClient(
+				sc, kc, rmf, ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions)),
+				ackcfg.Config{PoliciesFile: path},
+				ackmetrics.NewMetrics("bookstore"), ackrtcache.Caches{},
+			)
+			rm.On("EnsureTags", ctx, desired, scmd).Return(nil)
+
+			// The update is denied, the resource getting the condition of the
+			// action of the policy, with its failing rule
+			_, err := r.Sync(ctx, rm, desired)
+			var requeueNeededAfter *requeue.RequeueNeededAfter
+			require.True(errors.As(err, &requeueNeededAfter))
+			if tc.requeueAfter != 0 {
+				require.Equal(tc.requeueAfter, requeueNeededAfter.Duration())
+			} else {
+				require.Equal(ackerr.Terminal, requeueNeededAfter.Unwrap())
+			}
+			rm.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			require.Len(conditions, 2)
+			for _, condition := range conditions {
+				require.Equal(ackrt.PolicyDeniedReason, *condition.Reason)
+				switch condition.Type {
+				case tc.conditionType:
+					require.Equal(corev1.ConditionTrue, condition.Status)
+					require.Equal(
+						`Update denied by policy "immutable-a": A is immutable (!changes.exists(c, c.path == "Spec.A"))`,
+						*condition.Message,
+					)
+				case ackv1alpha1.ConditionTypeResourceSynced:
+					require.Equal(corev1.ConditionFalse, condition.Status)
+				default:
+					t.Fatalf("unexpected condition %s", condition.Type)
+				}
+			}
+		})
+	}
+}
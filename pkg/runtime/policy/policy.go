@@ -0,0 +1,324 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package policy contains the policies defined by the operators of a
+// controller: CEL rules, e.g. "no public S3 buckets" or "no 0.0.0.0/0
+// ingress", that the reconciler evaluates against the desired state of a
+// resource and the differences with its latest observed state before
+// creating, updating or deleting its AWS resource. The policies are enforced
+// by the runtime of all the controllers, whatever admission the cluster has.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"sigs.k8s.io/yaml"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// Action is what the reconciler does with the resources denied by a policy
+type Action string
+
+const (
+	// ActionBlock blocks the change of the AWS resource, setting the
+	// ACK.Blocked condition of the resource, and evaluates the policy again
+	// later. It is the default action.
+	ActionBlock Action = "Block"
+	// ActionTerminal sets the ACK.Terminal condition of the resource, whose
+	// spec must be changed for the AWS resource to be changed
+	ActionTerminal Action = "Terminal"
+)
+
+// Policy is a CEL rule that must be true for the AWS resources of its kinds
+// to be created, updated or deleted. The rule is evaluated with the
+// variables:
+//
+//   - object: the desired state of the resource
+//   - changes: the differences between the desired and the latest observed
+//     states of the resource, empty for a creation or a deletion, as a list
+//     of {path, desired, latest} maps, e.g. {"path": "Spec.ACL", "desired":
+//     "public-read", "latest": "private"}
+//   - operation: the operation, "Create", "Update" or "Delete"
+//
+// e.g.:
+//
+//	name: no-public-buckets
+//	kinds: [Bucket]
+//	rule: '!has(object.spec.acl) || !object.spec.acl.startsWith("public-")'
+//	message: S3 buckets must not be public
+type Policy struct {
+	// Name identifies the policy
+	Name string `json:"name"`
+	// Kinds are the kinds of the resources the policy applies to, all the
+	// kinds if empty
+	Kinds []string `json:"kinds,omitempty"`
+	// Operations are the operations the policy applies to, Create and Update
+	// if empty
+	Operations []acktypes.AWSResourceOperation `json:"operations,omitempty"`
+	// Rule is the CEL expression, evaluating to a boolean, that must be true
+	Rule string `json:"rule"`
+	// Action is what the reconciler does with the resources denied by the
+	// policy, Block by default
+	Action Action `json:"action,omitempty"`
+	// Message describes the policy to the owners of the resources it denies
+	Message string `json:"message,omitempty"`
+
+	program cel.Program
+}
+
+// Applies returns true if the policy applies to the supplied operation of
+// the resources of the supplied kind
+func (p *Policy) Applies(kind string, op acktypes.AWSResourceOperation) bool {
+	appliesToKind := len(p.Kinds) == 0
+	for _, k := range p.Kinds {
+		if strings.EqualFold(k, kind) {
+			appliesToKind = true
+			break
+		}
+	}
+	if !appliesToKind {
+		return false
+	}
+	for _, o := range p.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows returns true if the policy allows the supplied operation of the
+// supplied resource, in its unstructured representation, with the supplied
+// changes, as returned by Changes
+func (p *Policy) Allows(
+	object map[string]interface{},
+	changes []interface{},
+	op acktypes.AWSResourceOperation,
+) (bool, error) {
+	out, _, err := p.program.Eval(map[string]interface{}{
+		"object":    object,
+		"changes":   changes,
+		"operation": string(op),
+	})
+	if err != nil {
+		return false, fmt.Errorf("evaluating policy %q: %v", p.Name, err)
+	}
+	allowed, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("evaluating policy %q: rule did not return a boolean", p.Name)
+	}
+	return allowed, nil
+}
+
+// Denial returns the message describing the denial of the supplied
+// operation by the policy, quoting its failing rule
+func (p *Policy) Denial(op acktypes.AWSResourceOperation) string {
+	if p.Message == "" {
+		return fmt.Sprintf("%s denied by policy %q: %s", op, p.Name, p.Rule)
+	}
+	return fmt.Sprintf("%s denied by policy %q: %s (%s)", op, p.Name, p.Message, p.Rule)
+}
+
+// Changes returns the differences of the supplied delta as the changes
+// variable of the rules of the policies. A nil delta has no changes.
+func Changes(delta *ackcompare.Delta) ([]interface{}, error) {
+	changes := []interface{}{}
+	if delta == nil {
+		return changes, nil
+	}
+	for _, diff := range delta.Differences {
+		desired, err := unstructuredValue(diff.A)
+		if err != nil {
+			return nil, err
+		}
+		latest, err := unstructuredValue(diff.B)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, map[string]interface{}{
+			"path":    diff.Path.String(),
+			"desired": desired,
+			"latest":  latest,
+		})
+	}
+	return changes, nil
+}
+
+// unstructuredValue returns the supplied value of a field of a resource in
+// its unstructured representation
+func unstructuredValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var unstructured interface{}
+	if err = json.Unmarshal(data, &unstructured); err != nil {
+		return nil, err
+	}
+	return unstructured, nil
+}
+
+// file is the format of the policies file
+type file struct {
+	Policies []*Policy `json:"policies"`
+}
+
+// Parse returns the policies of the supplied YAML document:
+//
+//	policies:
+//	- name: no-open-ingress
+//	  kinds: [SecurityGroup]
+//	  action: Terminal
+//	  rule: '!has(object.spec.ingressRules) || object.spec.ingressRules.all(r,
+//	    !has(r.ipRanges) || r.ipRanges.all(i, i.cidrIP != "0.0.0.0/0"))'
+func Parse(data []byte) ([]*Policy, error) {
+	f := file{}
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, err
+	}
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]struct{}{}
+	for _, p := range f.Policies {
+		if p.Name == "" {
+			return nil, fmt.Errorf("policy without a name")
+		}
+		if _, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("policy %q is defined more than once", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if len(p.Operations) == 0 {
+			p.Operations = []acktypes.AWSResourceOperation{
+				acktypes.AWSResourceOperationCreate,
+				acktypes.AWSResourceOperationUpdate,
+			}
+		}
+		for i, op := range p.Operations {
+			if p.Operations[i] = operation(op); p.Operations[i] == "" {
+				return nil, fmt.Errorf("policy %q has an invalid operation %q", p.Name, op)
+			}
+		}
+		switch p.Action {
+		case "":
+			p.Action = ActionBlock
+		case ActionBlock, ActionTerminal:
+		default:
+			return nil, fmt.Errorf("policy %q has an invalid action %q", p.Name, p.Action)
+		}
+		ast, issues := env.Compile(p.Rule)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("policy %q has an invalid rule: %v", p.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return nil, fmt.Errorf("policy %q has a rule returning %s instead of a boolean", p.Name, ast.OutputType())
+		}
+		if p.program, err = env.Program(ast); err != nil {
+			return nil, fmt.Errorf("policy %q has an invalid rule: %v", p.Name, err)
+		}
+	}
+	return f.Policies, nil
+}
+
+// operation returns the operation, among the ones the policies apply to,
+// matching the supplied one case-insensitively, or an empty string
+func operation(op acktypes.AWSResourceOperation) acktypes.AWSResourceOperation {
+	for _, o := range []acktypes.AWSResourceOperation{
+		acktypes.AWSResourceOperationCreate,
+		acktypes.AWSResourceOperationUpdate,
+		acktypes.AWSResourceOperationDelete,
+	} {
+		if strings.EqualFold(string(o), string(op)) {
+			return o
+		}
+	}
+	return ""
+}
+
+// newEnv returns the CEL environment of the rules of the policies
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("changes", cel.ListType(cel.DynType)),
+		cel.Variable("operation", cel.StringType),
+	)
+}
+
+// File is the policies file of the --policies-file flag, read again whenever
+// its modification time changes. A missing file has no policies, so that it
+// can be mounted from an optional ConfigMap. A nil File has no policies.
+type File struct {
+	sync.Mutex
+	path     string
+	modTime  time.Time
+	policies []*Policy
+}
+
+// NewFile returns the policies file with the supplied path
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// For returns the policies applying to the supplied operation of the
+// resources of the supplied kind. When the file cannot be read or parsed,
+// the policies last read are returned along with the error.
+func (f *File) For(kind string, op acktypes.AWSResourceOperation) ([]*Policy, error) {
+	if f == nil {
+		return nil, nil
+	}
+	f.Lock()
+	defer f.Unlock()
+	err := f.load()
+	policies := []*Policy{}
+	for _, p := range f.policies {
+		if p.Applies(kind, op) {
+			policies = append(policies, p)
+		}
+	}
+	return policies, err
+}
+
+// load reads the policies file again if it changed since it was last read.
+// The caller must hold the lock.
+func (f *File) load() error {
+	info, err := os.Stat(f.path)
+	if os.IsNotExist(err) {
+		f.policies, f.modTime = nil, time.Time{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if f.policies != nil && info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	policies, err := Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing policies file %s: %v", f.path, err)
+	}
+	f.policies, f.modTime = policies, info.ModTime()
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package policy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/policy"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+func bucket(acl string) map[string]interface{} {
+	spec := map[string]interface{}{}
+	if acl != "" {
+		spec["acl"] = acl
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "my-bucket"},
+		"spec":     spec,
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	require := require.New(t)
+
+	policies, err := policy.Parse([]byte(`
+policies:
+- name: no-public-buckets
+  kinds: [Bucket]
+  rule: '!has(object.spec.acl) || !object.spec.acl.startsWith("public-")'
+  message: S3 buckets must not be public
+- name: no-acl-changes
+  action: Terminal
+  operations: [update, Delete]
+  rule: '!changes.exists(c, c.path == "Spec.ACL" && c.latest == "private")'
+`))
+	require.Nil(err)
+	require.Len(policies, 2)
+	public := policies[0]
+	require.Equal(policy.ActionBlock, public.Action)
+	require.True(public.Applies("bucket", acktypes.AWSResourceOperationCreate))
+	require.True(public.Applies("Bucket", acktypes.AWSResourceOperationUpdate))
+	require.False(public.Applies("Bucket", acktypes.AWSResourceOperationDelete))
+	require.False(public.Applies("Queue", acktypes.AWSResourceOperationCreate))
+
+	noChanges, err := policy.Changes(nil)
+	require.Nil(err)
+	allowed, err := public.Allows(bucket("private"), noChanges, acktypes.AWSResourceOperationCreate)
+	require.Nil(err)
+	require.True(allowed)
+	allowed, err = public.Allows(bucket(""), noChanges, acktypes.AWSResourceOperationCreate)
+	require.Nil(err)
+	require.True(allowed)
+	allowed, err = public.Allows(bucket("public-read"), noChanges, acktypes.AWSResourceOperationCreate)
+	require.Nil(err)
+	require.False(allowed)
+	require.Equal(
+		`Create denied by policy "no-public-buckets": S3 buckets must not be public `+
+			`(!has(object.spec.acl) || !object.spec.acl.startsWith("public-"))`,
+		public.Denial(acktypes.AWSResourceOperationCreate),
+	)
+
+	acl := policies[1]
+	require.Equal(policy.ActionTerminal, acl.Action)
+	require.True(acl.Applies("Queue", acktypes.AWSResourceOperationUpdate))
+	require.True(acl.Applies("Queue", acktypes.AWSResourceOperationDelete))
+	require.False(acl.Applies("Queue", acktypes.AWSResourceOperationCreate))
+
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.ACL", stringPtr("public-read"), stringPtr("private"))
+	changes, err := policy.Changes(delta)
+	require.Nil(err)
+	require.Equal([]interface{}{
+		map[string]interface{}{"path": "Spec.ACL", "desired": "public-read", "latest": "private"},
+	}, changes)
+	allowed, err = acl.Allows(bucket("public-read"), changes, acktypes.AWSResourceOperationUpdate)
+	require.Nil(err)
+	require.False(allowed)
+	require.Equal(
+		`Update denied by policy "no-acl-changes": !changes.exists(c, c.path == "Spec.ACL" && c.latest == "private")`,
+		acl.Denial(acktypes.AWSResourceOperationUpdate),
+	)
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{
+		"policies:\n- rule: 'true'",
+		"policies:\n- name: a\n  rule: 'true'\n- name: a\n  rule: 'true'",
+		"policies:\n- name: a\n  operations: [ReadOne]\n  rule: 'true'",
+		"policies:\n- name: a\n  action: Warn\n  rule: 'true'",
+		"policies:\n- name: a\n  rule: 'size('",
+		"policies:\n- name: a\n  rule: 'operation'",
+		"policies:\n- name: a\n  rules: 'true'",
+	} {
+		_, err := policy.Parse([]byte(data))
+		require.NotNil(t, err, data)
+	}
+}
+
+func TestFile(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+
+	// A missing file has no policies
+	f := policy.NewFile(path)
+	policies, err := f.For("Bucket", acktypes.AWSResourceOperationCreate)
+	require.Nil(err)
+	require.Empty(policies)
+
+	require.Nil(os.WriteFile(path, []byte(`
+policies:
+- name: no-bucket-deletion
+  kinds: [Bucket]
+  operations: [Delete]
+  rule: 'false'
+`), 0o600))
+	policies, err = f.For("Bucket", acktypes.AWSResourceOperationDelete)
+	require.Nil(err)
+	require.Len(policies, 1)
+	policies, err = f.For("Bucket", acktypes.AWSResourceOperationUpdate)
+	require.Nil(err)
+	require.Empty(policies)
+
+	var nilFile *policy.File
+	policies, err = nilFile.For("Bucket", acktypes.AWSResourceOperationDelete)
+	require.Nil(err)
+	require.Empty(policies)
+}
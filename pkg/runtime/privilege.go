@@ -0,0 +1,66 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// checkPermitted returns an error if the controller runs in minimal-privilege
+// mode and is not granted the IAM actions the resource manager declares the
+// supplied operations require. The skipped operation is reported with an
+// event and retried after the resync period, in case the granted actions
+// change.
+func (r *resourceReconciler) checkPermitted(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+	ops ...acktypes.AWSResourceOperation,
+) error {
+	if r.grantedActions == nil {
+		return nil
+	}
+	describer, ok := rm.(acktypes.AWSResourceActionDescriber)
+	if !ok {
+		return nil
+	}
+	for _, op := range ops {
+		missing := r.grantedActions.Missing(describer.RequiredActions(op, res))
+		if len(missing) == 0 {
+			continue
+		}
+		ackrtlog.FromContext(ctx).Info(
+			"skipping operation lacking granted IAM actions",
+			"operation", op,
+			"missing_actions", missing,
+		)
+		r.recordEvent(
+			res.RuntimeObject(), ackevents.ReasonOperationNotPermitted,
+			fmt.Sprintf("Skipped %s: the controller is not granted %s", op, strings.Join(missing, ", ")),
+		)
+		return requeue.NeededAfter(
+			fmt.Errorf("%w: %s requires %s", ackerr.OperationNotPermitted, op, strings.Join(missing, ", ")),
+			r.resyncPeriod,
+		)
+	}
+	return nil
+}
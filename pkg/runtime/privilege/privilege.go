@@ -0,0 +1,79 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package privilege matches the IAM actions required by the operations of
+// the resource managers against the IAM actions granted to a controller
+// running in minimal-privilege mode.
+package privilege
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Actions is a set of granted IAM actions. Actions can contain the IAM
+// wildcards '*' and '?' (e.g. "s3:Get*") and are matched case-insensitively,
+// like in IAM policies. A nil Actions grants every action.
+type Actions struct {
+	exact    map[string]struct{}
+	patterns []*regexp.Regexp
+}
+
+// New returns the Actions granting the supplied IAM actions, or nil if no
+// action is supplied, meaning that the granted actions are unknown.
+func New(actions []string) *Actions {
+	if len(actions) == 0 {
+		return nil
+	}
+	a := &Actions{exact: map[string]struct{}{}}
+	for _, action := range actions {
+		action = strings.ToLower(strings.TrimSpace(action))
+		if !strings.ContainsAny(action, "*?") {
+			a.exact[action] = struct{}{}
+			continue
+		}
+		pattern := regexp.QuoteMeta(action)
+		pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+		pattern = strings.ReplaceAll(pattern, `\?`, ".")
+		a.patterns = append(a.patterns, regexp.MustCompile("^"+pattern+"$"))
+	}
+	return a
+}
+
+// Allows returns true if the supplied IAM action is granted
+func (a *Actions) Allows(action string) bool {
+	if a == nil {
+		return true
+	}
+	action = strings.ToLower(action)
+	if _, ok := a.exact[action]; ok {
+		return true
+	}
+	for _, pattern := range a.patterns {
+		if pattern.MatchString(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the supplied required IAM actions that are not granted
+func (a *Actions) Missing(required []string) []string {
+	var missing []string
+	for _, action := range required {
+		if !a.Allows(action) {
+			missing = append(missing, action)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package privilege_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/privilege"
+)
+
+func TestActions(t *testing.T) {
+	require := require.New(t)
+
+	actions := privilege.New([]string{"s3:CreateBucket", "s3:Get*", "ec2:Describe?pc", " iam:PassRole "})
+	require.True(actions.Allows("s3:CreateBucket"))
+	require.True(actions.Allows("S3:createbucket"))
+	require.True(actions.Allows("s3:GetBucketTagging"))
+	require.True(actions.Allows("ec2:DescribeVpc"))
+	require.True(actions.Allows("iam:PassRole"))
+	require.False(actions.Allows("s3:DeleteBucket"))
+	require.False(actions.Allows("ec2:DescribeVpcs"))
+	require.False(actions.Allows("s3x:GetObject"))
+
+	require.Nil(actions.Missing([]string{"s3:CreateBucket", "s3:GetBucketPolicy"}))
+	require.Equal(
+		[]string{"s3:DeleteBucket", "s3:PutBucketTagging"},
+		actions.Missing([]string{"s3:DeleteBucket", "s3:GetBucketPolicy", "s3:PutBucketTagging"}),
+	)
+
+	require.True(privilege.New([]string{"*"}).Allows("s3:DeleteBucket"))
+
+	var nilActions *privilege.Actions
+	require.Nil(privilege.New(nil))
+	require.True(nilActions.Allows("s3:DeleteBucket"))
+	require.Nil(nilActions.Missing([]string{"s3:DeleteBucket"}))
+}
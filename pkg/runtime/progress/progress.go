@@ -0,0 +1,130 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package progress resolves the progress deadlines of the resources, after
+// which the resources whose create, update or delete operation is still not
+// complete are degraded.
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// Operation is the operation a resource progresses through
+type Operation string
+
+const (
+	// OperationCreate is the creation of the AWS resource of a resource,
+	// until the resource first syncs
+	OperationCreate Operation = "create"
+	// OperationUpdate is the update of the AWS resource of a resource, until
+	// the resource syncs again
+	OperationUpdate Operation = "update"
+	// OperationDelete is the deletion of the AWS resource of a deleted
+	// resource, until the resource is gone
+	OperationDelete Operation = "delete"
+)
+
+// operationAnnotations are the annotations of the progress deadlines of each
+// operation
+var operationAnnotations = map[Operation]string{
+	OperationCreate: ackv1alpha1.AnnotationCreateProgressDeadline,
+	OperationUpdate: ackv1alpha1.AnnotationUpdateProgressDeadline,
+	OperationDelete: ackv1alpha1.AnnotationDeleteProgressDeadline,
+}
+
+// Deadline returns the progress deadline of the supplied operation of the
+// supplied resource, from the annotation of the operation, e.g.
+// `services.k8s.aws/create-progress-deadline`, or from its
+// `services.k8s.aws/progress-deadline` annotation, or the supplied default
+// deadline of its kind if it has neither annotation. A zero deadline disables
+// the progress deadline. If the annotation is not a non-negative duration,
+// the default deadline is returned along with an error.
+func Deadline(obj metav1.Object, op Operation, defaultDeadline time.Duration) (time.Duration, error) {
+	annotations := obj.GetAnnotations()
+	annotation := operationAnnotations[op]
+	value, ok := annotations[annotation]
+	if !ok {
+		annotation = ackv1alpha1.AnnotationProgressDeadline
+		value, ok = annotations[annotation]
+	}
+	if !ok {
+		return defaultDeadline, nil
+	}
+	deadline, err := time.ParseDuration(value)
+	if err != nil || deadline < 0 {
+		return defaultDeadline, fmt.Errorf(
+			"invalid value %q of annotation %s, expected a non-negative duration", value, annotation,
+		)
+	}
+	return deadline, nil
+}
+
+// OperationOf returns the operation the supplied resource progresses through,
+// given its ACK.ResourceSynced and ACK.Degraded conditions before its latest
+// reconciliation. A deleted resource is deleted. Otherwise, the operation of
+// a resource that was already progressing is the operation recorded in its
+// ACK.Degraded condition, and a resource that was never reconciled is
+// created.
+func OperationOf(obj metav1.Object, synced *ackv1alpha1.Condition, degraded *ackv1alpha1.Condition) Operation {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return OperationDelete
+	}
+	switch op := RecordedOperation(degraded); op {
+	case OperationCreate, OperationUpdate:
+		return op
+	}
+	if synced == nil {
+		return OperationCreate
+	}
+	return OperationUpdate
+}
+
+// RecordedOperation returns the operation recorded in the reason of the
+// supplied ACK.Degraded condition, if any
+func RecordedOperation(degraded *ackv1alpha1.Condition) Operation {
+	if degraded == nil || degraded.Reason == nil {
+		return ""
+	}
+	for _, op := range []Operation{OperationCreate, OperationUpdate, OperationDelete} {
+		if *degraded.Reason == ProgressingReason(op) || strings.HasPrefix(*degraded.Reason, exceededPrefix(op)) {
+			return op
+		}
+	}
+	return ""
+}
+
+// ProgressingReason returns the reason of the False ACK.Degraded condition of
+// a resource progressing through the supplied operation
+func ProgressingReason(op Operation) string {
+	return fmt.Sprintf("Waiting for the %s operation of the resource to complete", op)
+}
+
+// ExceededReason returns the reason of the True ACK.Degraded condition of a
+// resource whose supplied operation did not complete within the supplied
+// deadline
+func ExceededReason(op Operation, deadline time.Duration) string {
+	return fmt.Sprintf("%s within its progress deadline of %s", exceededPrefix(op), deadline)
+}
+
+// exceededPrefix returns the prefix of the reason of the True ACK.Degraded
+// condition of a resource whose supplied operation did not complete in time
+func exceededPrefix(op Operation) string {
+	return fmt.Sprintf("The %s operation of the resource did not complete", op)
+}
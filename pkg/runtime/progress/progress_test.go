@@ -0,0 +1,260 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package progress_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/progress"
+)
+
+func TestDeadline(t *testing.T) {
+	cfg := ackcfg.Config{
+		DefaultProgressDeadlineSeconds:  600,
+		ResourceProgressDeadlineSeconds: []string{"DBInstance=3600"},
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		op          progress.Operation
+		kind        string
+		want        time.Duration
+		wantErr     bool
+	}{{
+		name:        "annotation",
+		annotations: map[string]string{ackv1alpha1.AnnotationProgressDeadline: "2h30m"},
+		op:          progress.OperationCreate,
+		kind:        "DBInstance",
+		want:        150 * time.Minute,
+	}, {
+		name:        "annotation disables",
+		annotations: map[string]string{ackv1alpha1.AnnotationProgressDeadline: "0s"},
+		op:          progress.OperationUpdate,
+		kind:        "DBInstance",
+		want:        0,
+	}, {
+		name: "operation annotation over annotation",
+		annotations: map[string]string{
+			ackv1alpha1.AnnotationProgressDeadline:       "2h",
+			ackv1alpha1.AnnotationCreateProgressDeadline: "45m",
+			ackv1alpha1.AnnotationDeleteProgressDeadline: "15m",
+		},
+		op:   progress.OperationCreate,
+		kind: "DBInstance",
+		want: 45 * time.Minute,
+	}, {
+		name: "delete annotation",
+		annotations: map[string]string{
+			ackv1alpha1.AnnotationCreateProgressDeadline: "45m",
+			ackv1alpha1.AnnotationDeleteProgressDeadline: "15m",
+		},
+		op:   progress.OperationDelete,
+		kind: "DBInstance",
+		want: 15 * time.Minute,
+	}, {
+		name: "other operation annotation",
+		annotations: map[string]string{
+			ackv1alpha1.AnnotationProgressDeadline:       "2h",
+			ackv1alpha1.AnnotationCreateProgressDeadline: "45m",
+		},
+		op:   progress.OperationUpdate,
+		kind: "DBInstance",
+		want: 2 * time.Hour,
+	}, {
+		name:        "operation annotation disables",
+		annotations: map[string]string{ackv1alpha1.AnnotationUpdateProgressDeadline: "0s"},
+		op:          progress.OperationUpdate,
+		kind:        "DBInstance",
+		want:        0,
+	}, {
+		name: "kind default",
+		op:   progress.OperationCreate,
+		kind: "DBInstance",
+		want: time.Hour,
+	}, {
+		name:        "flag default",
+		annotations: map[string]string{ackv1alpha1.AnnotationCreateProgressDeadline: "1h"},
+		op:          progress.OperationDelete,
+		kind:        "Bucket",
+		want:        10 * time.Minute,
+	}, {
+		name:        "invalid duration",
+		annotations: map[string]string{ackv1alpha1.AnnotationProgressDeadline: "soon"},
+		op:          progress.OperationCreate,
+		kind:        "Bucket",
+		want:        10 * time.Minute,
+		wantErr:     true,
+	}, {
+		name: "invalid operation duration",
+		annotations: map[string]string{
+			ackv1alpha1.AnnotationProgressDeadline:       "2h",
+			ackv1alpha1.AnnotationDeleteProgressDeadline: "soon",
+		},
+		op:      progress.OperationDelete,
+		kind:    "Bucket",
+		want:    10 * time.Minute,
+		wantErr: true,
+	}, {
+		name:        "duration without unit",
+		annotations: map[string]string{ackv1alpha1.AnnotationProgressDeadline: "3600"},
+		op:          progress.OperationCreate,
+		kind:        "DBInstance",
+		want:        time.Hour,
+		wantErr:     true,
+	}, {
+		name:        "empty duration",
+		annotations: map[string]string{ackv1alpha1.AnnotationProgressDeadline: ""},
+		op:          progress.OperationCreate,
+		kind:        "Bucket",
+		want:        10 * time.Minute,
+		wantErr:     true,
+	}, {
+		name:        "negative duration",
+		annotations: map[string]string{ackv1alpha1.AnnotationUpdateProgressDeadline: "-1h"},
+		op:          progress.OperationUpdate,
+		kind:        "DBInstance",
+		want:        time.Hour,
+		wantErr:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			obj := &metav1.ObjectMeta{Annotations: tt.annotations}
+			deadline, err := progress.Deadline(obj, tt.op, cfg.GetReconcileResourceProgressDeadline(tt.kind))
+			if tt.wantErr {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tt.want, deadline)
+		})
+	}
+}
+
+func TestOperationOf(t *testing.T) {
+	degraded := func(status corev1.ConditionStatus, reason string) *ackv1alpha1.Condition {
+		return &ackv1alpha1.Condition{
+			Type:   ackv1alpha1.ConditionTypeDegraded,
+			Status: status,
+			Reason: &reason,
+		}
+	}
+	synced := &ackv1alpha1.Condition{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionFalse,
+	}
+	now := metav1.Now()
+	tests := []struct {
+		name     string
+		deleted  bool
+		synced   *ackv1alpha1.Condition
+		degraded *ackv1alpha1.Condition
+		want     progress.Operation
+	}{{
+		name: "never reconciled",
+		want: progress.OperationCreate,
+	}, {
+		name:   "reconciled",
+		synced: synced,
+		want:   progress.OperationUpdate,
+	}, {
+		name:     "creating",
+		synced:   synced,
+		degraded: degraded(corev1.ConditionFalse, progress.ProgressingReason(progress.OperationCreate)),
+		want:     progress.OperationCreate,
+	}, {
+		name:   "create exceeded",
+		synced: synced,
+		degraded: degraded(
+			corev1.ConditionTrue, progress.ExceededReason(progress.OperationCreate, time.Hour),
+		),
+		want: progress.OperationCreate,
+	}, {
+		name:     "updating",
+		synced:   synced,
+		degraded: degraded(corev1.ConditionFalse, progress.ProgressingReason(progress.OperationUpdate)),
+		want:     progress.OperationUpdate,
+	}, {
+		name:     "unknown reason",
+		synced:   synced,
+		degraded: degraded(corev1.ConditionFalse, "other"),
+		want:     progress.OperationUpdate,
+	}, {
+		name:     "deleted while creating",
+		deleted:  true,
+		synced:   synced,
+		degraded: degraded(corev1.ConditionFalse, progress.ProgressingReason(progress.OperationCreate)),
+		want:     progress.OperationDelete,
+	}, {
+		name:    "deleted",
+		deleted: true,
+		want:    progress.OperationDelete,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{}
+			if tt.deleted {
+				obj.DeletionTimestamp = &now
+			}
+			require.Equal(t, tt.want, progress.OperationOf(obj, tt.synced, tt.degraded))
+		})
+	}
+}
+
+func TestRecordedOperation(t *testing.T) {
+	tests := []struct {
+		name     string
+		degraded *ackv1alpha1.Condition
+		want     progress.Operation
+	}{{
+		name: "no condition",
+	}, {
+		name:     "no reason",
+		degraded: &ackv1alpha1.Condition{Type: ackv1alpha1.ConditionTypeDegraded},
+	}, {
+		name: "progressing",
+		degraded: &ackv1alpha1.Condition{
+			Reason: ptr(progress.ProgressingReason(progress.OperationDelete)),
+		},
+		want: progress.OperationDelete,
+	}, {
+		name: "exceeded",
+		degraded: &ackv1alpha1.Condition{
+			Reason: ptr(progress.ExceededReason(progress.OperationUpdate, 10*time.Minute)),
+		},
+		want: progress.OperationUpdate,
+	}, {
+		name: "unknown reason",
+		degraded: &ackv1alpha1.Condition{
+			Reason: ptr("The resource did not sync within its progress deadline of 10m0s"),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, progress.RecordedOperation(tt.degraded))
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,128 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtprogress "github.com/aws-controllers-k8s/runtime/pkg/runtime/progress"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// checkProgressDeadline sets the ACK.Degraded condition of the supplied
+// resource, reconciled with the supplied previous conditions, when the
+// operation it progresses through has a progress deadline:
+//
+//   - synced resources have no ACK.Degraded condition, unless they are
+//     deleted
+//   - resources that are not synced yet, or whose AWS resource is being
+//     deleted, are progressing, with a False ACK.Degraded condition whose
+//     last transition time is the time they started progressing and whose
+//     reason records the operation they progress through
+//   - resources whose operation is still not complete after its progress
+//     deadline are degraded, with a True ACK.Degraded condition, an event
+//     and a metric
+//
+// Degraded resources are still retried as usual.
+func (r *resourceReconciler) checkProgressDeadline(
+	ctx context.Context,
+	previousConditions []*ackv1alpha1.Condition,
+	latest acktypes.AWSResource,
+) {
+	if ackcompare.IsNil(latest) {
+		return
+	}
+	var previous, previousSynced *ackv1alpha1.Condition
+	for _, c := range previousConditions {
+		switch c.Type {
+		case ackv1alpha1.ConditionTypeDegraded:
+			previous = c
+		case ackv1alpha1.ConditionTypeResourceSynced:
+			previousSynced = c
+		}
+	}
+	kind := r.rd.GroupVersionKind().Kind
+	op := ackrtprogress.OperationOf(latest.MetaObject(), previousSynced, previous)
+	deadline := r.getProgressDeadline(ctx, latest, op)
+	if deadline <= 0 {
+		return
+	}
+	if synced := condition.Synced(latest); op != ackrtprogress.OperationDelete &&
+		synced != nil && synced.Status == corev1.ConditionTrue {
+		condition.RemoveDegraded(latest)
+		return
+	}
+	// The deletion of a resource starts a new progress, even if it was
+	// still progressing through its creation or an update
+	if previous == nil || previous.LastTransitionTime == nil ||
+		(op == ackrtprogress.OperationDelete && ackrtprogress.RecordedOperation(previous) != op) {
+		reason := ackrtprogress.ProgressingReason(op)
+		condition.SetDegraded(latest, corev1.ConditionFalse, &condition.ProgressingMessage, &reason)
+		return
+	}
+	if previous.Status == corev1.ConditionTrue || time.Since(previous.LastTransitionTime.Time) < deadline {
+		restoreCondition(latest, previous)
+		return
+	}
+	reason := ackrtprogress.ExceededReason(op, deadline)
+	ackrtlog.FromContext(ctx).Info(
+		"resource operation did not complete within its progress deadline",
+		"operation", op,
+		"deadline", deadline,
+		"progressing_since", previous.LastTransitionTime.Time,
+	)
+	condition.SetDegraded(latest, corev1.ConditionTrue, &condition.ProgressDeadlineExceededMessage, &reason)
+	r.recordEvent(latest.RuntimeObject(), ackevents.ReasonProgressDeadlineExceeded, reason)
+	if r.metrics != nil {
+		r.metrics.RecordProgressDeadlineExceeded(kind)
+	}
+}
+
+// getProgressDeadline returns the progress deadline of the supplied operation
+// of the supplied resource. We look for the progress deadline annotations of
+// the resource first. Otherwise we use the progress deadline of its kind in
+// the controller configuration.
+func (r *resourceReconciler) getProgressDeadline(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	op ackrtprogress.Operation,
+) time.Duration {
+	deadline, err := ackrtprogress.Deadline(
+		res.MetaObject(), op, r.cfg.GetReconcileResourceProgressDeadline(r.rd.GroupVersionKind().Kind),
+	)
+	if err != nil {
+		ackrtlog.FromContext(ctx).Info("ignoring invalid progress deadline", "error", err)
+	}
+	return deadline
+}
+
+// restoreCondition replaces the condition of the supplied resource of the
+// type of the supplied condition by a copy of it
+func restoreCondition(res acktypes.AWSResource, c *ackv1alpha1.Condition) {
+	conditions := []*ackv1alpha1.Condition{}
+	for _, existing := range res.Conditions() {
+		if existing.Type != c.Type {
+			conditions = append(conditions, existing)
+		}
+	}
+	res.ReplaceConditions(append(conditions, c.DeepCopy()))
+}
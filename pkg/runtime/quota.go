@@ -0,0 +1,72 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtquota "github.com/aws-controllers-k8s/runtime/pkg/runtime/quota"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// addQuotaMonitor adds to the supplied manager the monitor of the AWS service
+// quotas declared by the supplied resource manager factories implementing
+// AWSResourceQuotaDescriber, and returns it. The quotas are read with the
+// default AWS configuration of the controller, in its account and region. It
+// returns nil if the monitoring is disabled or no kind declares quotas.
+func (c *serviceController) addQuotaMonitor(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+	rmfs map[string]acktypes.AWSResourceManagerFactory,
+) (*ackrtquota.Monitor, error) {
+	if cfg.ServiceQuotaIntervalSeconds <= 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(rmfs))
+	for key, rmf := range rmfs {
+		if describer, ok := rmf.(acktypes.AWSResourceQuotaDescriber); ok && len(describer.Quotas()) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	sort.Strings(keys)
+	awsCfg, err := c.NewAWSConfig(
+		context.Background(), ackv1alpha1.AWSRegion(cfg.Region), nil, "",
+		rmfs[keys[0]].ResourceDescriptor().GroupVersionKind(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	monitor := ackrtquota.NewMonitor(
+		c.log.WithName("service-quotas"), ackrtquota.NewAWSClient(awsCfg), c.metrics,
+		cfg.ServiceQuotaWarningThreshold/100,
+		time.Duration(cfg.ServiceQuotaIntervalSeconds)*time.Second,
+	)
+	for _, key := range keys {
+		rmf := rmfs[key]
+		monitor.Track(
+			rmf.ResourceDescriptor().GroupVersionKind().Kind,
+			rmf.(acktypes.AWSResourceQuotaDescriber).Quotas()...,
+		)
+	}
+	return monitor, mgr.Add(monitor)
+}
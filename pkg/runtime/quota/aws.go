@@ -0,0 +1,153 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	sqtypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// usageWindow is the window in which the latest datapoint of the usage
+	// metric of a quota is looked for
+	usageWindow = time.Hour
+	// usagePeriod is the period, in seconds, of the datapoints of the usage
+	// metric of a quota
+	usagePeriod = 300
+)
+
+// awsClient reads the value of the quotas from Service Quotas, and their
+// usage from the CloudWatch usage metric Service Quotas associates them with
+type awsClient struct {
+	quotas  *servicequotas.Client
+	metrics *cloudwatch.Client
+}
+
+// NewAWSClient returns the Client reading the quotas of the account and
+// region of the supplied AWS configuration
+func NewAWSClient(cfg aws.Config) Client {
+	return &awsClient{
+		quotas:  servicequotas.NewFromConfig(cfg),
+		metrics: cloudwatch.NewFromConfig(cfg),
+	}
+}
+
+// Usage returns the usage of the supplied quota. The default value of the
+// quota is used when it was never adjusted for the account.
+func (c *awsClient) Usage(
+	ctx context.Context,
+	quota acktypes.AWSResourceQuota,
+) (Usage, error) {
+	var sq *sqtypes.ServiceQuota
+	out, err := c.quotas.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(quota.ServiceCode),
+		QuotaCode:   aws.String(quota.QuotaCode),
+	})
+	var notFound *sqtypes.NoSuchResourceException
+	if errors.As(err, &notFound) {
+		var defaultOut *servicequotas.GetAWSDefaultServiceQuotaOutput
+		defaultOut, err = c.quotas.GetAWSDefaultServiceQuota(ctx, &servicequotas.GetAWSDefaultServiceQuotaInput{
+			ServiceCode: aws.String(quota.ServiceCode),
+			QuotaCode:   aws.String(quota.QuotaCode),
+		})
+		if err == nil {
+			sq = defaultOut.Quota
+		}
+	} else if err == nil {
+		sq = out.Quota
+	}
+	if err != nil {
+		return Usage{}, err
+	}
+	if sq == nil || sq.Value == nil {
+		return Usage{}, fmt.Errorf("quota %s of service %s has no value", quota.QuotaCode, quota.ServiceCode)
+	}
+	if sq.UsageMetric == nil || sq.UsageMetric.MetricName == nil {
+		return Usage{}, fmt.Errorf("quota %s of service %s has no usage metric", quota.QuotaCode, quota.ServiceCode)
+	}
+	used, err := c.latestValue(ctx, sq.UsageMetric)
+	if err != nil {
+		return Usage{}, err
+	}
+	return Usage{Limit: *sq.Value, Used: used}, nil
+}
+
+// latestValue returns the value of the latest datapoint of the supplied
+// usage metric, with its recommended statistic, or 0 if the metric has no
+// datapoint in the usage window, nothing consuming the quota
+func (c *awsClient) latestValue(
+	ctx context.Context,
+	metric *sqtypes.MetricInfo,
+) (float64, error) {
+	statistic := cwtypes.StatisticMaximum
+	if metric.MetricStatisticRecommendation != nil {
+		statistic = cwtypes.Statistic(*metric.MetricStatisticRecommendation)
+	}
+	dimensions := make([]cwtypes.Dimension, 0, len(metric.MetricDimensions))
+	for name, value := range metric.MetricDimensions {
+		dimensions = append(dimensions, cwtypes.Dimension{
+			Name:  aws.String(name),
+			Value: aws.String(value),
+		})
+	}
+	now := time.Now()
+	out, err := c.metrics.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  metric.MetricNamespace,
+		MetricName: metric.MetricName,
+		Dimensions: dimensions,
+		StartTime:  aws.Time(now.Add(-usageWindow)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int32(usagePeriod),
+		Statistics: []cwtypes.Statistic{statistic},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var latest *cwtypes.Datapoint
+	for i, dp := range out.Datapoints {
+		if dp.Timestamp == nil {
+			continue
+		}
+		if latest == nil || dp.Timestamp.After(*latest.Timestamp) {
+			latest = &out.Datapoints[i]
+		}
+	}
+	if latest == nil {
+		return 0, nil
+	}
+	var value *float64
+	switch statistic {
+	case cwtypes.StatisticAverage:
+		value = latest.Average
+	case cwtypes.StatisticMinimum:
+		value = latest.Minimum
+	case cwtypes.StatisticSum:
+		value = latest.Sum
+	case cwtypes.StatisticSampleCount:
+		value = latest.SampleCount
+	default:
+		value = latest.Maximum
+	}
+	return aws.ToFloat64(value), nil
+}
@@ -0,0 +1,185 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package quota monitors the usage of the AWS service quotas consumed by the
+// kinds of a service controller, exports their remaining capacity as metrics
+// and warns, with a condition of the ControllerConfig, when they are nearly
+// exhausted.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// Usage is the usage of an AWS service quota
+type Usage struct {
+	// Limit is the value of the quota
+	Limit float64
+	// Used is the consumed part of the quota
+	Used float64
+}
+
+// Ratio returns the consumed fraction of the quota, 0 if the quota has no
+// value
+func (u Usage) Ratio() float64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return u.Used / u.Limit
+}
+
+// Client reads the usage of AWS service quotas
+type Client interface {
+	// Usage returns the usage of the supplied quota
+	Usage(ctx context.Context, quota acktypes.AWSResourceQuota) (Usage, error)
+}
+
+// Monitor periodically reads the usage of the quotas consumed by the tracked
+// kinds, records it in the metrics and keeps the resulting condition.
+// Monitor implements the controller-runtime manager.Runnable interface and,
+// as the quotas are the same for all the replicas, only runs on the leader.
+type Monitor struct {
+	sync.RWMutex
+	log       logr.Logger
+	client    Client
+	metrics   *ackmetrics.Metrics
+	threshold float64
+	interval  time.Duration
+	quotas    map[string][]acktypes.AWSResourceQuota
+	condition *ackv1alpha1.Condition
+}
+
+// NewMonitor returns a Monitor reading the usage of the quotas with the
+// supplied client at the supplied interval, and warning when the consumed
+// fraction of a quota reaches the supplied threshold
+func NewMonitor(
+	log logr.Logger,
+	client Client,
+	metrics *ackmetrics.Metrics,
+	threshold float64,
+	interval time.Duration,
+) *Monitor {
+	return &Monitor{
+		log:       log,
+		client:    client,
+		metrics:   metrics,
+		threshold: threshold,
+		interval:  interval,
+		quotas:    map[string][]acktypes.AWSResourceQuota{},
+	}
+}
+
+// Track adds the supplied quotas of the supplied kind to the monitored ones
+func (m *Monitor) Track(kind string, quotas ...acktypes.AWSResourceQuota) {
+	m.Lock()
+	defer m.Unlock()
+	m.quotas[kind] = append(m.quotas[kind], quotas...)
+}
+
+// Start checks the usage of the quotas until the supplied context is done
+func (m *Monitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reads the usage of the quotas of the tracked kinds, each quota being
+// read once even when it is consumed by several kinds, and returns the
+// resulting conditions. The quotas whose usage cannot be read are skipped.
+func (m *Monitor) Check(ctx context.Context) []*ackv1alpha1.Condition {
+	m.Lock()
+	kinds := make([]string, 0, len(m.quotas))
+	for kind := range m.quotas {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	usages := map[acktypes.AWSResourceQuota]Usage{}
+	exhausted := []string{}
+	for _, kind := range kinds {
+		for _, quota := range m.quotas[kind] {
+			usage, ok := usages[quota]
+			if !ok {
+				var err error
+				if usage, err = m.client.Usage(ctx, quota); err != nil {
+					m.log.Error(err, "unable to read the usage of the service quota",
+						"kind", kind, "service_code", quota.ServiceCode, "quota_code", quota.QuotaCode)
+					continue
+				}
+				usages[quota] = usage
+			}
+			if m.metrics != nil {
+				m.metrics.RecordServiceQuota(kind, quota.ServiceCode, quota.QuotaCode, usage.Limit, usage.Used)
+			}
+			if usage.Limit > 0 && usage.Ratio() >= m.threshold {
+				exhausted = append(exhausted, fmt.Sprintf(
+					"%s (%s/%s, %.0f of %.0f used)",
+					kind, quota.ServiceCode, quota.QuotaCode, usage.Used, usage.Limit,
+				))
+			}
+		}
+	}
+	m.condition = condition(exhausted)
+	m.Unlock()
+	return m.Conditions()
+}
+
+// Conditions returns a copy of the condition of the last check, if any. It is
+// nil-safe, so that the absence of quota monitoring adds no conditions.
+func (m *Monitor) Conditions() []*ackv1alpha1.Condition {
+	if m == nil {
+		return nil
+	}
+	m.RLock()
+	defer m.RUnlock()
+	if m.condition == nil {
+		return nil
+	}
+	return []*ackv1alpha1.Condition{m.condition.DeepCopy()}
+}
+
+// condition returns the ACK.ServiceQuotasAvailable condition, False if some
+// quotas are nearly exhausted
+func condition(exhausted []string) *ackv1alpha1.Condition {
+	if len(exhausted) == 0 {
+		return &ackv1alpha1.Condition{
+			Type:   ackv1alpha1.ConditionTypeServiceQuotasAvailable,
+			Status: corev1.ConditionTrue,
+		}
+	}
+	msg := "service quotas nearly exhausted by " + strings.Join(exhausted, ", ")
+	return &ackv1alpha1.Condition{
+		Type:    ackv1alpha1.ConditionTypeServiceQuotasAvailable,
+		Status:  corev1.ConditionFalse,
+		Message: &msg,
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/quota"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+type fakeClient struct {
+	usages map[acktypes.AWSResourceQuota]quota.Usage
+	calls  int
+}
+
+func (c *fakeClient) Usage(
+	_ context.Context,
+	q acktypes.AWSResourceQuota,
+) (quota.Usage, error) {
+	c.calls++
+	usage, ok := c.usages[q]
+	if !ok {
+		return quota.Usage{}, errors.New("no usage metric")
+	}
+	return usage, nil
+}
+
+func TestMonitor(t *testing.T) {
+	require := require.New(t)
+
+	vpcs := acktypes.AWSResourceQuota{ServiceCode: "vpc", QuotaCode: "L-F678F1CE"}
+	eips := acktypes.AWSResourceQuota{ServiceCode: "ec2", QuotaCode: "L-0263D0A3"}
+	unknown := acktypes.AWSResourceQuota{ServiceCode: "ec2", QuotaCode: "L-UNKNOWN"}
+	client := &fakeClient{usages: map[acktypes.AWSResourceQuota]quota.Usage{
+		vpcs: {Limit: 5, Used: 2},
+		eips: {Limit: 5, Used: 2},
+	}}
+
+	var monitor *quota.Monitor
+	require.Nil(monitor.Conditions())
+
+	monitor = quota.NewMonitor(logr.Discard(), client, nil, 0.8, time.Minute)
+	require.Nil(monitor.Conditions())
+	monitor.Track("VPC", vpcs)
+	monitor.Track("Subnet", vpcs, unknown)
+	monitor.Track("ElasticIPAddress", eips)
+
+	conditions := monitor.Check(context.TODO())
+	require.Len(conditions, 1)
+	require.Equal(ackv1alpha1.ConditionTypeServiceQuotasAvailable, conditions[0].Type)
+	require.Equal(corev1.ConditionTrue, conditions[0].Status)
+	// The quotas consumed by several kinds are read once per check
+	require.Equal(3, client.calls)
+
+	client.usages[eips] = quota.Usage{Limit: 5, Used: 4}
+	conditions = monitor.Check(context.TODO())
+	require.Equal(corev1.ConditionFalse, conditions[0].Status)
+	require.Equal(
+		"service quotas nearly exhausted by ElasticIPAddress (ec2/L-0263D0A3, 4 of 5 used)",
+		*conditions[0].Message,
+	)
+	require.Equal(conditions, monitor.Conditions())
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// withRateLimit returns a copy of the supplied configuration of the AWS
+// service clients waiting, before each call, for the token bucket of the
+// supplied account and region and of the service called, as set by the
+// --aws-api-rate-limit and --aws-api-rate-limit-burst flags. The resource
+// managers built by the resource manager factories from the configuration
+// hence share the token buckets.
+func (r *reconciler) withRateLimit(
+	cfg aws.Config,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) aws.Config {
+	if r.rateLimiter == nil {
+		return cfg
+	}
+	apiOptions := make([]func(*middleware.Stack) error, 0, len(cfg.APIOptions)+1)
+	apiOptions = append(apiOptions, cfg.APIOptions...)
+	cfg.APIOptions = append(apiOptions, r.rateLimiter.Middleware(string(acctID), string(region)))
+	return cfg
+}
@@ -0,0 +1,113 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package ratelimit rate limits, on the client side, the calls of a
+// controller to the AWS service APIs, per AWS account, region and service, so
+// that a single noisy controller does not exhaust the API quotas of an
+// account shared with other workloads.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// middlewareID is the ID of the middleware waiting for the rate limiter
+// before each attempt of the calls to the AWS service APIs
+const middlewareID = "ACKRateLimit"
+
+// Key identifies the token bucket of the calls to the API of an AWS service
+// in an AWS account and region
+type Key struct {
+	Account string
+	Region  string
+	Service string
+}
+
+// Limiter holds a token bucket per AWS account, region and service, refilled
+// at the same rate. A nil Limiter does not limit anything.
+type Limiter struct {
+	sync.Mutex
+	limit   rate.Limit
+	burst   int
+	buckets map[Key]*rate.Limiter
+}
+
+// NewLimiter returns a Limiter allowing, per AWS account, region and service,
+// the supplied number of calls per second, with bursts of the supplied
+// number of calls. It returns nil if the rate is not positive. The burst is
+// at least 1.
+func NewLimiter(callsPerSecond float64, burst int) *Limiter {
+	if callsPerSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		limit:   rate.Limit(callsPerSecond),
+		burst:   burst,
+		buckets: map[Key]*rate.Limiter{},
+	}
+}
+
+// bucket returns the token bucket of the supplied key
+func (l *Limiter) bucket(key Key) *rate.Limiter {
+	l.Lock()
+	defer l.Unlock()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(l.limit, l.burst)
+		l.buckets[key] = bucket
+	}
+	return bucket
+}
+
+// Wait blocks until the token bucket of the supplied key allows a call, or
+// the supplied context is done, in which case it returns an error
+func (l *Limiter) Wait(ctx context.Context, key Key) error {
+	if l == nil {
+		return nil
+	}
+	return l.bucket(key).Wait(ctx)
+}
+
+// Middleware returns the API option waiting, before each attempt of the
+// calls to the AWS service APIs, retries included, for the token bucket of
+// the supplied account and region and of the service of the call
+func (l *Limiter) Middleware(account, region string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc(
+			middlewareID,
+			func(
+				ctx context.Context,
+				in middleware.FinalizeInput,
+				next middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				key := Key{
+					Account: account,
+					Region:  region,
+					Service: awsmiddleware.GetServiceID(ctx),
+				}
+				if err := l.Wait(ctx, key); err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+				return next.HandleFinalize(ctx, in)
+			},
+		), middleware.After)
+	}
+}
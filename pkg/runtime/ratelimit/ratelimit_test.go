@@ -0,0 +1,77 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/ratelimit"
+)
+
+func TestLimiter(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	key := ratelimit.Key{Account: "111111111111", Region: "us-west-2", Service: "S3"}
+
+	// A nil limiter, e.g. without a rate, does not limit anything
+	require.Nil(ratelimit.NewLimiter(0, 10))
+	var nilLimiter *ratelimit.Limiter
+	require.Nil(nilLimiter.Wait(ctx, key))
+
+	limiter := ratelimit.NewLimiter(0.001, 2)
+	require.Nil(limiter.Wait(ctx, key))
+	require.Nil(limiter.Wait(ctx, key))
+
+	// The burst is spent, hence the next call waits beyond the deadline
+	shortCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.NotNil(limiter.Wait(shortCtx, key))
+
+	// Other accounts, regions and services have their own token buckets
+	require.Nil(limiter.Wait(shortCtx, ratelimit.Key{Account: "222222222222", Region: "us-west-2", Service: "S3"}))
+	require.Nil(limiter.Wait(shortCtx, ratelimit.Key{Account: "111111111111", Region: "eu-west-1", Service: "S3"}))
+	require.Nil(limiter.Wait(shortCtx, ratelimit.Key{Account: "111111111111", Region: "us-west-2", Service: "SQS"}))
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	require := require.New(t)
+
+	limiter := ratelimit.NewLimiter(0.001, 1)
+	stack := middleware.NewStack("test", func() interface{} { return nil })
+	require.Nil(limiter.Middleware("111111111111", "us-west-2")(stack))
+
+	calls := 0
+	handler := middleware.DecorateHandler(
+		middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+			calls++
+			return nil, middleware.Metadata{}, nil
+		}),
+		stack,
+	)
+	_, _, err := handler.Handle(context.Background(), nil)
+	require.Nil(err)
+	require.Equal(1, calls)
+
+	// The burst is spent, hence the next call is not sent
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = handler.Handle(ctx, nil)
+	require.NotNil(err)
+	require.Equal(1, calls)
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"sync"
+
+	jq "github.com/itchyny/gojq"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// readinessQueries caches the parsed expressions of the readiness gates,
+// which are evaluated on every reconciliation of the resources
+var readinessQueries sync.Map
+
+// readinessQuery returns the parsed query of the supplied expression
+func readinessQuery(expression string) (*jq.Query, error) {
+	if query, ok := readinessQueries.Load(expression); ok {
+		return query.(*jq.Query), nil
+	}
+	query, err := jq.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	readinessQueries.Store(expression, query)
+	return query, nil
+}
+
+// EvaluateReadinessGates evaluates the supplied readiness gates on the
+// supplied resource. It returns true if the resource meets all of them, and
+// otherwise why it does not meet the first unmet gate.
+func EvaluateReadinessGates(
+	res acktypes.AWSResource,
+	gates []acktypes.ReadinessGate,
+) (bool, string) {
+	if len(gates) == 0 {
+		return true, ""
+	}
+	obj, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		return false, fmt.Sprintf("unable to evaluate the readiness gates: %v", err)
+	}
+	for _, gate := range gates {
+		query, err := readinessQuery(gate.Expression)
+		if err != nil {
+			return false, fmt.Sprintf("readiness gate %q has an invalid expression: %v", gate.Name, err)
+		}
+		result, ok := query.Run(obj).Next()
+		if err, isErr := result.(error); ok && isErr {
+			return false, fmt.Sprintf("unable to evaluate readiness gate %q: %v", gate.Name, err)
+		}
+		if met, _ := result.(bool); !ok || !met {
+			return false, fmt.Sprintf("readiness gate %q is not met: %s", gate.Name, gate.Expression)
+		}
+	}
+	return true, ""
+}
+
+// readinessGates returns the readiness gates the supplied resource manager
+// declares for the supplied resource, if any
+func readinessGates(
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+) []acktypes.ReadinessGate {
+	gater, ok := rm.(acktypes.AWSResourceReadinessGater)
+	if !ok {
+		return nil
+	}
+	return gater.ReadinessGates(res)
+}
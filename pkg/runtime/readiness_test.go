@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+func resourceWithStatus(status map[string]interface{}) *ackmocks.AWSResource {
+	res := &ackmocks.AWSResource{}
+	res.On("RuntimeObject").Return(&k8sunstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "cluster"},
+		"status":   status,
+	}})
+	return res
+}
+
+func TestEvaluateReadinessGates(t *testing.T) {
+	require := require.New(t)
+
+	gates := []acktypes.ReadinessGate{
+		{Name: "available", Expression: `.status.status == "AVAILABLE"`},
+		{Name: "endpoints", Expression: `.status.endpoints | length > 0`},
+	}
+
+	ready, reason := runtime.EvaluateReadinessGates(resourceWithStatus(map[string]interface{}{
+		"status": "AVAILABLE", "endpoints": []interface{}{"cluster.example.com"},
+	}), gates)
+	require.True(ready)
+	require.Empty(reason)
+
+	ready, reason = runtime.EvaluateReadinessGates(resourceWithStatus(map[string]interface{}{
+		"status": "CREATING",
+	}), gates)
+	require.False(ready)
+	require.Equal(`readiness gate "available" is not met: .status.status == "AVAILABLE"`, reason)
+
+	ready, reason = runtime.EvaluateReadinessGates(resourceWithStatus(map[string]interface{}{
+		"status": "AVAILABLE",
+	}), gates)
+	require.False(ready)
+	require.Contains(reason, `readiness gate "endpoints" is not met`)
+
+	ready, reason = runtime.EvaluateReadinessGates(resourceWithStatus(map[string]interface{}{}), []acktypes.ReadinessGate{
+		{Name: "invalid", Expression: `.status.status ==`},
+	})
+	require.False(ready)
+	require.Contains(reason, `readiness gate "invalid" has an invalid expression`)
+
+	ready, _ = runtime.EvaluateReadinessGates(resourceWithStatus(map[string]interface{}{}), nil)
+	require.True(ready)
+}
@@ -0,0 +1,129 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// reconcileHooks returns the reconcile hooks registered on the service
+// controller of the reconciler
+func (r *resourceReconciler) reconcileHooks() []acktypes.ReconcileHook {
+	if c, ok := r.sc.(*serviceController); ok {
+		return c.getReconcileHooks()
+	}
+	return nil
+}
+
+// preCreate calls the PreCreate method of the reconcile hooks, in order, and
+// returns the desired resource to create returned by the last one
+func (r *resourceReconciler) preCreate(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	for _, hook := range r.reconcileHooks() {
+		rlog.Enter("hook.PreCreate")
+		hooked, err := hook.PreCreate(ctx, desired)
+		rlog.Exit("hook.PreCreate", err)
+		if err != nil {
+			return desired, err
+		}
+		desired = hooked
+	}
+	return desired, nil
+}
+
+// postCreate calls the PostCreate method of the reconcile hooks, in reverse
+// order
+func (r *resourceReconciler) postCreate(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	err error,
+) {
+	hooks := r.reconcileHooks()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].PostCreate(ctx, desired, latest, err)
+	}
+}
+
+// preUpdate calls the PreUpdate method of the reconcile hooks, in order, and
+// returns the desired resource to update returned by the last one
+func (r *resourceReconciler) preUpdate(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	delta *ackcompare.Delta,
+) (acktypes.AWSResource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	for _, hook := range r.reconcileHooks() {
+		rlog.Enter("hook.PreUpdate")
+		hooked, err := hook.PreUpdate(ctx, desired, latest, delta)
+		rlog.Exit("hook.PreUpdate", err)
+		if err != nil {
+			return desired, err
+		}
+		desired = hooked
+	}
+	return desired, nil
+}
+
+// postUpdate calls the PostUpdate method of the reconcile hooks, in reverse
+// order
+func (r *resourceReconciler) postUpdate(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	err error,
+) {
+	hooks := r.reconcileHooks()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].PostUpdate(ctx, desired, latest, err)
+	}
+}
+
+// preDelete calls the PreDelete method of the reconcile hooks, in order
+func (r *resourceReconciler) preDelete(
+	ctx context.Context,
+	current acktypes.AWSResource,
+) error {
+	rlog := ackrtlog.FromContext(ctx)
+	for _, hook := range r.reconcileHooks() {
+		rlog.Enter("hook.PreDelete")
+		err := hook.PreDelete(ctx, current)
+		rlog.Exit("hook.PreDelete", err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postDelete calls the PostDelete method of the reconcile hooks, in reverse
+// order
+func (r *resourceReconciler) postDelete(
+	ctx context.Context,
+	current acktypes.AWSResource,
+	err error,
+) {
+	hooks := r.reconcileHooks()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].PostDelete(ctx, current, err)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	ctrlrtclientmock "github.com/aws-controllers-k8s/runtime/mocks/controller-runtime/pkg/client"
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// hookedReconciler returns a reconciler of the resources of the supplied
+// factory whose service controller has the supplied reconcile hooks
+func hookedReconciler(
+	rmf acktypes.AWSResourceManagerFactory,
+	hooks ...acktypes.ReconcileHook,
+) (acktypes.AWSResourceReconciler, *ctrlrtclientmock.Client, acktypes.ServiceControllerMetadata) {
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	sc := ackrt.NewServiceController(
+		"bookstore", "bookstore.services.k8s.aws", acktypes.VersionInfo{},
+	).(acktypes.ReconcileHookRegistrar).WithReconcileHooks(hooks...)
+	kc := &ctrlrtclientmock.Client{}
+	r := ackrt.NewReconcilerWithClient(
+		sc, kc, rmf, ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions)),
+		ackcfg.Config{}, ackmetrics.NewMetrics("bookstore"), ackrtcache.Caches{},
+	)
+	return r, kc, sc.GetMetadata()
+}
+
+func TestReconcilerCreate_ReconcileHooks(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	arn := ackv1alpha1.AWSResourceName("mybook-arn")
+
+	desired, _, _ := resourceMocks()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+	// The resource the hooks inject a tag in
+	injected, _, _ := resourceMocks()
+
+	ids := &ackmocks.AWSResourceIdentifiers{}
+	ids.On("ARN").Return(&arn)
+	latest, latestRTObj, _ := resourceMocks()
+	latest.On("Identifiers").Return(ids)
+	latest.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	latest.On("ReplaceConditions", mock.Anything).Return()
+
+	rm := &ackmocks.AWSResourceManager{}
+	rm.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+	rm.On("ClearResolvedReferences", desired).Return(desired)
+	rm.On("ClearResolvedReferences", latest).Return(latest)
+	rm.On("ReadOne", ctx, desired).Return(latest, ackerr.NotFound).Once()
+	rm.On("ReadOne", ctx, latest).Return(latest, nil)
+	rm.On("Create", ctx, injected).Return(latest, nil)
+	rm.On("IsSynced", ctx, latest).Return(true, nil)
+	rm.On("LateInitialize", ctx, latest).Return(latest, nil)
+
+	rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+	rd.On("IsManaged", desired).Return(true)
+	rd.On("Delta", desired, latest).Return(ackcompare.NewDelta())
+	rd.On("Delta", latest, latest).Return(ackcompare.NewDelta())
+
+	// The Pre methods are called in the order of the registration of the
+	// hooks, and the Post methods in the reverse order
+	calls := []string{}
+	first := &ackmocks.ReconcileHook{}
+	first.On("PreCreate", mock.Anything, desired).Return(injected, nil).Run(func(mock.Arguments) {
+		calls = append(calls, "first.PreCreate")
+	})
+	first.On("PostCreate", mock.Anything, injected, latest, nil).Return().Run(func(mock.Arguments) {
+		calls = append(calls, "first.PostCreate")
+	})
+	second := &ackmocks.ReconcileHook{}
+	second.On("PreCreate", mock.Anything, injected).Return(injected, nil).Run(func(mock.Arguments) {
+		calls = append(calls, "second.PreCreate")
+	})
+	second.On("PostCreate", mock.Anything, injected, latest, nil).Return().Run(func(mock.Arguments) {
+		calls = append(calls, "second.PostCreate")
+	})
+
+	r, kc, scmd := hookedReconciler(rmf, first, second)
+	rm.On("EnsureTags", ctx, desired, scmd).Return(nil)
+	kc.On("Patch", withoutCancelContextMatcher, latestRTObj, mock.AnythingOfType("*client.mergeFromPatch")).Return(nil)
+
+	_, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	rm.AssertCalled(t, "Create", ctx, injected)
+	require.Equal([]string{
+		"first.PreCreate", "second.PreCreate", "second.PostCreate", "first.PostCreate",
+	}, calls)
+}
+
+func TestReconcilerCreate_ReconcileHookRejects(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	desired, _, _ := resourceMocks()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+	desired.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	desired.On("ReplaceConditions", mock.Anything).Return()
+
+	rm := &ackmocks.AWSResourceManager{}
+	rm.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+	rm.On("ClearResolvedReferences", desired).Return(desired)
+	rm.On("ReadOne", ctx, desired).Return(nil, ackerr.NotFound)
+	rm.On("IsSynced", ctx, desired).Return(false, nil)
+
+	rmf, rd := managedResourceManagerFactoryMocks(desired, nil)
+	rd.On("IsManaged", desired).Return(true)
+
+	// The first hook rejecting the creation, the next ones are not called
+	policyErr := errors.New("bookstore policy forbids the creation")
+	policy := &ackmocks.ReconcileHook{}
+	policy.On("PreCreate", mock.Anything, desired).Return(nil, policyErr)
+	next := &ackmocks.ReconcileHook{}
+
+	r, _, scmd := hookedReconciler(rmf, policy, next)
+	rm.On("EnsureTags", ctx, desired, scmd).Return(nil)
+
+	_, err := r.Sync(ctx, rm, desired)
+	require.ErrorIs(err, policyErr)
+	rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	next.AssertNotCalled(t, "PreCreate", mock.Anything, mock.Anything)
+}
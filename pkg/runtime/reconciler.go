@@ -26,12 +26,16 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlrt "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlrtcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
@@ -39,16 +43,45 @@ import (
 	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
 	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
 	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
 	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
 	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/audit"
+	ackrtannotations "github.com/aws-controllers-k8s/runtime/pkg/runtime/annotations"
 	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/circuitbreaker"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/fieldcrypt"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/invariant"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/maintenance"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/managerbackoff"
+	ackrtoverlay "github.com/aws-controllers-k8s/runtime/pkg/runtime/overlay"
+	ackrtpatchbatch "github.com/aws-controllers-k8s/runtime/pkg/runtime/patchbatch"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/plan"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/policy"
+	ackrtprivilege "github.com/aws-controllers-k8s/runtime/pkg/runtime/privilege"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/ratelimit"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/redact"
+	ackrtreferents "github.com/aws-controllers-k8s/runtime/pkg/runtime/referents"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/regionhealth"
+	ackrtrequeuestorm "github.com/aws-controllers-k8s/runtime/pkg/runtime/requeuestorm"
+	ackrtresultwebhook "github.com/aws-controllers-k8s/runtime/pkg/runtime/resultwebhook"
+	ackrtresync "github.com/aws-controllers-k8s/runtime/pkg/runtime/resync"
+	ackrtscheduler "github.com/aws-controllers-k8s/runtime/pkg/runtime/scheduler"
+	ackrtslo "github.com/aws-controllers-k8s/runtime/pkg/runtime/slo"
+	ackrtstatedump "github.com/aws-controllers-k8s/runtime/pkg/runtime/statedump"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/teardown"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/tracing"
+	ackrttuning "github.com/aws-controllers-k8s/runtime/pkg/runtime/tuning"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 )
 
 const (
 	backoffReadOneTimeout = 10 * time.Second
+	// maxThrottleDelay caps the delay after which a throttled resource is
+	// requeued, whatever the delay AWS suggests
+	maxThrottleDelay = 15 * time.Minute
 	// The default duration to trigger the sync for an ACK resource after
 	// the successful reconciliation. This behavior for a resource can be
 	// overriden by RequeueOnSuccessSeconds configuration for that resource.
@@ -68,6 +101,32 @@ type reconciler struct {
 	cfg       ackcfg.Config
 	cache     ackrtcache.Caches
 	metrics   *ackmetrics.Metrics
+	// recorder emits Kubernetes events. It is nil until the reconciler is
+	// bound to a controller manager.
+	recorder *ackevents.Recorder
+	// eventFingerprints suppresses the repeated Warning events of the
+	// resources failing with the same error
+	eventFingerprints *eventFingerprints
+	// rateLimiter rate limits the calls to the AWS service APIs. It is nil
+	// until the reconciler is bound to a controller manager, or if the calls
+	// are not rate limited.
+	rateLimiter *ratelimit.Limiter
+	// circuitBreaker stops the calls to the AWS service APIs persistently
+	// failing. It is nil until the reconciler is bound to a controller
+	// manager, or if the circuit breakers are disabled.
+	circuitBreaker *circuitbreaker.Breaker
+	// tracer traces the reconciliations and the calls to the AWS service
+	// APIs. It is nil until the reconciler is bound to a controller
+	// manager, or if tracing is disabled.
+	tracer *tracing.Provider
+	// auditor writes the audit records of the changes of the AWS resources.
+	// It is nil until the reconciler is bound to a controller manager, or if
+	// the changes are not audited.
+	auditor *audit.Auditor
+	// deletions coordinates the deletions of the AWS resources of the
+	// service controllers. It is nil until the reconciler is bound to a
+	// controller manager.
+	deletions *teardown.Coordinator
 }
 
 // resourceReconciler is responsible for reconciling the state of a SINGLE KIND of
@@ -82,6 +141,78 @@ type resourceReconciler struct {
 	rmf          acktypes.AWSResourceManagerFactory
 	rd           acktypes.AWSResourceDescriptor
 	resyncPeriod time.Duration
+	// scheduler keeps the operations deferred for the resources of the kind
+	scheduler *ackrtscheduler.Scheduler
+	// deferred receives the resources whose deferred operations are due. It
+	// is nil until the reconciler is bound to a controller manager.
+	deferred chan event.GenericEvent
+	// overlay is the break-glass desired state overlay, nil if none is
+	// configured
+	overlay *ackrtoverlay.Overlay
+	// resyncBudget spreads the periodic drift checks of the resources, nil
+	// if the --resync-budget-per-minute flag is not set
+	resyncBudget *ackrtresync.Budget
+	// grantedActions are the IAM actions granted to the controller, nil if
+	// the --granted-iam-actions flag is not set
+	grantedActions *ackrtprivilege.Actions
+	// resultWebhook receives the reconciliation results of the resources,
+	// nil if the --reconcile-result-webhook flag has no entry for the kind
+	resultWebhook *ackrtresultwebhook.Webhook
+	// stateTracker tracks the work queue, the in-flight reconciliations and
+	// the backoffs of the reconciler, reported in state dumps. It is nil if
+	// neither the state dump signal nor the admin service are enabled.
+	stateTracker *ackrtstatedump.Tracker
+	// excludeSelectors match the resources the reconciler ignores, nil if
+	// no resources are excluded
+	excludeSelectors []labels.Selector
+	// tuning holds the settings of the reconciler tuned through the
+	// ControllerConfig, nil if the --controller-config flag is not set
+	tuning *ackrttuning.Settings
+	// slo tracks the service level indicators of the reconciler, nil if no
+	// service level objective is set
+	slo *ackrtslo.Tracker
+	// resourceStates counts the managed resources of the kind of the
+	// reconciler by state, nil if the reconciler has no metrics
+	resourceStates *ackmetrics.ResourceStateTracker
+	// encrypter decrypts the sensitive fields of the resources, nil if the
+	// --field-encryption-kms-key-id flag is not set
+	encrypter *fieldcrypt.Encrypter
+	// backoff counts the consecutive reconciliations of the resources that
+	// requested a requeue with backoff
+	backoff *backoffAttempts
+	// regionHealth tracks the health of the regional endpoints called by the
+	// reconciler, nil if the resources of its kind do not belong to a global
+	// AWS service
+	regionHealth *regionhealth.Tracker
+	// tagMutations holds the mutations of the tags of the resources that
+	// their reads may not reflect yet
+	tagMutations *tagMutations
+	// requeueStorms detects the resources reconciled again and again
+	// without their state changing, nil if the --requeue-storm-threshold
+	// flag is 0
+	requeueStorms *ackrtrequeuestorm.Detector
+	// managerFailures tracks the accounts, regions and IAM roles whose
+	// resource manager could not be constructed
+	managerFailures *managerbackoff.Tracker
+	// errorClassifications is the error classification file, nil if the
+	// --error-classification-file flag is not set
+	errorClassifications *errorClassificationFile
+	// updateHolds holds the updates of the resources for the debounce window
+	// of the kind, nil if the kind has no debounce window
+	updateHolds *updateHolds
+	// maintenanceWindow is the maintenance window of the resources whose
+	// namespace has none, nil if the --maintenance-window flag is not set
+	maintenanceWindow *maintenance.Window
+	// invariants is the invariants file of the --invariants-file flag, nil
+	// if the flag is not set
+	invariants *invariant.File
+	// policies is the policies file of the --policies-file flag, nil if the
+	// flag is not set
+	policies *policy.File
+	// repair triggers the reconciliations of the resources referenced by
+	// resources waiting on them. It is nil until the reconciler is bound to
+	// a controller manager.
+	repair *dependencyRepair
 }
 
 // GroupVersionKind returns the string containing the API group, version and
@@ -102,18 +233,58 @@ func (r *resourceReconciler) BindControllerManager(mgr ctrlrt.Manager) error {
 	}
 	r.kc = mgr.GetClient()
 	r.apiReader = mgr.GetAPIReader()
+	r.recorder = r.newEventRecorder(mgr)
+	r.rateLimiter = sharedBindingFor(mgr).rateLimiterFor(r.cfg)
+	r.circuitBreaker = sharedBindingFor(mgr).circuitBreakerFor(r.sc.GetMetadata().ServiceAlias, r.cfg, r.metrics)
+	r.tracer = sharedBindingFor(mgr).getTracer()
+	r.auditor = sharedBindingFor(mgr).getAuditor()
+	r.deletions = sharedBindingFor(mgr).deletionCoordinatorFor(r.cfg)
 	rd := r.rmf.ResourceDescriptor()
 	maxConcurrentReconciles := r.cfg.GetReconcileResourceMaxConcurrency(rd.GroupVersionKind().Kind)
-	return ctrlrt.NewControllerManagedBy(
+	if err := r.bindScheduler(mgr); err != nil {
+		return err
+	}
+	if err := r.bindResultWebhook(mgr); err != nil {
+		return err
+	}
+	builder := ctrlrt.NewControllerManagedBy(
 		mgr,
 	).For(
 		rd.EmptyRuntimeObject(),
-	).WithEventFilter(
-		predicate.GenerationChangedPredicate{},
+	).WatchesRawSource(
+		source.Channel(r.deferred, &handler.EnqueueRequestForObject{}),
+	).WatchesRawSource(
+		r.namespacePauseSource(mgr),
+	).WatchesRawSource(
+		r.bindDependencyRepair(mgr),
+	)
+	// Add the watch sources supplied by the service controller, e.g. to
+	// reconcile resources when the objects they depend on change.
+	if provider, ok := r.rmf.(acktypes.AWSResourceWatchSourceProvider); ok {
+		sources, err := provider.WatchSources(mgr)
+		if err != nil {
+			return fmt.Errorf("unable to get the watch sources of %s: %v", rd.GroupVersionKind().Kind, err)
+		}
+		for _, src := range sources {
+			builder = builder.WatchesRawSource(src)
+		}
+	}
+	opts := ctrlrtcontroller.Options{
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+	}
+	r.trackState(&opts)
+	if r.excludeSelectors != nil {
+		builder = builder.WithEventFilter(r.excludePredicate())
+	}
+	return builder.WithEventFilter(
+		predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			plan.Predicate(),
+			pausePredicate(),
+			ackrtannotations.ChangedPredicate(ackrtannotations.Watched...),
+		),
 	).WithOptions(
-		ctrlrtcontroller.Options{
-			MaxConcurrentReconciles: maxConcurrentReconciles,
-		},
+		opts,
 	).Complete(r)
 }
 
@@ -202,17 +373,69 @@ func (r *reconciler) WriteToSecret(
 	return nil
 }
 
+// referenceReader returns the client.Reader used to read referenced objects
+// while resolving references. When the reference cache is enabled, referents
+// are served from the cache instead of being read from the API server on
+// every reconciliation loop.
+func (r *reconciler) referenceReader() client.Reader {
+	if r.cache.References == nil {
+		return r.apiReader
+	}
+	return r.cache.References.Reader(r.apiReader)
+}
+
 // Reconcile implements `controller-runtime.Reconciler` and handles reconciling
 // a CR CRUD request
-func (r *resourceReconciler) Reconcile(ctx context.Context, req ctrlrt.Request) (ctrlrt.Result, error) {
+func (r *resourceReconciler) Reconcile(ctx context.Context, req ctrlrt.Request) (result ctrlrt.Result, err error) {
+	defer r.stateTracker.Begin(req.NamespacedName)()
+	ctx, span := r.startReconcileSpan(ctx, req)
+	defer func() { endSpan(span, err) }()
+	release, ok, err := r.acquireReconcile(ctx)
+	if err != nil {
+		return ctrlrt.Result{}, err
+	}
+	if !ok {
+		r.log.V(1).Info(
+			"reconciliation paused",
+			"kind", r.rd.GroupVersionKind().Kind,
+			"namespace", req.Namespace,
+			"name", req.Name,
+		)
+		return ctrlrt.Result{RequeueAfter: pausedRequeueAfter}, nil
+	}
+	defer release()
+	start := time.Now()
 	desired, err := r.getAWSResource(ctx, req)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// resource wasn't found. just ignore these.
+			r.scheduler.CancelAll(req.Namespace, req.Name)
+			r.backoff.reset(req.NamespacedName)
+			r.tagMutations.forget(req.NamespacedName)
+			r.forgetBackReferences(ctx, req.Namespace, req.Name)
+			r.deletions.Forget(r.objectRef(req.Namespace, req.Name))
+			r.resyncBudget.Forget(req.NamespacedName)
+			r.slo.Forget(req.NamespacedName)
+			r.resourceStates.Forget(req.NamespacedName.String())
+			r.requeueStorms.Forget(req.NamespacedName)
+			r.updateHolds.forget(req.NamespacedName)
 			return ctrlrt.Result{}, nil
 		}
 		return ctrlrt.Result{}, err
 	}
+	// The events of the excluded resources are filtered out, but the
+	// resources can still be enqueued by the other sources, e.g. when their
+	// deferred operations are due, or when their labels were just changed.
+	if r.excluded(desired.MetaObject().GetLabels()) {
+		r.log.V(1).Info(
+			"ignoring excluded resource",
+			"kind", r.rd.GroupVersionKind().Kind,
+			"namespace", req.Namespace,
+			"name", req.Name,
+		)
+		r.scheduler.CancelAll(req.Namespace, req.Name)
+		return ctrlrt.Result{}, nil
+	}
 
 	rlog := ackrtlog.NewResourceLogger(
 		r.log, desired,
@@ -226,6 +449,24 @@ func (r *resourceReconciler) Reconcile(ctx context.Context, req ctrlrt.Request)
 	// will be reflected in the context.
 	ctx = context.WithValue(ctx, ackrtlog.ContextKey, rlog)
 	ctx = context.WithValue(ctx, "resourceNamespace", req.Namespace)
+	if after, coolingDown := r.coolDownRequeueStorm(ctx, desired); coolingDown {
+		return ctrlrt.Result{RequeueAfter: after}, nil
+	}
+	if pausedBy := r.pausedBy(ctx, desired); pausedBy != "" {
+		return r.pauseResource(ctx, desired, pausedBy)
+	}
+	desired = r.resumeResource(ctx, desired)
+	if desired, err = r.decryptSensitiveFields(ctx, desired); err != nil {
+		return r.handleFieldDecryptionError(ctx, desired, err)
+	}
+	r.restoreDeferredOperations(ctx, desired)
+	desired = r.applyDesiredStateOverlay(ctx, desired)
+	if desired, err = r.applyNamespaceMutators(ctx, desired); err != nil {
+		return r.handleMutatorError(ctx, desired, err)
+	}
+	if after, deferred := r.deferDriftCheck(ctx, desired); deferred {
+		return ctrlrt.Result{RequeueAfter: after}, nil
+	}
 
 	// If a user has specified a namespace that is annotated with the
 	// an owner account ID, we need an appropriate role ARN to assume
@@ -236,12 +477,19 @@ func (r *resourceReconciler) Reconcile(ctx context.Context, req ctrlrt.Request)
 	// helpful message to the user.
 	acctID, needCARMLookup := r.getOwnerAccountID(desired)
 
-	var roleARN ackv1alpha1.AWSResourceName
-	if teamID := r.getTeamID(desired); teamID != "" && r.cfg.FeatureGates.IsEnabled(featuregate.TeamLevelCARM) {
+	// The IAM role of the owner role ARN annotation of the resource takes
+	// precedence over the account bindings of its namespace
+	roleARN, ownerAcctID, err := r.getOwnerRoleARN(desired)
+	if err != nil {
+		return r.handleOwnerRoleARNError(ctx, desired, err)
+	}
+	if roleARN != "" {
+		acctID = ownerAcctID
+	} else if teamID := r.getTeamID(desired); teamID != "" && r.cfg.FeatureGates.IsEnabled(featuregate.TeamLevelCARM) {
 		// The user is specifying a namespace that is annotated with a team ID.
 		// Requeue if the corresponding roleARN is not available in the Teams configmap.
 		// Additionally, set the account ID to the role's account ID.
-		roleARN, err = r.getRoleARN(string(teamID), ackrtcache.ACKRoleTeamMap)
+		roleARN, err = r.getBindingRoleARN(ctx, req.Namespace, string(teamID), ackrtcache.ACKRoleTeamMap)
 		if err != nil {
 			return r.handleCacheError(ctx, err, desired)
 		}
@@ -253,21 +501,57 @@ func (r *resourceReconciler) Reconcile(ctx context.Context, req ctrlrt.Request)
 	} else if needCARMLookup {
 		// The user is specifying a namespace that is annotated with an owner account ID.
 		// Requeue if the corresponding roleARN is not available in the Accounts configmap.
-		roleARN, err = r.getRoleARN(string(acctID), ackrtcache.ACKRoleAccountMap)
+		roleARN, err = r.getBindingRoleARN(ctx, req.Namespace, string(acctID), ackrtcache.ACKRoleAccountMap)
 		if err != nil {
 			return r.handleCacheError(ctx, err, desired)
 		}
 	}
 
-	region := r.getRegion(desired)
+	region, regionSource := r.resolveRegion(desired)
+	if region == "" {
+		return r.handleMissingRegion(ctx, desired)
+	}
+	// Never silently create the AWS resource again elsewhere once its region
+	// or owner account changed after creation
+	if move := r.detectRegionAccountMove(desired, acctID); move != nil {
+		if !move.authorized(desired) {
+			return r.handleRegionAccountMove(ctx, desired, move)
+		}
+		rlog.Info(
+			"migrating resource to another region or owner account",
+			"account", move.toAccount,
+			"region", move.toRegion,
+		)
+		r.recordEvent(
+			desired.RuntimeObject(), ackevents.ReasonRegionAccountMigrated,
+			fmt.Sprintf("Reconciling the resource in account %s and region %s", move.toAccount, move.toRegion),
+		)
+		region, regionSource = move.toRegion, move.toRegionSource
+	}
+	ctx = r.withCanaryCohort(ctx, desired)
 	endpointURL := r.getEndpointURL(desired)
 	gvk := r.rd.GroupVersionKind()
 	// The config pivot to the roleARN will happen if it is not empty.
 	// in the NewResourceManager
-	clientConfig, err := r.sc.NewAWSConfig(ctx, region, &endpointURL, roleARN, gvk)
+	// The resources of the global AWS services may be reconciled through the
+	// endpoints of another region, but keep the region they were created in
+	clientRegion := r.clientRegion(ctx, desired, region, endpointURL)
+	ctx = withResolvedRegion(ctx, region, regionSource, clientRegion)
+	clientConfig, err := r.sc.NewAWSConfig(ctx, clientRegion, &endpointURL, roleARN, gvk)
 	if err != nil {
 		return ctrlrt.Result{}, err
 	}
+	clientConfig = r.withRegionHealth(clientConfig, clientRegion)
+	ctx = r.withNamespaceRetry(ctx, desired.MetaObject().GetNamespace())
+	clientConfig = r.withRateLimit(clientConfig, acctID, clientRegion)
+	clientConfig = r.withCircuitBreaker(clientConfig, acctID, clientRegion)
+	clientConfig = r.withTracing(clientConfig, acctID, clientRegion)
+	clientConfig = r.withAudit(clientConfig)
+	ctx = r.withAuditIdentity(ctx, acctID, region, roleARN)
+	traceResource(ctx, desired, acctID, region)
+	if after, paused := r.pauseOpenCircuit(ctx, desired, acctID, clientRegion); paused {
+		return ctrlrt.Result{RequeueAfter: after}, nil
+	}
 
 	rlog.WithValues(
 		"account", acctID,
@@ -275,13 +559,35 @@ func (r *resourceReconciler) Reconcile(ctx context.Context, req ctrlrt.Request)
 		"region", region,
 	)
 
-	rm, err := r.rmf.ManagerFor(
-		r.cfg, clientConfig, r.log, r.metrics, r, acctID, region, roleARN,
-	)
+	rm, after, err := r.resourceManagerFor(ctx, desired, clientConfig, acctID, roleARN)
 	if err != nil {
-		return ctrlrt.Result{}, err
+		return r.handleManagerUnavailable(ctx, desired, err, after)
 	}
+	if r.planRequested(desired) {
+		return r.planResource(ctx, rm, desired)
+	}
+	previousConditions := copyConditions(desired.Conditions())
+	ctx = withPatchBatch(ctx)
 	latest, err := r.reconcile(ctx, rm, desired)
+	latest = r.handleAssumeRoleResult(ctx, desired, latest, roleARN, err)
+	if !ackcompare.IsNil(latest) {
+		condition.RemoveRegionAccountChanged(latest)
+	}
+	latest, err = r.handleRegionNotEnabled(ctx, desired, latest, region, acctID, err)
+	r.handleInfrastructureFault(ctx, latest, previousConditions, err)
+	err = r.handleClassifiedError(ctx, latest, err)
+	r.explainAccessDenied(ctx, desired, clientConfig, err)
+	if r.cfg.FingerprintErrors {
+		r.recordErrorFingerprint(ctx, rm, desired, latest, err)
+	}
+	err = withFlushError(err, r.flushPatchBatch(ctx))
+	if r.cfg.FingerprintErrors {
+		preserveConditionTransitions(previousConditions, latest)
+	}
+	r.checkProgressDeadline(ctx, previousConditions, latest)
+	r.sendReconcileResult(desired, latest, previousConditions, acctID, region, err)
+	r.fanOutToReferrers(ctx, latest, previousConditions)
+	r.observeSLO(desired, latest, start)
 	return r.HandleReconcileError(ctx, desired, latest, err)
 }
 
@@ -363,7 +669,7 @@ func (r *resourceReconciler) reconcile(
 			!(r.cfg.FeatureGates.IsEnabled(featuregate.ReadOnlyResources) && IsReadOnly(res)) {
 			// Resolve references before deleting the resource.
 			// Ignore any errors while resolving the references
-			resolved, _, _ := rm.ResolveReferences(ctx, r.apiReader, res)
+			resolved, _, _ := rm.ResolveReferences(ctx, r.referenceReader(), res)
 			return r.deleteResource(ctx, rm, resolved)
 		}
 
@@ -372,13 +678,20 @@ func (r *resourceReconciler) reconcile(
 		if err := r.setResourceUnmanaged(ctx, rm, res); err != nil {
 			return res, err
 		}
-		return r.handleRequeues(ctx, res)
+		return r.handleRequeues(ctx, rm, res)
+	}
+	// Replace the default finalizer of the resource with the custom one of
+	// this installation
+	if needsFinalizerMigration(r.rd, res) {
+		if err := r.setResourceManaged(ctx, rm, res); err != nil {
+			return res, err
+		}
 	}
 	latest, err := r.Sync(ctx, rm, res)
 	if err != nil {
 		return latest, err
 	}
-	return r.handleRequeues(ctx, latest)
+	return r.handleRequeues(ctx, rm, latest)
 }
 
 // Sync ensures that the supplied AWSResource's backing API resource
@@ -424,9 +737,22 @@ func (r *resourceReconciler) Sync(
 		rlog.WithValues("is_read_only", isReadOnly)
 	}
 
+	// If the ReferenceOwnerReferences or ReferenceIndex feature gates are
+	// enabled, record the resources read while resolving references so that
+	// we can add owner references to them, or index them.
+	reader := r.referenceReader()
+	var recorder *ackrtreferents.Recorder
+	manageOwnerReferences := !isReadOnly && r.cfg.FeatureGates.IsEnabled(featuregate.ReferenceOwnerReferences)
+	if manageOwnerReferences || r.cache.BackReferences != nil {
+		recorder = ackrtreferents.NewRecorder(reader, desired.MetaObject().GetNamespace())
+		reader = recorder
+	}
+
 	rlog.Enter("rm.ResolveReferences")
-	resolved, hasReferences, err := rm.ResolveReferences(ctx, r.apiReader, desired)
+	resolved, hasReferences, err := rm.ResolveReferences(ctx, reader, desired)
 	rlog.Exit("rm.ResolveReferences", err)
+	// Hasten the reconciliation of the referenced resources not synced yet
+	r.repairDependencies(ctx, desired, err)
 	// TODO (michaelhtm): should we fail here for `adopt-or-create` adoption policy?
 	if err != nil && !needAdoption && !isReadOnly {
 		return ackcondition.WithReferencesResolvedCondition(desired, err), err
@@ -434,6 +760,14 @@ func (r *resourceReconciler) Sync(
 	if hasReferences {
 		resolved = ackcondition.WithReferencesResolvedCondition(resolved, err)
 	}
+	if err == nil {
+		r.recordBackReferences(desired, recorder)
+	}
+	if hasReferences && err == nil && manageOwnerReferences {
+		if err = r.ensureOwnerReferences(ctx, rm, recorder, desired, resolved); err != nil {
+			return resolved, err
+		}
+	}
 
 	if needAdoption {
 		populated, err := r.handlePopulation(ctx, desired)
@@ -458,34 +792,47 @@ func (r *resourceReconciler) Sync(
 		}
 	}
 
+	if err = r.checkPermitted(ctx, rm, resolved, acktypes.AWSResourceOperationReadOne); err != nil {
+		return resolved, err
+	}
 	rlog.Enter("rm.ReadOne")
 	latest, err = rm.ReadOne(ctx, resolved)
 	rlog.Exit("rm.ReadOne", err)
 	if err != nil {
-		if err != ackerr.NotFound {
+		if !ackerr.IsNotFound(err) {
 			return latest, err
 		}
-		if adoptionPolicy == AdoptionPolicy_Adopt || isAdopted {
-			return nil, ackerr.AdoptedResourceNotFound
-		}
-		if isReadOnly {
-			return nil, ackerr.ReadOnlyResourceNotFound
+		if adoptionPolicy == AdoptionPolicy_Adopt || isAdopted || isReadOnly {
+			sentinel := ackerr.ReadOnlyResourceNotFound
+			if adoptionPolicy == AdoptionPolicy_Adopt || isAdopted {
+				sentinel = ackerr.AdoptedResourceNotFound
+			}
+			err = r.resourceNotFound(resolved, err, sentinel)
+			r.recordEvent(desired.RuntimeObject(), ackevents.ReasonResourceNotFound, err.Error())
+			return nil, err
 		}
 		if latest, err = r.createResource(ctx, rm, resolved); err != nil {
 			return latest, err
 		}
+		latest = r.recordRegion(ctx, latest)
+	} else if err = r.ensureClusterOwnership(ctx, rm, latest, isReadOnly); err != nil {
+		return latest, err
+	} else if latest, err = r.completeCreateIntent(ctx, rm, latest); err != nil {
+		return latest, err
 	} else if adoptionPolicy == AdoptionPolicy_Adopt {
 		rm.FilterSystemTags(latest)
 		if err = r.setResourceManaged(ctx, rm, latest); err != nil {
 			return latest, err
 		}
+		latest = r.fillCreateOnlyFields(ctx, rm, resolved, latest)
+		latest = r.recordRegion(ctx, latest)
 		r.rd.MarkAdopted(latest)
 		latest, err = r.patchResourceMetadataAndSpec(ctx, rm, desired, latest)
 		if err != nil {
 			return latest, err
 		}
 	} else if isReadOnly {
-		return latest, nil
+		return r.deriveStatus(ctx, rm, latest), nil
 	} else {
 		if adoptionPolicy == AdoptionPolicy_AdoptOrCreate {
 			// set adopt-or-create resource as managed before attempting
@@ -493,6 +840,8 @@ func (r *resourceReconciler) Sync(
 			if err = r.setResourceManaged(ctx, rm, latest); err != nil {
 				return latest, err
 			}
+			latest = r.fillCreateOnlyFields(ctx, rm, resolved, latest)
+			latest = r.recordRegion(ctx, latest)
 			r.rd.MarkAdopted(latest)
 		}
 		if latest, err = r.updateResource(ctx, rm, resolved, latest); err != nil {
@@ -504,7 +853,8 @@ func (r *resourceReconciler) Sync(
 	if latest, err = r.lateInitializeResource(ctx, rm, latest); err != nil {
 		return latest, err
 	}
-	return latest, nil
+	r.recordSpecHash(ctx, rm, desired, resolved)
+	return r.deriveStatus(ctx, rm, latest), nil
 }
 
 // resetConditions strips the supplied resource of all objects in its
@@ -547,20 +897,25 @@ func (r *resourceReconciler) ensureConditions(
 	}()
 
 	// If the ACK.ResourceSynced condition is not set using the custom hooks,
-	// determine the Synced condition using "rm.IsSynced" method
+	// determine the Synced condition using the readiness gates declared by
+	// the resource manager, and then the "rm.IsSynced" method
 	if ackcondition.Synced(res) == nil {
 		condStatus := corev1.ConditionFalse
 		synced := false
 		condMessage := ackcondition.NotSyncedMessage
 		var condReason string
-		rlog.Enter("rm.IsSynced")
-		if synced, err = rm.IsSynced(ctx, res); err == nil && synced {
-			condStatus = corev1.ConditionTrue
-			condMessage = ackcondition.SyncedMessage
-		} else if err != nil {
-			condReason = err.Error()
+		if ready, reason := EvaluateReadinessGates(res, readinessGates(rm, res)); !ready {
+			condReason = reason
+		} else {
+			rlog.Enter("rm.IsSynced")
+			if synced, err = rm.IsSynced(ctx, res); err == nil && synced {
+				condStatus = corev1.ConditionTrue
+				condMessage = ackcondition.SyncedMessage
+			} else if err != nil {
+				condReason = err.Error()
+			}
+			rlog.Exit("rm.IsSynced", err)
 		}
-		rlog.Exit("rm.IsSynced", err)
 
 		if reconcileErr != nil {
 			condReason = reconcileErr.Error()
@@ -610,16 +965,33 @@ func (r *resourceReconciler) createResource(
 
 	var latest acktypes.AWSResource // the newly created resource
 
+	// Skip the creation before marking the CR as managed if the controller
+	// knows it is not allowed to create the resource
+	if err = r.checkPermitted(ctx, rm, desired, acktypes.AWSResourceOperationCreate); err != nil {
+		return desired, err
+	}
+	if deferred, err := r.deferChange(ctx, desired, acktypes.AWSResourceOperationCreate); err != nil {
+		return deferred, err
+	}
+	if violated, err := r.checkInvariants(ctx, desired, desired); err != nil {
+		return violated, err
+	}
+	if denied, err := r.checkPolicies(ctx, desired, desired, acktypes.AWSResourceOperationCreate, nil); err != nil {
+		return denied, err
+	}
+
 	// Before we create the backend AWS service resources, let's first mark
 	// the CR as being managed by ACK. Internally, this means adding a
 	// finalizer to the CR; a finalizer that is removed once ACK no longer
 	// manages the resource OR if the backend AWS service resource is
-	// properly deleted.
-	if !r.rd.IsManaged(desired) {
-		if err = r.setResourceManaged(ctx, rm, desired); err != nil {
-			return nil, err
-		}
-
+	// properly deleted. If the finalizer policy of the CR is "after-create",
+	// the intent to create the resources is recorded instead, and the CR is
+	// marked as managed once they are created.
+	patched, err := r.recordCreateIntent(ctx, rm, desired)
+	if err != nil {
+		return nil, err
+	}
+	if patched {
 		// Ensure tags again after adding the finalizer and patching the
 		// resource. Patching desired resource omits the controller tags
 		// because they are not persisted in etcd. So we again ensure
@@ -632,16 +1004,30 @@ func (r *resourceReconciler) createResource(
 		}
 	}
 
+	// The reconcile hooks may change the desired state to create, e.g. add
+	// tags to it, without these changes being persisted in the CR
+	hooked, err := r.preCreate(ctx, desired)
+	if err != nil {
+		return desired, err
+	}
+	r.recordEvent(desired.RuntimeObject(), ackevents.ReasonCreateStarted, "Creating AWS resource")
+	ctx = r.beginAudit(ctx)
 	rlog.Enter("rm.Create")
-	latest, err = rm.Create(ctx, desired)
+	latest, err = rm.Create(ctx, hooked)
 	rlog.Exit("rm.Create", err)
+	r.audit(ctx, acktypes.AWSResourceOperationCreate, hooked, latest, nil, err)
+	r.postCreate(ctx, hooked, latest, err)
 	if err != nil {
 		// Here we're deciding to set a resource as unmanaged
 		// if the error is an AWS API Error. This will ensure
 		// that we're only managing (put finalizer) the resources
 		// that actually exist in AWS.
 		if _, ok := ackerr.AWSError(err); ok {
-			mErr := r.setResourceUnmanaged(ctx, rm, desired)
+			r.recordEvent(
+				desired.RuntimeObject(), ackevents.ReasonCreateFailed,
+				fmt.Sprintf("Unable to create AWS resource: %s", err),
+			)
+			mErr := r.abandonCreateIntent(ctx, rm, desired)
 			if mErr != nil {
 				return latest, err
 			}
@@ -653,7 +1039,7 @@ func (r *resourceReconciler) createResource(
 	observed, err := rm.ReadOne(ctx, latest)
 	rlog.Exit("rm.ReadOne", err)
 	if err != nil {
-		if err == ackerr.NotFound {
+		if ackerr.IsNotFound(err) {
 			// Some eventually-consistent APIs return a 404 from a
 			// ReadOne operation immediately after a successful
 			// Create operation. In these exceptional cases
@@ -673,6 +1059,14 @@ func (r *resourceReconciler) createResource(
 	// Take the status from the latest ReadOne
 	latest.SetStatus(observed)
 
+	// The creation is recorded along with the metadata below, and the CR is
+	// marked as managed if its finalizer policy deferred it until now
+	recordCreation := hasCreateIntent(desired)
+	if recordCreation {
+		clearCreateIntent(latest)
+		r.rd.MarkManaged(latest)
+	}
+
 	// Ensure that we are patching any changes to the annotations/metadata and
 	// the Spec that may have been set by the resource manager's successful
 	// Create call above.
@@ -680,7 +1074,13 @@ func (r *resourceReconciler) createResource(
 	if err != nil {
 		return latest, err
 	}
+	if recordCreation {
+		if err = r.flushPatchBatch(ctx); err != nil {
+			return latest, err
+		}
+	}
 	rlog.Info("created new resource")
+	r.recordEvent(latest.RuntimeObject(), ackevents.ReasonCreateSucceeded, "Created AWS resource")
 
 	return latest, nil
 }
@@ -713,7 +1113,7 @@ func (r *resourceReconciler) delayedReadOneAfterCreate(
 		rlog.Enter(fmt.Sprintf("rm.ReadOne (attempt %d)", attempts))
 		observed, err = rm.ReadOne(ctx, res)
 		rlog.Exit(fmt.Sprintf("rm.ReadOne (attempt %d)", attempts), err)
-		if err == nil || err != ackerr.NotFound {
+		if err == nil || !ackerr.IsNotFound(err) {
 			ticker.Stop()
 			break
 		}
@@ -724,6 +1124,31 @@ func (r *resourceReconciler) delayedReadOneAfterCreate(
 	return observed, nil
 }
 
+// resourceNotFound returns the supplied sentinel error, wrapped in an error
+// identifying the supplied resource whose AWS resource was not found. The
+// AWS identifier recorded by the resource manager in the supplied ReadOne
+// error, if any, is preferred to the ARN of the resource.
+func (r *resourceReconciler) resourceNotFound(
+	res acktypes.AWSResource,
+	readErr error,
+	sentinel error,
+) *ackerr.ResourceNotFoundError {
+	metaObj := res.MetaObject()
+	nfErr := ackerr.NewResourceNotFound(
+		r.rd.GroupVersionKind().GroupKind(),
+		types.NamespacedName{Namespace: metaObj.GetNamespace(), Name: metaObj.GetName()},
+		"",
+	)
+	nfErr.Err = sentinel
+	var readNFErr *ackerr.ResourceNotFoundError
+	if errors.As(readErr, &readNFErr) && readNFErr.Identifier != "" {
+		nfErr.Identifier = readNFErr.Identifier
+	} else if ids := res.Identifiers(); ids != nil && ids.ARN() != nil {
+		nfErr.Identifier = string(*ids.ARN())
+	}
+	return nfErr
+}
+
 // updateResource calls one or more AWS APIs to modify the backend AWS resource
 // and patches the CR's Metadata and Spec back to the Kubernetes API.
 //
@@ -746,6 +1171,9 @@ func (r *resourceReconciler) updateResource(
 	defer func() {
 		exit(err)
 	}()
+	// Read the resource again if its tags do not reflect their last mutation
+	// yet, rather than reporting them as drift
+	latest = r.awaitTagConsistency(ctx, rm, latest)
 	updated = latest
 
 	// Ensure the resource is managed
@@ -755,18 +1183,75 @@ func (r *resourceReconciler) updateResource(
 
 	// Check to see if the latest observed state already matches the
 	// desired state and if not, update the resource
-	delta := r.rd.Delta(desired, latest)
+	delta := r.resourceDelta(ctx, rm, desired, latest)
 	if delta.DifferentAt("Spec") {
+		// Changes made in quick succession are applied by a single update
+		if err = r.holdUpdate(ctx, desired, latest); err != nil {
+			return latest, err
+		}
 		rlog.Info(
 			"desired resource state has changed",
-			"diff", delta.Differences,
+			"diff", redact.Differences(r.rd.GroupVersionKind().GroupKind(), delta.Differences),
 		)
-		rlog.Enter("rm.Update")
-		updated, err = rm.Update(ctx, desired, latest, delta)
-		rlog.Exit("rm.Update", err, "latest", latest)
-		if err != nil {
-			return updated, err
+		changes := r.driftSummary(delta)
+		r.recordEvent(
+			desired.RuntimeObject(), ackevents.ReasonDriftDetected,
+			fmt.Sprintf("Desired state differs from the latest observed state: %s", strings.Join(changes, "; ")),
+		)
+		// Record the drift in the status of the resource written back at the
+		// end of the reconciliation, whether the update succeeds or not
+		defer func() {
+			updated = r.recordLastDrift(ctx, updated, changes)
+		}()
+		if err = r.checkPermitted(ctx, rm, latest, acktypes.AWSResourceOperationUpdate); err != nil {
+			return latest, err
+		}
+		if deferred, err := r.deferChange(ctx, latest, acktypes.AWSResourceOperationUpdate); err != nil {
+			return deferred, err
+		}
+		if violated, err := r.checkInvariants(ctx, desired, latest); err != nil {
+			return violated, err
+		}
+		if denied, err := r.checkPolicies(ctx, desired, latest, acktypes.AWSResourceOperationUpdate, delta); err != nil {
+			return denied, err
+		}
+		// The reconcile hooks may change the desired state to update, e.g.
+		// add tags to it, without these changes being persisted in the CR
+		var hooked acktypes.AWSResource
+		if hooked, err = r.preUpdate(ctx, desired, latest, delta); err != nil {
+			return latest, err
+		}
+		defer func() {
+			r.postUpdate(ctx, hooked, updated, err)
+		}()
+		ctx = r.beginAudit(ctx)
+		defer func() {
+			r.audit(ctx, acktypes.AWSResourceOperationUpdate, latest, updated, changes, err)
+		}()
+		if updater, ok := rm.(acktypes.AWSResourcePartialUpdater); ok {
+			// Only the groups of fields that changed are updated
+			updated, err = r.updateGroups(ctx, rm, updater, hooked, latest, delta)
+			if err != nil {
+				return updated, err
+			}
+		} else {
+			rlog.Enter("rm.Update")
+			updated, err = r.updateWithCompensation(ctx, rm, latest, func(ctx context.Context) (acktypes.AWSResource, error) {
+				return rm.Update(ctx, hooked, latest, delta)
+			})
+			rlog.Exit("rm.Update", err, "latest", r.redactedObject(latest))
+			if err != nil {
+				if _, ok := ackerr.AWSError(err); ok {
+					r.recordEvent(
+						desired.RuntimeObject(), ackevents.ReasonUpdateFailed,
+						fmt.Sprintf("Unable to update AWS resource: %s", err),
+					)
+				}
+				return updated, err
+			}
 		}
+		r.recordTagMutation(rm, desired, latest)
+		r.recordCanaryUpdate(ctx)
 		// Ensure that we are patching any changes to the annotations/metadata and
 		// the Spec that may have been set by the resource manager's successful
 		// Update call above.
@@ -778,10 +1263,69 @@ func (r *resourceReconciler) updateResource(
 			return updated, err
 		}
 		rlog.Info("updated resource")
+		r.recordEvent(updated.RuntimeObject(), ackevents.ReasonUpdateSucceeded, "Updated AWS resource")
+	} else {
+		// The changes reverted while the update was held need no update
+		r.updateHolds.forget(resourceKey(desired))
 	}
 	return updated, nil
 }
 
+// resourceDelta returns the differences between the supplied desired state
+// and latest observed state of a resource. Both states are normalized first
+// so that formatting differences are not reported as differences. The
+// unknowable create-only fields of adopted resources are excluded from the
+// comparison, and the differences between semantically equal values are
+// discarded.
+func (r *resourceReconciler) resourceDelta(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) *ackcompare.Delta {
+	unknowable := append(GetUnknowableFields(desired), GetUnknowableFields(latest)...)
+	delta := r.rd.Delta(
+		r.normalizeResource(ctx, rm, r.excludeUnknowableFields(ctx, desired, unknowable)),
+		r.normalizeResource(ctx, rm, r.excludeUnknowableFields(ctx, latest, unknowable)),
+	)
+	if comparator, ok := rm.(acktypes.AWSResourceComparator); ok {
+		delta = comparator.FieldComparators().Filter(delta)
+	}
+	return delta
+}
+
+// normalizeResource returns a copy of the supplied resource with the field
+// normalizers of the resource manager applied to it. If the resource manager
+// doesn't implement AWSResourceNormalizer, or does not return any normalizer,
+// the supplied resource is returned as is.
+func (r *resourceReconciler) normalizeResource(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+) acktypes.AWSResource {
+	normalizer, ok := rm.(acktypes.AWSResourceNormalizer)
+	if !ok || ackcompare.IsNil(res) {
+		return res
+	}
+	normalizers := normalizer.FieldNormalizers()
+	if len(normalizers) == 0 {
+		return res
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	obj, err := UnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		rlog.Debug("unable to normalize resource", "error", err)
+		return res
+	}
+	normalizers.Normalize(obj)
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		rlog.Debug("unable to normalize resource", "error", err)
+		return res
+	}
+	return r.rd.ResourceFromRuntimeObject(ro)
+}
+
 // lateInitializeResource calls AWSResourceManager.LateInitialize() method and
 // returns the AWSResource with late initialized fields.
 //
@@ -853,6 +1397,30 @@ func getPatchDocument(
 // patchResourceMetadataAndSpec patches the custom resource in the Kubernetes
 // API to match the supplied latest resource's metadata and spec.
 //
+// When the context holds a patch batch, the patch is deferred and coalesced
+// with the other patches of the reconciliation, see the patchbatch package.
+// The supplied latest resource is then returned as is: unlike the resource
+// returned by patchMetadataAndSpec, it does not hold the resourceVersion of
+// the patched resource. This is fine because the reconciler never patches
+// with the Optimistic Locking option, and the batch patches from its own copy
+// of the stored resource, which holds the resourceVersion of the last flushed
+// patch.
+func (r *resourceReconciler) patchResourceMetadataAndSpec(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	if batch := ackrtpatchbatch.FromContext(ctx); batch != nil {
+		batch.Add(rm, desired, latest)
+		return latest, nil
+	}
+	return r.patchMetadataAndSpec(ctx, rm, desired, latest)
+}
+
+// patchMetadataAndSpec patches the custom resource in the Kubernetes API to
+// match the supplied latest resource's metadata and spec.
+//
 // NOTE(redbackthomson): This method returns an updated version of the latest
 // parameter. This version has an updated metadata.resourceVersion, which is
 // incremented in the process of calling Patch. This is intentional, because
@@ -862,7 +1430,7 @@ func getPatchDocument(
 //
 // See https://github.com/kubernetes-sigs/controller-runtime/blob/165a8c869c4388b861c7c91cb1e5330f6e07ee16/pkg/client/patch.go#L81-L84
 // for more information.
-func (r *resourceReconciler) patchResourceMetadataAndSpec(
+func (r *resourceReconciler) patchMetadataAndSpec(
 	ctx context.Context,
 	rm acktypes.AWSResourceManager,
 	desired acktypes.AWSResource,
@@ -870,7 +1438,7 @@ func (r *resourceReconciler) patchResourceMetadataAndSpec(
 ) (acktypes.AWSResource, error) {
 	var err error
 	rlog := ackrtlog.FromContext(ctx)
-	exit := rlog.Trace("r.patchResourceMetadataAndSpec")
+	exit := rlog.Trace("r.patchMetadataAndSpec")
 	defer func() {
 		exit(err)
 	}()
@@ -905,7 +1473,12 @@ func (r *resourceReconciler) patchResourceMetadataAndSpec(
 	// behaviour; instead, we want to keep latest's original Status value.
 	latestCleaned.SetStatus(lorig)
 	rlog.Exit("kc.Patch (metadata + spec)", err)
-	return latestCleaned, err
+	if err != nil {
+		return latestCleaned, err
+	}
+	// The patched object holds the encrypted values of the sensitive fields
+	// stored in the custom resource
+	return r.decryptSensitiveFields(ctx, latestCleaned)
 }
 
 // patchResourceStatus patches the custom resource in the Kubernetes API to
@@ -933,6 +1506,7 @@ func (r *resourceReconciler) patchResourceStatus(
 	err = patchStatusWithoutCancel(ctx, r.kc, lobj, patch)
 
 	if err == nil {
+		r.observeResourceState(latest)
 		if rlog.IsDebugEnabled() {
 			js := getPatchDocument(patch, lobj)
 			rlog.Debug("patched resource status", "json", js)
@@ -966,19 +1540,51 @@ func (r *resourceReconciler) deleteResource(
 		exit(err)
 	}()
 
+	err = r.checkPermitted(
+		ctx, rm, current, acktypes.AWSResourceOperationReadOne, acktypes.AWSResourceOperationDelete,
+	)
+	if err != nil {
+		return current, err
+	}
 	rlog.Enter("rm.ReadOne")
 	observed, err := rm.ReadOne(ctx, current)
 	rlog.Exit("rm.ReadOne", err)
 	if err != nil {
-		if err == ackerr.NotFound {
+		if ackerr.IsNotFound(err) {
 			// If the aws resource is not found, remove finalizer
+			rlog.Info(
+				"AWS resource already deleted",
+				"reason", r.resourceNotFound(current, err, ackerr.NotFound).Error(),
+			)
+			r.endDelete(ctx, current, nil)
 			return current, r.setResourceUnmanaged(ctx, rm, current)
 		}
 		return current, err
 	}
+	if blocked, err := r.blockReferencedDelete(ctx, current); err != nil {
+		r.waitDelete(current)
+		return blocked, err
+	}
+	if deferred, err := r.deferChange(ctx, current, acktypes.AWSResourceOperationDelete); err != nil {
+		return deferred, err
+	}
+	if denied, err := r.checkPolicies(ctx, current, current, acktypes.AWSResourceOperationDelete, nil); err != nil {
+		return denied, err
+	}
+	if err = r.preDelete(ctx, current); err != nil {
+		return current, err
+	}
+	if err = r.beginDelete(ctx, current); err != nil {
+		return current, err
+	}
+	r.recordEvent(current.RuntimeObject(), ackevents.ReasonDeleteStarted, "Deleting AWS resource")
+	ctx = r.beginAudit(ctx)
 	rlog.Enter("rm.Delete")
 	latest, err := rm.Delete(ctx, observed)
 	rlog.Exit("rm.Delete", err)
+	r.audit(ctx, acktypes.AWSResourceOperationDelete, current, latest, nil, err)
+	r.postDelete(ctx, current, err)
+	r.endDelete(ctx, current, err)
 	if ackcompare.IsNotNil(latest) {
 		// The Delete operation may be asynchronous and the resource manager
 		// may have set a Spec field or metadata on the CR during `rm.Delete`,
@@ -993,6 +1599,12 @@ func (r *resourceReconciler) deleteResource(
 	if err != nil {
 		// NOTE: Delete() implementations that have asynchronously-completing
 		// deletions should return a RequeueNeededAfter.
+		if _, ok := ackerr.AWSError(err); ok {
+			r.recordEvent(
+				current.RuntimeObject(), ackevents.ReasonDeleteFailed,
+				fmt.Sprintf("Unable to delete AWS resource: %s", err),
+			)
+		}
 		return latest, err
 	}
 
@@ -1006,6 +1618,7 @@ func (r *resourceReconciler) deleteResource(
 	}
 	if err == nil {
 		rlog.Info("deleted resource")
+		r.recordEvent(current.RuntimeObject(), ackevents.ReasonDeleteSucceeded, "Deleted AWS resource")
 	}
 
 	return latest, err
@@ -1019,7 +1632,7 @@ func (r *resourceReconciler) setResourceManaged(
 	rm acktypes.AWSResourceManager,
 	res acktypes.AWSResource,
 ) error {
-	if r.rd.IsManaged(res) {
+	if r.rd.IsManaged(res) && !needsFinalizerMigration(r.rd, res) {
 		return nil
 	}
 	var err error
@@ -1035,6 +1648,10 @@ func (r *resourceReconciler) setResourceManaged(
 	if err != nil {
 		return err
 	}
+	// The finalizer must be persisted before the AWS resource is created
+	if err = r.flushPatchBatch(ctx); err != nil {
+		return err
+	}
 	rlog.Debug("marked resource as managed")
 	return nil
 }
@@ -1111,33 +1728,64 @@ func (r *resourceReconciler) getAWSResource(
 // handleRequeues examines the supplied latest observed resource state and
 // triggers a requeue for reconciling the resource when certain events occur
 // (or when nothing occurs and the resource manager for that kind of resource
-// indicates the resource should be repeatedly reconciled)
+// indicates the resource should be repeatedly reconciled). If the resource
+// manager returns a sync hint for the resource, the duration of the hint is
+// used instead of the default requeue durations.
 func (r *resourceReconciler) handleRequeues(
 	ctx context.Context,
+	rm acktypes.AWSResourceManager,
 	latest acktypes.AWSResource,
 ) (acktypes.AWSResource, error) {
 	if ackcompare.IsNotNil(latest) {
 		rlog := ackrtlog.FromContext(ctx)
+		hint := r.getSyncHint(ctx, rm, latest)
 		for _, condition := range latest.Conditions() {
 			if condition.Type != ackv1alpha1.ConditionTypeResourceSynced {
 				continue
 			}
 			// The code below only executes for "ConditionTypeResourceSynced"
 			if condition.Status == corev1.ConditionTrue {
-				rlog.Debug("requeuing", "after", r.resyncPeriod)
-				return latest, requeue.NeededAfter(nil, r.resyncPeriod)
+				r.recordDriftCheck(latest)
+				after := hint.AfterOr(r.getReconcileInterval(ctx, latest))
+				rlog.Debug("requeuing", "after", after)
+				return latest, requeue.NeededAfter(nil, after)
 			} else {
 				rlog.Debug(
 					"requeueing resource after finding resource synced condition false",
 				)
 				return latest, requeue.NeededAfter(
-					ackerr.TemporaryOutOfSync, requeue.DefaultRequeueAfterDuration)
+					ackerr.TemporaryOutOfSync, hint.AfterOr(requeue.DefaultRequeueAfterDuration))
 			}
 		}
 	}
 	return latest, nil
 }
 
+// getSyncHint returns the sync hint of the resource manager for the supplied
+// resource, or nil if the resource manager doesn't implement
+// AWSResourceSyncHinter.
+func (r *resourceReconciler) getSyncHint(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	latest acktypes.AWSResource,
+) *requeue.SyncHint {
+	hinter, ok := rm.(acktypes.AWSResourceSyncHinter)
+	if !ok {
+		return nil
+	}
+	hint := hinter.SyncHint(ctx, latest)
+	if hint != nil {
+		rlog := ackrtlog.FromContext(ctx)
+		rlog.Debug(
+			"resource manager returned a sync hint",
+			"state", hint.State,
+			"after", hint.After,
+			"reason", hint.Reason,
+		)
+	}
+	return hint
+}
+
 // HandleReconcileError will handle errors from reconcile handlers, which
 // respects runtime errors.
 //
@@ -1149,6 +1797,9 @@ func (r *resourceReconciler) HandleReconcileError(
 	latest acktypes.AWSResource,
 	err error,
 ) (ctrlrt.Result, error) {
+	// The metadata and spec patch still pending in the patch batch of the
+	// reconciliation, if any, is sent before the status patch
+	err = withFlushError(err, r.flushPatchBatch(ctx))
 	if ackcompare.IsNotNil(latest) {
 		// The reconciliation loop may have returned an error, but if latest is
 		// not nil, there may be some changes available in the CR's Status
@@ -1165,10 +1816,46 @@ func (r *resourceReconciler) HandleReconcileError(
 		// there is a more robust way to handle failures in the patch operation
 		_ = r.patchResourceStatus(ctx, desired, latest)
 	}
+	rlog := ackrtlog.FromContext(ctx)
+	// Honor the delay AWS suggests to throttled requests rather than the
+	// generic requeue delays, and the backoff of the resource starts over
+	if after, ok := ackerr.ThrottleDelay(err); ok {
+		r.resetBackoff(desired)
+		if after > maxThrottleDelay {
+			after = maxThrottleDelay
+		}
+		rlog.Debug(
+			"requeue needed after throttling error",
+			"error", err,
+			"after", after,
+		)
+		return ctrlrt.Result{RequeueAfter: after}, nil
+	}
+	if after, ok := r.backoffDelay(desired, err); ok {
+		rlog.Debug(
+			"requeue needed with backoff after error",
+			"error", err,
+			"after", after,
+		)
+		if cause := reconcileError(latest, err); cause != nil {
+			r.recordEvent(
+				desired.RuntimeObject(), ackevents.ReasonRequeuedWithBackoff,
+				fmt.Sprintf("Reconciliation failed, retrying with backoff: %s", cause),
+			)
+		}
+		return ctrlrt.Result{RequeueAfter: after}, nil
+	}
+	if err == ackerr.Terminal && ackcompare.IsNotNil(desired) {
+		if cause := reconcileError(latest, err); cause != nil {
+			r.recordEvent(
+				desired.RuntimeObject(), ackevents.ReasonTerminal,
+				fmt.Sprintf("Reconciliation failed with a terminal error: %s", cause),
+			)
+		}
+	}
 	if err == nil || err == ackerr.Terminal {
 		return ctrlrt.Result{}, nil
 	}
-	rlog := ackrtlog.FromContext(ctx)
 
 	var requeueNeededAfter *requeue.RequeueNeededAfter
 	if errors.As(err, &requeueNeededAfter) {
@@ -1281,36 +1968,12 @@ func (r *resourceReconciler) getRoleARN(id string, cacheName string) (ackv1alpha
 // getRegion returns the region the resource exists in, or if the resource
 // has yet to be created, the region the resource *should* be created in.
 //
-// If the resource has not yet been created, we look for the AWS region
-// in the following order of precedence:
-//   - The resource's `services.k8s.aws/region` annotation, if present
-//   - The resource's Namespace's `services.k8s.aws/region` annotation, if present
-//   - The controller's `--aws-region` CLI flag
+// See resolveRegion for the order of precedence of the region sources.
 func (r *resourceReconciler) getRegion(
 	res acktypes.AWSResource,
 ) ackv1alpha1.AWSRegion {
-	// first try to get the region from the status.resourceMetadata
-	metadataRegion := res.Identifiers().Region()
-	if metadataRegion != nil {
-		return *metadataRegion
-	}
-
-	// look for region in CR metadata annotations
-	resAnnotations := res.MetaObject().GetAnnotations()
-	region, ok := resAnnotations[ackv1alpha1.AnnotationRegion]
-	if ok {
-		return ackv1alpha1.AWSRegion(region)
-	}
-
-	// look for default region in namespace metadata annotations
-	ns := res.MetaObject().GetNamespace()
-	defaultRegion, ok := r.cache.Namespaces.GetDefaultRegion(ns)
-	if ok {
-		return ackv1alpha1.AWSRegion(defaultRegion)
-	}
-
-	// use controller configuration region
-	return ackv1alpha1.AWSRegion(r.cfg.Region)
+	region, _ := r.resolveRegion(res)
+	return region
 }
 
 // getDeletionPolicy returns the resource's deletion policy based on the default
@@ -1342,6 +2005,31 @@ func (r *resourceReconciler) getDeletionPolicy(
 	return r.cfg.DeletionPolicy
 }
 
+// getReconcileInterval returns the interval at which the supplied synced
+// resource is reconciled again. We look for the reconcile interval annotation
+// of the resource first, then of its namespace. Otherwise we use the resync
+// period of the controller configuration.
+func (r *resourceReconciler) getReconcileInterval(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) time.Duration {
+	value, ok := res.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationReconcileInterval]
+	if !ok {
+		value, ok = r.cache.Namespaces.GetReconcileInterval(res.MetaObject().GetNamespace())
+	}
+	if ok {
+		interval, err := time.ParseDuration(value)
+		if err == nil && interval > 0 {
+			return interval
+		}
+		ackrtlog.FromContext(ctx).Info(
+			"ignoring invalid reconcile interval, expected a positive duration",
+			"value", value,
+		)
+	}
+	return r.tuning.ResyncPeriod(r.rd.GroupVersionKind().Kind, r.resyncPeriod)
+}
+
 // getEndpointURL returns the AWS account that owns the supplied resource.
 // We look for the namespace associated endpoint url, if that is set we use it.
 // Otherwise if none of these annotations are set we use the endpoint url specified
@@ -1365,12 +2053,12 @@ func (r *resourceReconciler) getEndpointURL(
 // state of custom resources is maintained.
 // It attempts to retrieve the duration from the following sources, in this order:
 //  1. A resource-specific reconciliation resync period specified in the reconciliation resync
-//     configuration map (--reconcile-default-resync-seconds).
+//     configuration map (--reconcile-resource-resync-seconds).
 //  2. A resource-specific requeue on success period specified by the resource manager factory.
 //     The resource manager factory is controller-specific, and thus this period is to specified
 //     by controller authors (using ack-generate).
 //  3. The default reconciliation resync period period specified in the controller binary flags.
-//     (--reconcile-resource-resync-seconds)
+//     (--reconcile-default-resync-seconds)
 //  4. The default resync period defined in the ACK runtime package. Defined in defaultResyncPeriod
 //     within the same file
 //
@@ -1387,7 +2075,7 @@ func getResyncPeriod(rmf acktypes.AWSResourceManagerFactory, cfg ackcfg.Config)
 	// resync period configuration.
 	resourceKind := rmf.ResourceDescriptor().GroupVersionKind().Kind
 	if duration, ok := drc[strings.ToLower(resourceKind)]; ok && duration > 0 {
-		return time.Duration(duration) * time.Second
+		return duration
 	}
 
 	// Second, try to use a resource-specific requeue on success period specified by the
@@ -1440,6 +2128,7 @@ func NewReconcilerWithClient(
 		"reconciler kind", rmf.ResourceDescriptor().GroupVersionKind().Kind,
 		"resync period seconds", resyncPeriod.Seconds(),
 	)
+	registerSensitiveFields(rmf, cfg)
 	return &resourceReconciler{
 		reconciler: reconciler{
 			sc:      sc,
@@ -1448,9 +2137,33 @@ func NewReconcilerWithClient(
 			cfg:     cfg,
 			metrics: metrics,
 			cache:   cache,
+
+			eventFingerprints: newEventFingerprints(cfg),
 		},
-		rmf:          rmf,
-		rd:           rmf.ResourceDescriptor(),
-		resyncPeriod: resyncPeriod,
+		rmf:             rmf,
+		rd:              WithFinalizer(rmf.ResourceDescriptor(), cfg.FinalizerName, cfg.MigrateDefaultFinalizer),
+		resyncPeriod:    resyncPeriod,
+		scheduler:       ackrtscheduler.New(),
+		overlay:         newDesiredStateOverlay(cfg),
+		resyncBudget:    newResyncBudget(cfg, resyncPeriod),
+		backoff:         newBackoffAttempts(),
+		regionHealth:    newRegionHealthTracker(rmf),
+		tagMutations:    newTagMutations(),
+		managerFailures: managerbackoff.New(),
+
+		errorClassifications: newErrorClassificationFile(cfg),
+
+		grantedActions: ackrtprivilege.New(cfg.GrantedIAMActions),
+		stateTracker:   newStateTracker(cfg),
+		slo:            newSLOTracker(cfg),
+		resourceStates: ackmetrics.NewResourceStateTracker(metrics, rmf.ResourceDescriptor().GroupVersionKind().Kind),
+		requeueStorms:  newRequeueStormDetector(cfg),
+
+		excludeSelectors: cfg.GetReconcileExcludeSelectors(rmf.ResourceDescriptor().GroupVersionKind().Kind),
+		updateHolds:      newUpdateHolds(cfg, rmf.ResourceDescriptor().GroupVersionKind().Kind),
+
+		maintenanceWindow: newMaintenanceWindow(cfg),
+		invariants:        newInvariantsFile(cfg),
+		policies:          newPoliciesFile(cfg),
 	}
 }
@@ -17,11 +17,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -43,6 +45,7 @@ import (
 	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
 	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
 	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	ackrtpatchbatch "github.com/aws-controllers-k8s/runtime/pkg/runtime/patchbatch"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 
 	k8srtschemamocks "github.com/aws-controllers-k8s/runtime/mocks/apimachinery/pkg/runtime/schema"
@@ -755,6 +758,259 @@ func TestReconcilerCreate_ManagedResource_CheckReferencesResolveOnce(t *testing.
 	rm.AssertCalled(t, "EnsureTags", ctx, desired, scmd)
 }
 
+func TestReconcilerCreate_FinalizerPolicyAfterCreate(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+	arn := ackv1alpha1.AWSResourceName("mybook-arn")
+
+	desired, desiredRTObj, desiredMetaObj := resourceMocks()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+	desiredMetaObj.SetAnnotations(map[string]string{
+		ackv1alpha1.AnnotationFinalizerPolicy: string(ackv1alpha1.FinalizerPolicyAfterCreate),
+	})
+
+	ids := &ackmocks.AWSResourceIdentifiers{}
+	ids.On("ARN").Return(&arn)
+
+	latest, latestRTObj, _ := resourceMocks()
+	latest.On("Identifiers").Return(ids)
+	latest.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	latest.On(
+		"ReplaceConditions",
+		mock.AnythingOfType("[]*v1alpha1.Condition"),
+	).Return()
+
+	rm := &ackmocks.AWSResourceManager{}
+	rm.On("ResolveReferences", ctx, nil, desired).Return(
+		desired, false, nil,
+	).Once()
+	rm.On("ClearResolvedReferences", desired).Return(desired)
+	rm.On("ClearResolvedReferences", latest).Return(latest)
+	rm.On("ReadOne", ctx, desired).Return(
+		latest, ackerr.NotFound,
+	).Once()
+	rm.On("ReadOne", ctx, latest).Return(
+		latest, nil,
+	)
+	rm.On("Create", ctx, desired).Return(
+		latest, nil,
+	)
+	rm.On("IsSynced", ctx, latest).Return(true, nil)
+	rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+
+	rm.On("LateInitialize", ctx, latest).Return(latest, nil)
+	rd.On("IsManaged", desired).Return(false)
+	rd.On("ResourceFromRuntimeObject", desiredRTObj).Return(desired)
+	rd.On("MarkManaged", latest)
+	rd.On("Delta", desired, desired).Return(ackcompare.NewDelta())
+	rd.On("Delta", desired, latest).Return(ackcompare.NewDelta())
+	rd.On("Delta", latest, latest).Return(ackcompare.NewDelta())
+
+	r, kc, scmd := reconcilerMocks(rmf)
+	rm.On("EnsureTags", ctx, desired, scmd).Return(nil)
+	kc.On("Patch", withoutCancelContextMatcher, latestRTObj, mock.AnythingOfType("*client.mergeFromPatch")).Return(nil)
+
+	_, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	rm.AssertCalled(t, "Create", ctx, desired)
+	// The intent to create the resource is recorded instead of the finalizer
+	require.Contains(desiredMetaObj.GetAnnotations(), ackv1alpha1.AnnotationCreateIntent)
+	rd.AssertNotCalled(t, "MarkManaged", desired)
+	// The finalizer is added along with the creation
+	rd.AssertCalled(t, "MarkManaged", latest)
+	kc.AssertCalled(t, "Patch", withoutCancelContextMatcher, latestRTObj, mock.AnythingOfType("*client.mergeFromPatch"))
+}
+
+func TestReconcilerSync_CompleteInterruptedCreate(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+	arn := ackv1alpha1.AWSResourceName("mybook-arn")
+
+	delta := ackcompare.NewDelta()
+
+	desired, _, _ := resourceMocks()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+
+	ids := &ackmocks.AWSResourceIdentifiers{}
+	ids.On("ARN").Return(&arn)
+
+	// The controller stopped after creating the AWS resource, before adding
+	// the finalizer and removing the intent
+	latest, latestRTObj, latestMetaObj := resourceMocks()
+	latestMetaObj.SetAnnotations(map[string]string{
+		ackv1alpha1.AnnotationCreateIntent: "2026-10-15T12:00:00Z",
+	})
+	latest.On("Identifiers").Return(ids)
+	latest.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	latest.On(
+		"ReplaceConditions",
+		mock.AnythingOfType("[]*v1alpha1.Condition"),
+	).Return()
+
+	rm := &ackmocks.AWSResourceManager{}
+	rm.On("ResolveReferences", ctx, nil, desired).Return(
+		desired, false, nil,
+	)
+	rm.On("ClearResolvedReferences", desired).Return(desired)
+	rm.On("ClearResolvedReferences", latest).Return(latest)
+	rm.On("ReadOne", ctx, desired).Return(
+		latest, nil,
+	)
+	rm.On("IsSynced", ctx, latest).Return(true, nil)
+
+	rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+	rd.On("IsManaged", desired).Return(true)
+	rd.On("MarkManaged", latest)
+	rd.On("Delta", desired, latest).Return(delta)
+
+	rm.On("LateInitialize", ctx, latest).Return(latest, nil)
+	rd.On("Delta", latest, latest).Return(delta)
+
+	r, kc, scmd := reconcilerMocks(rmf)
+	rm.On("EnsureTags", ctx, desired, scmd).Return(nil)
+	kc.On("Patch", withoutCancelContextMatcher, latestRTObj, mock.AnythingOfType("*client.mergeFromPatch")).Return(nil)
+
+	_, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	rm.AssertNotCalled(t, "Create", ctx, desired)
+	// The finalizer is added and the intent is removed
+	rd.AssertCalled(t, "MarkManaged", latest)
+	require.NotContains(latestMetaObj.GetAnnotations(), ackv1alpha1.AnnotationCreateIntent)
+}
+
+// batchedSyncMocks returns the mocks of an update making two metadata and
+// spec patches: the resource manager's Update annotates the resource, then
+// its LateInitialize annotates it again
+func batchedSyncMocks(ctx context.Context, patchErr error) (
+	acktypes.AWSResourceReconciler,
+	*ackmocks.AWSResourceManager,
+	*ackmocks.AWSResource,
+	*ctrlrtclientmock.Client,
+	*ctrlrtclientmock.SubResourceWriter,
+) {
+	arn := ackv1alpha1.AWSResourceName("mybook-arn")
+
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.A", "val1", "val2")
+
+	desired, _, _ := resourceMocks()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+
+	ids := &ackmocks.AWSResourceIdentifiers{}
+	ids.On("ARN").Return(&arn)
+
+	mockLatest := func(annotations map[string]string) (*ackmocks.AWSResource, *ctrlrtclientmock.Object) {
+		res, rtObj, metaObj := resourceMocks()
+		metaObj.SetAnnotations(annotations)
+		res.On("Identifiers").Return(ids)
+		res.On("Conditions").Return([]*ackv1alpha1.Condition{})
+		res.On(
+			"ReplaceConditions",
+			mock.AnythingOfType("[]*v1alpha1.Condition"),
+		).Return()
+		return res, rtObj
+	}
+	latest, _ := mockLatest(map[string]string{})
+	updated, _ := mockLatest(map[string]string{"updated": "true"})
+	lateInitialized, lateInitializedRTObj := mockLatest(map[string]string{
+		"updated":          "true",
+		"late-initialized": "true",
+	})
+
+	rm := &ackmocks.AWSResourceManager{}
+	rm.On("ResolveReferences", ctx, nil, desired).Return(
+		desired, false, nil,
+	)
+	for _, res := range []*ackmocks.AWSResource{desired, latest, updated, lateInitialized} {
+		rm.On("ClearResolvedReferences", res).Return(res)
+	}
+	rm.On("ReadOne", ctx, desired).Return(
+		latest, nil,
+	)
+	rm.On("FilterSystemTags", latest)
+	rm.On("Update", ctx, desired, latest, delta).Return(
+		updated, nil,
+	)
+	rm.On("LateInitialize", ctx, updated).Return(lateInitialized, nil)
+	rm.On("IsSynced", ctx, lateInitialized).Return(true, nil)
+
+	rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+	rd.On("IsManaged", desired).Return(true)
+	rd.On("IsManaged", lateInitialized).Return(true)
+	rd.On("Delta", desired, latest).Return(
+		delta,
+	).Once()
+	rd.On("Delta", mock.Anything, mock.Anything).Return(ackcompare.NewDelta())
+
+	r, kc, scmd := reconcilerMocks(rmf)
+	rm.On("EnsureTags", ctx, desired, scmd).Return(nil)
+	kc.On("Patch", withoutCancelContextMatcher, mock.Anything, mock.AnythingOfType("*client.mergeFromPatch")).Return(patchErr)
+	statusWriter := &ctrlrtclientmock.SubResourceWriter{}
+	kc.On("Status").Return(statusWriter)
+	statusWriter.On("Patch", withoutCancelContextMatcher, lateInitializedRTObj, mock.AnythingOfType("*client.mergeFromPatch")).Return(nil)
+	return r, rm, desired, kc, statusWriter
+}
+
+func TestReconcilerSync_PatchBatch(t *testing.T) {
+	require := require.New(t)
+
+	ctx := ackrtpatchbatch.NewContext(context.TODO())
+	r, rm, desired, kc, statusWriter := batchedSyncMocks(ctx, nil)
+
+	latest, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	// The patches of the reconciliation are deferred...
+	kc.AssertNotCalled(t, "Patch", withoutCancelContextMatcher, mock.Anything, mock.Anything)
+	require.True(ackrtpatchbatch.FromContext(ctx).Pending())
+
+	_, err = r.HandleReconcileError(ctx, desired, latest, err)
+	require.Nil(err)
+	// ...and sent as exactly one metadata and spec patch, before exactly one
+	// status patch
+	kc.AssertNumberOfCalls(t, "Patch", 1)
+	statusWriter.AssertNumberOfCalls(t, "Patch", 1)
+	require.False(ackrtpatchbatch.FromContext(ctx).Pending())
+}
+
+func TestReconcilerSync_WithoutPatchBatch(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+	r, rm, desired, kc, _ := batchedSyncMocks(ctx, nil)
+
+	_, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	// Each mutation is patched on its own
+	kc.AssertNumberOfCalls(t, "Patch", 2)
+}
+
+func TestReconcilerSync_PatchBatchFlushError(t *testing.T) {
+	require := require.New(t)
+
+	ctx := ackrtpatchbatch.NewContext(context.TODO())
+	patchErr := errors.New("the object has been modified")
+	r, rm, desired, kc, statusWriter := batchedSyncMocks(ctx, patchErr)
+
+	latest, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+
+	_, err = r.HandleReconcileError(ctx, desired, latest, err)
+	// The failed flush fails the reconciliation, the status is still patched
+	require.Equal(patchErr, err)
+	kc.AssertNumberOfCalls(t, "Patch", 1)
+	statusWriter.AssertNumberOfCalls(t, "Patch", 1)
+	// The failed patch is kept, and retried by the next flush
+	require.True(ackrtpatchbatch.FromContext(ctx).Pending())
+
+	// The failed flush takes precedence over a terminal error, which would
+	// not requeue the resource
+	_, err = r.HandleReconcileError(ctx, desired, latest, ackerr.Terminal)
+	require.Equal(patchErr, err)
+	kc.AssertNumberOfCalls(t, "Patch", 2)
+}
+
 func TestReconcilerUpdate(t *testing.T) {
 	require := require.New(t)
 
@@ -1384,6 +1640,78 @@ func TestReconcilerHandleReconcilerError_NoPatchStatus_NoLatest(t *testing.T) {
 	kc.AssertNotCalled(t, "Patch")
 }
 
+func TestReconcilerHandleReconcilerError_RequeueWithBackoff(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+
+	desired, _, _ := resourceMocks()
+	rmf, _ := managedResourceManagerFactoryMocks(desired, nil)
+	r, _, _ := reconcilerMocks(rmf)
+
+	backoff := requeue.Backoff{Base: time.Second, Max: 3 * time.Second}
+	notReady := requeue.NeededWithBackoff(errors.New("dependency not ready"), backoff)
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		result, err := r.HandleReconcileError(ctx, desired, nil, notReady)
+		require.Nil(err)
+		require.Equal(expected, result.RequeueAfter)
+	}
+
+	// Any other result resets the backoff of the resource
+	_, err := r.HandleReconcileError(ctx, desired, nil, nil)
+	require.Nil(err)
+	result, err := r.HandleReconcileError(ctx, desired, nil, fmt.Errorf("resolving: %w", notReady))
+	require.Nil(err)
+	require.Equal(time.Second, result.RequeueAfter)
+}
+
+func TestReconcilerHandleReconcilerError_ThrottleDelay(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+
+	desired, _, _ := resourceMocks()
+	rmf, _ := managedResourceManagerFactoryMocks(desired, nil)
+	r, _, _ := reconcilerMocks(rmf)
+
+	throttled := func(retryAfter string) error {
+		header := http.Header{}
+		header.Set("Retry-After", retryAfter)
+		return &smithy.OperationError{
+			ServiceID:     "Route 53",
+			OperationName: "ChangeResourceRecordSets",
+			Err: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 429, Header: header}},
+				Err:      &smithy.GenericAPIError{Code: "Throttling"},
+			},
+		}
+	}
+
+	backoff := requeue.Backoff{Base: time.Second, Max: time.Minute}
+	notReady := requeue.NeededWithBackoff(errors.New("dependency not ready"), backoff)
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second} {
+		result, err := r.HandleReconcileError(ctx, desired, nil, notReady)
+		require.Nil(err)
+		require.Equal(expected, result.RequeueAfter)
+	}
+
+	// The delay suggested by AWS wins over the backoff of the error
+	backoffErr := requeue.NeededWithBackoff(throttled("42"), requeue.DefaultBackoff)
+	result, err := r.HandleReconcileError(ctx, desired, nil, backoffErr)
+	require.Nil(err)
+	require.Equal(42*time.Second, result.RequeueAfter)
+
+	// The throttling resets the backoff of the resource
+	result, err = r.HandleReconcileError(ctx, desired, nil, notReady)
+	require.Nil(err)
+	require.Equal(time.Second, result.RequeueAfter)
+
+	// It is capped
+	result, err = r.HandleReconcileError(ctx, desired, nil, throttled("86400"))
+	require.Nil(err)
+	require.Equal(15*time.Minute, result.RequeueAfter)
+}
+
 func TestReconcilerUpdate_ErrorInLateInitialization(t *testing.T) {
 	require := require.New(t)
 	assert := assert.New(t)
@@ -0,0 +1,232 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package redact contains the registry of the sensitive fields of the
+// resources of each kind, declared by the service controllers or added with
+// the --resource-sensitive-fields flag, and redacts their values from
+// everything the controller outputs: logs, events, plans and support
+// bundles.
+//
+// The paths of the fields are dot-separated and matched case-insensitively,
+// so that both the paths of the JSON representation of the resources (e.g.
+// "spec.masterUserPassword") and of their Go representation (e.g.
+// "Spec.MasterUserPassword", the paths of the differences of a Delta) are
+// matched. The items of lists are matched by the path of the list.
+package redact
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+const (
+	// Redacted replaces the values of the sensitive fields
+	Redacted = "REDACTED"
+	// minMessageValueLength is the minimum length of the values of the
+	// sensitive fields replaced in messages, shorter values being too likely
+	// to match unrelated parts of the messages
+	minMessageValueLength = 4
+)
+
+var (
+	registryLock sync.RWMutex
+	// registry holds the lowercased paths of the sensitive fields of each
+	// kind
+	registry = map[schema.GroupKind]map[string]struct{}{}
+)
+
+// Register adds the fields with the supplied paths to the sensitive fields of
+// the supplied kind. Registering a field twice is a no-op.
+func Register(gk schema.GroupKind, paths ...string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	fields, ok := registry[gk]
+	if !ok {
+		fields = map[string]struct{}{}
+		registry[gk] = fields
+	}
+	for _, path := range paths {
+		if path = strings.ToLower(strings.TrimSpace(path)); path != "" {
+			fields[path] = struct{}{}
+		}
+	}
+}
+
+// Fields returns the sorted, lowercased, paths of the sensitive fields of the
+// supplied kind
+func Fields(gk schema.GroupKind) []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	paths := make([]string, 0, len(registry[gk]))
+	for path := range registry[gk] {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// IsSensitive returns true if the field with the supplied path of the
+// resources of the supplied kind is sensitive or belongs to a sensitive field
+func IsSensitive(gk schema.GroupKind, path string) bool {
+	path = strings.ToLower(path)
+	for _, field := range Fields(gk) {
+		if path == field || strings.HasPrefix(path, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSensitiveFields returns true if the field with the supplied path of the
+// resources of the supplied kind contains sensitive fields, e.g. the parent
+// struct of a sensitive field
+func HasSensitiveFields(gk schema.GroupKind, path string) bool {
+	prefix := strings.ToLower(path) + "."
+	for _, field := range Fields(gk) {
+		if strings.HasPrefix(field, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the supplied value of the field with the supplied path of a
+// resource of the supplied kind, redacted if the field is sensitive. If the
+// field contains sensitive fields, a copy of the value with their values
+// redacted is returned, in its JSON representation.
+func Value(gk schema.GroupKind, path string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if IsSensitive(gk, path) {
+		return Redacted
+	}
+	prefix := strings.ToLower(path) + "."
+	nested := [][]string{}
+	for _, field := range Fields(gk) {
+		if strings.HasPrefix(field, prefix) {
+			nested = append(nested, strings.Split(strings.TrimPrefix(field, prefix), "."))
+		}
+	}
+	if len(nested) == 0 {
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Redacted
+	}
+	var copied interface{}
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return Redacted
+	}
+	for _, parts := range nested {
+		redactPath(copied, parts)
+	}
+	return copied
+}
+
+// Object redacts, in place, the values of the sensitive fields of the
+// supplied unstructured resource of the supplied kind
+func Object(gk schema.GroupKind, obj map[string]interface{}) {
+	for _, field := range Fields(gk) {
+		redactPath(obj, strings.Split(field, "."))
+	}
+}
+
+// Differences returns a copy of the supplied differences between two
+// resources of the supplied kind, with the values of their sensitive fields
+// redacted
+func Differences(gk schema.GroupKind, diffs []*ackcompare.Difference) []*ackcompare.Difference {
+	redacted := make([]*ackcompare.Difference, 0, len(diffs))
+	for _, diff := range diffs {
+		path := diff.Path.String()
+		redacted = append(redacted, &ackcompare.Difference{
+			Path: diff.Path,
+			A:    Value(gk, path, diff.A),
+			B:    Value(gk, path, diff.B),
+		})
+	}
+	return redacted
+}
+
+// Message returns the supplied message, e.g. an error echoed by an AWS
+// service API, with the string values of the sensitive fields of
+// the supplied unstructured resource of the supplied kind replaced
+func Message(gk schema.GroupKind, obj map[string]interface{}, message string) string {
+	for _, field := range Fields(gk) {
+		for _, value := range stringValues(obj, strings.Split(field, ".")) {
+			message = strings.ReplaceAll(message, value, Redacted)
+		}
+	}
+	return message
+}
+
+// redactPath replaces the values of the field with the supplied path parts
+// in the supplied unstructured value, in place
+func redactPath(value interface{}, parts []string) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			redactPath(item, parts)
+		}
+	case map[string]interface{}:
+		for key, child := range v {
+			if !strings.EqualFold(key, parts[0]) {
+				continue
+			}
+			if len(parts) == 1 {
+				if child != nil {
+					v[key] = Redacted
+				}
+				continue
+			}
+			redactPath(child, parts[1:])
+		}
+	}
+}
+
+// stringValues returns the string values, of at least minMessageValueLength
+// characters, of the field with the supplied path parts in the supplied
+// unstructured value
+func stringValues(value interface{}, parts []string) []string {
+	values := []string{}
+	switch v := value.(type) {
+	case string:
+		if len(parts) == 0 && len(v) >= minMessageValueLength {
+			values = append(values, v)
+		}
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, stringValues(item, parts)...)
+		}
+	case map[string]interface{}:
+		if len(parts) == 0 {
+			for _, child := range v {
+				values = append(values, stringValues(child, parts)...)
+			}
+			break
+		}
+		for key, child := range v {
+			if strings.EqualFold(key, parts[0]) {
+				values = append(values, stringValues(child, parts[1:])...)
+			}
+		}
+	}
+	return values
+}
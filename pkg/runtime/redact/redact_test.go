@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package redact_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/redact"
+)
+
+var dbInstance = schema.GroupKind{Group: "rds.services.k8s.aws", Kind: "DBInstance"}
+
+func init() {
+	redact.Register(dbInstance, "spec.masterUserPassword", "spec.credentials.secretKey", "")
+}
+
+func TestRegister(t *testing.T) {
+	require := require.New(t)
+
+	redact.Register(dbInstance, "spec.masterUserPassword")
+	require.Equal([]string{"spec.credentials.secretkey", "spec.masteruserpassword"}, redact.Fields(dbInstance))
+	require.Empty(redact.Fields(schema.GroupKind{Kind: "DBInstance"}))
+
+	require.True(redact.IsSensitive(dbInstance, "Spec.MasterUserPassword"))
+	require.True(redact.IsSensitive(dbInstance, "spec.credentials.secretKey.value"))
+	require.False(redact.IsSensitive(dbInstance, "Spec.Credentials"))
+	require.False(redact.IsSensitive(dbInstance, "Spec.MasterUsername"))
+
+	require.True(redact.HasSensitiveFields(dbInstance, "Spec.Credentials"))
+	require.True(redact.HasSensitiveFields(dbInstance, "Spec"))
+	require.False(redact.HasSensitiveFields(dbInstance, "Spec.MasterUserPassword"))
+	require.False(redact.HasSensitiveFields(dbInstance, "Spec.Cred"))
+}
+
+func TestValue(t *testing.T) {
+	require := require.New(t)
+
+	password := "hunter22"
+	require.Equal(redact.Redacted, redact.Value(dbInstance, "Spec.MasterUserPassword", &password))
+	require.Equal("admin", redact.Value(dbInstance, "Spec.MasterUsername", "admin"))
+	require.Nil(redact.Value(dbInstance, "Spec.MasterUserPassword", nil))
+
+	credentials := []map[string]string{{"accessKey": "AKIA", "secretKey": "s3cr3t"}}
+	require.Equal(
+		[]interface{}{map[string]interface{}{"accessKey": "AKIA", "secretKey": redact.Redacted}},
+		redact.Value(dbInstance, "Spec.Credentials", credentials),
+	)
+
+	diffs := redact.Differences(dbInstance, []*ackcompare.Difference{{
+		Path: ackcompare.NewPath("Spec.MasterUserPassword"),
+		A:    "new-password",
+		B:    "old-password",
+	}})
+	require.Equal(redact.Redacted, diffs[0].A)
+	require.Equal(redact.Redacted, diffs[0].B)
+}
+
+func TestObjectAndMessage(t *testing.T) {
+	require := require.New(t)
+
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"masterUsername":     "admin",
+			"masterUserPassword": "hunter22",
+			"credentials": []interface{}{
+				map[string]interface{}{"secretKey": "s3cr3t-key"},
+				map[string]interface{}{"secretKey": "abc"},
+			},
+		},
+	}
+	require.Equal(
+		"invalid password REDACTED for admin, key REDACTED, abc",
+		redact.Message(dbInstance, obj, "invalid password hunter22 for admin, key s3cr3t-key, abc"),
+	)
+
+	redact.Object(dbInstance, obj)
+	spec := obj["spec"].(map[string]interface{})
+	require.Equal("admin", spec["masterUsername"])
+	require.Equal(redact.Redacted, spec["masterUserPassword"])
+	require.Equal(redact.Redacted, spec["credentials"].([]interface{})[0].(map[string]interface{})["secretKey"])
+}
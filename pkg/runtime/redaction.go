@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/plan"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/redact"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// registerSensitiveFields registers the sensitive fields of the resources of
+// the supplied resource manager factory: the ones it declares, if it
+// implements AWSResourceSensitiveFieldDescriber, and the ones of the
+// --resource-sensitive-fields flag for their kind
+func registerSensitiveFields(rmf acktypes.AWSResourceManagerFactory, cfg ackcfg.Config) {
+	gk := rmf.ResourceDescriptor().GroupVersionKind().GroupKind()
+	if describer, ok := rmf.(acktypes.AWSResourceSensitiveFieldDescriber); ok {
+		redact.Register(gk, describer.SensitiveFields()...)
+	}
+	redact.Register(gk, cfg.GetResourceSensitiveFields(gk.Kind)...)
+}
+
+// redactedObject returns the unstructured representation of the supplied
+// resource with the values of its sensitive fields redacted, for logging, or
+// the resource itself if its kind has no sensitive fields
+func (r *resourceReconciler) redactedObject(res acktypes.AWSResource) interface{} {
+	gk := r.rd.GroupVersionKind().GroupKind()
+	if ackcompare.IsNil(res) || len(redact.Fields(gk)) == 0 {
+		return res
+	}
+	obj, err := UnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		return redact.Redacted
+	}
+	redact.Object(gk, obj)
+	return obj
+}
+
+// redactPlan redacts, in place, the values of the sensitive fields of the
+// supplied resource from the supplied plan of its reconciliation
+func (r *resourceReconciler) redactPlan(res acktypes.AWSResource, p *plan.Plan) {
+	gk := r.rd.GroupVersionKind().GroupKind()
+	for i := range p.Changes {
+		p.Changes[i].Current = redact.Value(gk, p.Changes[i].Path, p.Changes[i].Current)
+		p.Changes[i].Desired = redact.Value(gk, p.Changes[i].Path, p.Changes[i].Desired)
+	}
+	if p.Error != "" {
+		p.Error = r.redactMessage(res.RuntimeObject(), p.Error)
+	}
+}
+
+// redactMessage returns the supplied message about the supplied object, e.g.
+// an event message echoing an AWS service API error, with the values of the
+// sensitive fields of the object replaced
+func (r *reconciler) redactMessage(obj runtime.Object, message string) string {
+	gk, ok := r.groupKindOf(obj)
+	if !ok || len(redact.Fields(gk)) == 0 {
+		return message
+	}
+	content, err := UnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return message
+	}
+	return redact.Message(gk, content, message)
+}
+
+// groupKindOf returns the group and kind of the supplied object, looked up in
+// the scheme of the client of the reconciler when the object does not carry
+// them
+func (r *reconciler) groupKindOf(obj runtime.Object) (schema.GroupKind, bool) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() && r.kc != nil {
+		var err error
+		if gvk, err = apiutil.GVKForObject(obj, r.kc.Scheme()); err != nil {
+			return schema.GroupKind{}, false
+		}
+	}
+	return gvk.GroupKind(), !gvk.Empty()
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package referents records the Kubernetes objects read while resolving the
+// references of an ACK resource, to find out which ACK resources it
+// references, e.g. to make them the owners of the resource.
+package referents
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// ackGroupSuffix is the suffix of the API group of every ACK resource
+	ackGroupSuffix = ".services.k8s.aws"
+)
+
+// Referent is an ACK resource read while resolving references
+type Referent struct {
+	GVK    schema.GroupVersionKind
+	Object client.Object
+}
+
+// Recorder is a client.Reader that records the objects read through it
+type Recorder struct {
+	client.Reader
+	namespace string
+	objects   []client.Object
+}
+
+// NewRecorder returns a new Recorder reading from the supplied reader, for a
+// resource in the supplied namespace
+func NewRecorder(reader client.Reader, namespace string) *Recorder {
+	return &Recorder{Reader: reader, namespace: namespace}
+}
+
+// Get retrieves an object from the underlying reader and records it
+func (r *Recorder) Get(
+	ctx context.Context,
+	key client.ObjectKey,
+	obj client.Object,
+	opts ...client.GetOption,
+) error {
+	if err := r.Reader.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	r.objects = append(r.objects, obj)
+	return nil
+}
+
+// Referents returns the recorded ACK resources. The objects of the other API
+// groups, e.g. Secrets or ConfigMaps, are left out.
+func (r *Recorder) Referents(scheme *k8sruntime.Scheme) []Referent {
+	referents := []Referent{}
+	for _, obj := range r.objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil || !strings.HasSuffix(gvk.Group, ackGroupSuffix) {
+			continue
+		}
+		referents = append(referents, Referent{GVK: gvk, Object: obj})
+	}
+	return referents
+}
+
+// OwnerReferences returns the owner references to the recorded ACK resources
+// of the recorder namespace, excluding the resource with the supplied UID
+// itself
+func (r *Recorder) OwnerReferences(
+	scheme *k8sruntime.Scheme,
+	uid k8stypes.UID,
+) []metav1.OwnerReference {
+	refs := []metav1.OwnerReference{}
+	for _, referent := range r.Referents(scheme) {
+		obj := referent.Object
+		if obj.GetUID() == "" || obj.GetUID() == uid {
+			continue
+		}
+		if obj.GetNamespace() != r.namespace {
+			continue
+		}
+		refs = append(refs, metav1.OwnerReference{
+			APIVersion: referent.GVK.GroupVersion().String(),
+			Kind:       referent.GVK.Kind,
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+		})
+	}
+	return refs
+}
+
+// MissingOwnerReferences returns the supplied owner references to the owners
+// that the existing owner references do not point to yet, without duplicates
+func MissingOwnerReferences(
+	existing []metav1.OwnerReference,
+	refs []metav1.OwnerReference,
+) []metav1.OwnerReference {
+	missing := []metav1.OwnerReference{}
+	for _, ref := range refs {
+		if !hasOwnerReference(existing, ref) && !hasOwnerReference(missing, ref) {
+			missing = append(missing, ref)
+		}
+	}
+	return missing
+}
+
+// hasOwnerReference returns true if the supplied owner references contain a
+// reference to the same owner as ref
+func hasOwnerReference(refs []metav1.OwnerReference, ref metav1.OwnerReference) bool {
+	for _, r := range refs {
+		if r.UID == ref.UID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package referents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/referents"
+)
+
+var (
+	bookGVK   = schema.GroupVersionKind{Group: "bookstore.services.k8s.aws", Version: "v1alpha1", Kind: "Book"}
+	secretGVK = schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+)
+
+// reader is a client.Reader serving the objects it holds
+type reader struct {
+	client.Reader
+	objects map[k8stypes.NamespacedName]*unstructured.Unstructured
+}
+
+func newReader(objects ...*unstructured.Unstructured) *reader {
+	r := &reader{objects: map[k8stypes.NamespacedName]*unstructured.Unstructured{}}
+	for _, obj := range objects {
+		r.objects[client.ObjectKeyFromObject(obj)] = obj
+	}
+	return r
+}
+
+func (r *reader) Get(
+	_ context.Context,
+	key client.ObjectKey,
+	obj client.Object,
+	_ ...client.GetOption,
+) error {
+	stored, ok := r.objects[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	stored.DeepCopyInto(obj.(*unstructured.Unstructured))
+	return nil
+}
+
+func newObject(gvk schema.GroupVersionKind, namespace, name, uid string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID(k8stypes.UID(uid))
+	return obj
+}
+
+// read reads the supplied objects through the supplied recorder
+func read(t *testing.T, recorder *referents.Recorder, objs ...*unstructured.Unstructured) {
+	for _, obj := range objs {
+		read := &unstructured.Unstructured{}
+		read.SetGroupVersionKind(obj.GroupVersionKind())
+		require.NoError(t, recorder.Get(context.TODO(), client.ObjectKeyFromObject(obj), read))
+	}
+}
+
+func TestRecorder_Referents(t *testing.T) {
+	require := require.New(t)
+
+	book := newObject(bookGVK, "default", "book", "book-uid")
+	secret := newObject(secretGVK, "default", "secret", "secret-uid")
+	recorder := referents.NewRecorder(newReader(book, secret), "default")
+
+	read(t, recorder, book, secret)
+	// The objects that could not be read are not recorded
+	missing := &unstructured.Unstructured{}
+	missing.SetGroupVersionKind(bookGVK)
+	err := recorder.Get(context.TODO(), k8stypes.NamespacedName{Namespace: "default", Name: "missing"}, missing)
+	require.True(apierrors.IsNotFound(err))
+
+	// Only the ACK resources are referents
+	got := recorder.Referents(k8sruntime.NewScheme())
+	require.Len(got, 1)
+	require.Equal(bookGVK, got[0].GVK)
+	require.Equal("book", got[0].Object.GetName())
+}
+
+func TestRecorder_OwnerReferences(t *testing.T) {
+	book := newObject(bookGVK, "default", "book", "book-uid")
+	self := newObject(bookGVK, "default", "self", "self-uid")
+	other := newObject(bookGVK, "other", "book", "other-uid")
+	unsaved := newObject(bookGVK, "default", "unsaved", "")
+	secret := newObject(secretGVK, "default", "secret", "secret-uid")
+	bookRef := metav1.OwnerReference{
+		APIVersion: "bookstore.services.k8s.aws/v1alpha1",
+		Kind:       "Book",
+		Name:       "book",
+		UID:        "book-uid",
+	}
+
+	for _, tc := range []struct {
+		name string
+		read []*unstructured.Unstructured
+		want []metav1.OwnerReference
+	}{
+		{
+			name: "ACK resource",
+			read: []*unstructured.Unstructured{book},
+			want: []metav1.OwnerReference{bookRef},
+		},
+		{
+			name: "other API group",
+			read: []*unstructured.Unstructured{secret},
+			want: []metav1.OwnerReference{},
+		},
+		{
+			name: "self reference",
+			read: []*unstructured.Unstructured{self},
+			want: []metav1.OwnerReference{},
+		},
+		{
+			name: "other namespace",
+			read: []*unstructured.Unstructured{other},
+			want: []metav1.OwnerReference{},
+		},
+		{
+			name: "without UID",
+			read: []*unstructured.Unstructured{unsaved},
+			want: []metav1.OwnerReference{},
+		},
+		{
+			name: "mixed",
+			read: []*unstructured.Unstructured{secret, self, book, other},
+			want: []metav1.OwnerReference{bookRef},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := referents.NewRecorder(newReader(book, self, other, unsaved, secret), "default")
+			read(t, recorder, tc.read...)
+			require.Equal(t, tc.want, recorder.OwnerReferences(k8sruntime.NewScheme(), "self-uid"))
+		})
+	}
+}
+
+func TestMissingOwnerReferences(t *testing.T) {
+	ref := func(name string) metav1.OwnerReference {
+		return metav1.OwnerReference{
+			APIVersion: "bookstore.services.k8s.aws/v1alpha1",
+			Kind:       "Book",
+			Name:       name,
+			UID:        k8stypes.UID(name + "-uid"),
+		}
+	}
+	controller := true
+	owned := ref("owned")
+	owned.Controller = &controller
+
+	for _, tc := range []struct {
+		name     string
+		existing []metav1.OwnerReference
+		refs     []metav1.OwnerReference
+		want     []metav1.OwnerReference
+	}{
+		{
+			name: "none",
+			want: []metav1.OwnerReference{},
+		},
+		{
+			name: "missing",
+			refs: []metav1.OwnerReference{ref("a"), ref("b")},
+			want: []metav1.OwnerReference{ref("a"), ref("b")},
+		},
+		{
+			name: "duplicates",
+			refs: []metav1.OwnerReference{ref("a"), ref("b"), ref("a")},
+			want: []metav1.OwnerReference{ref("a"), ref("b")},
+		},
+		{
+			// The existing references, even with other fields or to resources
+			// not referenced anymore, are left alone
+			name:     "existing",
+			existing: []metav1.OwnerReference{owned, ref("stale")},
+			refs:     []metav1.OwnerReference{ref("owned"), ref("a")},
+			want:     []metav1.OwnerReference{ref("a")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, referents.MissingOwnerReferences(tc.existing, tc.refs))
+		})
+	}
+}
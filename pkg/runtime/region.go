@@ -0,0 +1,206 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtregion "github.com/aws-controllers-k8s/runtime/pkg/runtime/region"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// resolvedRegionContextKey is the context key of the region resolved for the
+// resource being reconciled
+type resolvedRegionContextKey struct{}
+
+// resolvedRegion is the region resolved for the resource being reconciled,
+// along with where it was resolved from and the region whose endpoints it is
+// reconciled through
+type resolvedRegion struct {
+	region ackv1alpha1.AWSRegion
+	source ackv1alpha1.AWSRegionSource
+	client ackv1alpha1.AWSRegion
+}
+
+// withResolvedRegion returns a copy of the supplied context holding the
+// region resolved for the resource being reconciled, its source and the
+// region whose endpoints it is reconciled through
+func withResolvedRegion(
+	ctx context.Context,
+	region ackv1alpha1.AWSRegion,
+	source ackv1alpha1.AWSRegionSource,
+	client ackv1alpha1.AWSRegion,
+) context.Context {
+	return context.WithValue(ctx, resolvedRegionContextKey{}, resolvedRegion{region, source, client})
+}
+
+// resolvedRegionFromContext returns the region resolved for the resource
+// being reconciled, its source and the region whose endpoints it is
+// reconciled through, if any
+func resolvedRegionFromContext(
+	ctx context.Context,
+) (ackv1alpha1.AWSRegion, ackv1alpha1.AWSRegionSource, ackv1alpha1.AWSRegion) {
+	resolved, _ := ctx.Value(resolvedRegionContextKey{}).(resolvedRegion)
+	return resolved.region, resolved.source, resolved.client
+}
+
+// resolveRegion returns the region the resource exists in, or if the resource
+// has yet to be created, the region the resource *should* be created in,
+// along with where that region was resolved from.
+//
+// If the resource has not yet been created, we look for the AWS region
+// in the following order of precedence:
+//   - The resource's `services.k8s.aws/region` annotation, if present and not empty
+//   - The resource's Namespace's `services.k8s.aws/default-region` annotation, if present and not empty
+//   - The region of the AccountConfig binding the resource's Namespace, if any
+//   - The controller's default region, from its `--aws-region` CLI flag, its
+//     AWS_REGION or AWS_DEFAULT_REGION environment variables or the instance
+//     metadata service
+//
+// The source of a region read from the resource status is empty, since it was
+// recorded when the resource was created.
+func (r *resourceReconciler) resolveRegion(
+	res acktypes.AWSResource,
+) (ackv1alpha1.AWSRegion, ackv1alpha1.AWSRegionSource) {
+	// first try to get the region from the status.resourceMetadata
+	metadataRegion := res.Identifiers().Region()
+	if metadataRegion != nil {
+		return *metadataRegion, ""
+	}
+	return r.resolveUnboundRegion(res)
+}
+
+// resolveUnboundRegion returns the region a resource that has yet to be
+// created should be created in, along with where that region was resolved
+// from. See resolveRegion for the order of precedence of the region sources.
+func (r *resourceReconciler) resolveUnboundRegion(
+	res acktypes.AWSResource,
+) (ackv1alpha1.AWSRegion, ackv1alpha1.AWSRegionSource) {
+	return r.regionFor(res.MetaObject())
+}
+
+// regionFor returns the region of the supplied object, a resource or an
+// AdoptedResource, along with where that region was resolved from. See
+// resolveRegion for the order of precedence of the region sources.
+func (r *reconciler) regionFor(
+	obj metav1.Object,
+) (ackv1alpha1.AWSRegion, ackv1alpha1.AWSRegionSource) {
+	return ackrtregion.Resolve(obj, r.cache.Namespaces, ackv1alpha1.AWSRegion(r.cfg.Region), r.cfg.RegionSource)
+}
+
+// handleMissingRegion sets the ResourceSynced condition of a resource whose
+// region could not be resolved to an explanation of the region sources, and
+// requeues it.
+func (r *resourceReconciler) handleMissingRegion(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+) (ctrlrt.Result, error) {
+	err := fmt.Errorf(
+		"unable to determine the AWS region of the resource. The region is resolved from, in order: "+
+			"the %s annotation of the resource, the %s annotation of namespace %q, the default region "+
+			"of the AccountConfig binding the namespace and the default "+
+			"region of the controller (--aws-region flag, AWS_REGION and AWS_DEFAULT_REGION environment "+
+			"variables, instance metadata service), which are all missing or empty",
+		ackv1alpha1.AnnotationRegion, ackv1alpha1.AnnotationDefaultRegion, desired.MetaObject().GetNamespace(),
+	)
+	reason := err.Error()
+	latest := desired.DeepCopy()
+	condition.SetSynced(latest, corev1.ConditionFalse, &condition.UnavailableRegionMessage, &reason)
+	return r.HandleReconcileError(ctx, desired, latest, requeue.NeededAfter(err, roleARNNotAvailableRequeueDelay))
+}
+
+// handleRegionNotEnabled turns the errors returned by AWS service APIs called
+// in an opt-in region that is not enabled for the AWS account into a Terminal
+// condition explaining how to enable the region. Such errors do not go away
+// on retry, so the resource is only reconciled again at the resync period,
+// or once its spec changes.
+func (r *resourceReconciler) handleRegionNotEnabled(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	region ackv1alpha1.AWSRegion,
+	accountID ackv1alpha1.AWSAccountID,
+	err error,
+) (acktypes.AWSResource, error) {
+	if !ackerr.RegionNotEnabled(err) {
+		return latest, err
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	rlog.Info("region not enabled for the AWS account", "error", err)
+	if r.metrics != nil {
+		r.metrics.RecordRegionNotEnabled(string(region), string(accountID))
+	}
+	reason := fmt.Sprintf(
+		"region %s is not enabled for AWS account %s. Enable the region in the AWS account settings "+
+			"(https://docs.aws.amazon.com/accounts/latest/reference/manage-acct-regions.html), or set "+
+			"the %s annotation of the resource to an enabled region: %s",
+		region, accountID, ackv1alpha1.AnnotationRegion, err,
+	)
+	r.recordEvent(desired.RuntimeObject(), ackevents.ReasonRegionNotEnabled, reason)
+
+	if ackcompare.IsNil(latest) {
+		latest = desired.DeepCopy()
+	}
+	condition.SetTerminal(latest, corev1.ConditionTrue, &condition.RegionNotEnabledMessage, &reason)
+	condition.SetSynced(latest, corev1.ConditionFalse, &condition.NotSyncedMessage, &reason)
+	return latest, requeue.NeededAfter(ackerr.Terminal, r.resyncPeriod)
+}
+
+// recordRegion records, in the `status.ackResourceMetadata.region` and
+// `status.ackResourceMetadata.regionSource` fields of a newly created or
+// adopted resource, the region it was resolved to and where that region was
+// resolved from. The fields already set, e.g. by the resource manager, are
+// kept, except a region set to the failover region the resource was
+// reconciled through.
+func (r *resourceReconciler) recordRegion(
+	ctx context.Context,
+	latest acktypes.AWSResource,
+) acktypes.AWSResource {
+	region, source, clientRegion := resolvedRegionFromContext(ctx)
+	if source == "" || ackcompare.IsNil(latest) {
+		return latest
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	obj, err := UnstructuredConverter.ToUnstructured(latest.RuntimeObject())
+	if err != nil {
+		rlog.Debug("unable to record region", "error", err)
+		return latest
+	}
+	changed, err := ackrtregion.Record(obj, region, clientRegion, source)
+	if err != nil {
+		rlog.Debug("unable to record region", "error", err)
+		return latest
+	}
+	if !changed {
+		return latest
+	}
+	ro := r.rd.EmptyRuntimeObject()
+	if err = UnstructuredConverter.FromUnstructured(obj, ro); err != nil {
+		rlog.Debug("unable to record region", "error", err)
+		return latest
+	}
+	return r.rd.ResourceFromRuntimeObject(ro)
+}
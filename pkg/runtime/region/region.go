@@ -0,0 +1,108 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package region resolves the AWS regions of the resources that have yet to
+// be created, and records in their status the region they were resolved to
+// and where it was resolved from.
+package region
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// NamespaceDefaults returns the default regions of the namespaces, set with
+// their `services.k8s.aws/default-region` annotation or by the AccountConfig
+// they are bound to, along with where they were set, e.g. the namespace cache
+type NamespaceDefaults interface {
+	GetDefaultRegionWithSource(namespace string) (string, ackv1alpha1.AWSRegionSource, bool)
+}
+
+// Resolve returns the region of the supplied object, a resource or an
+// AdoptedResource, along with where that region was resolved from, in the
+// following order of precedence:
+//   - The object's `services.k8s.aws/region` annotation, if present and not empty
+//   - The default region of the object's namespace, from its annotation or
+//     from the AccountConfig binding it, if any
+//   - The supplied default region of the controller, from the supplied
+//     source, or from its `--aws-region` flag if no source is supplied
+func Resolve(
+	obj metav1.Object,
+	namespaces NamespaceDefaults,
+	defaultRegion ackv1alpha1.AWSRegion,
+	defaultSource ackv1alpha1.AWSRegionSource,
+) (ackv1alpha1.AWSRegion, ackv1alpha1.AWSRegionSource) {
+	// look for region in CR metadata annotations
+	region := obj.GetAnnotations()[ackv1alpha1.AnnotationRegion]
+	if region != "" {
+		return ackv1alpha1.AWSRegion(region), ackv1alpha1.AWSRegionSourceResourceAnnotation
+	}
+
+	// look for default region in namespace metadata annotations, or in the
+	// AccountConfig binding the namespace
+	if namespaces != nil {
+		if region, source, ok := namespaces.GetDefaultRegionWithSource(obj.GetNamespace()); ok {
+			return ackv1alpha1.AWSRegion(region), source
+		}
+	}
+
+	// use controller configuration region
+	if defaultSource == "" {
+		defaultSource = ackv1alpha1.AWSRegionSourceFlag
+	}
+	return defaultRegion, defaultSource
+}
+
+// Record records, in the `status.ackResourceMetadata.region` and
+// `status.ackResourceMetadata.regionSource` fields of the supplied resource,
+// as unstructured content, the supplied region and source. The fields already
+// set, e.g. by the resource manager, are kept, except a region set to the
+// supplied client region, the region whose endpoints the resource was
+// reconciled through, when it differs from the supplied region: the resource
+// managers of a global service set the region of their clients, which is a
+// failover region while the endpoints of the region of the resource fail. It
+// returns true if the resource changed, and false if the fields were already
+// set or the resource has no resource metadata in its status.
+func Record(
+	obj map[string]interface{},
+	region ackv1alpha1.AWSRegion,
+	clientRegion ackv1alpha1.AWSRegion,
+	source ackv1alpha1.AWSRegionSource,
+) (bool, error) {
+	metadata, found, _ := k8sunstructured.NestedMap(obj, "status", "ackResourceMetadata")
+	if !found {
+		return false, nil
+	}
+	changed := false
+	current, _ := metadata["region"].(string)
+	if current != "" && current == string(clientRegion) && clientRegion != region {
+		current = ""
+	}
+	if current == "" && region != "" {
+		metadata["region"] = string(region)
+		changed = true
+	}
+	if current, _ := metadata["regionSource"].(string); current == "" && source != "" {
+		metadata["regionSource"] = string(source)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	if err := k8sunstructured.SetNestedMap(obj, metadata, "status", "ackResourceMetadata"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,223 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package region_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/region"
+)
+
+// newCaches returns the running caches of the "annotated" namespace, whose
+// default region is us-east-2, and of the "bound" and "annotated" namespaces
+// bound by an AccountConfig whose region is eu-west-1
+func newCaches(t *testing.T) ackrtcache.Caches {
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	fakeLogger := ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions))
+
+	accountConfigRegion := ackv1alpha1.AWSRegion("eu-west-1")
+	ac := &ackv1alpha1.AccountConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ackv1alpha1.GroupVersion.String(), Kind: "AccountConfig"},
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec: ackv1alpha1.AccountConfigSpec{
+			AccountID:  "123456789012",
+			RoleARN:    "arn:aws:iam::123456789012:role/Payments",
+			Namespaces: []string{"bound", "annotated"},
+			Region:     &accountConfigRegion,
+		},
+	}
+	obj, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(ac)
+	require.NoError(t, err)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			ackv1alpha1.GroupVersion.WithResource("accountconfigs"): "AccountConfigList",
+		},
+		&unstructured.Unstructured{Object: obj},
+	)
+	k8sClient := k8sfake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "annotated",
+			Annotations: map[string]string{
+				ackv1alpha1.AnnotationDefaultRegion: "us-east-2",
+			},
+		},
+	})
+
+	caches := ackrtcache.New(fakeLogger, ackrtcache.Config{}, featuregate.FeatureGates{})
+	caches.Run(k8sClient)
+	caches.RunAccountConfigs(dynamicClient)
+	require.True(t, caches.WaitForCachesToSync(context.Background()))
+	return caches
+}
+
+func TestResolve(t *testing.T) {
+	caches := newCaches(t)
+
+	for _, tc := range []struct {
+		name          string
+		namespace     string
+		annotations   map[string]string
+		defaultSource ackv1alpha1.AWSRegionSource
+		wantRegion    ackv1alpha1.AWSRegion
+		wantSource    ackv1alpha1.AWSRegionSource
+	}{
+		{
+			name:        "resource annotation",
+			namespace:   "annotated",
+			annotations: map[string]string{ackv1alpha1.AnnotationRegion: "ap-south-1"},
+			wantRegion:  "ap-south-1",
+			wantSource:  ackv1alpha1.AWSRegionSourceResourceAnnotation,
+		},
+		{
+			name:        "empty resource annotation",
+			namespace:   "unbound",
+			annotations: map[string]string{ackv1alpha1.AnnotationRegion: ""},
+			wantRegion:  "us-west-2",
+			wantSource:  ackv1alpha1.AWSRegionSourceFlag,
+		},
+		{
+			// The annotation of the namespace takes precedence over its
+			// AccountConfig
+			name:       "namespace annotation",
+			namespace:  "annotated",
+			wantRegion: "us-east-2",
+			wantSource: ackv1alpha1.AWSRegionSourceNamespaceAnnotation,
+		},
+		{
+			name:       "account config",
+			namespace:  "bound",
+			wantRegion: "eu-west-1",
+			wantSource: ackv1alpha1.AWSRegionSourceAccountConfig,
+		},
+		{
+			name:       "controller flag",
+			namespace:  "unbound",
+			wantRegion: "us-west-2",
+			wantSource: ackv1alpha1.AWSRegionSourceFlag,
+		},
+		{
+			name:          "controller environment",
+			namespace:     "unbound",
+			defaultSource: ackv1alpha1.AWSRegionSourceEnvironment,
+			wantRegion:    "us-west-2",
+			wantSource:    ackv1alpha1.AWSRegionSourceEnvironment,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Namespace: tc.namespace, Annotations: tc.annotations}
+			gotRegion, gotSource := region.Resolve(obj, caches.Namespaces, "us-west-2", tc.defaultSource)
+			require.Equal(t, tc.wantRegion, gotRegion)
+			require.Equal(t, tc.wantSource, gotSource)
+		})
+	}
+}
+
+func TestResolve_WithoutNamespaces(t *testing.T) {
+	require := require.New(t)
+
+	obj := &metav1.ObjectMeta{Namespace: "annotated"}
+	gotRegion, gotSource := region.Resolve(obj, nil, "us-west-2", "")
+	require.Equal(ackv1alpha1.AWSRegion("us-west-2"), gotRegion)
+	require.Equal(ackv1alpha1.AWSRegionSourceFlag, gotSource)
+}
+
+func TestRecord(t *testing.T) {
+	withMetadata := func(metadata map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"status": map[string]interface{}{"ackResourceMetadata": metadata},
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		obj         map[string]interface{}
+		wantChanged bool
+		want        map[string]interface{}
+	}{
+		{
+			name:        "recorded",
+			obj:         withMetadata(map[string]interface{}{"ownerAccountID": "123456789012"}),
+			wantChanged: true,
+			want: withMetadata(map[string]interface{}{
+				"ownerAccountID": "123456789012",
+				"region":         "eu-west-1",
+				"regionSource":   "NamespaceAnnotation",
+			}),
+		},
+		{
+			// The region set by the resource manager is kept
+			name:        "region already set",
+			obj:         withMetadata(map[string]interface{}{"region": "us-east-1"}),
+			wantChanged: true,
+			want: withMetadata(map[string]interface{}{
+				"region":       "us-east-1",
+				"regionSource": "NamespaceAnnotation",
+			}),
+		},
+		{
+			// The failover region set by the resource manager is replaced
+			name:        "failover region set",
+			obj:         withMetadata(map[string]interface{}{"region": "us-west-2"}),
+			wantChanged: true,
+			want: withMetadata(map[string]interface{}{
+				"region":       "eu-west-1",
+				"regionSource": "NamespaceAnnotation",
+			}),
+		},
+		{
+			name: "already recorded",
+			obj: withMetadata(map[string]interface{}{
+				"region":       "us-east-1",
+				"regionSource": "ResourceAnnotation",
+			}),
+			wantChanged: false,
+			want: withMetadata(map[string]interface{}{
+				"region":       "us-east-1",
+				"regionSource": "ResourceAnnotation",
+			}),
+		},
+		{
+			name:        "without resource metadata",
+			obj:         map[string]interface{}{"status": map[string]interface{}{}},
+			wantChanged: false,
+			want:        map[string]interface{}{"status": map[string]interface{}{}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := region.Record(tc.obj, "eu-west-1", "us-west-2", ackv1alpha1.AWSRegionSourceNamespaceAnnotation)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantChanged, changed)
+			require.Equal(t, tc.want, tc.obj)
+		})
+	}
+}
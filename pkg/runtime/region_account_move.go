@@ -0,0 +1,136 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// errRegionAccountChanged is the error of the reconciliations of the
+// resources whose region or owner account changed after creation
+var errRegionAccountChanged = errors.New("region or owner account changed after creation")
+
+// regionAccountMove describes the move of a created resource to a region or
+// owner account other than the one it was created in
+type regionAccountMove struct {
+	fromAccount ackv1alpha1.AWSAccountID
+	fromRegion  ackv1alpha1.AWSRegion
+	toAccount   ackv1alpha1.AWSAccountID
+	toRegion    ackv1alpha1.AWSRegion
+	// toRegionSource is where the destination region was resolved from
+	toRegionSource ackv1alpha1.AWSRegionSource
+}
+
+// destination returns the value of the migrate-to annotation authorizing the
+// move
+func (m *regionAccountMove) destination() string {
+	return fmt.Sprintf("%s/%s", m.toAccount, m.toRegion)
+}
+
+// authorized returns true if the migrate-to annotation of the supplied
+// resource authorizes the move
+func (m *regionAccountMove) authorized(res acktypes.AWSResource) bool {
+	return res.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationMigrateTo] == m.destination()
+}
+
+// detectRegionAccountMove returns the move of the supplied created resource,
+// from the region and owner account recorded in its status, to the supplied
+// resolved owner account and to the region resolved from its annotations, the
+// ones of its namespace or the AccountConfig binding it. It returns nil if the resource was not created
+// yet, or did not move.
+//
+// The status region of a created resource takes precedence over its region
+// annotations, so the controller never reconciles a resource in another
+// region on its own. The owner account however comes from the account
+// binding of the namespace, which can change after creation.
+func (r *resourceReconciler) detectRegionAccountMove(
+	res acktypes.AWSResource,
+	acctID ackv1alpha1.AWSAccountID,
+) *regionAccountMove {
+	ids := res.Identifiers()
+	createdAccount, createdRegion := ids.OwnerAccountID(), ids.Region()
+	if createdAccount == nil && createdRegion == nil {
+		return nil
+	}
+	move := &regionAccountMove{
+		fromAccount: acctID,
+		toAccount:   acctID,
+	}
+	if createdAccount != nil && *createdAccount != "" {
+		move.fromAccount = *createdAccount
+	}
+	if createdRegion != nil {
+		move.fromRegion = *createdRegion
+		move.toRegion = *createdRegion
+		region, source := r.resolveUnboundRegion(res)
+		switch source {
+		case ackv1alpha1.AWSRegionSourceResourceAnnotation,
+			ackv1alpha1.AWSRegionSourceNamespaceAnnotation,
+			ackv1alpha1.AWSRegionSourceAccountConfig:
+			if region != "" {
+				move.toRegion = region
+				move.toRegionSource = source
+			}
+		}
+	}
+	if move.fromAccount == move.toAccount && move.fromRegion == move.toRegion {
+		return nil
+	}
+	return move
+}
+
+// handleRegionAccountMove keeps a resource whose region or owner account
+// changed after creation from being reconciled, and reports the move in its
+// RegionAccountChanged and ResourceSynced conditions
+func (r *resourceReconciler) handleRegionAccountMove(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	move *regionAccountMove,
+) (ctrlrt.Result, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	reason := fmt.Sprintf(
+		"the AWS resource was created in account %s and region %s, but the resource now resolves to "+
+			"account %s and region %s. The resource is not reconciled until the change is reverted, or "+
+			"the %s annotation of the resource is set to %q to create the AWS resource again in account "+
+			"%s and region %s",
+		move.fromAccount, move.fromRegion, move.toAccount, move.toRegion,
+		ackv1alpha1.AnnotationMigrateTo, move.destination(), move.toAccount, move.toRegion,
+	)
+	rlog.Info(
+		"region or owner account changed after creation",
+		"from_account", move.fromAccount,
+		"from_region", move.fromRegion,
+		"to_account", move.toAccount,
+		"to_region", move.toRegion,
+	)
+	r.recordEvent(desired.RuntimeObject(), ackevents.ReasonRegionAccountChanged, reason)
+	latest := desired.DeepCopy()
+	condition.SetRegionAccountChanged(latest, corev1.ConditionTrue, &condition.RegionAccountChangedMessage, &reason)
+	condition.SetSynced(latest, corev1.ConditionFalse, &condition.NotSyncedMessage, &reason)
+	return r.HandleReconcileError(
+		ctx, desired, latest, requeue.NeededAfter(errRegionAccountChanged, requeue.DefaultRequeueAfterDuration),
+	)
+}
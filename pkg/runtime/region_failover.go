@@ -0,0 +1,87 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/regionhealth"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// newRegionHealthTracker returns the tracker of the health of the regional
+// endpoints called by the reconciler of the kind of the supplied resource
+// manager factory, nil if the resources of the kind do not belong to a global
+// AWS service
+func newRegionHealthTracker(rmf acktypes.AWSResourceManagerFactory) *regionhealth.Tracker {
+	describer, ok := rmf.(acktypes.AWSGlobalServiceDescriber)
+	if !ok || len(describer.FailoverRegions()) == 0 {
+		return nil
+	}
+	return regionhealth.NewTracker()
+}
+
+// clientRegion returns the region whose endpoints are called to reconcile
+// the supplied resource of the supplied region. The resources of the global
+// AWS services are reconciled through the endpoints of a failover region
+// while the endpoints of their region fail, unless they are reconciled
+// through a custom endpoint. The resource managers are looked up for the
+// returned region, since they keep the clients they were constructed with.
+func (r *resourceReconciler) clientRegion(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	region ackv1alpha1.AWSRegion,
+	endpointURL string,
+) ackv1alpha1.AWSRegion {
+	if r.regionHealth == nil || endpointURL != "" {
+		return region
+	}
+	describer, ok := r.rmf.(acktypes.AWSGlobalServiceDescriber)
+	if !ok {
+		return region
+	}
+	chosen := ackv1alpha1.AWSRegion(r.regionHealth.Choose(string(region), describer.FailoverRegions()))
+	if chosen != region {
+		rlog := ackrtlog.FromContext(ctx)
+		rlog.Info("endpoints of the region are unhealthy, failing over", "region", region, "failover_region", chosen)
+		r.recordEvent(
+			res.RuntimeObject(), ackevents.ReasonRegionFailover,
+			fmt.Sprintf("Reconciling the resource through the endpoints of region %s, the endpoints of region %s fail", chosen, region),
+		)
+	}
+	return chosen
+}
+
+// withRegionHealth returns a copy of the supplied configuration of the AWS
+// service clients recording the health of the endpoints of the supplied
+// region it calls
+func (r *resourceReconciler) withRegionHealth(
+	cfg aws.Config,
+	region ackv1alpha1.AWSRegion,
+) aws.Config {
+	if r.regionHealth == nil {
+		return cfg
+	}
+	apiOptions := make([]func(*middleware.Stack) error, 0, len(cfg.APIOptions)+1)
+	apiOptions = append(apiOptions, cfg.APIOptions...)
+	cfg.APIOptions = append(apiOptions, r.regionHealth.Middleware(string(region)))
+	return cfg
+}
@@ -0,0 +1,187 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package regionhealth tracks the health and the latency of the regional
+// endpoints of the global AWS services, e.g. IAM or Route53, so that their
+// resources can be reconciled through the endpoints of another region while
+// the endpoints of their region, or the STS endpoint of their region, fail.
+package regionhealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+)
+
+const (
+	// unhealthyAfter is the number of consecutive failed calls after which
+	// the endpoints of a region are considered unhealthy
+	unhealthyAfter = 3
+	// unhealthyFor is the time during which the endpoints of an unhealthy
+	// region are not called, unless no other region is healthy
+	unhealthyFor = time.Minute
+	// latencyWeight is the weight of the latest call in the moving average
+	// of the latency of the calls to the endpoints of a region
+	latencyWeight = 0.2
+	// middlewareID is the ID of the middleware recording the outcome of
+	// the calls to the AWS service APIs
+	middlewareID = "ACKRegionHealth"
+)
+
+// regionState is the health of the endpoints of a region
+type regionState struct {
+	// failures is the number of consecutive failed calls
+	failures int
+	// unhealthyUntil is the time until which the region is unhealthy
+	unhealthyUntil time.Time
+	// latency is the exponentially weighted moving average of the latency
+	// of the successful calls, zero if none succeeded yet
+	latency time.Duration
+}
+
+// Tracker tracks the health and the latency of the endpoints of the regions
+// called by a controller. A nil Tracker considers every region healthy.
+type Tracker struct {
+	sync.Mutex
+	regions map[string]*regionState
+}
+
+// NewTracker returns a Tracker considering every region healthy
+func NewTracker() *Tracker {
+	return &Tracker{regions: map[string]*regionState{}}
+}
+
+// IsFailure returns true if the supplied error of a call to an AWS service
+// API is caused by the endpoints of the region called rather than by the
+// request, e.g. a connection failure or a 5XX response, including the ones
+// of the STS endpoint assuming the role of the call
+func IsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if ackerr.IsEndpointConnection(err) {
+		return true
+	}
+	return ackerr.HTTPStatusCode(err) >= http.StatusInternalServerError
+}
+
+// Record records the outcome of a call to the endpoints of the supplied
+// region. The errors that are not failures of the endpoints, e.g. validation
+// errors, count as successful calls.
+func (t *Tracker) Record(region string, latency time.Duration, err error) {
+	if t == nil || region == "" {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	state, ok := t.regions[region]
+	if !ok {
+		state = &regionState{}
+		t.regions[region] = state
+	}
+	if IsFailure(err) {
+		state.failures++
+		if state.failures >= unhealthyAfter {
+			state.unhealthyUntil = time.Now().Add(unhealthyFor)
+		}
+		return
+	}
+	state.failures = 0
+	state.unhealthyUntil = time.Time{}
+	if state.latency == 0 {
+		state.latency = latency
+	} else {
+		state.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(state.latency))
+	}
+}
+
+// Healthy returns true if the endpoints of the supplied region are healthy
+func (t *Tracker) Healthy(region string) bool {
+	if t == nil {
+		return true
+	}
+	t.Lock()
+	defer t.Unlock()
+	return t.healthy(region)
+}
+
+func (t *Tracker) healthy(region string) bool {
+	state, ok := t.regions[region]
+	return !ok || !time.Now().Before(state.unhealthyUntil)
+}
+
+// Choose returns the region whose endpoints should be called: the preferred
+// region if it is healthy, otherwise the healthy fallback region with the
+// lowest latency, the fallback regions without a known latency coming next
+// in the supplied order. It returns the preferred region if no fallback
+// region is healthy.
+func (t *Tracker) Choose(preferred string, fallbacks []string) string {
+	if t == nil {
+		return preferred
+	}
+	t.Lock()
+	defer t.Unlock()
+	if t.healthy(preferred) {
+		return preferred
+	}
+	chosen := ""
+	var chosenLatency time.Duration
+	for _, region := range fallbacks {
+		if region == preferred || !t.healthy(region) {
+			continue
+		}
+		var latency time.Duration
+		if state, ok := t.regions[region]; ok {
+			latency = state.latency
+		}
+		switch {
+		case chosen == "":
+			chosen, chosenLatency = region, latency
+		case latency != 0 && (chosenLatency == 0 || latency < chosenLatency):
+			chosen, chosenLatency = region, latency
+		}
+	}
+	if chosen == "" {
+		return preferred
+	}
+	return chosen
+}
+
+// Middleware returns the API option recording in the Tracker the outcome and
+// the latency of the calls to the endpoints of the supplied region, retries
+// included
+func (t *Tracker) Middleware(region string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(
+			middlewareID,
+			func(
+				ctx context.Context,
+				in middleware.InitializeInput,
+				next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				start := time.Now()
+				out, md, err := next.HandleInitialize(ctx, in)
+				if !errors.Is(err, context.Canceled) {
+					t.Record(region, time.Now().Sub(start), err)
+				}
+				return out, md, err
+			},
+		), middleware.Before)
+	}
+}
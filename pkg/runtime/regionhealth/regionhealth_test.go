@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package regionhealth_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/regionhealth"
+)
+
+func responseError(status int) error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+		Err:      errors.New("service error"),
+	}
+}
+
+func TestIsFailure(t *testing.T) {
+	require := require.New(t)
+
+	require.False(regionhealth.IsFailure(nil))
+	require.False(regionhealth.IsFailure(errors.New("validation")))
+	require.False(regionhealth.IsFailure(responseError(http.StatusBadRequest)))
+	require.True(regionhealth.IsFailure(responseError(http.StatusServiceUnavailable)))
+	require.True(regionhealth.IsFailure(&net.DNSError{Err: "no such host", Name: "sts.us-east-1.amazonaws.com"}))
+}
+
+func TestTracker(t *testing.T) {
+	require := require.New(t)
+
+	var nilTracker *regionhealth.Tracker
+	require.True(nilTracker.Healthy("us-east-1"))
+	require.Equal("us-east-1", nilTracker.Choose("us-east-1", []string{"us-west-2"}))
+
+	tracker := regionhealth.NewTracker()
+	fallbacks := []string{"us-east-1", "us-west-2", "eu-west-1"}
+	require.Equal("us-east-1", tracker.Choose("us-east-1", fallbacks))
+
+	// Failures below the threshold, or errors of the requests, keep the
+	// region healthy
+	tracker.Record("us-east-1", time.Second, responseError(http.StatusInternalServerError))
+	tracker.Record("us-east-1", time.Second, responseError(http.StatusInternalServerError))
+	tracker.Record("us-east-1", time.Second, responseError(http.StatusBadRequest))
+	tracker.Record("us-east-1", time.Second, responseError(http.StatusInternalServerError))
+	tracker.Record("us-east-1", time.Second, responseError(http.StatusInternalServerError))
+	require.True(tracker.Healthy("us-east-1"))
+
+	tracker.Record("us-east-1", time.Second, responseError(http.StatusInternalServerError))
+	require.False(tracker.Healthy("us-east-1"))
+
+	// Without known latencies, the fallbacks are chosen in order
+	require.Equal("us-west-2", tracker.Choose("us-east-1", fallbacks))
+
+	// Otherwise, the fallback with the lowest latency is chosen
+	tracker.Record("us-west-2", 300*time.Millisecond, nil)
+	tracker.Record("eu-west-1", 100*time.Millisecond, nil)
+	require.Equal("eu-west-1", tracker.Choose("us-east-1", fallbacks))
+
+	// Unhealthy fallbacks are skipped
+	for i := 0; i < 3; i++ {
+		tracker.Record("eu-west-1", time.Second, &net.DNSError{Err: "no such host"})
+	}
+	require.Equal("us-west-2", tracker.Choose("us-east-1", fallbacks))
+
+	// The preferred region is chosen when no fallback is healthy
+	for i := 0; i < 3; i++ {
+		tracker.Record("us-west-2", time.Second, &net.DNSError{Err: "no such host"})
+	}
+	require.Equal("us-east-1", tracker.Choose("us-east-1", fallbacks))
+
+	// A successful call makes the region healthy again
+	tracker.Record("us-east-1", 50*time.Millisecond, nil)
+	require.True(tracker.Healthy("us-east-1"))
+	require.Equal("us-east-1", tracker.Choose("us-east-1", fallbacks))
+}
@@ -0,0 +1,124 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtrequeuestorm "github.com/aws-controllers-k8s/runtime/pkg/runtime/requeuestorm"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// newRequeueStormDetector returns the detector of the requeue storms
+// configured with the --requeue-storm-threshold flag, or nil
+func newRequeueStormDetector(cfg ackcfg.Config) *ackrtrequeuestorm.Detector {
+	if cfg.RequeueStormThreshold <= 0 {
+		return nil
+	}
+	return ackrtrequeuestorm.NewDetector(
+		cfg.RequeueStormThreshold,
+		time.Duration(cfg.RequeueStormCoolDownSeconds)*time.Second,
+	)
+}
+
+// stateFingerprint returns the fingerprint of the state of the supplied
+// resource: its spec, status, labels, annotations, finalizers and deletion
+// timestamp. The transition times of the conditions, which are refreshed on
+// every reconciliation, are left out.
+func stateFingerprint(res acktypes.AWSResource) (string, error) {
+	obj, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		return "", err
+	}
+	u := k8sunstructured.Unstructured{Object: obj}
+	status, _, _ := k8sunstructured.NestedMap(obj, "status")
+	if conditions, ok := status["conditions"].([]interface{}); ok {
+		for _, c := range conditions {
+			if condition, ok := c.(map[string]interface{}); ok {
+				delete(condition, "lastTransitionTime")
+			}
+		}
+	}
+	// encoding/json sorts the keys of the maps it serializes
+	js, err := json.Marshal(map[string]interface{}{
+		"spec":              obj["spec"],
+		"status":            status,
+		"labels":            u.GetLabels(),
+		"annotations":       u.GetAnnotations(),
+		"finalizers":        u.GetFinalizers(),
+		"deletionTimestamp": u.GetDeletionTimestamp(),
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(js)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// coolDownRequeueStorm returns true, and the duration after which to
+// reconcile the supplied resource again, if the resource is in a requeue
+// storm: it is reconciled again and again, e.g. because of a hook requeueing
+// it immediately, without its state changing. Such loops only burn the AWS
+// API rate limits, so the reconciliations of the resource are suspended for
+// a cool-down period, or until its state changes.
+func (r *resourceReconciler) coolDownRequeueStorm(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) (time.Duration, bool) {
+	if r.requeueStorms == nil {
+		return 0, false
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	fingerprint, err := stateFingerprint(res)
+	if err != nil {
+		rlog.Debug("unable to fingerprint the state of the resource", "error", err)
+		return 0, false
+	}
+	mo := res.MetaObject()
+	key := types.NamespacedName{Namespace: mo.GetNamespace(), Name: mo.GetName()}
+	coolDown, started := r.requeueStorms.Observe(key, fingerprint)
+	if coolDown == 0 {
+		return 0, false
+	}
+	if started {
+		rlog.Info(
+			"resource reconciled again and again without changing, cooling it down",
+			"threshold", r.cfg.RequeueStormThreshold,
+			"cool_down", coolDown,
+		)
+		r.recordEvent(
+			res.RuntimeObject(), ackevents.ReasonRequeueStorm,
+			fmt.Sprintf(
+				"Reconciled more than %d times in a minute without changing, reconciliations suspended for %s",
+				r.cfg.RequeueStormThreshold, coolDown,
+			),
+		)
+		if r.metrics != nil {
+			r.metrics.RecordRequeueStorm(r.rd.GroupVersionKind().Kind)
+		}
+	}
+	return coolDown, true
+}
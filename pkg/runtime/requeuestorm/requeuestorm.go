@@ -0,0 +1,164 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package requeuestorm detects requeue storms, the pathological loops in
+// which a resource is reconciled again and again without its state changing,
+// e.g. because of a hook requeueing it immediately, and cools them down.
+package requeuestorm
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// window is the duration of the windows in which the reconciliations of a
+// resource are counted
+const window = time.Minute
+
+// resource is the state of a resource, as tracked by a Detector
+type resource struct {
+	// fingerprint is the fingerprint of the last observed state
+	fingerprint string
+	// windowStart is the start of the current window
+	windowStart time.Time
+	// reconciliations is the number of reconciliations in the current
+	// window
+	reconciliations int
+	// stormSince is the start of the current storm, zero if the resource is
+	// not in a storm
+	stormSince time.Time
+	// coolDownUntil is the end of the cool-down of the current storm
+	coolDownUntil time.Time
+}
+
+// Detector detects the requeue storms of the resources of a kind: the
+// resources reconciled more than a threshold number of times in a minute
+// while their state did not change. The reconciliations of the resources in a
+// storm are suspended for a cool-down period, or until their state changes. A
+// nil Detector detects nothing.
+type Detector struct {
+	sync.Mutex
+	threshold int
+	coolDown  time.Duration
+	resources map[types.NamespacedName]*resource
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewDetector returns a Detector of the resources reconciled more than the
+// supplied number of times per minute without changing, cooling them down for
+// the supplied duration
+func NewDetector(threshold int, coolDown time.Duration) *Detector {
+	return &Detector{
+		threshold: threshold,
+		coolDown:  coolDown,
+		resources: map[types.NamespacedName]*resource{},
+		now:       time.Now,
+	}
+}
+
+// WithClock replaces the clock of the Detector
+func (d *Detector) WithClock(now func() time.Time) *Detector {
+	d.now = now
+	return d
+}
+
+// Observe records a reconciliation of the supplied resource, in the state of
+// the supplied fingerprint. If the resource is cooling down, the
+// reconciliation is not counted and the remaining cool-down is returned. If
+// the reconciliation starts a storm, the cool-down is returned and started is
+// true.
+func (d *Detector) Observe(
+	key types.NamespacedName,
+	fingerprint string,
+) (coolDown time.Duration, started bool) {
+	if d == nil {
+		return 0, false
+	}
+	d.Lock()
+	defer d.Unlock()
+	now := d.now()
+	res, ok := d.resources[key]
+	if !ok || res.fingerprint != fingerprint {
+		// The state of the resource changed, which ends its storm
+		d.resources[key] = &resource{fingerprint: fingerprint, windowStart: now, reconciliations: 1}
+		return 0, false
+	}
+	if now.Before(res.coolDownUntil) {
+		return res.coolDownUntil.Sub(now), false
+	}
+	if now.Sub(res.windowStart) >= window {
+		res.windowStart = now
+		res.reconciliations = 0
+	}
+	res.reconciliations++
+	if res.reconciliations <= d.threshold {
+		return 0, false
+	}
+	started = res.stormSince.IsZero()
+	if started {
+		res.stormSince = now
+	}
+	res.coolDownUntil = now.Add(d.coolDown)
+	res.windowStart = res.coolDownUntil
+	res.reconciliations = 0
+	return d.coolDown, started
+}
+
+// Forget removes the supplied resource, e.g. once it is deleted
+func (d *Detector) Forget(key types.NamespacedName) {
+	if d == nil {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	delete(d.resources, key)
+}
+
+// Storm is the requeue storm of a resource, as reported in state dumps
+type Storm struct {
+	// Resource is the namespace and name of the resource
+	Resource string `json:"resource"`
+	// Since is the start of the storm
+	Since time.Time `json:"since"`
+	// CoolDownUntil is the end of the current cool-down of the resource
+	CoolDownUntil time.Time `json:"coolDownUntil"`
+}
+
+// Storms returns the requeue storms of the resources, sorted by resource.
+// Storms last until the state of their resource changes.
+func (d *Detector) Storms() []Storm {
+	storms := []Storm{}
+	if d == nil {
+		return storms
+	}
+	d.Lock()
+	defer d.Unlock()
+	for key, res := range d.resources {
+		if res.stormSince.IsZero() {
+			continue
+		}
+		storms = append(storms, Storm{
+			Resource:      key.String(),
+			Since:         res.stormSince,
+			CoolDownUntil: res.coolDownUntil,
+		})
+	}
+	sort.Slice(storms, func(i, j int) bool {
+		return storms[i].Resource < storms[j].Resource
+	})
+	return storms
+}
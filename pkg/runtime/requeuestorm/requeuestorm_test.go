@@ -0,0 +1,95 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package requeuestorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/requeuestorm"
+)
+
+func TestDetector(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	d := requeuestorm.NewDetector(3, 5*time.Minute).WithClock(func() time.Time { return now })
+	a := types.NamespacedName{Namespace: "ns", Name: "a"}
+	b := types.NamespacedName{Namespace: "ns", Name: "b"}
+
+	// Reconciliations changing the state of the resource are not a storm
+	for _, fingerprint := range []string{"1", "2", "3", "4", "5"} {
+		_, started := d.Observe(b, fingerprint)
+		require.False(started)
+	}
+
+	// Reconciliations within the threshold are not a storm
+	for i := 0; i < 3; i++ {
+		coolDown, started := d.Observe(a, "1")
+		require.False(started)
+		require.Zero(coolDown)
+	}
+	require.Empty(d.Storms())
+
+	// Neither are reconciliations spread over more than a minute
+	now = now.Add(time.Minute)
+	coolDown, started := d.Observe(a, "1")
+	require.False(started)
+	require.Zero(coolDown)
+
+	for i := 0; i < 2; i++ {
+		_, started = d.Observe(a, "1")
+		require.False(started)
+	}
+	coolDown, started = d.Observe(a, "1")
+	require.True(started)
+	require.Equal(5*time.Minute, coolDown)
+	require.Equal([]requeuestorm.Storm{{
+		Resource:      "ns/a",
+		Since:         now,
+		CoolDownUntil: now.Add(5 * time.Minute),
+	}}, d.Storms())
+
+	// The resource cools down
+	now = now.Add(time.Minute)
+	coolDown, started = d.Observe(a, "1")
+	require.False(started)
+	require.Equal(4*time.Minute, coolDown)
+
+	// The storm goes on after the cool-down
+	now = now.Add(4 * time.Minute)
+	for i := 0; i < 3; i++ {
+		coolDown, _ = d.Observe(a, "1")
+		require.Zero(coolDown)
+	}
+	coolDown, started = d.Observe(a, "1")
+	require.False(started)
+	require.Equal(5*time.Minute, coolDown)
+	require.Len(d.Storms(), 1)
+
+	// A change of the state of the resource ends its storm
+	coolDown, started = d.Observe(a, "2")
+	require.False(started)
+	require.Zero(coolDown)
+	require.Empty(d.Storms())
+
+	var nilDetector *requeuestorm.Detector
+	coolDown, started = nilDetector.Observe(a, "1")
+	require.False(started)
+	require.Zero(coolDown)
+	require.Empty(nilDetector.Storms())
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// resourceStateOf returns the state of the supplied resource counted by the
+// ack_resources metric. A resource being deleted is deleting whatever its
+// conditions, and a terminal resource stays terminal even if it is synced.
+func resourceStateOf(res acktypes.AWSResource) ackmetrics.ResourceState {
+	isTrue := func(c *ackv1alpha1.Condition) bool {
+		return c != nil && c.Status == corev1.ConditionTrue
+	}
+	switch {
+	case !res.MetaObject().GetDeletionTimestamp().IsZero():
+		return ackmetrics.ResourceStateDeleting
+	case isTrue(ackcondition.Terminal(res)):
+		return ackmetrics.ResourceStateTerminal
+	case isTrue(ackcondition.Recoverable(res)):
+		return ackmetrics.ResourceStateRecoverable
+	case isTrue(ackcondition.Synced(res)):
+		return ackmetrics.ResourceStateSynced
+	default:
+		return ackmetrics.ResourceStateNotSynced
+	}
+}
+
+// observeResourceState records the state of the supplied resource, whose
+// status was just written, in the ack_resources metric. The resources not
+// managed yet, e.g. whose references are not resolved, are not counted.
+func (r *resourceReconciler) observeResourceState(res acktypes.AWSResource) {
+	mo := res.MetaObject()
+	key := k8stypes.NamespacedName{Namespace: mo.GetNamespace(), Name: mo.GetName()}.String()
+	if !r.rd.IsManaged(res) {
+		r.resourceStates.Forget(key)
+		return
+	}
+	r.resourceStates.Set(key, resourceStateOf(res))
+}
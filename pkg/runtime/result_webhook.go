@@ -0,0 +1,100 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackrtresultwebhook "github.com/aws-controllers-k8s/runtime/pkg/runtime/resultwebhook"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// bindResultWebhook adds to the supplied controller manager the webhook
+// receiving the reconciliation results of the resources of the kind, if the
+// --reconcile-result-webhook flag configures one
+func (r *resourceReconciler) bindResultWebhook(mgr ctrlrt.Manager) error {
+	kind := r.rd.GroupVersionKind().Kind
+	url := r.cfg.GetReconcileResultWebhook(kind)
+	if url == "" {
+		return nil
+	}
+	r.resultWebhook = ackrtresultwebhook.New(
+		r.log.WithName("result-webhook").WithValues("kind", kind), url, nil,
+	)
+	return mgr.Add(r.resultWebhook)
+}
+
+// sendReconcileResult sends the result of the reconciliation of the supplied
+// resource to the result webhook of the kind, if any
+func (r *resourceReconciler) sendReconcileResult(
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	previousConditions []*ackv1alpha1.Condition,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+	err error,
+) {
+	if r.resultWebhook == nil {
+		return
+	}
+	res := desired
+	if ackcompare.IsNotNil(latest) {
+		res = latest
+	}
+	mo := res.MetaObject()
+	result := ackrtresultwebhook.Result{
+		Kind:              r.rd.GroupVersionKind().Kind,
+		Namespace:         mo.GetNamespace(),
+		Name:              mo.GetName(),
+		Generation:        mo.GetGeneration(),
+		Phase:             reconcilePhase(res, err),
+		ConditionsChanged: ackrtresultwebhook.ChangedConditions(previousConditions, res.Conditions()),
+		Identifiers: ackrtresultwebhook.Identifiers{
+			AccountID: string(acctID),
+			Region:    string(region),
+		},
+		Time: time.Now().UTC(),
+	}
+	if arn := res.Identifiers().ARN(); arn != nil {
+		result.Identifiers.ARN = string(*arn)
+	}
+	if err = reconcileError(latest, err); err != nil {
+		result.Error = err.Error()
+	}
+	r.resultWebhook.Send(result)
+}
+
+// reconcilePhase returns the phase of the supplied resource after a
+// reconciliation that returned the supplied error
+func reconcilePhase(res acktypes.AWSResource, err error) ackrtresultwebhook.Phase {
+	if res.IsBeingDeleted() {
+		return ackrtresultwebhook.PhaseDeleting
+	}
+	if c := ackcondition.Terminal(res); c != nil && c.Status == corev1.ConditionTrue {
+		return ackrtresultwebhook.PhaseTerminal
+	}
+	if reconcileError(res, err) != nil {
+		return ackrtresultwebhook.PhaseError
+	}
+	if c := ackcondition.Synced(res); c != nil && c.Status == corev1.ConditionTrue {
+		return ackrtresultwebhook.PhaseSynced
+	}
+	return ackrtresultwebhook.PhaseProgressing
+}
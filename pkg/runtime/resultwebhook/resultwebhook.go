@@ -0,0 +1,214 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package resultwebhook contains a sender of compact reconciliation result
+// documents to outbound webhooks, so that external orchestrators (e.g.
+// ticketing or CMDB synchronization systems) can react to the reconciliation
+// of resources without watching the Kubernetes API server.
+package resultwebhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+const (
+	// queueSize is the number of results buffered by a Webhook. Results
+	// sent while the buffer is full are dropped.
+	queueSize = 1000
+	// sendTimeout is the timeout of a single webhook request
+	sendTimeout = 10 * time.Second
+)
+
+// Phase summarizes the state of a resource after a reconciliation
+type Phase string
+
+const (
+	// PhaseSynced is the phase of a resource whose ACK.ResourceSynced
+	// condition is True
+	PhaseSynced Phase = "Synced"
+	// PhaseProgressing is the phase of a resource that is not synced yet
+	PhaseProgressing Phase = "Progressing"
+	// PhaseTerminal is the phase of a resource whose ACK.Terminal condition
+	// is True
+	PhaseTerminal Phase = "Terminal"
+	// PhaseError is the phase of a resource whose reconciliation failed
+	PhaseError Phase = "Error"
+	// PhaseDeleting is the phase of a resource being deleted
+	PhaseDeleting Phase = "Deleting"
+)
+
+// ConditionChange is a condition whose status, reason or message changed
+// during a reconciliation
+type ConditionChange struct {
+	Type           ackv1alpha1.ConditionType `json:"type"`
+	Status         corev1.ConditionStatus    `json:"status"`
+	PreviousStatus corev1.ConditionStatus    `json:"previousStatus,omitempty"`
+	Reason         string                    `json:"reason,omitempty"`
+	Message        string                    `json:"message,omitempty"`
+}
+
+// Identifiers are the AWS identifiers of a resource
+type Identifiers struct {
+	ARN       string `json:"arn,omitempty"`
+	AccountID string `json:"accountID,omitempty"`
+	Region    string `json:"region,omitempty"`
+}
+
+// Result is the document sent to a webhook after each reconciliation
+type Result struct {
+	Kind              string            `json:"kind"`
+	Namespace         string            `json:"namespace"`
+	Name              string            `json:"name"`
+	Generation        int64             `json:"generation"`
+	Phase             Phase             `json:"phase"`
+	ConditionsChanged []ConditionChange `json:"conditionsChanged,omitempty"`
+	Identifiers       Identifiers       `json:"identifiers"`
+	Error             string            `json:"error,omitempty"`
+	Time              time.Time         `json:"time"`
+}
+
+// ChangedConditions returns the latest conditions whose status, reason or
+// message differs from the one of the previous condition of the same type
+func ChangedConditions(previous, latest []*ackv1alpha1.Condition) []ConditionChange {
+	byType := make(map[ackv1alpha1.ConditionType]*ackv1alpha1.Condition, len(previous))
+	for _, c := range previous {
+		byType[c.Type] = c
+	}
+	var changes []ConditionChange
+	for _, c := range latest {
+		change := ConditionChange{
+			Type:    c.Type,
+			Status:  c.Status,
+			Reason:  value(c.Reason),
+			Message: value(c.Message),
+		}
+		if prev, ok := byType[c.Type]; ok {
+			if prev.Status == c.Status && value(prev.Reason) == change.Reason &&
+				value(prev.Message) == change.Message {
+				continue
+			}
+			change.PreviousStatus = prev.Status
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// value returns the value of an optional string
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Webhook sends reconciliation results to an HTTP endpoint. Results are
+// queued and sent in order, in the background, so that a slow or failing
+// endpoint never slows down reconciliations. Failed requests are logged and
+// not retried. Webhook implements the controller-runtime manager.Runnable
+// interface. A nil Webhook drops every result.
+type Webhook struct {
+	log    logr.Logger
+	url    string
+	client *http.Client
+	queue  chan Result
+}
+
+// New returns a Webhook sending results to the supplied URL
+func New(log logr.Logger, url string, client *http.Client) *Webhook {
+	if client == nil {
+		client = &http.Client{Timeout: sendTimeout}
+	}
+	return &Webhook{
+		log:    log,
+		url:    url,
+		client: client,
+		queue:  make(chan Result, queueSize),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Results are
+// only produced by the reconcilers of the leader.
+func (w *Webhook) NeedLeaderElection() bool {
+	return false
+}
+
+// Send queues the supplied result, or drops it if the queue is full
+func (w *Webhook) Send(result Result) {
+	if w == nil {
+		return
+	}
+	select {
+	case w.queue <- result:
+	default:
+		w.log.Info(
+			"dropping reconcile result, webhook queue is full",
+			"kind", result.Kind,
+			"namespace", result.Namespace,
+			"name", result.Name,
+		)
+	}
+}
+
+// Start sends the queued results until the supplied context is done
+func (w *Webhook) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case result := <-w.queue:
+			if err := w.post(ctx, result); err != nil {
+				w.log.Error(
+					err, "unable to send reconcile result",
+					"kind", result.Kind,
+					"namespace", result.Namespace,
+					"name", result.Name,
+				)
+			}
+		}
+	}
+}
+
+// post sends the supplied result to the webhook endpoint
+func (w *Webhook) post(ctx context.Context, result Result) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
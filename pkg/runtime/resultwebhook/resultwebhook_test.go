@@ -0,0 +1,97 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resultwebhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/resultwebhook"
+)
+
+func condition(
+	conditionType ackv1alpha1.ConditionType,
+	status corev1.ConditionStatus,
+	message string,
+) *ackv1alpha1.Condition {
+	return &ackv1alpha1.Condition{Type: conditionType, Status: status, Message: &message}
+}
+
+func TestChangedConditions(t *testing.T) {
+	require := require.New(t)
+
+	previous := []*ackv1alpha1.Condition{
+		condition(ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionFalse, "not synced"),
+		condition(ackv1alpha1.ConditionTypeReferencesResolved, corev1.ConditionTrue, "resolved"),
+	}
+	latest := []*ackv1alpha1.Condition{
+		condition(ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionTrue, "synced"),
+		condition(ackv1alpha1.ConditionTypeReferencesResolved, corev1.ConditionTrue, "resolved"),
+		condition(ackv1alpha1.ConditionTypeLateInitialized, corev1.ConditionTrue, ""),
+	}
+	require.Equal([]resultwebhook.ConditionChange{
+		{
+			Type:           ackv1alpha1.ConditionTypeResourceSynced,
+			Status:         corev1.ConditionTrue,
+			PreviousStatus: corev1.ConditionFalse,
+			Message:        "synced",
+		},
+		{
+			Type:   ackv1alpha1.ConditionTypeLateInitialized,
+			Status: corev1.ConditionTrue,
+		},
+	}, resultwebhook.ChangedConditions(previous, latest))
+	require.Empty(resultwebhook.ChangedConditions(latest, latest))
+}
+
+func TestWebhook(t *testing.T) {
+	require := require.New(t)
+
+	received := make(chan resultwebhook.Result, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result resultwebhook.Result
+		if err := json.NewDecoder(r.Body).Decode(&result); err == nil {
+			received <- result
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	webhook := resultwebhook.New(logr.Discard(), server.URL, nil)
+	go func() { _ = webhook.Start(ctx) }()
+
+	webhook.Send(resultwebhook.Result{
+		Kind:        "Bucket",
+		Namespace:   "ns",
+		Name:        "my-bucket",
+		Phase:       resultwebhook.PhaseSynced,
+		Identifiers: resultwebhook.Identifiers{ARN: "arn:aws:s3:::my-bucket"},
+	})
+	result := <-received
+	require.Equal("my-bucket", result.Name)
+	require.Equal(resultwebhook.PhaseSynced, result.Phase)
+	require.Equal("arn:aws:s3:::my-bucket", result.Identifiers.ARN)
+
+	var nilWebhook *resultwebhook.Webhook
+	nilWebhook.Send(result)
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackrtresync "github.com/aws-controllers-k8s/runtime/pkg/runtime/resync"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// newResyncBudget returns the resync budget configured with the
+// --resync-budget-per-minute flag, or nil
+func newResyncBudget(cfg ackcfg.Config, resyncPeriod time.Duration) *ackrtresync.Budget {
+	if cfg.ResyncBudgetPerMinute <= 0 {
+		return nil
+	}
+	minAge := resyncPeriod
+	if cfg.ResyncMinAgeSeconds > 0 {
+		minAge = time.Duration(cfg.ResyncMinAgeSeconds) * time.Second
+	}
+	return ackrtresync.NewBudget(cfg.ResyncBudgetPerMinute, minAge)
+}
+
+// deferDriftCheck returns true, and the duration after which to reconcile the
+// supplied resource again, if the resync budget does not admit its drift
+// check now. Only the synced resources that are not being deleted are
+// subject to the budget.
+func (r *resourceReconciler) deferDriftCheck(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) (time.Duration, bool) {
+	if r.resyncBudget == nil || res.IsBeingDeleted() {
+		return 0, false
+	}
+	if synced := condition.Synced(res); synced == nil || synced.Status != corev1.ConditionTrue {
+		return 0, false
+	}
+	mo := res.MetaObject()
+	key := types.NamespacedName{Namespace: mo.GetNamespace(), Name: mo.GetName()}
+	after, admitted := r.resyncBudget.Admit(key, mo.GetGeneration())
+	if admitted {
+		return 0, false
+	}
+	ackrtlog.FromContext(ctx).Debug("deferring drift check", "after", after)
+	return after, true
+}
+
+// recordDriftCheck records a successful drift check of the supplied resource
+// in the resync budget
+func (r *resourceReconciler) recordDriftCheck(res acktypes.AWSResource) {
+	if r.resyncBudget == nil {
+		return
+	}
+	mo := res.MetaObject()
+	key := types.NamespacedName{Namespace: mo.GetNamespace(), Name: mo.GetName()}
+	r.resyncBudget.Verified(key, mo.GetGeneration())
+}
@@ -0,0 +1,195 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package resync contains the resync budget, spreading the periodic drift
+// checks of the resources of a kind over time instead of running them all at
+// once, e.g. when the informers of the controller resync.
+package resync
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// window is the duration of the budget windows
+const window = time.Minute
+
+// verification is the last successful drift check of a resource
+type verification struct {
+	at         time.Time
+	generation int64
+}
+
+// Budget admits the drift checks of a kind of resources:
+//
+//   - resources whose generation changed since their last drift check are
+//     always admitted, their spec changed
+//   - resources checked less than the minimum age ago are skipped until they
+//     reach the minimum age
+//   - other resources are admitted up to the per minute budget. Resources
+//     exceeding the budget wait for a later window, the resources whose last
+//     drift check is the oldest being given the earliest windows.
+//
+// Resources are unknown to a Budget until their first drift check, and are
+// then considered as the oldest ones. A nil Budget admits all drift checks.
+type Budget struct {
+	sync.Mutex
+	perMinute int
+	minAge    time.Duration
+	verified  map[types.NamespacedName]verification
+	// waiting maps the resources waiting for a later window to the time of
+	// their last drift check
+	waiting map[types.NamespacedName]time.Time
+	// windowStart is the start of the current window
+	windowStart time.Time
+	// admitted is the number of drift checks admitted in the current window
+	admitted int
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewBudget returns a Budget admitting the supplied number of drift checks per
+// minute, skipping the resources checked less than minAge ago
+func NewBudget(perMinute int, minAge time.Duration) *Budget {
+	return &Budget{
+		perMinute: perMinute,
+		minAge:    minAge,
+		verified:  map[types.NamespacedName]verification{},
+		waiting:   map[types.NamespacedName]time.Time{},
+		now:       time.Now,
+	}
+}
+
+// WithClock replaces the clock of the Budget
+func (b *Budget) WithClock(now func() time.Time) *Budget {
+	b.now = now
+	return b
+}
+
+// Verified records a successful drift check of the supplied resource, at the
+// supplied generation
+func (b *Budget) Verified(key types.NamespacedName, generation int64) {
+	if b == nil {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.verified[key] = verification{at: b.now(), generation: generation}
+	delete(b.waiting, key)
+}
+
+// Forget removes the supplied resource, e.g. once it is deleted
+func (b *Budget) Forget(key types.NamespacedName) {
+	if b == nil {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	delete(b.verified, key)
+	delete(b.waiting, key)
+}
+
+// Admit returns true if the drift check of the supplied resource, at the
+// supplied generation, can run now. Otherwise, it returns the duration after
+// which to try again.
+func (b *Budget) Admit(key types.NamespacedName, generation int64) (time.Duration, bool) {
+	if b == nil {
+		return 0, true
+	}
+	b.Lock()
+	defer b.Unlock()
+	now := b.now()
+	v, known := b.verified[key]
+	if known && v.generation != generation {
+		delete(b.waiting, key)
+		return 0, true
+	}
+	if known && now.Sub(v.at) < b.minAge {
+		return v.at.Add(b.minAge).Sub(now), false
+	}
+
+	if now.Sub(b.windowStart) >= window {
+		b.windowStart = now.Truncate(window)
+		b.admitted = 0
+	}
+	if b.perMinute <= 0 || b.admitted < b.perMinute {
+		b.admitted++
+		delete(b.waiting, key)
+		return 0, true
+	}
+
+	b.waiting[key] = v.at
+	rank := b.rank(key)
+	windows := time.Duration(rank/b.perMinute) * window
+	return b.windowStart.Add(window).Sub(now) + windows, false
+}
+
+// BudgetState is the state of a Budget, as reported in state dumps
+type BudgetState struct {
+	// PerMinute is the number of drift checks admitted per minute
+	PerMinute int `json:"perMinute"`
+	// WindowStart is the start of the current window
+	WindowStart time.Time `json:"windowStart"`
+	// Admitted is the number of drift checks admitted in the current window
+	Admitted int `json:"admitted"`
+	// Verified is the number of resources known to the Budget
+	Verified int `json:"verified"`
+	// Waiting contains the resources waiting for a later window
+	Waiting []string `json:"waiting,omitempty"`
+}
+
+// Snapshot returns the current state of the Budget, nil for a nil Budget
+func (b *Budget) Snapshot() *BudgetState {
+	if b == nil {
+		return nil
+	}
+	b.Lock()
+	defer b.Unlock()
+	waiting := make([]string, 0, len(b.waiting))
+	for key := range b.waiting {
+		waiting = append(waiting, key.String())
+	}
+	sort.Strings(waiting)
+	return &BudgetState{
+		PerMinute:   b.perMinute,
+		WindowStart: b.windowStart,
+		Admitted:    b.admitted,
+		Verified:    len(b.verified),
+		Waiting:     waiting,
+	}
+}
+
+// rank returns the number of waiting resources checked before the supplied
+// waiting one. The caller must hold the lock.
+func (b *Budget) rank(key types.NamespacedName) int {
+	keys := make([]types.NamespacedName, 0, len(b.waiting))
+	for k := range b.waiting {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ai, aj := b.waiting[keys[i]], b.waiting[keys[j]]
+		if !ai.Equal(aj) {
+			return ai.Before(aj)
+		}
+		return keys[i].String() < keys[j].String()
+	})
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return len(keys)
+}
@@ -0,0 +1,112 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resync_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/resync"
+)
+
+func TestBudget(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	b := resync.NewBudget(2, 10*time.Minute).WithClock(func() time.Time { return now })
+	a := types.NamespacedName{Namespace: "ns", Name: "a"}
+	c := types.NamespacedName{Namespace: "ns", Name: "c"}
+	d := types.NamespacedName{Namespace: "ns", Name: "d"}
+	e := types.NamespacedName{Namespace: "ns", Name: "e"}
+
+	// Recently verified resources are skipped
+	b.Verified(a, 1)
+	now = now.Add(4 * time.Minute)
+	after, ok := b.Admit(a, 1)
+	require.False(ok)
+	require.Equal(6*time.Minute, after)
+
+	// Resources whose spec changed are always admitted
+	_, ok = b.Admit(a, 2)
+	require.True(ok)
+
+	// Unknown resources are admitted within the budget
+	_, ok = b.Admit(c, 1)
+	require.True(ok)
+	_, ok = b.Admit(d, 1)
+	require.True(ok)
+	after, ok = b.Admit(e, 1)
+	require.False(ok)
+	require.Equal(time.Minute, after)
+	require.Equal(&resync.BudgetState{
+		PerMinute:   2,
+		WindowStart: now,
+		Admitted:    2,
+		Verified:    1,
+		Waiting:     []string{"ns/e"},
+	}, b.Snapshot())
+
+	b.Forget(e)
+	_, ok = b.Admit(e, 1)
+	require.False(ok)
+}
+
+func TestBudget_Priority(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	b := resync.NewBudget(1, time.Minute).WithClock(func() time.Time { return now })
+	oldest := types.NamespacedName{Namespace: "ns", Name: "oldest"}
+	older := types.NamespacedName{Namespace: "ns", Name: "older"}
+	recent := types.NamespacedName{Namespace: "ns", Name: "recent"}
+	b.Verified(oldest, 1)
+	now = now.Add(time.Minute)
+	b.Verified(older, 1)
+	now = now.Add(time.Minute)
+	b.Verified(recent, 1)
+	now = now.Add(time.Hour + 30*time.Second)
+
+	_, ok := b.Admit(recent, 1)
+	require.True(ok)
+	// The budget of the window is exhausted, the oldest resources wait
+	// for the next windows first
+	after, ok := b.Admit(older, 1)
+	require.False(ok)
+	require.Equal(30*time.Second, after)
+	after, ok = b.Admit(oldest, 1)
+	require.False(ok)
+	require.Equal(30*time.Second, after)
+	after, ok = b.Admit(older, 1)
+	require.False(ok)
+	require.Equal(90*time.Second, after)
+}
+
+func TestBudget_Unlimited(t *testing.T) {
+	require := require.New(t)
+
+	b := resync.NewBudget(0, 0)
+	for i := 0; i < 100; i++ {
+		_, ok := b.Admit(types.NamespacedName{Name: "a"}, 1)
+		require.True(ok)
+	}
+
+	var nilBudget *resync.Budget
+	nilBudget.Verified(types.NamespacedName{Name: "a"}, 1)
+	_, ok := nilBudget.Admit(types.NamespacedName{Name: "a"}, 1)
+	require.True(ok)
+	require.Nil(nilBudget.Snapshot())
+}
@@ -0,0 +1,73 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package rolecreds caches the credentials of the IAM roles assumed by a
+// controller, shared by its reconciliations so that a role is only assumed
+// again once its credentials expire.
+//
+// The credentials are cached per role, region and endpoint URL: the STS
+// client assuming a role is bound to the region and endpoint of the config it
+// was created from, so that the reconciliations of a role through different
+// regions, e.g. after the failover of a global service endpoint, or through
+// different endpoint overrides, never share an STS client.
+package rolecreds
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Key identifies the credentials of an IAM role assumed through the STS
+// client of a region and endpoint URL
+type Key struct {
+	RoleARN     string
+	Region      string
+	EndpointURL string
+}
+
+// Cache holds the credentials providers of the assumed IAM roles
+type Cache struct {
+	sync.Mutex
+	providers map[Key]aws.CredentialsProvider
+}
+
+// New returns an empty Cache
+func New() *Cache {
+	return &Cache{
+		providers: map[Key]aws.CredentialsProvider{},
+	}
+}
+
+// ProviderFor returns the credentials provider of the supplied key, created
+// with the supplied function the first time it is asked for
+func (c *Cache) ProviderFor(
+	key Key,
+	newProvider func() aws.CredentialsProvider,
+) aws.CredentialsProvider {
+	c.Lock()
+	defer c.Unlock()
+	provider, ok := c.providers[key]
+	if !ok {
+		provider = newProvider()
+		c.providers[key] = provider
+	}
+	return provider
+}
+
+// Len returns the number of cached credentials providers
+func (c *Cache) Len() int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.providers)
+}
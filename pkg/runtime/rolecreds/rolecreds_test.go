@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rolecreds_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/rolecreds"
+)
+
+const roleARN = "arn:aws:iam::111122223333:role/ack"
+
+// regionProvider returns the credentials provider of an STS client of the
+// supplied region and endpoint URL, counting its creations
+func regionProvider(region, endpointURL string, created *int) func() aws.CredentialsProvider {
+	return func() aws.CredentialsProvider {
+		*created++
+		return aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{Source: region + " " + endpointURL}, nil
+		})
+	}
+}
+
+func source(t *testing.T, provider aws.CredentialsProvider) string {
+	creds, err := provider.Retrieve(context.Background())
+	require.Nil(t, err)
+	return creds.Source
+}
+
+func TestCache_ProviderFor(t *testing.T) {
+	require := require.New(t)
+
+	cache := rolecreds.New()
+	created := 0
+
+	// The warm standby assumes the role in the region of the controller
+	standby := rolecreds.Key{RoleARN: roleARN, Region: "us-east-1"}
+	provider := cache.ProviderFor(standby, regionProvider("us-east-1", "", &created))
+	require.Equal("us-east-1 ", source(t, provider))
+
+	// The same role, region and endpoint reuse the provider
+	provider = cache.ProviderFor(standby, regionProvider("us-east-1", "", &created))
+	require.Equal("us-east-1 ", source(t, provider))
+	require.Equal(1, created)
+
+	// Reconciliations failed over to another region get their own STS client
+	failover := rolecreds.Key{RoleARN: roleARN, Region: "us-west-2"}
+	provider = cache.ProviderFor(failover, regionProvider("us-west-2", "", &created))
+	require.Equal("us-west-2 ", source(t, provider))
+
+	// So do the namespaces overriding the endpoint
+	override := rolecreds.Key{RoleARN: roleARN, Region: "us-east-1", EndpointURL: "https://vpce.example.com"}
+	provider = cache.ProviderFor(override, regionProvider("us-east-1", "https://vpce.example.com", &created))
+	require.Equal("us-east-1 https://vpce.example.com", source(t, provider))
+
+	// And the other roles
+	other := rolecreds.Key{RoleARN: "arn:aws:iam::444455556666:role/ack", Region: "us-east-1"}
+	cache.ProviderFor(other, regionProvider("us-east-1", "", &created))
+
+	require.Equal(4, created)
+	require.Equal(4, cache.Len())
+	require.Equal("us-east-1 ", source(t, cache.ProviderFor(standby, nil)))
+}
+
+func TestCache_ProviderForConcurrent(t *testing.T) {
+	cache := rolecreds.New()
+	key := rolecreds.Key{RoleARN: roleARN, Region: "us-east-1"}
+	created := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.ProviderFor(key, regionProvider("us-east-1", "", &created))
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, created)
+	require.Equal(t, 1, cache.Len())
+}
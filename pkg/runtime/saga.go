@@ -0,0 +1,90 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acksaga "github.com/aws-controllers-k8s/runtime/pkg/saga"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// updateWithCompensation calls the supplied update of the supplied resource.
+// If the resource manager compensates the updates of the resource, the update
+// is called with a context holding a saga and, when it fails, the compensating
+// actions of its successful steps are executed and their outcome recorded in
+// the ACK.UpdateRolledBack condition of the returned resource.
+func (r *resourceReconciler) updateWithCompensation(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	latest acktypes.AWSResource,
+	update func(ctx context.Context) (acktypes.AWSResource, error),
+) (acktypes.AWSResource, error) {
+	compensator, ok := rm.(acktypes.AWSResourceUpdateCompensator)
+	if !ok || !compensator.CompensatesUpdates(latest) {
+		return update(ctx)
+	}
+	sagaCtx, saga := acksaga.WithSaga(ctx)
+	updated, err := update(sagaCtx)
+	if err == nil {
+		if !ackcompare.IsNil(updated) {
+			ackcondition.RemoveUpdateRolledBack(updated)
+		}
+		return updated, nil
+	}
+	steps := saga.Steps()
+	if len(steps) == 0 {
+		return updated, err
+	}
+
+	rlog := ackrtlog.FromContext(ctx)
+	rlog.Info(
+		"update failed after partially updating the AWS resource, undoing the successful steps",
+		"steps", steps, "error", err,
+	)
+	result := saga.Compensate(ctx)
+	reason := err.Error()
+	if result.RolledBack() {
+		msg := fmt.Sprintf(
+			"Update failed, its successful steps were undone: %s", strings.Join(result.Compensated, ", "),
+		)
+		ackcondition.SetUpdateRolledBack(latest, corev1.ConditionTrue, &msg, &reason)
+		r.recordEvent(latest.RuntimeObject(), ackevents.ReasonUpdateRolledBack, msg)
+		return latest, err
+	}
+	failed := make([]string, 0, len(result.Failed))
+	for _, f := range result.Failed {
+		if f.Err == nil {
+			failed = append(failed, fmt.Sprintf("%s (no compensating action)", f.Step))
+		} else {
+			failed = append(failed, fmt.Sprintf("%s (%s)", f.Step, f.Err))
+		}
+	}
+	msg := fmt.Sprintf(
+		"Update failed and the AWS resource is partially updated, these steps could not be undone: %s",
+		strings.Join(failed, ", "),
+	)
+	rlog.Info("unable to undo all the successful steps of the failed update", "failed", failed)
+	ackcondition.SetUpdateRolledBack(latest, corev1.ConditionFalse, &msg, &reason)
+	r.recordEvent(latest.RuntimeObject(), ackevents.ReasonUpdateRollbackFailed, msg)
+	return latest, err
+}
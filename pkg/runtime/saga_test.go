@@ -0,0 +1,123 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	acksaga "github.com/aws-controllers-k8s/runtime/pkg/saga"
+
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// compensatingResourceManager is a resource manager whose failed updates are
+// compensated
+type compensatingResourceManager struct {
+	*ackmocks.AWSResourceManager
+	*ackmocks.AWSResourceUpdateCompensator
+}
+
+func TestReconcilerUpdate_Compensation(t *testing.T) {
+	tests := []struct {
+		name           string
+		compensateErr  error
+		expectedStatus corev1.ConditionStatus
+	}{
+		{"rolled back", nil, corev1.ConditionTrue},
+		{"rollback failed", errors.New("throttled"), corev1.ConditionFalse},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := context.TODO()
+			arn := ackv1alpha1.AWSResourceName("mybook-arn")
+
+			delta := ackcompare.NewDelta()
+			delta.Add("Spec.A", "val1", "val2")
+
+			desired, _, _ := resourceMocks()
+			desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+
+			ids := &ackmocks.AWSResourceIdentifiers{}
+			ids.On("ARN").Return(&arn)
+
+			latest, _, _ := resourceMocks()
+			latest.On("Identifiers").Return(ids)
+			latest.On("Conditions").Return([]*ackv1alpha1.Condition{})
+			var rolledBack *ackv1alpha1.Condition
+			latest.On("ReplaceConditions", mock.AnythingOfType("[]*v1alpha1.Condition")).Return().Run(
+				func(args mock.Arguments) {
+					for _, c := range args.Get(0).([]*ackv1alpha1.Condition) {
+						if c.Type == ackv1alpha1.ConditionTypeUpdateRolledBack {
+							rolledBack = c
+						}
+					}
+				},
+			)
+
+			compensated := []string{}
+			baseRM := &ackmocks.AWSResourceManager{}
+			baseRM.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+			baseRM.On("ClearResolvedReferences", desired).Return(desired)
+			baseRM.On("ClearResolvedReferences", latest).Return(latest)
+			baseRM.On("ReadOne", ctx, desired).Return(latest, nil)
+			baseRM.On("FilterSystemTags", latest)
+			baseRM.On("IsSynced", ctx, latest).Return(false, nil)
+			baseRM.On("Update", mock.Anything, desired, latest, delta).Return(nil, errors.New("PutPolicy failed")).Run(
+				func(args mock.Arguments) {
+					saga := acksaga.FromContext(args.Get(0).(context.Context))
+					saga.Step("PutLogging", func(ctx context.Context) error {
+						if test.compensateErr != nil {
+							return test.compensateErr
+						}
+						compensated = append(compensated, "PutLogging")
+						return nil
+					})
+				},
+			)
+			compensator := &ackmocks.AWSResourceUpdateCompensator{}
+			compensator.On("CompensatesUpdates", latest).Return(true)
+			rm := &compensatingResourceManager{baseRM, compensator}
+
+			rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+			rd.On("IsManaged", desired).Return(true)
+			rd.On("Delta", desired, latest).Return(delta)
+
+			r, _, scmd := reconcilerMocks(rmf)
+			baseRM.On("EnsureTags", ctx, desired, scmd).Return(nil)
+
+			_, err := r.Sync(ctx, rm, desired)
+			require.EqualError(err, "PutPolicy failed")
+			require.NotNil(rolledBack)
+			require.Equal(test.expectedStatus, rolledBack.Status)
+			require.Equal("PutPolicy failed", *rolledBack.Reason)
+			if test.compensateErr == nil {
+				require.Equal([]string{"PutLogging"}, compensated)
+				require.Contains(*rolledBack.Message, "PutLogging")
+			} else {
+				require.Empty(compensated)
+				require.Contains(*rolledBack.Message, "PutLogging (throttled)")
+			}
+		})
+	}
+}
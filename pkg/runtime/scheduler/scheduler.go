@@ -0,0 +1,277 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package scheduler contains a scheduler of the operations deferred by the
+// reconcilers, e.g. the end of a maintenance window, that do not fit in a
+// single requeue delay.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Operation is the name of a deferred operation, e.g. "maintenance-window"
+type Operation string
+
+// Key identifies an operation deferred for a resource
+type Key struct {
+	// Namespace is the namespace of the resource
+	Namespace string
+	// Name is the name of the resource
+	Name string
+	// Operation is the deferred operation
+	Operation Operation
+}
+
+// item is a scheduled operation
+type item struct {
+	key   Key
+	at    time.Time
+	index int
+}
+
+// items is a min-heap of scheduled operations, ordered by due time
+type items []*item
+
+func (h items) Len() int           { return len(h) }
+func (h items) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h items) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *items) Push(x interface{}) {
+	it := x.(*item)
+	it.index = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *items) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return it
+}
+
+// Scheduler keeps the operations deferred for resources and fires them once
+// they are due. Scheduling an operation again replaces its due time. The
+// Scheduler is in memory only: reconcilers persist the deferred operations on
+// the resources, with Encode, and schedule them again after a leader change.
+type Scheduler struct {
+	sync.Mutex
+	items items
+	index map[Key]*item
+	// wake is signalled when the earliest due time may have changed
+	wake chan struct{}
+}
+
+// New returns an empty Scheduler
+func New() *Scheduler {
+	return &Scheduler{
+		index: map[Key]*item{},
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Schedule schedules the supplied operation at the supplied time, replacing
+// its previous due time if it was already scheduled.
+func (s *Scheduler) Schedule(key Key, at time.Time) {
+	s.Lock()
+	if it, ok := s.index[key]; ok {
+		it.at = at
+		heap.Fix(&s.items, it.index)
+	} else {
+		it = &item{key: key, at: at}
+		heap.Push(&s.items, it)
+		s.index[key] = it
+	}
+	s.Unlock()
+	s.signal()
+}
+
+// Cancel removes the supplied operation from the schedule
+func (s *Scheduler) Cancel(key Key) {
+	s.Lock()
+	defer s.Unlock()
+	if it, ok := s.index[key]; ok {
+		heap.Remove(&s.items, it.index)
+		delete(s.index, key)
+	}
+}
+
+// CancelAll removes all the operations of the supplied resource from the
+// schedule
+func (s *Scheduler) CancelAll(namespace, name string) {
+	s.Lock()
+	defer s.Unlock()
+	for key, it := range s.index {
+		if key.Namespace == namespace && key.Name == name {
+			heap.Remove(&s.items, it.index)
+			delete(s.index, key)
+		}
+	}
+}
+
+// Scheduled returns the due time of the supplied operation, and whether it is
+// scheduled
+func (s *Scheduler) Scheduled(key Key) (time.Time, bool) {
+	s.Lock()
+	defer s.Unlock()
+	if it, ok := s.index[key]; ok {
+		return it.at, true
+	}
+	return time.Time{}, false
+}
+
+// ScheduledFor returns the due times of the operations of the supplied
+// resource
+func (s *Scheduler) ScheduledFor(namespace, name string) map[Operation]time.Time {
+	s.Lock()
+	defer s.Unlock()
+	scheduled := map[Operation]time.Time{}
+	for key, it := range s.index {
+		if key.Namespace == namespace && key.Name == name {
+			scheduled[key.Operation] = it.at
+		}
+	}
+	return scheduled
+}
+
+// Entry is a scheduled operation and its due time
+type Entry struct {
+	Key
+	// At is the due time of the operation
+	At time.Time
+}
+
+// Entries returns all the scheduled operations, in due time order
+func (s *Scheduler) Entries() []Entry {
+	s.Lock()
+	defer s.Unlock()
+	entries := make([]Entry, 0, len(s.items))
+	for _, it := range s.items {
+		entries = append(entries, Entry{Key: it.key, At: it.at})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+	return entries
+}
+
+// Len returns the number of scheduled operations
+func (s *Scheduler) Len() int {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.items)
+}
+
+// PopDue removes from the schedule, and returns in due time order, the
+// operations due at the supplied time
+func (s *Scheduler) PopDue(now time.Time) []Key {
+	s.Lock()
+	defer s.Unlock()
+	due := []Key{}
+	for len(s.items) > 0 && !s.items[0].at.After(now) {
+		it := heap.Pop(&s.items).(*item)
+		delete(s.index, it.key)
+		due = append(due, it.key)
+	}
+	return due
+}
+
+// next returns the earliest due time, and whether any operation is scheduled
+func (s *Scheduler) next() (time.Time, bool) {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.items) == 0 {
+		return time.Time{}, false
+	}
+	return s.items[0].at, true
+}
+
+// signal wakes up Run without blocking
+func (s *Scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run calls fire for each operation once it is due, until the supplied
+// context is done. It is meant to be run as a controller-runtime
+// manager.Runnable, so that operations are only fired by the leader.
+func (s *Scheduler) Run(ctx context.Context, fire func(Key)) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		for _, key := range s.PopDue(time.Now()) {
+			fire(key)
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var timerC <-chan time.Time
+		if at, ok := s.next(); ok {
+			timer.Reset(time.Until(at))
+			timerC = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.wake:
+		case <-timerC:
+		}
+	}
+}
+
+// Encode returns the value of the deferred operations annotation of a
+// resource with the supplied operations
+func Encode(operations map[Operation]time.Time) string {
+	values := make(map[Operation]string, len(operations))
+	for op, at := range operations {
+		values[op] = at.UTC().Format(time.RFC3339)
+	}
+	// Marshaling a map of strings cannot fail
+	js, _ := json.Marshal(values)
+	return string(js)
+}
+
+// Decode parses the value of the deferred operations annotation of a
+// resource
+func Decode(value string) (map[Operation]time.Time, error) {
+	values := map[Operation]string{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("invalid deferred operations %q: %v", value, err)
+	}
+	operations := make(map[Operation]time.Time, len(values))
+	for op, v := range values {
+		at, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due time of deferred operation %q: %v", op, err)
+		}
+		operations[op] = at
+	}
+	return operations, nil
+}
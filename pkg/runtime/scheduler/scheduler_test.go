@@ -0,0 +1,108 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/scheduler"
+)
+
+func TestScheduler(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	a := scheduler.Key{Namespace: "ns", Name: "a", Operation: "maintenance-window"}
+	b := scheduler.Key{Namespace: "ns", Name: "b", Operation: "maintenance-window"}
+	c := scheduler.Key{Namespace: "ns", Name: "a", Operation: "soft-delete-expiry"}
+
+	s := scheduler.New()
+	s.Schedule(a, now.Add(3*time.Minute))
+	s.Schedule(b, now.Add(time.Minute))
+	s.Schedule(c, now.Add(2*time.Minute))
+	require.Equal(3, s.Len())
+
+	// Scheduling an operation again replaces its due time
+	s.Schedule(a, now.Add(30*time.Second))
+	at, ok := s.Scheduled(a)
+	require.True(ok)
+	require.Equal(now.Add(30*time.Second), at)
+	require.Equal(3, s.Len())
+	require.Equal(map[scheduler.Operation]time.Time{
+		"maintenance-window": now.Add(30 * time.Second),
+		"soft-delete-expiry": now.Add(2 * time.Minute),
+	}, s.ScheduledFor("ns", "a"))
+	require.Equal([]scheduler.Entry{
+		{Key: a, At: now.Add(30 * time.Second)},
+		{Key: b, At: now.Add(time.Minute)},
+		{Key: c, At: now.Add(2 * time.Minute)},
+	}, s.Entries())
+
+	require.Empty(s.PopDue(now))
+	require.Equal([]scheduler.Key{a, b}, s.PopDue(now.Add(time.Minute)))
+	_, ok = s.Scheduled(a)
+	require.False(ok)
+
+	s.Cancel(c)
+	require.Equal(0, s.Len())
+
+	s.Schedule(a, now)
+	s.Schedule(b, now)
+	s.Schedule(c, now)
+	s.CancelAll("ns", "a")
+	require.Equal([]scheduler.Key{b}, s.PopDue(now))
+}
+
+func TestSchedulerRun(t *testing.T) {
+	require := require.New(t)
+
+	s := scheduler.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fired := make(chan scheduler.Key, 2)
+	done := make(chan error)
+	go func() { done <- s.Run(ctx, func(key scheduler.Key) { fired <- key }) }()
+
+	// Operations scheduled while Run waits are fired once due
+	later := scheduler.Key{Name: "later", Operation: "op"}
+	sooner := scheduler.Key{Name: "sooner", Operation: "op"}
+	s.Schedule(later, time.Now().Add(200*time.Millisecond))
+	s.Schedule(sooner, time.Now().Add(50*time.Millisecond))
+	require.Equal(sooner, <-fired)
+	require.Equal(later, <-fired)
+
+	cancel()
+	require.Nil(<-done)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	require := require.New(t)
+
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	value := scheduler.Encode(map[scheduler.Operation]time.Time{"maintenance-window": at})
+	require.Equal(`{"maintenance-window":"2024-06-01T12:00:00Z"}`, value)
+
+	operations, err := scheduler.Decode(value)
+	require.Nil(err)
+	require.True(at.Equal(operations["maintenance-window"]))
+
+	_, err = scheduler.Decode("oops")
+	require.NotNil(err)
+	_, err = scheduler.Decode(`{"op":"tomorrow"}`)
+	require.NotNil(err)
+}
@@ -0,0 +1,94 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// VerifyResourceDescriptor checks that the desired state construction and
+// comparison of a resource descriptor are consistent, returning the
+// discrepancies found. An empty resource, defaulted with the supplied scheme
+// if not nil, is compared with a deep copy of itself and with the resource
+// built back from its unstructured representation. Both comparisons must
+// return no differences: any difference is a mismatch between the generated
+// code and the runtime that would surface as a perpetual diff, and a
+// perpetual update, of every resource of the kind.
+func VerifyResourceDescriptor(
+	scheme *k8sruntime.Scheme,
+	rd acktypes.AWSResourceDescriptor,
+) (discrepancies []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			discrepancies = append(discrepancies, fmt.Sprintf("verification panicked: %v", r))
+		}
+	}()
+
+	obj := rd.EmptyRuntimeObject()
+	if scheme != nil {
+		scheme.Default(obj)
+	}
+	res := rd.ResourceFromRuntimeObject(obj)
+
+	if delta := rd.Delta(res, res.DeepCopy()); len(delta.Differences) > 0 {
+		discrepancies = append(discrepancies, fmt.Sprintf(
+			"a copy of the resource differs from the resource at %s", deltaPaths(delta),
+		))
+	}
+
+	u, err := UnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return append(discrepancies, fmt.Sprintf(
+			"unable to convert the resource to unstructured: %v", err,
+		))
+	}
+	roundTripped := rd.EmptyRuntimeObject()
+	if err := UnstructuredConverter.FromUnstructured(u, roundTripped); err != nil {
+		return append(discrepancies, fmt.Sprintf(
+			"unable to convert the resource from unstructured: %v", err,
+		))
+	}
+	delta := rd.Delta(res, rd.ResourceFromRuntimeObject(roundTripped))
+	if len(delta.Differences) > 0 {
+		discrepancies = append(discrepancies, fmt.Sprintf(
+			"the resource round-tripped through unstructured differs from the resource at %s",
+			deltaPaths(delta),
+		))
+	}
+	return discrepancies
+}
+
+// selfCheck logs the discrepancies found by VerifyResourceDescriptor for the
+// supplied resource descriptor
+func (c *serviceController) selfCheck(
+	mgr ctrlrt.Manager,
+	rd acktypes.AWSResourceDescriptor,
+) {
+	kind := rd.GroupVersionKind().Kind
+	discrepancies := VerifyResourceDescriptor(mgr.GetScheme(), rd)
+	for _, discrepancy := range discrepancies {
+		c.log.Info(
+			"self-check found a generator/runtime mismatch",
+			"kind", kind, "discrepancy", discrepancy,
+		)
+	}
+	if len(discrepancies) == 0 {
+		c.log.V(1).Info("self-check passed", "kind", kind)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	mocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+)
+
+func selfCheckResourceDescriptor(delta *ackcompare.Delta) *mocks.AWSResourceDescriptor {
+	res := &mocks.AWSResource{}
+	res.On("DeepCopy").Return(res)
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("EmptyRuntimeObject").Return(&fakeBook{})
+	rd.On("ResourceFromRuntimeObject", mock.Anything).Return(res)
+	rd.On("Delta", res, res).Return(delta)
+	return rd
+}
+
+func TestVerifyResourceDescriptor(t *testing.T) {
+	require := require.New(t)
+	// Other tests replace the converter with a mock
+	conv := ackrt.UnstructuredConverter
+	ackrt.UnstructuredConverter = k8sruntime.DefaultUnstructuredConverter
+	defer func() { ackrt.UnstructuredConverter = conv }()
+
+	rd := selfCheckResourceDescriptor(ackcompare.NewDelta())
+	require.Empty(ackrt.VerifyResourceDescriptor(scheme, rd))
+	rd.AssertNumberOfCalls(t, "Delta", 2)
+
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.Name", nil, "")
+	rd = selfCheckResourceDescriptor(delta)
+	discrepancies := ackrt.VerifyResourceDescriptor(nil, rd)
+	require.Len(discrepancies, 2)
+	require.Contains(discrepancies[0], "a copy of the resource differs from the resource at Spec.Name")
+	require.Contains(discrepancies[1], "round-tripped through unstructured differs from the resource at Spec.Name")
+
+	rd = &mocks.AWSResourceDescriptor{}
+	rd.On("EmptyRuntimeObject").Return(&fakeBook{})
+	rd.On("ResourceFromRuntimeObject", mock.Anything).Return(nil)
+	rd.On("Delta", mock.Anything, mock.Anything).Return(nil)
+	discrepancies = ackrt.VerifyResourceDescriptor(nil, rd)
+	require.Len(discrepancies, 1)
+	require.Contains(discrepancies[0], "verification panicked")
+}
@@ -15,26 +15,38 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	kubernetes "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
 	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
 	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/namespacestatus"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/rolecreds"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/smoketest"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
+	ackwebhook "github.com/aws-controllers-k8s/runtime/pkg/webhook"
 )
 
 const (
@@ -78,6 +90,26 @@ type serviceController struct {
 	// metrics contains a collection of Prometheus metric objects that the
 	// service controller and its reconcilers track
 	metrics *ackmetrics.Metrics
+	// awsConfigOptions are the options applied to the AWS SDK
+	// configurations of the service clients, set in `BindControllerManager`
+	awsConfigOptions []func(*config.LoadOptions) error
+	// httpTransport is the transport of the HTTP clients of the AWS SDK,
+	// nil for the default transport. It is set in `BindControllerManager`
+	// when a proxy or a CA bundle is configured.
+	httpTransport http.RoundTripper
+	// shared is what the service controller shares with the other service
+	// controllers bound to the same manager. It is set in
+	// `BindControllerManager`.
+	shared *sharedBinding
+	// roleCredentials holds the credentials of the IAM roles assumed by the
+	// service controller, per region and endpoint URL, nil if they are not
+	// shared between the reconciliations. It is set in
+	// `BindControllerManager`.
+	roleCredentials *rolecreds.Cache
+	// reconcileHooks are the hooks called by the reconcilers around the
+	// creations, updates and deletions of the AWS resources, in the order of
+	// their registration
+	reconcileHooks []acktypes.ReconcileHook
 }
 
 // GetReconcilers returns a slice of types.AWSResourceReconcilers associated
@@ -152,6 +184,12 @@ func (c *serviceController) GetFieldExportInstalled(mgr ctrlrt.Manager) (bool, e
 	return c.getResourceInstalled(mgr, "fieldexports")
 }
 
+// GetAccountConfigInstalled returns whether the AccountConfig CRD has been
+// installed into the cluster, and is accessible by the service controller.
+func (c *serviceController) GetAccountConfigInstalled(mgr ctrlrt.Manager) (bool, error) {
+	return c.getResourceInstalled(mgr, "accountconfigs")
+}
+
 // WithLogger sets up the service controller with the supplied logger
 func (c *serviceController) WithLogger(log logr.Logger) acktypes.ServiceController {
 	c.log = log
@@ -159,7 +197,9 @@ func (c *serviceController) WithLogger(log logr.Logger) acktypes.ServiceControll
 }
 
 // WithPrometheusRegistry registers all ACK service controller metrics with the
-// supplied prometheus Registry
+// supplied prometheus Registry. The metrics being labeled with the service,
+// their collectors are shared by the service controllers compiled into the
+// same binary, and registered once.
 func (c *serviceController) WithPrometheusRegistry(
 	reg prometheus.Registerer,
 ) acktypes.ServiceController {
@@ -167,7 +207,13 @@ func (c *serviceController) WithPrometheusRegistry(
 		return c
 	}
 	for _, collector := range c.metrics.Collectors() {
-		reg.MustRegister(collector)
+		if err := reg.Register(collector); err != nil {
+			var registered prometheus.AlreadyRegisteredError
+			if errors.As(err, &registered) && registered.ExistingCollector == collector {
+				continue
+			}
+			panic(err)
+		}
 	}
 	return c
 }
@@ -193,53 +239,150 @@ func (c *serviceController) WithResourceManagerFactories(
 	return c
 }
 
+// WithReconcileHooks appends the supplied hooks to the ones called by the
+// reconcilers around the creations, updates and deletions of the AWS
+// resources
+func (c *serviceController) WithReconcileHooks(
+	hooks ...acktypes.ReconcileHook,
+) acktypes.ServiceController {
+	c.metaLock.Lock()
+	defer c.metaLock.Unlock()
+	c.reconcileHooks = append(c.reconcileHooks, hooks...)
+	return c
+}
+
+// getReconcileHooks returns a copy of the reconcile hooks of the service
+// controller
+func (c *serviceController) getReconcileHooks() []acktypes.ReconcileHook {
+	c.metaLock.RLock()
+	defer c.metaLock.RUnlock()
+	return append([]acktypes.ReconcileHook(nil), c.reconcileHooks...)
+}
+
 // BindControllerManager takes a `controller-runtime.Manager`, creates all the
 // AWSResourceReconcilers needed for the service and binds all of the
 // reconcilers within the service controller with that manager. The adoption
 // reconciler will only be started if the types have been registered in the
 // cluster.
+//
+// Several service controllers may be bound to the same manager, e.g. when
+// compiled into the same binary. They then share the ACK caches and the
+// process-wide runnables, like the admin service, and each of them gets the
+// feature gates prefixed with its service alias.
 func (c *serviceController) BindControllerManager(mgr ctrlrt.Manager, cfg ackcfg.Config) error {
 	c.metaLock.Lock()
 	defer c.metaLock.Unlock()
 
+	cfg = cfg.ForService(c.ServiceAlias)
+	shared := sharedBindingFor(mgr)
+	shared.bind(c)
+
 	namespaces, err := cfg.GetWatchNamespaces()
 	if err != nil {
 		return fmt.Errorf("unable to get watch namespaces: %v", err)
 	}
 
-	cache := ackrtcache.New(c.log, ackrtcache.Config{
-		WatchScope: namespaces,
-		// Default to ignoring the kube-system, kube-public, and
-		// kube-node-lease namespaces.
-		// NOTE: Maybe we should make this configurable? It's not clear that
-		// we'd ever want to watch these namespaces.
-		Ignored: []string{
-			NamespaceKubeSystem,
-			NamespaceKubePublic,
-			NamespaceKubeNodeLease,
-		}},
-		cfg.FeatureGates,
-	)
-	// We want to run the caches if the length of the namespaces slice is
-	// either 0 (watching all namespaces) or greater than 1 (watching multiple
-	// namespaces).
-	//
-	// The caches are only used for cross account resource management. If the
-	// controller is not configured to watch multiple namespaces, then we don't
-	// need to run the caches.
-	if len(namespaces) == 0 || len(namespaces) >= 2 {
-		clusterConfig := mgr.GetConfig()
-		clientSet, err := kubernetes.NewForConfig(clusterConfig)
-		if err != nil {
-			return err
+	c.awsConfigOptions = awsRetryOptions(cfg)
+	transport, err := cfg.HTTPTransport()
+	if err != nil {
+		return err
+	}
+	if transport != nil {
+		c.httpTransport = transport
+	}
+
+	// The reconcilers get the tracer of the service controllers when they
+	// are bound, hence it is set up first
+	if cfg.TracingEndpoint != "" && shared.claim("tracing") {
+		if err := c.addTracing(mgr, cfg); err != nil {
+			return fmt.Errorf("unable to set up tracing: %v", err)
 		}
-		// Run the caches. This will not block as the caches are run in
-		// separate goroutines.
-		cache.Run(clientSet)
-		// Wait for the caches to sync
-		ctx := context.TODO()
-		synced := cache.WaitForCachesToSync(ctx)
-		c.log.Info("Waited for the caches to sync", "synced", synced)
+	}
+	// So is the auditor
+	if cfg.AuditLogSink != "" && shared.claim("audit") {
+		if err := c.addAuditor(mgr, cfg); err != nil {
+			return fmt.Errorf("unable to set up auditing: %v", err)
+		}
+	}
+
+	relabelCfg, err := cfg.MetricsRelabelConfig()
+	if err != nil {
+		return err
+	}
+	if c.metrics != nil {
+		c.metrics.WithRelabeler(ackmetrics.NewRelabeler(relabelCfg))
+	}
+
+	cache, err := shared.cachesFor(func() (ackrtcache.Caches, error) {
+		cache := ackrtcache.New(c.log, ackrtcache.Config{
+			WatchScope: namespaces,
+			// Default to ignoring the kube-system, kube-public, and
+			// kube-node-lease namespaces.
+			// NOTE: Maybe we should make this configurable? It's not clear that
+			// we'd ever want to watch these namespaces.
+			Ignored: []string{
+				NamespaceKubeSystem,
+				NamespaceKubePublic,
+				NamespaceKubeNodeLease,
+			}},
+			cfg.FeatureGates,
+		)
+		// We want to run the caches if the length of the namespaces slice is
+		// either 0 (watching all namespaces) or greater than 1 (watching multiple
+		// namespaces).
+		//
+		// The caches are only used for cross account resource management. If the
+		// controller is not configured to watch multiple namespaces, then we don't
+		// need to run the caches.
+		if len(namespaces) == 0 || len(namespaces) >= 2 {
+			clusterConfig := mgr.GetConfig()
+			clientSet, err := kubernetes.NewForConfig(clusterConfig)
+			if err != nil {
+				return cache, err
+			}
+			// Run the caches. This will not block as the caches are run in
+			// separate goroutines.
+			cache.Run(clientSet)
+			// Run the AccountConfigs cache too, if the AccountConfig CRD is
+			// installed, so that the bindings are reloaded as they change.
+			if installed, err := c.GetAccountConfigInstalled(mgr); err != nil {
+				c.log.Error(err, "unable to determine if the AccountConfig CRD is installed in the cluster")
+			} else if installed {
+				dynamicClient, err := dynamic.NewForConfig(clusterConfig)
+				if err != nil {
+					return cache, err
+				}
+				cache.RunAccountConfigs(dynamicClient)
+			}
+			// Wait for the caches to sync
+			ctx := context.TODO()
+			synced := cache.WaitForCachesToSync(ctx)
+			c.log.Info("Waited for the caches to sync", "synced", synced)
+		}
+		return cache, nil
+	})
+	if err != nil {
+		return err
+	}
+	// The AccountConfigs are validated once per manager
+	if cache.AccountConfigs.Running() && shared.claim("account-config") {
+		if err := c.addAccountConfigValidator(mgr, cfg); err != nil {
+			return fmt.Errorf("unable to set up the account config validator: %v", err)
+		}
+	}
+	if len(namespaces) == 0 || len(namespaces) >= 2 {
+		shared.useInformers(
+			c.ServiceAlias,
+			corev1.SchemeGroupVersion.WithKind("Namespace").String(),
+			corev1.SchemeGroupVersion.WithKind("ConfigMap").String(),
+		)
+	}
+	if cfg.FeatureGates.IsEnabled(featuregate.ReferenceCaching) {
+		cache.References = shared.referenceCacheFor(func() *ackrtcache.ReferenceCache {
+			return ackrtcache.NewReferenceCache(
+				c.log, mgr.GetCache(), mgr.GetScheme(),
+			)
+		})
 	}
 
 	if cfg.EnableAdoptedResourceReconciler {
@@ -255,12 +398,16 @@ func (c *serviceController) BindControllerManager(mgr ctrlrt.Manager, cfg ackcfg
 				return err
 			}
 			c.adoptionReconciler = rec
+			shared.useInformers(
+				c.ServiceAlias,
+				ackv1alpha1.GroupVersion.WithKind("AdoptedResource").String(),
+			)
 		}
 	}
 
 	exporterInstalled := false
 	exporterLogger := c.log.WithName("exporter")
-	
+
 	if cfg.EnableFieldExportReconciler {
 		exporterInstalled, err := c.GetFieldExportInstalled(mgr)
 		if err != nil {
@@ -273,6 +420,10 @@ func (c *serviceController) BindControllerManager(mgr ctrlrt.Manager, cfg ackcfg
 				return err
 			}
 			c.fieldExportReconciler = rec
+			shared.useInformers(
+				c.ServiceAlias,
+				ackv1alpha1.GroupVersion.WithKind("FieldExport").String(),
+			)
 		}
 	}
 
@@ -302,12 +453,43 @@ func (c *serviceController) BindControllerManager(mgr ctrlrt.Manager, cfg ackcfg
 		}
 	}
 
+	if cfg.EnableWebhookServer && shared.claim("webhook-cert-manager") {
+		if err := c.addWebhookCertManager(mgr, cfg); err != nil {
+			return fmt.Errorf("unable to set up the webhook certificate: %v", err)
+		}
+	}
+
+	encrypter, err := c.newFieldEncrypter(context.TODO(), cfg)
+	if err != nil {
+		return fmt.Errorf("unable to set up field encryption: %v", err)
+	}
+
 	for _, rmf := range filteredRMFs {
+		if cfg.SelfCheck {
+			c.selfCheck(mgr, rmf.ResourceDescriptor())
+		}
 		rec := NewReconciler(c, rmf, c.log, cfg, c.metrics, cache)
+		if r, ok := rec.(*resourceReconciler); ok {
+			r.encrypter = encrypter
+		}
 		if err := rec.BindControllerManager(mgr); err != nil {
 			return err
 		}
 		c.reconcilers = append(c.reconcilers, rec)
+		shared.useInformers(c.ServiceAlias, rmf.ResourceDescriptor().GroupVersionKind().String())
+
+		if cfg.EnableWebhookServer {
+			if wh := ackwebhook.NewSpecChangeWebhook(rmf); wh != nil {
+				if err := wh.Setup(mgr); err != nil {
+					return fmt.Errorf("unable to set up webhook %s: %v", wh.UID(), err)
+				}
+			}
+			if wh := ackwebhook.NewFieldEncryptionWebhook(rmf, encrypter); wh != nil {
+				if err := wh.Setup(mgr); err != nil {
+					return fmt.Errorf("unable to set up webhook %s: %v", wh.UID(), err)
+				}
+			}
+		}
 
 		if cfg.EnableFieldExportReconciler && exporterInstalled {
 			rd := rmf.ResourceDescriptor()
@@ -319,6 +501,89 @@ func (c *serviceController) BindControllerManager(mgr ctrlrt.Manager, cfg ackcfg
 		}
 	}
 
+	if cfg.AdminBindAddress != "" {
+		if err := c.addAdminServer(mgr, cfg, cache); err != nil {
+			return fmt.Errorf("unable to set up the admin service: %v", err)
+		}
+	}
+
+	if cfg.WarmStandby {
+		if err := c.addWarmStandby(mgr, cfg, cache); err != nil {
+			return fmt.Errorf("unable to set up the warm standby: %v", err)
+		}
+	}
+
+	sloEvaluator, err := c.addSLOEvaluator(mgr, cfg)
+	if err != nil {
+		return fmt.Errorf("unable to set up the SLO evaluator: %v", err)
+	}
+
+	quotaMonitor, err := c.addQuotaMonitor(mgr, cfg, filteredRMFs)
+	if err != nil {
+		return fmt.Errorf("unable to set up the service quota monitor: %v", err)
+	}
+
+	if cfg.ControllerConfig != "" {
+		if err := c.addControllerConfigWatcher(mgr, cfg, sloEvaluator, quotaMonitor); err != nil {
+			return fmt.Errorf("unable to set up the controller config watcher: %v", err)
+		}
+	}
+
+	if cfg.StateDumpSignal && shared.claim("state-dumper") {
+		if err := c.addStateDumper(mgr, cfg.StateDumpDirectory, cfg.StateDumpRetention, cache); err != nil {
+			return fmt.Errorf("unable to set up the state dump: %v", err)
+		}
+	}
+
+	if mode, _ := namespacestatus.ParseMode(cfg.NamespaceStatus); mode != namespacestatus.ModeDisabled {
+		gvks := make([]schema.GroupVersionKind, 0, len(filteredRMFs))
+		for _, rmf := range filteredRMFs {
+			gvks = append(gvks, rmf.ResourceDescriptor().GroupVersionKind())
+		}
+		name := "ack-" + c.ServiceAlias + "-status"
+		if mode == namespacestatus.ModeAnnotation {
+			name = ackv1alpha1.AnnotationPrefix + c.ServiceAlias + "-status"
+		}
+		writer := namespacestatus.NewWriter(
+			c.log.WithName("namespace-status"), mgr.GetClient(), mgr.GetAPIReader(),
+			mode, name, time.Duration(cfg.NamespaceStatusIntervalSeconds)*time.Second, gvks,
+		)
+		if err := mgr.Add(writer); err != nil {
+			return err
+		}
+	}
+
+	if cfg.KubeStateMetricsConfigFile != "" {
+		if err := writeKubeStateMetricsConfig(cfg.KubeStateMetricsConfigFile, filteredRMFs); err != nil {
+			return fmt.Errorf("unable to write the kube-state-metrics configuration: %v", err)
+		}
+	}
+
+	if cfg.InventoryDestination != "" {
+		if err := c.addInventoryExporter(mgr, cfg, filteredRMFs); err != nil {
+			return fmt.Errorf("unable to set up the inventory exporter: %v", err)
+		}
+	}
+
+	if cfg.SmokeTest {
+		template, err := smoketest.LoadTemplate(cfg.SmokeTestTemplate)
+		if err != nil {
+			return err
+		}
+		runner := smoketest.NewRunner(
+			c.log.WithName("smoke-test"), mgr.GetClient(), c.metrics, template,
+			time.Duration(cfg.SmokeTestIntervalSeconds)*time.Second,
+			time.Duration(cfg.SmokeTestTimeoutSeconds)*time.Second,
+			client.ObjectKey{
+				Namespace: ackrtcache.SystemNamespace(),
+				Name:      "ack-" + c.ServiceAlias + "-controller-status",
+			},
+		).WithRetention(time.Duration(cfg.SmokeTestRetentionSeconds) * time.Second)
+		if err := mgr.Add(runner); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -335,9 +600,9 @@ func NewServiceController(
 ) acktypes.ServiceController {
 	return &serviceController{
 		ServiceControllerMetadata: acktypes.ServiceControllerMetadata{
-			VersionInfo:        versionInfo,
-			ServiceAlias:       svcAlias,
-			ServiceAPIGroup:    svcAPIGroup,
+			VersionInfo:     versionInfo,
+			ServiceAlias:    svcAlias,
+			ServiceAPIGroup: svcAPIGroup,
 		},
 		metrics: ackmetrics.NewMetrics(svcAlias),
 	}
@@ -15,12 +15,15 @@ package runtime_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,7 +39,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	k8sscheme "sigs.k8s.io/controller-runtime/pkg/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
@@ -53,7 +58,7 @@ var (
 func init() {
 	groupVersion := schema.GroupVersion{Group: "bookstore.services.k8s.aws", Version: "v1alpha1"}
 	schemeBuilder := &k8sscheme.Builder{GroupVersion: groupVersion}
-	schemeBuilder.Register(&fakeBook{})
+	schemeBuilder.Register(&fakeBook{}, &watchedBook{})
 
 	_ = schemeBuilder.AddToScheme(scheme)
 	_ = clientgoscheme.AddToScheme(scheme)
@@ -100,6 +105,9 @@ func (b *fakeBook) DeepCopy() *fakeBook              { return nil }
 func (b *fakeBook) DeepCopyInto(*fakeBook)           {}
 func (b *fakeBook) DeepCopyObject() runtime.Object   { return nil }
 
+// watchedBook is a fakeBook whose controller has additional watch sources
+type watchedBook struct{ fakeBook }
+
 type fakeManager struct{}
 
 func (m *fakeManager) GetLogger() logr.Logger {
@@ -195,3 +203,79 @@ func TestServiceController(t *testing.T) {
 	require.True(foundfakeBookRecon)
 	rd.AssertCalled(t, "EmptyRuntimeObject")
 }
+
+// watchingFactory is a resource manager factory supplying additional watch
+// sources to the controller of its resource kind
+type watchingFactory struct {
+	*mocks.AWSResourceManagerFactory
+	*mocks.AWSResourceWatchSourceProvider
+}
+
+func TestServiceController_WatchSources(t *testing.T) {
+	require := require.New(t)
+
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("GroupVersionKind").Return(
+		schema.GroupVersionKind{
+			Group: "bookstore.services.k8s.aws",
+			Kind:  "watchedBook",
+		},
+	)
+	rd.On("EmptyRuntimeObject").Return(
+		&watchedBook{},
+	)
+
+	mgr := &fakeManager{}
+	rmf := &watchingFactory{
+		AWSResourceManagerFactory:      &mocks.AWSResourceManagerFactory{},
+		AWSResourceWatchSourceProvider: &mocks.AWSResourceWatchSourceProvider{},
+	}
+	rmf.AWSResourceManagerFactory.On("ResourceDescriptor").Return(rd)
+	rmf.AWSResourceManagerFactory.On("RequeueOnSuccessSeconds").Return(0)
+	rmf.AWSResourceWatchSourceProvider.On("WatchSources", mgr).Return(
+		[]source.Source{ackrt.NewWatchSource(mgr, &corev1.ConfigMap{}, func(context.Context, client.Object) []reconcile.Request {
+			return nil
+		})}, nil,
+	).Once()
+	rmf.AWSResourceWatchSourceProvider.On("WatchSources", mgr).Return(
+		nil, errors.New("oops"),
+	).Once()
+
+	reg := ackrt.NewRegistry()
+	reg.RegisterResourceManagerFactory(rmf)
+	newServiceController := func() acktypes.ServiceController {
+		sc := ackrt.NewServiceController("bookstore", "bookstore.services.k8s.aws", acktypes.VersionInfo{})
+		sc.WithLogger(logr.New(log.NullLogSink{}))
+		sc.WithResourceManagerFactories(reg.GetResourceManagerFactories())
+		return sc
+	}
+	cfg := ackcfg.Config{
+		// Disable caches, by setting a mono-namespace watch mode
+		WatchNamespace: "default",
+	}
+
+	require.Nil(newServiceController().BindControllerManager(mgr, cfg))
+	err := newServiceController().BindControllerManager(mgr, cfg)
+	require.NotNil(err)
+	require.Contains(err.Error(), "unable to get the watch sources of watchedBook: oops")
+	rmf.AWSResourceWatchSourceProvider.AssertNumberOfCalls(t, "WatchSources", 2)
+}
+
+func TestServiceController_SharedManager(t *testing.T) {
+	require := require.New(t)
+
+	// Several service controllers compiled into the same binary register
+	// the same metric collectors, and are bound to the same manager
+	reg := prometheus.NewRegistry()
+	mgr := &fakeManager{}
+	cfg := ackcfg.Config{
+		// Disable caches, by setting a mono-namespace watch mode
+		WatchNamespace: "default",
+	}
+	for _, alias := range []string{"bookstore", "library"} {
+		sc := ackrt.NewServiceController(alias, alias+".services.k8s.aws", acktypes.VersionInfo{})
+		sc.WithLogger(logr.New(log.NullLogSink{}))
+		require.NotPanics(func() { sc.WithPrometheusRegistry(reg) })
+		require.Nil(sc.BindControllerManager(mgr, cfg))
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/admin"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/audit"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/circuitbreaker"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/ratelimit"
+	ackrtstatedump "github.com/aws-controllers-k8s/runtime/pkg/runtime/statedump"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/teardown"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/tracing"
+)
+
+// sharedBinding holds what the service controllers bound to the same
+// controller-runtime Manager share when several of them are compiled into the
+// same binary: the ACK caches, whose informers are then run once, and the
+// runnables serving the whole process, e.g. the admin service. It also
+// accounts for the informers of the manager used by each service controller.
+type sharedBinding struct {
+	sync.Mutex
+	// controllers are the service controllers bound to the manager, in the
+	// order they were bound
+	controllers []*serviceController
+	// caches are the caches created by the first service controller, nil
+	// until then
+	caches *ackrtcache.Caches
+	// adminServer is the admin service added by the first service
+	// controller enabling it, nil until then
+	adminServer *admin.Server
+	// claims are the names of the process-wide runnables and controllers
+	// already added to the manager
+	claims map[string]struct{}
+	// informers maps the informers of the manager to the aliases of the
+	// service controllers using them
+	informers map[string]map[string]struct{}
+	// rateLimiter rate limits the calls of all the service controllers to
+	// the AWS service APIs, nil until the first reconciler asks for it
+	rateLimiter *ratelimit.Limiter
+	// circuitBreakers are the circuit breakers of the calls to the AWS
+	// service APIs, keyed by service alias
+	circuitBreakers map[string]*circuitbreaker.Breaker
+	// tracer provides the tracer of the reconcilers, nil if tracing is
+	// disabled
+	tracer *tracing.Provider
+	// auditor writes the audit records of the changes of the AWS resources,
+	// nil if they are not audited
+	auditor *audit.Auditor
+	// deletions coordinates the deletions of the AWS resources of all the
+	// service controllers, nil until the first reconciler asks for it
+	deletions *teardown.Coordinator
+}
+
+var (
+	sharedBindingsLock sync.Mutex
+	// sharedBindings holds the shared binding of each manager
+	sharedBindings = map[ctrlrt.Manager]*sharedBinding{}
+)
+
+// sharedBindingFor returns the shared binding of the supplied manager
+func sharedBindingFor(mgr ctrlrt.Manager) *sharedBinding {
+	sharedBindingsLock.Lock()
+	defer sharedBindingsLock.Unlock()
+	b, ok := sharedBindings[mgr]
+	if !ok {
+		b = &sharedBinding{
+			claims:          map[string]struct{}{},
+			informers:       map[string]map[string]struct{}{},
+			circuitBreakers: map[string]*circuitbreaker.Breaker{},
+		}
+		sharedBindings[mgr] = b
+	}
+	return b
+}
+
+// bind records the binding of the supplied service controller to the manager
+func (b *sharedBinding) bind(c *serviceController) {
+	b.Lock()
+	defer b.Unlock()
+	b.controllers = append(b.controllers, c)
+	c.shared = b
+}
+
+// serviceControllers returns the service controllers bound to the manager.
+// A nil sharedBinding returns the supplied service controller only.
+func (b *sharedBinding) serviceControllers(c *serviceController) []*serviceController {
+	if b == nil {
+		return []*serviceController{c}
+	}
+	b.Lock()
+	defer b.Unlock()
+	return append([]*serviceController{}, b.controllers...)
+}
+
+// cachesFor returns the caches shared by the service controllers, created
+// with the supplied function by the first service controller asking for them,
+// hence with its feature gates
+func (b *sharedBinding) cachesFor(
+	newCaches func() (ackrtcache.Caches, error),
+) (ackrtcache.Caches, error) {
+	b.Lock()
+	defer b.Unlock()
+	if b.caches == nil {
+		caches, err := newCaches()
+		if err != nil {
+			return ackrtcache.Caches{}, err
+		}
+		b.caches = &caches
+	}
+	return *b.caches, nil
+}
+
+// referenceCacheFor returns the reference cache shared by the service
+// controllers enabling reference caching, created with the supplied function
+// by the first of them
+func (b *sharedBinding) referenceCacheFor(
+	newReferenceCache func() *ackrtcache.ReferenceCache,
+) *ackrtcache.ReferenceCache {
+	b.Lock()
+	defer b.Unlock()
+	if b.caches.References == nil {
+		b.caches.References = newReferenceCache()
+	}
+	return b.caches.References
+}
+
+// getAdminServer returns the admin service of the service controllers, nil
+// if none was added yet. A nil sharedBinding returns nil.
+func (b *sharedBinding) getAdminServer() *admin.Server {
+	if b == nil {
+		return nil
+	}
+	b.Lock()
+	defer b.Unlock()
+	return b.adminServer
+}
+
+// setAdminServer sets the admin service of the service controllers
+func (b *sharedBinding) setAdminServer(srv *admin.Server) {
+	if b == nil {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.adminServer = srv
+}
+
+// getTracer returns the tracer provider of the service controllers, nil if
+// tracing is disabled. A nil sharedBinding returns nil.
+func (b *sharedBinding) getTracer() *tracing.Provider {
+	if b == nil {
+		return nil
+	}
+	b.Lock()
+	defer b.Unlock()
+	return b.tracer
+}
+
+// setTracer sets the tracer provider of the service controllers
+func (b *sharedBinding) setTracer(tracer *tracing.Provider) {
+	if b == nil {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.tracer = tracer
+}
+
+// getAuditor returns the auditor of the service controllers, nil if the
+// changes of the AWS resources are not audited. A nil sharedBinding returns
+// nil.
+func (b *sharedBinding) getAuditor() *audit.Auditor {
+	if b == nil {
+		return nil
+	}
+	b.Lock()
+	defer b.Unlock()
+	return b.auditor
+}
+
+// setAuditor sets the auditor of the service controllers
+func (b *sharedBinding) setAuditor(auditor *audit.Auditor) {
+	if b == nil {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.auditor = auditor
+}
+
+// rateLimiterFor returns the rate limiter of the calls of the service
+// controllers to the AWS service APIs, created from the supplied
+// configuration by the first reconciler asking for it. It is nil if the
+// calls are not rate limited.
+func (b *sharedBinding) rateLimiterFor(cfg ackcfg.Config) *ratelimit.Limiter {
+	b.Lock()
+	defer b.Unlock()
+	if b.rateLimiter == nil {
+		b.rateLimiter = ratelimit.NewLimiter(cfg.AWSAPIRateLimit, cfg.GetAWSAPIRateLimitBurst())
+	}
+	return b.rateLimiter
+}
+
+// deletionCoordinatorFor returns the coordinator of the deletions of the AWS
+// resources of the service controllers, created from the supplied
+// configuration by the first reconciler asking for it
+func (b *sharedBinding) deletionCoordinatorFor(cfg ackcfg.Config) *teardown.Coordinator {
+	b.Lock()
+	defer b.Unlock()
+	if b.deletions == nil {
+		b.deletions = teardown.NewCoordinator(cfg.MaxConcurrentDeletions)
+	}
+	return b.deletions
+}
+
+// circuitBreakerFor returns the circuit breaker of the calls to the AWS
+// service APIs of the service controller with the supplied alias, created
+// from the supplied configuration by the first of its reconcilers asking for
+// it, and recording its trips in the supplied metrics. It is nil if the
+// circuit breakers are disabled.
+func (b *sharedBinding) circuitBreakerFor(
+	alias string,
+	cfg ackcfg.Config,
+	metrics *ackmetrics.Metrics,
+) *circuitbreaker.Breaker {
+	b.Lock()
+	defer b.Unlock()
+	breaker, ok := b.circuitBreakers[alias]
+	if !ok {
+		breaker = circuitbreaker.NewBreaker(
+			cfg.CircuitBreakerThreshold,
+			time.Duration(cfg.CircuitBreakerCoolDownSeconds)*time.Second,
+		)
+		if breaker != nil && metrics != nil {
+			breaker.OnTrip(func(key circuitbreaker.Key) {
+				metrics.RecordCircuitBreakerTrip(key.Account, key.Region)
+			})
+		}
+		b.circuitBreakers[alias] = breaker
+	}
+	return breaker
+}
+
+// claim returns true if the process-wide runnable with the supplied name was
+// not added to the manager yet, in which case the caller adds it
+func (b *sharedBinding) claim(name string) bool {
+	b.Lock()
+	defer b.Unlock()
+	if _, ok := b.claims[name]; ok {
+		return false
+	}
+	b.claims[name] = struct{}{}
+	return true
+}
+
+// controllerName returns the name of the controller of the supplied service
+// controller watching the kind shared by all the service controllers, e.g.
+// AdoptedResource. The controller of the first service controller keeps the
+// supplied name, while the others are suffixed with the service alias, the
+// controller names having to be unique within a manager. A nil
+// sharedBinding returns the supplied name.
+func (b *sharedBinding) controllerName(alias, name string) string {
+	if b == nil || b.claim("controller/"+name) {
+		return name
+	}
+	return name + "-" + alias
+}
+
+// useInformers records that the service controller with the supplied alias
+// uses the informers with the supplied names
+func (b *sharedBinding) useInformers(alias string, informers ...string) {
+	b.Lock()
+	defer b.Unlock()
+	for _, informer := range informers {
+		if b.informers[informer] == nil {
+			b.informers[informer] = map[string]struct{}{}
+		}
+		b.informers[informer][alias] = struct{}{}
+	}
+}
+
+// snapshot returns the accounting of what the service controllers share. A
+// nil sharedBinding returns nil.
+func (b *sharedBinding) snapshot() *ackrtstatedump.Shared {
+	if b == nil {
+		return nil
+	}
+	b.Lock()
+	defer b.Unlock()
+	shared := &ackrtstatedump.Shared{
+		Services:  make([]string, 0, len(b.controllers)),
+		Informers: make(map[string][]string, len(b.informers)),
+	}
+	for _, c := range b.controllers {
+		shared.Services = append(shared.Services, c.ServiceAlias)
+	}
+	for informer, aliases := range b.informers {
+		services := make([]string, 0, len(aliases))
+		for alias := range aliases {
+			services = append(services, alias)
+		}
+		sort.Strings(services)
+		shared.Informers[informer] = services
+	}
+	if teardowns := b.deletions.Snapshot(); len(teardowns) > 0 {
+		shared.Teardowns = teardowns
+	}
+	return shared
+}
@@ -0,0 +1,100 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtslo "github.com/aws-controllers-k8s/runtime/pkg/runtime/slo"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// sloEvaluationInterval is the interval at which the service level
+// objectives are evaluated
+const sloEvaluationInterval = time.Minute
+
+// sloObjectives returns the service level objectives of the --slo-* flags
+func sloObjectives(cfg ackcfg.Config) ackrtslo.Objectives {
+	return ackrtslo.Objectives{
+		Synced:     cfg.SLOSyncedObjective / 100,
+		TimeToSync: time.Duration(cfg.SLOTimeToSyncSeconds) * time.Second,
+	}
+}
+
+// newSLOTracker returns the tracker of the service level indicators of a
+// reconciler, nil if no objective is set
+func newSLOTracker(cfg ackcfg.Config) *ackrtslo.Tracker {
+	objectives := sloObjectives(cfg)
+	if objectives.Synced <= 0 && objectives.TimeToSync <= 0 {
+		return nil
+	}
+	return ackrtslo.NewTracker(time.Duration(cfg.SLOWindowSeconds) * time.Second)
+}
+
+// observeSLO records the sync state of the supplied resource at the end of a
+// reconciliation started at the supplied time. Resources being deleted are
+// not tracked anymore.
+func (r *resourceReconciler) observeSLO(
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	start time.Time,
+) {
+	if r.slo == nil {
+		return
+	}
+	res := desired
+	if ackcompare.IsNotNil(latest) {
+		res = latest
+	}
+	mo := res.MetaObject()
+	key := k8stypes.NamespacedName{Namespace: mo.GetNamespace(), Name: mo.GetName()}
+	if !mo.GetDeletionTimestamp().IsZero() {
+		r.slo.Forget(key)
+		return
+	}
+	synced := false
+	for _, c := range res.Conditions() {
+		if c.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			synced = c.Status == corev1.ConditionTrue
+		}
+	}
+	r.slo.Observe(key, mo.GetGeneration(), synced, start, time.Now())
+}
+
+// addSLOEvaluator adds to the supplied manager the evaluator of the service
+// level objectives of the reconcilers, and returns it. It returns nil if no
+// objective is set.
+func (c *serviceController) addSLOEvaluator(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+) (*ackrtslo.Evaluator, error) {
+	objectives := sloObjectives(cfg)
+	if objectives.Synced <= 0 && objectives.TimeToSync <= 0 {
+		return nil, nil
+	}
+	evaluator := ackrtslo.NewEvaluator(c.metrics, objectives, sloEvaluationInterval)
+	for _, rec := range c.reconcilers {
+		if r, ok := rec.(*resourceReconciler); ok {
+			evaluator.Track(r.rd.GroupVersionKind().Kind, r.slo)
+		}
+	}
+	return evaluator, mgr.Add(evaluator)
+}
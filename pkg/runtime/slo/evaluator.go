@@ -0,0 +1,123 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package slo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+)
+
+const (
+	// ObjectiveSynced is the name of the synced objective in the burn rate
+	// metrics
+	ObjectiveSynced = "synced"
+	// ObjectiveTimeToSync is the name of the time-to-sync objective in the
+	// burn rate metrics
+	ObjectiveTimeToSync = "time_to_sync"
+)
+
+// Evaluator periodically evaluates the indicators of the tracked kinds
+// against the objectives of the controller, records them in the metrics and
+// keeps the resulting conditions. Evaluator implements the
+// controller-runtime manager.Runnable interface and, as the resources are
+// only reconciled by the leader, only runs on the leader.
+type Evaluator struct {
+	sync.RWMutex
+	metrics    *ackmetrics.Metrics
+	objectives Objectives
+	interval   time.Duration
+	trackers   map[string]*Tracker
+	conditions []*ackv1alpha1.Condition
+}
+
+// NewEvaluator returns an Evaluator evaluating the supplied objectives at
+// the supplied interval
+func NewEvaluator(
+	metrics *ackmetrics.Metrics,
+	objectives Objectives,
+	interval time.Duration,
+) *Evaluator {
+	return &Evaluator{
+		metrics:    metrics,
+		objectives: objectives,
+		interval:   interval,
+		trackers:   map[string]*Tracker{},
+	}
+}
+
+// Track adds the tracker of the supplied kind to the evaluated ones
+func (e *Evaluator) Track(kind string, tracker *Tracker) {
+	e.Lock()
+	defer e.Unlock()
+	e.trackers[kind] = tracker
+}
+
+// Start evaluates the objectives until the supplied context is done
+func (e *Evaluator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			e.Evaluate(now)
+		}
+	}
+}
+
+// Evaluate evaluates the objectives at the supplied time, and returns the
+// resulting conditions
+func (e *Evaluator) Evaluate(now time.Time) []*ackv1alpha1.Condition {
+	e.Lock()
+	indicators := make(map[string]Indicators, len(e.trackers))
+	for kind, tracker := range e.trackers {
+		indicators[kind] = tracker.Indicators(now)
+		if e.metrics == nil {
+			continue
+		}
+		burnRates := map[string]float64{}
+		if e.objectives.Synced > 0 {
+			burnRates[ObjectiveSynced] = e.objectives.SyncedBurnRate(indicators[kind])
+		}
+		if e.objectives.TimeToSync > 0 {
+			burnRates[ObjectiveTimeToSync] = e.objectives.TimeToSyncBurnRate(indicators[kind])
+		}
+		e.metrics.RecordSLOIndicators(
+			kind, indicators[kind].SyncedRatio, indicators[kind].TimeToSyncP99, burnRates,
+		)
+	}
+	e.conditions = e.objectives.Conditions(indicators)
+	e.Unlock()
+	return e.Conditions()
+}
+
+// Conditions returns a copy of the conditions of the last evaluation. It is
+// nil-safe, so that the absence of objectives adds no conditions.
+func (e *Evaluator) Conditions() []*ackv1alpha1.Condition {
+	if e == nil {
+		return nil
+	}
+	e.RLock()
+	defer e.RUnlock()
+	conditions := make([]*ackv1alpha1.Condition, 0, len(e.conditions))
+	for _, c := range e.conditions {
+		conditions = append(conditions, c.DeepCopy())
+	}
+	return conditions
+}
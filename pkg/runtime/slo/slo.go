@@ -0,0 +1,251 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package slo computes the service level indicators of the reconcilers of a
+// controller, i.e. the percentage of synced resources and the time it takes
+// resources to sync, and evaluates them against the objectives of the
+// controller.
+package slo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// maxSamples is the maximum number of time-to-sync samples kept per kind.
+// The oldest samples are dropped first.
+const maxSamples = 10000
+
+// timeToSyncBudget is the fraction of the resources allowed to take longer
+// than the time-to-sync objective, which is a 99th percentile
+const timeToSyncBudget = 0.01
+
+// Indicators are the service level indicators of the resources of a kind
+type Indicators struct {
+	// Resources is the number of resources observed
+	Resources int
+	// Synced is the number of resources whose last reconciliation left them
+	// synced
+	Synced int
+	// SyncedRatio is the fraction of the resources that are synced, 1 if
+	// no resources were observed
+	SyncedRatio float64
+	// TimeToSyncP99 is the 99th percentile of the times it took the
+	// resources to sync over the window
+	TimeToSyncP99 time.Duration
+	// TimeToSync contains the times it took the resources to sync over the
+	// window, sorted
+	TimeToSync []time.Duration
+}
+
+// resourceState is the sync state of a resource
+type resourceState struct {
+	generation int64
+	synced     bool
+	// since is the start of the first reconciliation of the generation,
+	// zero once the generation is synced
+	since time.Time
+}
+
+// sample is a time-to-sync sample
+type sample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// Tracker tracks the sync state of the resources of a kind. The time it
+// takes a resource to sync is measured from the start of the first
+// reconciliation of a generation of its spec to the end of the
+// reconciliation that synced it. A nil Tracker tracks nothing.
+type Tracker struct {
+	sync.Mutex
+	window    time.Duration
+	resources map[k8stypes.NamespacedName]*resourceState
+	samples   []sample
+}
+
+// NewTracker returns a Tracker keeping the time-to-sync samples of the
+// supplied window
+func NewTracker(window time.Duration) *Tracker {
+	return &Tracker{
+		window:    window,
+		resources: map[k8stypes.NamespacedName]*resourceState{},
+	}
+}
+
+// Observe records the sync state of a resource at the end of a
+// reconciliation, started and ended at the supplied times
+func (t *Tracker) Observe(
+	key k8stypes.NamespacedName,
+	generation int64,
+	synced bool,
+	start time.Time,
+	end time.Time,
+) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	state, ok := t.resources[key]
+	if !ok || state.generation != generation {
+		state = &resourceState{generation: generation, since: start}
+		t.resources[key] = state
+	}
+	state.synced = synced
+	if synced && !state.since.IsZero() {
+		t.samples = append(t.samples, sample{at: end, duration: end.Sub(state.since)})
+		if len(t.samples) > maxSamples {
+			t.samples = t.samples[len(t.samples)-maxSamples:]
+		}
+		state.since = time.Time{}
+	}
+}
+
+// Forget stops tracking a deleted resource
+func (t *Tracker) Forget(key k8stypes.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	delete(t.resources, key)
+}
+
+// Indicators returns the indicators of the tracked resources at the
+// supplied time
+func (t *Tracker) Indicators(now time.Time) Indicators {
+	indicators := Indicators{SyncedRatio: 1}
+	if t == nil {
+		return indicators
+	}
+	t.Lock()
+	defer t.Unlock()
+	for _, state := range t.resources {
+		indicators.Resources++
+		if state.synced {
+			indicators.Synced++
+		}
+	}
+	if indicators.Resources > 0 {
+		indicators.SyncedRatio = float64(indicators.Synced) / float64(indicators.Resources)
+	}
+
+	oldest := now.Add(-t.window)
+	kept := t.samples[:0]
+	for _, s := range t.samples {
+		if s.at.Before(oldest) {
+			continue
+		}
+		kept = append(kept, s)
+		indicators.TimeToSync = append(indicators.TimeToSync, s.duration)
+	}
+	t.samples = kept
+	sort.Slice(indicators.TimeToSync, func(i, j int) bool {
+		return indicators.TimeToSync[i] < indicators.TimeToSync[j]
+	})
+	if n := len(indicators.TimeToSync); n > 0 {
+		indicators.TimeToSyncP99 = indicators.TimeToSync[int(math.Ceil(0.99*float64(n)))-1]
+	}
+	return indicators
+}
+
+// Objectives are the service level objectives of a controller
+type Objectives struct {
+	// Synced is the minimum fraction of the resources of each kind that are
+	// synced, e.g. 0.99. Zero disables the objective.
+	Synced float64
+	// TimeToSync is the maximum 99th percentile of the time it takes the
+	// resources of each kind to sync. Zero disables the objective.
+	TimeToSync time.Duration
+}
+
+// SyncedBurnRate returns the rate at which the supplied indicators burn the
+// error budget of the synced objective: 1 means that the budget is consumed
+// exactly, higher values that the objective is not met
+func (o Objectives) SyncedBurnRate(indicators Indicators) float64 {
+	if o.Synced <= 0 || o.Synced >= 1 {
+		return 0
+	}
+	return (1 - indicators.SyncedRatio) / (1 - o.Synced)
+}
+
+// TimeToSyncBurnRate returns the rate at which the supplied indicators burn
+// the error budget of the time-to-sync objective
+func (o Objectives) TimeToSyncBurnRate(indicators Indicators) float64 {
+	if o.TimeToSync <= 0 || len(indicators.TimeToSync) == 0 {
+		return 0
+	}
+	slow := len(indicators.TimeToSync) - sort.Search(len(indicators.TimeToSync), func(i int) bool {
+		return indicators.TimeToSync[i] > o.TimeToSync
+	})
+	return float64(slow) / float64(len(indicators.TimeToSync)) / timeToSyncBudget
+}
+
+// Conditions returns the conditions, one per enabled objective, reporting
+// whether the supplied indicators, keyed by kind, meet the objectives
+func (o Objectives) Conditions(indicators map[string]Indicators) []*ackv1alpha1.Condition {
+	kinds := make([]string, 0, len(indicators))
+	for kind := range indicators {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	conditions := []*ackv1alpha1.Condition{}
+	if o.Synced > 0 {
+		conditions = append(conditions, condition(
+			ackv1alpha1.ConditionTypeSyncedObjectiveMet, kinds,
+			func(kind string) float64 { return o.SyncedBurnRate(indicators[kind]) },
+		))
+	}
+	if o.TimeToSync > 0 {
+		conditions = append(conditions, condition(
+			ackv1alpha1.ConditionTypeTimeToSyncObjectiveMet, kinds,
+			func(kind string) float64 { return o.TimeToSyncBurnRate(indicators[kind]) },
+		))
+	}
+	return conditions
+}
+
+// condition returns the condition of the supplied type, False if the burn
+// rate of some of the supplied kinds is greater than 1
+func condition(
+	conditionType ackv1alpha1.ConditionType,
+	kinds []string,
+	burnRate func(kind string) float64,
+) *ackv1alpha1.Condition {
+	breaching := []string{}
+	for _, kind := range kinds {
+		if rate := burnRate(kind); rate > 1 {
+			breaching = append(breaching, fmt.Sprintf("%s (burn rate %.2f)", kind, rate))
+		}
+	}
+	if len(breaching) == 0 {
+		return &ackv1alpha1.Condition{Type: conditionType, Status: corev1.ConditionTrue}
+	}
+	msg := "objective not met by " + strings.Join(breaching, ", ")
+	return &ackv1alpha1.Condition{
+		Type:    conditionType,
+		Status:  corev1.ConditionFalse,
+		Message: &msg,
+	}
+}
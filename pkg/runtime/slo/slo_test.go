@@ -0,0 +1,116 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package slo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/slo"
+)
+
+func TestTracker(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	a := k8stypes.NamespacedName{Namespace: "ns", Name: "a"}
+	b := k8stypes.NamespacedName{Namespace: "ns", Name: "b"}
+	tracker := slo.NewTracker(time.Hour)
+
+	// a syncs 30s after the first reconciliation of its generation
+	tracker.Observe(a, 1, false, now, now.Add(time.Second))
+	tracker.Observe(a, 1, true, now.Add(20*time.Second), now.Add(30*time.Second))
+	// Further reconciliations of the synced generation are not samples
+	tracker.Observe(a, 1, true, now.Add(time.Minute), now.Add(time.Minute))
+	tracker.Observe(b, 1, false, now, now.Add(time.Second))
+
+	indicators := tracker.Indicators(now.Add(time.Minute))
+	require.Equal(2, indicators.Resources)
+	require.Equal(1, indicators.Synced)
+	require.Equal(0.5, indicators.SyncedRatio)
+	require.Equal([]time.Duration{30 * time.Second}, indicators.TimeToSync)
+	require.Equal(30*time.Second, indicators.TimeToSyncP99)
+
+	// A new generation is measured from its first reconciliation
+	tracker.Observe(a, 2, true, now.Add(2*time.Minute), now.Add(2*time.Minute+time.Second))
+	indicators = tracker.Indicators(now.Add(2 * time.Minute))
+	require.Equal([]time.Duration{time.Second, 30 * time.Second}, indicators.TimeToSync)
+
+	// Samples out of the window are dropped
+	tracker.Forget(b)
+	indicators = tracker.Indicators(now.Add(time.Hour + time.Minute))
+	require.Equal(1.0, indicators.SyncedRatio)
+	require.Equal([]time.Duration{time.Second}, indicators.TimeToSync)
+
+	var nilTracker *slo.Tracker
+	nilTracker.Observe(a, 1, true, now, now)
+	require.Equal(1.0, nilTracker.Indicators(now).SyncedRatio)
+}
+
+func TestObjectivesConditions(t *testing.T) {
+	require := require.New(t)
+
+	objectives := slo.Objectives{Synced: 0.9, TimeToSync: time.Minute}
+	good := slo.Indicators{
+		Resources: 10, Synced: 10, SyncedRatio: 1,
+		TimeToSync: []time.Duration{time.Second},
+	}
+	bad := slo.Indicators{
+		Resources: 10, Synced: 5, SyncedRatio: 0.5,
+		TimeToSync: []time.Duration{time.Second, time.Hour},
+	}
+	require.InDelta(5.0, objectives.SyncedBurnRate(bad), 0.0001)
+	require.InDelta(50.0, objectives.TimeToSyncBurnRate(bad), 0.0001)
+	require.Equal(0.0, objectives.TimeToSyncBurnRate(good))
+
+	conditions := objectives.Conditions(map[string]slo.Indicators{"Bucket": good})
+	require.Len(conditions, 2)
+	require.Equal(ackv1alpha1.ConditionTypeSyncedObjectiveMet, conditions[0].Type)
+	require.Equal(corev1.ConditionTrue, conditions[0].Status)
+	require.Equal(ackv1alpha1.ConditionTypeTimeToSyncObjectiveMet, conditions[1].Type)
+	require.Equal(corev1.ConditionTrue, conditions[1].Status)
+
+	conditions = objectives.Conditions(map[string]slo.Indicators{"Bucket": good, "Queue": bad})
+	require.Equal(corev1.ConditionFalse, conditions[0].Status)
+	require.Equal("objective not met by Queue (burn rate 5.00)", *conditions[0].Message)
+
+	// Disabled objectives have no condition
+	require.Len(slo.Objectives{Synced: 0.9}.Conditions(nil), 1)
+}
+
+func TestEvaluator(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	tracker := slo.NewTracker(time.Hour)
+	tracker.Observe(k8stypes.NamespacedName{Name: "a"}, 1, false, now, now)
+	evaluator := slo.NewEvaluator(nil, slo.Objectives{Synced: 0.99}, time.Minute)
+	evaluator.Track("Bucket", tracker)
+
+	require.Empty(evaluator.Conditions())
+	conditions := evaluator.Evaluate(now)
+	require.Len(conditions, 1)
+	require.Equal(corev1.ConditionFalse, conditions[0].Status)
+	// The returned conditions are copies
+	conditions[0].Status = corev1.ConditionTrue
+	require.Equal(corev1.ConditionFalse, evaluator.Conditions()[0].Status)
+
+	var nilEvaluator *slo.Evaluator
+	require.Nil(nilEvaluator.Conditions())
+}
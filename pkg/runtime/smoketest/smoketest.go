@@ -0,0 +1,364 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package smoketest contains a runner of smoke tests, which create, verify
+// and delete a canary ACK resource to continuously check that the controller
+// credentials and permissions still work.
+package smoketest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+)
+
+const (
+	// LabelSmokeTest is the label set on the canary resources created by the
+	// smoke tests
+	LabelSmokeTest = ackv1alpha1.AnnotationPrefix + "smoke-test"
+	// StatusDataKey is the key of the result of the last smoke test in the
+	// data of the controller status ConfigMap
+	StatusDataKey = "smokeTest"
+	// defaultPollInterval is the interval at which the canary resource is
+	// read while waiting for it to be synced or deleted
+	defaultPollInterval = 5 * time.Second
+)
+
+// Stage is a stage of a smoke test
+type Stage string
+
+const (
+	// StageCreate is the creation of the canary resource
+	StageCreate Stage = "Create"
+	// StageVerify is the wait for the canary resource to be synced
+	StageVerify Stage = "Verify"
+	// StageDelete is the deletion of the canary resource
+	StageDelete Stage = "Delete"
+)
+
+// Result is the result of a smoke test
+type Result struct {
+	// Kind is the kind of the canary resource
+	Kind string `json:"kind"`
+	// Name is the name of the canary resource
+	Name string `json:"name,omitempty"`
+	// Succeeded is true if the canary resource was created, synced and
+	// deleted
+	Succeeded bool `json:"succeeded"`
+	// FailedStage is the stage the smoke test failed at
+	FailedStage Stage `json:"failedStage,omitempty"`
+	// Message explains the failure of the smoke test
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the smoke test started
+	StartTime metav1.Time `json:"startTime"`
+	// Duration is the duration of the smoke test
+	Duration metav1.Duration `json:"duration"`
+}
+
+// LoadTemplate reads the YAML manifest of the canary resource of the smoke
+// tests from the supplied file. The manifest must have a namespace, and
+// either a name or a generateName.
+func LoadTemplate(path string) (*k8sunstructured.Unstructured, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading smoke test template: %v", err)
+	}
+	u := &k8sunstructured.Unstructured{}
+	if err := yaml.Unmarshal(b, &u.Object); err != nil {
+		return nil, fmt.Errorf("parsing smoke test template: %v", err)
+	}
+	if u.GetKind() == "" || u.GetAPIVersion() == "" {
+		return nil, fmt.Errorf("invalid smoke test template: missing apiVersion or kind")
+	}
+	if u.GetNamespace() == "" {
+		return nil, fmt.Errorf("invalid smoke test template: missing metadata.namespace")
+	}
+	if u.GetName() == "" && u.GetGenerateName() == "" {
+		return nil, fmt.Errorf("invalid smoke test template: missing metadata.name or metadata.generateName")
+	}
+	return u, nil
+}
+
+// Runner runs smoke tests at startup and, if it has an interval,
+// periodically. Runner implements the controller-runtime manager.Runnable
+// interface and only runs on the leader.
+type Runner struct {
+	log      logr.Logger
+	kc       client.Client
+	metrics  *ackmetrics.Metrics
+	template *k8sunstructured.Unstructured
+	// interval is the interval between smoke tests. Smoke tests are only run
+	// at startup if it is zero.
+	interval time.Duration
+	// timeout is the maximum duration of each of the Verify and Delete
+	// stages
+	timeout      time.Duration
+	pollInterval time.Duration
+	// retention is the age after which the canary resources left over by
+	// interrupted smoke tests are deleted. They are never deleted if it is
+	// zero.
+	retention time.Duration
+	// status identifies the ConfigMap the results are written in
+	status client.ObjectKey
+}
+
+// NewRunner returns a Runner of smoke tests creating canary resources from
+// the supplied template and writing their results in the data of the
+// supplied controller status ConfigMap.
+func NewRunner(
+	log logr.Logger,
+	kc client.Client,
+	metrics *ackmetrics.Metrics,
+	template *k8sunstructured.Unstructured,
+	interval time.Duration,
+	timeout time.Duration,
+	status client.ObjectKey,
+) *Runner {
+	return &Runner{
+		log:          log,
+		kc:           kc,
+		metrics:      metrics,
+		template:     template,
+		interval:     interval,
+		timeout:      timeout,
+		pollInterval: defaultPollInterval,
+		status:       status,
+	}
+}
+
+// WithPollInterval sets the interval at which the canary resource is read
+// while waiting for it to be synced or deleted
+func (r *Runner) WithPollInterval(interval time.Duration) *Runner {
+	r.pollInterval = interval
+	return r
+}
+
+// WithRetention sets the age after which the canary resources left over by
+// interrupted smoke tests, e.g. when the controller restarted during a smoke
+// test, are deleted before the next smoke test
+func (r *Runner) WithRetention(retention time.Duration) *Runner {
+	r.retention = retention
+	return r
+}
+
+// Start runs the smoke tests until the supplied context is done
+func (r *Runner) Start(ctx context.Context) error {
+	for {
+		r.prune(ctx)
+		result := r.Run(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		r.report(ctx, result)
+		if r.interval <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// Run runs a single smoke test: it creates the canary resource, waits for it
+// to be synced and deletes it.
+func (r *Runner) Run(ctx context.Context) Result {
+	start := time.Now()
+	result := Result{
+		Kind:      r.template.GetKind(),
+		StartTime: metav1.NewTime(start),
+	}
+	fail := func(stage Stage, err error) Result {
+		result.FailedStage = stage
+		result.Message = err.Error()
+		result.Duration = metav1.Duration{Duration: time.Since(start)}
+		return result
+	}
+
+	canary := r.template.DeepCopy()
+	labels := canary.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[LabelSmokeTest] = "true"
+	canary.SetLabels(labels)
+	if err := r.kc.Create(ctx, canary); err != nil {
+		return fail(StageCreate, err)
+	}
+	result.Name = canary.GetName()
+	key := client.ObjectKeyFromObject(canary)
+
+	err := r.poll(ctx, func() (bool, error) {
+		current := canary.DeepCopy()
+		if err := r.kc.Get(ctx, key, current); err != nil {
+			return false, err
+		}
+		if msg, ok := conditionTrue(current, ackv1alpha1.ConditionTypeTerminal); ok {
+			return false, fmt.Errorf("canary resource is in a terminal state: %s", msg)
+		}
+		_, synced := conditionTrue(current, ackv1alpha1.ConditionTypeResourceSynced)
+		return synced, nil
+	})
+	if err != nil {
+		// Clean up the canary resource, without waiting for its deletion
+		_ = client.IgnoreNotFound(r.kc.Delete(ctx, canary))
+		return fail(StageVerify, err)
+	}
+
+	if err := client.IgnoreNotFound(r.kc.Delete(ctx, canary)); err != nil {
+		return fail(StageDelete, err)
+	}
+	err = r.poll(ctx, func() (bool, error) {
+		err := r.kc.Get(ctx, key, canary.DeepCopy())
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fail(StageDelete, err)
+	}
+
+	result.Succeeded = true
+	result.Duration = metav1.Duration{Duration: time.Since(start)}
+	return result
+}
+
+// prune deletes the canary resources older than the retention, which were
+// left over by interrupted smoke tests
+func (r *Runner) prune(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+	gvk := r.template.GroupVersionKind()
+	list := &k8sunstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	err := r.kc.List(
+		ctx, list,
+		client.InNamespace(r.template.GetNamespace()),
+		client.MatchingLabels{LabelSmokeTest: "true"},
+	)
+	if err != nil {
+		r.log.Error(err, "unable to list leftover canary resources")
+		return
+	}
+	pruned := 0
+	for i := range list.Items {
+		canary := &list.Items[i]
+		if time.Since(canary.GetCreationTimestamp().Time) < r.retention {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.kc.Delete(ctx, canary)); err != nil {
+			r.log.Error(err, "unable to delete leftover canary resource", "name", canary.GetName())
+			continue
+		}
+		pruned++
+	}
+	if pruned > 0 {
+		r.log.Info("deleted leftover canary resources", "kind", gvk.Kind, "count", pruned)
+	}
+	if r.metrics != nil {
+		r.metrics.RecordCompanionObjectsPruned(ackmetrics.CompanionObjectSmokeTestCanary, pruned)
+	}
+}
+
+// poll calls the supplied condition function until it returns true or an
+// error, or the timeout of the stage expires
+func (r *Runner) poll(ctx context.Context, condition func() (bool, error)) error {
+	err := wait.PollUntilContextTimeout(
+		ctx, r.pollInterval, r.timeout, true,
+		func(context.Context) (bool, error) { return condition() },
+	)
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out after %s", r.timeout)
+	}
+	return err
+}
+
+// report records the supplied result in the metrics and in the controller
+// status ConfigMap
+func (r *Runner) report(ctx context.Context, result Result) {
+	if result.Succeeded {
+		r.log.Info("smoke test succeeded", "kind", result.Kind, "duration", result.Duration.Duration)
+	} else {
+		r.log.Info(
+			"smoke test failed", "kind", result.Kind,
+			"stage", result.FailedStage, "message", result.Message,
+		)
+	}
+	if r.metrics != nil {
+		r.metrics.RecordSmokeTestResult(result.Kind, result.Succeeded, result.Duration.Duration)
+	}
+	if err := r.writeStatus(ctx, result); err != nil {
+		r.log.Error(err, "unable to write smoke test result")
+	}
+}
+
+// writeStatus writes the supplied result in the controller status ConfigMap
+func (r *Runner) writeStatus(ctx context.Context, result Result) error {
+	js, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{}
+	err = r.kc.Get(ctx, r.status, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: r.status.Namespace, Name: r.status.Name},
+			Data:       map[string]string{StatusDataKey: string(js)},
+		}
+		return r.kc.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[StatusDataKey] = string(js)
+	return r.kc.Patch(ctx, cm, patch)
+}
+
+// conditionTrue returns the message of the condition of the supplied type of
+// the supplied resource, and whether its status is True
+func conditionTrue(
+	u *k8sunstructured.Unstructured,
+	conditionType ackv1alpha1.ConditionType,
+) (string, bool) {
+	conditions, _, _ := k8sunstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok || cm["type"] != string(conditionType) {
+			continue
+		}
+		msg, _ := cm["message"].(string)
+		return msg, cm["status"] == string(corev1.ConditionTrue)
+	}
+	return "", false
+}
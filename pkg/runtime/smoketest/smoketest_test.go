@@ -0,0 +1,191 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package smoketest_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/smoketest"
+)
+
+var bookGVK = schema.GroupVersionKind{
+	Group:   "bookstore.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Book",
+}
+
+const template = `apiVersion: bookstore.services.k8s.aws/v1alpha1
+kind: Book
+metadata:
+  namespace: ack-system
+  name: canary
+spec:
+  title: Dune
+`
+
+func newScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(bookGVK, &k8sunstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(bookGVK.GroupVersion().WithKind("BookList"), &k8sunstructured.UnstructuredList{})
+	metav1.AddToGroupVersion(scheme, bookGVK.GroupVersion())
+	return scheme
+}
+
+func loadTemplate(t *testing.T) *k8sunstructured.Unstructured {
+	path := filepath.Join(t.TempDir(), "canary.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(template), 0o600))
+	u, err := smoketest.LoadTemplate(path)
+	require.Nil(t, err)
+	return u
+}
+
+// newClient returns a fake client reporting the canary resources as having
+// the supplied condition as soon as they are created
+func newClient(conditionType ackv1alpha1.ConditionType) client.Client {
+	return fake.NewClientBuilder().WithScheme(newScheme()).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if u, ok := obj.(*k8sunstructured.Unstructured); ok && conditionType != "" {
+				u.Object["status"] = map[string]interface{}{
+					"conditions": []interface{}{map[string]interface{}{
+						"type":    string(conditionType),
+						"status":  string(corev1.ConditionTrue),
+						"message": "oops",
+					}},
+				}
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+}
+
+func TestLoadTemplate(t *testing.T) {
+	require := require.New(t)
+
+	u := loadTemplate(t)
+	require.Equal("Book", u.GetKind())
+	require.Equal("canary", u.GetName())
+
+	path := filepath.Join(t.TempDir(), "invalid.yaml")
+	require.Nil(os.WriteFile(path, []byte("kind: Book\n"), 0o600))
+	_, err := smoketest.LoadTemplate(path)
+	require.NotNil(err)
+	_, err = smoketest.LoadTemplate(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NotNil(err)
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.TODO()
+	status := client.ObjectKey{Namespace: "ack-system", Name: "ack-bookstore-controller-status"}
+
+	t.Run("succeeded", func(t *testing.T) {
+		require := require.New(t)
+		kc := newClient(ackv1alpha1.ConditionTypeResourceSynced)
+		runner := smoketest.NewRunner(
+			logr.Discard(), kc, ackmetrics.NewMetrics("bookstore"), loadTemplate(t),
+			0, time.Second, status,
+		).WithPollInterval(10 * time.Millisecond)
+		require.Nil(runner.Start(ctx))
+
+		cm := &corev1.ConfigMap{}
+		require.Nil(kc.Get(ctx, status, cm))
+		result := smoketest.Result{}
+		require.Nil(json.Unmarshal([]byte(cm.Data[smoketest.StatusDataKey]), &result))
+		require.True(result.Succeeded)
+		require.Equal("Book", result.Kind)
+		require.Equal("canary", result.Name)
+
+		// The canary resource is deleted
+		canary := &k8sunstructured.Unstructured{}
+		canary.SetGroupVersionKind(bookGVK)
+		err := kc.Get(ctx, client.ObjectKey{Namespace: "ack-system", Name: "canary"}, canary)
+		require.True(apierrors.IsNotFound(err))
+	})
+
+	t.Run("terminal", func(t *testing.T) {
+		require := require.New(t)
+		kc := newClient(ackv1alpha1.ConditionTypeTerminal)
+		result := smoketest.NewRunner(
+			logr.Discard(), kc, nil, loadTemplate(t), 0, time.Second, status,
+		).WithPollInterval(10 * time.Millisecond).Run(ctx)
+		require.False(result.Succeeded)
+		require.Equal(smoketest.StageVerify, result.FailedStage)
+		require.Contains(result.Message, "terminal state: oops")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		require := require.New(t)
+		kc := newClient("")
+		result := smoketest.NewRunner(
+			logr.Discard(), kc, nil, loadTemplate(t), 0, 50*time.Millisecond, status,
+		).WithPollInterval(10 * time.Millisecond).Run(ctx)
+		require.False(result.Succeeded)
+		require.Equal(smoketest.StageVerify, result.FailedStage)
+		require.Contains(result.Message, "timed out")
+	})
+
+	t.Run("create failed", func(t *testing.T) {
+		require := require.New(t)
+		kc := newClient(ackv1alpha1.ConditionTypeResourceSynced)
+		runner := smoketest.NewRunner(
+			logr.Discard(), kc, nil, loadTemplate(t), 0, time.Second, status,
+		).WithPollInterval(10 * time.Millisecond)
+		// A leftover canary resource makes the creation fail
+		require.Nil(kc.Create(ctx, loadTemplate(t)))
+		result := runner.Run(ctx)
+		require.False(result.Succeeded)
+		require.Equal(smoketest.StageCreate, result.FailedStage)
+	})
+
+	t.Run("leftover canary pruned", func(t *testing.T) {
+		require := require.New(t)
+		kc := newClient(ackv1alpha1.ConditionTypeResourceSynced)
+		leftover := loadTemplate(t)
+		leftover.SetLabels(map[string]string{smoketest.LabelSmokeTest: "true"})
+		leftover.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-2 * time.Hour)))
+		require.Nil(kc.Create(ctx, leftover))
+
+		runner := smoketest.NewRunner(
+			logr.Discard(), kc, ackmetrics.NewMetrics("bookstore"), loadTemplate(t),
+			0, time.Second, status,
+		).WithPollInterval(10 * time.Millisecond).WithRetention(time.Hour)
+		require.Nil(runner.Start(ctx))
+
+		cm := &corev1.ConfigMap{}
+		require.Nil(kc.Get(ctx, status, cm))
+		result := smoketest.Result{}
+		require.Nil(json.Unmarshal([]byte(cm.Data[smoketest.StatusDataKey]), &result))
+		require.True(result.Succeeded, result.Message)
+	})
+}
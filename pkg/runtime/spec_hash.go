@@ -0,0 +1,80 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// specHashPrefix is the prefix of the spec hashes, naming their algorithm
+const specHashPrefix = "sha256:"
+
+// SpecHash returns the hash of the spec of the supplied resource. The hash
+// is stable: the fields of the spec are serialized in a deterministic order.
+func SpecHash(res acktypes.AWSResource) (string, error) {
+	obj, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(res.RuntimeObject())
+	if err != nil {
+		return "", err
+	}
+	// encoding/json sorts the keys of the maps it serializes
+	js, err := json.Marshal(obj["spec"])
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(js)
+	return specHashPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// recordSpecHash records the hash of the spec of the supplied resolved
+// resource, i.e. the desired state actually synced, in the spec hash
+// annotation of the supplied desired resource, if it changed
+func (r *resourceReconciler) recordSpecHash(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+	resolved acktypes.AWSResource,
+) {
+	if !r.cfg.SpecHashAnnotation {
+		return
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	hash, err := SpecHash(resolved)
+	if err != nil {
+		rlog.Info("unable to hash the spec of the resource", "error", err)
+		return
+	}
+	if desired.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationSpecHash] == hash {
+		return
+	}
+	annotated := pendingOrCopy(ctx, desired)
+	mo := annotated.MetaObject()
+	annotations := mo.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ackv1alpha1.AnnotationSpecHash] = hash
+	mo.SetAnnotations(annotations)
+	if _, err := r.patchResourceMetadataAndSpec(ctx, rm, desired, annotated); err != nil {
+		rlog.Info("unable to record the spec hash", "error", err)
+	}
+}
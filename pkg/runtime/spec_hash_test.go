@@ -0,0 +1,57 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime"
+)
+
+func resourceWithSpec(spec map[string]interface{}) *ackmocks.AWSResource {
+	res := &ackmocks.AWSResource{}
+	res.On("RuntimeObject").Return(&k8sunstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "bucket"},
+		"spec":     spec,
+		"status":   map[string]interface{}{"location": "us-west-2"},
+	}})
+	return res
+}
+
+func TestSpecHash(t *testing.T) {
+	require := require.New(t)
+
+	hash, err := runtime.SpecHash(resourceWithSpec(map[string]interface{}{
+		"name": "bucket", "versioning": map[string]interface{}{"status": "Enabled", "mfa": "Disabled"},
+	}))
+	require.Nil(err)
+	require.Regexp("^sha256:[0-9a-f]{64}$", hash)
+
+	// The hash is stable
+	same, err := runtime.SpecHash(resourceWithSpec(map[string]interface{}{
+		"versioning": map[string]interface{}{"mfa": "Disabled", "status": "Enabled"}, "name": "bucket",
+	}))
+	require.Nil(err)
+	require.Equal(hash, same)
+
+	different, err := runtime.SpecHash(resourceWithSpec(map[string]interface{}{
+		"name": "bucket", "versioning": map[string]interface{}{"status": "Suspended", "mfa": "Disabled"},
+	}))
+	require.Nil(err)
+	require.NotEqual(hash, different)
+}
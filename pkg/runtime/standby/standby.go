@@ -0,0 +1,140 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package standby keeps a replica of a controller that is not the leader
+// ready to take over: it syncs the informers of the reconciled kinds, which
+// the controllers of the leader would otherwise only start once elected, and
+// keeps the credentials of the IAM roles of the bindings fresh.
+package standby
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	ctrlrtcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+// ConfigFor returns the configuration of the AWS service clients assuming
+// the supplied IAM role
+type ConfigFor func(ctx context.Context, roleARN ackv1alpha1.AWSResourceName) (aws.Config, error)
+
+// Standby warms a replica that is not the leader. It never reconciles.
+type Standby struct {
+	log       logr.Logger
+	informers ctrlrtcache.Informers
+	objects   []client.Object
+	caches    ackrtcache.Caches
+	configFor ConfigFor
+	interval  time.Duration
+}
+
+// New returns a Standby syncing, with the supplied informers, the informers
+// of the supplied objects of the reconciled kinds, and refreshing at the
+// supplied interval the credentials of the IAM roles of the bindings of the
+// supplied caches, retrieved from the supplied client configurations
+func New(
+	log logr.Logger,
+	informers ctrlrtcache.Informers,
+	objects []client.Object,
+	caches ackrtcache.Caches,
+	configFor ConfigFor,
+	interval time.Duration,
+) *Standby {
+	return &Standby{
+		log:       log,
+		informers: informers,
+		objects:   objects,
+		caches:    caches,
+		configFor: configFor,
+		interval:  interval,
+	}
+}
+
+// Run syncs the informers of the reconciled kinds, then refreshes the
+// credentials of the IAM roles of the bindings until the supplied elected
+// channel is closed, once the replica is elected, or the supplied context is
+// done
+func (s *Standby) Run(ctx context.Context, elected <-chan struct{}) error {
+	for _, obj := range s.objects {
+		if _, err := s.informers.GetInformer(ctx, obj); err != nil {
+			s.log.Error(err, "unable to warm the informer", "type", fmt.Sprintf("%T", obj))
+		}
+	}
+	s.log.Info("synced the informers of the reconciled kinds")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.RefreshCredentials(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-elected:
+			s.log.Info("elected leader, resuming the reconciliations")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshCredentials retrieves the credentials of the IAM roles of the
+// bindings cached in the account and team maps and of the AccountConfigs,
+// assuming the roles whose credentials expired. Nothing is refreshed without
+// client configurations.
+func (s *Standby) RefreshCredentials(ctx context.Context) {
+	if s.configFor == nil {
+		return
+	}
+	for _, roleARN := range BindingRoles(s.caches.Snapshot()) {
+		awsCfg, err := s.configFor(ctx, roleARN)
+		if err == nil {
+			_, err = awsCfg.Credentials.Retrieve(ctx)
+		}
+		if err != nil {
+			s.log.V(1).Info("unable to refresh the credentials of the role", "role", roleARN, "error", err.Error())
+		}
+	}
+}
+
+// BindingRoles returns the sorted IAM roles of the bindings, and of the
+// AccountConfigs, of the supplied snapshot of the caches
+func BindingRoles(snapshot ackrtcache.Snapshot) []ackv1alpha1.AWSResourceName {
+	set := map[string]struct{}{}
+	for _, roleARN := range snapshot.Accounts {
+		set[roleARN] = struct{}{}
+	}
+	for _, roleARN := range snapshot.Teams {
+		set[roleARN] = struct{}{}
+	}
+	for _, ac := range snapshot.AccountConfigs {
+		set[ac.RoleARN] = struct{}{}
+	}
+	roles := make([]ackv1alpha1.AWSResourceName, 0, len(set))
+	for roleARN := range set {
+		if roleARN != "" {
+			roles = append(roles, ackv1alpha1.AWSResourceName(roleARN))
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i] < roles[j]
+	})
+	return roles
+}
@@ -0,0 +1,200 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package standby_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	ctrlrtcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/standby"
+)
+
+const (
+	roleA = "arn:aws:iam::111111111111:role/a"
+	roleB = "arn:aws:iam::222222222222:role/b"
+)
+
+// informers records the objects whose informers are warmed
+type informers struct {
+	ctrlrtcache.Informers
+	sync.Mutex
+	warmed []client.Object
+}
+
+func (i *informers) GetInformer(
+	_ context.Context,
+	obj client.Object,
+	_ ...ctrlrtcache.InformerGetOption,
+) (ctrlrtcache.Informer, error) {
+	i.Lock()
+	defer i.Unlock()
+	i.warmed = append(i.warmed, obj)
+	if _, ok := obj.(*corev1.Secret); ok {
+		return nil, errors.New("forbidden")
+	}
+	return nil, nil
+}
+
+// refreshes records the credentials retrieved for each IAM role
+type refreshes struct {
+	sync.Mutex
+	counts map[ackv1alpha1.AWSResourceName]int
+}
+
+func (r *refreshes) configFor(_ context.Context, roleARN ackv1alpha1.AWSResourceName) (aws.Config, error) {
+	if roleARN == roleB {
+		return aws.Config{}, errors.New("unable to assume role")
+	}
+	return aws.Config{Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		r.Lock()
+		defer r.Unlock()
+		r.counts[roleARN]++
+		return aws.Credentials{}, nil
+	})}, nil
+}
+
+func (r *refreshes) count(roleARN ackv1alpha1.AWSResourceName) int {
+	r.Lock()
+	defer r.Unlock()
+	return r.counts[roleARN]
+}
+
+// newCaches returns the running caches of the account map binding two
+// accounts to IAM roles
+func newCaches(t *testing.T) ackrtcache.Caches {
+	k8sClient := k8sfake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ackrtcache.ACKRoleAccountMap,
+			Namespace: ackrtcache.SystemNamespace(),
+		},
+		Data: map[string]string{
+			"111111111111": roleA,
+			"222222222222": roleB,
+		},
+	})
+	caches := ackrtcache.New(logr.Discard(), ackrtcache.Config{}, featuregate.FeatureGates{})
+	caches.Run(k8sClient)
+	require.True(t, caches.WaitForCachesToSync(context.Background()))
+	require.Eventually(t, func() bool {
+		return len(caches.Snapshot().Accounts) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	return caches
+}
+
+func TestStandby_Run(t *testing.T) {
+	require := require.New(t)
+
+	caches := newCaches(t)
+	informers := &informers{}
+	refreshes := &refreshes{counts: map[ackv1alpha1.AWSResourceName]int{}}
+	objects := []client.Object{&corev1.ConfigMap{}, &corev1.Secret{}}
+	s := standby.New(logr.Discard(), informers, objects, caches, refreshes.configFor, 10*time.Millisecond)
+
+	elected := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- s.Run(context.Background(), elected)
+	}()
+
+	// The credentials are refreshed on start, then at every interval
+	require.Eventually(func() bool {
+		return refreshes.count(roleA) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Zero(refreshes.count(roleB))
+
+	// The informers are warmed once, including after a failure
+	informers.Lock()
+	require.Equal(objects, informers.warmed)
+	informers.Unlock()
+
+	// The standby stops once the replica is elected
+	close(elected)
+	select {
+	case err := <-done:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		require.Fail("the standby did not stop once elected")
+	}
+}
+
+func TestStandby_RunStopsWithContext(t *testing.T) {
+	require := require.New(t)
+
+	refreshes := &refreshes{counts: map[ackv1alpha1.AWSResourceName]int{}}
+	s := standby.New(logr.Discard(), &informers{}, nil, newCaches(t), refreshes.configFor, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx, make(chan struct{}))
+	}()
+	require.Eventually(func() bool {
+		return refreshes.count(roleA) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		require.Fail("the standby did not stop with its context")
+	}
+	require.Equal(1, refreshes.count(roleA))
+}
+
+func TestStandby_RefreshCredentialsWithoutConfigs(t *testing.T) {
+	s := standby.New(logr.Discard(), &informers{}, nil, newCaches(t), nil, time.Hour)
+	require.NotPanics(t, func() { s.RefreshCredentials(context.Background()) })
+}
+
+func TestBindingRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot ackrtcache.Snapshot
+		want     []ackv1alpha1.AWSResourceName
+	}{{
+		name: "empty",
+		want: []ackv1alpha1.AWSResourceName{},
+	}, {
+		name: "accounts, teams and AccountConfigs",
+		snapshot: ackrtcache.Snapshot{
+			Accounts: map[string]string{"222222222222": roleB, "111111111111": roleA},
+			Teams:    map[string]string{"payments": roleA, "empty": ""},
+			AccountConfigs: map[string]ackrtcache.AccountConfigSnapshot{
+				"shared": {RoleARN: "arn:aws:iam::333333333333:role/c"},
+			},
+		},
+		want: []ackv1alpha1.AWSResourceName{roleA, roleB, "arn:aws:iam::333333333333:role/c"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, standby.BindingRoles(tt.snapshot))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	ctrlrtcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	ackrtstatedump "github.com/aws-controllers-k8s/runtime/pkg/runtime/statedump"
+)
+
+// newStateTracker returns the tracker of the work queue, in-flight
+// reconciliations and backoffs of a reconciler, nil if neither the state dump
+// signal nor the admin service, which report them, are enabled
+func newStateTracker(cfg ackcfg.Config) *ackrtstatedump.Tracker {
+	if !cfg.StateDumpSignal && cfg.AdminBindAddress == "" {
+		return nil
+	}
+	return ackrtstatedump.NewTracker()
+}
+
+// trackState sets up the supplied controller options so that the work queue
+// and the backoffs of the reconciler are tracked
+func (r *resourceReconciler) trackState(opts *ctrlrtcontroller.Options) {
+	if r.stateTracker == nil {
+		return
+	}
+	opts.RateLimiter = r.stateTracker.RateLimiter(
+		workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+	)
+	opts.NewQueue = r.stateTracker.NewQueue
+}
+
+// stateDump returns the internal state of the reconciler
+func (r *resourceReconciler) stateDump() ackrtstatedump.Kind {
+	kind := r.rd.GroupVersionKind().Kind
+	state := r.stateTracker.Snapshot(kind)
+	state.DeferredOperations = r.scheduler.Entries()
+	state.ResyncBudget = r.resyncBudget.Snapshot()
+	if storms := r.requeueStorms.Storms(); len(storms) > 0 {
+		state.RequeueStorms = storms
+	}
+	if failures := r.managerFailures.Snapshot(); len(failures) > 0 {
+		state.ManagerFailures = failures
+	}
+	state.Settings = map[string]interface{}{
+		"resyncPeriod":            r.resyncPeriod.String(),
+		"maxConcurrentReconciles": r.cfg.GetReconcileResourceMaxConcurrency(kind),
+		"watchNamespaces":         r.cfg.WatchNamespace,
+		"fingerprintErrors":       r.cfg.FingerprintErrors,
+	}
+	return state
+}
+
+// stateDump returns the internal state of the service controller, i.e. the
+// state of its reconcilers and the contents of the supplied caches. It
+// includes the reconcilers of the service controllers bound to the same
+// manager, and what they share.
+func (c *serviceController) stateDump(caches ackrtcache.Caches) ackrtstatedump.State {
+	state := ackrtstatedump.State{
+		Time:   time.Now(),
+		Kinds:  []ackrtstatedump.Kind{},
+		Caches: caches.Snapshot(),
+		Shared: c.shared.snapshot(),
+	}
+	for _, sc := range c.shared.serviceControllers(c) {
+		for _, rec := range sc.reconcilers {
+			if r, ok := rec.(*resourceReconciler); ok {
+				state.Kinds = append(state.Kinds, r.stateDump())
+			}
+		}
+	}
+	sort.Slice(state.Kinds, func(i, j int) bool {
+		return state.Kinds[i].Kind < state.Kinds[j].Kind
+	})
+	return state
+}
+
+// addStateDumper adds to the supplied manager the runnable dumping the
+// internal state of the service controller on SIGUSR1, keeping the supplied
+// number of the most recent dumps
+func (c *serviceController) addStateDumper(
+	mgr ctrlrt.Manager,
+	dir string,
+	retention int,
+	caches ackrtcache.Caches,
+) error {
+	dumper := ackrtstatedump.NewDumper(
+		c.log.WithName("state-dump"), dir,
+		func() ackrtstatedump.State { return c.stateDump(caches) },
+	).WithRetention(retention, c.metrics)
+	return mgr.Add(dumper)
+}
@@ -0,0 +1,400 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package statedump dumps the internal state of a controller, e.g. the
+// contents of its queues, its in-flight reconciliations and its backoffs, to
+// its logs or to files collected in support bundles.
+package statedump
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrtrequeuestorm "github.com/aws-controllers-k8s/runtime/pkg/runtime/requeuestorm"
+	ackrtresync "github.com/aws-controllers-k8s/runtime/pkg/runtime/resync"
+	ackrtscheduler "github.com/aws-controllers-k8s/runtime/pkg/runtime/scheduler"
+)
+
+// InFlight is a reconciliation in progress
+type InFlight struct {
+	// Resource is the namespace and name of the reconciled resource
+	Resource string `json:"resource"`
+	// Since is the start time of the reconciliation
+	Since time.Time `json:"since"`
+}
+
+// Backoff is the backoff of a resource whose reconciliations are failing
+type Backoff struct {
+	// Resource is the namespace and name of the resource
+	Resource string `json:"resource"`
+	// Failures is the number of consecutive failed reconciliations
+	Failures int `json:"failures"`
+	// Delay is the delay before the next reconciliation
+	Delay string `json:"delay"`
+	// RetryAt is the time of the next reconciliation
+	RetryAt time.Time `json:"retryAt"`
+}
+
+// ManagerFailure is the failure to construct the resource manager of an
+// account and region, whose construction is backed off
+type ManagerFailure struct {
+	// Account is the AWS account ID
+	Account string `json:"account"`
+	// Region is the AWS region
+	Region string `json:"region"`
+	// RoleARN is the IAM role of the resource manager, empty for the
+	// credentials of the controller
+	RoleARN string `json:"roleARN,omitempty"`
+	// Error is the error of the last construction
+	Error string `json:"error"`
+	// Failures is the number of consecutive failed constructions
+	Failures int `json:"failures"`
+	// RetryAt is the time of the next construction
+	RetryAt time.Time `json:"retryAt"`
+	// Namespaces are the namespaces of the resources hitting the failure
+	Namespaces []string `json:"namespaces"`
+}
+
+// Kind is the state of the reconciler of a kind
+type Kind struct {
+	// Kind is the reconciled kind
+	Kind string `json:"kind"`
+	// QueueLength is the number of resources waiting in the work queue
+	QueueLength int `json:"queueLength"`
+	// InFlight contains the reconciliations in progress
+	InFlight []InFlight `json:"inFlight"`
+	// Backoffs contains the backoffs of the failing resources
+	Backoffs []Backoff `json:"backoffs"`
+	// DeferredOperations contains the operations deferred for the resources
+	DeferredOperations []ackrtscheduler.Entry `json:"deferredOperations"`
+	// ResyncBudget is the state of the drift check budget, if any
+	ResyncBudget *ackrtresync.BudgetState `json:"resyncBudget,omitempty"`
+	// RequeueStorms contains the resources reconciled again and again
+	// without their state changing
+	RequeueStorms []ackrtrequeuestorm.Storm `json:"requeueStorms,omitempty"`
+	// ManagerFailures contains the accounts and regions whose resource
+	// manager could not be constructed
+	ManagerFailures []ManagerFailure `json:"managerFailures,omitempty"`
+	// Settings contains the reconciliation settings of the kind
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// State is the state of a controller
+type State struct {
+	// Time is the time of the dump
+	Time time.Time `json:"time"`
+	// Kinds contains the state of the reconcilers, sorted by kind
+	Kinds []Kind `json:"kinds"`
+	// Caches contains the contents of the caches of the controller
+	Caches interface{} `json:"caches,omitempty"`
+	// Shared contains what the service controllers running in the same
+	// binary share
+	Shared *Shared `json:"shared,omitempty"`
+}
+
+// Shared is what the service controllers bound to the same manager share
+type Shared struct {
+	// Services are the aliases of the service controllers, in the order
+	// they were bound to the manager
+	Services []string `json:"services"`
+	// Informers maps the informers of the manager to the sorted aliases of
+	// the service controllers using them
+	Informers map[string][]string `json:"informers"`
+	// Teardowns contains the progress of the deletions of the resources of
+	// each namespace
+	Teardowns interface{} `json:"teardowns,omitempty"`
+}
+
+// backoff is the backoff of a resource, as tracked by a Tracker
+type backoff struct {
+	failures int
+	delay    time.Duration
+	retryAt  time.Time
+}
+
+// Tracker tracks the work queue, the in-flight reconciliations and the
+// backoffs of the reconciler of a kind. A nil Tracker tracks nothing.
+type Tracker struct {
+	sync.Mutex
+	inFlight map[types.NamespacedName]time.Time
+	backoffs map[types.NamespacedName]backoff
+	queue    workqueue.TypedRateLimitingInterface[reconcile.Request]
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewTracker returns an empty Tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		inFlight: map[types.NamespacedName]time.Time{},
+		backoffs: map[types.NamespacedName]backoff{},
+		now:      time.Now,
+	}
+}
+
+// WithClock replaces the clock of the Tracker
+func (t *Tracker) WithClock(now func() time.Time) *Tracker {
+	t.now = now
+	return t
+}
+
+// Begin records the start of the reconciliation of the supplied resource and
+// returns the function recording its end
+func (t *Tracker) Begin(key types.NamespacedName) func() {
+	if t == nil {
+		return func() {}
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.inFlight[key] = t.now()
+	return func() {
+		t.Lock()
+		defer t.Unlock()
+		delete(t.inFlight, key)
+	}
+}
+
+// RateLimiter returns a rate limiter, for the work queue of the reconciler,
+// delegating to the supplied one and recording the backoffs it computes
+func (t *Tracker) RateLimiter(
+	limiter workqueue.TypedRateLimiter[reconcile.Request],
+) workqueue.TypedRateLimiter[reconcile.Request] {
+	return &rateLimiter{tracker: t, limiter: limiter}
+}
+
+// NewQueue returns the work queue of the reconciler and records it, so that
+// its length is reported. It has the signature of the NewQueue controller
+// option.
+func (t *Tracker) NewQueue(
+	controllerName string,
+	limiter workqueue.TypedRateLimiter[reconcile.Request],
+) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		limiter,
+		workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{Name: controllerName},
+	)
+	t.Lock()
+	defer t.Unlock()
+	t.queue = queue
+	return queue
+}
+
+// Snapshot returns the state tracked for the supplied kind, sorted by resource
+func (t *Tracker) Snapshot(kind string) Kind {
+	state := Kind{Kind: kind, InFlight: []InFlight{}, Backoffs: []Backoff{}}
+	if t == nil {
+		return state
+	}
+	t.Lock()
+	defer t.Unlock()
+	if t.queue != nil {
+		state.QueueLength = t.queue.Len()
+	}
+	for key, since := range t.inFlight {
+		state.InFlight = append(state.InFlight, InFlight{Resource: key.String(), Since: since})
+	}
+	sort.Slice(state.InFlight, func(i, j int) bool {
+		return state.InFlight[i].Resource < state.InFlight[j].Resource
+	})
+	for key, b := range t.backoffs {
+		state.Backoffs = append(state.Backoffs, Backoff{
+			Resource: key.String(),
+			Failures: b.failures,
+			Delay:    b.delay.String(),
+			RetryAt:  b.retryAt,
+		})
+	}
+	sort.Slice(state.Backoffs, func(i, j int) bool {
+		return state.Backoffs[i].Resource < state.Backoffs[j].Resource
+	})
+	return state
+}
+
+// rateLimiter is a rate limiter recording the backoffs computed by another
+type rateLimiter struct {
+	tracker *Tracker
+	limiter workqueue.TypedRateLimiter[reconcile.Request]
+}
+
+// When implements workqueue.TypedRateLimiter
+func (l *rateLimiter) When(item reconcile.Request) time.Duration {
+	delay := l.limiter.When(item)
+	if l.tracker == nil {
+		return delay
+	}
+	l.tracker.Lock()
+	defer l.tracker.Unlock()
+	l.tracker.backoffs[item.NamespacedName] = backoff{
+		failures: l.limiter.NumRequeues(item),
+		delay:    delay,
+		retryAt:  l.tracker.now().Add(delay),
+	}
+	return delay
+}
+
+// Forget implements workqueue.TypedRateLimiter
+func (l *rateLimiter) Forget(item reconcile.Request) {
+	l.limiter.Forget(item)
+	if l.tracker == nil {
+		return
+	}
+	l.tracker.Lock()
+	defer l.tracker.Unlock()
+	delete(l.tracker.backoffs, item.NamespacedName)
+}
+
+// NumRequeues implements workqueue.TypedRateLimiter
+func (l *rateLimiter) NumRequeues(item reconcile.Request) int {
+	return l.limiter.NumRequeues(item)
+}
+
+// Dumper dumps the state of the controller when the process receives the
+// dump signal (SIGUSR1), to a file of its directory or, if it has none, to
+// its logs.
+type Dumper struct {
+	log   logr.Logger
+	dir   string
+	state func() State
+	// retention is the number of the most recent dumps kept in the
+	// directory. All the dumps are kept if it is zero.
+	retention int
+	metrics   *ackmetrics.Metrics
+}
+
+// NewDumper returns a Dumper of the state returned by the supplied function,
+// writing to the supplied directory, or to the logs if it is empty.
+func NewDumper(log logr.Logger, dir string, state func() State) *Dumper {
+	return &Dumper{
+		log:   log,
+		dir:   dir,
+		state: state,
+	}
+}
+
+// WithRetention sets the number of the most recent dumps kept in the
+// directory of the Dumper, the older ones being pruned at startup and after
+// each dump, and the metrics the pruned dumps are recorded in
+func (d *Dumper) WithRetention(retention int, metrics *ackmetrics.Metrics) *Dumper {
+	d.retention = retention
+	d.metrics = metrics
+	return d
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The state is
+// dumped on every replica, since each of them has its own.
+func (d *Dumper) NeedLeaderElection() bool {
+	return false
+}
+
+// Start dumps the state of the controller on each dump signal, until the
+// supplied context is done.
+func (d *Dumper) Start(ctx context.Context) error {
+	if len(dumpSignals) == 0 {
+		d.log.Info("state dump signals are not supported on this platform")
+		<-ctx.Done()
+		return nil
+	}
+	// The directory may hold the dumps of the previous runs of the
+	// controller
+	d.prune()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, dumpSignals...)
+	defer signal.Stop(signals)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signals:
+			if _, err := d.Dump(); err != nil {
+				d.log.Error(err, "unable to dump the controller state")
+			}
+		}
+	}
+}
+
+// Dump dumps the state of the controller and returns the path of the file it
+// was written to, empty if it was written to the logs.
+func (d *Dumper) Dump() (string, error) {
+	state := d.state()
+	if d.dir == "" {
+		d.log.Info("controller state", "state", state)
+		return "", nil
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(
+		d.dir, fmt.Sprintf("ack-state-%s.json", state.Time.UTC().Format("20060102T150405.000Z")),
+	)
+	// The state is written to a temporary file first, so that support
+	// bundles never collect partial dumps.
+	tmp, err := os.CreateTemp(d.dir, ".ack-state-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	d.log.Info("dumped the controller state", "path", path)
+	d.prune()
+	return path, nil
+}
+
+// prune removes the dumps of the directory beyond the retention, the oldest
+// first. The names of the dumps sort by time.
+func (d *Dumper) prune() {
+	if d.dir == "" || d.retention <= 0 {
+		return
+	}
+	paths, err := filepath.Glob(filepath.Join(d.dir, "ack-state-*.json"))
+	if err != nil || len(paths) <= d.retention {
+		return
+	}
+	sort.Strings(paths)
+	pruned := 0
+	for _, path := range paths[:len(paths)-d.retention] {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			d.log.Error(err, "unable to prune state dump", "path", path)
+			continue
+		}
+		pruned++
+	}
+	if pruned > 0 {
+		d.log.Info("pruned state dumps", "count", pruned, "retention", d.retention)
+	}
+	if d.metrics != nil {
+		d.metrics.RecordCompanionObjectsPruned(ackmetrics.CompanionObjectStateDump, pruned)
+	}
+}
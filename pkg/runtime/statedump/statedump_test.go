@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package statedump_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/statedump"
+)
+
+func TestTracker(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := statedump.NewTracker().WithClock(func() time.Time { return now })
+	a := types.NamespacedName{Namespace: "ns", Name: "a"}
+	b := types.NamespacedName{Namespace: "ns", Name: "b"}
+
+	endA := tracker.Begin(a)
+	endB := tracker.Begin(b)
+	endB()
+
+	limiter := tracker.RateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](time.Second, time.Minute),
+	)
+	queue := tracker.NewQueue("test", limiter)
+	defer queue.ShutDown()
+	queue.Add(reconcile.Request{NamespacedName: a})
+	require.Equal(time.Second, limiter.When(reconcile.Request{NamespacedName: b}))
+	require.Equal(2*time.Second, limiter.When(reconcile.Request{NamespacedName: b}))
+
+	state := tracker.Snapshot("Bucket")
+	require.Equal("Bucket", state.Kind)
+	require.Equal(1, state.QueueLength)
+	require.Equal([]statedump.InFlight{{Resource: "ns/a", Since: now}}, state.InFlight)
+	require.Equal([]statedump.Backoff{{
+		Resource: "ns/b",
+		Failures: 2,
+		Delay:    "2s",
+		RetryAt:  now.Add(2 * time.Second),
+	}}, state.Backoffs)
+
+	endA()
+	limiter.Forget(reconcile.Request{NamespacedName: b})
+	state = tracker.Snapshot("Bucket")
+	require.Empty(state.InFlight)
+	require.Empty(state.Backoffs)
+
+	var nilTracker *statedump.Tracker
+	nilTracker.Begin(a)()
+	require.Empty(nilTracker.Snapshot("Bucket").InFlight)
+}
+
+func TestDumper(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := statedump.State{
+		Time:  now,
+		Kinds: []statedump.Kind{{Kind: "Bucket", QueueLength: 3}},
+	}
+	dir := t.TempDir()
+	dumper := statedump.NewDumper(logr.Discard(), dir, func() statedump.State { return state })
+	path, err := dumper.Dump()
+	require.Nil(err)
+	require.Equal(filepath.Join(dir, "ack-state-20260101T100000.000Z.json"), path)
+
+	data, err := os.ReadFile(path)
+	require.Nil(err)
+	var dumped statedump.State
+	require.Nil(json.Unmarshal(data, &dumped))
+	require.Equal(state.Kinds[0].Kind, dumped.Kinds[0].Kind)
+	require.Equal(3, dumped.Kinds[0].QueueLength)
+	entries, err := os.ReadDir(dir)
+	require.Nil(err)
+	require.Len(entries, 1)
+
+	path, err = statedump.NewDumper(logr.Discard(), "", func() statedump.State { return state }).Dump()
+	require.Nil(err)
+	require.Empty(path)
+
+	// The dumps beyond the retention are pruned, the oldest first
+	dumper = statedump.NewDumper(logr.Discard(), dir, func() statedump.State { return state }).WithRetention(2, nil)
+	for i := 1; i <= 3; i++ {
+		state.Time = now.Add(time.Duration(i) * time.Minute)
+		_, err = dumper.Dump()
+		require.Nil(err)
+	}
+	entries, err = os.ReadDir(dir)
+	require.Nil(err)
+	require.Len(entries, 2)
+	require.Equal("ack-state-20260101T100200.000Z.json", entries[0].Name())
+	require.Equal("ack-state-20260101T100300.000Z.json", entries[1].Name())
+}
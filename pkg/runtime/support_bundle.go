@@ -0,0 +1,112 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	goruntime "runtime"
+	"runtime/debug"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/supportbundle"
+)
+
+// runtimeModulePath is the path of the module of the ACK runtime
+const runtimeModulePath = "github.com/aws-controllers-k8s/runtime"
+
+// writeSupportBundle writes the support bundle of the service controller to
+// the supplied writer
+func (c *serviceController) writeSupportBundle(
+	ctx context.Context,
+	w io.Writer,
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+	caches ackrtcache.Caches,
+) error {
+	kinds := []schema.GroupVersionKind{}
+	for _, rec := range c.reconcilers {
+		if r, ok := rec.(*resourceReconciler); ok {
+			kinds = append(kinds, r.rd.GroupVersionKind())
+		}
+	}
+	return supportbundle.Write(ctx, w, supportbundle.Source{
+		Metadata: supportbundle.Metadata{
+			Time:    time.Now(),
+			Service: c.ServiceAlias,
+			Versions: supportbundle.Versions{
+				GitVersion: c.VersionInfo.GitVersion,
+				GitCommit:  c.VersionInfo.GitCommit,
+				BuildDate:  c.VersionInfo.BuildDate,
+				Runtime:    runtimeModuleVersion(),
+				Go:         goruntime.Version(),
+			},
+		},
+		Config: func() map[string]interface{} { return supportBundleConfig(cfg) },
+		FeatureGates: func() map[string]bool {
+			gates := map[string]bool{}
+			for name, feature := range cfg.FeatureGates {
+				gates[name] = feature.Enabled
+			}
+			return gates
+		},
+		State: func() interface{} {
+			// The caches hold the role ARNs of the account bindings, which
+			// have no place in a bundle attached to issues
+			state := c.stateDump(caches)
+			state.Caches = nil
+			return state
+		},
+		Kinds:  kinds,
+		Reader: mgr.GetAPIReader(),
+	})
+}
+
+// supportBundleConfig returns the configuration collected in support bundles,
+// keyed by the names of the fields of the supplied configuration. The feature
+// gates are collected separately.
+func supportBundleConfig(cfg ackcfg.Config) map[string]interface{} {
+	values := map[string]interface{}{}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return values
+	}
+	_ = json.Unmarshal(data, &values)
+	delete(values, "FeatureGates")
+	return values
+}
+
+// runtimeModuleVersion returns the version of the ACK runtime module the
+// controller was built with, empty if it is unknown
+func runtimeModuleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == runtimeModulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == runtimeModulePath {
+			return dep.Version
+		}
+	}
+	return ""
+}
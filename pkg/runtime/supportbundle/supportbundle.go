@@ -0,0 +1,378 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package supportbundle collects, in a single gzipped tar archive attached to
+// issues, what is needed to troubleshoot a controller: its sanitized
+// configuration, feature gates and versions, its internal state, a summary of
+// the errors of its resources and a sample of the resources that are not
+// synced. The specs of the resources are never collected.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/redact"
+)
+
+const (
+	// DefaultSamplesPerKind is the default maximum number of problem
+	// resources of each kind collected in a bundle
+	DefaultSamplesPerKind = 10
+	// redacted replaces the sanitized values
+	redacted = "REDACTED"
+)
+
+// urlPattern matches the URLs found in configuration values
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s,]+`)
+
+// Versions contains the versions of the controller
+type Versions struct {
+	// GitVersion is the latest Git tag of the service controller
+	GitVersion string `json:"gitVersion"`
+	// GitCommit is the commit of the service controller
+	GitCommit string `json:"gitCommit"`
+	// BuildDate is when the service controller was built
+	BuildDate string `json:"buildDate"`
+	// Runtime is the version of the ACK runtime module
+	Runtime string `json:"runtime,omitempty"`
+	// Go is the version of Go the controller was built with
+	Go string `json:"go"`
+}
+
+// Metadata describes the controller a bundle was collected from
+type Metadata struct {
+	// Time is when the bundle was collected
+	Time time.Time `json:"time"`
+	// Service is the alias of the service API of the controller, e.g. "s3"
+	Service string `json:"service"`
+	// Versions contains the versions of the controller
+	Versions Versions `json:"versions"`
+}
+
+// ErrorSummary groups the resources failing with the same error
+type ErrorSummary struct {
+	// Message is the normalized message of the error
+	Message string `json:"message"`
+	// Count is the number of resources failing with the error
+	Count int `json:"count"`
+	// Kinds contains the kinds of the resources failing with the error
+	Kinds []string `json:"kinds"`
+}
+
+// ProblemResource is a resource that is not synced. Only the metadata and
+// the conditions of the resource are collected.
+type ProblemResource struct {
+	Kind        string                 `json:"kind"`
+	Namespace   string                 `json:"namespace"`
+	Name        string                 `json:"name"`
+	Generation  int64                  `json:"generation"`
+	Annotations map[string]string      `json:"annotations,omitempty"`
+	Conditions  []interface{}          `json:"conditions,omitempty"`
+	Metadata    map[string]interface{} `json:"ackResourceMetadata,omitempty"`
+}
+
+// Source supplies the contents of a bundle
+type Source struct {
+	// Metadata describes the controller
+	Metadata Metadata
+	// Config returns the configuration of the controller. Its values are
+	// sanitized with Sanitize before they are collected.
+	Config func() map[string]interface{}
+	// FeatureGates returns the feature gates of the controller, keyed by
+	// name
+	FeatureGates func() map[string]bool
+	// State returns the internal state of the controller, e.g. its work
+	// queues and backoffs
+	State func() interface{}
+	// Kinds contains the kinds reconciled by the controller
+	Kinds []schema.GroupVersionKind
+	// Reader lists the resources of the reconciled kinds
+	Reader client.Reader
+	// SamplesPerKind is the maximum number of problem resources of each kind
+	// collected. DefaultSamplesPerKind is used if it is not positive.
+	SamplesPerKind int
+}
+
+// Write writes the bundle of the supplied source, as a gzipped tar archive,
+// to the supplied writer
+func Write(ctx context.Context, w io.Writer, src Source) error {
+	problems, summaries, err := collectProblems(ctx, src)
+	if err != nil {
+		return err
+	}
+	files := []struct {
+		name  string
+		value interface{}
+	}{
+		{"metadata.json", src.Metadata},
+		{"config.json", Sanitize(call(src.Config))},
+		{"feature-gates.json", call(src.FeatureGates)},
+		{"state.json", call(src.State)},
+		{"errors.json", summaries},
+		{"problem-resources.json", problems},
+	}
+	dir := fmt.Sprintf("ack-support-bundle-%s", src.Metadata.Time.UTC().Format("20060102T150405Z"))
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to encode %s: %v", f.name, err)
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    dir + "/" + f.name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: src.Metadata.Time,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// call returns the value returned by the supplied function, an empty map if
+// the function is nil
+func call[T any](fn func() T) interface{} {
+	if fn == nil {
+		return map[string]interface{}{}
+	}
+	return fn()
+}
+
+// collectProblems lists the resources of the reconciled kinds and returns a
+// sample of the ones that are not synced, along with the summary of their
+// errors, sorted by decreasing number of resources
+func collectProblems(
+	ctx context.Context,
+	src Source,
+) ([]ProblemResource, []ErrorSummary, error) {
+	problems := []ProblemResource{}
+	summaries := []ErrorSummary{}
+	if src.Reader == nil {
+		return problems, summaries, nil
+	}
+	samples := src.SamplesPerKind
+	if samples <= 0 {
+		samples = DefaultSamplesPerKind
+	}
+	byMessage := map[string]*ErrorSummary{}
+	for _, gvk := range src.Kinds {
+		list := &k8sunstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := src.Reader.List(ctx, list); err != nil {
+			return nil, nil, fmt.Errorf("unable to list %s resources: %v", gvk.Kind, err)
+		}
+		sampled := 0
+		for i := range list.Items {
+			u := &list.Items[i]
+			conditions, _, _ := k8sunstructured.NestedSlice(u.Object, "status", "conditions")
+			conditions = redactConditions(gvk.GroupKind(), u.Object, conditions)
+			message, synced := problemMessage(conditions)
+			if synced {
+				continue
+			}
+			summary, ok := byMessage[message]
+			if !ok {
+				summary = &ErrorSummary{Message: message, Kinds: []string{}}
+				byMessage[message] = summary
+			}
+			summary.Count++
+			if len(summary.Kinds) == 0 || summary.Kinds[len(summary.Kinds)-1] != gvk.Kind {
+				summary.Kinds = append(summary.Kinds, gvk.Kind)
+			}
+			if sampled >= samples {
+				continue
+			}
+			sampled++
+			metadata, _, _ := k8sunstructured.NestedMap(u.Object, "status", "ackResourceMetadata")
+			problems = append(problems, ProblemResource{
+				Kind:        gvk.Kind,
+				Namespace:   u.GetNamespace(),
+				Name:        u.GetName(),
+				Generation:  u.GetGeneration(),
+				Annotations: ackAnnotations(u),
+				Conditions:  conditions,
+				Metadata:    metadata,
+			})
+		}
+	}
+	for _, summary := range byMessage {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Message < summaries[j].Message
+	})
+	return problems, summaries, nil
+}
+
+// problemMessage returns the normalized message explaining why a resource
+// with the supplied conditions is not synced, and whether it is synced
+func problemMessage(conditions []interface{}) (string, bool) {
+	synced := false
+	message := ""
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		status, _ := cm["status"].(string)
+		reason, _ := cm["reason"].(string)
+		switch ackv1alpha1.ConditionType(fmt.Sprint(cm["type"])) {
+		case ackv1alpha1.ConditionTypeResourceSynced:
+			synced = status == string(corev1.ConditionTrue)
+			if message == "" {
+				message = reason
+			}
+		case ackv1alpha1.ConditionTypeTerminal, ackv1alpha1.ConditionTypeRecoverable:
+			// The terminal and recoverable conditions carry the error of the
+			// resource, and take precedence over the reason of the synced one
+			if status == string(corev1.ConditionTrue) {
+				if reason == "" {
+					reason, _ = cm["message"].(string)
+				}
+				message = reason
+				synced = false
+			}
+		}
+	}
+	if message == "" {
+		message = "unknown"
+	}
+	return ackerr.NormalizeMessage(message), synced
+}
+
+// redactConditions returns the supplied conditions of the supplied resource
+// of the supplied kind, with the values of the sensitive fields of the
+// resource echoed in their reasons and messages redacted
+func redactConditions(
+	gk schema.GroupKind,
+	obj map[string]interface{},
+	conditions []interface{},
+) []interface{} {
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range []string{"reason", "message"} {
+			if value, ok := cm[field].(string); ok {
+				cm[field] = redact.Message(gk, obj, value)
+			}
+		}
+	}
+	return conditions
+}
+
+// ackAnnotations returns the ACK annotations of the supplied resource
+func ackAnnotations(u *k8sunstructured.Unstructured) map[string]string {
+	annotations := map[string]string{}
+	for k, v := range u.GetAnnotations() {
+		if strings.HasPrefix(k, ackv1alpha1.AnnotationPrefix) {
+			annotations[k] = v
+		}
+	}
+	return annotations
+}
+
+// Sanitize returns a copy of the supplied configuration in which credentials
+// are redacted: the user information and query of URLs, and the values whose
+// key suggests a secret, e.g. "token" or "password".
+func Sanitize(config interface{}) interface{} {
+	return sanitize("", config)
+}
+
+func sanitize(key string, value interface{}) interface{} {
+	if isSecretKey(key) {
+		return redacted
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			out[k] = sanitize(k, vv)
+		}
+		return out
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = sanitizeString(s)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, vv := range v {
+			out[i] = sanitize("", vv)
+		}
+		return out
+	case string:
+		return sanitizeString(v)
+	}
+	return value
+}
+
+// isSecretKey returns true if the supplied configuration key suggests its
+// value is a secret
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range []string{"token", "password", "secret", "credential"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeString redacts the user information and query of the URLs found in
+// the supplied string
+func sanitizeString(s string) string {
+	return urlPattern.ReplaceAllStringFunc(s, func(raw string) string {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return raw
+		}
+		if u.User != nil {
+			u.User = url.User(redacted)
+		}
+		if u.RawQuery != "" {
+			u.RawQuery = redacted
+		}
+		return u.String()
+	})
+}
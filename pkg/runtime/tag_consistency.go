@@ -0,0 +1,203 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+const (
+	// tagConsistencyWindow is the time after a mutation of the tags of a
+	// resource during which reads not observing it are considered stale.
+	// Past it, the differences of the tags are drift.
+	tagConsistencyWindow = 2 * time.Minute
+	// tagConsistencyRereads is the number of times a resource whose latest
+	// observed tags do not reflect their last mutation is read again
+	tagConsistencyRereads = 3
+	// tagConsistencyRereadDelay is the delay before the first read of a
+	// resource whose latest observed tags do not reflect their last
+	// mutation. It doubles on each read.
+	tagConsistencyRereadDelay = 250 * time.Millisecond
+)
+
+// tagMutation is a mutation of the tags of a resource that its reads may not
+// reflect yet, AWS tagging APIs being eventually consistent
+type tagMutation struct {
+	// set are the tags set by the mutation
+	set acktags.Tags
+	// removed are the tags removed by the mutation
+	removed acktags.Tags
+	// at is the time of the mutation
+	at time.Time
+}
+
+// observedIn returns true if the supplied tags reflect the mutation
+func (m tagMutation) observedIn(tags acktags.Tags) bool {
+	for key, value := range m.set {
+		if observed, ok := tags[key]; !ok || observed != value {
+			return false
+		}
+	}
+	for key := range m.removed {
+		if _, ok := tags[key]; ok {
+			if _, set := m.set[key]; !set {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// tagMutations holds, for each resource, the last mutation of its tags not
+// observed yet. A nil tagMutations holds nothing.
+type tagMutations struct {
+	sync.Mutex
+	pending map[types.NamespacedName]tagMutation
+}
+
+// newTagMutations returns an empty tagMutations
+func newTagMutations() *tagMutations {
+	return &tagMutations{pending: map[types.NamespacedName]tagMutation{}}
+}
+
+// record records the mutation of the tags of the supplied resource from the
+// supplied tags to the supplied tags. Mutations changing nothing are not
+// recorded.
+func (m *tagMutations) record(key types.NamespacedName, from, to acktags.Tags) {
+	if m == nil {
+		return
+	}
+	added, _, removed := ackcompare.GetTagsDifference(from, to)
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.pending[key] = tagMutation{set: added, removed: removed, at: time.Now()}
+}
+
+// get returns the last mutation of the tags of the supplied resource not
+// observed yet, if it happened during the consistency window
+func (m *tagMutations) get(key types.NamespacedName) (tagMutation, bool) {
+	if m == nil {
+		return tagMutation{}, false
+	}
+	m.Lock()
+	defer m.Unlock()
+	mutation, ok := m.pending[key]
+	if ok && time.Since(mutation.at) > tagConsistencyWindow {
+		delete(m.pending, key)
+		return tagMutation{}, false
+	}
+	return mutation, ok
+}
+
+// forget forgets the mutation of the tags of the supplied resource
+func (m *tagMutations) forget(key types.NamespacedName) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	delete(m.pending, key)
+}
+
+// resourceKey returns the namespaced name of the supplied resource
+func resourceKey(res acktypes.AWSResource) types.NamespacedName {
+	metaObj := res.MetaObject()
+	return types.NamespacedName{Namespace: metaObj.GetNamespace(), Name: metaObj.GetName()}
+}
+
+// recordTagMutation records the mutation of the tags of the supplied
+// resource made by its update from the supplied latest observed state to the
+// supplied desired state
+func (r *resourceReconciler) recordTagMutation(
+	rm acktypes.AWSResourceManager,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) {
+	getter, ok := rm.(acktypes.AWSResourceTagGetter)
+	if !ok {
+		return
+	}
+	r.tagMutations.record(resourceKey(desired), getter.GetTags(latest), getter.GetTags(desired))
+}
+
+// awaitTagConsistency returns the latest observed state of the supplied
+// resource once it reflects the last mutation of its tags. AWS tagging APIs
+// being eventually consistent, a read following a mutation of the tags may
+// return the tags from before the mutation, which would be reported as drift
+// and mutated again. The resource is read again a few times, with backoff,
+// before its tags are compared with their desired state. If the mutation is
+// still not observed, the last observed state is returned and the differences
+// are considered drift.
+func (r *resourceReconciler) awaitTagConsistency(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	latest acktypes.AWSResource,
+) acktypes.AWSResource {
+	getter, ok := rm.(acktypes.AWSResourceTagGetter)
+	if !ok || ackcompare.IsNil(latest) {
+		return latest
+	}
+	key := resourceKey(latest)
+	mutation, ok := r.tagMutations.get(key)
+	if !ok {
+		return latest
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	kind := r.rd.GroupVersionKind().Kind
+	observed := latest
+	delay := tagConsistencyRereadDelay
+	for attempt := 0; ; attempt++ {
+		if mutation.observedIn(getter.GetTags(observed)) {
+			r.tagMutations.forget(key)
+			if r.metrics != nil {
+				r.metrics.RecordTagConsistency(kind, time.Since(mutation.at), true)
+			}
+			return observed
+		}
+		if attempt == tagConsistencyRereads {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return observed
+		case <-time.After(delay):
+		}
+		delay *= 2
+		rlog.Debug("latest observed tags do not reflect their last mutation, reading resource again")
+		reread, err := rm.ReadOne(ctx, observed)
+		if err != nil {
+			rlog.Debug("unable to read resource again", "error", err)
+			return observed
+		}
+		observed = reread
+	}
+	rlog.Info("latest observed tags still do not reflect their last mutation, considering them drifted")
+	r.tagMutations.forget(key)
+	if r.metrics != nil {
+		r.metrics.RecordTagConsistency(kind, time.Since(mutation.at), false)
+	}
+	return observed
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	ctrlrtclientmock "github.com/aws-controllers-k8s/runtime/mocks/controller-runtime/pkg/client"
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+func TestReconcilerUpdate_TagConsistency(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.Tags", acktags.Tags{}, acktags.Tags{"team": "a"})
+
+	desired, _, _ := resourceMocks()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+
+	// stale is the state read before the update of the tags is observed,
+	// fresh the one read after
+	stale, _, _ := resourceMocks()
+	stale.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	stale.On("ReplaceConditions", mock.Anything).Return()
+	fresh, _, _ := resourceMocks()
+	fresh.On("Conditions").Return([]*ackv1alpha1.Condition{})
+	fresh.On("ReplaceConditions", mock.Anything).Return()
+
+	base := &ackmocks.AWSResourceManager{}
+	base.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+	base.On("ClearResolvedReferences", desired).Return(desired)
+	base.On("ClearResolvedReferences", fresh).Return(fresh)
+	base.On("ReadOne", ctx, desired).Return(stale, nil)
+	base.On("ReadOne", ctx, stale).Return(fresh, nil)
+	base.On("Update", ctx, desired, stale, delta).Return(fresh, nil)
+	base.On("LateInitialize", ctx, fresh).Return(fresh, nil)
+	base.On("IsSynced", ctx, fresh).Return(true, nil)
+	tagGetter := &ackmocks.AWSResourceTagGetter{}
+	tagGetter.On("GetTags", desired).Return(acktags.Tags{"team": "a"})
+	tagGetter.On("GetTags", stale).Return(acktags.Tags{})
+	tagGetter.On("GetTags", fresh).Return(acktags.Tags{"team": "a"})
+	rm := &taggedResourceManager{base, tagGetter}
+
+	rmf, rd := managedResourceManagerFactoryMocks(desired, stale)
+	rd.On("IsManaged", desired).Return(true)
+	rd.On("IsManaged", fresh).Return(true)
+	rd.On("Delta", desired, stale).Return(delta)
+	rd.On("Delta", desired, fresh).Return(ackcompare.NewDelta())
+	rd.On("Delta", fresh, fresh).Return(ackcompare.NewDelta())
+
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	sc := &ackmocks.ServiceController{}
+	scmd := acktypes.ServiceControllerMetadata{}
+	sc.On("GetMetadata").Return(scmd)
+	kc := &ctrlrtclientmock.Client{}
+	r := ackrt.NewReconcilerWithClient(
+		sc, kc, rmf, ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions)),
+		ackcfg.Config{}, ackmetrics.NewMetrics("bookstore"), ackrtcache.Caches{},
+	)
+	base.On("EnsureTags", ctx, desired, scmd).Return(nil)
+
+	// The first reconciliation updates the tags
+	_, err := r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	base.AssertNumberOfCalls(t, "Update", 1)
+	base.AssertNotCalled(t, "ReadOne", ctx, stale)
+
+	// The next one reads the resource again rather than updating its tags
+	// again, the first read not reflecting their update yet
+	_, err = r.Sync(ctx, rm, desired)
+	require.Nil(err)
+	base.AssertNumberOfCalls(t, "Update", 1)
+	base.AssertCalled(t, "ReadOne", ctx, stale)
+}
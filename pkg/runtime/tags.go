@@ -84,7 +84,7 @@ func GetDefaultTags(
 	md acktypes.ServiceControllerMetadata,
 ) acktags.Tags {
 	defaultTags := acktags.NewTags()
-	if obj == nil || config == nil || len(config.ResourceTags) == 0 {
+	if obj == nil || config == nil || (len(config.ResourceTags) == 0 && config.ClusterID == "") {
 		return defaultTags
 	}
 	for _, tagKeyVal := range config.ResourceTags {
@@ -99,6 +99,9 @@ func GetDefaultTags(
 		}
 		defaultTags[key] = expandTagValue(val, obj, md)
 	}
+	if config.ClusterID != "" {
+		defaultTags[acktags.ClusterIDTagKey] = config.ClusterID
+	}
 	return defaultTags
 }
 
@@ -86,4 +86,11 @@ func TestGetDefaultTags(t *testing.T) {
 	assert.Equal("s3-v0.0.10", expandedTags["services.k8s.aws/controller-version"])
 	assert.Equal("ns", expandedTags["services.k8s.aws/namespace"])
 	assert.Equal("res", expandedTags["services.k8s.aws/name"])
+
+	// cluster identity tag
+	cfg.ResourceTags = nil
+	cfg.ClusterID = "blue"
+	expandedTags = runtime.GetDefaultTags(&cfg, &obj, md)
+	assert.Equal(1, len(expandedTags))
+	assert.Equal("blue", expandedTags[acktags.ClusterIDTagKey])
 }
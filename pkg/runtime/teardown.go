@@ -0,0 +1,89 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/teardown"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// deletionSlotRequeueAfter is the delay after which the deletion of a
+// resource waiting on a deletion slot is attempted again, unless a slot is
+// released before
+const deletionSlotRequeueAfter = 15 * time.Second
+
+// errDeletionSlotsTaken is the error of the deletions waiting on a deletion
+// slot
+var errDeletionSlotsTaken = errors.New("the maximum number of AWS resources are being deleted")
+
+// waitDelete records that the deletion of the supplied resource waits on the
+// deletion of the resources referencing it
+func (r *resourceReconciler) waitDelete(res acktypes.AWSResource) {
+	metaObj := res.MetaObject()
+	r.deletions.Wait(r.objectRef(metaObj.GetNamespace(), metaObj.GetName()))
+}
+
+// beginDelete takes a deletion slot for the AWS resource of the supplied
+// resource, returning a requeue error if none is free
+func (r *resourceReconciler) beginDelete(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) error {
+	metaObj := res.MetaObject()
+	if r.deletions.Begin(r.objectRef(metaObj.GetNamespace(), metaObj.GetName())) {
+		return nil
+	}
+	ackrtlog.FromContext(ctx).Debug("waiting on a deletion slot")
+	return requeue.NeededAfter(errDeletionSlotsTaken, deletionSlotRequeueAfter)
+}
+
+// endDelete releases the deletion slot of the supplied resource, whose
+// deletion attempt failed with the supplied error, and reconciles the
+// resources its outcome unblocks: the next resource waiting on a deletion
+// slot and, if the AWS resource was deleted, the resources of the namespace
+// whose deletions failed, e.g. with a DependencyViolation error, so that they
+// are attempted again without waiting for their backoff.
+func (r *resourceReconciler) endDelete(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	err error,
+) {
+	outcome := teardown.OutcomeDeleted
+	switch {
+	case err == nil:
+	case ackerr.ErrorCode(err) == "DependencyViolation":
+		outcome = teardown.OutcomeDependencyViolation
+	default:
+		outcome = teardown.OutcomeRetry
+	}
+	metaObj := res.MetaObject()
+	wake := r.deletions.End(r.objectRef(metaObj.GetNamespace(), metaObj.GetName()), outcome)
+	if progress, ok := r.deletions.Progress(metaObj.GetNamespace()); ok && outcome != teardown.OutcomeRetry {
+		ackrtlog.FromContext(ctx).Info(
+			"namespace teardown progress",
+			"outcome", outcome,
+			"deleted", progress.Deleted,
+			"deleting", progress.Deleting,
+			"waiting", progress.Waiting,
+		)
+	}
+	r.enqueueObjects(ctx, wake)
+}
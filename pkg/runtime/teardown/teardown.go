@@ -0,0 +1,299 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package teardown coordinates the deletions of the AWS resources of the ACK
+// resources deleted together, e.g. when their namespace is deleted. The
+// reconcilers order the deletions by the references between the resources;
+// the Coordinator bounds how many AWS resources are deleted at once, wakes up
+// the deletions waiting on the deletion of other resources of their namespace
+// as soon as one completes, and reports the progress of each namespace.
+package teardown
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+)
+
+// Outcome is the outcome of the deletion attempt of an AWS resource
+type Outcome string
+
+const (
+	// OutcomeDeleted is the outcome of the deletions that completed
+	OutcomeDeleted Outcome = "Deleted"
+	// OutcomeDependencyViolation is the outcome of the deletions rejected
+	// because other AWS resources depend on the AWS resource
+	OutcomeDependencyViolation Outcome = "DependencyViolation"
+	// OutcomeRetry is the outcome of the deletions that failed otherwise, or
+	// that complete asynchronously
+	OutcomeRetry Outcome = "Retry"
+)
+
+// Progress is the progress of the teardown of the ACK resources of a
+// namespace, from the first deletion of its current teardown
+type Progress struct {
+	// StartedAt is the time of the first deletion attempt
+	StartedAt time.Time `json:"startedAt"`
+	// FinishedAt is the time the last deletion completed, nil while
+	// resources are still being deleted
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Waiting is the number of resources waiting on the deletion of other
+	// resources or on a deletion slot
+	Waiting int `json:"waiting"`
+	// Deleting is the number of resources whose AWS resources are being
+	// deleted
+	Deleting int `json:"deleting"`
+	// Deleted is the number of resources whose AWS resources were deleted
+	Deleted int `json:"deleted"`
+	// DependencyViolations is the number of deletions rejected because other
+	// AWS resources depended on the AWS resource
+	DependencyViolations int `json:"dependencyViolations"`
+}
+
+// teardown is the teardown of a namespace
+type teardown struct {
+	progress Progress
+	// waiting holds the resources waiting on the deletion of other
+	// resources of the namespace
+	waiting map[ackrtcache.ObjectRef]struct{}
+	// deleting holds the resources being deleted
+	deleting map[ackrtcache.ObjectRef]struct{}
+}
+
+// Coordinator coordinates the deletions of the AWS resources of the ACK
+// resources. A nil Coordinator coordinates nothing and never bounds the
+// deletions.
+type Coordinator struct {
+	sync.Mutex
+	// maxConcurrent is the maximum number of AWS resources deleted at once,
+	// 0 if unbounded
+	maxConcurrent int
+	// inFlight is the number of AWS resources being deleted
+	inFlight int
+	// slotWaiters are the resources waiting on a deletion slot, in the
+	// order they asked for one
+	slotWaiters []ackrtcache.ObjectRef
+	// teardowns holds the teardown of each namespace
+	teardowns map[string]*teardown
+	// now returns the current time
+	now func() time.Time
+}
+
+// NewCoordinator returns a Coordinator deleting at most the supplied number of
+// AWS resources at once, any number of them if it is 0
+func NewCoordinator(maxConcurrent int) *Coordinator {
+	return &Coordinator{
+		maxConcurrent: maxConcurrent,
+		teardowns:     map[string]*teardown{},
+		now:           time.Now,
+	}
+}
+
+// teardownOf returns the teardown of the namespace of the supplied resource,
+// starting a new one if the previous one finished
+func (c *Coordinator) teardownOf(ref ackrtcache.ObjectRef) *teardown {
+	t, ok := c.teardowns[ref.Namespace]
+	if !ok || t.progress.FinishedAt != nil {
+		t = &teardown{
+			progress: Progress{StartedAt: c.now()},
+			waiting:  map[ackrtcache.ObjectRef]struct{}{},
+			deleting: map[ackrtcache.ObjectRef]struct{}{},
+		}
+		c.teardowns[ref.Namespace] = t
+	}
+	return t
+}
+
+// Wait records that the deletion of the supplied resource waits on the
+// deletion of other resources, e.g. the resources referencing it
+func (c *Coordinator) Wait(ref ackrtcache.ObjectRef) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	t := c.teardownOf(ref)
+	t.waiting[ref] = struct{}{}
+	t.update(c.now())
+}
+
+// Begin returns true if the AWS resource of the supplied resource can be
+// deleted now, in which case the caller calls End once the deletion attempt
+// is over. It returns false if all the deletion slots are taken, the
+// resource then being woken up by End once one is released.
+func (c *Coordinator) Begin(ref ackrtcache.ObjectRef) bool {
+	if c == nil {
+		return true
+	}
+	c.Lock()
+	defer c.Unlock()
+	t := c.teardownOf(ref)
+	if _, ok := t.deleting[ref]; ok {
+		return true
+	}
+	if c.maxConcurrent > 0 && c.inFlight >= c.maxConcurrent {
+		t.waiting[ref] = struct{}{}
+		t.update(c.now())
+		c.addSlotWaiter(ref)
+		return false
+	}
+	c.inFlight++
+	c.removeSlotWaiter(ref)
+	delete(t.waiting, ref)
+	t.deleting[ref] = struct{}{}
+	t.update(c.now())
+	return true
+}
+
+// End records the outcome of the deletion attempt of the AWS resource of the
+// supplied resource, releasing its deletion slot, and returns the resources
+// to wake up: the next resource waiting on a deletion slot, and the resources
+// of the namespace waiting on the deletion of other resources if the AWS
+// resource was deleted.
+func (c *Coordinator) End(ref ackrtcache.ObjectRef, outcome Outcome) []ackrtcache.ObjectRef {
+	if c == nil {
+		return nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	t := c.teardownOf(ref)
+	if _, ok := t.deleting[ref]; ok {
+		delete(t.deleting, ref)
+		c.inFlight--
+	}
+	wake := []ackrtcache.ObjectRef{}
+	if len(c.slotWaiters) > 0 {
+		wake = append(wake, c.slotWaiters[0])
+	}
+	switch outcome {
+	case OutcomeDeleted:
+		delete(t.waiting, ref)
+		t.progress.Deleted++
+		for waiter := range t.waiting {
+			wake = append(wake, waiter)
+		}
+	case OutcomeDependencyViolation:
+		t.waiting[ref] = struct{}{}
+		t.progress.DependencyViolations++
+	default:
+		t.waiting[ref] = struct{}{}
+	}
+	t.update(c.now())
+	return dedupe(wake)
+}
+
+// Forget removes the supplied resource from the teardown of its namespace,
+// e.g. once its deletion is cancelled or it is deleted without its AWS
+// resource
+func (c *Coordinator) Forget(ref ackrtcache.ObjectRef) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	t, ok := c.teardowns[ref.Namespace]
+	if !ok {
+		return
+	}
+	c.removeSlotWaiter(ref)
+	_, deleting := t.deleting[ref]
+	_, waiting := t.waiting[ref]
+	if !deleting && !waiting {
+		return
+	}
+	if deleting {
+		delete(t.deleting, ref)
+		c.inFlight--
+	}
+	delete(t.waiting, ref)
+	t.update(c.now())
+}
+
+// Progress returns the progress of the teardown of the supplied namespace,
+// false if no resource of the namespace was deleted
+func (c *Coordinator) Progress(namespace string) (Progress, bool) {
+	if c == nil {
+		return Progress{}, false
+	}
+	c.Lock()
+	defer c.Unlock()
+	t, ok := c.teardowns[namespace]
+	if !ok {
+		return Progress{}, false
+	}
+	return t.progress, true
+}
+
+// Snapshot returns the progress of the teardown of each namespace
+func (c *Coordinator) Snapshot() map[string]Progress {
+	snapshot := map[string]Progress{}
+	if c == nil {
+		return snapshot
+	}
+	c.Lock()
+	defer c.Unlock()
+	for namespace, t := range c.teardowns {
+		snapshot[namespace] = t.progress
+	}
+	return snapshot
+}
+
+// addSlotWaiter adds the supplied resource to the resources waiting on a
+// deletion slot, unless it already waits on one
+func (c *Coordinator) addSlotWaiter(ref ackrtcache.ObjectRef) {
+	for _, waiter := range c.slotWaiters {
+		if waiter == ref {
+			return
+		}
+	}
+	c.slotWaiters = append(c.slotWaiters, ref)
+}
+
+// removeSlotWaiter removes the supplied resource from the resources waiting
+// on a deletion slot
+func (c *Coordinator) removeSlotWaiter(ref ackrtcache.ObjectRef) {
+	for i, waiter := range c.slotWaiters {
+		if waiter == ref {
+			c.slotWaiters = append(c.slotWaiters[:i], c.slotWaiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// update updates the counts of the progress of the teardown, finishing it at
+// the supplied time if no resource is left
+func (t *teardown) update(now time.Time) {
+	t.progress.Waiting = len(t.waiting)
+	t.progress.Deleting = len(t.deleting)
+	if t.progress.Waiting == 0 && t.progress.Deleting == 0 {
+		t.progress.FinishedAt = &now
+	}
+}
+
+// dedupe returns the supplied resources without duplicates, sorted
+func dedupe(refs []ackrtcache.ObjectRef) []ackrtcache.ObjectRef {
+	set := make(map[ackrtcache.ObjectRef]struct{}, len(refs))
+	for _, ref := range refs {
+		set[ref] = struct{}{}
+	}
+	deduped := make([]ackrtcache.ObjectRef, 0, len(set))
+	for ref := range set {
+		deduped = append(deduped, ref)
+	}
+	sort.Slice(deduped, func(i, j int) bool {
+		return deduped[i].String() < deduped[j].String()
+	})
+	return deduped
+}
@@ -0,0 +1,94 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package teardown_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/teardown"
+)
+
+func ref(kind, namespace, name string) ackrtcache.ObjectRef {
+	return ackrtcache.ObjectRef{
+		GroupKind:      schema.GroupKind{Group: "ec2.services.k8s.aws", Kind: kind},
+		NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
+	}
+}
+
+func TestCoordinator_BoundsDeletions(t *testing.T) {
+	require := require.New(t)
+	c := teardown.NewCoordinator(1)
+
+	subnet := ref("Subnet", "stack", "a")
+	sg := ref("SecurityGroup", "stack", "web")
+	require.True(c.Begin(subnet))
+	require.True(c.Begin(subnet))
+	require.False(c.Begin(sg))
+
+	progress, ok := c.Progress("stack")
+	require.True(ok)
+	require.Equal(1, progress.Deleting)
+	require.Equal(1, progress.Waiting)
+
+	require.Equal([]ackrtcache.ObjectRef{sg}, c.End(subnet, teardown.OutcomeRetry))
+	require.True(c.Begin(sg))
+	require.False(c.Begin(subnet))
+}
+
+func TestCoordinator_WakesDependencyViolations(t *testing.T) {
+	require := require.New(t)
+	c := teardown.NewCoordinator(0)
+
+	vpc := ref("VPC", "stack", "main")
+	subnet := ref("Subnet", "stack", "a")
+	other := ref("Subnet", "other", "b")
+	require.True(c.Begin(vpc))
+	require.True(c.Begin(subnet))
+	require.True(c.Begin(other))
+
+	require.Empty(c.End(vpc, teardown.OutcomeDependencyViolation))
+	require.Equal([]ackrtcache.ObjectRef{vpc}, c.End(subnet, teardown.OutcomeDeleted))
+
+	progress, _ := c.Progress("stack")
+	require.Equal(1, progress.Waiting)
+	require.Equal(1, progress.Deleted)
+	require.Equal(1, progress.DependencyViolations)
+	require.Nil(progress.FinishedAt)
+
+	require.True(c.Begin(vpc))
+	require.Empty(c.End(vpc, teardown.OutcomeDeleted))
+	progress, _ = c.Progress("stack")
+	require.Equal(2, progress.Deleted)
+	require.NotNil(progress.FinishedAt)
+
+	c.Forget(other)
+	require.Len(c.Snapshot(), 2)
+	progress, _ = c.Progress("other")
+	require.NotNil(progress.FinishedAt)
+	require.Equal(0, progress.Deleted)
+}
+
+func TestCoordinator_Nil(t *testing.T) {
+	require := require.New(t)
+
+	var c *teardown.Coordinator
+	require.True(c.Begin(ref("VPC", "stack", "main")))
+	require.Empty(c.End(ref("VPC", "stack", "main"), teardown.OutcomeDeleted))
+	require.Empty(c.Snapshot())
+}
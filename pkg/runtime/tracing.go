@@ -0,0 +1,112 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/tracing"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// addTracing adds to the supplied manager the tracer provider exporting the
+// spans of the reconciliations of the service controllers to the endpoint of
+// the --tracing-endpoint flag
+func (c *serviceController) addTracing(mgr ctrlrt.Manager, cfg ackcfg.Config) error {
+	provider, err := tracing.NewOTLP(
+		context.TODO(), cfg.TracingEndpoint, cfg.TracingSamplingRatio,
+		"ack-"+c.ServiceAlias+"-controller", c.GitVersion,
+	)
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(provider); err != nil {
+		return err
+	}
+	c.shared.setTracer(provider)
+	return nil
+}
+
+// startReconcileSpan starts the span of the reconciliation of the resource
+// of the supplied request, and returns the context of the reconciliation
+// carrying it
+func (r *resourceReconciler) startReconcileSpan(
+	ctx context.Context,
+	req ctrlrt.Request,
+) (context.Context, trace.Span) {
+	kind := r.rd.GroupVersionKind().Kind
+	return r.tracer.Tracer().Start(
+		ctx, "Reconcile "+kind,
+		trace.WithAttributes(
+			tracing.AttributeKind.String(kind),
+			semconv.K8SNamespaceName(req.Namespace),
+			tracing.AttributeName.String(req.Name),
+		),
+	)
+}
+
+// traceResource adds to the span of the supplied context of a reconciliation
+// the account and region of the reconciled resource, and the link to the span
+// of the request of its services.k8s.aws/traceparent annotation
+func traceResource(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		semconv.CloudAccountID(string(acctID)),
+		semconv.CloudRegion(string(region)),
+	)
+	if link, ok := tracing.Link(res.MetaObject().GetAnnotations()); ok {
+		span.AddLink(link)
+	}
+}
+
+// endSpan ends the supplied span of a reconciliation, failed with the
+// supplied error if it is not nil
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// withTracing returns a copy of the supplied configuration of the AWS service
+// clients starting a span for each of their calls in the supplied account and
+// region, child of the span of the reconciliation making it
+func (r *reconciler) withTracing(
+	cfg aws.Config,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) aws.Config {
+	if r.tracer == nil {
+		return cfg
+	}
+	apiOptions := make([]func(*middleware.Stack) error, 0, len(cfg.APIOptions)+1)
+	apiOptions = append(apiOptions, cfg.APIOptions...)
+	cfg.APIOptions = append(apiOptions, r.tracer.Middleware(string(acctID), string(region)))
+	return cfg
+}
@@ -0,0 +1,198 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package tracing traces the reconciliations of the resources, and the calls
+// to the AWS service APIs they make, with OpenTelemetry. The spans are
+// exported to an OTLP/HTTP endpoint, e.g. an OpenTelemetry collector.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+const (
+	// InstrumentationName is the name of the tracer of the runtime
+	InstrumentationName = "github.com/aws-controllers-k8s/runtime"
+	// middlewareID is the ID of the middleware tracing the calls to the AWS
+	// service APIs
+	middlewareID = "ACKTracing"
+	// defaultURLPath is the path of the OTLP/HTTP endpoint receiving the
+	// traces, when the endpoint URL has none
+	defaultURLPath = "/v1/traces"
+	// shutdownTimeout bounds the export of the remaining spans on shutdown
+	shutdownTimeout = 10 * time.Second
+)
+
+var (
+	// AttributeKind is the attribute of the kind of the reconciled resource
+	AttributeKind = attribute.Key("ack.resource.kind")
+	// AttributeName is the attribute of the name of the reconciled resource
+	AttributeName = attribute.Key("ack.resource.name")
+)
+
+// Provider provides the tracer of the reconcilers. It exports the spans
+// until it is stopped, and is meant to be added to the controller-runtime
+// Manager. A nil Provider provides a tracer recording nothing.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+}
+
+// New returns a Provider of the tracer of the supplied OpenTelemetry tracer
+// provider
+func New(provider *sdktrace.TracerProvider) *Provider {
+	return &Provider{
+		provider: provider,
+		tracer:   provider.Tracer(InstrumentationName),
+	}
+}
+
+// NewOTLP returns a Provider exporting the spans to the OTLP/HTTP endpoint of
+// the supplied URL, e.g. "http://otel-collector:4318", sampling the supplied
+// ratio of the traces. The spans are attributed to the supplied service name
+// and version.
+func NewOTLP(
+	ctx context.Context,
+	endpoint string,
+	samplingRatio float64,
+	serviceName string,
+	serviceVersion string,
+) (*Provider, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint %q: %v", endpoint, err)
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(defaultURLPath),
+	}
+	if u.Path != "" && u.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+	if u.Scheme != "https" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the trace exporter: %v", err)
+	}
+	return New(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(serviceVersion),
+		)),
+	)), nil
+}
+
+// Tracer returns the tracer of the Provider
+func (p *Provider) Tracer() trace.Tracer {
+	if p == nil {
+		return noop.NewTracerProvider().Tracer(InstrumentationName)
+	}
+	return p.tracer
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every
+// replica of the controller traces its own reconciliations.
+func (p *Provider) NeedLeaderElection() bool {
+	return false
+}
+
+// Start waits for the supplied context to be done, and then exports the
+// remaining spans and stops the Provider
+func (p *Provider) Start(ctx context.Context) error {
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return p.provider.Shutdown(shutdownCtx)
+}
+
+// Middleware returns the API option starting a span for each call to the AWS
+// service APIs, retries included, in the supplied account and region. The
+// spans are children of the span of the context of the calls, e.g. the span
+// of the reconciliation making them.
+func (p *Provider) Middleware(account, region string) func(*middleware.Stack) error {
+	tracer := p.Tracer()
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(
+			middlewareID,
+			func(
+				ctx context.Context,
+				in middleware.InitializeInput,
+				next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				service := awsmiddleware.GetServiceID(ctx)
+				operation := awsmiddleware.GetOperationName(ctx)
+				ctx, span := tracer.Start(
+					ctx, service+"."+operation,
+					trace.WithSpanKind(trace.SpanKindClient),
+					trace.WithAttributes(
+						semconv.RPCSystemKey.String("aws-api"),
+						semconv.RPCService(service),
+						semconv.RPCMethod(operation),
+						semconv.CloudAccountID(account),
+						semconv.CloudRegion(region),
+					),
+				)
+				defer span.End()
+				out, md, err := next.HandleInitialize(ctx, in)
+				if requestID, ok := awsmiddleware.GetRequestIDMetadata(md); ok {
+					span.SetAttributes(semconv.AWSRequestID(requestID))
+				}
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+				}
+				return out, md, err
+			},
+		), middleware.After)
+	}
+}
+
+// Link returns the link to the span of the request whose W3C trace context
+// is the value of the services.k8s.aws/traceparent annotation of the supplied
+// annotations. It returns false if the annotation is missing or invalid.
+func Link(annotations map[string]string) (trace.Link, bool) {
+	traceParent, ok := annotations[ackv1alpha1.AnnotationTraceParent]
+	if !ok {
+		return trace.Link{}, false
+	}
+	ctx := propagation.TraceContext{}.Extract(
+		context.Background(),
+		propagation.MapCarrier{"traceparent": traceParent},
+	)
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return trace.Link{}, false
+	}
+	return trace.Link{SpanContext: spanContext}, true
+}
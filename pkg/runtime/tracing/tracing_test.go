@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/tracing"
+)
+
+func TestProvider_Middleware(t *testing.T) {
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := tracing.New(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	stack := middleware.NewStack("test", func() interface{} { return nil })
+	require.Nil(stack.Initialize.Add(&awsmiddleware.RegisterServiceMetadata{
+		ServiceID:     "S3",
+		OperationName: "PutBucketTagging",
+	}, middleware.Before))
+	require.Nil(provider.Middleware("111111111111", "us-west-2")(stack))
+	handler := middleware.DecorateHandler(
+		middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+			return nil, middleware.Metadata{}, errors.New("access denied")
+		}),
+		stack,
+	)
+
+	ctx, parent := provider.Tracer().Start(context.Background(), "Reconcile Bucket")
+	_, _, err := handler.Handle(ctx, nil)
+	require.NotNil(err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(spans, 2)
+	call := spans[0]
+	require.Equal("S3.PutBucketTagging", call.Name())
+	require.Equal(parent.SpanContext().SpanID(), call.Parent().SpanID())
+	require.Equal(codes.Error, call.Status().Code)
+	require.Contains(call.Attributes(), attribute.String("rpc.method", "PutBucketTagging"))
+	require.Contains(call.Attributes(), attribute.String("cloud.account.id", "111111111111"))
+	require.Contains(call.Attributes(), attribute.String("cloud.region", "us-west-2"))
+}
+
+func TestProvider_Nil(t *testing.T) {
+	require := require.New(t)
+
+	var provider *tracing.Provider
+	_, span := provider.Tracer().Start(context.Background(), "Reconcile Bucket")
+	require.False(span.SpanContext().IsValid())
+}
+
+func TestLink(t *testing.T) {
+	require := require.New(t)
+
+	_, ok := tracing.Link(nil)
+	require.False(ok)
+	_, ok = tracing.Link(map[string]string{ackv1alpha1.AnnotationTraceParent: "not-a-trace-context"})
+	require.False(ok)
+
+	link, ok := tracing.Link(map[string]string{
+		ackv1alpha1.AnnotationTraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	require.True(ok)
+	require.Equal("4bf92f3577b34da6a3ce929d0e0e4736", link.SpanContext.TraceID().String())
+	require.Equal("00f067aa0ba902b7", link.SpanContext.SpanID().String())
+	require.True(link.SpanContext.IsSampled())
+}
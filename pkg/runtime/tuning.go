@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	ackrtquota "github.com/aws-controllers-k8s/runtime/pkg/runtime/quota"
+	ackrtslo "github.com/aws-controllers-k8s/runtime/pkg/runtime/slo"
+	ackrttuning "github.com/aws-controllers-k8s/runtime/pkg/runtime/tuning"
+)
+
+// pausedRequeueAfter is the duration after which the resources whose
+// reconciliation is paused are checked again
+const pausedRequeueAfter = time.Minute
+
+// addControllerConfigWatcher makes the reconcilers of the controller tunable
+// through the ControllerConfig named by the --controller-config flag, and
+// adds the watcher applying it to the supplied manager. The conditions of the
+// supplied SLO evaluator and service quota monitor, if any, are written in
+// the status of the ControllerConfig.
+func (c *serviceController) addControllerConfigWatcher(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+	sloEvaluator *ackrtslo.Evaluator,
+	quotaMonitor *ackrtquota.Monitor,
+) error {
+	settings := ackrttuning.NewSettings()
+	for _, rec := range c.reconcilers {
+		if r, ok := rec.(*resourceReconciler); ok {
+			r.tune(settings)
+		}
+	}
+	watcher := ackrttuning.NewWatcher(
+		c.log.WithName("controller-config"), mgr.GetClient(), mgr.GetAPIReader(),
+		client.ObjectKey{Namespace: ackrtcache.SystemNamespace(), Name: cfg.ControllerConfig},
+		time.Duration(cfg.ControllerConfigIntervalSeconds)*time.Second,
+		settings,
+	).WithConditions(func() []*ackv1alpha1.Condition {
+		return append(sloEvaluator.Conditions(), quotaMonitor.Conditions()...)
+	})
+	return mgr.Add(watcher)
+}
+
+// tune registers the kind of the reconciler, and the settings of its flags,
+// in the supplied settings, which the reconciler applies from then on
+func (r *resourceReconciler) tune(settings *ackrttuning.Settings) {
+	kind := r.rd.GroupVersionKind().Kind
+	settings.Register(kind, ackrttuning.KindSettings{
+		MaxConcurrentReconciles: r.cfg.GetReconcileResourceMaxConcurrency(kind),
+		ResyncPeriod:            r.resyncPeriod,
+		CanaryPercent:           r.flagCanaryPercent(),
+	})
+	r.tuning = settings
+}
+
+// acquireReconcile waits until the tuned concurrency of the kind allows a
+// reconciliation to start, and returns the function to call once it is done.
+// It returns false if the reconciliation of the kind is paused.
+func (r *resourceReconciler) acquireReconcile(ctx context.Context) (func(), bool, error) {
+	kind := r.rd.GroupVersionKind().Kind
+	if r.tuning.Paused(kind) {
+		return nil, false, nil
+	}
+	release, err := r.tuning.Limiter(kind).Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return release, true, nil
+}
@@ -0,0 +1,291 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package tuning applies the settings of the ControllerConfig of a controller,
+// i.e. the reconciliation concurrency, drift detection interval, pause and
+// canary percentage of each resource kind, that operators can tune while the
+// controller runs.
+package tuning
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// KindSettings are the settings of the reconciler of a resource kind
+type KindSettings struct {
+	// MaxConcurrentReconciles is the maximum number of concurrent
+	// reconciliations of the resources of the kind
+	MaxConcurrentReconciles int
+	// ResyncPeriod is the drift detection interval of the resources
+	ResyncPeriod time.Duration
+	// Paused is true if the reconciliation of the resources is paused
+	Paused bool
+	// CanaryPercent is the percentage of the resources getting the new
+	// behavior rolled out by an upgrade of the controller, nil if the kind has
+	// no rollout in progress
+	CanaryPercent *int
+}
+
+// Settings holds the settings applied to the reconcilers of a controller. The
+// settings of a kind default to the ones it was registered with, i.e. the
+// ones of the controller flags. A nil Settings keeps the registered settings,
+// never pauses reconciliations and never limits their concurrency.
+type Settings struct {
+	sync.RWMutex
+	// kinds maps the registered kinds, in lowercase, to their name
+	kinds map[string]string
+	// defaults contains the registered settings, keyed by lowercase kind.
+	// The registered MaxConcurrentReconciles is the number of workers of the
+	// reconciler, which cannot be increased while it runs.
+	defaults map[string]KindSettings
+	// applied contains the applied settings, keyed by lowercase kind
+	applied  map[string]KindSettings
+	limiters map[string]*Limiter
+}
+
+// NewSettings returns a Settings without registered kinds
+func NewSettings() *Settings {
+	return &Settings{
+		kinds:    map[string]string{},
+		defaults: map[string]KindSettings{},
+		applied:  map[string]KindSettings{},
+		limiters: map[string]*Limiter{},
+	}
+}
+
+// Register registers a kind and the settings it was started with
+func (s *Settings) Register(kind string, defaults KindSettings) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	key := strings.ToLower(kind)
+	s.kinds[key] = kind
+	s.defaults[key] = defaults
+	s.applied[key] = defaults
+	s.limiters[key] = NewLimiter(defaults.MaxConcurrentReconciles)
+}
+
+// Kind returns the settings applied to the supplied kind, and false if the
+// kind is not registered
+func (s *Settings) Kind(kind string) (KindSettings, bool) {
+	if s == nil {
+		return KindSettings{}, false
+	}
+	s.RLock()
+	defer s.RUnlock()
+	settings, ok := s.applied[strings.ToLower(kind)]
+	return settings, ok
+}
+
+// Paused returns true if the reconciliation of the supplied kind is paused
+func (s *Settings) Paused(kind string) bool {
+	settings, _ := s.Kind(kind)
+	return settings.Paused
+}
+
+// ResyncPeriod returns the drift detection interval of the supplied kind, or
+// the supplied default one if the kind is not registered
+func (s *Settings) ResyncPeriod(kind string, defaultPeriod time.Duration) time.Duration {
+	settings, ok := s.Kind(kind)
+	if !ok || settings.ResyncPeriod <= 0 {
+		return defaultPeriod
+	}
+	return settings.ResyncPeriod
+}
+
+// CanaryPercent returns the canary percentage of the supplied kind, and
+// false if the kind is not registered or has no rollout in progress
+func (s *Settings) CanaryPercent(kind string) (int, bool) {
+	settings, ok := s.Kind(kind)
+	if !ok || settings.CanaryPercent == nil {
+		return 0, false
+	}
+	return *settings.CanaryPercent, true
+}
+
+// Limiter returns the concurrency limiter of the supplied kind, nil if the
+// kind is not registered
+func (s *Settings) Limiter(kind string) *Limiter {
+	if s == nil {
+		return nil
+	}
+	s.RLock()
+	defer s.RUnlock()
+	return s.limiters[strings.ToLower(kind)]
+}
+
+// Validate returns an error if the supplied spec has settings for unknown or
+// duplicate kinds, or invalid settings
+func (s *Settings) Validate(spec ackv1alpha1.ControllerConfigSpec) error {
+	if s == nil {
+		return nil
+	}
+	s.RLock()
+	defer s.RUnlock()
+	seen := map[string]struct{}{}
+	for _, kc := range spec.Kinds {
+		key := strings.ToLower(kc.Kind)
+		if _, ok := s.kinds[key]; !ok {
+			return fmt.Errorf("kind %q is not reconciled by this controller", kc.Kind)
+		}
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("kind %q has more than one entry", kc.Kind)
+		}
+		seen[key] = struct{}{}
+		if kc.MaxConcurrentReconciles != nil && *kc.MaxConcurrentReconciles < 1 {
+			return fmt.Errorf("maxConcurrentReconciles of kind %q must be greater than 0", kc.Kind)
+		}
+		if kc.ResyncPeriodSeconds != nil && *kc.ResyncPeriodSeconds < 1 {
+			return fmt.Errorf("resyncPeriodSeconds of kind %q must be greater than 0", kc.Kind)
+		}
+		if kc.CanaryPercent != nil && (*kc.CanaryPercent < 0 || *kc.CanaryPercent > 100) {
+			return fmt.Errorf("canaryPercent of kind %q must be between 0 and 100", kc.Kind)
+		}
+	}
+	return nil
+}
+
+// Apply validates and applies the supplied spec, and returns the settings
+// applied to each kind, sorted by kind. An invalid spec is not applied at
+// all. Concurrency limits greater than the number of workers of a reconciler
+// are staged: the number of workers is applied instead, until the controller
+// restarts with the new limit.
+func (s *Settings) Apply(spec ackv1alpha1.ControllerConfigSpec) ([]ackv1alpha1.ControllerKindStatus, error) {
+	if err := s.Validate(spec); err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, nil
+	}
+	byKind := map[string]ackv1alpha1.ControllerKindConfig{}
+	for _, kc := range spec.Kinds {
+		byKind[strings.ToLower(kc.Kind)] = kc
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	statuses := make([]ackv1alpha1.ControllerKindStatus, 0, len(s.defaults))
+	for key, defaults := range s.defaults {
+		settings := defaults
+		settings.Paused = defaults.Paused || spec.Paused
+		status := ackv1alpha1.ControllerKindStatus{Kind: s.kinds[key]}
+		if kc, ok := byKind[key]; ok {
+			if kc.MaxConcurrentReconciles != nil {
+				settings.MaxConcurrentReconciles = *kc.MaxConcurrentReconciles
+				if settings.MaxConcurrentReconciles > defaults.MaxConcurrentReconciles {
+					status.Staged = append(status.Staged, fmt.Sprintf(
+						"maxConcurrentReconciles=%d", settings.MaxConcurrentReconciles,
+					))
+					settings.MaxConcurrentReconciles = defaults.MaxConcurrentReconciles
+				}
+			}
+			if kc.ResyncPeriodSeconds != nil {
+				settings.ResyncPeriod = time.Duration(*kc.ResyncPeriodSeconds) * time.Second
+			}
+			if kc.Paused != nil {
+				settings.Paused = settings.Paused || *kc.Paused
+			}
+			if kc.CanaryPercent != nil {
+				percent := *kc.CanaryPercent
+				settings.CanaryPercent = &percent
+			}
+		}
+		s.applied[key] = settings
+		s.limiters[key].SetLimit(settings.MaxConcurrentReconciles)
+		status.MaxConcurrentReconciles = settings.MaxConcurrentReconciles
+		status.ResyncPeriodSeconds = int64(settings.ResyncPeriod / time.Second)
+		status.Paused = settings.Paused
+		status.CanaryPercent = settings.CanaryPercent
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Kind < statuses[j].Kind
+	})
+	return statuses, nil
+}
+
+// Limiter limits the number of concurrent reconciliations. Its limit can be
+// changed while reconciliations are in progress: lowering it makes new
+// reconciliations wait until enough of the in-progress ones are done. A nil
+// Limiter never limits reconciliations.
+type Limiter struct {
+	sync.Mutex
+	limit  int
+	active int
+	// wake is closed, and replaced, when a reconciliation may start
+	wake chan struct{}
+}
+
+// NewLimiter returns a Limiter allowing the supplied number of concurrent
+// reconciliations
+func NewLimiter(limit int) *Limiter {
+	return &Limiter{limit: limit, wake: make(chan struct{})}
+}
+
+// SetLimit changes the number of concurrent reconciliations allowed
+func (l *Limiter) SetLimit(limit int) {
+	if l == nil {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.limit = limit
+	l.broadcast()
+}
+
+// Acquire waits until a reconciliation can start, or the supplied context is
+// done, and returns the function to call once the reconciliation is done
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	for {
+		l.Lock()
+		if l.limit <= 0 || l.active < l.limit {
+			l.active++
+			l.Unlock()
+			return l.release, nil
+		}
+		wake := l.wake
+		l.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// release records the end of a reconciliation
+func (l *Limiter) release() {
+	l.Lock()
+	defer l.Unlock()
+	l.active--
+	l.broadcast()
+}
+
+// broadcast wakes up the waiting reconciliations. The caller must hold the
+// lock.
+func (l *Limiter) broadcast() {
+	close(l.wake)
+	l.wake = make(chan struct{})
+}
@@ -0,0 +1,245 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tuning_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/tuning"
+)
+
+func newSettings() *tuning.Settings {
+	settings := tuning.NewSettings()
+	settings.Register("Bucket", tuning.KindSettings{
+		MaxConcurrentReconciles: 4,
+		ResyncPeriod:            10 * time.Hour,
+	})
+	settings.Register("Queue", tuning.KindSettings{
+		MaxConcurrentReconciles: 1,
+		ResyncPeriod:            time.Hour,
+	})
+	return settings
+}
+
+func TestSettingsApply(t *testing.T) {
+	require := require.New(t)
+	settings := newSettings()
+
+	two, big := 2, 8
+	resync := int64(60)
+	paused := true
+	statuses, err := settings.Apply(ackv1alpha1.ControllerConfigSpec{
+		Kinds: []ackv1alpha1.ControllerKindConfig{
+			{Kind: "bucket", MaxConcurrentReconciles: &two, ResyncPeriodSeconds: &resync},
+			{Kind: "Queue", MaxConcurrentReconciles: &big, Paused: &paused},
+		},
+	})
+	require.Nil(err)
+	require.Equal([]ackv1alpha1.ControllerKindStatus{
+		{Kind: "Bucket", MaxConcurrentReconciles: 2, ResyncPeriodSeconds: 60},
+		{
+			Kind: "Queue", MaxConcurrentReconciles: 1, ResyncPeriodSeconds: 3600,
+			Paused: true, Staged: []string{"maxConcurrentReconciles=8"},
+		},
+	}, statuses)
+	require.Equal(time.Minute, settings.ResyncPeriod("Bucket", time.Second))
+	require.False(settings.Paused("Bucket"))
+	require.True(settings.Paused("Queue"))
+
+	// An invalid spec is not applied at all
+	zero := 0
+	_, err = settings.Apply(ackv1alpha1.ControllerConfigSpec{
+		Paused: true,
+		Kinds:  []ackv1alpha1.ControllerKindConfig{{Kind: "Bucket", MaxConcurrentReconciles: &zero}},
+	})
+	require.NotNil(err)
+	require.False(settings.Paused("Bucket"))
+	_, err = settings.Apply(ackv1alpha1.ControllerConfigSpec{
+		Kinds: []ackv1alpha1.ControllerKindConfig{{Kind: "Topic"}},
+	})
+	require.NotNil(err)
+	_, err = settings.Apply(ackv1alpha1.ControllerConfigSpec{
+		Kinds: []ackv1alpha1.ControllerKindConfig{{Kind: "Bucket"}, {Kind: "bucket"}},
+	})
+	require.NotNil(err)
+
+	// The registered settings apply to the kinds without settings
+	statuses, err = settings.Apply(ackv1alpha1.ControllerConfigSpec{Paused: true})
+	require.Nil(err)
+	require.Equal(ackv1alpha1.ControllerKindStatus{
+		Kind: "Bucket", MaxConcurrentReconciles: 4, ResyncPeriodSeconds: 36000, Paused: true,
+	}, statuses[0])
+	require.Equal(10*time.Hour, settings.ResyncPeriod("Bucket", time.Second))
+	require.Equal(time.Second, settings.ResyncPeriod("Topic", time.Second))
+
+	var nilSettings *tuning.Settings
+	require.False(nilSettings.Paused("Bucket"))
+	require.Equal(time.Second, nilSettings.ResyncPeriod("Bucket", time.Second))
+	require.Nil(nilSettings.Limiter("Bucket"))
+	_, ok := nilSettings.CanaryPercent("Bucket")
+	require.False(ok)
+}
+
+func TestSettingsApplyCanaryPercent(t *testing.T) {
+	require := require.New(t)
+	settings := newSettings()
+
+	_, ok := settings.CanaryPercent("Bucket")
+	require.False(ok)
+
+	// The canary percentage is ramped up
+	ten := 10
+	statuses, err := settings.Apply(ackv1alpha1.ControllerConfigSpec{
+		Kinds: []ackv1alpha1.ControllerKindConfig{{Kind: "Bucket", CanaryPercent: &ten}},
+	})
+	require.Nil(err)
+	require.Equal(&ten, statuses[0].CanaryPercent)
+	percent, ok := settings.CanaryPercent("Bucket")
+	require.True(ok)
+	require.Equal(10, percent)
+
+	tooMany := 101
+	_, err = settings.Apply(ackv1alpha1.ControllerConfigSpec{
+		Kinds: []ackv1alpha1.ControllerKindConfig{{Kind: "Bucket", CanaryPercent: &tooMany}},
+	})
+	require.NotNil(err)
+	percent, _ = settings.CanaryPercent("Bucket")
+	require.Equal(10, percent)
+}
+
+func TestLimiter(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	limiter := tuning.NewLimiter(1)
+	release, err := limiter.Acquire(ctx)
+	require.Nil(err)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(timeoutCtx)
+	require.Equal(context.DeadlineExceeded, err)
+
+	acquired := make(chan func())
+	go func() {
+		release, _ := limiter.Acquire(ctx)
+		acquired <- release
+	}()
+	// Raising the limit lets the waiting reconciliation start
+	limiter.SetLimit(2)
+	second := <-acquired
+	release()
+	second()
+
+	var nilLimiter *tuning.Limiter
+	release, err = nilLimiter.Acquire(ctx)
+	require.Nil(err)
+	release()
+}
+
+func TestWatcherSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	scheme := k8sruntime.NewScheme()
+	_ = ackv1alpha1.AddToScheme(scheme)
+	two := 2
+	cfg := &ackv1alpha1.ControllerConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ack-system", Name: "ack-s3", Generation: 1},
+		Spec: ackv1alpha1.ControllerConfigSpec{
+			Kinds: []ackv1alpha1.ControllerKindConfig{{Kind: "Bucket", MaxConcurrentReconciles: &two}},
+		},
+	}
+	kc := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(cfg).WithStatusSubresource(cfg).Build()
+	key := client.ObjectKeyFromObject(cfg)
+	settings := newSettings()
+	watcher := tuning.NewWatcher(logr.Discard(), kc, kc, key, time.Minute, settings)
+
+	require.Nil(watcher.Sync(ctx))
+	got := &ackv1alpha1.ControllerConfig{}
+	require.Nil(kc.Get(ctx, key, got))
+	require.Equal(int64(1), got.Status.ObservedGeneration)
+	require.Len(got.Status.Conditions, 1)
+	require.Equal(corev1.ConditionTrue, got.Status.Conditions[0].Status)
+	require.Equal(2, got.Status.Kinds[0].MaxConcurrentReconciles)
+	bucket, _ := settings.Kind("Bucket")
+	require.Equal(2, bucket.MaxConcurrentReconciles)
+
+	// An invalid spec keeps the last valid one applied
+	zero := 0
+	got.Spec.Kinds[0].MaxConcurrentReconciles = &zero
+	got.Generation = 2
+	require.Nil(kc.Update(ctx, got))
+	require.Nil(watcher.Sync(ctx))
+	require.Nil(kc.Get(ctx, key, got))
+	require.Equal(int64(2), got.Status.ObservedGeneration)
+	require.Equal(corev1.ConditionFalse, got.Status.Conditions[0].Status)
+	require.NotNil(got.Status.Conditions[0].Message)
+	bucket, _ = settings.Kind("Bucket")
+	require.Equal(2, bucket.MaxConcurrentReconciles)
+
+	// Deleting the config applies the registered settings again
+	require.Nil(kc.Delete(ctx, got))
+	require.Nil(watcher.Sync(ctx))
+	bucket, _ = settings.Kind("Bucket")
+	require.Equal(4, bucket.MaxConcurrentReconciles)
+}
+
+func TestWatcherSyncConditions(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	scheme := k8sruntime.NewScheme()
+	_ = ackv1alpha1.AddToScheme(scheme)
+	cfg := &ackv1alpha1.ControllerConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ack-system", Name: "ack-s3", Generation: 1},
+	}
+	kc := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(cfg).WithStatusSubresource(cfg).Build()
+	key := client.ObjectKeyFromObject(cfg)
+	status := corev1.ConditionTrue
+	watcher := tuning.NewWatcher(logr.Discard(), kc, kc, key, time.Minute, newSettings()).
+		WithConditions(func() []*ackv1alpha1.Condition {
+			return []*ackv1alpha1.Condition{{
+				Type:   ackv1alpha1.ConditionTypeSyncedObjectiveMet,
+				Status: status,
+			}}
+		})
+
+	require.Nil(watcher.Sync(ctx))
+	got := &ackv1alpha1.ControllerConfig{}
+	require.Nil(kc.Get(ctx, key, got))
+	require.Len(got.Status.Conditions, 2)
+	require.Equal(ackv1alpha1.ConditionTypeSyncedObjectiveMet, got.Status.Conditions[1].Type)
+	require.Equal(corev1.ConditionTrue, got.Status.Conditions[1].Status)
+
+	// The conditions are written even if the spec did not change
+	status = corev1.ConditionFalse
+	require.Nil(watcher.Sync(ctx))
+	require.Nil(kc.Get(ctx, key, got))
+	require.Len(got.Status.Conditions, 2)
+	require.Equal(corev1.ConditionFalse, got.Status.Conditions[1].Status)
+}
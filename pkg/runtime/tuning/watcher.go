@@ -0,0 +1,166 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tuning
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// Watcher periodically reads a ControllerConfig, applies its spec to a
+// Settings and writes the applied settings in its status. Watcher implements
+// the controller-runtime manager.Runnable interface and, as it writes to the
+// Kubernetes API, only runs on the leader, which is the only replica
+// reconciling resources.
+type Watcher struct {
+	log      logr.Logger
+	kc       client.Client
+	reader   client.Reader
+	key      client.ObjectKey
+	interval time.Duration
+	settings *Settings
+	// generation is the generation of the last spec applied, zero if the
+	// registered settings are applied
+	generation int64
+	// conditions returns the conditions, other than ACK.ResourceSynced, to
+	// write in the status, nil if none
+	conditions func() []*ackv1alpha1.Condition
+}
+
+// NewWatcher returns a Watcher applying, at the supplied interval, the spec
+// of the ControllerConfig with the supplied key to the supplied Settings
+func NewWatcher(
+	log logr.Logger,
+	kc client.Client,
+	reader client.Reader,
+	key client.ObjectKey,
+	interval time.Duration,
+	settings *Settings,
+) *Watcher {
+	return &Watcher{
+		log:      log,
+		kc:       kc,
+		reader:   reader,
+		key:      key,
+		interval: interval,
+		settings: settings,
+	}
+}
+
+// WithConditions sets the function returning the conditions, e.g. the
+// service level objective ones, that the Watcher writes in the status of the
+// ControllerConfig in addition to the ACK.ResourceSynced one
+func (w *Watcher) WithConditions(conditions func() []*ackv1alpha1.Condition) *Watcher {
+	w.conditions = conditions
+	return w
+}
+
+// Start applies the ControllerConfig until the supplied context is done
+func (w *Watcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Sync(ctx); err != nil {
+			w.log.Error(err, "unable to apply the controller config")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync applies the spec of the ControllerConfig if its generation changed
+// since the last call, and writes its status if it changed. The registered
+// settings are applied again once the ControllerConfig is deleted.
+func (w *Watcher) Sync(ctx context.Context) error {
+	cfg := &ackv1alpha1.ControllerConfig{}
+	err := w.reader.Get(ctx, w.key, cfg)
+	if apierrors.IsNotFound(err) {
+		if w.generation != 0 {
+			w.log.Info("controller config deleted, applying the controller flags")
+			_, _ = w.settings.Apply(ackv1alpha1.ControllerConfigSpec{})
+			w.generation = 0
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	status := cfg.Status.DeepCopy()
+	if cfg.Generation != w.generation || cfg.Status.ObservedGeneration != cfg.Generation {
+		status.ObservedGeneration = cfg.Generation
+		synced := &ackv1alpha1.Condition{
+			Type:   ackv1alpha1.ConditionTypeResourceSynced,
+			Status: corev1.ConditionTrue,
+		}
+		kinds, err := w.settings.Apply(cfg.Spec)
+		if err != nil {
+			// The last valid spec stays applied
+			w.log.Info("invalid controller config", "generation", cfg.Generation, "error", err.Error())
+			msg := err.Error()
+			synced.Status = corev1.ConditionFalse
+			synced.Message = &msg
+		} else {
+			w.log.Info("applied controller config", "generation", cfg.Generation)
+			status.Kinds = kinds
+		}
+		w.generation = cfg.Generation
+		status.Conditions = setCondition(status.Conditions, synced)
+	}
+	if w.conditions != nil {
+		for _, c := range w.conditions() {
+			status.Conditions = setCondition(status.Conditions, c)
+		}
+	}
+	if reflect.DeepEqual(status, &cfg.Status) {
+		return nil
+	}
+	patch := client.MergeFrom(cfg.DeepCopy())
+	cfg.Status = *status
+	return w.kc.Status().Patch(ctx, cfg, patch)
+}
+
+// setCondition replaces the condition of the same type as the supplied one,
+// keeping its transition time if its status did not change
+func setCondition(
+	conditions []*ackv1alpha1.Condition,
+	condition *ackv1alpha1.Condition,
+) []*ackv1alpha1.Condition {
+	for i, c := range conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		condition.LastTransitionTime = c.LastTransitionTime
+		if c.Status != condition.Status || condition.LastTransitionTime == nil {
+			now := metav1.Now()
+			condition.LastTransitionTime = &now
+		}
+		conditions[i] = condition
+		return conditions
+	}
+	now := metav1.Now()
+	condition.LastTransitionTime = &now
+	return append(conditions, condition)
+}
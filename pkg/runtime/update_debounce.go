@@ -0,0 +1,117 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackevents "github.com/aws-controllers-k8s/runtime/pkg/events"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// UpdatePendingReason is the reason of the ACK.ResourceSynced condition of
+// the resources whose update is held by the debounce window of their kind
+const UpdatePendingReason = "UpdatePending"
+
+// updateHolds holds the updates of the resources of a kind for a debounce
+// window, from the first change detected, so that the changes made to their
+// spec in quick succession are applied by a single update. A nil updateHolds
+// holds nothing.
+type updateHolds struct {
+	sync.Mutex
+	window time.Duration
+	since  map[types.NamespacedName]time.Time
+}
+
+// newUpdateHolds returns the updateHolds of the supplied kind configured with
+// the --reconcile-resource-update-debounce-seconds flag, or nil
+func newUpdateHolds(cfg ackcfg.Config, kind string) *updateHolds {
+	window := cfg.GetReconcileResourceUpdateDebounce(kind)
+	if window <= 0 {
+		return nil
+	}
+	return &updateHolds{
+		window: window,
+		since:  map[types.NamespacedName]time.Time{},
+	}
+}
+
+// hold returns the time until which the update of the supplied resource is
+// held, and whether the hold just started. The update is no longer held, and
+// a zero time is returned, once the window elapsed.
+func (h *updateHolds) hold(key types.NamespacedName, now time.Time) (time.Time, bool) {
+	if h == nil {
+		return time.Time{}, false
+	}
+	h.Lock()
+	defer h.Unlock()
+	since, ok := h.since[key]
+	if !ok {
+		h.since[key] = now
+		return now.Add(h.window), true
+	}
+	if until := since.Add(h.window); now.Before(until) {
+		return until, false
+	}
+	delete(h.since, key)
+	return time.Time{}, false
+}
+
+// forget releases the hold of the supplied resource, if any
+func (h *updateHolds) forget(key types.NamespacedName) {
+	if h == nil {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	delete(h.since, key)
+}
+
+// holdUpdate returns a RequeueNeededAfter error, and the supplied latest
+// observed state of the resource with its ACK.ResourceSynced condition set to
+// False with the UpdatePending reason, if the update of the resource is held
+// by the debounce window of its kind. It returns nil if the resource can be
+// updated.
+func (r *resourceReconciler) holdUpdate(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) error {
+	now := time.Now()
+	until, started := r.updateHolds.hold(resourceKey(desired), now)
+	if until.IsZero() {
+		return nil
+	}
+	message := fmt.Sprintf(
+		"Update of the AWS resource held until %s, to apply the changes made meanwhile at once",
+		until.UTC().Format(time.RFC3339),
+	)
+	reason := UpdatePendingReason
+	ackcondition.SetSynced(latest, corev1.ConditionFalse, &message, &reason)
+	if started {
+		ackrtlog.FromContext(ctx).Info("update held", "until", until)
+		r.recordEvent(desired.RuntimeObject(), ackevents.ReasonUpdatePending, message)
+	}
+	return requeue.NeededAfter(nil, until.Sub(now))
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	ctrlrtzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+	ctrlrtclientmock "github.com/aws-controllers-k8s/runtime/mocks/controller-runtime/pkg/client"
+	ackmocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+func TestReconcilerUpdate_Debounce(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.A", "val1", "val2")
+
+	desired, _, _ := resourceMocks()
+	desired.On("ReplaceConditions", []*ackv1alpha1.Condition{}).Return()
+
+	var conditions []*ackv1alpha1.Condition
+	latest, _, _ := resourceMocks()
+	latest.On("Conditions").Return(func() []*ackv1alpha1.Condition {
+		return conditions
+	})
+	latest.On("ReplaceConditions", mock.Anything).Return().Run(func(args mock.Arguments) {
+		conditions = args.Get(0).([]*ackv1alpha1.Condition)
+	})
+
+	rm := &ackmocks.AWSResourceManager{}
+	rm.On("ResolveReferences", ctx, nil, desired).Return(desired, false, nil)
+	rm.On("ClearResolvedReferences", desired).Return(desired)
+	rm.On("ReadOne", ctx, desired).Return(latest, nil)
+
+	rmf, rd := managedResourceManagerFactoryMocks(desired, latest)
+	rd.On("IsManaged", desired).Return(true)
+	rd.On("Delta", desired, latest).Return(delta)
+
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	sc := &ackmocks.ServiceController{}
+	scmd := acktypes.ServiceControllerMetadata{}
+	sc.On("GetMetadata").Return(scmd)
+	kc := &ctrlrtclientmock.Client{}
+	r := ackrt.NewReconcilerWithClient(
+		sc, kc, rmf, ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions)),
+		ackcfg.Config{ResourceUpdateDebounceSeconds: []string{"fakeBook=60"}},
+		ackmetrics.NewMetrics("bookstore"), ackrtcache.Caches{},
+	)
+	rm.On("EnsureTags", ctx, desired, scmd).Return(nil)
+
+	// The update is held for the debounce window, the resource being
+	// reported as not synced with the UpdatePending reason
+	_, err := r.Sync(ctx, rm, desired)
+	var requeueNeededAfter *requeue.RequeueNeededAfter
+	require.True(errors.As(err, &requeueNeededAfter))
+	require.Nil(requeueNeededAfter.Unwrap())
+	require.InDelta(60, requeueNeededAfter.Duration().Seconds(), 1)
+	rm.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.Len(conditions, 1)
+	require.Equal(ackv1alpha1.ConditionTypeResourceSynced, conditions[0].Type)
+	require.Equal(corev1.ConditionFalse, conditions[0].Status)
+	require.Equal(ackrt.UpdatePendingReason, *conditions[0].Reason)
+
+	// The next reconciliations within the window are held until its end
+	_, err = r.Sync(ctx, rm, desired)
+	require.True(errors.As(err, &requeueNeededAfter))
+	require.LessOrEqual(requeueNeededAfter.Duration().Seconds(), 60.0)
+	rm.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
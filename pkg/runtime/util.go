@@ -88,6 +88,41 @@ func IsReadOnly(res acktypes.AWSResource) bool {
 	return false
 }
 
+// IsTakeOver returns true if the supplied AWSResource has an annotation
+// indicating that the controller may take over the ownership of its AWS
+// resource from the controller of another cluster.
+func IsTakeOver(res acktypes.AWSResource) bool {
+	mo := res.MetaObject()
+	if mo == nil {
+		// Should never happen... if it does, it's buggy code.
+		panic("IsTakeOver received resource with nil RuntimeObject")
+	}
+	for k, v := range mo.GetAnnotations() {
+		if k == ackv1alpha1.AnnotationTakeOver {
+			return strings.ToLower(v) == "true"
+		}
+	}
+	return false
+}
+
+// GetUnknowableFields returns the paths of the fields of the supplied
+// AWSResource that are annotated as unknowable, and therefore excluded from
+// drift detection.
+func GetUnknowableFields(res acktypes.AWSResource) []string {
+	mo := res.MetaObject()
+	if mo == nil {
+		// Should never happen... if it does, it's buggy code.
+		panic("GetUnknowableFields received resource with nil RuntimeObject")
+	}
+	var fields []string
+	for _, field := range strings.Split(mo.GetAnnotations()[ackv1alpha1.AnnotationUnknowableFields], ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
 // GetAdoptionPolicy returns the Adoption Policy of the resource
 // defined by the user in annotation. Possible values are:
 // adopt-only | adopt-or-create
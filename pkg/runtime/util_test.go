@@ -119,3 +119,22 @@ func TestExtractAdoptionFields(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, actual)
 }
+
+func TestGetUnknowableFields(t *testing.T) {
+	require := require.New(t)
+
+	res := &mocks.AWSResource{}
+	res.On("MetaObject").Return(&metav1.ObjectMeta{
+		Annotations: map[string]string{
+			ackv1alpha1.AnnotationUnknowableFields: "spec.masterUserPassword, spec.encryption.kmsKeyID,",
+		},
+	})
+	require.Equal(
+		[]string{"spec.masterUserPassword", "spec.encryption.kmsKeyID"},
+		ackrt.GetUnknowableFields(res),
+	)
+
+	res = &mocks.AWSResource{}
+	res.On("MetaObject").Return(&metav1.ObjectMeta{})
+	require.Empty(ackrt.GetUnknowableFields(res))
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackrtcache "github.com/aws-controllers-k8s/runtime/pkg/runtime/cache"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/rolecreds"
+	ackrtstandby "github.com/aws-controllers-k8s/runtime/pkg/runtime/standby"
+)
+
+// warmStandbyInterval is the interval at which a replica that is not the
+// leader refreshes the credentials of the IAM roles of the bindings
+const warmStandbyInterval = 5 * time.Minute
+
+// warmStandby keeps a replica that is not the leader ready to take over: it
+// syncs the informers of the kinds of the reconcilers, which the controllers
+// of the leader would otherwise only start once elected, and keeps the
+// credentials of the IAM roles of the bindings fresh. It never reconciles,
+// and stops once the replica is elected.
+type warmStandby struct {
+	log      logr.Logger
+	mgr      ctrlrt.Manager
+	c        *serviceController
+	region   ackv1alpha1.AWSRegion
+	caches   ackrtcache.Caches
+	interval time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The standby
+// runs on the replicas that are not the leader.
+func (w *warmStandby) NeedLeaderElection() bool {
+	return false
+}
+
+// Start syncs the informers of the reconciled kinds, then refreshes the
+// credentials of the IAM roles of the bindings until the replica is elected
+// or the supplied context is done.
+//
+// Only the credentials of the region of the controller and of the default
+// endpoints are refreshed: the reconciliations through other regions or
+// endpoint overrides assume the roles with their own STS clients.
+func (w *warmStandby) Start(ctx context.Context) error {
+	objects := []client.Object{}
+	for _, rec := range w.c.reconcilers {
+		r, ok := rec.(*resourceReconciler)
+		if !ok {
+			continue
+		}
+		if obj, ok := r.rd.EmptyRuntimeObject().(client.Object); ok {
+			objects = append(objects, obj)
+		}
+	}
+	configFor := func(ctx context.Context, roleARN ackv1alpha1.AWSResourceName) (aws.Config, error) {
+		gvk := w.c.reconcilers[0].GroupVersionKind()
+		return w.c.NewAWSConfig(ctx, w.region, nil, roleARN, *gvk)
+	}
+	if len(w.c.reconcilers) == 0 {
+		configFor = nil
+	}
+	return ackrtstandby.New(
+		w.log, w.mgr.GetCache(), objects, w.caches, configFor, w.interval,
+	).Run(ctx, w.mgr.Elected())
+}
+
+// addWarmStandby adds to the supplied manager the warm standby of the
+// service controller, and shares the credentials of the assumed IAM roles
+// between its reconciliations
+func (c *serviceController) addWarmStandby(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+	caches ackrtcache.Caches,
+) error {
+	c.roleCredentials = rolecreds.New()
+	return mgr.Add(&warmStandby{
+		log:      c.log.WithName("warm-standby"),
+		mgr:      mgr,
+		c:        c,
+		region:   ackv1alpha1.AWSRegion(cfg.Region),
+		caches:   caches,
+		interval: warmStandbyInterval,
+	})
+}
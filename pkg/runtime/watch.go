@@ -0,0 +1,38 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// NewWatchSource returns a watch source, to be returned by
+// AWSResourceWatchSourceProvider.WatchSources, reconciling the resources
+// returned by the supplied function whenever an object of the type of the
+// supplied object changes. Events can be filtered with the supplied
+// predicates.
+func NewWatchSource(
+	mgr ctrlrt.Manager,
+	obj client.Object,
+	mapFunc handler.MapFunc,
+	predicates ...predicate.Predicate,
+) source.Source {
+	return source.Kind(
+		mgr.GetCache(), obj, handler.EnqueueRequestsFromMapFunc(mapFunc), predicates...,
+	)
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/webhookcert"
+)
+
+// errCustomWebhookServer is returned when the webhook certificate is managed
+// for a webhook server whose certificate directory is unknown
+var errCustomWebhookServer = errors.New(
+	"unable to manage the certificate of a webhook server not built with webhook.NewServer",
+)
+
+// addWebhookCertManager adds to the supplied controller manager the manager
+// of the serving certificate of its webhook server. The certificate is
+// synced before returning, so that the webhook server finds it when the
+// controller manager starts.
+func (c *serviceController) addWebhookCertManager(
+	mgr ctrlrt.Manager,
+	cfg ackcfg.Config,
+) error {
+	// The flags were validated with the configuration
+	mode, _ := webhookcert.ParseMode(cfg.WebhookCertMode)
+	if mode == webhookcert.ModeExternal {
+		return nil
+	}
+	secret, _ := webhookcert.ParseObjectKey(cfg.WebhookCertSecret)
+	service, _ := webhookcert.ParseObjectKey(cfg.WebhookService)
+	server, ok := mgr.GetWebhookServer().(*webhook.DefaultServer)
+	if !ok {
+		return errCustomWebhookServer
+	}
+	// The webhook server only defaults its options when it starts
+	opts := server.Options
+	if opts.CertDir == "" {
+		opts.CertDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+	}
+	if opts.CertName == "" {
+		opts.CertName = "tls.crt"
+	}
+	if opts.KeyName == "" {
+		opts.KeyName = "tls.key"
+	}
+	certManager := webhookcert.NewManager(
+		c.log.WithName("webhook-cert"), mgr.GetClient(), mgr.GetAPIReader(),
+		webhookcert.Options{
+			Mode:                  mode,
+			Secret:                secret,
+			Service:               service,
+			WebhookConfigurations: cfg.WebhookConfigurations,
+			CertDir:               opts.CertDir,
+			CertName:              opts.CertName,
+			KeyName:               opts.KeyName,
+		},
+	)
+	if err := certManager.Sync(context.TODO()); err != nil {
+		return err
+	}
+	return mgr.Add(certManager)
+}
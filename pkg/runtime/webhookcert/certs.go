@@ -0,0 +1,267 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhookcert
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SecretCAKeyKey is the key of the private key of the CA in the data of
+	// the certificate Secret
+	SecretCAKeyKey = "ca.key"
+
+	// caValidity is the validity of the self-signed CAs
+	caValidity = 365 * 24 * time.Hour
+	// certValidity is the validity of the serving certificates
+	certValidity = 90 * 24 * time.Hour
+	// clockSkew is subtracted from the start of the validity of the
+	// certificates, so that they are valid for API servers whose clock is
+	// slightly behind
+	clockSkew = 5 * time.Minute
+)
+
+var errMalformed = errors.New("malformed certificate data")
+
+// keyPair is a parsed certificate and its private key
+type keyPair struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// issuer issues the self-signed CAs and serving certificates of the webhook
+// server, valid for the supplied DNS names
+type issuer struct {
+	dnsNames []string
+	now      func() time.Time
+}
+
+// rotate returns the certificate Secret data derived from the supplied one,
+// issuing a new CA when the current one is missing or about to expire, and a
+// new serving certificate when the current one is missing, about to expire,
+// not issued by the current CA or not valid for the DNS names of the issuer.
+// It returns whether the data changed.
+//
+// The CA bundle of the data, under the ca.crt key, holds the current CA
+// followed by the previous CAs that did not expire yet, so that API servers
+// trusting the bundle accept the serving certificates of the replicas that
+// did not pick up a rotation yet.
+func (i *issuer) rotate(data map[string][]byte) (map[string][]byte, bool, error) {
+	now := i.now()
+	ca, caErr := parseKeyPair(data[corev1.ServiceAccountRootCAKey], data[SecretCAKeyKey])
+	bundle := validCerts(data[corev1.ServiceAccountRootCAKey], now)
+	rotatedCA := false
+	if caErr != nil || expiresSoon(ca.cert, caValidity, now) {
+		newCA, err := i.newCA(now)
+		if err != nil {
+			return nil, false, err
+		}
+		ca = newCA
+		bundle = append([]*x509.Certificate{newCA.cert}, bundle...)
+		rotatedCA = true
+	}
+
+	serving, servingErr := parseKeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	reissue := rotatedCA || servingErr != nil ||
+		expiresSoon(serving.cert, certValidity, now) ||
+		serving.cert.CheckSignatureFrom(ca.cert) != nil ||
+		!sameNames(serving.cert.DNSNames, i.dnsNames)
+	if !reissue && bytes.Equal(data[corev1.ServiceAccountRootCAKey], encodeCerts(bundle)) {
+		return data, false, nil
+	}
+	if reissue {
+		newServing, err := i.newServingCert(ca, now)
+		if err != nil {
+			return nil, false, err
+		}
+		serving = newServing
+	}
+
+	caKey, err := encodeKey(ca.key)
+	if err != nil {
+		return nil, false, err
+	}
+	servingKey, err := encodeKey(serving.key)
+	if err != nil {
+		return nil, false, err
+	}
+	return map[string][]byte{
+		corev1.ServiceAccountRootCAKey: encodeCerts(bundle),
+		SecretCAKeyKey:                 caKey,
+		corev1.TLSCertKey:              encodeCerts([]*x509.Certificate{serving.cert}),
+		corev1.TLSPrivateKeyKey:        servingKey,
+	}, true, nil
+}
+
+// newCA returns a new self-signed CA
+func (i *issuer) newCA(now time.Time) (*keyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "ack-webhook-ca"},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return issue(template, nil)
+}
+
+// newServingCert returns a new serving certificate issued by the supplied CA
+func (i *issuer) newServingCert(ca *keyPair, now time.Time) (*keyPair, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: i.dnsNames[0]},
+		DNSNames:    i.dnsNames,
+		NotBefore:   now.Add(-clockSkew),
+		NotAfter:    now.Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return issue(template, ca)
+}
+
+// issue returns a certificate with a new key, built from the supplied
+// template and signed by the supplied CA, or self-signed if it is nil
+func issue(template *x509.Certificate, ca *keyPair) (*keyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+	parent, signer := template, crypto.Signer(key)
+	if ca != nil {
+		parent, signer = ca.cert, ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &keyPair{cert: cert, key: key}, nil
+}
+
+// expiresSoon returns whether less than a third of the supplied validity is
+// left to the supplied certificate
+func expiresSoon(cert *x509.Certificate, validity time.Duration, now time.Time) bool {
+	return cert.NotAfter.Sub(now) < validity/3
+}
+
+// sameNames returns whether the supplied DNS names are the same, in any
+// order
+func sameNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseKeyPair parses the supplied PEM encoded certificate, the first one of
+// the data if it holds several, and private key
+func parseKeyPair(certPEM, keyPEM []byte) (*keyPair, error) {
+	certs := parseCerts(certPEM)
+	if len(certs) == 0 {
+		return nil, errMalformed
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errMalformed
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errMalformed
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errMalformed
+	}
+	return &keyPair{cert: certs[0], key: signer}, nil
+}
+
+// parseCerts returns the certificates of the supplied PEM data, skipping the
+// blocks that are not valid certificates
+func parseCerts(data []byte) []*x509.Certificate {
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// validCerts returns the certificates of the supplied PEM data that did not
+// expire
+func validCerts(data []byte, now time.Time) []*x509.Certificate {
+	valid := []*x509.Certificate{}
+	for _, cert := range parseCerts(data) {
+		if now.Before(cert.NotAfter) {
+			valid = append(valid, cert)
+		}
+	}
+	return valid
+}
+
+// encodeCerts returns the PEM encoding of the supplied certificates
+func encodeCerts(certs []*x509.Certificate) []byte {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.Bytes()
+}
+
+// encodeKey returns the PEM encoding of the supplied private key
+func encodeKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
@@ -0,0 +1,382 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package webhookcert manages the serving certificate of the webhook server
+// of the controllers, and the CA bundle of the webhook configurations
+// pointing at it.
+//
+// The webhook server of controller-runtime watches its certificate directory
+// and reloads the certificate when it changes, so rotated certificates are
+// picked up without restarting the controller.
+package webhookcert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Mode is the way the serving certificate of the webhook server is managed
+type Mode string
+
+const (
+	// ModeExternal leaves the certificate, and the CA bundle of the webhook
+	// configurations, to be provisioned outside of the controller
+	ModeExternal Mode = ""
+	// ModeCertManager relies on a cert-manager Certificate, whose Secret is
+	// mounted in the certificate directory of the webhook server, and has
+	// the cert-manager CA injector set the CA bundle of the webhook
+	// configurations
+	ModeCertManager Mode = "cert-manager"
+	// ModeSelfSigned issues a self-signed CA and serving certificate, stored
+	// in a Secret shared by the replicas of the controller, rotates them
+	// before they expire and sets the CA bundle of the webhook
+	// configurations
+	ModeSelfSigned Mode = "self-signed"
+)
+
+const (
+	// InjectCAFromSecretAnnotation is the annotation of the webhook
+	// configurations telling the cert-manager CA injector the Secret whose
+	// CA it injects
+	InjectCAFromSecretAnnotation = "cert-manager.io/inject-ca-from-secret"
+
+	// defaultCheckInterval is the interval at which the certificates are
+	// checked for rotation
+	defaultCheckInterval = time.Hour
+)
+
+// ParseMode parses the supplied certificate management mode
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(value); mode {
+	case ModeExternal, ModeCertManager, ModeSelfSigned:
+		return mode, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid webhook certificate mode %q: expected %q, %q or an empty string",
+			value, ModeCertManager, ModeSelfSigned,
+		)
+	}
+}
+
+// ParseObjectKey parses the supplied 'namespace/name' object key
+func ParseObjectKey(value string) (types.NamespacedName, error) {
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return types.NamespacedName{}, fmt.Errorf("expected 'namespace/name', got %q", value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// Options configures a Manager
+type Options struct {
+	// Mode is the way the certificate is managed
+	Mode Mode
+	// Secret is the Secret holding the certificate: the one written by
+	// cert-manager in ModeCertManager, the one written by the Manager in
+	// ModeSelfSigned
+	Secret types.NamespacedName
+	// Service is the Service in front of the webhook server, whose DNS
+	// names the self-signed serving certificate is valid for
+	Service types.NamespacedName
+	// WebhookConfigurations are the names of the mutating and validating
+	// webhook configurations whose CA bundle is managed
+	WebhookConfigurations []string
+	// CertDir is the certificate directory of the webhook server
+	CertDir string
+	// CertName is the name of the certificate file in CertDir
+	CertName string
+	// KeyName is the name of the private key file in CertDir
+	KeyName string
+}
+
+// Manager manages the serving certificate of the webhook server. Manager
+// implements the controller-runtime manager.Runnable interface, and runs on
+// every replica, as each writes the certificate of its own webhook server.
+type Manager struct {
+	log    logr.Logger
+	kc     client.Client
+	reader client.Reader
+	opts   Options
+	issuer *issuer
+	// interval is the interval at which the certificates are checked
+	interval time.Duration
+}
+
+// NewManager returns a Manager of the webhook server certificate configured
+// with the supplied options
+func NewManager(
+	log logr.Logger,
+	kc client.Client,
+	reader client.Reader,
+	opts Options,
+) *Manager {
+	svc := opts.Service
+	return &Manager{
+		log:    log,
+		kc:     kc,
+		reader: reader,
+		opts:   opts,
+		issuer: &issuer{
+			dnsNames: []string{
+				svc.Name + "." + svc.Namespace + ".svc",
+				svc.Name + "." + svc.Namespace + ".svc.cluster.local",
+				svc.Name + "." + svc.Namespace,
+				svc.Name,
+			},
+			now: time.Now,
+		},
+		interval: defaultCheckInterval,
+	}
+}
+
+// WithClock sets the clock used to issue and check the certificates, for
+// tests
+func (m *Manager) WithClock(now func() time.Time) *Manager {
+	m.issuer.now = now
+	return m
+}
+
+// NeedLeaderElection returns false, as every replica of the controller
+// writes its own certificate files
+func (m *Manager) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the certificates, rotating them when needed, until the
+// supplied context is done
+func (m *Manager) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if err := m.Sync(ctx); err != nil {
+			m.log.Error(err, "unable to sync webhook certificate")
+		}
+	}
+}
+
+// Sync brings the certificate and the webhook configurations up to date. In
+// ModeSelfSigned it rotates the certificates of the Secret when needed,
+// writes the serving certificate in the certificate directory and sets the
+// CA bundle of the webhook configurations. In ModeCertManager it annotates
+// the webhook configurations for the cert-manager CA injector.
+func (m *Manager) Sync(ctx context.Context) error {
+	switch m.opts.Mode {
+	case ModeCertManager:
+		return m.patchWebhookConfigurations(ctx, func(obj client.Object, _ []*admissionregistrationv1.WebhookClientConfig) bool {
+			return setAnnotation(obj, InjectCAFromSecretAnnotation, m.opts.Secret.String())
+		})
+	case ModeSelfSigned:
+		data, err := m.rotateSecret(ctx)
+		if err != nil {
+			return fmt.Errorf("rotating certificate secret %s: %v", m.opts.Secret, err)
+		}
+		if err := m.writeFiles(data); err != nil {
+			return fmt.Errorf("writing certificate files: %v", err)
+		}
+		bundle := data[corev1.ServiceAccountRootCAKey]
+		return m.patchWebhookConfigurations(ctx, func(_ client.Object, clientConfigs []*admissionregistrationv1.WebhookClientConfig) bool {
+			return setCABundle(clientConfigs, bundle)
+		})
+	}
+	return nil
+}
+
+// rotateSecret rotates the certificates of the Secret when needed, creating
+// it if it does not exist, and returns its data. Conflicting writes, from
+// other replicas rotating the certificates at the same time, are retried
+// against the Secret they wrote.
+func (m *Manager) rotateSecret(ctx context.Context) (map[string][]byte, error) {
+	var data map[string][]byte
+	retriable := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+	err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+		secret := &corev1.Secret{}
+		err := m.reader.Get(ctx, m.opts.Secret, secret)
+		notFound := apierrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+		rotated, changed, err := m.issuer.rotate(secret.Data)
+		if err != nil {
+			return err
+		}
+		data = rotated
+		if !changed {
+			return nil
+		}
+		if notFound {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: m.opts.Secret.Namespace,
+					Name:      m.opts.Secret.Name,
+				},
+				Type: corev1.SecretTypeTLS,
+				Data: rotated,
+			}
+			err = m.kc.Create(ctx, secret)
+		} else {
+			secret.Data = rotated
+			err = m.kc.Update(ctx, secret)
+		}
+		if err == nil {
+			m.log.Info("rotated webhook certificate", "secret", m.opts.Secret.String())
+		}
+		return err
+	})
+	return data, err
+}
+
+// writeFiles writes the serving certificate and private key of the supplied
+// Secret data in the certificate directory, when they changed. The private
+// key is written first, so that the webhook server, reloading the
+// certificate on each change, ends up with a matching pair.
+func (m *Manager) writeFiles(data map[string][]byte) error {
+	if err := os.MkdirAll(m.opts.CertDir, 0o700); err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(m.opts.CertDir, m.opts.KeyName), data[corev1.TLSPrivateKeyKey]); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(m.opts.CertDir, m.opts.CertName), data[corev1.TLSCertKey])
+}
+
+// writeFile atomically replaces the content of the supplied file, unless it
+// already has the supplied content
+func writeFile(path string, content []byte) error {
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, content) {
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// patchWebhookConfigurations applies the supplied mutation to the mutating
+// and validating webhook configurations with the configured names, patching
+// the ones it changed. The mutation is given the configuration and the client
+// configurations of its webhooks, and returns whether it changed them.
+// Missing configurations are skipped.
+func (m *Manager) patchWebhookConfigurations(
+	ctx context.Context,
+	mutate func(obj client.Object, clientConfigs []*admissionregistrationv1.WebhookClientConfig) bool,
+) error {
+	for _, name := range m.opts.WebhookConfigurations {
+		key := client.ObjectKey{Name: name}
+
+		mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := m.patch(ctx, key, mutating, func() bool {
+			clientConfigs := []*admissionregistrationv1.WebhookClientConfig{}
+			for i := range mutating.Webhooks {
+				clientConfigs = append(clientConfigs, &mutating.Webhooks[i].ClientConfig)
+			}
+			return mutate(mutating, clientConfigs)
+		}); err != nil {
+			return err
+		}
+
+		validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := m.patch(ctx, key, validating, func() bool {
+			clientConfigs := []*admissionregistrationv1.WebhookClientConfig{}
+			for i := range validating.Webhooks {
+				clientConfigs = append(clientConfigs, &validating.Webhooks[i].ClientConfig)
+			}
+			return mutate(validating, clientConfigs)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patch reads the object with the supplied key and patches it if the
+// supplied mutation changed it. Missing objects are skipped.
+func (m *Manager) patch(
+	ctx context.Context,
+	key client.ObjectKey,
+	obj client.Object,
+	mutate func() bool,
+) error {
+	if err := m.reader.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if !mutate() {
+		return nil
+	}
+	if err := m.kc.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("patching webhook configuration %s: %v", key.Name, err)
+	}
+	m.log.Info("updated webhook configuration", "name", key.Name, "mode", string(m.opts.Mode))
+	return nil
+}
+
+// setCABundle sets the CA bundle of the supplied webhook client
+// configurations, returning whether it changed
+func setCABundle(clientConfigs []*admissionregistrationv1.WebhookClientConfig, bundle []byte) bool {
+	changed := false
+	for _, cc := range clientConfigs {
+		if !bytes.Equal(cc.CABundle, bundle) {
+			cc.CABundle = bundle
+			changed = true
+		}
+	}
+	return changed
+}
+
+// setAnnotation sets an annotation of the supplied object, returning whether
+// it changed
+func setAnnotation(obj client.Object, key, value string) bool {
+	annotations := obj.GetAnnotations()
+	if annotations[key] == value {
+		return false
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+	return true
+}
@@ -0,0 +1,202 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhookcert_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/webhookcert"
+)
+
+var secretKey = types.NamespacedName{Namespace: "ack-system", Name: "ack-s3-webhook-cert"}
+
+func newClient() client.Client {
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "ack-s3"},
+			Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "a.s3.services.k8s.aws"}},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "ack-s3"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{Name: "b.s3.services.k8s.aws"}, {Name: "c.s3.services.k8s.aws"},
+			},
+		},
+	).Build()
+}
+
+func newManager(kc client.Client, mode webhookcert.Mode, dir string, now *time.Time) *webhookcert.Manager {
+	return webhookcert.NewManager(logr.Discard(), kc, kc, webhookcert.Options{
+		Mode:                  mode,
+		Secret:                secretKey,
+		Service:               types.NamespacedName{Namespace: "ack-system", Name: "ack-s3-webhook"},
+		WebhookConfigurations: []string{"ack-s3", "missing"},
+		CertDir:               dir,
+		CertName:              "tls.crt",
+		KeyName:               "tls.key",
+	}).WithClock(func() time.Time { return *now })
+}
+
+// caBundles returns the CA bundles of the webhooks of the test configurations
+func caBundles(t *testing.T, kc client.Client) [][]byte {
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.Nil(t, kc.Get(context.Background(), client.ObjectKey{Name: "ack-s3"}, mutating))
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	require.Nil(t, kc.Get(context.Background(), client.ObjectKey{Name: "ack-s3"}, validating))
+	bundles := [][]byte{mutating.Webhooks[0].ClientConfig.CABundle}
+	for _, wh := range validating.Webhooks {
+		bundles = append(bundles, wh.ClientConfig.CABundle)
+	}
+	return bundles
+}
+
+// servingCert returns the serving certificate written in the supplied
+// directory, checking it is valid for the supplied CA bundle
+func servingCert(t *testing.T, dir string, bundle []byte, now time.Time) *x509.Certificate {
+	pair, err := tls.LoadX509KeyPair(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"))
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	require.Nil(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(bundle))
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:     "ack-s3-webhook.ack-system.svc",
+		Roots:       roots,
+		CurrentTime: now,
+	})
+	require.Nil(t, err)
+	return cert
+}
+
+func countCerts(bundle []byte) int {
+	n := 0
+	for {
+		var block *pem.Block
+		if block, bundle = pem.Decode(bundle); block == nil {
+			return n
+		}
+		n++
+	}
+}
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	for _, value := range []string{"", "cert-manager", "self-signed"} {
+		mode, err := webhookcert.ParseMode(value)
+		require.Nil(err)
+		require.Equal(webhookcert.Mode(value), mode)
+	}
+	_, err := webhookcert.ParseMode("vault")
+	require.NotNil(err)
+
+	key, err := webhookcert.ParseObjectKey("ack-system/ack-s3-webhook-cert")
+	require.Nil(err)
+	require.Equal(secretKey, key)
+	for _, value := range []string{"", "name", "/name", "ns/", "ns/a/b"} {
+		_, err := webhookcert.ParseObjectKey(value)
+		require.NotNil(err, value)
+	}
+}
+
+func TestManager_SelfSigned(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kc := newClient()
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	m := newManager(kc, webhookcert.ModeSelfSigned, dir, &now)
+
+	require.Nil(m.Sync(ctx))
+	secret := &corev1.Secret{}
+	require.Nil(kc.Get(ctx, secretKey, secret))
+	require.Equal(corev1.SecretTypeTLS, secret.Type)
+	bundle := secret.Data[corev1.ServiceAccountRootCAKey]
+	require.Equal(1, countCerts(bundle))
+	for _, b := range caBundles(t, kc) {
+		require.Equal(bundle, b)
+	}
+	first := servingCert(t, dir, bundle, now)
+
+	// Nothing changes while the certificates are fresh
+	now = now.Add(24 * time.Hour)
+	require.Nil(m.Sync(ctx))
+	require.Equal(first.Raw, servingCert(t, dir, bundle, now).Raw)
+
+	// The serving certificate is reissued by the same CA before it expires
+	now = now.Add(70 * 24 * time.Hour)
+	require.Nil(m.Sync(ctx))
+	require.Nil(kc.Get(ctx, secretKey, secret))
+	require.Equal(bundle, secret.Data[corev1.ServiceAccountRootCAKey])
+	second := servingCert(t, dir, bundle, now)
+	require.NotEqual(first.Raw, second.Raw)
+
+	// A rotated CA is trusted along with the previous one until it expires
+	now = now.Add(250 * 24 * time.Hour)
+	require.Nil(m.Sync(ctx))
+	require.Nil(kc.Get(ctx, secretKey, secret))
+	rotated := secret.Data[corev1.ServiceAccountRootCAKey]
+	require.Equal(2, countCerts(rotated))
+	for _, b := range caBundles(t, kc) {
+		require.Equal(rotated, b)
+	}
+	servingCert(t, dir, rotated, now)
+
+	// Another replica picks up the certificates of the Secret
+	otherDir := t.TempDir()
+	require.Nil(newManager(kc, webhookcert.ModeSelfSigned, otherDir, &now).Sync(ctx))
+	got, err := os.ReadFile(filepath.Join(otherDir, "tls.crt"))
+	require.Nil(err)
+	require.Equal(secret.Data[corev1.TLSCertKey], got)
+}
+
+func TestManager_CertManager(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kc := newClient()
+	dir := t.TempDir()
+	now := time.Now()
+	require.Nil(newManager(kc, webhookcert.ModeCertManager, dir, &now).Sync(ctx))
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.Nil(kc.Get(ctx, client.ObjectKey{Name: "ack-s3"}, mutating))
+	require.Equal(secretKey.String(), mutating.Annotations[webhookcert.InjectCAFromSecretAnnotation])
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	require.Nil(kc.Get(ctx, client.ObjectKey{Name: "ack-s3"}, validating))
+	require.Equal(secretKey.String(), validating.Annotations[webhookcert.InjectCAFromSecretAnnotation])
+
+	// The certificate itself is left to cert-manager
+	require.NotNil(kc.Get(ctx, secretKey, &corev1.Secret{}))
+	_, err := os.Stat(filepath.Join(dir, "tls.crt"))
+	require.True(os.IsNotExist(err))
+}
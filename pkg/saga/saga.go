@@ -0,0 +1,143 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package saga lets resource managers whose updates span multiple AWS API
+// calls register, after each successful call, the compensating action undoing
+// it. When an update fails, the runtime executes the compensating actions of
+// the successful calls, in reverse order, so that the AWS resource is not left
+// half-updated, and records the outcome in the status of the resource.
+//
+// A resource manager registers the steps of an update on the saga of the
+// context of its Update call:
+//
+//	if _, err := rm.sdkapi.PutBucketLogging(ctx, input); err != nil {
+//	    return nil, err
+//	}
+//	saga.FromContext(ctx).Step("PutBucketLogging", func(ctx context.Context) error {
+//	    _, err := rm.sdkapi.PutBucketLogging(ctx, previousInput)
+//	    return err
+//	})
+package saga
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the context key of the Saga of an update
+type contextKey struct{}
+
+// Compensation is a compensating action, undoing a successful step of an
+// update
+type Compensation func(ctx context.Context) error
+
+// step is a successful step of an update
+type step struct {
+	name       string
+	compensate Compensation
+}
+
+// Saga records the successful steps of an update and their compensating
+// actions. A nil Saga records nothing, so that resource managers can register
+// steps whether or not the runtime coordinates their updates.
+type Saga struct {
+	sync.Mutex
+	steps []step
+}
+
+// WithSaga returns a copy of the supplied context holding a new, empty, Saga
+func WithSaga(ctx context.Context) (context.Context, *Saga) {
+	s := &Saga{}
+	return context.WithValue(ctx, contextKey{}, s), s
+}
+
+// FromContext returns the Saga of the supplied context, or nil if it has none
+func FromContext(ctx context.Context) *Saga {
+	s, _ := ctx.Value(contextKey{}).(*Saga)
+	return s
+}
+
+// Step records a successful step of the update, and the compensating action
+// undoing it. A nil compensating action marks a step that cannot be undone.
+func (s *Saga) Step(name string, compensate Compensation) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.steps = append(s.steps, step{name: name, compensate: compensate})
+}
+
+// Steps returns the names of the successful steps of the update, in order
+func (s *Saga) Steps() []string {
+	if s == nil {
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	names := make([]string, 0, len(s.steps))
+	for _, st := range s.steps {
+		names = append(names, st.name)
+	}
+	return names
+}
+
+// Failure is a step whose compensating action failed, or that has none
+type Failure struct {
+	// Step is the name of the step
+	Step string
+	// Err is the error of the compensating action, nil if the step has no
+	// compensating action
+	Err error
+}
+
+// Result is the outcome of the compensation of the steps of an update
+type Result struct {
+	// Compensated contains the names of the steps that were undone, in the
+	// order they were undone
+	Compensated []string
+	// Failed contains the steps that could not be undone
+	Failed []Failure
+}
+
+// RolledBack returns true if all the steps of the update were undone
+func (r Result) RolledBack() bool {
+	return len(r.Failed) == 0
+}
+
+// Compensate executes the compensating actions of the successful steps of the
+// update, in reverse order. A failed compensating action does not prevent the
+// others from being executed. The steps are forgotten once compensated.
+func (s *Saga) Compensate(ctx context.Context) Result {
+	result := Result{}
+	if s == nil {
+		return result
+	}
+	s.Lock()
+	steps := s.steps
+	s.steps = nil
+	s.Unlock()
+	for i := len(steps) - 1; i >= 0; i-- {
+		st := steps[i]
+		if st.compensate == nil {
+			result.Failed = append(result.Failed, Failure{Step: st.name})
+			continue
+		}
+		if err := st.compensate(ctx); err != nil {
+			result.Failed = append(result.Failed, Failure{Step: st.name, Err: err})
+			continue
+		}
+		result.Compensated = append(result.Compensated, st.name)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/saga"
+)
+
+func TestSaga(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(saga.FromContext(context.TODO()))
+	// Steps registered without a saga are ignored
+	saga.FromContext(context.TODO()).Step("ignored", nil)
+
+	ctx, s := saga.WithSaga(context.TODO())
+	require.Equal(s, saga.FromContext(ctx))
+
+	undone := []string{}
+	undo := func(name string, err error) saga.Compensation {
+		return func(ctx context.Context) error {
+			if err == nil {
+				undone = append(undone, name)
+			}
+			return err
+		}
+	}
+	s.Step("PutLogging", undo("PutLogging", nil))
+	s.Step("PutPolicy", undo("PutPolicy", errors.New("access denied")))
+	s.Step("PutTags", nil)
+	s.Step("PutCors", undo("PutCors", nil))
+	require.Equal([]string{"PutLogging", "PutPolicy", "PutTags", "PutCors"}, s.Steps())
+
+	result := s.Compensate(ctx)
+	require.False(result.RolledBack())
+	require.Equal([]string{"PutCors", "PutLogging"}, result.Compensated)
+	require.Equal([]string{"PutCors", "PutLogging"}, undone)
+	require.Len(result.Failed, 2)
+	require.Equal("PutTags", result.Failed[0].Step)
+	require.Nil(result.Failed[0].Err)
+	require.Equal("PutPolicy", result.Failed[1].Step)
+	require.EqualError(result.Failed[1].Err, "access denied")
+
+	// The steps are forgotten once compensated
+	require.Empty(s.Steps())
+	require.True(s.Compensate(ctx).RolledBack())
+}
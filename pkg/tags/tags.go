@@ -13,6 +13,10 @@
 
 package tags
 
+// ClusterIDTagKey is the key of the tag identifying the cluster whose ACK
+// service controller manages an AWS resource
+const ClusterIDTagKey = "services.k8s.aws/cluster-id"
+
 // Tags represents the AWS tags which will be added to the AWS resource.
 // Inside aws-sdk-go, Tags are represented using multiple types, Ex: map of
 // string, list of structs etc...
@@ -0,0 +1,29 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// AWSGlobalServiceDescriber is an optional interface that an
+// AWSResourceManagerFactory can implement in order to declare that its
+// resources belong to a global AWS service, e.g. IAM or Route53, whose
+// resources are the same whatever the region of the endpoint called. The
+// runtime tracks the health of the regional endpoints, including the STS
+// endpoints assuming the IAM roles of cross account resource management, and
+// reconciles the resources through the endpoints of the failover regions
+// while the endpoints of their region fail.
+type AWSGlobalServiceDescriber interface {
+	// FailoverRegions returns the regions whose endpoints may be called
+	// instead of the endpoints of the region of a resource, e.g.
+	// ["us-east-1", "us-west-2"]
+	FailoverRegions() []string
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// AWSResourceOperation is an operation performed by an AWSResourceManager on
+// an AWS resource
+type AWSResourceOperation string
+
+const (
+	// AWSResourceOperationReadOne is the AWSResourceManager.ReadOne operation
+	AWSResourceOperationReadOne AWSResourceOperation = "ReadOne"
+	// AWSResourceOperationCreate is the AWSResourceManager.Create operation
+	AWSResourceOperationCreate AWSResourceOperation = "Create"
+	// AWSResourceOperationUpdate is the AWSResourceManager.Update operation
+	AWSResourceOperationUpdate AWSResourceOperation = "Update"
+	// AWSResourceOperationDelete is the AWSResourceManager.Delete operation
+	AWSResourceOperationDelete AWSResourceOperation = "Delete"
+)
+
+// AWSResourceActionDescriber is an optional interface that an
+// AWSResourceManager can implement in order to declare the IAM actions its
+// operations require. When the controller is configured with the IAM actions
+// it is granted, the operations requiring actions that are not granted are
+// skipped and reported instead of being attempted.
+type AWSResourceActionDescriber interface {
+	// RequiredActions returns the IAM actions (e.g. "s3:CreateBucket")
+	// required to perform the supplied operation on the supplied resource
+	RequiredActions(AWSResourceOperation, AWSResource) []string
+}
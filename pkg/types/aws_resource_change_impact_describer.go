@@ -0,0 +1,49 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// ChangeImpact is the impact on an AWS resource of changing one of its fields
+type ChangeImpact string
+
+const (
+	// ChangeImpactReplacement is the impact of changing a field that cannot
+	// be updated in place: the AWS resource is deleted and created again
+	ChangeImpactReplacement ChangeImpact = "Replacement"
+	// ChangeImpactDowntime is the impact of changing a field whose update
+	// makes the AWS resource unavailable for a while, e.g. while it restarts
+	ChangeImpactDowntime ChangeImpact = "Downtime"
+	// ChangeImpactDestructive is the impact of changing a field whose update
+	// loses data, e.g. shrinking a volume
+	ChangeImpactDestructive ChangeImpact = "Destructive"
+)
+
+// FieldChangeImpact describes the impact of changing a field of an AWS
+// resource
+type FieldChangeImpact struct {
+	// Impact is the impact of changing the field
+	Impact ChangeImpact
+	// Message is an optional explanation of the impact, shown to users
+	Message string
+}
+
+// AWSResourceChangeImpactDescriber is an optional interface that an
+// AWSResourceManagerFactory can implement in order to have the validating
+// webhook warn users, when they apply a change, that it will replace the AWS
+// resource, cause downtime or lose data.
+type AWSResourceChangeImpactDescriber interface {
+	// FieldChangeImpacts returns the impacts of changing fields of the AWS
+	// resource, keyed by Delta field path (e.g. "Spec.Engine"). An impact
+	// applies to changes of the field and of all of its subfields.
+	FieldChangeImpacts() map[string]FieldChangeImpact
+}
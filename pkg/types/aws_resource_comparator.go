@@ -0,0 +1,28 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+// AWSResourceComparator is an optional interface that an AWSResourceManager
+// can implement in order to have some fields compared semantically (e.g. as
+// CIDR blocks or ARNs) rather than by value.
+type AWSResourceComparator interface {
+	// FieldComparators returns the semantic comparators, keyed by Delta
+	// field path, used to discard differences between semantically equal
+	// values from the Delta returned by AWSResourceDescriptor.Delta
+	FieldComparators() ackcompare.FieldComparators
+}
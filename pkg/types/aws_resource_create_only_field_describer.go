@@ -0,0 +1,29 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// AWSResourceCreateOnlyFieldDescriber is an optional interface that an
+// AWSResourceManager can implement in order to declare the spec fields that
+// can only be set when a resource is created and that ReadOne cannot read
+// back from the AWS service API (e.g. initial passwords).
+//
+// When such a resource is adopted, the runtime fills these fields from the
+// spec or the adoption fields of the resource, and otherwise annotates them
+// as unknowable so that they are excluded from drift detection.
+type AWSResourceCreateOnlyFieldDescriber interface {
+	// CreateOnlyFields returns the dot-separated JSON paths of the
+	// create-only fields, starting at the root of the object, e.g.
+	// "spec.masterUserPassword". Paths cannot traverse lists.
+	CreateOnlyFields() []string
+}
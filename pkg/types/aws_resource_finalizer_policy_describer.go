@@ -0,0 +1,34 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// AWSResourceFinalizerPolicyDescriber is an optional interface that an
+// AWSResourceManager can implement in order to declare when the finalizer
+// of the resources it manages is added, relative to the creation of their
+// AWS resource.
+//
+// The finalizer policy annotation of a resource takes precedence over the
+// policy of its kind, which takes precedence over the policy of the
+// controller configuration.
+type AWSResourceFinalizerPolicyDescriber interface {
+	// FinalizerPolicy returns the finalizer policy of the resources of the
+	// kind, e.g. FinalizerPolicyBeforeCreate for the AWS resources whose
+	// identifier is generated by the AWS service API and could not be found
+	// again if the creation was interrupted.
+	FinalizerPolicy() ackv1alpha1.FinalizerPolicy
+}
@@ -38,7 +38,8 @@ type AWSResourceManager interface {
 	//
 	// Implementers should return (nil, ackerrors.NotFound) when the backend
 	// AWS service API cannot find the resource identified by the supplied
-	// AWSResource's AWS identifier information.
+	// AWSResource's AWS identifier information, or an
+	// ackerrors.ResourceNotFoundError identifying the missing resource.
 	ReadOne(context.Context, AWSResource) (AWSResource, error)
 	// Create attempts to create the supplied AWSResource in the backend AWS
 	// service API, returning an AWSResource representing the newly-created
@@ -0,0 +1,32 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+// AWSResourceNormalizer is an optional interface that an AWSResourceManager
+// can implement in order to have the values of some fields normalized before
+// the desired and latest observed states of a resource are compared.
+//
+// This avoids reporting differences between semantically equal values that
+// are only formatted differently (e.g. JSON policy documents with different
+// white space or key order).
+type AWSResourceNormalizer interface {
+	// FieldNormalizers returns the normalizers to apply, keyed by field
+	// path, to both the desired and latest resources before calling
+	// AWSResourceDescriptor.Delta
+	FieldNormalizers() ackcompare.FieldNormalizers
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	"context"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+// AWSResourceUpdateGroup is a group of fields of a resource that an
+// AWSResourcePartialUpdater updates with dedicated AWS API calls, e.g. the
+// tags or the logging configuration of the resource.
+type AWSResourceUpdateGroup struct {
+	// Name identifies the group, e.g. "tags"
+	Name string
+	// Paths are the Delta field paths (e.g. "Spec.Tags") of the fields of
+	// the group
+	Paths []string
+}
+
+// AWSResourcePartialUpdater is an optional interface that an
+// AWSResourceManager can implement in order to update only the groups of
+// fields of a resource that changed, instead of the whole resource. Updating
+// the whole resource restarts or interrupts some AWS resources.
+//
+// The runtime calls UpdateGroup, in the order of the groups returned by
+// UpdateGroups, for each group with differences, and tracks the success or
+// failure of each group. The differences outside of all the groups are passed
+// to AWSResourceManager.Update.
+type AWSResourcePartialUpdater interface {
+	// UpdateGroups returns the update groups of the resources
+	UpdateGroups() []AWSResourceUpdateGroup
+	// UpdateGroup updates the fields of the supplied group of the supplied
+	// resource. The supplied Delta only contains the differences of the
+	// group.
+	UpdateGroup(
+		ctx context.Context,
+		group string,
+		desired AWSResource,
+		latest AWSResource,
+		delta *ackcompare.Delta,
+	) (AWSResource, error)
+}
@@ -0,0 +1,32 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// AWSResourceQuotaDescriber is an optional interface that an
+// AWSResourceManagerFactory can implement in order to declare the AWS service
+// quotas its resources consume. When the --service-quota-interval-seconds
+// flag is set, the runtime periodically reads the usage of these quotas from
+// Service Quotas and exports their remaining capacity.
+type AWSResourceQuotaDescriber interface {
+	// Quotas returns the service quotas consumed by the resources
+	Quotas() []AWSResourceQuota
+}
+
+// AWSResourceQuota identifies an AWS service quota in Service Quotas
+type AWSResourceQuota struct {
+	// ServiceCode is the code of the AWS service of the quota, e.g. "ec2"
+	ServiceCode string
+	// QuotaCode is the code of the quota, e.g. "L-0263D0A3"
+	QuotaCode string
+}
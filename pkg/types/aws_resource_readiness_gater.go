@@ -0,0 +1,37 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// ReadinessGate is a predicate on the latest observed state of a resource,
+// e.g. the state returned by ReadOne, that must hold for the resource to be
+// synced.
+type ReadinessGate struct {
+	// Name identifies the gate in the ACK.ResourceSynced condition of the
+	// resources that do not meet it, e.g. "available"
+	Name string
+	// Expression is a jq expression evaluated on the resource, e.g.
+	// `.status.status == "AVAILABLE" and (.status.endpoints | length > 0)`.
+	// The gate is met if the expression evaluates to true.
+	Expression string
+}
+
+// AWSResourceReadinessGater is an optional interface that an
+// AWSResourceManager can implement in order to declare the readiness gates of
+// its resources. The runtime evaluates the gates on the latest observed state
+// of the resources, and only considers the resources meeting all of them
+// synced, before calling IsSynced.
+type AWSResourceReadinessGater interface {
+	// ReadinessGates returns the readiness gates of the supplied resource
+	ReadinessGates(AWSResource) []ReadinessGate
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// AWSResourceSensitiveFieldDescriber is an optional interface that an
+// AWSResourceManagerFactory can implement in order to declare the fields of
+// its resources holding sensitive values. When field encryption is enabled,
+// the mutating webhook encrypts these fields before the resources are stored,
+// and the runtime decrypts them before the resources are reconciled.
+type AWSResourceSensitiveFieldDescriber interface {
+	// SensitiveFields returns the dot-separated paths, in the JSON
+	// representation of the custom resource, of its sensitive string fields,
+	// e.g. "spec.masterUserPassword"
+	SensitiveFields() []string
+}
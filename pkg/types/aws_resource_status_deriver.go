@@ -0,0 +1,35 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// AWSResourceStatusDeriver is an optional interface that an
+// AWSResourceManager can implement in order to have fields of the status of
+// its resources derived from their latest observed state, e.g. an endpoint
+// URL assembled from several fields returned by the AWS service API, or the
+// number of days until a certificate expires.
+//
+// The derived fields are computed again at the end of each reconciliation, so
+// that they never go stale. Being status fields, they are never compared with
+// the desired state of the resources.
+type AWSResourceStatusDeriver interface {
+	// DerivedStatusFields returns the computations of the derived fields,
+	// keyed by their dot-separated path relative to the status of the
+	// resource (e.g. "endpointURL" or "certificate.daysUntilExpiry")
+	DerivedStatusFields() map[string]DerivedStatusField
+}
+
+// DerivedStatusField computes the value of a derived status field from the
+// supplied latest observed state of a resource. The value must be encodable
+// to JSON. A nil value removes the field from the status.
+type DerivedStatusField func(AWSResource) (interface{}, error)
@@ -0,0 +1,31 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	"context"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/requeue"
+)
+
+// AWSResourceSyncHinter is an optional interface that an AWSResourceManager
+// can implement in order to control when a resource is next reconciled,
+// depending on its latest observed state.
+type AWSResourceSyncHinter interface {
+	// SyncHint returns a hint about when the supplied latest observed
+	// resource should be reconciled again, or nil to use the runtime
+	// defaults (the resync period for synced resources, and
+	// requeue.DefaultRequeueAfterDuration otherwise).
+	SyncHint(context.Context, AWSResource) *requeue.SyncHint
+}
@@ -0,0 +1,27 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+)
+
+// AWSResourceTagGetter is an optional interface that an AWSResourceManager
+// can implement in order to expose the AWS tags of a resource to the
+// runtime, e.g. to check which cluster manages the resource.
+type AWSResourceTagGetter interface {
+	// GetTags returns the AWS tags of the supplied resource, as last observed
+	// by the resource manager. Resources that do not support tags return nil.
+	GetTags(AWSResource) acktags.Tags
+}
@@ -0,0 +1,28 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+// AWSResourceUpdateCompensator is an optional interface that an
+// AWSResourceManager, whose updates span multiple AWS API calls, can implement
+// in order to have the runtime undo the successful calls of its failed
+// updates. The context of the updates of the resources it compensates holds a
+// saga.Saga, on which the resource manager registers, after each successful
+// call, the compensating action undoing it. When the update fails, the
+// runtime executes the compensating actions in reverse order and records the
+// outcome in the ACK.UpdateRolledBack condition of the resource.
+type AWSResourceUpdateCompensator interface {
+	// CompensatesUpdates returns true if the successful calls of the failed
+	// updates of the supplied resource should be undone
+	CompensatesUpdates(AWSResource) bool
+}
@@ -0,0 +1,32 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// AWSResourceWatchSourceProvider is an optional interface that an
+// AWSResourceManagerFactory can implement in order to have the controller of
+// its resource kind reconcile resources when other objects change, e.g. to
+// reconcile IAM roles when the Deployments using them change.
+type AWSResourceWatchSourceProvider interface {
+	// WatchSources returns the additional sources of reconcile requests of
+	// the controller of the resource kind. The sources are not subject to
+	// the event filters of the controller, and should map events to the
+	// requests of the resources to reconcile, e.g. with
+	// handler.EnqueueRequestsFromMapFunc.
+	WatchSources(mgr ctrlrt.Manager) ([]source.Source, error)
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	"context"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+// ReconcileHook is called by the reconcilers of a service controller around
+// the creations, updates and deletions of the AWS resources, e.g. to audit
+// them, to enforce policies on them or to inject custom tags. Hooks are
+// registered on the service controller with
+// ReconcileHookRegistrar.WithReconcileHooks: their Pre methods are called in
+// the order of their registration, and their Post methods in the reverse
+// order.
+//
+// Hooks are called for the resources of every kind of the service controller,
+// from concurrent reconciliations.
+type ReconcileHook interface {
+	// PreCreate is called before the AWS resource of the supplied desired
+	// resource is created. It returns the desired resource to create, which
+	// the next hooks are called with, or an error aborting the creation.
+	PreCreate(
+		ctx context.Context,
+		desired AWSResource,
+	) (AWSResource, error)
+	// PostCreate is called after the creation of the AWS resource of the
+	// supplied desired resource, with the created resource and the error of
+	// the creation, if any
+	PostCreate(
+		ctx context.Context,
+		desired AWSResource,
+		latest AWSResource,
+		err error,
+	)
+	// PreUpdate is called before the AWS resource of the supplied desired
+	// resource, whose latest observed state is supplied along with the
+	// differences to update, is updated. It returns the desired resource to
+	// update, which the next hooks are called with, or an error aborting the
+	// update.
+	PreUpdate(
+		ctx context.Context,
+		desired AWSResource,
+		latest AWSResource,
+		delta *ackcompare.Delta,
+	) (AWSResource, error)
+	// PostUpdate is called after the update of the AWS resource of the
+	// supplied desired resource, with the updated resource and the error of
+	// the update, if any
+	PostUpdate(
+		ctx context.Context,
+		desired AWSResource,
+		latest AWSResource,
+		err error,
+	)
+	// PreDelete is called before the AWS resource of the supplied resource is
+	// deleted. It returns an error aborting the deletion.
+	PreDelete(
+		ctx context.Context,
+		current AWSResource,
+	) error
+	// PostDelete is called after the deletion of the AWS resource of the
+	// supplied resource, with the error of the deletion, if any
+	PostDelete(
+		ctx context.Context,
+		current AWSResource,
+		err error,
+	)
+}
+
+// ReconcileHookRegistrar is an optional interface that a ServiceController
+// can implement in order to accept reconcile hooks. The service controller
+// of the ACK runtime implements it.
+type ReconcileHookRegistrar interface {
+	// WithReconcileHooks appends the supplied hooks to the ones called by the
+	// reconcilers around the creations, updates and deletions of the AWS
+	// resources
+	WithReconcileHooks(...ReconcileHook) ServiceController
+}
@@ -0,0 +1,196 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package unstructured provides typed accessors for the common ACK fields
+// (`status.ackResourceMetadata` and `status.conditions`) of ACK custom
+// resources represented as `unstructured.Unstructured` objects.
+package unstructured
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+var (
+	// resourceMetadataPath is the path of the ACK resource metadata within
+	// an ACK custom resource.
+	resourceMetadataPath = []string{"status", "ackResourceMetadata"}
+	// conditionsPath is the path of the conditions within an ACK custom
+	// resource.
+	conditionsPath = []string{"status", "conditions"}
+
+	// accountIDRegexp matches a valid AWS account ID
+	accountIDRegexp = regexp.MustCompile(`^[0-9]{12}$`)
+	// regionRegexp matches a valid AWS region name
+	regionRegexp = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+)
+
+// GetResourceMetadata returns the `status.ackResourceMetadata` object of the
+// supplied unstructured object, or nil if the field is not set. An error is
+// returned if the field cannot be decoded or fails validation.
+func GetResourceMetadata(
+	u *k8sunstructured.Unstructured,
+) (*ackv1alpha1.ResourceMetadata, error) {
+	raw, found, err := k8sunstructured.NestedMap(u.Object, resourceMetadataPath...)
+	if err != nil {
+		return nil, fmt.Errorf("reading resource metadata: %v", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	metadata := &ackv1alpha1.ResourceMetadata{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(raw, metadata); err != nil {
+		return nil, fmt.Errorf("decoding resource metadata: %v", err)
+	}
+	if err := ValidateResourceMetadata(metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// SetResourceMetadata validates and writes the supplied resource metadata
+// into the `status.ackResourceMetadata` field of the unstructured object. A
+// nil metadata removes the field.
+func SetResourceMetadata(
+	u *k8sunstructured.Unstructured,
+	metadata *ackv1alpha1.ResourceMetadata,
+) error {
+	if metadata == nil {
+		k8sunstructured.RemoveNestedField(u.Object, resourceMetadataPath...)
+		return nil
+	}
+	if err := ValidateResourceMetadata(metadata); err != nil {
+		return err
+	}
+	raw, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(metadata)
+	if err != nil {
+		return fmt.Errorf("encoding resource metadata: %v", err)
+	}
+	return k8sunstructured.SetNestedMap(u.Object, raw, resourceMetadataPath...)
+}
+
+// ValidateResourceMetadata returns an error if any of the set fields of the
+// supplied resource metadata are malformed.
+func ValidateResourceMetadata(metadata *ackv1alpha1.ResourceMetadata) error {
+	if metadata.ARN != nil && *metadata.ARN != "" && !arn.IsARN(string(*metadata.ARN)) {
+		return fmt.Errorf("invalid resource metadata: %q is not a valid ARN", *metadata.ARN)
+	}
+	if metadata.OwnerAccountID != nil && !accountIDRegexp.MatchString(string(*metadata.OwnerAccountID)) {
+		return fmt.Errorf("invalid resource metadata: %q is not a valid AWS account ID", *metadata.OwnerAccountID)
+	}
+	if metadata.Region != nil && !regionRegexp.MatchString(string(*metadata.Region)) {
+		return fmt.Errorf("invalid resource metadata: %q is not a valid AWS region", *metadata.Region)
+	}
+	return nil
+}
+
+// GetConditions returns the `status.conditions` of the supplied unstructured
+// object. An error is returned if the field cannot be decoded.
+func GetConditions(
+	u *k8sunstructured.Unstructured,
+) ([]*ackv1alpha1.Condition, error) {
+	raw, found, err := k8sunstructured.NestedSlice(u.Object, conditionsPath...)
+	if err != nil {
+		return nil, fmt.Errorf("reading conditions: %v", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	conditions := make([]*ackv1alpha1.Condition, 0, len(raw))
+	for i, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("decoding condition %d: expected an object, got %T", i, r)
+		}
+		condition := &ackv1alpha1.Condition{}
+		if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(m, condition); err != nil {
+			return nil, fmt.Errorf("decoding condition %d: %v", i, err)
+		}
+		if condition.Type == "" {
+			return nil, fmt.Errorf("decoding condition %d: missing condition type", i)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// SetConditions replaces the `status.conditions` of the supplied unstructured
+// object with the supplied conditions.
+func SetConditions(
+	u *k8sunstructured.Unstructured,
+	conditions []*ackv1alpha1.Condition,
+) error {
+	raw := make([]interface{}, 0, len(conditions))
+	for _, condition := range conditions {
+		if condition == nil {
+			continue
+		}
+		if condition.Type == "" {
+			return fmt.Errorf("encoding condition: missing condition type")
+		}
+		m, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(condition)
+		if err != nil {
+			return fmt.Errorf("encoding condition %q: %v", condition.Type, err)
+		}
+		raw = append(raw, m)
+	}
+	return k8sunstructured.SetNestedSlice(u.Object, raw, conditionsPath...)
+}
+
+// GetCondition returns the first condition of the supplied type in the
+// unstructured object, or nil if there is no such condition.
+func GetCondition(
+	u *k8sunstructured.Unstructured,
+	conditionType ackv1alpha1.ConditionType,
+) (*ackv1alpha1.Condition, error) {
+	conditions, err := GetConditions(u)
+	if err != nil {
+		return nil, err
+	}
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition, nil
+		}
+	}
+	return nil, nil
+}
+
+// SetCondition adds the supplied condition to the unstructured object,
+// replacing any existing condition of the same type.
+func SetCondition(
+	u *k8sunstructured.Unstructured,
+	condition *ackv1alpha1.Condition,
+) error {
+	conditions, err := GetConditions(u)
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, c := range conditions {
+		if c.Type == condition.Type {
+			conditions[i] = condition
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		conditions = append(conditions, condition)
+	}
+	return SetConditions(u, conditions)
+}
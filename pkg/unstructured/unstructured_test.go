@@ -0,0 +1,159 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package unstructured_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackunstructured "github.com/aws-controllers-k8s/runtime/pkg/unstructured"
+)
+
+func TestResourceMetadata(t *testing.T) {
+	require := require.New(t)
+
+	u := &k8sunstructured.Unstructured{Object: map[string]interface{}{}}
+	metadata, err := ackunstructured.GetResourceMetadata(u)
+	require.Nil(err)
+	require.Nil(metadata)
+
+	arn := ackv1alpha1.AWSResourceName("arn:aws:s3:::my-bucket")
+	account := ackv1alpha1.AWSAccountID("123456789012")
+	region := ackv1alpha1.AWSRegion("us-west-2")
+	require.Nil(ackunstructured.SetResourceMetadata(u, &ackv1alpha1.ResourceMetadata{
+		ARN:            &arn,
+		OwnerAccountID: &account,
+		Region:         &region,
+	}))
+
+	raw, found, err := k8sunstructured.NestedString(u.Object, "status", "ackResourceMetadata", "arn")
+	require.Nil(err)
+	require.True(found)
+	require.Equal(string(arn), raw)
+
+	metadata, err = ackunstructured.GetResourceMetadata(u)
+	require.Nil(err)
+	require.Equal(arn, *metadata.ARN)
+	require.Equal(account, *metadata.OwnerAccountID)
+	require.Equal(region, *metadata.Region)
+
+	require.Nil(ackunstructured.SetResourceMetadata(u, nil))
+	metadata, err = ackunstructured.GetResourceMetadata(u)
+	require.Nil(err)
+	require.Nil(metadata)
+}
+
+func TestValidateResourceMetadata(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		arn     *string
+		account *string
+		region  *string
+		wantErr bool
+	}{
+		{"empty", nil, nil, nil, false},
+		{"valid", ptr("arn:aws:s3:::my-bucket"), ptr("123456789012"), ptr("us-gov-west-1"), false},
+		{"empty arn", ptr(""), nil, nil, false},
+		{"invalid arn", ptr("my-bucket"), nil, nil, true},
+		{"invalid account", nil, ptr("1234"), nil, true},
+		{"invalid region", nil, nil, ptr("US_WEST_2"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := &ackv1alpha1.ResourceMetadata{}
+			if tt.arn != nil {
+				arn := ackv1alpha1.AWSResourceName(*tt.arn)
+				metadata.ARN = &arn
+			}
+			if tt.account != nil {
+				account := ackv1alpha1.AWSAccountID(*tt.account)
+				metadata.OwnerAccountID = &account
+			}
+			if tt.region != nil {
+				region := ackv1alpha1.AWSRegion(*tt.region)
+				metadata.Region = &region
+			}
+			err := ackunstructured.ValidateResourceMetadata(metadata)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestGetResourceMetadata_Invalid(t *testing.T) {
+	u := &k8sunstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"ackResourceMetadata": map[string]interface{}{
+				"ownerAccountID": "not-an-account",
+			},
+		},
+	}}
+	_, err := ackunstructured.GetResourceMetadata(u)
+	require.NotNil(t, err)
+
+	u.Object["status"] = map[string]interface{}{"ackResourceMetadata": "oops"}
+	_, err = ackunstructured.GetResourceMetadata(u)
+	require.NotNil(t, err)
+}
+
+func TestConditions(t *testing.T) {
+	require := require.New(t)
+
+	u := &k8sunstructured.Unstructured{Object: map[string]interface{}{}}
+	conditions, err := ackunstructured.GetConditions(u)
+	require.Nil(err)
+	require.Empty(conditions)
+
+	msg := "synced"
+	require.Nil(ackunstructured.SetCondition(u, &ackv1alpha1.Condition{
+		Type:    ackv1alpha1.ConditionTypeResourceSynced,
+		Status:  corev1.ConditionFalse,
+		Message: &msg,
+	}))
+	require.Nil(ackunstructured.SetCondition(u, &ackv1alpha1.Condition{
+		Type:   ackv1alpha1.ConditionTypeTerminal,
+		Status: corev1.ConditionTrue,
+	}))
+	// Replaces the existing condition of the same type
+	require.Nil(ackunstructured.SetCondition(u, &ackv1alpha1.Condition{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionTrue,
+	}))
+
+	conditions, err = ackunstructured.GetConditions(u)
+	require.Nil(err)
+	require.Len(conditions, 2)
+
+	synced, err := ackunstructured.GetCondition(u, ackv1alpha1.ConditionTypeResourceSynced)
+	require.Nil(err)
+	require.Equal(corev1.ConditionTrue, synced.Status)
+	require.Nil(synced.Message)
+
+	missing, err := ackunstructured.GetCondition(u, ackv1alpha1.ConditionTypeAdopted)
+	require.Nil(err)
+	require.Nil(missing)
+
+	// Conditions without a type are rejected
+	require.NotNil(ackunstructured.SetConditions(u, []*ackv1alpha1.Condition{{}}))
+	u.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{"oops"},
+	}
+	_, err = ackunstructured.GetConditions(u)
+	require.NotNil(err)
+}
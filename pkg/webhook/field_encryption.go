@@ -0,0 +1,94 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/fieldcrypt"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// FieldEncryptionDefaulter is a mutating webhook handler encrypting the
+// sensitive fields of the resources on every write, so that their plaintext
+// values are never stored in etcd.
+type FieldEncryptionDefaulter struct {
+	encrypter *fieldcrypt.Encrypter
+	fields    []string
+}
+
+var _ admission.CustomDefaulter = &FieldEncryptionDefaulter{}
+
+// NewFieldEncryptionDefaulter returns a FieldEncryptionDefaulter encrypting
+// the supplied fields, given as dot-separated paths, e.g.
+// "spec.masterUserPassword"
+func NewFieldEncryptionDefaulter(
+	encrypter *fieldcrypt.Encrypter,
+	fields []string,
+) *FieldEncryptionDefaulter {
+	return &FieldEncryptionDefaulter{encrypter: encrypter, fields: fields}
+}
+
+// Default implements admission.CustomDefaulter. It encrypts the plaintext
+// values of the sensitive fields of the supplied object, in place.
+func (d *FieldEncryptionDefaulter) Default(
+	ctx context.Context,
+	obj runtime.Object,
+) error {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	changed, err := d.encrypter.EncryptFields(ctx, u, d.fields)
+	if err != nil || !changed {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u, obj)
+}
+
+// NewFieldEncryptionWebhook returns the mutating webhook encrypting the
+// sensitive fields of the resources of the supplied resource manager factory
+// with the supplied encrypter, or nil if the encrypter is nil, or if the
+// factory does not implement AWSResourceSensitiveFieldDescriber or declares no
+// sensitive fields.
+//
+// The webhook is served at the controller-runtime path of the mutating
+// webhook of the kind, e.g. /mutate-rds-services-k8s-aws-v1alpha1-dbinstance.
+func NewFieldEncryptionWebhook(
+	rmf acktypes.AWSResourceManagerFactory,
+	encrypter *fieldcrypt.Encrypter,
+) *Webhook {
+	describer, ok := rmf.(acktypes.AWSResourceSensitiveFieldDescriber)
+	if !ok || encrypter == nil || len(describer.SensitiveFields()) == 0 {
+		return nil
+	}
+	rd := rmf.ResourceDescriptor()
+	gvk := rd.GroupVersionKind()
+	defaulter := NewFieldEncryptionDefaulter(encrypter, describer.SensitiveFields())
+	return New(
+		gvk.GroupVersion().String(),
+		gvk.Kind,
+		string(WebhookTypeMutating),
+		func(mgr ctrlrt.Manager) error {
+			return ctrlrt.NewWebhookManagedBy(mgr).
+				For(rd.EmptyRuntimeObject()).
+				WithDefaulter(defaulter).
+				Complete()
+		},
+	)
+}
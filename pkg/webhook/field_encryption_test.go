@@ -0,0 +1,94 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhook_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/fieldcrypt"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws-controllers-k8s/runtime/pkg/webhook"
+
+	mocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+// staticKeys is a KeyProvider returning the same data key, unencrypted
+type staticKeys struct{}
+
+func (staticKeys) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	key := make([]byte, 32)
+	return key, key, nil
+}
+
+func (staticKeys) Decrypt(ctx context.Context, encrypted []byte) ([]byte, error) {
+	return encrypted, nil
+}
+
+func TestFieldEncryptionDefaulter(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	encrypter := fieldcrypt.NewEncrypter(staticKeys{})
+	defaulter := webhook.NewFieldEncryptionDefaulter(encrypter, []string{"spec.aws.nameOrID"})
+	obj := &ackv1alpha1.AdoptedResource{
+		Spec: ackv1alpha1.AdoptedResourceSpec{
+			AWS: &ackv1alpha1.AWSIdentifiers{NameOrID: "hunter2"},
+		},
+	}
+	obj.SetName("db")
+	require.Nil(defaulter.Default(ctx, obj))
+	require.Equal("db", obj.GetName())
+	require.True(fieldcrypt.IsEncrypted(obj.Spec.AWS.NameOrID))
+
+	encrypted := obj.Spec.AWS.NameOrID
+	require.Nil(defaulter.Default(ctx, obj))
+	require.Equal(encrypted, obj.Spec.AWS.NameOrID)
+}
+
+func TestNewFieldEncryptionWebhook(t *testing.T) {
+	require := require.New(t)
+	encrypter := fieldcrypt.NewEncrypter(staticKeys{})
+
+	rmf := &mocks.AWSResourceManagerFactory{}
+	require.Nil(webhook.NewFieldEncryptionWebhook(rmf, encrypter))
+
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("GroupVersionKind").Return(
+		ackv1alpha1.GroupVersion.WithKind("Book"),
+	)
+	factory := &mocks.AWSResourceManagerFactory{}
+	factory.On("ResourceDescriptor").Return(rd)
+	fields := &mocks.AWSResourceSensitiveFieldDescriber{}
+	fields.On("SensitiveFields").Return([]string{"spec.password"})
+	describer := &sensitiveFactory{
+		AWSResourceManagerFactory:          factory,
+		AWSResourceSensitiveFieldDescriber: fields,
+	}
+	require.Nil(webhook.NewFieldEncryptionWebhook(describer, nil))
+
+	wh := webhook.NewFieldEncryptionWebhook(describer, encrypter)
+	require.NotNil(wh)
+	require.Equal("mutating/Book/"+ackv1alpha1.GroupVersion.String(), wh.UID())
+}
+
+// sensitiveFactory is a resource manager factory declaring the sensitive
+// fields of its resources
+type sensitiveFactory struct {
+	acktypes.AWSResourceManagerFactory
+	acktypes.AWSResourceSensitiveFieldDescriber
+}
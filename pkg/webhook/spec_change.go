@@ -0,0 +1,145 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+)
+
+// SpecChangeValidator is a validating webhook handler that never rejects
+// changes, but returns warnings, shown by kubectl, when an update changes
+// fields whose change replaces the AWS resource, causes downtime or loses
+// data.
+type SpecChangeValidator struct {
+	rd      acktypes.AWSResourceDescriptor
+	impacts map[string]acktypes.FieldChangeImpact
+}
+
+var _ admission.CustomValidator = &SpecChangeValidator{}
+
+// NewSpecChangeValidator returns a SpecChangeValidator warning about the
+// changes of the supplied fields, keyed by Delta field path
+func NewSpecChangeValidator(
+	rd acktypes.AWSResourceDescriptor,
+	impacts map[string]acktypes.FieldChangeImpact,
+) *SpecChangeValidator {
+	return &SpecChangeValidator{rd: rd, impacts: impacts}
+}
+
+// ValidateCreate implements admission.CustomValidator. Creations have no
+// impact on existing AWS resources.
+func (v *SpecChangeValidator) ValidateCreate(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.CustomValidator. It returns a warning
+// for each changed field with a declared impact.
+func (v *SpecChangeValidator) ValidateUpdate(
+	ctx context.Context,
+	oldObj runtime.Object,
+	newObj runtime.Object,
+) (admission.Warnings, error) {
+	if len(v.impacts) == 0 {
+		return nil, nil
+	}
+	oldRes, oldOK := oldObj.(client.Object)
+	newRes, newOK := newObj.(client.Object)
+	if !oldOK || !newOK {
+		return nil, nil
+	}
+	delta := v.rd.Delta(
+		v.rd.ResourceFromRuntimeObject(newRes),
+		v.rd.ResourceFromRuntimeObject(oldRes),
+	)
+	paths := make([]string, 0, len(v.impacts))
+	for path := range v.impacts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	warnings := admission.Warnings{}
+	for _, path := range paths {
+		if !delta.DifferentAt(path) {
+			continue
+		}
+		impact := v.impacts[path]
+		warning := fmt.Sprintf("%s: changing %s", impact.Impact, strings.TrimPrefix(path, "Spec."))
+		switch impact.Impact {
+		case acktypes.ChangeImpactReplacement:
+			warning += " replaces the AWS resource"
+		case acktypes.ChangeImpactDowntime:
+			warning += " makes the AWS resource unavailable during the update"
+		case acktypes.ChangeImpactDestructive:
+			warning += " loses data of the AWS resource"
+		}
+		if impact.Message != "" {
+			warning += ": " + impact.Message
+		}
+		warnings = append(warnings, warning)
+	}
+	if len(warnings) == 0 {
+		return nil, nil
+	}
+	return warnings, nil
+}
+
+// ValidateDelete implements admission.CustomValidator. Deletions are subject
+// to the deletion policy of the resource, not to warnings.
+func (v *SpecChangeValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// NewSpecChangeWebhook returns the validating webhook warning about the
+// impactful changes of the resources of the supplied resource manager
+// factory, or nil if the factory does not implement
+// AWSResourceChangeImpactDescriber.
+//
+// The webhook is served at the controller-runtime path of the validating
+// webhook of the kind, e.g. /validate-s3-services-k8s-aws-v1alpha1-bucket.
+func NewSpecChangeWebhook(rmf acktypes.AWSResourceManagerFactory) *Webhook {
+	describer, ok := rmf.(acktypes.AWSResourceChangeImpactDescriber)
+	if !ok {
+		return nil
+	}
+	rd := rmf.ResourceDescriptor()
+	gvk := rd.GroupVersionKind()
+	validator := NewSpecChangeValidator(rd, describer.FieldChangeImpacts())
+	return New(
+		gvk.GroupVersion().String(),
+		gvk.Kind,
+		string(WebhookTypeValidating),
+		func(mgr ctrlrt.Manager) error {
+			return ctrlrt.NewWebhookManagedBy(mgr).
+				For(rd.EmptyRuntimeObject()).
+				WithValidator(validator).
+				Complete()
+		},
+	)
+}
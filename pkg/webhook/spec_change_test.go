@@ -0,0 +1,100 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhook_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws-controllers-k8s/runtime/pkg/webhook"
+
+	mocks "github.com/aws-controllers-k8s/runtime/mocks/pkg/types"
+)
+
+func TestSpecChangeValidator(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	oldObj := &ackv1alpha1.AdoptedResource{}
+	newObj := &ackv1alpha1.AdoptedResource{}
+	oldRes := &mocks.AWSResource{}
+	newRes := &mocks.AWSResource{}
+	delta := ackcompare.NewDelta()
+	delta.Add("Spec.Engine", "mysql", "postgres")
+	delta.Add("Spec.Storage.Size", 20, 10)
+	delta.Add("Spec.Tags", nil, nil)
+
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("ResourceFromRuntimeObject", oldObj).Return(oldRes)
+	rd.On("ResourceFromRuntimeObject", newObj).Return(newRes)
+	rd.On("Delta", newRes, oldRes).Return(delta)
+
+	validator := webhook.NewSpecChangeValidator(rd, map[string]acktypes.FieldChangeImpact{
+		"Spec.Engine":        {Impact: acktypes.ChangeImpactReplacement},
+		"Spec.Storage":       {Impact: acktypes.ChangeImpactDestructive, Message: "storage cannot shrink"},
+		"Spec.InstanceClass": {Impact: acktypes.ChangeImpactDowntime},
+	})
+	warnings, err := validator.ValidateUpdate(ctx, oldObj, newObj)
+	require.Nil(err)
+	require.Equal([]string{
+		"Replacement: changing Engine replaces the AWS resource",
+		"Destructive: changing Storage loses data of the AWS resource: storage cannot shrink",
+	}, []string(warnings))
+
+	warnings, err = validator.ValidateCreate(ctx, newObj)
+	require.Nil(err)
+	require.Nil(warnings)
+	warnings, err = validator.ValidateDelete(ctx, oldObj)
+	require.Nil(err)
+	require.Nil(warnings)
+}
+
+func TestNewSpecChangeWebhook(t *testing.T) {
+	require := require.New(t)
+
+	rmf := &mocks.AWSResourceManagerFactory{}
+	require.Nil(webhook.NewSpecChangeWebhook(rmf))
+
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("GroupVersionKind").Return(
+		ackv1alpha1.GroupVersion.WithKind("Book"),
+	)
+	describer := &describingFactory{
+		AWSResourceManagerFactory:        &mocks.AWSResourceManagerFactory{},
+		AWSResourceChangeImpactDescriber: &mocks.AWSResourceChangeImpactDescriber{},
+	}
+	describer.AWSResourceManagerFactory.(*mocks.AWSResourceManagerFactory).On("ResourceDescriptor").Return(rd)
+	describer.AWSResourceChangeImpactDescriber.(*mocks.AWSResourceChangeImpactDescriber).On(
+		"FieldChangeImpacts",
+	).Return(map[string]acktypes.FieldChangeImpact{})
+
+	wh := webhook.NewSpecChangeWebhook(describer)
+	require.NotNil(wh)
+	require.Equal("validating/Book/"+ackv1alpha1.GroupVersion.String(), wh.UID())
+	rd.AssertCalled(t, "GroupVersionKind")
+	rd.AssertNotCalled(t, "Delta", mock.Anything, mock.Anything)
+}
+
+// describingFactory is a resource manager factory declaring the impacts of
+// the changes of its resources
+type describingFactory struct {
+	acktypes.AWSResourceManagerFactory
+	acktypes.AWSResourceChangeImpactDescriber
+}
@@ -24,7 +24,9 @@ type WebhookType string
 const (
 	WebhookTypeUnknown    WebhookType = "unknown"
 	WebhookTypeConversion WebhookType = "conversion"
-	//TODO(a-hilaly) add validating and defaulting types
+	WebhookTypeValidating WebhookType = "validating"
+	WebhookTypeMutating   WebhookType = "mutating"
+	//TODO(a-hilaly) add defaulting types
 )
 
 // Webhook contains information about a custom Webhook